@@ -0,0 +1,179 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/adminapi"
+	standardadminapi "github.com/attestantio/vouch/services/adminapi/standard"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/validatorsmanager"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-majordomo"
+)
+
+// redactedKeys are the fragments of configuration keys whose values are not
+// returned by the admin API.
+var redactedKeys = []string{
+	"passphrase",
+	"password",
+	"secret",
+	"token",
+	"key",
+	"credentials",
+}
+
+// viperConfiguration provides the current configuration from viper.
+type viperConfiguration struct{}
+
+// Configuration returns the current configuration, with sensitive values redacted.
+func (viperConfiguration) Configuration(_ context.Context) map[string]any {
+	return redactConfiguration(viper.AllSettings())
+}
+
+// redactConfiguration replaces the values of sensitive keys in the configuration.
+// Only values are redacted, so sections such as 'keymanagerapi' are still returned.
+func redactConfiguration(settings map[string]any) map[string]any {
+	res := make(map[string]any, len(settings))
+	for key, value := range settings {
+		if sensitiveKey(key) {
+			if _, isMap := value.(map[string]any); !isMap {
+				res[key] = "<redacted>"
+				continue
+			}
+		}
+		res[key] = redactValue(value)
+	}
+
+	return res
+}
+
+// redactValue redacts sensitive information within a configuration value,
+// descending into sections and lists.
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return redactConfiguration(v)
+	case []map[string]any:
+		res := make([]map[string]any, len(v))
+		for i := range v {
+			res[i] = redactConfiguration(v[i])
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i := range v {
+			res[i] = redactValue(v[i])
+		}
+		return res
+	case []string:
+		res := make([]string, len(v))
+		for i := range v {
+			res[i] = redactURL(v[i])
+		}
+		return res
+	case string:
+		return redactURL(v)
+	default:
+		return value
+	}
+}
+
+// redactURL removes any user information, such as basic authentication
+// credentials, from a URL.  Addresses without a scheme are only altered if
+// they contain a password, to avoid mangling values that are not URLs.
+func redactURL(value string) string {
+	if !strings.Contains(value, "@") {
+		return value
+	}
+
+	schemeless := !strings.Contains(value, "://")
+	input := value
+	if schemeless {
+		input = "//" + value
+	}
+	u, err := url.Parse(input)
+	if err != nil || u.User == nil || u.Host == "" {
+		return value
+	}
+	if _, hasPassword := u.User.Password(); schemeless && !hasPassword {
+		return value
+	}
+	u.User = nil
+
+	return strings.TrimPrefix(u.String(), "//")
+}
+
+// sensitiveKey returns true if the configuration key may hold a sensitive value.
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range redactedKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// startAdminAPI starts the admin API server if configured.
+func startAdminAPI(ctx context.Context,
+	majordomo majordomo.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+	accountManager accountmanager.Service,
+	validatorsManager validatorsmanager.Service,
+	dutyController adminapi.DutyController,
+	maintenanceController adminapi.MaintenanceController,
+) error {
+	if viper.GetString("adminapi.listen-address") == "" {
+		return nil
+	}
+
+	accountsRefresher, isRefresher := accountManager.(accountmanager.Refresher)
+	if !isRefresher {
+		return errors.New("account manager does not support refreshing accounts")
+	}
+	if viper.GetString("adminapi.bearer-token") == "" {
+		return errors.New("no bearer token specified")
+	}
+	bearerToken, err := majordomo.Fetch(ctx, viper.GetString("adminapi.bearer-token"))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain bearer token")
+	}
+
+	log.Trace().Msg("Starting admin API")
+	_, err = standardadminapi.New(ctx,
+		standardadminapi.WithLogLevel(util.LogLevel("adminapi")),
+		standardadminapi.WithListenAddress(viper.GetString("adminapi.listen-address")),
+		standardadminapi.WithBearerToken(strings.TrimSpace(string(bearerToken))),
+		standardadminapi.WithChainTime(chainTime),
+		standardadminapi.WithScheduler(scheduler),
+		standardadminapi.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
+		standardadminapi.WithValidatorsManager(validatorsManager),
+		standardadminapi.WithAccountsRefresher(accountsRefresher),
+		standardadminapi.WithDutyController(dutyController),
+		standardadminapi.WithMaintenanceController(maintenanceController),
+		standardadminapi.WithConfigurationProvider(viperConfiguration{}),
+	)
+
+	return err
+}
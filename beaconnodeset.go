@@ -0,0 +1,290 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	standardbeaconnodeset "github.com/attestantio/vouch/services/beaconnodeset/standard"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/submitter"
+	dynamicsubmitter "github.com/attestantio/vouch/services/submitter/dynamic"
+	dynamicaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/dynamic"
+	dynamicattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/dynamic"
+	dynamicbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/dynamic"
+	dynamicbeaconblockrootstrategy "github.com/attestantio/vouch/strategies/beaconblockroot/dynamic"
+	dynamicsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/dynamic"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// beaconNodeRebuild rebuilds a service in the given context, returning a
+// function that puts the rebuilt service in to use.
+type beaconNodeRebuild func(ctx context.Context) (func(), error)
+
+// beaconNodeReloader rebuilds the strategies and submitter that use beacon
+// nodes when the configured set of beacon nodes changes.  Each set of services
+// runs in its own context, which is cancelled once it has been replaced.
+// Beacon node clients are shared between sets, so are created in the
+// reloader's context.
+type beaconNodeReloader struct {
+	ctx        context.Context
+	mu         sync.Mutex
+	rebuilds   []beaconNodeRebuild
+	currentCtx context.Context
+	cancel     context.CancelFunc
+}
+
+// newBeaconNodeReloader creates a beacon node reloader if reloading is enabled.
+func newBeaconNodeReloader(ctx context.Context) (*beaconNodeReloader, error) {
+	if !viper.GetBool("beaconnodeset.enable") {
+		return nil, nil
+	}
+	if viper.ConfigFileUsed() == "" {
+		return nil, errors.New("reloading beacon nodes requires a configuration file")
+	}
+
+	currentCtx, cancel := context.WithCancel(context.WithValue(ctx, clientContextKey{}, ctx))
+
+	return &beaconNodeReloader{
+		ctx:        ctx,
+		currentCtx: currentCtx,
+		cancel:     cancel,
+	}, nil
+}
+
+// withBeaconNodeReload builds a service.  If beacon node reloading is enabled
+// the service is wrapped so that it can be rebuilt when the set of beacon nodes
+// changes.
+func withBeaconNodeReload[T any](ctx context.Context,
+	reloader *beaconNodeReloader,
+	build func(ctx context.Context) (T, error),
+	wrap func(ctx context.Context, service T) (T, func(service T), error),
+) (
+	T,
+	error,
+) {
+	if reloader == nil {
+		return build(ctx)
+	}
+
+	var res T
+	service, err := build(reloader.currentCtx)
+	if err != nil {
+		return res, err
+	}
+	res, set, err := wrap(ctx, service)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to create reloadable service")
+	}
+
+	reloader.mu.Lock()
+	reloader.rebuilds = append(reloader.rebuilds, func(ctx context.Context) (func(), error) {
+		service, err := build(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() { set(service) }, nil
+	})
+	reloader.mu.Unlock()
+
+	return res, nil
+}
+
+// OnBeaconNodesChanged rebuilds the services with the new beacon nodes.  If
+// any service fails to rebuild the existing services remain in use.
+func (r *beaconNodeReloader) OnBeaconNodesChanged(_ context.Context, addresses []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := util.BeaconNodeAddresses("")
+	viper.Set("beacon-node-addresses", addresses)
+
+	ctx, cancel := context.WithCancel(context.WithValue(r.ctx, clientContextKey{}, r.ctx))
+	commits := make([]func(), 0, len(r.rebuilds))
+	for _, rebuild := range r.rebuilds {
+		commit, err := rebuild(ctx)
+		if err != nil {
+			cancel()
+			viper.Set("beacon-node-addresses", previous)
+			return err
+		}
+		commits = append(commits, commit)
+	}
+
+	for _, commit := range commits {
+		commit()
+	}
+	r.cancel()
+	r.currentCtx = ctx
+	r.cancel = cancel
+
+	return nil
+}
+
+// configFileBeaconNodeAddresses provides the beacon node addresses from the
+// configuration file.
+type configFileBeaconNodeAddresses struct {
+	path string
+}
+
+// BeaconNodeAddresses provides the beacon node addresses from the configuration file.
+func (c *configFileBeaconNodeAddresses) BeaconNodeAddresses(_ context.Context) ([]string, error) {
+	v := viper.New()
+	v.SetConfigFile(c.path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrap(err, "failed to read configuration file")
+	}
+
+	return v.GetStringSlice("beacon-node-addresses"), nil
+}
+
+// startBeaconNodeSet starts watching the configuration file for changes to the
+// beacon nodes, if reloading is enabled.
+func startBeaconNodeSet(ctx context.Context,
+	monitor metrics.Service,
+	scheduler scheduler.Service,
+	reloader *beaconNodeReloader,
+) error {
+	if reloader == nil {
+		return nil
+	}
+
+	_, err := standardbeaconnodeset.New(ctx,
+		standardbeaconnodeset.WithLogLevel(util.LogLevel("beaconnodeset")),
+		standardbeaconnodeset.WithMonitor(monitor),
+		standardbeaconnodeset.WithScheduler(scheduler),
+		standardbeaconnodeset.WithAddresses(util.BeaconNodeAddresses("")),
+		standardbeaconnodeset.WithAddressesProvider(&configFileBeaconNodeAddresses{path: viper.ConfigFileUsed()}),
+		standardbeaconnodeset.WithHandler(reloader),
+		standardbeaconnodeset.WithCheckInterval(viper.GetDuration("beaconnodeset.check-interval")),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to start beacon node set")
+	}
+	log.Info().Str("config_file", viper.ConfigFileUsed()).Msg("Watching configuration file for beacon node changes")
+
+	return nil
+}
+
+func dynamicSubmitter(ctx context.Context, service submitter.Service) (submitter.Service, func(submitter.Service), error) {
+	dynamic, err := dynamicsubmitter.New(ctx,
+		dynamicsubmitter.WithLogLevel(util.LogLevel("submitter.dynamic")),
+		dynamicsubmitter.WithSubmitter(service),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, func(service submitter.Service) {
+		if err := dynamic.SetSubmitter(service); err != nil {
+			log.Error().Err(err).Msg("Failed to replace submitter")
+		}
+	}, nil
+}
+
+func dynamicAttestationDataProvider(ctx context.Context,
+	provider eth2client.AttestationDataProvider,
+) (
+	eth2client.AttestationDataProvider,
+	func(eth2client.AttestationDataProvider),
+	error,
+) {
+	dynamic, err := dynamicattestationdatastrategy.New(ctx,
+		dynamicattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.dynamic")),
+		dynamicattestationdatastrategy.WithAttestationDataProvider(provider),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, dynamic.SetAttestationDataProvider, nil
+}
+
+func dynamicAggregateAttestationProvider(ctx context.Context,
+	provider eth2client.AggregateAttestationProvider,
+) (
+	eth2client.AggregateAttestationProvider,
+	func(eth2client.AggregateAttestationProvider),
+	error,
+) {
+	dynamic, err := dynamicaggregateattestationstrategy.New(ctx,
+		dynamicaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.dynamic")),
+		dynamicaggregateattestationstrategy.WithAggregateAttestationProvider(provider),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, dynamic.SetAggregateAttestationProvider, nil
+}
+
+func dynamicProposalProvider(ctx context.Context,
+	provider eth2client.ProposalProvider,
+) (
+	eth2client.ProposalProvider,
+	func(eth2client.ProposalProvider),
+	error,
+) {
+	dynamic, err := dynamicbeaconblockproposalstrategy.New(ctx,
+		dynamicbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.dynamic")),
+		dynamicbeaconblockproposalstrategy.WithProposalProvider(provider),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, dynamic.SetProposalProvider, nil
+}
+
+func dynamicSyncCommitteeContributionProvider(ctx context.Context,
+	provider eth2client.SyncCommitteeContributionProvider,
+) (
+	eth2client.SyncCommitteeContributionProvider,
+	func(eth2client.SyncCommitteeContributionProvider),
+	error,
+) {
+	dynamic, err := dynamicsynccommitteecontributionstrategy.New(ctx,
+		dynamicsynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.dynamic")),
+		dynamicsynccommitteecontributionstrategy.WithSyncCommitteeContributionProvider(provider),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, dynamic.SetSyncCommitteeContributionProvider, nil
+}
+
+func dynamicBeaconBlockRootProvider(ctx context.Context,
+	provider eth2client.BeaconBlockRootProvider,
+) (
+	eth2client.BeaconBlockRootProvider,
+	func(eth2client.BeaconBlockRootProvider),
+	error,
+) {
+	dynamic, err := dynamicbeaconblockrootstrategy.New(ctx,
+		dynamicbeaconblockrootstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockroot.dynamic")),
+		dynamicbeaconblockrootstrategy.WithBeaconBlockRootProvider(provider),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, dynamic.SetBeaconBlockRootProvider, nil
+}
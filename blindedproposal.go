@@ -0,0 +1,141 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nomev
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/cache"
+	"github.com/attestantio/vouch/services/chainspec"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	bestblindedbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/blindedbeaconblockproposal/best"
+	dynamicblindedbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/blindedbeaconblockproposal/dynamic"
+	firstblindedbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/blindedbeaconblockproposal/first"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// startBlindedProposalProvider starts the blinded proposal provider, reloading
+// it when the beacon nodes are reloaded.
+func startBlindedProposalProvider(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
+	chainTime chaintime.Service,
+	cacheSvc cache.Service,
+	nodeMonitor nodemonitor.Service,
+	beaconNodeReloader *beaconNodeReloader,
+) (
+	eth2client.BlindedProposalProvider,
+	error,
+) {
+	return withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (eth2client.BlindedProposalProvider, error) {
+		return selectBlindedProposalProvider(ctx, monitor, eth2Client, chainSpec, chainTime, cacheSvc, nodeMonitor)
+	}, dynamicBlindedProposalProvider)
+}
+
+// selectBlindedProposalProvider selects the appropriate blinded proposal provider given user input.
+func selectBlindedProposalProvider(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
+	chainTime chaintime.Service,
+	cacheSvc cache.Service,
+	nodeMonitor nodemonitor.Service,
+) (eth2client.BlindedProposalProvider, error) {
+	var blindedProposalProvider eth2client.BlindedProposalProvider
+	var err error
+	switch viper.GetString("strategies.blindedbeaconblockproposal.style") {
+	case "best":
+		log.Info().Msg("Starting best blinded beacon block proposal strategy")
+		recordStrategy("strategies.blindedbeaconblockproposal", "best")
+		blindedProposalProviders := make(map[string]eth2client.BlindedProposalProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.blindedbeaconblockproposal.best") {
+			client, err := fetchClient(ctx, monitor, address)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for blinded beacon block proposal strategy", address))
+			}
+			blindedProposalProviders[address] = client.(eth2client.BlindedProposalProvider)
+		}
+		blindedProposalProvider, err = bestblindedbeaconblockproposalstrategy.New(ctx,
+			bestblindedbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			bestblindedbeaconblockproposalstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.blindedbeaconblockproposal.best")),
+			bestblindedbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.blindedbeaconblockproposal.best")),
+			bestblindedbeaconblockproposalstrategy.WithNodeMonitor(nodeMonitor),
+			bestblindedbeaconblockproposalstrategy.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
+			bestblindedbeaconblockproposalstrategy.WithChainTimeService(chainTime),
+			bestblindedbeaconblockproposalstrategy.WithSpecProvider(chainSpec),
+			bestblindedbeaconblockproposalstrategy.WithBlindedProposalProviders(blindedProposalProviders),
+			bestblindedbeaconblockproposalstrategy.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
+			bestblindedbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.blindedbeaconblockproposal.best")),
+			bestblindedbeaconblockproposalstrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start best blinded beacon block proposal strategy")
+		}
+	case "first":
+		log.Info().Msg("Starting first blinded beacon block proposal strategy")
+		recordStrategy("strategies.blindedbeaconblockproposal", "first")
+		blindedProposalProviders := make(map[string]eth2client.BlindedProposalProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.blindedbeaconblockproposal.first") {
+			client, err := fetchClient(ctx, monitor, address)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for blinded beacon block proposal strategy", address))
+			}
+			blindedProposalProviders[address] = client.(eth2client.BlindedProposalProvider)
+		}
+		blindedProposalProvider, err = firstblindedbeaconblockproposalstrategy.New(ctx,
+			firstblindedbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			firstblindedbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.blindedbeaconblockproposal.first")),
+			firstblindedbeaconblockproposalstrategy.WithNodeMonitor(nodeMonitor),
+			firstblindedbeaconblockproposalstrategy.WithChainTimeService(chainTime),
+			firstblindedbeaconblockproposalstrategy.WithBlindedProposalProviders(blindedProposalProviders),
+			firstblindedbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.blindedbeaconblockproposal.first")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start first blinded beacon block proposal strategy")
+		}
+	default:
+		log.Info().Msg("Starting simple blinded beacon block proposal strategy")
+		recordStrategy("strategies.blindedbeaconblockproposal", "simple")
+		blindedProposalProvider = eth2Client.(eth2client.BlindedProposalProvider)
+	}
+
+	return blindedProposalProvider, nil
+}
+
+func dynamicBlindedProposalProvider(ctx context.Context,
+	provider eth2client.BlindedProposalProvider,
+) (
+	eth2client.BlindedProposalProvider,
+	func(eth2client.BlindedProposalProvider),
+	error,
+) {
+	dynamic, err := dynamicblindedbeaconblockproposalstrategy.New(ctx,
+		dynamicblindedbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.blindedbeaconblockproposal.dynamic")),
+		dynamicblindedbeaconblockproposalstrategy.WithBlindedProposalProvider(provider),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dynamic, dynamic.SetBlindedProposalProvider, nil
+}
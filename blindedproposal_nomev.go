@@ -0,0 +1,46 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build nomev
+
+package main
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/cache"
+	"github.com/attestantio/vouch/services/chainspec"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
+)
+
+// startBlindedProposalProvider does not start a blinded proposal provider, as
+// this build of Vouch only proposes locally built blocks.
+func startBlindedProposalProvider(_ context.Context,
+	_ metrics.Service,
+	_ eth2client.Service,
+	_ chainspec.Service,
+	_ chaintime.Service,
+	_ cache.Service,
+	_ nodemonitor.Service,
+	_ *beaconNodeReloader,
+) (
+	eth2client.BlindedProposalProvider,
+	error,
+) {
+	log.Trace().Msg("Built without block relay support; not selecting blinded beacon block proposal provider")
+
+	return nil, nil
+}
@@ -0,0 +1,198 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nomev
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	standardblockrelay "github.com/attestantio/vouch/services/blockrelay/standard"
+	"github.com/attestantio/vouch/services/chainspec"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/strategies/builderbid"
+	bestbuilderbidstrategy "github.com/attestantio/vouch/strategies/builderbid/best"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-majordomo"
+)
+
+// blockRelaySupported is true if this build of Vouch supports block relays.
+const blockRelaySupported = true
+
+// startBlockRelay starts the block relay, or a local block relay if relays are disabled.
+func startBlockRelay(ctx context.Context,
+	majordomo majordomo.Service,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
+	scheduler scheduler.Service,
+	chainTime chaintime.Service,
+	accountManager accountmanager.Service,
+	signerSvc signer.Service,
+) (
+	blockrelay.Service,
+	error,
+) {
+	if viper.GetBool("blockrelay.disable") {
+		return startLocalBlockRelay(ctx, majordomo, scheduler, chainTime, accountManager)
+	}
+
+	builderBidProvider, err := selectBuilderBidProvider(ctx, monitor, eth2Client, chainSpec, chainTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// We also need to submit validator registrations to all nodes that are acting as blinded beacon block proposers, as
+	// some of them use the registration as part of the condition to decide if the blinded block should be called or not.
+	nodeAddresses := util.BeaconNodeAddressesForProposing()
+	secondaryValidatorRegistrationsSubmitters := make([]eth2client.ValidatorRegistrationsSubmitter, 0, len(nodeAddresses))
+	for _, address := range nodeAddresses {
+		client, err := fetchSubmissionClient(ctx, monitor, address)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for secondary validator registration", address))
+		}
+		secondaryValidatorRegistrationsSubmitters = append(secondaryValidatorRegistrationsSubmitters, client.(eth2client.ValidatorRegistrationsSubmitter))
+	}
+
+	fallbackFeeRecipient, err := fallbackFeeRecipientFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	fallbackMinValue, err := fallbackMinValueFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	excludedBuilders, err := pubKeysFromConfig("blockrelay.excluded-builders")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid excluded builders")
+	}
+	excludedProposers, err := pubKeysFromConfig("beaconblockproposer.excluded-validators")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid excluded validators")
+	}
+
+	var blockRelay blockrelay.Service
+	blockRelay, err = standardblockrelay.New(ctx,
+		standardblockrelay.WithLogLevel(util.LogLevel("blockrelay")),
+		standardblockrelay.WithMonitor(monitor),
+		standardblockrelay.WithMajordomo(majordomo),
+		standardblockrelay.WithScheduler(scheduler),
+		standardblockrelay.WithChainTime(chainTime),
+		standardblockrelay.WithConfigURL(viper.GetString("blockrelay.config.url")),
+		standardblockrelay.WithFallbackFeeRecipient(fallbackFeeRecipient),
+		standardblockrelay.WithFallbackGasLimit(viper.GetUint64("blockrelay.fallback-gas-limit")),
+		standardblockrelay.WithFallbackMinValue(fallbackMinValue),
+		standardblockrelay.WithClientCertURL(viper.GetString("blockrelay.config.client-cert")),
+		standardblockrelay.WithClientKeyURL(viper.GetString("blockrelay.config.client-key")),
+		standardblockrelay.WithCACertURL(viper.GetString("blockrelay.config.ca-cert")),
+		standardblockrelay.WithVerificationKeyURL(viper.GetString("blockrelay.config.verification-key")),
+		standardblockrelay.WithSignatureURL(viper.GetString("blockrelay.config.signature")),
+		standardblockrelay.WithAccountsProvider(accountManager.(accountmanager.AccountsProvider)),
+		standardblockrelay.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
+		standardblockrelay.WithListenAddress(viper.GetString("blockrelay.listen-address")),
+		standardblockrelay.WithValidatorRegistrationSigner(signerSvc.(signer.ValidatorRegistrationSigner)),
+		standardblockrelay.WithSecondaryValidatorRegistrationsSubmitters(secondaryValidatorRegistrationsSubmitters),
+		standardblockrelay.WithLogResults(viper.GetBool("blockrelay.log-results")),
+		standardblockrelay.WithReleaseVersion(ReleaseVersion),
+		standardblockrelay.WithBuilderBidProvider(builderBidProvider),
+		standardblockrelay.WithExcludedBuilders(excludedBuilders),
+		standardblockrelay.WithExcludedProposers(excludedProposers),
+		standardblockrelay.WithAuctionSampleRate(viper.GetFloat64("blockrelay.auction-sample-rate")),
+		standardblockrelay.WithProposerDutiesProvider(eth2Client.(eth2client.ProposerDutiesProvider)),
+		standardblockrelay.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
+		standardblockrelay.WithFeeRecipientsFile(feeRecipientsFileFromConfig()),
+		standardblockrelay.WithFeeRecipientsReloadInterval(viper.GetDuration("blockrelay.fee-recipients.reload-interval")),
+		standardblockrelay.WithRegistrationRetryInterval(viper.GetDuration("blockrelay.registration-retry-interval")),
+		standardblockrelay.WithRegistrationBatchSize(viper.GetInt("blockrelay.registration-batch-size")),
+		standardblockrelay.WithRegistrationBatchInterval(viper.GetDuration("blockrelay.registration-batch-interval")),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start block relay")
+	}
+
+	return blockRelay, nil
+}
+
+// selectBuilderBidProvider selects the provider for builder bids.
+// Builder bids are blinded execution payload headers provided by relays.
+func selectBuilderBidProvider(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
+	chainTime chaintime.Service,
+) (
+	builderbid.Provider,
+	error,
+) {
+	log.Trace().Msg("Selecting builder bid strategy")
+
+	var provider builderbid.Provider
+	var err error
+
+	switch viper.GetString("strategies.builderbid.style") {
+	case "best", "":
+		log.Info().Msg("Starting best builder bid strategy")
+		recordStrategy("strategies.builderbid", "best")
+		provider, err = bestbuilderbidstrategy.New(ctx,
+			bestbuilderbidstrategy.WithLogLevel(util.LogLevel("strategies.builderbid.best")),
+			bestbuilderbidstrategy.WithMonitor(monitor),
+			bestbuilderbidstrategy.WithSpecProvider(chainSpec),
+			bestbuilderbidstrategy.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
+			bestbuilderbidstrategy.WithChainTime(chainTime),
+			bestbuilderbidstrategy.WithTimeout(util.Timeout("strategies.builderbid.best")),
+			bestbuilderbidstrategy.WithSoftTimeout(viper.GetDuration("strategies.builderbid.best.soft-timeout")),
+			bestbuilderbidstrategy.WithReleaseVersion(ReleaseVersion),
+			bestbuilderbidstrategy.WithLocationPreferences(viper.GetStringSlice("blockrelay.location-preferences")),
+			bestbuilderbidstrategy.WithCensorshipResistance(viper.GetBool("blockrelay.censorship-resistance.enable")),
+			bestbuilderbidstrategy.WithCensoringMargin(viper.GetUint64("blockrelay.censorship-resistance.margin")),
+		)
+	default:
+		err = fmt.Errorf("unknown builder bid strategy %s", viper.GetString("strategies.builderbid.style"))
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate builder bid strategy")
+	}
+
+	return provider, nil
+}
+
+// fallbackMinValueFromConfig obtains the fallback minimum value of relay bids, in wei,
+// from the configuration.  The configuration value is in Ether.
+func fallbackMinValueFromConfig() (decimal.Decimal, error) {
+	if viper.GetString("blockrelay.min-value") == "" {
+		return decimal.Zero, nil
+	}
+	minValue, err := decimal.NewFromString(viper.GetString("blockrelay.min-value"))
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "blockrelay: invalid minimum value")
+	}
+	if minValue.Sign() == -1 {
+		return decimal.Zero, errors.New("blockrelay: minimum value cannot be negative")
+	}
+
+	return minValue.Mul(decimal.New(1, 18)), nil
+}
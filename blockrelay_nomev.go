@@ -0,0 +1,52 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build nomev
+
+package main
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/attestantio/vouch/services/chainspec"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/wealdtech/go-majordomo"
+)
+
+// blockRelaySupported is true if this build of Vouch supports block relays.
+const blockRelaySupported = false
+
+// startBlockRelay starts a local block relay, as this build of Vouch does not support relays.
+func startBlockRelay(ctx context.Context,
+	majordomo majordomo.Service,
+	_ metrics.Service,
+	_ eth2client.Service,
+	_ chainspec.Service,
+	scheduler scheduler.Service,
+	chainTime chaintime.Service,
+	accountManager accountmanager.Service,
+	_ signer.Service,
+) (
+	blockrelay.Service,
+	error,
+) {
+	log.Trace().Msg("Built without block relay support")
+
+	return startLocalBlockRelay(ctx, majordomo, scheduler, chainTime, accountManager)
+}
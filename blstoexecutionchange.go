@@ -0,0 +1,180 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	standardblstoexecutionchange "github.com/attestantio/vouch/services/blstoexecutionchange/standard"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	majordomo "github.com/wealdtech/go-majordomo"
+)
+
+// blsToExecutionChangeCommand generates, signs and broadcasts BLS to execution changes.
+func blsToExecutionChangeCommand(ctx context.Context, majordomo majordomo.Service) bool {
+	if err := runBLSToExecutionChange(ctx, majordomo); err != nil {
+		fmt.Fprintf(os.Stderr, "BLS to execution change failed: %v\n", err)
+	}
+
+	return true
+}
+
+// runBLSToExecutionChange runs the BLS to execution change command.  The
+// withdrawal credentials of the validators are always checked against the
+// withdrawal keys; unless this is a dry run the changes are then signed and
+// broadcast to all configured beacon nodes.
+func runBLSToExecutionChange(ctx context.Context, majordomo majordomo.Service) error {
+	if err := e2types.InitBLS(); err != nil {
+		return errors.Wrap(err, "failed to initialise BLS library")
+	}
+
+	address, err := blsToExecutionChangeAddress()
+	if err != nil {
+		return err
+	}
+	withdrawalAccounts, err := blsToExecutionChangeWithdrawalAccounts(ctx, majordomo)
+	if err != nil {
+		return err
+	}
+	pubKeys, err := commandValidators(ctx, majordomo, "bls-to-execution-change.validators")
+	if err != nil {
+		return err
+	}
+
+	// Force disable metrics.
+	viper.Set("metrics.prometheus.listen-address", "")
+	viper.Set("metrics.prometheus.push.url", "")
+	consensusClient, chainSpec, _, monitor, err := startBasicServices(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to start basic services")
+	}
+
+	dryRun := viper.GetBool("bls-to-execution-change.dry-run")
+	params := []standardblstoexecutionchange.Parameter{
+		standardblstoexecutionchange.WithLogLevel(util.LogLevel("blstoexecutionchange")),
+		standardblstoexecutionchange.WithValidatorsProvider(consensusClient.(eth2client.ValidatorsProvider)),
+	}
+	if !dryRun {
+		signerSvc, err := startSigner(ctx, monitor, consensusClient, chainSpec, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to start signer")
+		}
+		// Changes are broadcast to all beacon nodes regardless of the configured submitter strategy.
+		submitterSvc, err := startMultinodeSubmitter(ctx, monitor, nil, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to start submitter")
+		}
+		params = append(params,
+			standardblstoexecutionchange.WithBLSToExecutionChangeSigner(signerSvc.(signer.BLSToExecutionChangeSigner)),
+			standardblstoexecutionchange.WithBLSToExecutionChangesSubmitter(submitterSvc.(submitter.BLSToExecutionChangesSubmitter)),
+		)
+	}
+	blsToExecutionChange, err := standardblstoexecutionchange.New(ctx, params...)
+	if err != nil {
+		return errors.Wrap(err, "failed to start BLS to execution change service")
+	}
+
+	changes, err := blsToExecutionChange.PrepareChanges(ctx, pubKeys, withdrawalAccounts, address)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		fmt.Printf("Validator %d: withdrawal credentials match withdrawal key %#x; change to %s\n", change.ValidatorIndex, change.FromBLSPubkey, change.ToExecutionAddress.String())
+	}
+	if dryRun {
+		fmt.Printf("Dry run: %d changes validated but not signed or broadcast\n", len(changes))
+		return nil
+	}
+
+	signedChanges, err := blsToExecutionChange.SignChanges(ctx, changes, withdrawalAccounts)
+	if err != nil {
+		return err
+	}
+	if err := blsToExecutionChange.SubmitChanges(ctx, signedChanges); err != nil {
+		return err
+	}
+	fmt.Printf("Broadcast %d changes\n", len(signedChanges))
+
+	return nil
+}
+
+// blsToExecutionChangeAddress obtains the execution address to which withdrawal credentials change.
+func blsToExecutionChangeAddress() (bellatrix.ExecutionAddress, error) {
+	var address bellatrix.ExecutionAddress
+	if viper.GetString("bls-to-execution-change.execution-address") == "" {
+		return address, errors.New("no execution address specified")
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("bls-to-execution-change.execution-address"), "0x"))
+	if err != nil {
+		return address, errors.Wrap(err, "invalid execution address")
+	}
+	if len(data) != len(address) {
+		return address, errors.New("incorrect length for execution address")
+	}
+	copy(address[:], data)
+	if address.IsZero() {
+		return address, errors.New("execution address cannot be zero")
+	}
+
+	return address, nil
+}
+
+// blsToExecutionChangeWithdrawalAccounts loads the withdrawal keys from the configured keystores.
+func blsToExecutionChangeWithdrawalAccounts(ctx context.Context, majordomo majordomo.Service) ([]e2wtypes.Account, error) {
+	dir := viper.GetString("bls-to-execution-change.withdrawal-keystores")
+	if dir == "" {
+		return nil, errors.New("no withdrawal keystores specified")
+	}
+	paths, err := filepath.Glob(filepath.Join(resolvePath(dir), "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list withdrawal keystores")
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no withdrawal keystores found in %s", resolvePath(dir))
+	}
+	sort.Strings(paths)
+	keystores := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+		}
+		keystores = append(keystores, data)
+	}
+
+	passphrase := ""
+	if viper.GetString("bls-to-execution-change.withdrawal-passphrase") != "" {
+		data, err := majordomo.Fetch(ctx, viper.GetString("bls-to-execution-change.withdrawal-passphrase"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch withdrawal passphrase")
+		}
+		passphrase = strings.TrimSpace(string(data))
+	}
+
+	return standardblstoexecutionchange.WithdrawalAccounts(ctx, keystores, passphrase)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/vouch/util"
+	"github.com/spf13/viper"
+)
+
+// supportedForks are the forks that this build of Vouch understands.
+var supportedForks = []spec.DataVersion{
+	spec.DataVersionPhase0,
+	spec.DataVersionAltair,
+	spec.DataVersionBellatrix,
+	spec.DataVersionCapella,
+	spec.DataVersionDeneb,
+}
+
+// startedStrategies are the styles of the strategies that have been started,
+// keyed by their configuration key.  Only strategies that this build of Vouch
+// contains and has started are present.
+var (
+	startedStrategies   = make(map[string]string)
+	startedStrategiesMu sync.Mutex
+)
+
+// buildInfo contains information about this build of Vouch.
+type buildInfo struct {
+	Version    string            `json:"version"`
+	Commit     string            `json:"commit"`
+	GoVersion  string            `json:"go_version"`
+	Forks      []string          `json:"forks"`
+	Strategies map[string]string `json:"strategies"`
+	BlockRelay bool              `json:"block_relay"`
+}
+
+var versionEndpointOnce sync.Once
+
+// currentBuildInfo returns the build information for this instance.
+func currentBuildInfo() *buildInfo {
+	forks := make([]string, len(supportedForks))
+	for i := range supportedForks {
+		forks[i] = supportedForks[i].String()
+	}
+
+	return &buildInfo{
+		Version:    ReleaseVersion,
+		Commit:     util.CommitHash(),
+		GoVersion:  runtime.Version(),
+		Forks:      forks,
+		Strategies: enabledStrategies(),
+		BlockRelay: blockRelaySupported && !viper.GetBool("blockrelay.disable"),
+	}
+}
+
+// recordStrategy records the style of a strategy when it is started.
+func recordStrategy(strategy string, style string) {
+	startedStrategiesMu.Lock()
+	startedStrategies[strategy] = style
+	startedStrategiesMu.Unlock()
+
+	setBuildStrategy(strategy, style)
+}
+
+// enabledStrategies returns the style in use for each strategy that has been started.
+func enabledStrategies() map[string]string {
+	startedStrategiesMu.Lock()
+	defer startedStrategiesMu.Unlock()
+
+	res := make(map[string]string, len(startedStrategies))
+	for strategy, style := range startedStrategies {
+		res[strategy] = style
+	}
+
+	return res
+}
+
+// initVersionEndpoint adds the /version endpoint to the default HTTP server.
+func initVersionEndpoint() {
+	versionEndpointOnce.Do(func() {
+		http.HandleFunc("/version", func(w http.ResponseWriter, _ *http.Request) {
+			data, err := json.Marshal(currentBuildInfo())
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to marshal build information")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := w.Write(data); err != nil {
+				log.Debug().Err(err).Msg("Failed to write build information")
+			}
+		})
+	})
+}
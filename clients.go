@@ -1,4 +1,4 @@
-// Copyright © 2020 - 2023 Attestant Limited.
+// Copyright © 2020 - 2024 Attestant Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -23,8 +23,10 @@ import (
 	httpclient "github.com/attestantio/go-eth2-client/http"
 	multiclient "github.com/attestantio/go-eth2-client/multi"
 	"github.com/attestantio/vouch/services/metrics"
+	sszsubmitter "github.com/attestantio/vouch/services/submitter/ssz"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -32,11 +34,27 @@ var (
 	knownClientsMu sync.Mutex
 )
 
+// clientContextKey is the context key for the context in which clients are
+// created, where it differs from the context in which they are requested.
+type clientContextKey struct{}
+
+// clientContext returns the context in which clients should be created.
+// Clients are shared, so must outlive contexts that are cancelled when the
+// services using them are rebuilt.
+func clientContext(ctx context.Context) context.Context {
+	if clientCtx, isCtx := ctx.Value(clientContextKey{}).(context.Context); isCtx {
+		return clientCtx
+	}
+
+	return ctx
+}
+
 // fetchClient fetches a client service, instantiating it if required.
 func fetchClient(ctx context.Context, monitor metrics.Service, address string) (eth2client.Service, error) {
 	if address == "" {
 		return nil, errors.New("no address supplied for client")
 	}
+	ctx = clientContext(ctx)
 
 	knownClientsMu.Lock()
 	client, exists := knownClients[address]
@@ -64,11 +82,53 @@ func fetchClient(ctx context.Context, monitor metrics.Service, address string) (
 	return client, nil
 }
 
+// fetchSubmissionClient fetches a client service for submitting items,
+// instantiating it if required.  If SSZ submission is enabled the client
+// submits in SSZ where the beacon node accepts it, otherwise this is the
+// same as fetchClient.
+func fetchSubmissionClient(ctx context.Context, monitor metrics.Service, address string) (eth2client.Service, error) {
+	client, err := fetchClient(ctx, monitor, address)
+	if err != nil {
+		return nil, err
+	}
+	if !viper.GetBool("submitter.ssz") {
+		return client, nil
+	}
+	ctx = clientContext(ctx)
+
+	sszID := fmt.Sprintf("ssz:%s", address)
+
+	knownClientsMu.Lock()
+	sszClient, exists := knownClients[sszID]
+	knownClientsMu.Unlock()
+
+	if !exists {
+		sszClient, err = sszsubmitter.New(ctx,
+			sszsubmitter.WithLogLevel(util.LogLevel("submitter.ssz")),
+			sszsubmitter.WithClient(client),
+			sszsubmitter.WithTimeout(util.Timeout(fmt.Sprintf("eth2client.%s", address))),
+			sszsubmitter.WithExtraHeaders(map[string]string{
+				"User-Agent": fmt.Sprintf("Vouch/%s", ReleaseVersion),
+			}),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initiate SSZ submission client")
+		}
+
+		knownClientsMu.Lock()
+		knownClients[sszID] = sszClient
+		knownClientsMu.Unlock()
+	}
+
+	return sszClient, nil
+}
+
 // fetchMulticlient fetches a multiclient service, instantiating it if required.
 func fetchMultiClient(ctx context.Context, monitor metrics.Service, addresses []string) (eth2client.Service, error) {
 	if len(addresses) == 0 {
 		return nil, errors.New("no addresses supplied for multiclient")
 	}
+	ctx = clientContext(ctx)
 
 	multiID := fmt.Sprintf("multi:%s", strings.Join(addresses, ","))
 
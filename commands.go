@@ -16,6 +16,7 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -27,74 +28,120 @@ import (
 	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/blockrelay"
 	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	majordomo "github.com/wealdtech/go-majordomo"
 )
 
 // proposerConfigCheck checks a proposer configuration.
 func proposerConfigCheck(ctx context.Context, majordomo majordomo.Service) bool {
-	if err := e2types.InitBLS(); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to initialise BLS library: %v\n", err)
+	blockRelaySvc, account, pubkey, err := commandBlockRelay(ctx, majordomo, viper.GetString("proposer-config-check"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 		return true
 	}
 
-	// Force disable metrics.
-	viper.Set("metrics.prometheus.listen-address", "")
-	consensusClient, chainTime, monitor, err := startBasicServices(ctx)
+	proposerConfig, err := blockRelaySvc.(blockrelay.ExecutionConfigProvider).ProposerConfig(ctx, account, pubkey)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start basic services: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Failed to obtain proposer config: %v\n", err)
 		return true
 	}
 
-	validatorsManager, err := startValidatorsManager(ctx, monitor, consensusClient)
+	data, err := proposerConfig.MarshalJSON()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start validators manager: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Invalid proposer config: %v\n", err)
 		return true
 	}
-	accountManager, err := startAccountManager(ctx, monitor, consensusClient, validatorsManager, majordomo, chainTime)
+
+	fmt.Printf("%s\n", string(data))
+	return true
+}
+
+// validatorRegistrationPreview shows the validator registrations that would be
+// submitted for a validator, without signing or submitting them.
+func validatorRegistrationPreview(ctx context.Context, majordomo majordomo.Service) bool {
+	blockRelaySvc, account, pubkey, err := commandBlockRelay(ctx, majordomo, viper.GetString("validator-registration-preview"))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start account manager: %v\n", err)
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 		return true
 	}
-	scheduler := mockscheduler.New()
-	signer, err := startSigner(ctx, monitor, consensusClient)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start signer: %v\n", err)
+
+	previewer, isPreviewer := blockRelaySvc.(blockrelay.ValidatorRegistrationsPreviewer)
+	if !isPreviewer {
+		fmt.Fprintf(os.Stderr, "Validator registrations are not submitted without relays\n")
 		return true
 	}
-	blockRelaySvc, err := startBlockRelay(ctx, majordomo, monitor, consensusClient, scheduler, chainTime, accountManager, signer)
+	registrations, err := previewer.ValidatorRegistrationsPreview(ctx, account, pubkey)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start block relay: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Failed to preview validator registrations: %v\n", err)
 		return true
 	}
 
-	// Set up the required account and pubkey.
-	var pubkey phase0.BLSPubKey
-	data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("proposer-config-check"), "0x"))
+	data, err := json.Marshal(registrations)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "invalid public key: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Invalid validator registrations: %v\n", err)
 		return true
 	}
-	copy(pubkey[:], data)
-	account, err := accountManager.(accountmanager.AccountsProvider).AccountByPublicKey(ctx, pubkey)
+
+	fmt.Printf("%s\n", string(data))
+	return true
+}
+
+// commandBlockRelay starts the services required to obtain information from the
+// block relay about the validator with the given public key.
+func commandBlockRelay(ctx context.Context,
+	majordomo majordomo.Service,
+	pubkeyStr string,
+) (
+	blockrelay.Service,
+	e2wtypes.Account,
+	phase0.BLSPubKey,
+	error,
+) {
+	var pubkey phase0.BLSPubKey
+
+	if err := e2types.InitBLS(); err != nil {
+		return nil, nil, pubkey, errors.Wrap(err, "failed to initialise BLS library")
+	}
+
+	// Force disable metrics.
+	viper.Set("metrics.prometheus.listen-address", "")
+	viper.Set("metrics.prometheus.push.url", "")
+	consensusClient, chainSpec, chainTime, monitor, err := startBasicServices(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Could not obtain account for public key: %v.  Please ensure the public key matches a validator managed by this Vouch instance.", err)
-		return true
+		return nil, nil, pubkey, errors.Wrap(err, "failed to start basic services")
 	}
 
-	proposerConfig, err := blockRelaySvc.(blockrelay.ExecutionConfigProvider).ProposerConfig(ctx, account, pubkey)
+	validatorsManager, err := startValidatorsManager(ctx, monitor, consensusClient)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to obtain proposer config: %v\n", err)
-		return true
+		return nil, nil, pubkey, errors.Wrap(err, "failed to start validators manager")
+	}
+	accountManager, err := startAccountManager(ctx, monitor, consensusClient, chainSpec, validatorsManager, majordomo, chainTime, nil)
+	if err != nil {
+		return nil, nil, pubkey, errors.Wrap(err, "failed to start account manager")
+	}
+	scheduler := mockscheduler.New()
+	signer, err := startSigner(ctx, monitor, consensusClient, chainSpec, nil)
+	if err != nil {
+		return nil, nil, pubkey, errors.Wrap(err, "failed to start signer")
+	}
+	blockRelaySvc, err := startBlockRelay(ctx, majordomo, monitor, consensusClient, chainSpec, scheduler, chainTime, accountManager, signer)
+	if err != nil {
+		return nil, nil, pubkey, errors.Wrap(err, "failed to start block relay")
 	}
 
-	data, err = proposerConfig.MarshalJSON()
+	// Set up the required account and pubkey.
+	data, err := hex.DecodeString(strings.TrimPrefix(pubkeyStr, "0x"))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Invalid proposer config: %v\n", err)
-		return true
+		return nil, nil, pubkey, errors.Wrap(err, "invalid public key")
+	}
+	copy(pubkey[:], data)
+	account, err := accountManager.(accountmanager.AccountsProvider).AccountByPublicKey(ctx, pubkey)
+	if err != nil {
+		return nil, nil, pubkey, errors.Wrap(err, "could not obtain account for public key; please ensure the public key matches a validator managed by this Vouch instance")
 	}
 
-	fmt.Printf("%s\n", string(data))
-	return true
+	return blockRelaySvc, account, pubkey, nil
 }
@@ -0,0 +1,77 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/attestantio/vouch/services/coordination"
+	consulcoordination "github.com/attestantio/vouch/services/coordination/consul"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-majordomo"
+)
+
+// startCoordinator starts the coordination service if configured.
+func startCoordinator(ctx context.Context,
+	majordomo majordomo.Service,
+	monitor metrics.Service,
+) (
+	coordination.Service,
+	error,
+) {
+	if viper.GetString("coordination.consul.address") == "" {
+		return nil, nil
+	}
+
+	instanceID := viper.GetString("coordination.instance-id")
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain hostname for instance ID")
+		}
+		instanceID = hostname
+	}
+
+	token := ""
+	if viper.GetString("coordination.consul.token") != "" {
+		data, err := majordomo.Fetch(ctx, viper.GetString("coordination.consul.token"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain Consul token")
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	coordinator, err := consulcoordination.New(ctx,
+		consulcoordination.WithLogLevel(util.LogLevel("coordination")),
+		consulcoordination.WithMonitor(monitor),
+		consulcoordination.WithAddress(viper.GetString("coordination.consul.address")),
+		consulcoordination.WithToken(token),
+		consulcoordination.WithKey(viper.GetString("coordination.consul.key")),
+		consulcoordination.WithInstanceID(instanceID),
+		consulcoordination.WithSessionTTL(viper.GetDuration("coordination.session-ttl")),
+		consulcoordination.WithLockDelay(viper.GetDuration("coordination.lock-delay")),
+		consulcoordination.WithTimeout(util.Timeout("coordination")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("instance_id", instanceID).Msg("Started coordinator; duties will only be carried out when elected leader")
+
+	return coordinator, nil
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	standardcontroller "github.com/attestantio/vouch/services/controller/standard"
+)
+
+var dutyPlanEndpointOnce sync.Once
+
+// initDutyPlanEndpoint adds the /debug/duties endpoint to the default HTTP server.
+// This returns the controller's plan of jobs for the slot supplied in the 'slot'
+// query parameter, defaulting to the current slot.
+func initDutyPlanEndpoint(chainTime chaintime.Service, controller *standardcontroller.Service) {
+	dutyPlanEndpointOnce.Do(func() {
+		http.HandleFunc("/debug/duties", func(w http.ResponseWriter, r *http.Request) {
+			slot := chainTime.CurrentSlot()
+			if slotStr := r.URL.Query().Get("slot"); slotStr != "" {
+				tmp, err := strconv.ParseUint(slotStr, 10, 64)
+				if err != nil {
+					http.Error(w, "invalid slot", http.StatusBadRequest)
+					return
+				}
+				slot = phase0.Slot(tmp)
+			}
+
+			data, err := json.Marshal(controller.DutyPlan(r.Context(), slot))
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to marshal duty plan")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := w.Write(data); err != nil {
+				log.Debug().Err(err).Msg("Failed to write duty plan")
+			}
+		})
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	standardcontroller "github.com/attestantio/vouch/services/controller/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyPlanEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	initDutyPlanEndpoint(chainTime, &standardcontroller.Service{})
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{
+			name:   "SlotInvalid",
+			query:  "?slot=bad",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "SlotNegative",
+			query:  "?slot=-1",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/debug/duties"+test.query, nil)
+			rec := httptest.NewRecorder()
+			http.DefaultServeMux.ServeHTTP(rec, req)
+			require.Equal(t, test.status, rec.Code)
+		})
+	}
+}
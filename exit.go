@@ -0,0 +1,241 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/chaintime"
+	standarddutyblacklist "github.com/attestantio/vouch/services/dutyblacklist/standard"
+	"github.com/attestantio/vouch/services/signer"
+	standardvoluntaryexit "github.com/attestantio/vouch/services/voluntaryexit/standard"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	majordomo "github.com/wealdtech/go-majordomo"
+)
+
+// exitCommand generates, signs, stores and broadcasts voluntary exits.
+func exitCommand(ctx context.Context, majordomo majordomo.Service) bool {
+	if err := runExit(ctx, majordomo); err != nil {
+		fmt.Fprintf(os.Stderr, "Exit failed: %v\n", err)
+	}
+
+	return true
+}
+
+// runExit runs the exit command.  Exits are either read from a directory of
+// previously stored exits and broadcast, or generated for the validators,
+// then stored and/or broadcast.
+func runExit(ctx context.Context, majordomo majordomo.Service) error {
+	if err := e2types.InitBLS(); err != nil {
+		return errors.Wrap(err, "failed to initialise BLS library")
+	}
+
+	passphrase := ""
+	if viper.GetString("exit.passphrase") != "" {
+		data, err := majordomo.Fetch(ctx, viper.GetString("exit.passphrase"))
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch passphrase")
+		}
+		passphrase = strings.TrimSpace(string(data))
+	}
+
+	// Force disable metrics.
+	viper.Set("metrics.prometheus.listen-address", "")
+	viper.Set("metrics.prometheus.push.url", "")
+	consensusClient, chainSpec, chainTime, monitor, err := startBasicServices(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to start basic services")
+	}
+
+	if viper.GetString("exit.input") != "" {
+		return broadcastStoredExits(ctx, consensusClient, resolvePath(viper.GetString("exit.input")), passphrase)
+	}
+
+	output := viper.GetString("exit.output")
+	broadcast := viper.GetBool("exit.broadcast")
+	if output == "" && !broadcast {
+		return errors.New("exits must be stored with --exit.output, broadcast with --exit.broadcast, or both")
+	}
+	pubKeys, err := commandValidators(ctx, majordomo, "exit.validators")
+	if err != nil {
+		return err
+	}
+	epoch, err := exitEpoch(chainTime, broadcast)
+	if err != nil {
+		return err
+	}
+
+	validatorsManager, err := startValidatorsManager(ctx, monitor, consensusClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to start validators manager")
+	}
+	accountManager, err := startAccountManager(ctx, monitor, consensusClient, chainSpec, validatorsManager, majordomo, chainTime, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to start account manager")
+	}
+	signerSvc, err := startSigner(ctx, monitor, consensusClient, chainSpec, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to start signer")
+	}
+	voluntaryExit, err := standardvoluntaryexit.New(ctx,
+		standardvoluntaryexit.WithLogLevel(util.LogLevel("voluntaryexit")),
+		standardvoluntaryexit.WithAccountsProvider(accountManager.(accountmanager.AccountsProvider)),
+		standardvoluntaryexit.WithValidatorsProvider(consensusClient.(eth2client.ValidatorsProvider)),
+		standardvoluntaryexit.WithVoluntaryExitSigner(signerSvc.(signer.VoluntaryExitSigner)),
+		standardvoluntaryexit.WithVoluntaryExitSubmitter(consensusClient.(eth2client.VoluntaryExitSubmitter)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to start voluntary exit service")
+	}
+
+	exits, err := voluntaryExit.SignExits(ctx, epoch, pubKeys)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Signed %d exits for epoch %d\n", len(exits), epoch)
+
+	if output != "" {
+		if err := storeExits(resolvePath(output), exits, passphrase); err != nil {
+			return err
+		}
+		fmt.Printf("Stored exits in %s\n", resolvePath(output))
+	}
+
+	if broadcast {
+		signedExits := make([]*phase0.SignedVoluntaryExit, 0, len(exits))
+		for _, exit := range exits {
+			signedExits = append(signedExits, exit)
+		}
+		sort.Slice(signedExits, func(i, j int) bool {
+			return signedExits[i].Message.ValidatorIndex < signedExits[j].Message.ValidatorIndex
+		})
+		if err := voluntaryExit.SubmitExits(ctx, signedExits); err != nil {
+			return err
+		}
+		fmt.Printf("Broadcast %d exits\n", len(signedExits))
+	}
+
+	return nil
+}
+
+// commandValidators fetches the public keys of the validators on which a
+// command operates from the majordomo URL at the given configuration key.
+func commandValidators(ctx context.Context, majordomo majordomo.Service, key string) ([]phase0.BLSPubKey, error) {
+	if viper.GetString(key) == "" {
+		return nil, errors.New("no validators specified")
+	}
+	data, err := majordomo.Fetch(ctx, viper.GetString(key))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch validators")
+	}
+	entries, err := standarddutyblacklist.ParseBlacklist(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse validators")
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no validators supplied")
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(entries))
+	for pubKey := range entries {
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	return pubKeys, nil
+}
+
+// exitEpoch returns the epoch for the exits, defaulting to the current epoch.
+// Exits for a future epoch can be stored, but not broadcast.
+func exitEpoch(chainTime chaintime.Service, broadcast bool) (phase0.Epoch, error) {
+	epoch := phase0.Epoch(viper.GetUint64("exit.epoch"))
+	if epoch == 0 {
+		epoch = chainTime.CurrentEpoch()
+	}
+	if broadcast && epoch > chainTime.CurrentEpoch() {
+		return 0, fmt.Errorf("exits for future epoch %d cannot be broadcast until that epoch", epoch)
+	}
+
+	return epoch, nil
+}
+
+// storeExits writes exits to the given directory, one file per validator.
+func storeExits(dir string, exits map[phase0.BLSPubKey]*phase0.SignedVoluntaryExit, passphrase string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create exit directory")
+	}
+	for pubKey, exit := range exits {
+		data, err := standardvoluntaryexit.MarshalExit(pubKey, exit, passphrase)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(filepath.Join(dir, fmt.Sprintf("exit-%#x.json", pubKey)), append(data, '\n')); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write exit for validator %#x", pubKey))
+		}
+	}
+
+	return nil
+}
+
+// broadcastStoredExits broadcasts the exits stored in the given directory.
+func broadcastStoredExits(ctx context.Context,
+	consensusClient eth2client.Service,
+	dir string,
+	passphrase string,
+) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return errors.Wrap(err, "failed to list exits")
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no exits found in %s", dir)
+	}
+	sort.Strings(paths)
+	exits := make([]*phase0.SignedVoluntaryExit, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+		}
+		exit, err := standardvoluntaryexit.UnmarshalExit(data, passphrase)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to read exit from %s", path))
+		}
+		exits = append(exits, exit)
+	}
+
+	voluntaryExit, err := standardvoluntaryexit.New(ctx,
+		standardvoluntaryexit.WithLogLevel(util.LogLevel("voluntaryexit")),
+		standardvoluntaryexit.WithValidatorsProvider(consensusClient.(eth2client.ValidatorsProvider)),
+		standardvoluntaryexit.WithVoluntaryExitSubmitter(consensusClient.(eth2client.VoluntaryExitSubmitter)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to start voluntary exit service")
+	}
+	if err := voluntaryExit.SubmitExits(ctx, exits); err != nil {
+		return err
+	}
+	fmt.Printf("Broadcast %d exits\n", len(exits))
+
+	return nil
+}
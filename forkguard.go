@@ -0,0 +1,157 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chainspec"
+	"github.com/attestantio/vouch/services/chaintime"
+	standardcontroller "github.com/attestantio/vouch/services/controller/standard"
+	"github.com/spf13/viper"
+)
+
+// forkGuardEscalationEpochs is the number of epochs before an unsupported fork
+// at which warnings escalate to errors.
+const forkGuardEscalationEpochs = 225
+
+// farFutureEpoch is the epoch used for forks that are not yet scheduled.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// forkGuard tracks the state of the guard against unsupported forks.
+type forkGuard struct {
+	forkScheduleProvider eth2client.ForkScheduleProvider
+	chainTime            chaintime.Service
+	controller           *standardcontroller.Service
+	action               string
+	warned               bool
+	exitTimer            *time.Timer
+	exitEpoch            phase0.Epoch
+}
+
+// startForkGuard starts monitoring the fork schedule for forks that this build of
+// Vouch does not support, taking the configured action before they activate.
+// The fork schedule is checked each epoch, and immediately if it changes.
+func startForkGuard(ctx context.Context,
+	chainSpec chainspec.Service,
+	chainTime chaintime.Service,
+	controller *standardcontroller.Service,
+) error {
+	action := viper.GetString("fork-guard.action")
+	switch action {
+	case "continue", "stop-proposals", "exit":
+	default:
+		return fmt.Errorf("unknown fork guard action %q", action)
+	}
+
+	g := &forkGuard{
+		forkScheduleProvider: chainSpec,
+		chainTime:            chainTime,
+		controller:           controller,
+		action:               action,
+	}
+	changedCh := make(chan struct{}, 1)
+	if notifier, isNotifier := chainSpec.(chainspec.ChangeNotifier); isNotifier {
+		notifier.AddChangeHandler(func(_ context.Context) {
+			select {
+			case changedCh <- struct{}{}:
+			default:
+				// Check already pending.
+			}
+		})
+	}
+	go func() {
+		for {
+			if forkEpoch, found := g.unsupportedForkEpoch(ctx); found {
+				g.guard(ctx, forkEpoch)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-changedCh:
+			case <-time.After(time.Until(chainTime.StartOfEpoch(chainTime.CurrentEpoch() + 1))):
+			}
+		}
+	}()
+
+	return nil
+}
+
+// unsupportedForkEpoch returns the epoch of the first scheduled fork that this
+// build of Vouch does not support, if any.
+func (g *forkGuard) unsupportedForkEpoch(ctx context.Context) (phase0.Epoch, bool) {
+	forkScheduleResponse, err := g.forkScheduleProvider.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain fork schedule to check for unsupported forks")
+		return 0, false
+	}
+
+	for i, fork := range forkScheduleResponse.Data {
+		if i >= len(supportedForks) && fork.Epoch != farFutureEpoch {
+			return fork.Epoch, true
+		}
+	}
+
+	return 0, false
+}
+
+// guard warns about an upcoming unsupported fork, escalating as it approaches,
+// and takes the configured action.
+func (g *forkGuard) guard(ctx context.Context, forkEpoch phase0.Epoch) {
+	currentEpoch := g.chainTime.CurrentEpoch()
+	log := log.With().Uint64("fork_epoch", uint64(forkEpoch)).Str("action", g.action).Logger()
+
+	if currentEpoch >= forkEpoch {
+		log.Error().Msg("A fork that this build of Vouch does not support is active; upgrade Vouch")
+	} else {
+		epochsUntilFork := uint64(forkEpoch - currentEpoch)
+		switch {
+		case epochsUntilFork <= forkGuardEscalationEpochs:
+			log.Error().Uint64("epochs_until_fork", epochsUntilFork).Msg("A fork that this build of Vouch does not support is imminent; upgrade Vouch")
+		case !g.warned || epochsUntilFork%forkGuardEscalationEpochs == 0:
+			log.Warn().Uint64("epochs_until_fork", epochsUntilFork).Msg("A fork that this build of Vouch does not support is scheduled; upgrade Vouch")
+		}
+	}
+	g.warned = true
+
+	switch g.action {
+	case "stop-proposals":
+		g.controller.StopProposals(ctx, forkEpoch)
+	case "exit":
+		if g.exitTimer != nil {
+			if g.exitEpoch == forkEpoch {
+				// Already set.
+				return
+			}
+			// The fork schedule has changed; replace the timer.
+			g.exitTimer.Stop()
+		}
+		// Exit at the start of the last slot prior to the fork, allowing
+		// attestations for that slot to complete.
+		exitTime := g.chainTime.StartOfEpoch(forkEpoch)
+		if forkEpoch > 0 {
+			exitTime = g.chainTime.StartOfSlot(g.chainTime.FirstSlotOfEpoch(forkEpoch) - 1)
+		}
+		g.exitEpoch = forkEpoch
+		g.exitTimer = time.AfterFunc(time.Until(exitTime), func() {
+			requestShutdown(fmt.Sprintf("fork at epoch %d is not supported by this build of Vouch", forkEpoch))
+		})
+	}
+}
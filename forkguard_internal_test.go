@@ -0,0 +1,189 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedForkScheduleProvider returns a fixed fork schedule.
+type fixedForkScheduleProvider struct {
+	forkSchedule []*phase0.Fork
+	err          error
+}
+
+func (p *fixedForkScheduleProvider) ForkSchedule(_ context.Context, _ *api.ForkScheduleOpts) (*api.Response[[]*phase0.Fork], error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &api.Response[[]*phase0.Fork]{
+		Data:     p.forkSchedule,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// supportedForkSchedule returns a fork schedule containing the supported forks
+// followed by the given additional fork epochs.
+func supportedForkSchedule(additional ...phase0.Epoch) []*phase0.Fork {
+	forkSchedule := make([]*phase0.Fork, 0, len(supportedForks)+len(additional))
+	for i := range supportedForks {
+		forkSchedule = append(forkSchedule, &phase0.Fork{Epoch: phase0.Epoch(i)})
+	}
+	for _, epoch := range additional {
+		forkSchedule = append(forkSchedule, &phase0.Fork{Epoch: epoch})
+	}
+
+	return forkSchedule
+}
+
+func TestUnsupportedForkEpoch(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		provider *fixedForkScheduleProvider
+		epoch    phase0.Epoch
+		found    bool
+	}{
+		{
+			name: "ProviderErrors",
+			provider: &fixedForkScheduleProvider{
+				err: errors.New("mock error"),
+			},
+		},
+		{
+			name: "Supported",
+			provider: &fixedForkScheduleProvider{
+				forkSchedule: supportedForkSchedule(),
+			},
+		},
+		{
+			name: "UnsupportedUnscheduled",
+			provider: &fixedForkScheduleProvider{
+				forkSchedule: supportedForkSchedule(farFutureEpoch),
+			},
+		},
+		{
+			name: "UnsupportedScheduled",
+			provider: &fixedForkScheduleProvider{
+				forkSchedule: supportedForkSchedule(500),
+			},
+			epoch: 500,
+			found: true,
+		},
+		{
+			name: "UnsupportedScheduledAfterUnscheduled",
+			provider: &fixedForkScheduleProvider{
+				forkSchedule: supportedForkSchedule(farFutureEpoch, 600),
+			},
+			epoch: 600,
+			found: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &forkGuard{
+				forkScheduleProvider: test.provider,
+			}
+			epoch, found := g.unsupportedForkEpoch(ctx)
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.epoch, epoch)
+		})
+	}
+}
+
+func TestForkGuardExit(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	g := &forkGuard{
+		chainTime: chainTime,
+		action:    "exit",
+	}
+	defer func() {
+		if g.exitTimer != nil {
+			g.exitTimer.Stop()
+		}
+	}()
+
+	forkEpoch := chainTime.CurrentEpoch() + 1000
+	g.guard(ctx, forkEpoch)
+	require.True(t, g.warned)
+	require.NotNil(t, g.exitTimer)
+	require.Equal(t, forkEpoch, g.exitEpoch)
+
+	// Same fork; timer is retained.
+	exitTimer := g.exitTimer
+	g.guard(ctx, forkEpoch)
+	require.Same(t, exitTimer, g.exitTimer)
+
+	// Fork rescheduled; timer is replaced.
+	g.guard(ctx, forkEpoch+10)
+	require.NotSame(t, exitTimer, g.exitTimer)
+	require.Equal(t, forkEpoch+10, g.exitEpoch)
+	require.False(t, exitTimer.Stop())
+
+	// No shutdown has been requested.
+	select {
+	case reason := <-shutdownCh:
+		require.Fail(t, "unexpected shutdown", reason)
+	default:
+	}
+}
+
+func TestForkGuardContinue(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	g := &forkGuard{
+		chainTime: chainTime,
+		action:    "continue",
+	}
+	g.guard(ctx, chainTime.CurrentEpoch()+1000)
+	require.True(t, g.warned)
+	require.Nil(t, g.exitTimer)
+}
+
+func TestStartForkGuardUnknownAction(t *testing.T) {
+	viper.Set("fork-guard.action", "bad")
+	defer viper.Reset()
+
+	err := startForkGuard(context.Background(), nil, nil, nil)
+	require.EqualError(t, err, `unknown fork guard action "bad"`)
+}
@@ -0,0 +1,301 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/spf13/viper"
+)
+
+// forkRehearsal rehearses the fork at the configured epoch, exercising the data
+// formats that Vouch will handle after the fork without submitting anything to
+// the network.
+func forkRehearsal(ctx context.Context) bool {
+	// Force disable metrics.
+	viper.Set("metrics.prometheus.listen-address", "")
+	viper.Set("metrics.prometheus.push.url", "")
+	_, chainSpec, chainTime, _, err := startBasicServices(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start basic services: %v\n", err)
+		return true
+	}
+
+	forkScheduleResponse, err := chainSpec.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to obtain fork schedule: %v\n", err)
+		return true
+	}
+	forkSchedule := forkScheduleResponse.Data
+
+	epoch := phase0.Epoch(viper.GetUint64("fork-rehearsal.epoch"))
+	if epoch == 0 {
+		// Default to the next scheduled fork.
+		for _, fork := range forkSchedule {
+			if fork.Epoch > chainTime.CurrentEpoch() {
+				epoch = fork.Epoch
+				break
+			}
+		}
+		if epoch == 0 {
+			fmt.Fprintf(os.Stderr, "No upcoming fork in the fork schedule; please supply fork-rehearsal.epoch\n")
+			return true
+		}
+	}
+
+	version, err := forkVersionAtEpoch(forkSchedule, epoch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to obtain fork for epoch %d: %v\n", epoch, err)
+		return true
+	}
+	fmt.Printf("Rehearsing %s fork at epoch %d\n", version, epoch)
+
+	specResponse, err := chainSpec.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to obtain spec: %v\n", err)
+		return true
+	}
+
+	slot := chainTime.FirstSlotOfEpoch(epoch)
+	problems := rehearseFork(specResponse.Data, version, slot)
+	if len(problems) == 0 {
+		fmt.Printf("No incompatibilities found\n")
+		return true
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("Incompatibility: %s\n", problem)
+	}
+
+	return true
+}
+
+// forkVersionAtEpoch returns the data version in force at the given epoch.
+func forkVersionAtEpoch(forkSchedule []*phase0.Fork, epoch phase0.Epoch) (spec.DataVersion, error) {
+	index := -1
+	for i := range forkSchedule {
+		if forkSchedule[i].Epoch <= epoch {
+			index = i
+		}
+	}
+	if index == -1 {
+		return spec.DataVersionUnknown, errors.New("no fork active at epoch")
+	}
+
+	// Data versions start at unknown, so the fork at index 0 is phase 0.
+	return spec.DataVersion(index + 1), nil
+}
+
+// rehearseFork exercises the data formats of the given version, returning any
+// incompatibilities found.
+func rehearseFork(chainSpec map[string]any, version spec.DataVersion, slot phase0.Slot) []string {
+	problems := make([]string, 0)
+
+	supported := false
+	for _, supportedFork := range supportedForks {
+		if supportedFork == version {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return append(problems, fmt.Sprintf("fork %s is not supported by this build of Vouch", version))
+	}
+
+	if version != spec.DataVersionPhase0 {
+		key := fmt.Sprintf("%s_FORK_EPOCH", strings.ToUpper(version.String()))
+		if _, exists := chainSpec[key]; !exists {
+			problems = append(problems, fmt.Sprintf("beacon node spec does not contain %s", key))
+		}
+	}
+
+	proposal, err := rehearsalProposal(version, slot)
+	if err != nil {
+		return append(problems, fmt.Sprintf("failed to create %s proposal: %v", version, err))
+	}
+
+	proposalSlot, err := proposal.Slot()
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("failed to obtain proposal slot: %v", err))
+	} else if proposalSlot != slot {
+		problems = append(problems, "proposal slot mismatch")
+	}
+	if _, err := proposal.Graffiti(); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to obtain proposal graffiti: %v", err))
+	}
+	if _, err := proposal.BodyRoot(); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to calculate proposal body root: %v", err))
+	}
+	if _, err := proposal.Root(); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to calculate proposal root: %v", err))
+	}
+	if version >= spec.DataVersionBellatrix {
+		if _, err := proposal.FeeRecipient(); err != nil {
+			problems = append(problems, fmt.Sprintf("failed to obtain proposal fee recipient: %v", err))
+		}
+	}
+	if err := rehearseJSON(proposal); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to round-trip proposal JSON: %v", err))
+	}
+
+	return problems
+}
+
+// rehearseJSON ensures that the block within the proposal survives a round trip
+// through its JSON representation.
+func rehearseJSON(proposal *api.VersionedProposal) error {
+	var block any
+	switch proposal.Version {
+	case spec.DataVersionPhase0:
+		block = proposal.Phase0
+	case spec.DataVersionAltair:
+		block = proposal.Altair
+	case spec.DataVersionBellatrix:
+		block = proposal.Bellatrix
+	case spec.DataVersionCapella:
+		block = proposal.Capella
+	case spec.DataVersionDeneb:
+		block = proposal.Deneb
+	default:
+		return errors.New("unhandled proposal version")
+	}
+
+	data, err := json.Marshal(block)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+	if err := json.Unmarshal(data, block); err != nil {
+		return errors.Wrap(err, "failed to unmarshal")
+	}
+
+	return nil
+}
+
+// rehearsalProposal creates a minimal proposal of the given version.
+func rehearsalProposal(version spec.DataVersion, slot phase0.Slot) (*api.VersionedProposal, error) {
+	eth1Data := &phase0.ETH1Data{
+		BlockHash: make([]byte, 32),
+	}
+	syncAggregate := &altair.SyncAggregate{
+		SyncCommitteeBits: bitfield.NewBitvector512(),
+	}
+
+	proposal := &api.VersionedProposal{
+		Version: version,
+	}
+	switch version {
+	case spec.DataVersionPhase0:
+		proposal.Phase0 = &phase0.BeaconBlock{
+			Slot: slot,
+			Body: &phase0.BeaconBlockBody{
+				ETH1Data:          eth1Data,
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+			},
+		}
+	case spec.DataVersionAltair:
+		proposal.Altair = &altair.BeaconBlock{
+			Slot: slot,
+			Body: &altair.BeaconBlockBody{
+				ETH1Data:          eth1Data,
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				SyncAggregate:     syncAggregate,
+			},
+		}
+	case spec.DataVersionBellatrix:
+		proposal.Bellatrix = &bellatrix.BeaconBlock{
+			Slot: slot,
+			Body: &bellatrix.BeaconBlockBody{
+				ETH1Data:          eth1Data,
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				SyncAggregate:     syncAggregate,
+				ExecutionPayload: &bellatrix.ExecutionPayload{
+					Transactions: []bellatrix.Transaction{},
+				},
+			},
+		}
+	case spec.DataVersionCapella:
+		proposal.Capella = &capella.BeaconBlock{
+			Slot: slot,
+			Body: &capella.BeaconBlockBody{
+				ETH1Data:          eth1Data,
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				SyncAggregate:     syncAggregate,
+				ExecutionPayload: &capella.ExecutionPayload{
+					Transactions: []bellatrix.Transaction{},
+					Withdrawals:  []*capella.Withdrawal{},
+				},
+				BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+			},
+		}
+	case spec.DataVersionDeneb:
+		proposal.Deneb = &apiv1deneb.BlockContents{
+			Block: &deneb.BeaconBlock{
+				Slot: slot,
+				Body: &deneb.BeaconBlockBody{
+					ETH1Data:          eth1Data,
+					ProposerSlashings: []*phase0.ProposerSlashing{},
+					AttesterSlashings: []*phase0.AttesterSlashing{},
+					Attestations:      []*phase0.Attestation{},
+					Deposits:          []*phase0.Deposit{},
+					VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+					SyncAggregate:     syncAggregate,
+					ExecutionPayload: &deneb.ExecutionPayload{
+						BaseFeePerGas: uint256.NewInt(0),
+						Transactions:  []bellatrix.Transaction{},
+						Withdrawals:   []*capella.Withdrawal{},
+					},
+					BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+					BlobKZGCommitments:    []deneb.KZGCommitment{},
+				},
+			},
+			KZGProofs: make([]deneb.KZGProof, 0),
+			Blobs:     make([]deneb.Blob, 0),
+		}
+	default:
+		return nil, errors.New("unhandled proposal version")
+	}
+
+	return proposal, nil
+}
@@ -0,0 +1,167 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkVersionAtEpoch(t *testing.T) {
+	forkSchedule := []*phase0.Fork{
+		{Epoch: 0},
+		{Epoch: 10},
+		{Epoch: 20},
+	}
+
+	tests := []struct {
+		name         string
+		forkSchedule []*phase0.Fork
+		epoch        phase0.Epoch
+		expected     spec.DataVersion
+		err          string
+	}{
+		{
+			name:  "Empty",
+			epoch: 5,
+			err:   "no fork active at epoch",
+		},
+		{
+			name:         "Genesis",
+			forkSchedule: forkSchedule,
+			epoch:        0,
+			expected:     spec.DataVersionPhase0,
+		},
+		{
+			name:         "BeforeFork",
+			forkSchedule: forkSchedule,
+			epoch:        9,
+			expected:     spec.DataVersionPhase0,
+		},
+		{
+			name:         "AtFork",
+			forkSchedule: forkSchedule,
+			epoch:        10,
+			expected:     spec.DataVersionAltair,
+		},
+		{
+			name:         "AfterLastFork",
+			forkSchedule: forkSchedule,
+			epoch:        100,
+			expected:     spec.DataVersionBellatrix,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := forkVersionAtEpoch(test.forkSchedule, test.epoch)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, version)
+			}
+		})
+	}
+}
+
+func TestRehearseFork(t *testing.T) {
+	chainSpec := map[string]any{
+		"ALTAIR_FORK_EPOCH":    phase0.Epoch(1),
+		"BELLATRIX_FORK_EPOCH": phase0.Epoch(2),
+		"CAPELLA_FORK_EPOCH":   phase0.Epoch(3),
+		"DENEB_FORK_EPOCH":     phase0.Epoch(4),
+	}
+
+	tests := []struct {
+		name      string
+		chainSpec map[string]any
+		version   spec.DataVersion
+		problems  []string
+	}{
+		{
+			name:      "Phase0",
+			chainSpec: chainSpec,
+			version:   spec.DataVersionPhase0,
+		},
+		{
+			name:      "Altair",
+			chainSpec: chainSpec,
+			version:   spec.DataVersionAltair,
+		},
+		{
+			name:      "Bellatrix",
+			chainSpec: chainSpec,
+			version:   spec.DataVersionBellatrix,
+		},
+		{
+			name:      "Capella",
+			chainSpec: chainSpec,
+			version:   spec.DataVersionCapella,
+		},
+		{
+			name:      "Deneb",
+			chainSpec: chainSpec,
+			version:   spec.DataVersionDeneb,
+		},
+		{
+			name:      "SpecMissingForkEpoch",
+			chainSpec: map[string]any{},
+			version:   spec.DataVersionDeneb,
+			problems: []string{
+				"beacon node spec does not contain DENEB_FORK_EPOCH",
+			},
+		},
+		{
+			name:      "Unsupported",
+			chainSpec: chainSpec,
+			version:   spec.DataVersion(len(supportedForks) + 1),
+			problems: []string{
+				fmt.Sprintf("fork %s is not supported by this build of Vouch", spec.DataVersion(len(supportedForks)+1)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := rehearseFork(test.chainSpec, test.version, 32)
+			if len(test.problems) == 0 {
+				require.Empty(t, problems)
+			} else {
+				require.Equal(t, test.problems, problems)
+			}
+		})
+	}
+}
+
+func TestRehearsalProposal(t *testing.T) {
+	for _, version := range supportedForks {
+		t.Run(version.String(), func(t *testing.T) {
+			proposal, err := rehearsalProposal(version, 12345)
+			require.NoError(t, err)
+			require.Equal(t, version, proposal.Version)
+			slot, err := proposal.Slot()
+			require.NoError(t, err)
+			require.Equal(t, phase0.Slot(12345), slot)
+			require.NoError(t, rehearseJSON(proposal))
+		})
+	}
+
+	_, err := rehearsalProposal(spec.DataVersionUnknown, 1)
+	require.EqualError(t, err, "unhandled proposal version")
+}
@@ -0,0 +1,409 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	standarddutyblacklist "github.com/attestantio/vouch/services/dutyblacklist/standard"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	majordomo "github.com/wealdtech/go-majordomo"
+)
+
+// handoffSnapshot is the snapshot of duties written for the receiving instance.
+type handoffSnapshot struct {
+	DrainEpoch     phase0.Epoch          `json:"drain_epoch,string"`
+	ReleaseEpoch   phase0.Epoch          `json:"release_epoch,string"`
+	Validators     []*handoffValidator   `json:"validators"`
+	AttesterDuties []*apiv1.AttesterDuty `json:"attester_duties"`
+	ProposerDuties []*apiv1.ProposerDuty `json:"proposer_duties"`
+}
+
+// handoffValidator is a validator being handed off.
+type handoffValidator struct {
+	Index  phase0.ValidatorIndex `json:"index,string"`
+	PubKey string                `json:"pubkey"`
+}
+
+// handoff moves a set of validators from a source Vouch instance to a target
+// Vouch instance.
+func handoff(ctx context.Context, majordomo majordomo.Service) bool {
+	// Force disable metrics.
+	viper.Set("metrics.prometheus.listen-address", "")
+	viper.Set("metrics.prometheus.push.url", "")
+	consensusClient, _, chainTime, _, err := startBasicServices(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start basic services: %v\n", err)
+		return true
+	}
+
+	if err := runHandoff(ctx, majordomo, consensusClient, chainTime); err != nil {
+		fmt.Fprintf(os.Stderr, "Handoff failed: %v\n", err)
+	}
+
+	return true
+}
+
+// runHandoff carries out the handoff.  The source instance is drained at the
+// start of the drain epoch by adding the validators to its blacklist.  Once the
+// chain shows that the source has stopped attesting, the validators are removed
+// from the blacklist of the target instance two epochs later.  The source is
+// only released once the chain shows that the target is attesting.
+func runHandoff(ctx context.Context,
+	majordomo majordomo.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+) error {
+	sourceBlacklist := viper.GetString("handoff.source.blacklist")
+	if sourceBlacklist == "" {
+		return errors.New("no source blacklist specified")
+	}
+	sourceBlacklist = resolvePath(sourceBlacklist)
+	targetBlacklist := viper.GetString("handoff.target.blacklist")
+	if targetBlacklist == "" {
+		return errors.New("no target blacklist specified")
+	}
+	targetBlacklist = resolvePath(targetBlacklist)
+	sourceExecutionConfig := viper.GetString("handoff.source.execution-config")
+	targetExecutionConfig := viper.GetString("handoff.target.execution-config")
+	if (sourceExecutionConfig == "") != (targetExecutionConfig == "") {
+		return errors.New("execution configuration must be specified for both source and target, or neither")
+	}
+	if sourceExecutionConfig != "" {
+		sourceExecutionConfig = resolvePath(sourceExecutionConfig)
+		targetExecutionConfig = resolvePath(targetExecutionConfig)
+	}
+	confirmationEpochs := viper.GetUint64("handoff.confirmation-epochs")
+	if confirmationEpochs == 0 {
+		return errors.New("confirmation epochs must be positive")
+	}
+
+	validators, err := handoffValidators(ctx, majordomo, eth2Client)
+	if err != nil {
+		return err
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(validators))
+	for _, pubKey := range validators {
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	// The target must have the validators suspended, otherwise it could
+	// already be signing for them.
+	data, err := os.ReadFile(targetBlacklist)
+	if err != nil {
+		return errors.Wrap(err, "failed to read target blacklist")
+	}
+	suspended, err := standarddutyblacklist.ParseBlacklist(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse target blacklist")
+	}
+	for _, pubKey := range pubKeys {
+		if _, exists := suspended[pubKey]; !exists {
+			return fmt.Errorf("target blacklist does not contain validator %#x; the target instance could already be signing for it", pubKey)
+		}
+	}
+
+	drainEpoch := phase0.Epoch(viper.GetUint64("handoff.epoch"))
+	if drainEpoch == 0 {
+		drainEpoch = chainTime.CurrentEpoch() + 1
+	}
+	if drainEpoch <= chainTime.CurrentEpoch() {
+		return fmt.Errorf("handoff epoch %d is not in the future", drainEpoch)
+	}
+	releaseEpoch := drainEpoch + 2
+	fmt.Printf("Handing off %d validators: source drains at epoch %d, target starts at epoch %d\n", len(validators), drainEpoch, releaseEpoch)
+
+	// Drain the source.
+	if err := handoffWait(ctx, chainTime.StartOfEpoch(drainEpoch)); err != nil {
+		return err
+	}
+	if err := addToBlacklist(sourceBlacklist, pubKeys, fmt.Sprintf("handed off at epoch %d", drainEpoch)); err != nil {
+		return errors.Wrap(err, "failed to update source blacklist")
+	}
+	fmt.Printf("Added validators to source blacklist\n")
+
+	// Confirm that the source has stopped.
+	if err := handoffWait(ctx, chainTime.StartOfEpoch(releaseEpoch)); err != nil {
+		return err
+	}
+	attesters, err := epochAttesters(ctx, eth2Client, chainTime, releaseEpoch-1, validators)
+	if err != nil {
+		return errors.Wrap(err, "failed to confirm source has stopped")
+	}
+	if len(attesters) > 0 {
+		return fmt.Errorf("%d validators attested in epoch %d after the source was drained; validators remain suspended on both instances", len(attesters), releaseEpoch-1)
+	}
+	fmt.Printf("Source has stopped attesting\n")
+
+	// Transfer state to the target.
+	if viper.GetString("handoff.snapshot") != "" {
+		if err := writeHandoffSnapshot(ctx, eth2Client, resolvePath(viper.GetString("handoff.snapshot")), drainEpoch, releaseEpoch, validators); err != nil {
+			return errors.Wrap(err, "failed to write duty snapshot")
+		}
+		fmt.Printf("Written duty snapshot\n")
+	}
+	if sourceExecutionConfig != "" {
+		missing, err := copyExecutionConfig(sourceExecutionConfig, targetExecutionConfig, pubKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to transfer execution configuration")
+		}
+		for _, pubKey := range missing {
+			fmt.Printf("No validator-specific execution configuration for %#x; target defaults apply\n", pubKey)
+		}
+		fmt.Printf("Transferred execution configuration\n")
+	}
+
+	// Release the target.
+	if err := removeFromBlacklist(targetBlacklist, pubKeys); err != nil {
+		return errors.Wrap(err, "failed to update target blacklist")
+	}
+	fmt.Printf("Removed validators from target blacklist\n")
+
+	// Confirm that the target is attesting.
+	confirmed := make(map[phase0.ValidatorIndex]phase0.Slot)
+	for epoch := releaseEpoch; epoch < releaseEpoch+phase0.Epoch(confirmationEpochs) && len(confirmed) < len(validators); epoch++ {
+		if err := handoffWait(ctx, chainTime.StartOfEpoch(epoch+1)); err != nil {
+			return err
+		}
+		attesters, err := epochAttesters(ctx, eth2Client, chainTime, epoch, validators)
+		if err != nil {
+			return errors.Wrap(err, "failed to confirm target is attesting")
+		}
+		for index, slot := range attesters {
+			confirmed[index] = slot
+		}
+	}
+	if len(confirmed) < len(validators) {
+		for index, pubKey := range validators {
+			if _, exists := confirmed[index]; !exists {
+				fmt.Printf("Validator %d (%#x) not seen attesting\n", index, pubKey)
+			}
+		}
+		return fmt.Errorf("%d validators were not seen attesting from the target; the source has not been released", len(validators)-len(confirmed))
+	}
+	fmt.Printf("Target is attesting\n")
+
+	// Release the source.
+	if sourceExecutionConfig != "" {
+		if err := removeExecutionConfig(sourceExecutionConfig, pubKeys); err != nil {
+			return errors.Wrap(err, "failed to remove execution configuration from source")
+		}
+	}
+	fmt.Printf("Handoff complete; the validators' keys can now be removed from the source instance, after which they can be removed from its blacklist\n")
+
+	return nil
+}
+
+// handoffValidators fetches the validators to hand off, returning them keyed by index.
+func handoffValidators(ctx context.Context,
+	majordomo majordomo.Service,
+	eth2Client eth2client.Service,
+) (
+	map[phase0.ValidatorIndex]phase0.BLSPubKey,
+	error,
+) {
+	if viper.GetString("handoff.validators") == "" {
+		return nil, errors.New("no validators specified")
+	}
+	data, err := majordomo.Fetch(ctx, viper.GetString("handoff.validators"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch validators")
+	}
+	entries, err := standarddutyblacklist.ParseBlacklist(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse validators")
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no validators to hand off")
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(entries))
+	for pubKey := range entries {
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	validatorsResponse, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		PubKeys: pubKeys,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := make(map[phase0.ValidatorIndex]phase0.BLSPubKey, len(validatorsResponse.Data))
+	for index, validator := range validatorsResponse.Data {
+		validators[index] = validator.Validator.PublicKey
+		delete(entries, validator.Validator.PublicKey)
+	}
+	if len(entries) > 0 {
+		unknown := make([]string, 0, len(entries))
+		for pubKey := range entries {
+			unknown = append(unknown, fmt.Sprintf("%#x", pubKey))
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("validators not known to the beacon node: %s", strings.Join(unknown, ", "))
+	}
+
+	return validators, nil
+}
+
+// handoffWait waits until the given time.
+func handoffWait(ctx context.Context, until time.Time) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(sigCh)
+	select {
+	case <-sigCh:
+		return errors.New("signal received")
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(until)):
+		return nil
+	}
+}
+
+// epochAttesters returns the slots of the attestations for the given epoch made
+// by the given validators, as found in blocks on chain.
+func epochAttesters(ctx context.Context,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	epoch phase0.Epoch,
+	validators map[phase0.ValidatorIndex]phase0.BLSPubKey,
+) (
+	map[phase0.ValidatorIndex]phase0.Slot,
+	error,
+) {
+	committeesResponse, err := eth2Client.(eth2client.BeaconCommitteesProvider).BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: "head",
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	for _, committee := range committeesResponse.Data {
+		if _, exists := committees[committee.Slot]; !exists {
+			committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		committees[committee.Slot][committee.Index] = committee.Validators
+	}
+
+	attesters := make(map[phase0.ValidatorIndex]phase0.Slot)
+	for slot := chainTime.FirstSlotOfEpoch(epoch); slot < chainTime.CurrentSlot(); slot++ {
+		blockResponse, err := eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// Empty slot.
+				continue
+			}
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if blockResponse == nil || blockResponse.Data == nil {
+			// Empty slot.
+			continue
+		}
+
+		attestations, err := blockResponse.Data.Attestations()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain attestations")
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Target.Epoch != epoch {
+				continue
+			}
+			committee, exists := committees[attestation.Data.Slot][attestation.Data.Index]
+			if !exists {
+				continue
+			}
+			for i := uint64(0); i < attestation.AggregationBits.Len() && i < uint64(len(committee)); i++ {
+				if !attestation.AggregationBits.BitAt(i) {
+					continue
+				}
+				if _, exists := validators[committee[i]]; exists {
+					attesters[committee[i]] = attestation.Data.Slot
+				}
+			}
+		}
+	}
+
+	return attesters, nil
+}
+
+// writeHandoffSnapshot writes the duties of the validators for the release epoch
+// for the target instance.
+func writeHandoffSnapshot(ctx context.Context,
+	eth2Client eth2client.Service,
+	path string,
+	drainEpoch phase0.Epoch,
+	releaseEpoch phase0.Epoch,
+	validators map[phase0.ValidatorIndex]phase0.BLSPubKey,
+) error {
+	snapshot := &handoffSnapshot{
+		DrainEpoch:   drainEpoch,
+		ReleaseEpoch: releaseEpoch,
+		Validators:   make([]*handoffValidator, 0, len(validators)),
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for index := range validators {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	for _, index := range indices {
+		snapshot.Validators = append(snapshot.Validators, &handoffValidator{
+			Index:  index,
+			PubKey: fmt.Sprintf("%#x", validators[index]),
+		})
+	}
+
+	attesterDutiesResponse, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   releaseEpoch,
+		Indices: indices,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attester duties")
+	}
+	snapshot.AttesterDuties = attesterDutiesResponse.Data
+
+	proposerDutiesResponse, err := eth2Client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch:   releaseEpoch,
+		Indices: indices,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer duties")
+	}
+	snapshot.ProposerDuties = proposerDutiesResponse.Data
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal snapshot")
+	}
+
+	return writeFileAtomic(path, append(data, '\n'))
+}
@@ -0,0 +1,324 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	standarddutyblacklist "github.com/attestantio/vouch/services/dutyblacklist/standard"
+	"github.com/pkg/errors"
+)
+
+// addToBlacklist adds the given public keys to the blacklist at the given path,
+// retaining its format and existing entries.
+func addToBlacklist(path string, pubKeys []phase0.BLSPubKey, comment string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to read blacklist")
+	}
+	existing, err := standarddutyblacklist.ParseBlacklist(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse blacklist")
+	}
+
+	if isJSONBlacklist(data) {
+		var entries []string
+		if err := json.Unmarshal(bytes.TrimSpace(data), &entries); err != nil {
+			return errors.Wrap(err, "invalid JSON blacklist")
+		}
+		for _, pubKey := range pubKeys {
+			if _, exists := existing[pubKey]; !exists {
+				entries = append(entries, fmt.Sprintf("%#x", pubKey))
+			}
+		}
+		data, err = json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal blacklist")
+		}
+
+		return writeFileAtomic(path, append(data, '\n'))
+	}
+
+	buf := bytes.NewBuffer(data)
+	if buf.Len() > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	for _, pubKey := range pubKeys {
+		if _, exists := existing[pubKey]; !exists {
+			buf.WriteString(fmt.Sprintf("%#x # %s\n", pubKey, comment))
+		}
+	}
+
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// removeFromBlacklist removes the given public keys from the blacklist at the
+// given path, retaining its format and other entries.
+func removeFromBlacklist(path string, pubKeys []phase0.BLSPubKey) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read blacklist")
+	}
+	remove := make(map[string]struct{}, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		remove[fmt.Sprintf("%x", pubKey)] = struct{}{}
+	}
+	matches := func(entry string) bool {
+		_, exists := remove[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(entry), "0x"))]
+		return exists
+	}
+
+	if isJSONBlacklist(data) {
+		var entries []string
+		if err := json.Unmarshal(bytes.TrimSpace(data), &entries); err != nil {
+			return errors.Wrap(err, "invalid JSON blacklist")
+		}
+		retained := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if !matches(entry) {
+				retained = append(retained, entry)
+			}
+		}
+		data, err = json.MarshalIndent(retained, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal blacklist")
+		}
+
+		return writeFileAtomic(path, append(data, '\n'))
+	}
+
+	lines := strings.Split(string(data), "\n")
+	retained := make([]string, 0, len(lines))
+	for _, line := range lines {
+		entry := line
+		if comment := strings.Index(entry, "#"); comment != -1 {
+			entry = entry[:comment]
+		}
+		if !matches(entry) {
+			retained = append(retained, line)
+		}
+	}
+
+	return writeFileAtomic(path, []byte(strings.Join(retained, "\n")))
+}
+
+// isJSONBlacklist returns true if the blacklist is in JSON format.
+func isJSONBlacklist(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// copyExecutionConfig copies the validator-specific execution configuration for
+// the given public keys from the source file to the target file, returning the
+// public keys that have no validator-specific configuration.
+func copyExecutionConfig(sourcePath string, targetPath string, pubKeys []phase0.BLSPubKey) ([]phase0.BLSPubKey, error) {
+	source, err := readExecutionConfig(sourcePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read source")
+	}
+	target, err := readExecutionConfig(targetPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read target")
+	}
+	if source.v2 != target.v2 {
+		return nil, errors.New("source and target execution configurations have different versions")
+	}
+
+	missing := make([]phase0.BLSPubKey, 0)
+	for _, pubKey := range pubKeys {
+		entry, exists := source.entry(pubKey)
+		if !exists {
+			missing = append(missing, pubKey)
+			continue
+		}
+		target.setEntry(pubKey, entry)
+	}
+
+	if err := target.write(targetPath); err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// removeExecutionConfig removes the validator-specific execution configuration
+// for the given public keys from the file.
+func removeExecutionConfig(path string, pubKeys []phase0.BLSPubKey) error {
+	config, err := readExecutionConfig(path)
+	if err != nil {
+		return err
+	}
+	for _, pubKey := range pubKeys {
+		config.removeEntry(pubKey)
+	}
+
+	return config.write(path)
+}
+
+// handoffExecutionConfig is an execution configuration file, holding the
+// validator-specific entries separately so that they can be edited without
+// altering the remainder of the configuration.
+type handoffExecutionConfig struct {
+	fields map[string]json.RawMessage
+	v2     bool
+	// proposerConfig holds the entries of a version 1 configuration.
+	proposerConfig map[string]json.RawMessage
+	// proposers holds the entries of a version 2 configuration.
+	proposers []map[string]json.RawMessage
+}
+
+func readExecutionConfig(path string) (*handoffExecutionConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read execution configuration")
+	}
+	config := &handoffExecutionConfig{
+		fields:         make(map[string]json.RawMessage),
+		proposerConfig: make(map[string]json.RawMessage),
+		proposers:      make([]map[string]json.RawMessage, 0),
+	}
+	if err := json.Unmarshal(data, &config.fields); err != nil {
+		return nil, errors.Wrap(err, "invalid execution configuration")
+	}
+	if version, exists := config.fields["version"]; exists {
+		config.v2 = string(version) == "2"
+	}
+	if config.v2 {
+		if proposers, exists := config.fields["proposers"]; exists {
+			if err := json.Unmarshal(proposers, &config.proposers); err != nil {
+				return nil, errors.Wrap(err, "invalid proposers")
+			}
+		}
+	} else if proposerConfig, exists := config.fields["proposer_config"]; exists {
+		if err := json.Unmarshal(proposerConfig, &config.proposerConfig); err != nil {
+			return nil, errors.Wrap(err, "invalid proposer configuration")
+		}
+	}
+
+	return config, nil
+}
+
+// isPubKey returns true if the key refers to the given public key.
+func isPubKey(key string, pubKey phase0.BLSPubKey) bool {
+	return strings.EqualFold(strings.TrimPrefix(key, "0x"), hex.EncodeToString(pubKey[:]))
+}
+
+// proposerIndex returns the index of the version 2 entry for the public key, or -1.
+func (c *handoffExecutionConfig) proposerIndex(pubKey phase0.BLSPubKey) int {
+	for i, proposer := range c.proposers {
+		var key string
+		if err := json.Unmarshal(proposer["proposer"], &key); err != nil {
+			continue
+		}
+		if isPubKey(key, pubKey) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (c *handoffExecutionConfig) entry(pubKey phase0.BLSPubKey) (map[string]json.RawMessage, bool) {
+	if c.v2 {
+		index := c.proposerIndex(pubKey)
+		if index == -1 {
+			return nil, false
+		}
+		return c.proposers[index], true
+	}
+
+	for key, value := range c.proposerConfig {
+		if isPubKey(key, pubKey) {
+			return map[string]json.RawMessage{key: value}, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *handoffExecutionConfig) setEntry(pubKey phase0.BLSPubKey, entry map[string]json.RawMessage) {
+	c.removeEntry(pubKey)
+	if c.v2 {
+		c.proposers = append(c.proposers, entry)
+		return
+	}
+	for key, value := range entry {
+		c.proposerConfig[key] = value
+	}
+}
+
+func (c *handoffExecutionConfig) removeEntry(pubKey phase0.BLSPubKey) {
+	if c.v2 {
+		if index := c.proposerIndex(pubKey); index != -1 {
+			c.proposers = append(c.proposers[:index], c.proposers[index+1:]...)
+		}
+		return
+	}
+	for key := range c.proposerConfig {
+		if isPubKey(key, pubKey) {
+			delete(c.proposerConfig, key)
+		}
+	}
+}
+
+func (c *handoffExecutionConfig) write(path string) error {
+	var err error
+	if c.v2 {
+		c.fields["proposers"], err = json.Marshal(c.proposers)
+	} else {
+		c.fields["proposer_config"], err = json.Marshal(c.proposerConfig)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal proposers")
+	}
+
+	data, err := json.MarshalIndent(c.fields, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal execution configuration")
+	}
+
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// writeFileAtomic writes the file via a temporary file, so that a Vouch instance
+// reloading the file never sees partial contents.
+func writeFileAtomic(path string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), fmt.Sprintf(".%s.*", filepath.Base(path)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to rename temporary file")
+	}
+
+	return nil
+}
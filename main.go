@@ -15,9 +15,11 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	// #nosec G108
 	_ "net/http/pprof"
@@ -26,6 +28,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -33,41 +36,80 @@ import (
 	"github.com/attestantio/go-block-relay/services/blockauctioneer"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	httpclient "github.com/attestantio/go-eth2-client/http"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
 	dirkaccountmanager "github.com/attestantio/vouch/services/accountmanager/dirk"
+	failoveraccountmanager "github.com/attestantio/vouch/services/accountmanager/failover"
 	walletaccountmanager "github.com/attestantio/vouch/services/accountmanager/wallet"
 	"github.com/attestantio/vouch/services/attestationaggregator"
 	standardattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/standard"
+	"github.com/attestantio/vouch/services/attestationrebroadcaster"
+	standardattestationrebroadcaster "github.com/attestantio/vouch/services/attestationrebroadcaster/standard"
 	"github.com/attestantio/vouch/services/attester"
 	standardattester "github.com/attestantio/vouch/services/attester/standard"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	standardbeaconblockproposer "github.com/attestantio/vouch/services/beaconblockproposer/standard"
 	"github.com/attestantio/vouch/services/beaconcommitteesubscriber"
 	standardbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/standard"
+	"github.com/attestantio/vouch/services/beaconnodequota"
+	standardbeaconnodequota "github.com/attestantio/vouch/services/beaconnodequota/standard"
 	"github.com/attestantio/vouch/services/blockrelay"
-	standardblockrelay "github.com/attestantio/vouch/services/blockrelay/standard"
+	localblockrelay "github.com/attestantio/vouch/services/blockrelay/local"
 	"github.com/attestantio/vouch/services/cache"
 	standardcache "github.com/attestantio/vouch/services/cache/standard"
+	"github.com/attestantio/vouch/services/chainspec"
+	standardchainspec "github.com/attestantio/vouch/services/chainspec/standard"
 	"github.com/attestantio/vouch/services/chaintime"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/circuitbreaker"
+	standardcircuitbreaker "github.com/attestantio/vouch/services/circuitbreaker/standard"
 	standardcontroller "github.com/attestantio/vouch/services/controller/standard"
+	"github.com/attestantio/vouch/services/coordination"
+	"github.com/attestantio/vouch/services/doppelganger"
+	standarddoppelganger "github.com/attestantio/vouch/services/doppelganger/standard"
+	"github.com/attestantio/vouch/services/dutyblacklist"
+	standarddutyblacklist "github.com/attestantio/vouch/services/dutyblacklist/standard"
+	"github.com/attestantio/vouch/services/dutyevents"
+	natsdutyevents "github.com/attestantio/vouch/services/dutyevents/nats"
+	dutyproxyclient "github.com/attestantio/vouch/services/dutyproxy/client"
+	dutyproxyserver "github.com/attestantio/vouch/services/dutyproxy/server"
+	"github.com/attestantio/vouch/services/dutystore"
+	filedutystore "github.com/attestantio/vouch/services/dutystore/file"
+	standarddutystore "github.com/attestantio/vouch/services/dutystore/standard"
 	"github.com/attestantio/vouch/services/graffitiprovider"
 	dynamicgraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/dynamic"
+	overridegraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/override"
+	remotegraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/remote"
 	staticgraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/static"
+	standardkeymanagerapi "github.com/attestantio/vouch/services/keymanagerapi/standard"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	prometheusmetrics "github.com/attestantio/vouch/services/metrics/prometheus"
+	"github.com/attestantio/vouch/services/nodelatency"
+	standardnodelatency "github.com/attestantio/vouch/services/nodelatency/standard"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	standardnodemonitor "github.com/attestantio/vouch/services/nodemonitor/standard"
+	"github.com/attestantio/vouch/services/proposalaudit"
+	fileproposalaudit "github.com/attestantio/vouch/services/proposalaudit/file"
 	"github.com/attestantio/vouch/services/proposalpreparer"
 	standardproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/standard"
+	"github.com/attestantio/vouch/services/proposalreadiness"
+	standardproposalreadiness "github.com/attestantio/vouch/services/proposalreadiness/standard"
+	"github.com/attestantio/vouch/services/proposalrevenue"
+	standardproposalrevenue "github.com/attestantio/vouch/services/proposalrevenue/standard"
 	"github.com/attestantio/vouch/services/scheduler"
 	advancedscheduler "github.com/attestantio/vouch/services/scheduler/advanced"
+	monotonicscheduler "github.com/attestantio/vouch/services/scheduler/monotonic"
 	"github.com/attestantio/vouch/services/signer"
 	standardsigner "github.com/attestantio/vouch/services/signer/standard"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	standardslashingprotection "github.com/attestantio/vouch/services/slashingprotection/standard"
 	"github.com/attestantio/vouch/services/submitter"
 	immediatesubmitter "github.com/attestantio/vouch/services/submitter/immediate"
 	multinodesubmitter "github.com/attestantio/vouch/services/submitter/multinode"
+	publishingsubmitter "github.com/attestantio/vouch/services/submitter/publishing"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	standardsynccommitteeaggregator "github.com/attestantio/vouch/services/synccommitteeaggregator/standard"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
@@ -81,14 +123,11 @@ import (
 	bestattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/best"
 	firstattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/first"
 	majorityattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/majority"
+	prefetchattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/prefetch"
 	bestbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/best"
 	firstbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/first"
 	firstbeaconblockrootstrategy "github.com/attestantio/vouch/strategies/beaconblockroot/first"
 	majoritybeaconblockrootstrategy "github.com/attestantio/vouch/strategies/beaconblockroot/majority"
-	bestblindedbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/blindedbeaconblockproposal/best"
-	firstblindedbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/blindedbeaconblockproposal/first"
-	"github.com/attestantio/vouch/strategies/builderbid"
-	bestbuilderbidstrategy "github.com/attestantio/vouch/strategies/builderbid/best"
 	bestsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/best"
 	firstsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/first"
 	"github.com/attestantio/vouch/util"
@@ -110,6 +149,18 @@ import (
 // ReleaseVersion is the release version for the code.
 var ReleaseVersion = "1.8.0-beta.3"
 
+// shutdownCh receives internal requests for Vouch to shut down.
+var shutdownCh = make(chan string, 1)
+
+// requestShutdown requests that Vouch shut down, giving the reason.
+func requestShutdown(reason string) {
+	select {
+	case shutdownCh <- reason:
+	default:
+		// Shutdown already requested.
+	}
+}
+
 func main() {
 	exitCode := main2()
 	if exitCode != 0 {
@@ -153,6 +204,11 @@ func main2() int {
 		return 1
 	}
 
+	if err := initNetwork(); err != nil {
+		log.Error().Err(err).Msg("Failed to initialise network")
+		return 1
+	}
+
 	runtime.GOMAXPROCS(runtime.NumCPU() * 8)
 
 	if err := e2types.InitBLS(); err != nil {
@@ -160,7 +216,13 @@ func main2() int {
 		return 1
 	}
 
-	chainTime, controller, err := startServices(ctx, majordomo)
+	dirty, err := markRunning()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to mark vouch as running")
+		return 1
+	}
+
+	chainTime, controller, err := startServices(ctx, majordomo, dirty)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to initialise services")
 		return 1
@@ -168,10 +230,16 @@ func main2() int {
 	setReady(true)
 	log.Info().Msg("All services operational")
 
-	// Wait for signal.
+	handleMaintenanceSignal(ctx, controller)
+
+	// Wait for signal or internal request to shut down.
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-	<-sigCh
+	select {
+	case <-sigCh:
+	case reason := <-shutdownCh:
+		log.Warn().Str("reason", reason).Msg("Shutdown requested")
+	}
 	// Received a signal to stop, but don't do so until we have finished attesting for this slot.
 	slot := chainTime.CurrentSlot()
 	first := true
@@ -187,6 +255,7 @@ func main2() int {
 		time.Sleep(100 * time.Millisecond)
 	}
 
+	markStopped()
 	log.Info().Msg("Stopping vouch")
 	return 0
 }
@@ -201,6 +270,28 @@ func fetchConfig() error {
 	pflag.String("beacon-node-address", "", "Address on which to contact the beacon node")
 	pflag.Bool("version", false, "show Vouch version and exit")
 	pflag.String("proposer-config-check", "", "show the proposer configuration for the given public key and exit")
+	pflag.String("validator-registration-preview", "", "show the validator registrations that would be submitted for the given public key and exit")
+	pflag.Bool("fork-rehearsal", false, "rehearse the upcoming fork against mock data, report incompatibilities and exit")
+	pflag.Uint64("fork-rehearsal.epoch", 0, "the epoch of the fork to rehearse; defaults to the next scheduled fork")
+	pflag.String("handoff.validators", "", "majordomo URL to the public keys of the validators to hand off")
+	pflag.String("handoff.source.blacklist", "", "path to the duty blacklist of the instance handing off the validators")
+	pflag.String("handoff.target.blacklist", "", "path to the duty blacklist of the instance receiving the validators")
+	pflag.String("handoff.source.execution-config", "", "path to the execution configuration of the instance handing off the validators")
+	pflag.String("handoff.target.execution-config", "", "path to the execution configuration of the instance receiving the validators")
+	pflag.String("handoff.snapshot", "", "path to which to write the duty snapshot for the instance receiving the validators")
+	pflag.Uint64("handoff.epoch", 0, "the epoch at which the instance handing off the validators stops duties; defaults to the next epoch")
+	pflag.Uint64("handoff.confirmation-epochs", 2, "the number of epochs to wait for the instance receiving the validators to attest")
+	pflag.String("exit.validators", "", "majordomo URL to the public keys of the validators to exit")
+	pflag.Uint64("exit.epoch", 0, "the epoch from which the exits are valid; defaults to the current epoch")
+	pflag.String("exit.output", "", "directory in which to store the signed exits")
+	pflag.String("exit.passphrase", "", "majordomo URL to the passphrase with which stored exits are encrypted")
+	pflag.Bool("exit.broadcast", false, "broadcast the signed exits")
+	pflag.String("exit.input", "", "directory of previously stored exits to broadcast")
+	pflag.String("bls-to-execution-change.validators", "", "majordomo URL to the public keys of the validators to change")
+	pflag.String("bls-to-execution-change.execution-address", "", "the execution address to which to change withdrawal credentials")
+	pflag.String("bls-to-execution-change.withdrawal-keystores", "", "directory of EIP-2335 keystores holding the withdrawal keys")
+	pflag.String("bls-to-execution-change.withdrawal-passphrase", "", "majordomo URL to the passphrase of the withdrawal keystores")
+	pflag.Bool("bls-to-execution-change.dry-run", false, "check the withdrawal credentials without signing or broadcasting the changes")
 	pflag.Parse()
 	if err := viper.BindPFlags(pflag.CommandLine); err != nil {
 		return errors.Wrap(err, "failed to bind pflags to viper")
@@ -230,15 +321,67 @@ func fetchConfig() error {
 	viper.SetDefault("timeout", 2*time.Second)
 	viper.SetDefault("eth2client.timeout", 2*time.Minute)
 	viper.SetDefault("controller.max-proposal-delay", 0)
-	viper.SetDefault("controller.max-attestation-delay", 4*time.Second)
-	viper.SetDefault("controller.max-sync-committee-message-delay", 4*time.Second)
+	viper.SetDefault("dutyevents.nats.subject", "vouch.duties")
+	viper.SetDefault("controller.attestation-trigger", "block")
+	viper.SetDefault("controller.sync-committee-message-grace-period", 200*time.Millisecond)
 	viper.SetDefault("controller.attestation-aggregation-delay", 8*time.Second)
+	viper.SetDefault("controller.startup-reconciliation-epochs", 2)
+	viper.SetDefault("attestationaggregator.aggregate-completeness", 0.9)
+	viper.SetDefault("attestationaggregator.poll-interval", 500*time.Millisecond)
 	viper.SetDefault("controller.sync-committee-aggregation-delay", 8*time.Second)
+	viper.SetDefault("controller.duty-budget.wait", 1.5)
+	viper.SetDefault("controller.duty-budget.fetch", 1.0)
+	viper.SetDefault("controller.duty-budget.sign", 1.0)
+	viper.SetDefault("controller.duty-budget.submit", 1.0)
+	viper.SetDefault("controller.delay-tuning.window", 64)
+	viper.SetDefault("controller.delay-tuning.min-delay", 2*time.Second)
+	viper.SetDefault("chainspec.refresh-interval", 5*time.Minute)
+	viper.SetDefault("dutyblacklist.reload-interval", time.Minute)
+	viper.SetDefault("doppelganger.epochs", 2)
+	viper.SetDefault("attestationrebroadcast.window", 1)
+	viper.SetDefault("slashingprotection.path", "slashing-protection.json")
+	viper.SetDefault("accountmanager.failover.slashing-protection-path", "failover-slashing-protection.json")
+	viper.SetDefault("nodemonitor.probe-interval", 12*time.Second)
+	viper.SetDefault("dutyproxy.cache-ttl", 12*time.Second)
+	viper.SetDefault("beaconnodeset.check-interval", time.Minute)
+	viper.SetDefault("coordination.consul.key", "vouch/leader")
+	viper.SetDefault("coordination.session-ttl", 15*time.Second)
+	viper.SetDefault("coordination.lock-delay", 30*time.Second)
+	viper.SetDefault("partitioning.check-interval", 12*time.Second)
+	viper.SetDefault("partitioning.takeover-failures", 3)
+	viper.SetDefault("nodemonitor.threshold", 0.5)
+	viper.SetDefault("nodemonitor.min-peers", 16)
+	viper.SetDefault("nodemonitor.max-latency", time.Second)
+	viper.SetDefault("metrics.prometheus.push.job", "vouch")
+	viper.SetDefault("metrics.prometheus.push.interval", 15*time.Second)
 	viper.SetDefault("blockrelay.timeout", 1*time.Second)
 	viper.SetDefault("blockrelay.listen-address", "0.0.0.0:18550")
 	viper.SetDefault("blockrelay.fallback-gas-limit", uint64(30000000))
+	viper.SetDefault("blockrelay.fee-recipients.reload-interval", time.Minute)
+	viper.SetDefault("blockrelay.registration-retry-interval", 30*time.Second)
+	viper.SetDefault("blockrelay.registration-batch-size", 500)
+	viper.SetDefault("proposalaudit.max-size", int64(100*1024*1024))
+	viper.SetDefault("beaconblockproposer.builder-boost-factor", uint64(100))
+	viper.SetDefault("proposalaudit.max-files", 10)
+	viper.SetDefault("network.fallback-delay", 300*time.Millisecond)
+	viper.SetDefault("beaconnodequotas.threshold", 0.9)
+	viper.SetDefault("beaconnodequotas.check-interval", time.Minute)
+	viper.SetDefault("circuitbreaker.missed-slots", 8)
+	viper.SetDefault("circuitbreaker.window", 32)
+	viper.SetDefault("circuitbreaker.max-finality-distance", 4)
 	viper.SetDefault("accountmanager.dirk.timeout", 30*time.Second)
 	viper.SetDefault("strategies.beaconblockproposal.best.execution-payload-factor", float64(0.0005))
+	viper.SetDefault("strategies.aggregateattestation.best.verify-signatures", true)
+	viper.SetDefault("strategies.attestationdata.prefetch.offset", 2*time.Second)
+	viper.SetDefault("strategies.attestationdata.best.trust-half-life", time.Hour)
+	viper.SetDefault("safe-mode.flag-file", "vouch.running")
+	viper.SetDefault("submitter.proposal.publish-policy", "first")
+	viper.SetDefault("submitter.ssz", false)
+	viper.SetDefault("fork-guard.action", "continue")
+	viper.SetDefault("controller.duty-statements.dir", "duty-statements")
+	viper.SetDefault("dutystore.dir", "duty-store")
+	viper.SetDefault("controller.proposal-readiness-slots", 4)
+	viper.SetDefault("controller.accounts-refresh-slices", 1)
 
 	if err := viper.ReadInConfig(); err != nil {
 		switch {
@@ -279,6 +422,21 @@ func initProfiling() {
 	}
 }
 
+// initNetwork initialises the address family preferences for outgoing connections.
+func initNetwork() error {
+	preferences := make(map[string]util.AddressFamily)
+	for host, input := range viper.GetStringMapString("network.address-families") {
+		family, err := util.ParseAddressFamily(input)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid address family for %s", host))
+		}
+		preferences[host] = family
+		log.Trace().Str("host", host).Str("address_family", input).Msg("Address family preference")
+	}
+
+	return util.ConfigureDialer(viper.GetDuration("network.fallback-delay"), preferences)
+}
+
 func startClient(ctx context.Context, monitor metrics.Service) (eth2client.Service, error) {
 	log.Trace().Msg("Starting consensus client service")
 	var consensusClient eth2client.Service
@@ -297,12 +455,13 @@ func startClient(ctx context.Context, monitor metrics.Service) (eth2client.Servi
 
 func startServices(ctx context.Context,
 	majordomo majordomo.Service,
+	safeMode bool,
 ) (
 	chaintime.Service,
 	*standardcontroller.Service,
 	error,
 ) {
-	eth2Client, chainTime, monitor, err := startBasicServices(ctx)
+	eth2Client, chainSpec, chainTime, monitor, err := startBasicServices(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -338,22 +497,80 @@ func startServices(ctx context.Context,
 		return nil, nil, err
 	}
 
-	scheduler, cacheSvc, signerSvc, accountManager, err := startSharedServices(ctx, eth2Client, majordomo, chainTime, monitor)
+	// The coordinator is started before the signer, as the signer uses it to
+	// fence slashable signatures.
+	coordinator, err := startCoordinator(ctx, majordomo, monitor)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start coordinator")
+	}
+
+	scheduler, cacheSvc, signerSvc, accountManager, validatorsManager, err := startSharedServices(ctx, eth2Client, chainSpec, majordomo, chainTime, monitor, coordinator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if safeMode {
+		if err := runSafeMode(ctx, eth2Client, chainTime, accountManager); err != nil {
+			return nil, nil, errors.Wrap(err, "safe mode check failed")
+		}
+	}
+
+	beaconNodeQuotas, err := startBeaconNodeQuotas(ctx, monitor, scheduler)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodeMonitor, err := startNodeMonitor(ctx, monitor, scheduler, chainTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	beaconNodeReloader, err := newBeaconNodeReloader(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	submitter, err := selectSubmitterStrategy(ctx, monitor, eth2Client)
+	submitter, err := withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (submitter.Service, error) {
+		return selectSubmitterStrategy(ctx, monitor, eth2Client, beaconNodeQuotas, nodeMonitor)
+	}, dynamicSubmitter)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to select submitter")
 	}
 
-	blockRelay, err := startBlockRelay(ctx, majordomo, monitor, eth2Client, scheduler, chainTime, accountManager, signerSvc)
+	dutyEvents, err := startDutyEvents(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start duty events")
+	}
+	if dutyEvents != nil {
+		submitter, err = publishingsubmitter.New(ctx,
+			publishingsubmitter.WithLogLevel(util.LogLevel("submitter.publishing")),
+			publishingsubmitter.WithSubmitter(submitter),
+			publishingsubmitter.WithDutyEvents(dutyEvents),
+		)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to start publishing submitter")
+		}
+	}
+
+	blockRelay, err := startBlockRelay(ctx, majordomo, monitor, eth2Client, chainSpec, scheduler, chainTime, accountManager, signerSvc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodeLatency, err := standardnodelatency.New(ctx,
+		standardnodelatency.WithLogLevel(util.LogLevel("nodelatency")),
+		standardnodelatency.WithMonitor(monitor),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start node latency service")
+	}
+
+	dutyProxyClient, err := startDutyProxyClient(ctx, majordomo, eth2Client)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	beaconBlockProposer, attester, attestationAggregator, beaconCommitteeSubscriber, err := startSigningServices(ctx, majordomo, monitor, eth2Client, chainTime, cacheSvc, signerSvc, blockRelay, accountManager, submitter)
+	beaconBlockProposer, attester, attestationAggregator, beaconCommitteeSubscriber, err := startSigningServices(ctx, majordomo, monitor, eth2Client, chainSpec, chainTime, scheduler, cacheSvc, signerSvc, blockRelay, accountManager, submitter, nodeLatency, nodeMonitor, dutyProxyClient, beaconNodeReloader)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -362,7 +579,7 @@ func startServices(ctx context.Context,
 	var syncCommitteeMessenger synccommitteemessenger.Service
 	var syncCommitteeAggregator synccommitteeaggregator.Service
 	if altairCapable {
-		syncCommitteeSubscriber, syncCommitteeMessenger, syncCommitteeAggregator, err = startAltairServices(ctx, monitor, eth2Client, submitter, signerSvc, accountManager, chainTime, cacheSvc)
+		syncCommitteeSubscriber, syncCommitteeMessenger, syncCommitteeAggregator, err = startAltairServices(ctx, monitor, eth2Client, chainSpec, submitter, signerSvc, accountManager, chainTime, cacheSvc, nodeLatency, nodeMonitor, beaconNodeReloader)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -401,16 +618,56 @@ func startServices(ctx context.Context,
 		return nil, nil, errors.Wrap(err, "failed to fetch multiclient for controller")
 	}
 
+	excludedProposers, err := pubKeysFromConfig("beaconblockproposer.excluded-validators")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid excluded validators")
+	}
+
+	canaryValidators, err := pubKeysFromConfig("controller.canary-validators")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid canary validators")
+	}
+
+	dutyStatementKey, err := dutyStatementKeyFromConfig(ctx, majordomo)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid duty statement key")
+	}
+
+	proposalReadinessChecker, err := startProposalReadiness(ctx, monitor, chainTime, accountManager, signerSvc, blockRelay, nodeMonitor)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start proposal readiness checker")
+	}
+
+	// Duties are obtained from the duty proxy if configured.
+	proposerDutiesProvider := eth2Client.(eth2client.ProposerDutiesProvider)
+	attesterDutiesProvider := eth2Client.(eth2client.AttesterDutiesProvider)
+	syncCommitteeDutiesProvider := eth2Client.(eth2client.SyncCommitteeDutiesProvider)
+	if dutyProxyClient != nil {
+		proposerDutiesProvider = dutyProxyClient
+		attesterDutiesProvider = dutyProxyClient
+		syncCommitteeDutiesProvider = dutyProxyClient
+	}
+	// Duties are persisted across restarts if configured.
+	dutyStore, err := startDutyStore(ctx, chainTime, eth2Client, attesterDutiesProvider, proposerDutiesProvider, syncCommitteeDutiesProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dutyStore != nil {
+		proposerDutiesProvider = dutyStore
+		attesterDutiesProvider = dutyStore
+		syncCommitteeDutiesProvider = dutyStore
+	}
+
 	log.Trace().Msg("Starting controller")
 	controller, err := standardcontroller.New(ctx,
 		standardcontroller.WithLogLevel(util.LogLevel("controller")),
 		standardcontroller.WithMonitor(monitor.(metrics.ControllerMonitor)),
-		standardcontroller.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardcontroller.WithSpecProvider(chainSpec),
 		standardcontroller.WithChainTimeService(chainTime),
 		standardcontroller.WithWaitedForGenesis(waitedForGenesis),
-		standardcontroller.WithProposerDutiesProvider(eth2Client.(eth2client.ProposerDutiesProvider)),
-		standardcontroller.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
-		standardcontroller.WithSyncCommitteeDutiesProvider(eth2Client.(eth2client.SyncCommitteeDutiesProvider)),
+		standardcontroller.WithProposerDutiesProvider(proposerDutiesProvider),
+		standardcontroller.WithAttesterDutiesProvider(attesterDutiesProvider),
+		standardcontroller.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
 		standardcontroller.WithEventsProvider(eventsConsensusClient.(eth2client.EventsProvider)),
 		standardcontroller.WithScheduler(scheduler),
 		standardcontroller.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
@@ -428,13 +685,54 @@ func startServices(ctx context.Context,
 		standardcontroller.WithBlockToSlotSetter(cacheSvc.(cache.BlockRootToSlotSetter)),
 		standardcontroller.WithMaxProposalDelay(viper.GetDuration("controller.max-proposal-delay")),
 		standardcontroller.WithMaxAttestationDelay(viper.GetDuration("controller.max-attestation-delay")),
+		standardcontroller.WithAttestationTrigger(viper.GetString("controller.attestation-trigger")),
+		standardcontroller.WithMinAttestationDelay(viper.GetDuration("controller.min-attestation-delay")),
 		standardcontroller.WithAttestationAggregationDelay(viper.GetDuration("controller.attestation-aggregation-delay")),
+		standardcontroller.WithMinAttestationAggregationDelay(viper.GetDuration("controller.min-attestation-aggregation-delay")),
+		standardcontroller.WithStartupReconciliationEpochs(viper.GetUint64("controller.startup-reconciliation-epochs")),
 		standardcontroller.WithMaxSyncCommitteeMessageDelay(viper.GetDuration("controller.max-sync-committee-message-delay")),
+		standardcontroller.WithSyncCommitteeMessageGracePeriod(viper.GetDuration("controller.sync-committee-message-grace-period")),
 		standardcontroller.WithSyncCommitteeAggregationDelay(viper.GetDuration("controller.sync-committee-aggregation-delay")),
+		standardcontroller.WithDutyDeadline(viper.GetDuration("controller.duty-deadline")),
+		standardcontroller.WithDutyBudget(selectDutyBudget()),
+		standardcontroller.WithAttestationHeadWait(viper.GetDuration("controller.attestation-head-wait")),
+		standardcontroller.WithLogLateBlocks(viper.GetBool("controller.log-late-blocks")),
+		standardcontroller.WithDelayTuning(selectDelayTuning()),
+		standardcontroller.WithExcludedProposers(excludedProposers),
+		standardcontroller.WithProposalNotificationURL(viper.GetString("controller.proposal-notification-url")),
+		standardcontroller.WithDutyStatementKey(dutyStatementKey),
+		standardcontroller.WithDutyStatementDir(resolvePath(viper.GetString("controller.duty-statements.dir"))),
+		standardcontroller.WithProposalReadinessChecker(proposalReadinessChecker),
+		standardcontroller.WithProposalReadinessSlots(viper.GetUint64("controller.proposal-readiness-slots")),
+		standardcontroller.WithAccountsRefreshSlices(viper.GetUint64("controller.accounts-refresh-slices")),
+		standardcontroller.WithIdleAccountsRefreshInterval(viper.GetDuration("controller.idle-accounts-refresh-interval")),
+		standardcontroller.WithCanaryValidators(canaryValidators),
+		standardcontroller.WithDutyEvents(dutyEvents),
+		standardcontroller.WithMaintenanceProposalFreeSlots(viper.GetUint64("maintenance.proposal-free-slots")),
+		standardcontroller.WithCoordinator(coordinator),
 	)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to start controller service")
 	}
+	initDutyPlanEndpoint(chainTime, controller)
+
+	handleBeaconNodeRestarts(nodeMonitor, controller)
+
+	if err := startForkGuard(ctx, chainSpec, chainTime, controller); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start fork guard")
+	}
+
+	if err := startBeaconNodeSet(ctx, monitor, scheduler, beaconNodeReloader); err != nil {
+		return nil, nil, err
+	}
+
+	if err := startKeymanagerAPI(ctx, majordomo, eth2Client, accountManager, blockRelay); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start keymanager API")
+	}
+
+	if err := startAdminAPI(ctx, majordomo, chainTime, scheduler, accountManager, validatorsManager, controller, controller); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start admin API")
+	}
 
 	return chainTime, controller, nil
 }
@@ -442,6 +740,7 @@ func startServices(ctx context.Context,
 func startBasicServices(ctx context.Context,
 ) (
 	eth2client.Service,
+	chainspec.Service,
 	chaintime.Service,
 	metrics.Service,
 	error,
@@ -450,89 +749,159 @@ func startBasicServices(ctx context.Context,
 	// client can provide metrics.
 	monitor, err := startMonitor(ctx, nil, false)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, "failed to start metrics service")
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start metrics service")
 	}
 
 	eth2Client, err := startClient(ctx, monitor)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+	log.Trace().Msg("Starting chain specification service")
+	chainSpec, err := standardchainspec.New(ctx,
+		standardchainspec.WithLogLevel(util.LogLevel("chainspec")),
+		standardchainspec.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardchainspec.WithForkScheduleProvider(eth2Client.(eth2client.ForkScheduleProvider)),
+		standardchainspec.WithGenesisProvider(eth2Client.(eth2client.GenesisProvider)),
+		standardchainspec.WithRefreshInterval(viper.GetDuration("chainspec.refresh-interval")),
+		standardchainspec.WithForkEpochOverrides(forkEpochOverrides()),
+	)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start chain specification service")
 	}
+
 	log.Trace().Msg("Starting chain time service")
 	chainTime, err := standardchaintime.New(ctx,
 		standardchaintime.WithLogLevel(util.LogLevel("chaintime")),
 		standardchaintime.WithGenesisProvider(eth2Client.(eth2client.GenesisProvider)),
-		standardchaintime.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithSpecProvider(chainSpec),
 	)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, "failed to start chain time service")
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start chain time service")
 	}
 
 	log.Trace().Msg("Starting metrics service")
 	// Reinitialise monitor with chainTime service and an operational server.
 	monitor, err = startMonitor(ctx, chainTime, true)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, "failed to start metrics service")
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start metrics service")
 	}
 	if err := registerMetrics(monitor); err != nil {
-		return nil, nil, nil, errors.Wrap(err, "failed to register metrics")
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to register metrics")
 	}
 	setRelease(ReleaseVersion)
+	setBuildInfo(currentBuildInfo())
+	initVersionEndpoint()
 	setReady(false)
 
-	return eth2Client, chainTime, monitor, nil
+	return eth2Client, chainSpec, chainTime, monitor, nil
 }
 
 func startSharedServices(ctx context.Context,
 	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
 	majordomo majordomo.Service,
 	chainTime chaintime.Service,
 	monitor metrics.Service,
+	coordinator coordination.Service,
 ) (
 	scheduler.Service,
 	cache.Service,
 	signer.Service,
 	accountmanager.Service,
+	validatorsmanager.Service,
 	error,
 ) {
 	log.Trace().Msg("Selecting scheduler")
-	scheduler, err := selectScheduler(ctx, monitor)
+	scheduler, err := selectScheduler(ctx, monitor, chainTime)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to select scheduler")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to select scheduler")
 	}
 
 	log.Trace().Msg("Starting cache")
 	cacheSvc, err := startCache(ctx, monitor, chainTime, scheduler, eth2Client)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start cache")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start cache")
 	}
 
 	log.Trace().Msg("Starting validators manager")
 	validatorsManager, err := startValidatorsManager(ctx, monitor, eth2Client)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start validators manager")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start validators manager")
 	}
 
 	log.Trace().Msg("Starting signer")
-	signerSvc, err := startSigner(ctx, monitor, eth2Client)
+	signerSvc, err := startSigner(ctx, monitor, eth2Client, chainSpec, coordinator)
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start signer")
+	}
+
+	log.Trace().Msg("Starting duty blacklist")
+	dutyBlacklist, err := startDutyBlacklist(ctx, majordomo, scheduler)
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start duty blacklist")
+	}
+
+	log.Trace().Msg("Starting doppelgänger detection")
+	doppelgangerSvc, err := startDoppelganger(ctx, monitor, eth2Client, chainTime, scheduler, validatorsManager)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start signer")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start doppelgänger detection")
 	}
 
 	log.Trace().Msg("Starting account manager")
-	accountManager, err := startAccountManager(ctx, monitor, eth2Client, validatorsManager, majordomo, chainTime)
+	accountManager, err := startAccountManager(ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyblacklist.Combine(dutyBlacklist, doppelgangerSvc))
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start account manager")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start account manager")
 	}
+	accountManager, err = startPartitionedAccountManager(ctx, monitor, accountManager)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
+	setAdaptiveProcessConcurrency(ctx, chainTime, accountManager)
+
+	return scheduler, cacheSvc, signerSvc, accountManager, validatorsManager, nil
+}
 
-	return scheduler, cacheSvc, signerSvc, accountManager, nil
+// setAdaptiveProcessConcurrency sets the default process concurrency for the
+// services that have yet to start according to the number of validators and
+// available processors.  Explicitly configured values take precedence.
+func setAdaptiveProcessConcurrency(ctx context.Context,
+	chainTime chaintime.Service,
+	accountManager accountmanager.Service,
+) {
+	validatingAccountsProvider, isProvider := accountManager.(accountmanager.ValidatingAccountsProvider)
+	if !isProvider {
+		return
+	}
+	accounts, err := validatingAccountsProvider.ValidatingAccountsForEpoch(ctx, chainTime.CurrentEpoch())
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain validating accounts; not adapting process concurrency")
+		return
+	}
+
+	procs := runtime.GOMAXPROCS(-1)
+	concurrency := util.AdaptiveProcessConcurrency(len(accounts), procs)
+	viper.SetDefault("process-concurrency", concurrency)
+	log.Debug().
+		Int("validators", len(accounts)).
+		Int("procs", procs).
+		Int64("default_process_concurrency", concurrency).
+		Int64("process_concurrency", viper.GetInt64("process-concurrency")).
+		Msg("Set adaptive process concurrency")
 }
 
 func startProviders(ctx context.Context,
 	majordomo majordomo.Service,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
 	chainTime chaintime.Service,
+	scheduler scheduler.Service,
 	cache cache.Service,
+	nodeLatency nodelatency.Service,
+	nodeMonitor nodemonitor.Service,
+	dutyProxyClient *dutyproxyclient.Service,
+	beaconNodeReloader *beaconNodeReloader,
 ) (
 	graffitiprovider.Service,
 	eth2client.ProposalProvider,
@@ -548,25 +917,44 @@ func startProviders(ctx context.Context,
 	}
 
 	log.Trace().Msg("Selecting beacon block proposal provider")
-	beaconBlockProposalProvider, err := selectProposalProvider(ctx, monitor, eth2Client, chainTime, cache)
+	beaconBlockProposalProvider, err := withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (eth2client.ProposalProvider, error) {
+		return selectProposalProvider(ctx, monitor, eth2Client, chainSpec, chainTime, cache, nodeMonitor)
+	}, dynamicProposalProvider)
 	if err != nil {
 		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to select beacon block proposal provider")
 	}
 
 	log.Trace().Msg("Selecting blinded beacon block proposal provider")
-	blindedProposalProvider, err := selectBlindedProposalProvider(ctx, monitor, eth2Client, chainTime, cache)
+	blindedProposalProvider, err := startBlindedProposalProvider(ctx, monitor, eth2Client, chainSpec, chainTime, cache, nodeMonitor, beaconNodeReloader)
 	if err != nil {
 		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to select blinded beacon block proposal provider")
 	}
 
 	log.Trace().Msg("Selecting attestation data provider")
-	attestationDataProvider, err := selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cache)
+	var attestationDataProvider eth2client.AttestationDataProvider
+	if dutyProxyClient != nil {
+		// Attestation data is obtained from the duty proxy.
+		attestationDataProvider = dutyProxyClient
+	} else {
+		attestationDataProvider, err = withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (eth2client.AttestationDataProvider, error) {
+			return selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cache, nodeMonitor)
+		}, dynamicAttestationDataProvider)
+		if err != nil {
+			return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to select attestation data provider")
+		}
+	}
+	attestationDataProvider, err = startAttestationDataPrefetch(ctx, monitor, eth2Client, chainTime, scheduler, attestationDataProvider)
 	if err != nil {
-		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to select attestation data provider")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start attestation data prefetch")
+	}
+	if err := startDutyProxyServer(ctx, majordomo, monitor, eth2Client, attestationDataProvider); err != nil {
+		return nil, nil, nil, nil, nil, err
 	}
 
 	log.Trace().Msg("Selecting aggregate attestation provider")
-	aggregateAttestationProvider, err := selectAggregateAttestationProvider(ctx, monitor, eth2Client)
+	aggregateAttestationProvider, err := withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (eth2client.AggregateAttestationProvider, error) {
+		return selectAggregateAttestationProvider(ctx, monitor, eth2Client, chainSpec, nodeLatency, nodeMonitor)
+	}, dynamicAggregateAttestationProvider)
 	if err != nil {
 		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to select aggregate attestation provider")
 	}
@@ -577,11 +965,15 @@ func startProviders(ctx context.Context,
 func startAltairServices(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
 	submitterStrategy submitter.Service,
 	signerSvc signer.Service,
 	accountManager accountmanager.Service,
 	chainTime chaintime.Service,
 	cacheSvc cache.Service,
+	nodeLatency nodelatency.Service,
+	nodeMonitor nodemonitor.Service,
+	beaconNodeReloader *beaconNodeReloader,
 ) (
 	synccommitteesubscriber.Service,
 	synccommitteemessenger.Service,
@@ -599,13 +991,17 @@ func startAltairServices(ctx context.Context,
 	}
 
 	log.Trace().Msg("Selecting sync committee contribution provider")
-	syncCommitteeContributionProvider, err := selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client)
+	syncCommitteeContributionProvider, err := withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (eth2client.SyncCommitteeContributionProvider, error) {
+		return selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client, nodeLatency, nodeMonitor)
+	}, dynamicSyncCommitteeContributionProvider)
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "failed to select sync committee contribution provider")
 	}
 
 	log.Trace().Msg("Selecting beacon block root provider")
-	beaconBlockRootProvider, err := selectBeaconBlockRootProvider(ctx, monitor, eth2Client, cacheSvc)
+	beaconBlockRootProvider, err := withBeaconNodeReload(ctx, beaconNodeReloader, func(ctx context.Context) (eth2client.BeaconBlockRootProvider, error) {
+		return selectBeaconBlockRootProvider(ctx, monitor, eth2Client, cacheSvc, nodeMonitor)
+	}, dynamicBeaconBlockRootProvider)
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "failed to select beacon block root provider")
 	}
@@ -614,7 +1010,7 @@ func startAltairServices(ctx context.Context,
 	syncCommitteeAggregator, err := standardsynccommitteeaggregator.New(ctx,
 		standardsynccommitteeaggregator.WithLogLevel(util.LogLevel("synccommitteeaggregator")),
 		standardsynccommitteeaggregator.WithMonitor(monitor.(metrics.SyncCommitteeAggregationMonitor)),
-		standardsynccommitteeaggregator.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardsynccommitteeaggregator.WithSpecProvider(chainSpec),
 		standardsynccommitteeaggregator.WithBeaconBlockRootProvider(beaconBlockRootProvider),
 		standardsynccommitteeaggregator.WithContributionAndProofSigner(signerSvc.(signer.ContributionAndProofSigner)),
 		standardsynccommitteeaggregator.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
@@ -630,7 +1026,7 @@ func startAltairServices(ctx context.Context,
 		standardsynccommitteemessenger.WithLogLevel(util.LogLevel("synccommitteemessenger")),
 		standardsynccommitteemessenger.WithProcessConcurrency(viper.GetInt64("process-concurrency")),
 		standardsynccommitteemessenger.WithMonitor(monitor.(metrics.SyncCommitteeMessageMonitor)),
-		standardsynccommitteemessenger.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardsynccommitteemessenger.WithSpecProvider(chainSpec),
 		standardsynccommitteemessenger.WithChainTimeService(chainTime),
 		standardsynccommitteemessenger.WithSyncCommitteeAggregator(syncCommitteeAggregator),
 		standardsynccommitteemessenger.WithBeaconBlockRootProvider(beaconBlockRootProvider),
@@ -651,12 +1047,18 @@ func startSigningServices(ctx context.Context,
 	majordomo majordomo.Service,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
 	chainTime chaintime.Service,
+	scheduler scheduler.Service,
 	cacheSvc cache.Service,
 	signerSvc signer.Service,
 	blockRelay blockrelay.Service,
 	accountManager accountmanager.Service,
 	submitterStrategy submitter.Service,
+	nodeLatency nodelatency.Service,
+	nodeMonitor nodemonitor.Service,
+	dutyProxyClient *dutyproxyclient.Service,
+	beaconNodeReloader *beaconNodeReloader,
 ) (
 	beaconblockproposer.Service,
 	attester.Service,
@@ -664,17 +1066,49 @@ func startSigningServices(ctx context.Context,
 	beaconcommitteesubscriber.Service,
 	error,
 ) {
-	graffitiProvider, proposalProvider, blindedProposalProvider, attestationDataProvider, aggregateAttestationProvider, err := startProviders(ctx, majordomo, monitor, eth2Client, chainTime, cacheSvc)
+	graffitiProvider, proposalProvider, blindedProposalProvider, attestationDataProvider, aggregateAttestationProvider, err := startProviders(ctx, majordomo, monitor, eth2Client, chainSpec, chainTime, scheduler, cacheSvc, nodeLatency, nodeMonitor, dutyProxyClient, beaconNodeReloader)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
 
+	// The block relay is only a block auctioneer if relays are enabled.
+	var blockAuctioneer blockauctioneer.BlockAuctioneer
+	if auctioneer, isAuctioneer := blockRelay.(blockauctioneer.BlockAuctioneer); isAuctioneer {
+		blockAuctioneer = auctioneer
+	}
+
+	var circuitBreaker circuitbreaker.Service
+	if blockAuctioneer != nil {
+		circuitBreaker, err = startCircuitBreaker(ctx, monitor, eth2Client, chainTime, scheduler)
+		if err != nil {
+			return nil, nil, nil, nil, errors.Wrap(err, "failed to start circuit breaker")
+		}
+	}
+
+	slashingProtection, err := startSlashingProtection(ctx, monitor, eth2Client)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start slashing protection")
+	}
+
+	proposalRevenue, err := startProposalRevenue(ctx, monitor, chainTime, scheduler)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start proposal revenue service")
+	}
+
+	proposalAudit, err := startProposalAudit(ctx)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start proposal audit service")
+	}
+
 	beaconBlockProposer, err := standardbeaconblockproposer.New(ctx,
 		standardbeaconblockproposer.WithLogLevel(util.LogLevel("beaconblockproposer")),
 		standardbeaconblockproposer.WithChainTime(chainTime),
 		standardbeaconblockproposer.WithProposalDataProvider(proposalProvider),
 		standardbeaconblockproposer.WithBlindedProposalDataProvider(blindedProposalProvider),
-		standardbeaconblockproposer.WithBlockAuctioneer(blockRelay.(blockauctioneer.BlockAuctioneer)),
+		standardbeaconblockproposer.WithBlockAuctioneer(blockAuctioneer),
+		standardbeaconblockproposer.WithCircuitBreaker(circuitBreaker),
+		standardbeaconblockproposer.WithProposalRevenue(proposalRevenue),
+		standardbeaconblockproposer.WithProposalAudit(proposalAudit),
 		standardbeaconblockproposer.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardbeaconblockproposer.WithExecutionChainHeadProvider(cacheSvc.(cache.ExecutionChainHeadProvider)),
 		standardbeaconblockproposer.WithGraffitiProvider(graffitiProvider),
@@ -684,22 +1118,40 @@ func startSigningServices(ctx context.Context,
 		standardbeaconblockproposer.WithBeaconBlockSigner(signerSvc.(signer.BeaconBlockSigner)),
 		standardbeaconblockproposer.WithBlobSidecarSigner(signerSvc.(signer.BlobSidecarSigner)),
 		standardbeaconblockproposer.WithUnblindFromAllRelays(viper.GetBool("beaconblockproposer.unblind-from-all-relays")),
+		standardbeaconblockproposer.WithBuilderBoostFactor(viper.GetUint64("beaconblockproposer.builder-boost-factor")),
+		standardbeaconblockproposer.WithLocalGraffiti(viper.GetString("beaconblockproposer.local-graffiti")),
+		standardbeaconblockproposer.WithSlashingProtection(slashingProtection),
 	)
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon block proposer service")
 	}
 
+	attestationRebroadcaster, err := startAttestationRebroadcaster(ctx, monitor, eth2Client, chainTime)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to start attestation rebroadcaster")
+	}
+
+	retryAttestationsSubmitter, err := selectRetryAttestationsSubmitter(ctx, monitor)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	log.Trace().Msg("Starting attester")
 	attester, err := standardattester.New(ctx,
 		standardattester.WithLogLevel(util.LogLevel("attester")),
 		standardattester.WithProcessConcurrency(util.ProcessConcurrency("attester")),
 		standardattester.WithChainTimeService(chainTime),
-		standardattester.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardattester.WithSpecProvider(chainSpec),
 		standardattester.WithAttestationDataProvider(attestationDataProvider),
 		standardattester.WithAttestationsSubmitter(submitterStrategy.(submitter.AttestationsSubmitter)),
 		standardattester.WithMonitor(monitor.(metrics.AttestationMonitor)),
 		standardattester.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardattester.WithBeaconAttestationsSigner(signerSvc.(signer.BeaconAttestationsSigner)),
+		standardattester.WithAttestationRebroadcaster(attestationRebroadcaster),
+		standardattester.WithSlashingProtection(slashingProtection),
+		standardattester.WithSigningChunkSize(viper.GetInt("attester.signing-chunk-size")),
+		standardattester.WithSigningTimeout(viper.GetDuration("attester.signing-timeout")),
+		standardattester.WithRetryAttestationsSubmitter(retryAttestationsSubmitter),
 	)
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "failed to start attester service")
@@ -714,19 +1166,26 @@ func startSigningServices(ctx context.Context,
 		standardattestationaggregator.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardattestationaggregator.WithSlotSelectionSigner(signerSvc.(signer.SlotSelectionSigner)),
 		standardattestationaggregator.WithAggregateAndProofSigner(signerSvc.(signer.AggregateAndProofSigner)),
-		standardattestationaggregator.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardattestationaggregator.WithSpecProvider(chainSpec),
+		standardattestationaggregator.WithAggregateCompleteness(viper.GetFloat64("attestationaggregator.aggregate-completeness")),
+		standardattestationaggregator.WithPollInterval(viper.GetDuration("attestationaggregator.poll-interval")),
 	)
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon attestation aggregator service")
 	}
 
+	attesterDutiesProvider := eth2Client.(eth2client.AttesterDutiesProvider)
+	if dutyProxyClient != nil {
+		attesterDutiesProvider = dutyProxyClient
+	}
+
 	log.Trace().Msg("Starting beacon committee subscriber service")
 	beaconCommitteeSubscriber, err := standardbeaconcommitteesubscriber.New(ctx,
 		standardbeaconcommitteesubscriber.WithLogLevel(util.LogLevel("beaconcommiteesubscriber")),
 		standardbeaconcommitteesubscriber.WithProcessConcurrency(util.ProcessConcurrency("beaconcommitteesubscriber")),
 		standardbeaconcommitteesubscriber.WithMonitor(monitor.(metrics.BeaconCommitteeSubscriptionMonitor)),
 		standardbeaconcommitteesubscriber.WithChainTimeService(chainTime),
-		standardbeaconcommitteesubscriber.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
+		standardbeaconcommitteesubscriber.WithAttesterDutiesProvider(attesterDutiesProvider),
 		standardbeaconcommitteesubscriber.WithAttestationAggregator(attestationAggregator),
 		standardbeaconcommitteesubscriber.WithBeaconCommitteeSubmitter(submitterStrategy.(submitter.BeaconCommitteeSubscriptionsSubmitter)),
 	)
@@ -852,18 +1311,25 @@ func startMonitor(ctx context.Context,
 ) {
 	log.Trace().Msg("Starting metrics service")
 	var monitor metrics.Service
-	if viper.Get("metrics.prometheus.listen-address") != nil {
+	if viper.GetString("metrics.prometheus.listen-address") != "" || viper.GetString("metrics.prometheus.push.url") != "" {
 		var err error
 		monitor, err = prometheusmetrics.New(ctx,
 			prometheusmetrics.WithLogLevel(util.LogLevel("metrics.prometheus")),
 			prometheusmetrics.WithAddress(viper.GetString("metrics.prometheus.listen-address")),
 			prometheusmetrics.WithChainTime(chainTime),
 			prometheusmetrics.WithCreateServer(createServer),
+			prometheusmetrics.WithDerivedMetrics(derivedMetrics()),
+			prometheusmetrics.WithFederationPeers(viper.GetStringSlice("metrics.prometheus.federation.peers")),
+			prometheusmetrics.WithFederationTimeout(util.Timeout("metrics.prometheus.federation")),
+			prometheusmetrics.WithPushURL(viper.GetString("metrics.prometheus.push.url")),
+			prometheusmetrics.WithPushJob(viper.GetString("metrics.prometheus.push.job")),
+			prometheusmetrics.WithPushInstance(viper.GetString("metrics.prometheus.push.instance")),
+			prometheusmetrics.WithPushInterval(viper.GetDuration("metrics.prometheus.push.interval")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start prometheus metrics service")
 		}
-		log.Info().Str("listen_address", viper.GetString("metrics.prometheus.listen-address")).Msg("Started prometheus metrics service")
+		log.Info().Str("listen_address", viper.GetString("metrics.prometheus.listen-address")).Str("push_url", viper.GetString("metrics.prometheus.push.url")).Msg("Started prometheus metrics service")
 	} else {
 		log.Debug().Msg("No metrics service supplied; monitor not starting")
 		monitor = nullmetrics.New(ctx)
@@ -871,19 +1337,72 @@ func startMonitor(ctx context.Context,
 	return monitor, nil
 }
 
+// forkEpochOverrides obtains the operator-defined fork epoch overrides from configuration.
+func forkEpochOverrides() map[string]phase0.Epoch {
+	res := make(map[string]phase0.Epoch)
+	for fork := range viper.GetStringMap("chainspec.fork-epochs") {
+		res[fork] = phase0.Epoch(viper.GetUint64(fmt.Sprintf("chainspec.fork-epochs.%s", fork)))
+	}
+
+	return res
+}
+
+// domainProvider returns the provider of signature domains.  If fork epochs
+// have been overridden then domains are calculated by the chain specification
+// service, as the beacon node is unaware of the overrides.
+func domainProvider(eth2Client eth2client.Service, chainSpec chainspec.Service) eth2client.DomainProvider {
+	if len(forkEpochOverrides()) > 0 {
+		if provider, isProvider := chainSpec.(eth2client.DomainProvider); isProvider {
+			return provider
+		}
+	}
+
+	return eth2Client.(eth2client.DomainProvider)
+}
+
+// derivedMetrics obtains the operator-defined derived metrics from configuration.
+func derivedMetrics() []*prometheusmetrics.DerivedMetric {
+	names := make([]string, 0)
+	for name := range viper.GetStringMap("metrics.prometheus.derived") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := make([]*prometheusmetrics.DerivedMetric, 0, len(names))
+	for _, name := range names {
+		res = append(res, &prometheusmetrics.DerivedMetric{
+			Name:       name,
+			Help:       viper.GetString(fmt.Sprintf("metrics.prometheus.derived.%s.help", name)),
+			Expression: viper.GetString(fmt.Sprintf("metrics.prometheus.derived.%s.expression", name)),
+		})
+	}
+
+	return res
+}
+
 // selectScheduler selects the appropriate scheduler given user input.
-func selectScheduler(ctx context.Context, monitor metrics.Service) (scheduler.Service, error) {
+func selectScheduler(ctx context.Context, monitor metrics.Service, chainTime chaintime.Service) (scheduler.Service, error) {
 	var scheduler scheduler.Service
 	var err error
 	switch viper.GetString("scheduler.style") {
 	case "basic":
 		log.Warn().Msg("Basic scheduler is no longer available; defaulting to advanced scheduler.  To avoid this message in future please change your scheduler type to 'advanced'")
+		recordStrategy("scheduler", "advanced")
 		scheduler, err = advancedscheduler.New(ctx,
 			advancedscheduler.WithLogLevel(util.LogLevel("scheduler.advanced")),
 			advancedscheduler.WithMonitor(monitor.(metrics.SchedulerMonitor)),
 		)
+	case "monotonic":
+		log.Info().Msg("Starting monotonic scheduler")
+		recordStrategy("scheduler", "monotonic")
+		scheduler, err = monotonicscheduler.New(ctx,
+			monotonicscheduler.WithLogLevel(util.LogLevel("scheduler.monotonic")),
+			monotonicscheduler.WithMonitor(monitor.(metrics.SchedulerMonitor)),
+			monotonicscheduler.WithChainTime(chainTime),
+		)
 	default:
 		log.Info().Msg("Starting advanced scheduler")
+		recordStrategy("scheduler", "advanced")
 		scheduler, err = advancedscheduler.New(ctx,
 			advancedscheduler.WithLogLevel(util.LogLevel("scheduler.advanced")),
 			advancedscheduler.WithMonitor(monitor.(metrics.SchedulerMonitor)),
@@ -919,21 +1438,73 @@ func startCache(ctx context.Context,
 
 // startGraffitiProvider starts the appropriate graffiti provider given user input.
 func startGraffitiProvider(ctx context.Context, majordomo majordomo.Service) (graffitiprovider.Service, error) {
+	var provider graffitiprovider.Service
+	var err error
 	switch {
+	case viper.Get("graffiti.remote") != nil:
+		log.Info().Msg("Starting remote graffiti provider")
+		params := []remotegraffitiprovider.Parameter{
+			remotegraffitiprovider.WithLogLevel(util.LogLevel("graffiti.remote")),
+			remotegraffitiprovider.WithURL(viper.GetString("graffiti.remote.url")),
+			remotegraffitiprovider.WithFallback([]byte(viper.GetString("graffiti.remote.fallback"))),
+		}
+		// Graffiti is obtained whilst proposing, so the general timeout is not used.
+		if viper.GetDuration("graffiti.remote.timeout") != 0 {
+			params = append(params, remotegraffitiprovider.WithTimeout(viper.GetDuration("graffiti.remote.timeout")))
+		}
+		if viper.GetDuration("graffiti.remote.ttl") != 0 {
+			params = append(params, remotegraffitiprovider.WithTTL(viper.GetDuration("graffiti.remote.ttl")))
+		}
+		provider, err = remotegraffitiprovider.New(ctx, params...)
+		if err != nil {
+			return nil, err
+		}
 	case viper.Get("graffiti.dynamic") != nil:
 		log.Info().Msg("Starting dynamic graffiti provider")
-		return dynamicgraffitiprovider.New(ctx,
+		var selection dynamicgraffitiprovider.Selection
+		selection, err = dynamicgraffitiprovider.ParseSelection(viper.GetString("graffiti.dynamic.selection"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid dynamic graffiti selection")
+		}
+		provider, err = dynamicgraffitiprovider.New(ctx,
 			dynamicgraffitiprovider.WithMajordomo(majordomo),
 			dynamicgraffitiprovider.WithLogLevel(util.LogLevel("graffiti.dynamic")),
 			dynamicgraffitiprovider.WithLocation(viper.GetString("graffiti.dynamic.location")),
+			dynamicgraffitiprovider.WithSelection(selection),
 		)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		log.Info().Msg("Starting static graffiti provider")
-		return staticgraffitiprovider.New(ctx,
+		provider, err = staticgraffitiprovider.New(ctx,
 			staticgraffitiprovider.WithLogLevel(util.LogLevel("graffiti.static")),
 			staticgraffitiprovider.WithGraffiti([]byte(viper.GetString("graffiti.static.value"))),
 		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	validatorGraffiti := viper.GetStringMapString("graffiti.validators")
+	if len(validatorGraffiti) == 0 {
+		return provider, nil
 	}
+	overrides := make(map[phase0.ValidatorIndex][]byte, len(validatorGraffiti))
+	for validatorIndexStr, graffiti := range validatorGraffiti {
+		validatorIndex, err := strconv.ParseUint(validatorIndexStr, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid validator index %s for graffiti", validatorIndexStr))
+		}
+		overrides[phase0.ValidatorIndex(validatorIndex)] = []byte(graffiti)
+	}
+	log.Info().Int("validators", len(overrides)).Msg("Starting graffiti overrides")
+
+	return overridegraffitiprovider.New(ctx,
+		overridegraffitiprovider.WithLogLevel(util.LogLevel("graffiti.override")),
+		overridegraffitiprovider.WithOverrides(overrides),
+		overridegraffitiprovider.WithProvider(provider),
+	)
 }
 
 // startValidatorsManager starts the appropriate validators manager given user input.
@@ -955,13 +1526,14 @@ func startValidatorsManager(ctx context.Context, monitor metrics.Service, eth2Cl
 	return validatorsManager, nil
 }
 
-func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service) (signer.Service, error) {
+func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainSpec chainspec.Service, coordinator coordination.Service) (signer.Service, error) {
 	signer, err := standardsigner.New(ctx,
 		standardsigner.WithLogLevel(util.LogLevel("signer")),
 		standardsigner.WithMonitor(monitor.(metrics.SignerMonitor)),
 		standardsigner.WithClientMonitor(monitor.(metrics.ClientMonitor)),
-		standardsigner.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
-		standardsigner.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
+		standardsigner.WithSpecProvider(chainSpec),
+		standardsigner.WithDomainProvider(domainProvider(eth2Client, chainSpec)),
+		standardsigner.WithCoordinator(coordinator),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to start signer provider service")
@@ -969,104 +1541,558 @@ func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2cl
 	return signer, nil
 }
 
-// startAccountManager starts the appropriate account manager given user input.
-func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service) (accountmanager.Service, error) {
-	if len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0 &&
-		len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0 {
-		return nil, errors.New("multiple account managers configured; Vouch only supports a single account manager")
+// startProposalReadiness starts the proposal readiness checker, if enabled.
+func startProposalReadiness(ctx context.Context,
+	monitor metrics.Service,
+	chainTime chaintime.Service,
+	accountManager accountmanager.Service,
+	signerSvc signer.Service,
+	blockRelay blockrelay.Service,
+	nodeMonitor nodemonitor.Service,
+) (
+	proposalreadiness.Service,
+	error,
+) {
+	if viper.GetUint64("controller.proposal-readiness-slots") == 0 {
+		log.Debug().Msg("Proposal readiness checks disabled")
+		return nil, nil
+	}
+
+	checker, err := standardproposalreadiness.New(ctx,
+		standardproposalreadiness.WithLogLevel(util.LogLevel("proposalreadiness")),
+		standardproposalreadiness.WithMonitor(monitor),
+		standardproposalreadiness.WithChainTime(chainTime),
+		standardproposalreadiness.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
+		standardproposalreadiness.WithRANDAORevealSigner(signerSvc.(signer.RANDAORevealSigner)),
+		standardproposalreadiness.WithExecutionConfigProvider(blockRelay.(blockrelay.ExecutionConfigProvider)),
+		standardproposalreadiness.WithNodeMonitor(nodeMonitor),
+		standardproposalreadiness.WithBeaconNodeAddresses(util.BeaconNodeAddressesForProposing()),
+		standardproposalreadiness.WithTimeout(util.Timeout("proposalreadiness")),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	var accountManager accountmanager.Service
-	if len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0 {
-		log.Info().Msg("Starting dirk account manager")
-		certPEMBlock, err := majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.client-cert"))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to obtain server certificate")
-		}
-		keyPEMBlock, err := majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.client-key"))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to obtain server key")
-		}
-		var caPEMBlock []byte
-		if viper.GetString("accountmanager.dirk.ca-cert") != "" {
-			caPEMBlock, err = majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.ca-cert"))
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to obtain client CA certificate")
-			}
-		}
-		accountManager, err = dirkaccountmanager.New(ctx,
-			dirkaccountmanager.WithLogLevel(util.LogLevel("accountmanager.dirk")),
-			dirkaccountmanager.WithMonitor(monitor.(metrics.AccountManagerMonitor)),
-			dirkaccountmanager.WithTimeout(util.Timeout("accountmanager.dirk")),
-			dirkaccountmanager.WithClientMonitor(monitor.(metrics.ClientMonitor)),
-			dirkaccountmanager.WithProcessConcurrency(util.ProcessConcurrency("accountmanager.dirk")),
-			dirkaccountmanager.WithValidatorsManager(validatorsManager),
-			dirkaccountmanager.WithEndpoints(viper.GetStringSlice("accountmanager.dirk.endpoints")),
-			dirkaccountmanager.WithAccountPaths(viper.GetStringSlice("accountmanager.dirk.accounts")),
-			dirkaccountmanager.WithClientCert(certPEMBlock),
-			dirkaccountmanager.WithClientKey(keyPEMBlock),
-			dirkaccountmanager.WithCACert(caPEMBlock),
-			dirkaccountmanager.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
-			dirkaccountmanager.WithFarFutureEpochProvider(eth2Client.(eth2client.FarFutureEpochProvider)),
-			dirkaccountmanager.WithCurrentEpochProvider(chainTime),
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to start dirk account manager service")
-		}
-		return accountManager, nil
+	return checker, nil
+}
+
+// subscriptionBeaconNodeAddresses returns the addresses of the beacon nodes
+// to which subscriptions are submitted.
+func subscriptionBeaconNodeAddresses() []string {
+	return append(util.BeaconNodeAddresses("submitter.beaconcommitteesubscription.multinode"),
+		util.BeaconNodeAddresses("submitter.synccommitteesubscription.multinode")...)
+}
+
+// handleBeaconNodeRestarts re-issues subscriptions to beacon nodes that restart.
+func handleBeaconNodeRestarts(nodeMonitor nodemonitor.Service,
+	controller *standardcontroller.Service,
+) {
+	restartNotifier, isNotifier := nodeMonitor.(nodemonitor.RestartNotifier)
+	if !isNotifier {
+		return
 	}
 
-	if len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0 {
-		log.Info().Msg("Starting wallet account manager")
-		var err error
-		passphrases := make([][]byte, 0)
-		for _, passphraseURL := range viper.GetStringSlice("accountmanager.wallet.passphrases") {
-			passphrase, err := majordomo.Fetch(ctx, passphraseURL)
-			if err != nil {
-				log.Error().Err(err).Str("url", string(passphrase)).Msg("failed to obtain passphrase")
-				continue
-			}
-			passphrases = append(passphrases, passphrase)
-		}
-		if len(passphrases) == 0 {
-			return nil, errors.New("no passphrases for wallet supplied")
-		}
-		accountManager, err = walletaccountmanager.New(ctx,
-			walletaccountmanager.WithLogLevel(util.LogLevel("accountmanager.wallet")),
-			walletaccountmanager.WithMonitor(monitor.(metrics.AccountManagerMonitor)),
-			walletaccountmanager.WithProcessConcurrency(util.ProcessConcurrency("accountmanager.wallet")),
-			walletaccountmanager.WithValidatorsManager(validatorsManager),
-			walletaccountmanager.WithAccountPaths(viper.GetStringSlice("accountmanager.wallet.accounts")),
-			walletaccountmanager.WithPassphrases(passphrases),
-			walletaccountmanager.WithLocations(viper.GetStringSlice("accountmanager.wallet.locations")),
-			walletaccountmanager.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
-			walletaccountmanager.WithFarFutureEpochProvider(eth2Client.(eth2client.FarFutureEpochProvider)),
-			walletaccountmanager.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
-			walletaccountmanager.WithCurrentEpochProvider(chainTime),
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to start wallet account manager service")
+	addresses := make(map[string]struct{})
+	for _, address := range subscriptionBeaconNodeAddresses() {
+		addresses[address] = struct{}{}
+	}
+	restartNotifier.AddRestartHandler(func(ctx context.Context, address string) {
+		if _, exists := addresses[address]; exists {
+			controller.HandleBeaconNodeRestart(ctx, address)
 		}
-		return accountManager, nil
+	})
+}
+
+// startKeymanagerAPI starts the keymanager API server if configured.
+func startKeymanagerAPI(ctx context.Context,
+	majordomo majordomo.Service,
+	eth2Client eth2client.Service,
+	accountManager accountmanager.Service,
+	blockRelay blockrelay.Service,
+) error {
+	if viper.GetString("keymanagerapi.listen-address") == "" {
+		return nil
 	}
 
-	return nil, errors.New("no account manager defined")
+	keystoreManager, isManager := accountManager.(accountmanager.KeystoreManager)
+	if !isManager {
+		return errors.New("account manager does not support managing keystores")
+	}
+	proposerConfigOverrider, isOverrider := blockRelay.(blockrelay.ProposerConfigOverrider)
+	if !isOverrider {
+		return errors.New("block relay does not support overriding proposer configuration")
+	}
+	if viper.GetString("keymanagerapi.bearer-token") == "" {
+		return errors.New("no bearer token specified")
+	}
+	bearerToken, err := majordomo.Fetch(ctx, viper.GetString("keymanagerapi.bearer-token"))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain bearer token")
+	}
+
+	log.Trace().Msg("Starting keymanager API")
+	_, err = standardkeymanagerapi.New(ctx,
+		standardkeymanagerapi.WithLogLevel(util.LogLevel("keymanagerapi")),
+		standardkeymanagerapi.WithListenAddress(viper.GetString("keymanagerapi.listen-address")),
+		standardkeymanagerapi.WithBearerToken(strings.TrimSpace(string(bearerToken))),
+		standardkeymanagerapi.WithGenesisProvider(eth2Client.(eth2client.GenesisProvider)),
+		standardkeymanagerapi.WithAccountsProvider(accountManager.(accountmanager.AccountsProvider)),
+		standardkeymanagerapi.WithKeystoreManager(keystoreManager),
+		standardkeymanagerapi.WithExecutionConfigProvider(blockRelay.(blockrelay.ExecutionConfigProvider)),
+		standardkeymanagerapi.WithProposerConfigOverrider(proposerConfigOverrider),
+	)
+
+	return err
 }
 
-// selectAttestationDataProvider selects the appropriate attestation data provider given user input.
-func selectAttestationDataProvider(ctx context.Context,
+// startDutyBlacklist starts the duty blacklist if configured.
+func startDutyBlacklist(ctx context.Context,
+	majordomo majordomo.Service,
+	scheduler scheduler.Service,
+) (
+	dutyblacklist.Service,
+	error,
+) {
+	if viper.GetString("dutyblacklist.location") == "" {
+		return nil, nil
+	}
+
+	dutyBlacklist, err := standarddutyblacklist.New(ctx,
+		standarddutyblacklist.WithLogLevel(util.LogLevel("dutyblacklist")),
+		standarddutyblacklist.WithMajordomo(majordomo),
+		standarddutyblacklist.WithScheduler(scheduler),
+		standarddutyblacklist.WithLocation(viper.GetString("dutyblacklist.location")),
+		standarddutyblacklist.WithReloadInterval(viper.GetDuration("dutyblacklist.reload-interval")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("location", viper.GetString("dutyblacklist.location")).Msg("Started duty blacklist")
+
+	return dutyBlacklist, nil
+}
+
+// selectRetryAttestationsSubmitter selects the attestations submitter used to
+// retry rejected attestations, if any.
+func selectRetryAttestationsSubmitter(ctx context.Context,
+	monitor metrics.Service,
+) (
+	eth2client.AttestationsSubmitter,
+	error,
+) {
+	addresses := viper.GetStringSlice("attester.retry.beacon-node-addresses")
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	client, err := fetchMultiClient(ctx, monitor, addresses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch client for attestation retries")
+	}
+
+	return client.(eth2client.AttestationsSubmitter), nil
+}
+
+// startAttestationRebroadcaster starts the attestation rebroadcaster if configured.
+func startAttestationRebroadcaster(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
 	chainTime chaintime.Service,
-	cacheSvc cache.Service,
-) (eth2client.AttestationDataProvider, error) {
-	var attestationDataProvider eth2client.AttestationDataProvider
-	var err error
-	switch viper.GetString("strategies.attestationdata.style") {
-	case "best":
-		log.Info().Msg("Starting best attestation data strategy")
-		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
-		for _, address := range util.BeaconNodeAddresses("strategies.attestationdata.best") {
+) (
+	attestationrebroadcaster.Service,
+	error,
+) {
+	addresses := viper.GetStringSlice("attestationrebroadcast.beacon-node-addresses")
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	submitters := make(map[string]eth2client.AttestationsSubmitter, len(addresses))
+	for _, address := range addresses {
+		client, err := fetchClient(ctx, monitor, address)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for attestation rebroadcaster", address))
+		}
+		submitters[address] = client.(eth2client.AttestationsSubmitter)
+	}
+
+	attestationRebroadcaster, err := standardattestationrebroadcaster.New(ctx,
+		standardattestationrebroadcaster.WithLogLevel(util.LogLevel("attestationrebroadcast")),
+		standardattestationrebroadcaster.WithMonitor(monitor),
+		standardattestationrebroadcaster.WithChainTime(chainTime),
+		standardattestationrebroadcaster.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
+		standardattestationrebroadcaster.WithAttestationsSubmitters(submitters),
+		standardattestationrebroadcaster.WithWindow(viper.GetUint64("attestationrebroadcast.window")),
+		standardattestationrebroadcaster.WithTimeout(util.Timeout("attestationrebroadcast")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Strs("beacon_node_addresses", addresses).Msg("Started attestation rebroadcaster")
+
+	return attestationRebroadcaster, nil
+}
+
+// startCircuitBreaker starts the circuit breaker if enabled.
+func startCircuitBreaker(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+) (
+	circuitbreaker.Service,
+	error,
+) {
+	if !viper.GetBool("circuitbreaker.enable") {
+		return nil, nil
+	}
+
+	circuitBreaker, err := standardcircuitbreaker.New(ctx,
+		standardcircuitbreaker.WithLogLevel(util.LogLevel("circuitbreaker")),
+		standardcircuitbreaker.WithMonitor(monitor),
+		standardcircuitbreaker.WithScheduler(scheduler),
+		standardcircuitbreaker.WithChainTime(chainTime),
+		standardcircuitbreaker.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
+		standardcircuitbreaker.WithFinalityProvider(eth2Client.(eth2client.FinalityProvider)),
+		standardcircuitbreaker.WithMissedSlots(viper.GetUint64("circuitbreaker.missed-slots")),
+		standardcircuitbreaker.WithWindow(viper.GetUint64("circuitbreaker.window")),
+		standardcircuitbreaker.WithMaxFinalityDistance(viper.GetUint64("circuitbreaker.max-finality-distance")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Msg("Started circuit breaker")
+
+	return circuitBreaker, nil
+}
+
+// startProposalRevenue starts the proposal revenue service if configured.
+func startProposalRevenue(ctx context.Context,
+	monitor metrics.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+) (
+	proposalrevenue.Service,
+	error,
+) {
+	if viper.GetString("proposalrevenue.execution-address") == "" {
+		return nil, nil
+	}
+
+	proposalRevenue, err := standardproposalrevenue.New(ctx,
+		standardproposalrevenue.WithLogLevel(util.LogLevel("proposalrevenue")),
+		standardproposalrevenue.WithMonitor(monitor),
+		standardproposalrevenue.WithScheduler(scheduler),
+		standardproposalrevenue.WithChainTime(chainTime),
+		standardproposalrevenue.WithExecutionAddress(viper.GetString("proposalrevenue.execution-address")),
+		standardproposalrevenue.WithTimeout(util.Timeout("proposalrevenue")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Msg("Started proposal revenue service")
+
+	return proposalRevenue, nil
+}
+
+// startProposalAudit starts the proposal audit service if configured.
+func startProposalAudit(ctx context.Context) (proposalaudit.Service, error) {
+	if viper.GetString("proposalaudit.path") == "" {
+		return nil, nil
+	}
+
+	proposalAudit, err := fileproposalaudit.New(ctx,
+		fileproposalaudit.WithLogLevel(util.LogLevel("proposalaudit")),
+		fileproposalaudit.WithPath(resolvePath(viper.GetString("proposalaudit.path"))),
+		fileproposalaudit.WithMaxSize(viper.GetInt64("proposalaudit.max-size")),
+		fileproposalaudit.WithMaxFiles(viper.GetInt("proposalaudit.max-files")),
+		fileproposalaudit.WithMaxAge(viper.GetDuration("proposalaudit.max-age")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Msg("Started proposal audit service")
+
+	return proposalAudit, nil
+}
+
+// startDutyEvents starts the duty events service if configured.
+func startDutyEvents(ctx context.Context) (dutyevents.Service, error) {
+	if viper.GetString("dutyevents.nats.url") == "" {
+		return nil, nil
+	}
+
+	credentialsFile := viper.GetString("dutyevents.nats.credentials-file")
+	if credentialsFile != "" {
+		credentialsFile = resolvePath(credentialsFile)
+	}
+
+	dutyEvents, err := natsdutyevents.New(ctx,
+		natsdutyevents.WithLogLevel(util.LogLevel("dutyevents")),
+		natsdutyevents.WithURL(viper.GetString("dutyevents.nats.url")),
+		natsdutyevents.WithSubject(viper.GetString("dutyevents.nats.subject")),
+		natsdutyevents.WithCredentialsFile(credentialsFile),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Msg("Started NATS duty events service")
+
+	return dutyEvents, nil
+}
+
+// startSlashingProtection starts local slashing protection if configured.
+func startSlashingProtection(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+) (
+	slashingprotection.Service,
+	error,
+) {
+	if !viper.GetBool("slashingprotection.enable") {
+		return nil, nil
+	}
+
+	slashingProtection, err := newSlashingProtection(ctx, monitor, eth2Client, viper.GetString("slashingprotection.path"))
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("path", resolvePath(viper.GetString("slashingprotection.path"))).Msg("Started local slashing protection")
+
+	return slashingProtection, nil
+}
+
+// newSlashingProtection creates a local slashing protection service holding its data at the given path.
+func newSlashingProtection(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	path string,
+) (
+	*standardslashingprotection.Service,
+	error,
+) {
+	genesisResponse, err := eth2Client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	return standardslashingprotection.New(ctx,
+		standardslashingprotection.WithLogLevel(util.LogLevel("slashingprotection")),
+		standardslashingprotection.WithMonitor(monitor),
+		standardslashingprotection.WithPath(resolvePath(path)),
+		standardslashingprotection.WithGenesisValidatorsRoot(genesisResponse.Data.GenesisValidatorsRoot),
+	)
+}
+
+// startDoppelganger starts doppelgänger detection if configured.
+func startDoppelganger(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+	validatorsManager validatorsmanager.Service,
+) (
+	doppelganger.Service,
+	error,
+) {
+	if !viper.GetBool("doppelganger.enable") {
+		return nil, nil
+	}
+
+	excludedValidators, err := pubKeysFromConfig("doppelganger.excluded-validators")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain excluded validators")
+	}
+
+	doppelgangerSvc, err := standarddoppelganger.New(ctx,
+		standarddoppelganger.WithLogLevel(util.LogLevel("doppelganger")),
+		standarddoppelganger.WithMonitor(monitor),
+		standarddoppelganger.WithChainTime(chainTime),
+		standarddoppelganger.WithScheduler(scheduler),
+		standarddoppelganger.WithValidatorsManager(validatorsManager),
+		standarddoppelganger.WithBeaconCommitteesProvider(eth2Client.(eth2client.BeaconCommitteesProvider)),
+		standarddoppelganger.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
+		standarddoppelganger.WithEpochs(viper.GetUint64("doppelganger.epochs")),
+		standarddoppelganger.WithExcludedValidators(excludedValidators),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return doppelgangerSvc, nil
+}
+
+// startAccountManager starts the appropriate account manager given user input.
+func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainSpec chainspec.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service, dutyBlacklist dutyblacklist.Service) (accountmanager.Service, error) {
+	if viper.GetString("accountmanager.failover.primary") != "" ||
+		viper.GetString("accountmanager.failover.fallback") != "" {
+		return startFailoverAccountManager(ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	}
+
+	if len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0 &&
+		len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0 {
+		return nil, errors.New("multiple account managers configured; Vouch only supports a single account manager")
+	}
+
+	if len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0 {
+		return startDirkAccountManager(ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	}
+
+	if len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0 {
+		return startWalletAccountManager(ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	}
+
+	return nil, errors.New("no account manager defined")
+}
+
+// startFailoverAccountManager starts a primary and a fallback account manager,
+// and composes them in to a failover account manager.
+func startFailoverAccountManager(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainSpec chainspec.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service, dutyBlacklist dutyblacklist.Service) (accountmanager.Service, error) {
+	primary, err := startNamedAccountManager(viper.GetString("accountmanager.failover.primary"), ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start primary account manager")
+	}
+	fallback, err := startNamedAccountManager(viper.GetString("accountmanager.failover.fallback"), ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start fallback account manager")
+	}
+
+	// The failover account manager has its own slashing protection data, as
+	// messages it signs have already been recorded by any local slashing
+	// protection used by the duties.
+	slashingProtection, err := newSlashingProtection(ctx, monitor, eth2Client, viper.GetString("accountmanager.failover.slashing-protection-path"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start failover slashing protection")
+	}
+
+	log.Info().Msg("Starting failover account manager")
+	accountManager, err := failoveraccountmanager.New(ctx,
+		failoveraccountmanager.WithLogLevel(util.LogLevel("accountmanager.failover")),
+		failoveraccountmanager.WithMonitor(monitor),
+		failoveraccountmanager.WithPrimary(primary),
+		failoveraccountmanager.WithFallback(fallback),
+		failoveraccountmanager.WithSlashingProtection(slashingProtection),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start failover account manager service")
+	}
+
+	return accountManager, nil
+}
+
+// startNamedAccountManager starts the account manager with the given name.
+func startNamedAccountManager(name string, ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainSpec chainspec.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service, dutyBlacklist dutyblacklist.Service) (accountmanager.Service, error) {
+	switch name {
+	case "dirk":
+		if len(viper.GetStringSlice("accountmanager.dirk.accounts")) == 0 {
+			return nil, errors.New("no dirk accounts configured")
+		}
+		return startDirkAccountManager(ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	case "wallet":
+		if len(viper.GetStringSlice("accountmanager.wallet.accounts")) == 0 {
+			return nil, errors.New("no wallet accounts configured")
+		}
+		return startWalletAccountManager(ctx, monitor, eth2Client, chainSpec, validatorsManager, majordomo, chainTime, dutyBlacklist)
+	case "":
+		return nil, errors.New("no account manager specified")
+	default:
+		return nil, fmt.Errorf("unknown account manager %s", name)
+	}
+}
+
+// startDirkAccountManager starts the dirk account manager.
+func startDirkAccountManager(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainSpec chainspec.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service, dutyBlacklist dutyblacklist.Service) (accountmanager.Service, error) {
+	log.Info().Msg("Starting dirk account manager")
+	certPEMBlock, err := majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.client-cert"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain server certificate")
+	}
+	keyPEMBlock, err := majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.client-key"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain server key")
+	}
+	var caPEMBlock []byte
+	if viper.GetString("accountmanager.dirk.ca-cert") != "" {
+		caPEMBlock, err = majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.ca-cert"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain client CA certificate")
+		}
+	}
+	accountManager, err := dirkaccountmanager.New(ctx,
+		dirkaccountmanager.WithLogLevel(util.LogLevel("accountmanager.dirk")),
+		dirkaccountmanager.WithMonitor(monitor.(metrics.AccountManagerMonitor)),
+		dirkaccountmanager.WithTimeout(util.Timeout("accountmanager.dirk")),
+		dirkaccountmanager.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+		dirkaccountmanager.WithProcessConcurrency(util.ProcessConcurrency("accountmanager.dirk")),
+		dirkaccountmanager.WithValidatorsManager(validatorsManager),
+		dirkaccountmanager.WithEndpoints(viper.GetStringSlice("accountmanager.dirk.endpoints")),
+		dirkaccountmanager.WithAccountPaths(viper.GetStringSlice("accountmanager.dirk.accounts")),
+		dirkaccountmanager.WithClientCert(certPEMBlock),
+		dirkaccountmanager.WithClientKey(keyPEMBlock),
+		dirkaccountmanager.WithCACert(caPEMBlock),
+		dirkaccountmanager.WithDomainProvider(domainProvider(eth2Client, chainSpec)),
+		dirkaccountmanager.WithFarFutureEpochProvider(eth2Client.(eth2client.FarFutureEpochProvider)),
+		dirkaccountmanager.WithCurrentEpochProvider(chainTime),
+		dirkaccountmanager.WithDutyBlacklist(dutyBlacklist),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start dirk account manager service")
+	}
+	return accountManager, nil
+}
+
+// startWalletAccountManager starts the wallet account manager.
+func startWalletAccountManager(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainSpec chainspec.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service, dutyBlacklist dutyblacklist.Service) (accountmanager.Service, error) {
+	log.Info().Msg("Starting wallet account manager")
+	passphrases := make([][]byte, 0)
+	for _, passphraseURL := range viper.GetStringSlice("accountmanager.wallet.passphrases") {
+		passphrase, err := majordomo.Fetch(ctx, passphraseURL)
+		if err != nil {
+			log.Error().Err(err).Str("url", string(passphrase)).Msg("failed to obtain passphrase")
+			continue
+		}
+		passphrases = append(passphrases, passphrase)
+	}
+	if len(passphrases) == 0 {
+		return nil, errors.New("no passphrases for wallet supplied")
+	}
+	accountManager, err := walletaccountmanager.New(ctx,
+		walletaccountmanager.WithLogLevel(util.LogLevel("accountmanager.wallet")),
+		walletaccountmanager.WithMonitor(monitor.(metrics.AccountManagerMonitor)),
+		walletaccountmanager.WithProcessConcurrency(util.ProcessConcurrency("accountmanager.wallet")),
+		walletaccountmanager.WithValidatorsManager(validatorsManager),
+		walletaccountmanager.WithAccountPaths(viper.GetStringSlice("accountmanager.wallet.accounts")),
+		walletaccountmanager.WithPassphrases(passphrases),
+		walletaccountmanager.WithLocations(viper.GetStringSlice("accountmanager.wallet.locations")),
+		walletaccountmanager.WithImportWallet(viper.GetString("accountmanager.wallet.import-wallet")),
+		walletaccountmanager.WithSpecProvider(chainSpec),
+		walletaccountmanager.WithFarFutureEpochProvider(eth2Client.(eth2client.FarFutureEpochProvider)),
+		walletaccountmanager.WithDomainProvider(domainProvider(eth2Client, chainSpec)),
+		walletaccountmanager.WithCurrentEpochProvider(chainTime),
+		walletaccountmanager.WithDutyBlacklist(dutyBlacklist),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start wallet account manager service")
+	}
+	return accountManager, nil
+}
+
+// selectAttestationDataProvider selects the appropriate attestation data provider given user input.
+func selectAttestationDataProvider(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	cacheSvc cache.Service,
+	nodeMonitor nodemonitor.Service,
+) (eth2client.AttestationDataProvider, error) {
+	var attestationDataProvider eth2client.AttestationDataProvider
+	var err error
+	switch viper.GetString("strategies.attestationdata.style") {
+	case "best":
+		log.Info().Msg("Starting best attestation data strategy")
+		recordStrategy("strategies.attestationdata", "best")
+		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.attestationdata.best") {
 			client, err := fetchClient(ctx, monitor, address)
 			if err != nil {
 				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for attestation data strategy", address))
@@ -1077,16 +2103,20 @@ func selectAttestationDataProvider(ctx context.Context,
 			bestattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			bestattestationdatastrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.best")),
+			bestattestationdatastrategy.WithNodeMonitor(nodeMonitor),
 			bestattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			bestattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithChainTime(chainTime),
 			bestattestationdatastrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+			bestattestationdatastrategy.WithTrustHalfLife(viper.GetDuration("strategies.attestationdata.best.trust-half-life")),
+			bestattestationdatastrategy.WithHeadLagTolerance(viper.GetUint64("strategies.attestationdata.best.head-lag-tolerance")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best attestation data strategy")
 		}
 	case "majority":
 		log.Info().Msg("Starting majority attestation data strategy")
+		recordStrategy("strategies.attestationdata", "majority")
 		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.attestationdata.majority") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1095,21 +2125,42 @@ func selectAttestationDataProvider(ctx context.Context,
 			}
 			attestationDataProviders[address] = client.(eth2client.AttestationDataProvider)
 		}
+		var fallback eth2client.AttestationDataProvider
+		if viper.GetBool("strategies.attestationdata.majority.fallback") {
+			fallback, err = bestattestationdatastrategy.New(ctx,
+				bestattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+				bestattestationdatastrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.attestationdata.majority")),
+				bestattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.majority")),
+				bestattestationdatastrategy.WithNodeMonitor(nodeMonitor),
+				bestattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
+				bestattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.majority")),
+				bestattestationdatastrategy.WithChainTime(chainTime),
+				bestattestationdatastrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+				bestattestationdatastrategy.WithTrustHalfLife(viper.GetDuration("strategies.attestationdata.best.trust-half-life")),
+				bestattestationdatastrategy.WithHeadLagTolerance(viper.GetUint64("strategies.attestationdata.best.head-lag-tolerance")),
+			)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to start fallback attestation data strategy")
+			}
+		}
 		attestationDataProvider, err = majorityattestationdatastrategy.New(ctx,
 			majorityattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			majorityattestationdatastrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.attestationdata.majority")),
 			majorityattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.majority")),
+			majorityattestationdatastrategy.WithNodeMonitor(nodeMonitor),
 			majorityattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			majorityattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.majority")),
 			majorityattestationdatastrategy.WithChainTime(chainTime),
 			majorityattestationdatastrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
 			majorityattestationdatastrategy.WithThreshold(viper.GetUint64("strategies.attestationdata.majority.threshold")),
+			majorityattestationdatastrategy.WithFallback(fallback),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start majority attestation data strategy")
 		}
 	case "first":
 		log.Info().Msg("Starting first attestation data strategy")
+		recordStrategy("strategies.attestationdata", "first")
 		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.attestationdata.first") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1121,6 +2172,7 @@ func selectAttestationDataProvider(ctx context.Context,
 		attestationDataProvider, err = firstattestationdatastrategy.New(ctx,
 			firstattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			firstattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.first")),
+			firstattestationdatastrategy.WithNodeMonitor(nodeMonitor),
 			firstattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			firstattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.first")),
 		)
@@ -1129,16 +2181,104 @@ func selectAttestationDataProvider(ctx context.Context,
 		}
 	default:
 		log.Info().Msg("Starting simple attestation data strategy")
+		recordStrategy("strategies.attestationdata", "simple")
 		attestationDataProvider = eth2Client.(eth2client.AttestationDataProvider)
 	}
 
 	return attestationDataProvider, nil
 }
 
+// selectDelayTuning returns the configuration for automatic tuning of duty
+// delays, or nil if it is not enabled.
+func selectDelayTuning() *standardcontroller.DelayTuning {
+	if !viper.GetBool("controller.delay-tuning.enable") {
+		return nil
+	}
+
+	// A maximum delay of 0 is set to the maximum attestation delay by the controller.
+	return &standardcontroller.DelayTuning{
+		Window:   viper.GetInt("controller.delay-tuning.window"),
+		MinDelay: viper.GetDuration("controller.delay-tuning.min-delay"),
+		MaxDelay: viper.GetDuration("controller.delay-tuning.max-delay"),
+	}
+}
+
+// selectDutyBudget returns the budget for the phases of duties.
+func selectDutyBudget() *util.DutyBudget {
+	return &util.DutyBudget{
+		Wait:   viper.GetFloat64("controller.duty-budget.wait"),
+		Fetch:  viper.GetFloat64("controller.duty-budget.fetch"),
+		Sign:   viper.GetFloat64("controller.duty-budget.sign"),
+		Submit: viper.GetFloat64("controller.duty-budget.submit"),
+	}
+}
+
+// maxAttestationDelay returns the maximum attestation delay, which if not
+// configured explicitly is the wait share of the duty budget.
+func maxAttestationDelay(chainTime chaintime.Service) time.Duration {
+	if delay := viper.GetDuration("controller.max-attestation-delay"); delay != 0 {
+		return delay
+	}
+	slotDuration := chainTime.StartOfSlot(1).Sub(chainTime.StartOfSlot(0))
+	dutyDeadline := viper.GetDuration("controller.duty-deadline")
+	if dutyDeadline == 0 {
+		dutyDeadline = slotDuration
+	}
+	if delay := selectDutyBudget().WaitDuration(dutyDeadline); delay != 0 {
+		return delay
+	}
+
+	return slotDuration / 3
+}
+
+// startAttestationDataPrefetch wraps the attestation data provider with
+// prefetching if configured, otherwise returns it unchanged.
+func startAttestationDataPrefetch(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+	attestationDataProvider eth2client.AttestationDataProvider,
+) (
+	eth2client.AttestationDataProvider,
+	error,
+) {
+	if !viper.GetBool("strategies.attestationdata.prefetch.enable") {
+		return attestationDataProvider, nil
+	}
+
+	offset := viper.GetDuration("strategies.attestationdata.prefetch.offset")
+	if offset >= maxAttestationDelay(chainTime) {
+		return nil, errors.New("attestation data prefetch offset must be less than the maximum attestation delay")
+	}
+	if delayTuning := selectDelayTuning(); delayTuning != nil && offset >= delayTuning.MinDelay {
+		return nil, errors.New("attestation data prefetch offset must be less than the delay tuning minimum delay")
+	}
+
+	log.Info().Dur("offset", offset).Msg("Starting attestation data prefetch")
+	prefetchProvider, err := prefetchattestationdatastrategy.New(ctx,
+		prefetchattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.prefetch")),
+		prefetchattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+		prefetchattestationdatastrategy.WithChainTime(chainTime),
+		prefetchattestationdatastrategy.WithScheduler(scheduler),
+		prefetchattestationdatastrategy.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
+		prefetchattestationdatastrategy.WithAttestationDataProvider(attestationDataProvider),
+		prefetchattestationdatastrategy.WithOffset(offset),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return prefetchProvider, nil
+}
+
 // selectAggregateAttestationProvider selects the appropriate aggregate attestation provider given user input.
 func selectAggregateAttestationProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
+	nodeLatency nodelatency.Service,
+	nodeMonitor nodemonitor.Service,
 ) (
 	eth2client.AggregateAttestationProvider,
 	error,
@@ -1148,6 +2288,7 @@ func selectAggregateAttestationProvider(ctx context.Context,
 	switch viper.GetString("strategies.aggregateattestation.style") {
 	case "best":
 		log.Info().Msg("Starting best aggregate attestation strategy")
+		recordStrategy("strategies.aggregateattestation", "best")
 		aggregateAttestationProviders := make(map[string]eth2client.AggregateAttestationProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.aggregateattestation.best") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1160,14 +2301,21 @@ func selectAggregateAttestationProvider(ctx context.Context,
 			bestaggregateattestationstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			bestaggregateattestationstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.aggregateattestation.best")),
 			bestaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.best")),
+			bestaggregateattestationstrategy.WithNodeMonitor(nodeMonitor),
 			bestaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
 			bestaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.best")),
+			bestaggregateattestationstrategy.WithVerifySignatures(viper.GetBool("strategies.aggregateattestation.best.verify-signatures")),
+			bestaggregateattestationstrategy.WithSpecProvider(chainSpec),
+			bestaggregateattestationstrategy.WithDomainProvider(domainProvider(eth2Client, chainSpec)),
+			bestaggregateattestationstrategy.WithBeaconCommitteesProvider(eth2Client.(eth2client.BeaconCommitteesProvider)),
+			bestaggregateattestationstrategy.WithValidatorsProvider(eth2Client.(eth2client.ValidatorsProvider)),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best aggregate attestation strategy")
 		}
 	case "first":
 		log.Info().Msg("Starting first aggregate attestation strategy")
+		recordStrategy("strategies.aggregateattestation", "first")
 		aggregateAttestationProviders := make(map[string]eth2client.AggregateAttestationProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.aggregateattestation.first") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1179,14 +2327,18 @@ func selectAggregateAttestationProvider(ctx context.Context,
 		aggregateAttestationProvider, err = firstaggregateattestationstrategy.New(ctx,
 			firstaggregateattestationstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			firstaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.first")),
+			firstaggregateattestationstrategy.WithNodeMonitor(nodeMonitor),
 			firstaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
 			firstaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.first")),
+			firstaggregateattestationstrategy.WithNodeLatency(nodeLatency),
+			firstaggregateattestationstrategy.WithHedgeDelay(viper.GetDuration("strategies.aggregateattestation.first.hedge-delay")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first aggregate attestation strategy")
 		}
 	default:
 		log.Info().Msg("Starting simple aggregate attestation strategy")
+		recordStrategy("strategies.aggregateattestation", "simple")
 		aggregateAttestationProvider = eth2Client.(eth2client.AggregateAttestationProvider)
 	}
 
@@ -1197,14 +2349,17 @@ func selectAggregateAttestationProvider(ctx context.Context,
 func selectProposalProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainSpec chainspec.Service,
 	chainTime chaintime.Service,
 	cacheSvc cache.Service,
+	nodeMonitor nodemonitor.Service,
 ) (eth2client.ProposalProvider, error) {
 	var proposalProvider eth2client.ProposalProvider
 	var err error
 	switch viper.GetString("strategies.beaconblockproposal.style") {
 	case "best":
 		log.Info().Msg("Starting best beacon block proposal strategy")
+		recordStrategy("strategies.beaconblockproposal", "best")
 		proposalProviders := make(map[string]eth2client.ProposalProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.beaconblockproposal.best") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1217,9 +2372,10 @@ func selectProposalProvider(ctx context.Context,
 			bestbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			bestbeaconblockproposalstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.beaconblockproposal.best")),
 			bestbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.best")),
+			bestbeaconblockproposalstrategy.WithNodeMonitor(nodeMonitor),
 			bestbeaconblockproposalstrategy.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
 			bestbeaconblockproposalstrategy.WithChainTimeService(chainTime),
-			bestbeaconblockproposalstrategy.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+			bestbeaconblockproposalstrategy.WithSpecProvider(chainSpec),
 			bestbeaconblockproposalstrategy.WithProposalProviders(proposalProviders),
 			bestbeaconblockproposalstrategy.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
 			bestbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.beaconblockproposal.best")),
@@ -1231,6 +2387,7 @@ func selectProposalProvider(ctx context.Context,
 		}
 	case "first":
 		log.Info().Msg("Starting first beacon block proposal strategy")
+		recordStrategy("strategies.beaconblockproposal", "first")
 		proposalProviders := make(map[string]eth2client.ProposalProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.beaconblockproposal.first") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1242,6 +2399,7 @@ func selectProposalProvider(ctx context.Context,
 		proposalProvider, err = firstbeaconblockproposalstrategy.New(ctx,
 			firstbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			firstbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.first")),
+			firstbeaconblockproposalstrategy.WithNodeMonitor(nodeMonitor),
 			firstbeaconblockproposalstrategy.WithProposalProviders(proposalProviders),
 			firstbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.beaconblockproposal.first")),
 		)
@@ -1250,85 +2408,26 @@ func selectProposalProvider(ctx context.Context,
 		}
 	default:
 		log.Info().Msg("Starting simple beacon block proposal strategy")
+		recordStrategy("strategies.beaconblockproposal", "simple")
 		proposalProvider = eth2Client.(eth2client.ProposalProvider)
 	}
 
 	return proposalProvider, nil
 }
 
-// selectBlindedProposalProvider selects the appropriate blinded proposal provider given user input.
-func selectBlindedProposalProvider(ctx context.Context,
-	monitor metrics.Service,
-	eth2Client eth2client.Service,
-	chainTime chaintime.Service,
-	cacheSvc cache.Service,
-) (eth2client.BlindedProposalProvider, error) {
-	var blindedProposalProvider eth2client.BlindedProposalProvider
-	var err error
-	switch viper.GetString("strategies.blindedbeaconblockproposal.style") {
-	case "best":
-		log.Info().Msg("Starting best blinded beacon block proposal strategy")
-		blindedProposalProviders := make(map[string]eth2client.BlindedProposalProvider)
-		for _, address := range util.BeaconNodeAddresses("strategies.blindedbeaconblockproposal.best") {
-			client, err := fetchClient(ctx, monitor, address)
-			if err != nil {
-				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for blinded beacon block proposal strategy", address))
-			}
-			blindedProposalProviders[address] = client.(eth2client.BlindedProposalProvider)
-		}
-		blindedProposalProvider, err = bestblindedbeaconblockproposalstrategy.New(ctx,
-			bestblindedbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
-			bestblindedbeaconblockproposalstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.blindedbeaconblockproposal.best")),
-			bestblindedbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.blindedbeaconblockproposal.best")),
-			bestblindedbeaconblockproposalstrategy.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
-			bestblindedbeaconblockproposalstrategy.WithChainTimeService(chainTime),
-			bestblindedbeaconblockproposalstrategy.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
-			bestblindedbeaconblockproposalstrategy.WithBlindedProposalProviders(blindedProposalProviders),
-			bestblindedbeaconblockproposalstrategy.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
-			bestblindedbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.blindedbeaconblockproposal.best")),
-			bestblindedbeaconblockproposalstrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to start best blinded beacon block proposal strategy")
-		}
-	case "first":
-		log.Info().Msg("Starting first blinded beacon block proposal strategy")
-		blindedProposalProviders := make(map[string]eth2client.BlindedProposalProvider)
-		for _, address := range util.BeaconNodeAddresses("strategies.blindedbeaconblockproposal.first") {
-			client, err := fetchClient(ctx, monitor, address)
-			if err != nil {
-				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for blinded beacon block proposal strategy", address))
-			}
-			blindedProposalProviders[address] = client.(eth2client.BlindedProposalProvider)
-		}
-		blindedProposalProvider, err = firstblindedbeaconblockproposalstrategy.New(ctx,
-			firstblindedbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
-			firstblindedbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.blindedbeaconblockproposal.first")),
-			firstblindedbeaconblockproposalstrategy.WithChainTimeService(chainTime),
-			firstblindedbeaconblockproposalstrategy.WithBlindedProposalProviders(blindedProposalProviders),
-			firstblindedbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.blindedbeaconblockproposal.first")),
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to start first blinded beacon block proposal strategy")
-		}
-	default:
-		log.Info().Msg("Starting simple blinded beacon block proposal strategy")
-		blindedProposalProvider = eth2Client.(eth2client.BlindedProposalProvider)
-	}
-
-	return blindedProposalProvider, nil
-}
-
 // selectSyncCommitteeContributionProvider selects the appropriate sync committee contribution provider given user input.
 func selectSyncCommitteeContributionProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	nodeLatency nodelatency.Service,
+	nodeMonitor nodemonitor.Service,
 ) (eth2client.SyncCommitteeContributionProvider, error) {
 	var syncCommitteeContributionProvider eth2client.SyncCommitteeContributionProvider
 	var err error
 	switch viper.GetString("strategies.synccommitteecontribution.style") {
 	case "best":
 		log.Info().Msg("Starting best sync committee contribution strategy")
+		recordStrategy("strategies.synccommitteecontribution", "best")
 		syncCommitteeContributionProviders := make(map[string]eth2client.SyncCommitteeContributionProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.synccommitteecontribution.best") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1341,6 +2440,7 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 			bestsynccommitteecontributionstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			bestsynccommitteecontributionstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.synccommitteecontribution.best")),
 			bestsynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.best")),
+			bestsynccommitteecontributionstrategy.WithNodeMonitor(nodeMonitor),
 			bestsynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
 			bestsynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.best")),
 		)
@@ -1349,6 +2449,7 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 		}
 	case "first":
 		log.Info().Msg("Starting first sync committee contribution strategy")
+		recordStrategy("strategies.synccommitteecontribution", "first")
 		syncCommitteeContributionProviders := make(map[string]eth2client.SyncCommitteeContributionProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.synccommitteecontribution.first") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1360,14 +2461,18 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 		syncCommitteeContributionProvider, err = firstsynccommitteecontributionstrategy.New(ctx,
 			firstsynccommitteecontributionstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			firstsynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.first")),
+			firstsynccommitteecontributionstrategy.WithNodeMonitor(nodeMonitor),
 			firstsynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
 			firstsynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.first")),
+			firstsynccommitteecontributionstrategy.WithNodeLatency(nodeLatency),
+			firstsynccommitteecontributionstrategy.WithHedgeDelay(viper.GetDuration("strategies.synccommitteecontribution.first.hedge-delay")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first sync committee contribution strategy")
 		}
 	default:
 		log.Info().Msg("Starting simple sync committee contribution strategy")
+		recordStrategy("strategies.synccommitteecontribution", "simple")
 		syncCommitteeContributionProvider = eth2Client.(eth2client.SyncCommitteeContributionProvider)
 	}
 
@@ -1379,12 +2484,14 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
 	cacheSvc cache.Service,
+	nodeMonitor nodemonitor.Service,
 ) (eth2client.BeaconBlockRootProvider, error) {
 	var beaconBlockRootProvider eth2client.BeaconBlockRootProvider
 	var err error
 	switch viper.GetString("strategies.beaconblockroot.style") {
 	case "majority":
 		log.Info().Msg("Starting majority beacon block root strategy")
+		recordStrategy("strategies.beaconblockroot", "majority")
 		beaconBlockRootProviders := make(map[string]eth2client.BeaconBlockRootProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.beaconblockroot.majority") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1398,6 +2505,7 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 			majoritybeaconblockrootstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			majoritybeaconblockrootstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.beaconblockroot.best")),
 			majoritybeaconblockrootstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockroot.best")),
+			majoritybeaconblockrootstrategy.WithNodeMonitor(nodeMonitor),
 			majoritybeaconblockrootstrategy.WithBeaconBlockRootProviders(beaconBlockRootProviders),
 			majoritybeaconblockrootstrategy.WithTimeout(util.Timeout("strategies.beaconblockroot.best")),
 			majoritybeaconblockrootstrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
@@ -1407,6 +2515,7 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 		}
 	case "first":
 		log.Info().Msg("Starting first beacon block root strategy")
+		recordStrategy("strategies.beaconblockroot", "first")
 		beaconBlockRootProviders := make(map[string]eth2client.BeaconBlockRootProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.beaconblockroot.first") {
 			client, err := fetchClient(ctx, monitor, address)
@@ -1418,6 +2527,7 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 		beaconBlockRootProvider, err = firstbeaconblockrootstrategy.New(ctx,
 			firstbeaconblockrootstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			firstbeaconblockrootstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockroot.first")),
+			firstbeaconblockrootstrategy.WithNodeMonitor(nodeMonitor),
 			firstbeaconblockrootstrategy.WithBeaconBlockRootProviders(beaconBlockRootProviders),
 			firstbeaconblockrootstrategy.WithTimeout(util.Timeout("strategies.beaconblockroot.first")),
 		)
@@ -1426,14 +2536,265 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 		}
 	default:
 		log.Info().Msg("Starting simple beacon block root strategy")
+		recordStrategy("strategies.beaconblockroot", "simple")
 		beaconBlockRootProvider = eth2Client.(eth2client.BeaconBlockRootProvider)
 	}
 
 	return beaconBlockRootProvider, nil
 }
 
+// startBeaconNodeQuotas starts the beacon node quota service if quotas are configured.
+func startBeaconNodeQuotas(ctx context.Context,
+	monitor metrics.Service,
+	scheduler scheduler.Service,
+) (
+	beaconnodequota.Service,
+	error,
+) {
+	quotas := make(map[string]*beaconnodequota.Quota)
+	if err := viper.UnmarshalKey("beaconnodequotas.quotas", &quotas); err != nil {
+		return nil, errors.Wrap(err, "invalid beacon node quotas")
+	}
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+	if monitor.Presenter() != "prometheus" {
+		return nil, errors.New("beacon node quotas require prometheus metrics")
+	}
+
+	beaconNodeQuotas, err := standardbeaconnodequota.New(ctx,
+		standardbeaconnodequota.WithLogLevel(util.LogLevel("beaconnodequotas")),
+		standardbeaconnodequota.WithMonitor(monitor),
+		standardbeaconnodequota.WithScheduler(scheduler),
+		standardbeaconnodequota.WithQuotas(quotas),
+		standardbeaconnodequota.WithThreshold(viper.GetFloat64("beaconnodequotas.threshold")),
+		standardbeaconnodequota.WithCheckInterval(viper.GetDuration("beaconnodequotas.check-interval")),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start beacon node quotas")
+	}
+	log.Info().Int("beacon_nodes", len(quotas)).Msg("Started beacon node quotas")
+
+	return beaconNodeQuotas, nil
+}
+
+// startNodeMonitor starts the node monitor, probing the beacon nodes used by
+// the strategies and submitters.  Health scores are only used if the node
+// monitor is enabled, but execution health and restarts are always tracked.
+func startNodeMonitor(ctx context.Context,
+	monitor metrics.Service,
+	scheduler scheduler.Service,
+	chainTime chaintime.Service,
+) (
+	nodemonitor.Service,
+	error,
+) {
+	priorities := make(map[string]int)
+	if err := viper.UnmarshalKey("nodemonitor.priorities", &priorities); err != nil {
+		return nil, errors.Wrap(err, "invalid beacon node priorities")
+	}
+	if !viper.GetBool("nodemonitor.enable") && len(priorities) > 0 {
+		return nil, errors.New("beacon node priorities require the node monitor to be enabled")
+	}
+
+	addresses := append(util.BeaconNodeAddresses("nodemonitor"), util.BeaconNodeAddressesForAttesting()...)
+	addresses = append(addresses, util.BeaconNodeAddressesForProposing()...)
+	addresses = append(addresses, subscriptionBeaconNodeAddresses()...)
+	// Beacon nodes with priorities are probed, so that failover between them can occur.
+	for address := range priorities {
+		addresses = append(addresses, address)
+	}
+	clients := make(map[string]eth2client.Service)
+	for _, address := range addresses {
+		if _, exists := clients[address]; exists {
+			continue
+		}
+		client, err := fetchClient(ctx, monitor, address)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for node monitor", address))
+		}
+		clients[address] = client
+	}
+
+	nodeMonitor, err := standardnodemonitor.New(ctx,
+		standardnodemonitor.WithLogLevel(util.LogLevel("nodemonitor")),
+		standardnodemonitor.WithMonitor(monitor),
+		standardnodemonitor.WithScheduler(scheduler),
+		standardnodemonitor.WithChainTime(chainTime),
+		standardnodemonitor.WithClients(clients),
+		standardnodemonitor.WithProbeInterval(viper.GetDuration("nodemonitor.probe-interval")),
+		standardnodemonitor.WithScoring(viper.GetBool("nodemonitor.enable")),
+		standardnodemonitor.WithThreshold(viper.GetFloat64("nodemonitor.threshold")),
+		standardnodemonitor.WithMinPeers(viper.GetUint64("nodemonitor.min-peers")),
+		standardnodemonitor.WithMaxLatency(viper.GetDuration("nodemonitor.max-latency")),
+		standardnodemonitor.WithPriorities(priorities),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start node monitor")
+	}
+	log.Info().Int("beacon_nodes", len(clients)).Msg("Started node monitor")
+
+	return nodeMonitor, nil
+}
+
+// startDutyProxyServer starts the duty proxy server if configured, serving
+// duties and attestation data to sibling instances.
+func startDutyProxyServer(ctx context.Context,
+	majordomo majordomo.Service,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	attestationDataProvider eth2client.AttestationDataProvider,
+) error {
+	if viper.GetString("dutyproxy.listen-address") == "" {
+		return nil
+	}
+	if viper.GetString("dutyproxy.address") != "" {
+		return errors.New("duty proxy cannot both serve and use a duty proxy")
+	}
+
+	serverCert, serverKey, caCert, err := dutyProxyCerts(ctx, majordomo, "dutyproxy.server-cert", "dutyproxy.server-key")
+	if err != nil {
+		return err
+	}
+
+	_, err = dutyproxyserver.New(ctx,
+		dutyproxyserver.WithLogLevel(util.LogLevel("dutyproxy")),
+		dutyproxyserver.WithMonitor(monitor),
+		dutyproxyserver.WithListenAddress(viper.GetString("dutyproxy.listen-address")),
+		dutyproxyserver.WithServerCert(serverCert),
+		dutyproxyserver.WithServerKey(serverKey),
+		dutyproxyserver.WithCACert(caCert),
+		dutyproxyserver.WithTimeout(util.Timeout("dutyproxy")),
+		dutyproxyserver.WithCacheTTL(viper.GetDuration("dutyproxy.cache-ttl")),
+		dutyproxyserver.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
+		dutyproxyserver.WithProposerDutiesProvider(eth2Client.(eth2client.ProposerDutiesProvider)),
+		dutyproxyserver.WithSyncCommitteeDutiesProvider(eth2Client.(eth2client.SyncCommitteeDutiesProvider)),
+		dutyproxyserver.WithAttestationDataProvider(attestationDataProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to start duty proxy server")
+	}
+
+	return nil
+}
+
+// startDutyProxyClient starts the duty proxy client if configured, obtaining
+// duties and attestation data from another instance.
+func startDutyProxyClient(ctx context.Context,
+	majordomo majordomo.Service,
+	eth2Client eth2client.Service,
+) (
+	*dutyproxyclient.Service,
+	error,
+) {
+	if viper.GetString("dutyproxy.address") == "" {
+		return nil, nil
+	}
+
+	clientCert, clientKey, caCert, err := dutyProxyCerts(ctx, majordomo, "dutyproxy.client-cert", "dutyproxy.client-key")
+	if err != nil {
+		return nil, err
+	}
+
+	dutyProxyClient, err := dutyproxyclient.New(ctx,
+		dutyproxyclient.WithLogLevel(util.LogLevel("dutyproxy")),
+		dutyproxyclient.WithAddress(viper.GetString("dutyproxy.address")),
+		dutyproxyclient.WithTimeout(util.Timeout("dutyproxy")),
+		dutyproxyclient.WithClientCert(clientCert),
+		dutyproxyclient.WithClientKey(clientKey),
+		dutyproxyclient.WithCACert(caCert),
+		dutyproxyclient.WithFallbackAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
+		dutyproxyclient.WithFallbackProposerDutiesProvider(eth2Client.(eth2client.ProposerDutiesProvider)),
+		dutyproxyclient.WithFallbackSyncCommitteeDutiesProvider(eth2Client.(eth2client.SyncCommitteeDutiesProvider)),
+		dutyproxyclient.WithFallbackAttestationDataProvider(eth2Client.(eth2client.AttestationDataProvider)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start duty proxy client")
+	}
+	log.Info().Str("address", viper.GetString("dutyproxy.address")).Msg("Using duty proxy")
+
+	return dutyProxyClient, nil
+}
+
+// startDutyStore starts the duty store if configured.
+func startDutyStore(ctx context.Context,
+	chainTime chaintime.Service,
+	eth2Client eth2client.Service,
+	attesterDutiesProvider eth2client.AttesterDutiesProvider,
+	proposerDutiesProvider eth2client.ProposerDutiesProvider,
+	syncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider,
+) (
+	dutystore.Service,
+	error,
+) {
+	if !viper.GetBool("dutystore.enable") {
+		return nil, nil
+	}
+
+	dir := resolvePath(viper.GetString("dutystore.dir"))
+	store, err := filedutystore.New(ctx,
+		filedutystore.WithLogLevel(util.LogLevel("dutystore")),
+		filedutystore.WithBaseDir(dir),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start duty store file store")
+	}
+
+	dutyStore, err := standarddutystore.New(ctx,
+		standarddutystore.WithLogLevel(util.LogLevel("dutystore")),
+		standarddutystore.WithChainTime(chainTime),
+		standarddutystore.WithStore(store),
+		standarddutystore.WithAttesterDutiesProvider(attesterDutiesProvider),
+		standarddutystore.WithProposerDutiesProvider(proposerDutiesProvider),
+		standarddutystore.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+		standarddutystore.WithBeaconBlockHeadersProvider(eth2Client.(eth2client.BeaconBlockHeadersProvider)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start duty store")
+	}
+	log.Info().Str("dir", dir).Msg("Persisting duties")
+
+	return dutyStore, nil
+}
+
+// dutyProxyCerts fetches the certificate, key and CA certificate for the duty proxy.
+// Each is nil if not configured.
+func dutyProxyCerts(ctx context.Context,
+	majordomo majordomo.Service,
+	certKey string,
+	keyKey string,
+) (
+	[]byte,
+	[]byte,
+	[]byte,
+	error,
+) {
+	var cert, key, caCert []byte
+	var err error
+	if viper.GetString(certKey) != "" {
+		cert, err = majordomo.Fetch(ctx, viper.GetString(certKey))
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to obtain duty proxy certificate")
+		}
+	}
+	if viper.GetString(keyKey) != "" {
+		key, err = majordomo.Fetch(ctx, viper.GetString(keyKey))
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to obtain duty proxy key")
+		}
+	}
+	if viper.GetString("dutyproxy.ca-cert") != "" {
+		caCert, err = majordomo.Fetch(ctx, viper.GetString("dutyproxy.ca-cert"))
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to obtain duty proxy CA certificate")
+		}
+	}
+
+	return cert, key, caCert, nil
+}
+
 // selectSubmitterStrategy selects the appropriate submitter strategy given user input.
-func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service) (submitter.Service, error) {
+func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, beaconNodeQuotas beaconnodequota.Service, nodeMonitor nodemonitor.Service) (submitter.Service, error) {
 	log.Trace().Msg("Selecting submitter strategy")
 
 	var submitter submitter.Service
@@ -1441,20 +2802,31 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 	switch viper.GetString("submitter.style") {
 	case "multinode", "all":
 		log.Info().Msg("Starting multinode submitter strategy")
-		submitter, err = startMultinodeSubmitter(ctx, monitor)
+		recordStrategy("submitter", "multinode")
+		submitter, err = startMultinodeSubmitter(ctx, monitor, beaconNodeQuotas, nodeMonitor)
 	default:
 		log.Info().Msg("Starting standard submitter strategy")
+		recordStrategy("submitter", "standard")
+		// SSZ submission is per beacon node, so only applies when there is a single node.
+		submissionClient := eth2Client
+		if _, isHTTPClient := eth2Client.(*httpclient.Service); isHTTPClient {
+			submissionClient, err = fetchSubmissionClient(ctx, monitor, eth2Client.Address())
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to fetch submission client")
+			}
+		}
 		submitter, err = immediatesubmitter.New(ctx,
 			immediatesubmitter.WithLogLevel(util.LogLevel("submitter.immediate")),
 			immediatesubmitter.WithClientMonitor(monitor.(metrics.ClientMonitor)),
-			immediatesubmitter.WithProposalSubmitter(eth2Client.(eth2client.ProposalSubmitter)),
-			immediatesubmitter.WithAttestationsSubmitter(eth2Client.(eth2client.AttestationsSubmitter)),
-			immediatesubmitter.WithSyncCommitteeMessagesSubmitter(eth2Client.(eth2client.SyncCommitteeMessagesSubmitter)),
+			immediatesubmitter.WithProposalSubmitter(submissionClient.(eth2client.ProposalSubmitter)),
+			immediatesubmitter.WithAttestationsSubmitter(submissionClient.(eth2client.AttestationsSubmitter)),
+			immediatesubmitter.WithSyncCommitteeMessagesSubmitter(submissionClient.(eth2client.SyncCommitteeMessagesSubmitter)),
 			immediatesubmitter.WithSyncCommitteeContributionsSubmitter(eth2Client.(eth2client.SyncCommitteeContributionsSubmitter)),
 			immediatesubmitter.WithSyncCommitteeSubscriptionsSubmitter(eth2Client.(eth2client.SyncCommitteeSubscriptionsSubmitter)),
 			immediatesubmitter.WithBeaconCommitteeSubscriptionsSubmitter(eth2Client.(eth2client.BeaconCommitteeSubscriptionsSubmitter)),
 			immediatesubmitter.WithAggregateAttestationsSubmitter(eth2Client.(eth2client.AggregateAttestationsSubmitter)),
 			immediatesubmitter.WithProposalPreparationsSubmitter(eth2Client.(eth2client.ProposalPreparationsSubmitter)),
+			immediatesubmitter.WithBLSToExecutionChangesSubmitter(eth2Client.(eth2client.BLSToExecutionChangesSubmitter)),
 		)
 	}
 	if err != nil {
@@ -1465,6 +2837,8 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 
 func startMultinodeSubmitter(ctx context.Context,
 	monitor metrics.Service,
+	beaconNodeQuotas beaconnodequota.Service,
+	nodeMonitor nodemonitor.Service,
 ) (
 	submitter.Service,
 	error,
@@ -1480,7 +2854,7 @@ func startMultinodeSubmitter(ctx context.Context,
 
 	attestationsSubmitters := make(map[string]eth2client.AttestationsSubmitter)
 	for _, address := range util.BeaconNodeAddresses("submitter.attestation.multinode") {
-		client, err := fetchClient(ctx, monitor, address)
+		client, err := fetchSubmissionClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for attestation submitter strategy", address))
 		}
@@ -1489,7 +2863,7 @@ func startMultinodeSubmitter(ctx context.Context,
 
 	proposalSubmitters := make(map[string]eth2client.ProposalSubmitter)
 	for _, address := range util.BeaconNodeAddresses("submitter.proposal.multinode") {
-		client, err := fetchClient(ctx, monitor, address)
+		client, err := fetchSubmissionClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for proposal submitter strategy", address))
 		}
@@ -1525,7 +2899,7 @@ func startMultinodeSubmitter(ctx context.Context,
 
 	syncCommitteeMessagesSubmitters := make(map[string]eth2client.SyncCommitteeMessagesSubmitter)
 	for _, address := range util.BeaconNodeAddresses("submitter.synccommitteemessage.multinode") {
-		client, err := fetchClient(ctx, monitor, address)
+		client, err := fetchSubmissionClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for sync committee message submitter strategy", address))
 		}
@@ -1541,19 +2915,34 @@ func startMultinodeSubmitter(ctx context.Context,
 		syncCommitteeSubscriptionsSubmitters[address] = client.(eth2client.SyncCommitteeSubscriptionsSubmitter)
 	}
 
+	blsToExecutionChangesSubmitters := make(map[string]eth2client.BLSToExecutionChangesSubmitter)
+	for _, address := range util.BeaconNodeAddresses("submitter.blstoexecutionchange.multinode") {
+		client, err := fetchClient(ctx, monitor, address)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for BLS to execution change submitter strategy", address))
+		}
+		blsToExecutionChangesSubmitters[address] = client.(eth2client.BLSToExecutionChangesSubmitter)
+	}
+
 	submitter, err := multinodesubmitter.New(ctx,
 		multinodesubmitter.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 		multinodesubmitter.WithProcessConcurrency(util.ProcessConcurrency("submitter.multinode")),
 		multinodesubmitter.WithLogLevel(util.LogLevel("submitter.multinode")),
 		multinodesubmitter.WithTimeout(util.Timeout("submitter.multinode")),
 		multinodesubmitter.WithProposalSubmitters(proposalSubmitters),
+		multinodesubmitter.WithProposalTimeout(util.Timeout("submitter.proposal.multinode")),
+		multinodesubmitter.WithProposalPublishPolicy(viper.GetString("submitter.proposal.publish-policy")),
 		multinodesubmitter.WithAttestationsSubmitters(attestationsSubmitters),
+		multinodesubmitter.WithAttestationsSplitThreshold(viper.GetInt("submitter.attestation.split-threshold")),
 		multinodesubmitter.WithSyncCommitteeMessagesSubmitters(syncCommitteeMessagesSubmitters),
 		multinodesubmitter.WithSyncCommitteeContributionsSubmitters(syncCommitteeContributionsSubmitters),
 		multinodesubmitter.WithSyncCommitteeSubscriptionsSubmitters(syncCommitteeSubscriptionsSubmitters),
 		multinodesubmitter.WithAggregateAttestationsSubmitters(aggregateAttestationSubmitters),
 		multinodesubmitter.WithBeaconCommitteeSubscriptionsSubmitters(beaconCommitteeSubscriptionsSubmitters),
 		multinodesubmitter.WithProposalPreparationsSubmitters(proposalPreparationSubmitters),
+		multinodesubmitter.WithBLSToExecutionChangesSubmitters(blsToExecutionChangesSubmitters),
+		multinodesubmitter.WithBeaconNodeQuotas(beaconNodeQuotas),
+		multinodesubmitter.WithNodeMonitor(nodeMonitor),
 	)
 	if err != nil {
 		return nil, err
@@ -1576,6 +2965,30 @@ func runCommands(ctx context.Context,
 		return proposerConfigCheck(ctx, majordomo)
 	}
 
+	if viper.GetString("validator-registration-preview") != "" {
+		return validatorRegistrationPreview(ctx, majordomo)
+	}
+
+	if viper.GetBool("fork-rehearsal") {
+		return forkRehearsal(ctx)
+	}
+
+	if pflag.Arg(0) == "handoff" {
+		return handoff(ctx, majordomo)
+	}
+
+	if pflag.Arg(0) == "exit" {
+		return exitCommand(ctx, majordomo)
+	}
+
+	if pflag.Arg(0) == "bls-to-execution-change" {
+		return blsToExecutionChangeCommand(ctx, majordomo)
+	}
+
+	if pflag.Arg(0) == "slashing-protection" {
+		return slashingProtectionCommand(ctx)
+	}
+
 	return false
 }
 
@@ -1615,127 +3028,117 @@ func consensusClientCapabilities(ctx context.Context, consensusClient eth2client
 	return altairCapable, bellatrixCapable, capellaCapable, nil
 }
 
-func startBlockRelay(ctx context.Context,
+// startLocalBlockRelay starts a block relay that only provides execution
+// configuration, for use when relays are disabled.
+func startLocalBlockRelay(ctx context.Context,
 	majordomo majordomo.Service,
-	monitor metrics.Service,
-	eth2Client eth2client.Service,
 	scheduler scheduler.Service,
 	chainTime chaintime.Service,
 	accountManager accountmanager.Service,
-	signerSvc signer.Service,
 ) (
 	blockrelay.Service,
 	error,
 ) {
-	builderBidProvider, err := selectBuilderBidProvider(ctx, monitor, eth2Client, chainTime)
+	fallbackFeeRecipient, err := fallbackFeeRecipientFromConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	// We also need to submit validator registrations to all nodes that are acting as blinded beacon block proposers, as
-	// some of them use the registration as part of the condition to decide if the blinded block should be called or not.
-	nodeAddresses := util.BeaconNodeAddressesForProposing()
-	secondaryValidatorRegistrationsSubmitters := make([]eth2client.ValidatorRegistrationsSubmitter, 0, len(nodeAddresses))
-	for _, address := range nodeAddresses {
-		client, err := fetchClient(ctx, monitor, address)
-		if err != nil {
-			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for secondary validator registration", address))
-		}
-		secondaryValidatorRegistrationsSubmitters = append(secondaryValidatorRegistrationsSubmitters, client.(eth2client.ValidatorRegistrationsSubmitter))
+	blockRelay, err := localblockrelay.New(ctx,
+		localblockrelay.WithLogLevel(util.LogLevel("blockrelay")),
+		localblockrelay.WithMajordomo(majordomo),
+		localblockrelay.WithScheduler(scheduler),
+		localblockrelay.WithChainTime(chainTime),
+		localblockrelay.WithConfigURL(viper.GetString("blockrelay.config.url")),
+		localblockrelay.WithFallbackFeeRecipient(fallbackFeeRecipient),
+		localblockrelay.WithFallbackGasLimit(viper.GetUint64("blockrelay.fallback-gas-limit")),
+		localblockrelay.WithClientCertURL(viper.GetString("blockrelay.config.client-cert")),
+		localblockrelay.WithClientKeyURL(viper.GetString("blockrelay.config.client-key")),
+		localblockrelay.WithCACertURL(viper.GetString("blockrelay.config.ca-cert")),
+		localblockrelay.WithVerificationKeyURL(viper.GetString("blockrelay.config.verification-key")),
+		localblockrelay.WithSignatureURL(viper.GetString("blockrelay.config.signature")),
+		localblockrelay.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
+		localblockrelay.WithFeeRecipientsFile(feeRecipientsFileFromConfig()),
+		localblockrelay.WithFeeRecipientsReloadInterval(viper.GetDuration("blockrelay.fee-recipients.reload-interval")),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start local block relay")
 	}
 
+	return blockRelay, nil
+}
+
+// fallbackFeeRecipientFromConfig obtains the fallback fee recipient from the configuration.
+func fallbackFeeRecipientFromConfig() (bellatrix.ExecutionAddress, error) {
 	var fallbackFeeRecipient bellatrix.ExecutionAddress
 	feeRecipient, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("blockrelay.fallback-fee-recipient"), "0x"))
 	if err != nil {
-		return nil, errors.New("blockrelay: invalid fallback fee recipient")
+		return fallbackFeeRecipient, errors.New("blockrelay: invalid fallback fee recipient")
 	}
 	if len(feeRecipient) == 0 {
-		return nil, errors.New("blockrelay: no fallback fee recipient supplied")
+		return fallbackFeeRecipient, errors.New("blockrelay: no fallback fee recipient supplied")
 	}
 	if len(feeRecipient) != len(fallbackFeeRecipient) {
-		return nil, errors.New("blockrelay: incorrect length for fallback fee recipient")
+		return fallbackFeeRecipient, errors.New("blockrelay: incorrect length for fallback fee recipient")
 	}
 	copy(fallbackFeeRecipient[:], feeRecipient)
 	if fallbackFeeRecipient.IsZero() {
-		return nil, errors.New("blockrelay: fee recipient supplied is zero")
+		return fallbackFeeRecipient, errors.New("blockrelay: fee recipient supplied is zero")
+	}
+
+	return fallbackFeeRecipient, nil
+}
+
+// feeRecipientsFileFromConfig obtains the path of the fee recipients file from the configuration,
+// or an empty string if none is configured.
+func feeRecipientsFileFromConfig() string {
+	if viper.GetString("blockrelay.fee-recipients.path") == "" {
+		return ""
 	}
 
-	excludedBuilders := make([]phase0.BLSPubKey, len(viper.GetStringSlice("blockrelay.excluded-builders")))
-	for i, excludedBuilder := range viper.GetStringSlice("blockrelay.excluded-builders") {
-		tmp, err := hex.DecodeString(strings.TrimPrefix(excludedBuilder, "0x"))
+	return resolvePath(viper.GetString("blockrelay.fee-recipients.path"))
+}
+
+// pubKeysFromConfig obtains a list of public keys from the given configuration key.
+func pubKeysFromConfig(key string) ([]phase0.BLSPubKey, error) {
+	return parsePubKeys(viper.GetStringSlice(key))
+}
+
+// parsePubKeys parses hex-encoded public keys.
+func parsePubKeys(pubKeyStrs []string) ([]phase0.BLSPubKey, error) {
+	pubKeys := make([]phase0.BLSPubKey, len(pubKeyStrs))
+	for i, pubKeyStr := range pubKeyStrs {
+		tmp, err := hex.DecodeString(strings.TrimPrefix(pubKeyStr, "0x"))
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode excluded builder")
+			return nil, errors.Wrap(err, "failed to decode public key")
 		}
 		if len(tmp) != phase0.PublicKeyLength {
-			return nil, errors.New("incorrect length for excluded builder")
-		}
-		copy(excludedBuilders[i][:], tmp)
-	}
-
-	var blockRelay blockrelay.Service
-	blockRelay, err = standardblockrelay.New(ctx,
-		standardblockrelay.WithLogLevel(util.LogLevel("blockrelay")),
-		standardblockrelay.WithMonitor(monitor),
-		standardblockrelay.WithMajordomo(majordomo),
-		standardblockrelay.WithScheduler(scheduler),
-		standardblockrelay.WithChainTime(chainTime),
-		standardblockrelay.WithConfigURL(viper.GetString("blockrelay.config.url")),
-		standardblockrelay.WithFallbackFeeRecipient(fallbackFeeRecipient),
-		standardblockrelay.WithFallbackGasLimit(viper.GetUint64("blockrelay.fallback-gas-limit")),
-		standardblockrelay.WithClientCertURL(viper.GetString("blockrelay.config.client-cert")),
-		standardblockrelay.WithClientKeyURL(viper.GetString("blockrelay.config.client-key")),
-		standardblockrelay.WithCACertURL(viper.GetString("blockrelay.config.ca-cert")),
-		standardblockrelay.WithAccountsProvider(accountManager.(accountmanager.AccountsProvider)),
-		standardblockrelay.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
-		standardblockrelay.WithListenAddress(viper.GetString("blockrelay.listen-address")),
-		standardblockrelay.WithValidatorRegistrationSigner(signerSvc.(signer.ValidatorRegistrationSigner)),
-		standardblockrelay.WithSecondaryValidatorRegistrationsSubmitters(secondaryValidatorRegistrationsSubmitters),
-		standardblockrelay.WithLogResults(viper.GetBool("blockrelay.log-results")),
-		standardblockrelay.WithReleaseVersion(ReleaseVersion),
-		standardblockrelay.WithBuilderBidProvider(builderBidProvider),
-		standardblockrelay.WithExcludedBuilders(excludedBuilders),
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to start block relay")
+			return nil, errors.New("incorrect length for public key")
+		}
+		copy(pubKeys[i][:], tmp)
 	}
 
-	return blockRelay, nil
+	return pubKeys, nil
 }
 
-// selectBuilderBidProvider selects the provider for builder bids.
-// Builder bids are blinded execution payload headers provided by relays.
-func selectBuilderBidProvider(ctx context.Context,
-	monitor metrics.Service,
-	eth2Client eth2client.Service,
-	chainTime chaintime.Service,
-) (
-	builderbid.Provider,
-	error,
-) {
-	log.Trace().Msg("Selecting builder bid strategy")
-
-	var provider builderbid.Provider
-	var err error
-
-	switch viper.GetString("strategies.builderbid.style") {
-	case "best", "":
-		log.Info().Msg("Starting best builder bid strategy")
-		provider, err = bestbuilderbidstrategy.New(ctx,
-			bestbuilderbidstrategy.WithLogLevel(util.LogLevel("strategies.builderbid.best")),
-			bestbuilderbidstrategy.WithMonitor(monitor),
-			bestbuilderbidstrategy.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
-			bestbuilderbidstrategy.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
-			bestbuilderbidstrategy.WithChainTime(chainTime),
-			bestbuilderbidstrategy.WithTimeout(util.Timeout("strategies.builderbid.best")),
-			bestbuilderbidstrategy.WithReleaseVersion(ReleaseVersion),
-		)
-	default:
-		err = fmt.Errorf("unknown builder bid strategy %s", viper.GetString("strategies.builderbid.style"))
+// dutyStatementKeyFromConfig obtains the key used to sign duty statements, if configured.
+// The key is a hex-encoded ed25519 seed.
+func dutyStatementKeyFromConfig(ctx context.Context, majordomo majordomo.Service) (ed25519.PrivateKey, error) {
+	if viper.GetString("controller.duty-statements.key") == "" {
+		return nil, nil
 	}
 
+	data, err := majordomo.Fetch(ctx, viper.GetString("controller.duty-statements.key"))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to instantiate builder bid strategy")
+		return nil, errors.Wrap(err, "failed to obtain key")
+	}
+	seed, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode key")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.New("incorrect length for key")
 	}
 
-	return provider, nil
+	return ed25519.NewKeyFromSeed(seed), nil
 }
@@ -0,0 +1,43 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/attestantio/vouch/services/adminapi"
+)
+
+// handleMaintenanceSignal enters maintenance mode when Vouch receives SIGUSR1.
+func handleMaintenanceSignal(ctx context.Context, maintenanceController adminapi.MaintenanceController) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				signal.Stop(sigCh)
+				return
+			case <-sigCh:
+				log.Info().Msg("Maintenance signal received")
+				maintenanceController.EnterMaintenance(ctx)
+			}
+		}
+	}()
+}
@@ -14,6 +14,8 @@
 package main
 
 import (
+	"strings"
+
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,8 +24,10 @@ import (
 var metricsNamespace = "vouch"
 
 var (
-	releaseMetric *prometheus.GaugeVec
-	readyMetric   prometheus.Gauge
+	releaseMetric       *prometheus.GaugeVec
+	readyMetric         prometheus.Gauge
+	buildInfoMetric     *prometheus.GaugeVec
+	buildStrategyMetric *prometheus.GaugeVec
 )
 
 func registerMetrics(monitor metrics.Service) error {
@@ -73,9 +77,48 @@ func registerPrometheusMetrics() error {
 		return errors.Wrap(err, "failed to regsiter ready")
 	}
 
+	buildInfoMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "build_info",
+		Help:      "Information about the build of this instance.",
+	}, []string{"version", "commit", "go_version", "forks"})
+	if err := prometheus.Register(buildInfoMetric); err != nil {
+		return errors.Wrap(err, "failed to register build_info")
+	}
+
+	buildStrategyMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "build_strategy",
+		Help:      "The style in use for each strategy of this instance.",
+	}, []string{"strategy", "style"})
+	if err := prometheus.Register(buildStrategyMetric); err != nil {
+		return errors.Wrap(err, "failed to register build_strategy")
+	}
+
 	return nil
 }
 
+// setBuildInfo is called when the build information is established.
+func setBuildInfo(info *buildInfo) {
+	if buildInfoMetric == nil {
+		return
+	}
+
+	buildInfoMetric.WithLabelValues(info.Version, info.Commit, info.GoVersion, strings.Join(info.Forks, ",")).Set(1)
+	for strategy, style := range info.Strategies {
+		buildStrategyMetric.WithLabelValues(strategy, style).Set(1)
+	}
+}
+
+// setBuildStrategy is called when a strategy is started.
+func setBuildStrategy(strategy string, style string) {
+	if buildStrategyMetric == nil {
+		return
+	}
+
+	buildStrategyMetric.WithLabelValues(strategy, style).Set(1)
+}
+
 // SetRelease is called when the release version is established.
 func setRelease(version string) {
 	if releaseMetric == nil {
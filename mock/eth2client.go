@@ -19,6 +19,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -529,6 +530,47 @@ func (m *SleepyProposalPreparationsSubmitter) SubmitProposalPreparations(ctx con
 	return m.next.SubmitProposalPreparations(ctx, preparations)
 }
 
+// BLSToExecutionChangesSubmitter is a mock for eth2client.BLSToExecutionChangesSubmitter.
+type BLSToExecutionChangesSubmitter struct {
+	mu      sync.Mutex
+	changes []*capella.SignedBLSToExecutionChange
+}
+
+// NewBLSToExecutionChangesSubmitter returns a mock BLS to execution changes submitter.
+func NewBLSToExecutionChangesSubmitter() *BLSToExecutionChangesSubmitter {
+	return &BLSToExecutionChangesSubmitter{}
+}
+
+// SubmitBLSToExecutionChanges is a mock.
+func (m *BLSToExecutionChangesSubmitter) SubmitBLSToExecutionChanges(_ context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.changes = append(m.changes, changes...)
+
+	return nil
+}
+
+// Changes returns the changes submitted to the mock.
+func (m *BLSToExecutionChangesSubmitter) Changes() []*capella.SignedBLSToExecutionChange {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.changes
+}
+
+// ErroringBLSToExecutionChangesSubmitter is a mock for eth2client.BLSToExecutionChangesSubmitter that returns errors.
+type ErroringBLSToExecutionChangesSubmitter struct{}
+
+// NewErroringBLSToExecutionChangesSubmitter returns a mock BLS to execution changes submitter that returns errors.
+func NewErroringBLSToExecutionChangesSubmitter() eth2client.BLSToExecutionChangesSubmitter {
+	return &ErroringBLSToExecutionChangesSubmitter{}
+}
+
+// SubmitBLSToExecutionChanges is a mock.
+func (*ErroringBLSToExecutionChangesSubmitter) SubmitBLSToExecutionChanges(_ context.Context, _ []*capella.SignedBLSToExecutionChange) error {
+	return errors.New("error")
+}
+
 // BeaconCommitteeSubscriptionsSubmitter is a mock for eth2client.BeaconCommitteeSubscriptionsSubmitter.
 type BeaconCommitteeSubscriptionsSubmitter struct{}
 
@@ -1231,6 +1273,7 @@ func (*SpecProvider) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map
 				// Mainnet params (give or take).
 				"DOMAIN_AGGREGATE_AND_PROOF":               phase0.DomainType{0x06, 0x00, 0x00, 0x00},
 				"DOMAIN_BEACON_ATTESTER":                   phase0.DomainType{0x00, 0x00, 0x00, 0x00},
+				"DOMAIN_BLS_TO_EXECUTION_CHANGE":           phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
 				"DOMAIN_BEACON_PROPOSER":                   phase0.DomainType{0x01, 0x00, 0x00, 0x00},
 				"DOMAIN_CONTRIBUTION_AND_PROOF":            phase0.DomainType{0x09, 0x00, 0x00, 0x00},
 				"DOMAIN_DEPOSIT":                           phase0.DomainType{0x03, 0x00, 0x00, 0x00},
@@ -0,0 +1,156 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	partitionedaccountmanager "github.com/attestantio/vouch/services/accountmanager/partitioned"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// partitioningPartner is the configuration for a partner instance.
+type partitioningPartner struct {
+	Instance uint64 `mapstructure:"instance"`
+	CheckURL string `mapstructure:"check-url"`
+}
+
+// partitioningAssignment is the configuration for the validators assigned to an instance.
+type partitioningAssignment struct {
+	Instance    uint64   `mapstructure:"instance"`
+	IndexRanges []string `mapstructure:"index-ranges"`
+	PubKeys     []string `mapstructure:"pubkeys"`
+}
+
+// startPartitionedAccountManager restricts the account manager to the
+// validators for which this instance is responsible, if configured.
+func startPartitionedAccountManager(ctx context.Context,
+	monitor metrics.Service,
+	accountManager accountmanager.Service,
+) (
+	accountmanager.Service,
+	error,
+) {
+	if viper.GetUint64("partitioning.instances") == 0 {
+		return accountManager, nil
+	}
+
+	partnersConfig := make([]*partitioningPartner, 0)
+	if err := viper.UnmarshalKey("partitioning.partners", &partnersConfig); err != nil {
+		return nil, errors.Wrap(err, "invalid partitioning partners")
+	}
+	partners := make(map[uint64]string, len(partnersConfig))
+	for _, partner := range partnersConfig {
+		if _, exists := partners[partner.Instance]; exists {
+			return nil, fmt.Errorf("duplicate partitioning partner %d", partner.Instance)
+		}
+		partners[partner.Instance] = partner.CheckURL
+	}
+
+	strategy, err := partitionedaccountmanager.ParseStrategy(viper.GetString("partitioning.strategy"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid partitioning strategy")
+	}
+	assignments, err := partitioningAssignments()
+	if err != nil {
+		return nil, err
+	}
+
+	partitioned, err := partitionedaccountmanager.New(ctx,
+		partitionedaccountmanager.WithLogLevel(util.LogLevel("accountmanager.partitioned")),
+		partitionedaccountmanager.WithMonitor(monitor),
+		partitionedaccountmanager.WithAccountManager(accountManager),
+		partitionedaccountmanager.WithInstances(viper.GetUint64("partitioning.instances")),
+		partitionedaccountmanager.WithInstance(viper.GetUint64("partitioning.instance")),
+		partitionedaccountmanager.WithStrategy(strategy),
+		partitionedaccountmanager.WithAssignments(assignments),
+		partitionedaccountmanager.WithPartners(partners),
+		partitionedaccountmanager.WithCheckInterval(viper.GetDuration("partitioning.check-interval")),
+		partitionedaccountmanager.WithTakeoverFailures(viper.GetUint64("partitioning.takeover-failures")),
+		partitionedaccountmanager.WithTimeout(util.Timeout("partitioning")),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start partitioned account manager")
+	}
+	log.Info().
+		Uint64("instance", viper.GetUint64("partitioning.instance")).
+		Uint64("instances", viper.GetUint64("partitioning.instances")).
+		Str("strategy", viper.GetString("partitioning.strategy")).
+		Int("partners", len(partners)).
+		Msg("Partitioning validators between instances")
+
+	return partitioned, nil
+}
+
+// partitioningAssignments obtains the validators explicitly assigned to each instance.
+func partitioningAssignments() (map[uint64]*partitionedaccountmanager.Assignment, error) {
+	assignmentsConfig := make([]*partitioningAssignment, 0)
+	if err := viper.UnmarshalKey("partitioning.assignments", &assignmentsConfig); err != nil {
+		return nil, errors.Wrap(err, "invalid partitioning assignments")
+	}
+
+	assignments := make(map[uint64]*partitionedaccountmanager.Assignment, len(assignmentsConfig))
+	for _, assignmentConfig := range assignmentsConfig {
+		if _, exists := assignments[assignmentConfig.Instance]; exists {
+			return nil, fmt.Errorf("duplicate partitioning assignment for instance %d", assignmentConfig.Instance)
+		}
+		assignment := &partitionedaccountmanager.Assignment{
+			IndexRanges: make([]partitionedaccountmanager.IndexRange, 0, len(assignmentConfig.IndexRanges)),
+		}
+		for _, input := range assignmentConfig.IndexRanges {
+			indexRange, err := parseIndexRange(input)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid index range for instance %d", assignmentConfig.Instance))
+			}
+			assignment.IndexRanges = append(assignment.IndexRanges, indexRange)
+		}
+		pubKeys, err := parsePubKeys(assignmentConfig.PubKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key for instance %d", assignmentConfig.Instance))
+		}
+		assignment.PubKeys = pubKeys
+		assignments[assignmentConfig.Instance] = assignment
+	}
+
+	return assignments, nil
+}
+
+// parseIndexRange parses an index range of the form "from-to", or a single index.
+func parseIndexRange(input string) (partitionedaccountmanager.IndexRange, error) {
+	fromStr, toStr, isRange := strings.Cut(strings.TrimSpace(input), "-")
+	if !isRange {
+		toStr = fromStr
+	}
+	from, err := strconv.ParseUint(strings.TrimSpace(fromStr), 10, 64)
+	if err != nil {
+		return partitionedaccountmanager.IndexRange{}, errors.Wrap(err, "invalid start of range")
+	}
+	to, err := strconv.ParseUint(strings.TrimSpace(toStr), 10, 64)
+	if err != nil {
+		return partitionedaccountmanager.IndexRange{}, errors.Wrap(err, "invalid end of range")
+	}
+
+	return partitionedaccountmanager.IndexRange{
+		From: phase0.ValidatorIndex(from),
+		To:   phase0.ValidatorIndex(to),
+	}, nil
+}
@@ -0,0 +1,211 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// markRunning marks Vouch as running by creating the safe mode flag file.  It
+// returns true if the flag file was already present, implying that the previous
+// instance of Vouch did not shut down cleanly.
+func markRunning() (bool, error) {
+	if !viper.GetBool("safe-mode.enable") {
+		return false, nil
+	}
+
+	flagFile := resolvePath(viper.GetString("safe-mode.flag-file"))
+	dirty := false
+	if _, err := os.Stat(flagFile); err == nil {
+		dirty = true
+	} else if !os.IsNotExist(err) {
+		return false, errors.Wrap(err, "failed to check safe mode flag file")
+	}
+
+	if err := os.WriteFile(flagFile, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0o600); err != nil {
+		return false, errors.Wrap(err, "failed to create safe mode flag file")
+	}
+
+	return dirty, nil
+}
+
+// markStopped marks Vouch as having shut down cleanly by removing the safe mode flag file.
+func markStopped() {
+	if !viper.GetBool("safe-mode.enable") {
+		return
+	}
+
+	if err := os.Remove(resolvePath(viper.GetString("safe-mode.flag-file"))); err != nil {
+		log.Warn().Err(err).Msg("Failed to remove safe mode flag file")
+	}
+}
+
+// runSafeMode delays the start of duties for a full epoch, then inspects the
+// chain to ensure that no other instance is signing for our validators before
+// allowing Vouch to continue.  If configured to await inclusion it also waits
+// for the following epoch, during which attestations for the full epoch can
+// still be included.
+func runSafeMode(ctx context.Context,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	accountManager accountmanager.Service,
+) error {
+	// The epoch that we observe is the first full epoch after startup.  We
+	// resume once it has ended or, if awaiting inclusion, once the inclusion
+	// window for its attestations has closed.
+	epoch := chainTime.CurrentEpoch() + 1
+	lastEpoch := epoch
+	if viper.GetBool("safe-mode.await-inclusion") {
+		lastEpoch++
+	}
+	resumeTime := chainTime.StartOfEpoch(lastEpoch + 1)
+	log.Warn().
+		Uint64("observed_epoch", uint64(epoch)).
+		Str("resume_time", resumeTime.String()).
+		Msg("Previous instance did not shut down cleanly; delaying duties in safe mode")
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(sigCh)
+	select {
+	case <-sigCh:
+		return errors.New("signal received")
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(resumeTime)):
+	}
+
+	validatingAccounts, err := accountManager.(accountmanager.ValidatingAccountsProvider).ValidatingAccountsForEpoch(ctx, epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validating accounts")
+	}
+	if len(validatingAccounts) == 0 {
+		log.Info().Msg("No validating accounts; leaving safe mode")
+		return nil
+	}
+
+	conflicts, err := safeModeConflicts(ctx, eth2Client, chainTime, epoch, lastEpoch, validatingAccounts)
+	if err != nil {
+		return errors.Wrap(err, "failed to inspect chain for conflicting signatures")
+	}
+	if len(conflicts) > 0 {
+		for _, conflict := range conflicts {
+			log.Error().Str("conflict", conflict).Msg("Validator activity detected while in safe mode")
+		}
+		return fmt.Errorf("%d validators were active while in safe mode; another instance may be signing for them", len(conflicts))
+	}
+
+	log.Info().Uint64("observed_epoch", uint64(epoch)).Msg("No conflicting signatures found; leaving safe mode")
+
+	return nil
+}
+
+// safeModeConflicts returns a description of any attestations or proposals by our
+// validators found on chain for the given epoch.  Attestations for the epoch can
+// be included up to the end of the following epoch, so blocks up to the given
+// last epoch are inspected.
+func safeModeConflicts(ctx context.Context,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	epoch phase0.Epoch,
+	lastEpoch phase0.Epoch,
+	validatingAccounts map[phase0.ValidatorIndex]e2wtypes.Account,
+) (
+	[]string,
+	error,
+) {
+	committeesResponse, err := eth2Client.(eth2client.BeaconCommitteesProvider).BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: "head",
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	for _, committee := range committeesResponse.Data {
+		if _, exists := committees[committee.Slot]; !exists {
+			committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		committees[committee.Slot][committee.Index] = committee.Validators
+	}
+
+	conflicts := make([]string, 0)
+	for slot := chainTime.FirstSlotOfEpoch(epoch); slot < chainTime.FirstSlotOfEpoch(lastEpoch+1); slot++ {
+		blockResponse, err := eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// Empty slot.
+				continue
+			}
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if blockResponse == nil || blockResponse.Data == nil {
+			// Empty slot.
+			continue
+		}
+		block := blockResponse.Data
+
+		if chainTime.SlotToEpoch(slot) == epoch {
+			proposerIndex, err := block.ProposerIndex()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain proposer index")
+			}
+			if _, exists := validatingAccounts[proposerIndex]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("validator %d proposed block at slot %d", proposerIndex, slot))
+			}
+		}
+
+		attestations, err := block.Attestations()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain attestations")
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Target.Epoch != epoch {
+				continue
+			}
+			committee, exists := committees[attestation.Data.Slot][attestation.Data.Index]
+			if !exists {
+				continue
+			}
+			for i := uint64(0); i < attestation.AggregationBits.Len() && i < uint64(len(committee)); i++ {
+				if !attestation.AggregationBits.BitAt(i) {
+					continue
+				}
+				if _, exists := validatingAccounts[committee[i]]; exists {
+					conflicts = append(conflicts, fmt.Sprintf("validator %d attested for slot %d", committee[i], attestation.Data.Slot))
+				}
+			}
+		}
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,278 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestMarkRunning(t *testing.T) {
+	flagFile := filepath.Join(t.TempDir(), "vouch.running")
+	viper.Set("safe-mode.flag-file", flagFile)
+	defer viper.Set("safe-mode.enable", false)
+
+	// Disabled.
+	viper.Set("safe-mode.enable", false)
+	dirty, err := markRunning()
+	require.NoError(t, err)
+	require.False(t, dirty)
+	require.NoFileExists(t, flagFile)
+
+	// Clean start.
+	viper.Set("safe-mode.enable", true)
+	dirty, err = markRunning()
+	require.NoError(t, err)
+	require.False(t, dirty)
+	require.FileExists(t, flagFile)
+
+	// Start without a clean shutdown.
+	dirty, err = markRunning()
+	require.NoError(t, err)
+	require.True(t, dirty)
+
+	// Clean shutdown then start.
+	markStopped()
+	require.NoFileExists(t, flagFile)
+	dirty, err = markRunning()
+	require.NoError(t, err)
+	require.False(t, dirty)
+
+	// Disabled shutdown leaves the flag file in place.
+	viper.Set("safe-mode.enable", false)
+	markStopped()
+	require.FileExists(t, flagFile)
+}
+
+func TestMarkRunningBadFlagFile(t *testing.T) {
+	viper.Set("safe-mode.enable", true)
+	viper.Set("safe-mode.flag-file", filepath.Join(t.TempDir(), "missing", "vouch.running"))
+	defer viper.Set("safe-mode.enable", false)
+
+	_, err := markRunning()
+	require.ErrorContains(t, err, "failed to create safe mode flag file")
+}
+
+// safeModeClient provides a single committee for every slot and blocks for a
+// fixed set of slots.
+type safeModeClient struct {
+	chainTime  *standardchaintime.Service
+	validators []phase0.ValidatorIndex
+	blocks     map[phase0.Slot]*phase0.SignedBeaconBlock
+}
+
+func (*safeModeClient) Name() string {
+	return "safe mode client"
+}
+
+func (*safeModeClient) Address() string {
+	return "localhost"
+}
+
+func (c *safeModeClient) BeaconCommittees(_ context.Context,
+	opts *api.BeaconCommitteesOpts,
+) (
+	*api.Response[[]*apiv1.BeaconCommittee],
+	error,
+) {
+	committees := make([]*apiv1.BeaconCommittee, 0)
+	for slot := c.chainTime.FirstSlotOfEpoch(*opts.Epoch); slot < c.chainTime.FirstSlotOfEpoch(*opts.Epoch+1); slot++ {
+		committees = append(committees, &apiv1.BeaconCommittee{
+			Slot:       slot,
+			Index:      0,
+			Validators: c.validators,
+		})
+	}
+
+	return &api.Response[[]*apiv1.BeaconCommittee]{
+		Data: committees,
+	}, nil
+}
+
+func (c *safeModeClient) SignedBeaconBlock(_ context.Context,
+	opts *api.SignedBeaconBlockOpts,
+) (
+	*api.Response[*spec.VersionedSignedBeaconBlock],
+	error,
+) {
+	for slot, block := range c.blocks {
+		if opts.Block == fmt.Sprintf("%d", slot) {
+			return &api.Response[*spec.VersionedSignedBeaconBlock]{
+				Data: &spec.VersionedSignedBeaconBlock{
+					Version: spec.DataVersionPhase0,
+					Phase0:  block,
+				},
+			}, nil
+		}
+	}
+
+	return nil, &api.Error{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+func safeModeBlock(slot phase0.Slot, proposer phase0.ValidatorIndex, attestations ...*phase0.Attestation) *phase0.SignedBeaconBlock {
+	return &phase0.SignedBeaconBlock{
+		Message: &phase0.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			Body: &phase0.BeaconBlockBody{
+				Attestations: attestations,
+			},
+		},
+	}
+}
+
+func safeModeAttestation(slot phase0.Slot, target phase0.Epoch, bits ...uint64) *phase0.Attestation {
+	aggregationBits := bitfield.NewBitlist(4)
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+
+	return &phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Index:  0,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{
+				Epoch: target,
+			},
+		},
+	}
+}
+
+func TestSafeModeConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	// Validator 11, at position 1 in the committee, is ours.
+	validators := []phase0.ValidatorIndex{10, 11, 12, 13}
+	validatingAccounts := map[phase0.ValidatorIndex]e2wtypes.Account{
+		11: nil,
+	}
+	epoch := phase0.Epoch(2)
+	firstSlot := chainTime.FirstSlotOfEpoch(epoch)
+	nextEpochSlot := chainTime.FirstSlotOfEpoch(epoch + 1)
+
+	tests := []struct {
+		name           string
+		awaitInclusion bool
+		blocks         map[phase0.Slot]*phase0.SignedBeaconBlock
+		conflicts      []string
+	}{
+		{
+			name: "NoBlocks",
+		},
+		{
+			name: "NoConflicts",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				firstSlot:     safeModeBlock(firstSlot, 10),
+				firstSlot + 1: safeModeBlock(firstSlot+1, 12, safeModeAttestation(firstSlot, epoch, 0, 2, 3)),
+				nextEpochSlot: safeModeBlock(nextEpochSlot, 13, safeModeAttestation(nextEpochSlot-1, epoch, 0, 2)),
+			},
+		},
+		{
+			name: "ProposalConflict",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				firstSlot + 3: safeModeBlock(firstSlot+3, 11),
+			},
+			conflicts: []string{
+				fmt.Sprintf("validator 11 proposed block at slot %d", firstSlot+3),
+			},
+		},
+		{
+			name: "ProposalNextEpoch",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				nextEpochSlot: safeModeBlock(nextEpochSlot, 11),
+			},
+		},
+		{
+			name: "AttestationConflict",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				firstSlot + 2: safeModeBlock(firstSlot+2, 10, safeModeAttestation(firstSlot+1, epoch, 0, 1)),
+			},
+			conflicts: []string{
+				fmt.Sprintf("validator 11 attested for slot %d", firstSlot+1),
+			},
+		},
+		{
+			name: "AttestationIncludedNextEpochNotAwaited",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				nextEpochSlot + 5: safeModeBlock(nextEpochSlot+5, 10, safeModeAttestation(nextEpochSlot-1, epoch, 1)),
+			},
+		},
+		{
+			name:           "AttestationIncludedNextEpoch",
+			awaitInclusion: true,
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				nextEpochSlot + 5: safeModeBlock(nextEpochSlot+5, 10, safeModeAttestation(nextEpochSlot-1, epoch, 1)),
+			},
+			conflicts: []string{
+				fmt.Sprintf("validator 11 attested for slot %d", nextEpochSlot-1),
+			},
+		},
+		{
+			name:           "AttestationOtherTarget",
+			awaitInclusion: true,
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				nextEpochSlot + 1: safeModeBlock(nextEpochSlot+1, 10, safeModeAttestation(nextEpochSlot, epoch+1, 1)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &safeModeClient{
+				chainTime:  chainTime,
+				validators: validators,
+				blocks:     test.blocks,
+			}
+			lastEpoch := epoch
+			if test.awaitInclusion {
+				lastEpoch++
+			}
+			conflicts, err := safeModeConflicts(ctx, client, chainTime, epoch, lastEpoch, validatingAccounts)
+			require.NoError(t, err)
+			if test.conflicts == nil {
+				require.Empty(t, conflicts)
+			} else {
+				require.Equal(t, test.conflicts, conflicts)
+			}
+		})
+	}
+}
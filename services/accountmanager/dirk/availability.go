@@ -0,0 +1,146 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dirk
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// walletAvailability is the availability of the accounts in a wallet.
+type walletAvailability struct {
+	// expected is the number of accounts the wallet is expected to provide.
+	expected int
+	// signable is the number of accounts for which signatures can be obtained.
+	signable int
+	// degraded is the number of signable accounts with at least one unreachable participant.
+	degraded int
+}
+
+// updateWalletAvailability calculates the availability of the accounts in each wallet,
+// given the reachability of the Dirk endpoints, and reports changes.
+func (s *Service) updateWalletAvailability(ctx context.Context,
+	walletAccounts map[string]map[phase0.BLSPubKey]e2wtypes.Account,
+	reachable map[string]bool,
+) {
+	// Check any participants that are not configured endpoints.
+	unchecked := make(map[string]struct{})
+	for _, accounts := range walletAccounts {
+		for _, account := range accounts {
+			if distributedAccount, isDistributedAccount := account.(e2wtypes.DistributedAccount); isDistributedAccount {
+				for _, participant := range distributedAccount.Participants() {
+					if _, exists := reachable[participant]; !exists {
+						unchecked[participant] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+	if len(unchecked) > 0 {
+		participants := make([]string, 0, len(unchecked))
+		for participant := range unchecked {
+			participants = append(participants, participant)
+		}
+		for participant, participantReachable := range s.checkEndpoints(ctx, participants) {
+			reachable[participant] = participantReachable
+		}
+	}
+
+	anyEndpointReachable := false
+	for _, endpoint := range s.endpoints {
+		if reachable[endpoint.String()] {
+			anyEndpointReachable = true
+			break
+		}
+	}
+
+	s.availabilityMu.Lock()
+	defer s.availabilityMu.Unlock()
+	for wallet, accounts := range walletAccounts {
+		availability := walletAvailability{
+			expected: len(accounts),
+		}
+		if accounts == nil {
+			// The wallet could not be opened, so retain the previous expectation.
+			availability.expected = s.availability[wallet].expected
+		}
+		for _, account := range accounts {
+			signable, degraded := accountAvailability(account, reachable, anyEndpointReachable)
+			if signable {
+				availability.signable++
+			}
+			if degraded {
+				availability.degraded++
+			}
+		}
+
+		s.monitor.WalletAccounts(wallet, "expected", availability.expected)
+		s.monitor.WalletAccounts(wallet, "signable", availability.signable)
+		s.monitor.WalletAccounts(wallet, "degraded", availability.degraded)
+
+		previous, exists := s.availability[wallet]
+		s.availability[wallet] = availability
+		if exists && previous == availability {
+			continue
+		}
+		switch {
+		case availability.signable < availability.expected:
+			log.Warn().
+				Str("wallet", wallet).
+				Int("expected", availability.expected).
+				Int("signable", availability.signable).
+				Msg("Wallet has accounts that cannot sign")
+		case availability.degraded > 0:
+			log.Warn().
+				Str("wallet", wallet).
+				Int("expected", availability.expected).
+				Int("degraded", availability.degraded).
+				Msg("Wallet has accounts with reduced signing margin")
+		case exists && (previous.signable < previous.expected || previous.degraded > 0):
+			log.Info().
+				Str("wallet", wallet).
+				Int("expected", availability.expected).
+				Msg("Wallet accounts fully available")
+		}
+	}
+}
+
+// accountAvailability returns true if a signature can be obtained for the account,
+// and true if it can but only with reduced margin.
+func accountAvailability(account e2wtypes.Account,
+	reachable map[string]bool,
+	anyEndpointReachable bool,
+) (
+	bool,
+	bool,
+) {
+	distributedAccount, isDistributedAccount := account.(e2wtypes.DistributedAccount)
+	if !isDistributedAccount {
+		// A non-distributed account is held by a single server, and can sign if any endpoint is reachable.
+		return anyEndpointReachable, false
+	}
+
+	participants := distributedAccount.Participants()
+	reachableParticipants := 0
+	for _, participant := range participants {
+		if reachable[participant] {
+			reachableParticipants++
+		}
+	}
+	signable := reachableParticipants >= int(distributedAccount.SigningThreshold())
+
+	return signable, signable && reachableParticipants < len(participants)
+}
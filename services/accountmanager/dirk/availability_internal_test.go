@@ -0,0 +1,196 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dirk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	dirk "github.com/wealdtech/go-eth2-wallet-dirk"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// distributedAccount is a distributed account with fixed participants.
+type distributedAccount struct {
+	e2wtypes.Account
+	threshold    uint32
+	participants map[uint64]string
+}
+
+func (*distributedAccount) CompositePublicKey() e2types.PublicKey {
+	return nil
+}
+
+func (a *distributedAccount) SigningThreshold() uint32 {
+	return a.threshold
+}
+
+func (a *distributedAccount) Participants() map[uint64]string {
+	return a.participants
+}
+
+// singleAccount is a non-distributed account.
+type singleAccount struct {
+	e2wtypes.Account
+}
+
+// walletMonitor records wallet account metrics.
+type walletMonitor struct {
+	walletAccounts map[string]map[string]int
+}
+
+func (*walletMonitor) Accounts(_ string, _ uint64) {}
+
+func (*walletMonitor) AccountsRefreshed(_ time.Time, _ int, _ int) {}
+
+func (*walletMonitor) AccountsRefreshFailed(_ string) {}
+
+func (m *walletMonitor) WalletAccounts(wallet string, state string, count int) {
+	if _, exists := m.walletAccounts[wallet]; !exists {
+		m.walletAccounts[wallet] = make(map[string]int)
+	}
+	m.walletAccounts[wallet][state] = count
+}
+
+func TestAccountAvailability(t *testing.T) {
+	account := &distributedAccount{
+		threshold: 2,
+		participants: map[uint64]string{
+			1: "dirk1:13141",
+			2: "dirk2:13141",
+			3: "dirk3:13141",
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		account              e2wtypes.Account
+		reachable            map[string]bool
+		anyEndpointReachable bool
+		signable             bool
+		degraded             bool
+	}{
+		{
+			name:    "DistributedAllReachable",
+			account: account,
+			reachable: map[string]bool{
+				"dirk1:13141": true,
+				"dirk2:13141": true,
+				"dirk3:13141": true,
+			},
+			anyEndpointReachable: true,
+			signable:             true,
+		},
+		{
+			name:    "DistributedOneUnreachable",
+			account: account,
+			reachable: map[string]bool{
+				"dirk1:13141": true,
+				"dirk2:13141": false,
+				"dirk3:13141": true,
+			},
+			anyEndpointReachable: true,
+			signable:             true,
+			degraded:             true,
+		},
+		{
+			name:    "DistributedBelowThreshold",
+			account: account,
+			reachable: map[string]bool{
+				"dirk1:13141": true,
+			},
+			anyEndpointReachable: true,
+		},
+		{
+			name:                 "NonDistributedReachable",
+			account:              &singleAccount{},
+			anyEndpointReachable: true,
+			signable:             true,
+		},
+		{
+			name:    "NonDistributedUnreachable",
+			account: &singleAccount{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signable, degraded := accountAvailability(test.account, test.reachable, test.anyEndpointReachable)
+			require.Equal(t, test.signable, signable)
+			require.Equal(t, test.degraded, degraded)
+		})
+	}
+}
+
+func TestUpdateWalletAvailability(t *testing.T) {
+	ctx := context.Background()
+
+	accounts := map[phase0.BLSPubKey]e2wtypes.Account{
+		{0x01}: &distributedAccount{
+			threshold: 2,
+			participants: map[uint64]string{
+				1: "dirk1:13141",
+				2: "dirk2:13141",
+				3: "dirk3:13141",
+			},
+		},
+		{0x02}: &distributedAccount{
+			threshold: 3,
+			participants: map[uint64]string{
+				1: "dirk1:13141",
+				2: "dirk2:13141",
+				3: "dirk3:13141",
+			},
+		},
+		{0x03}: &singleAccount{},
+	}
+
+	monitor := &walletMonitor{
+		walletAccounts: make(map[string]map[string]int),
+	}
+	s := &Service{
+		monitor: monitor,
+		endpoints: []*dirk.Endpoint{
+			dirk.NewEndpoint("dirk1", 13141),
+			dirk.NewEndpoint("dirk2", 13141),
+			dirk.NewEndpoint("dirk3", 13141),
+		},
+		availability: make(map[string]walletAvailability),
+	}
+
+	// All endpoints reachable.
+	s.updateWalletAvailability(ctx,
+		map[string]map[phase0.BLSPubKey]e2wtypes.Account{"wallet1": accounts},
+		map[string]bool{"dirk1:13141": true, "dirk2:13141": true, "dirk3:13141": true},
+	)
+	require.Equal(t, map[string]int{"expected": 3, "signable": 3, "degraded": 0}, monitor.walletAccounts["wallet1"])
+
+	// One endpoint unreachable.
+	s.updateWalletAvailability(ctx,
+		map[string]map[phase0.BLSPubKey]e2wtypes.Account{"wallet1": accounts},
+		map[string]bool{"dirk1:13141": true, "dirk2:13141": false, "dirk3:13141": true},
+	)
+	require.Equal(t, map[string]int{"expected": 3, "signable": 2, "degraded": 1}, monitor.walletAccounts["wallet1"])
+
+	// Wallet cannot be opened.
+	s.updateWalletAvailability(ctx,
+		map[string]map[phase0.BLSPubKey]e2wtypes.Account{"wallet1": nil},
+		map[string]bool{"dirk1:13141": false, "dirk2:13141": false, "dirk3:13141": false},
+	)
+	require.Equal(t, map[string]int{"expected": 3, "signable": 0, "degraded": 0}, monitor.walletAccounts["wallet1"])
+}
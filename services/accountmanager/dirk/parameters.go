@@ -19,6 +19,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/dutyblacklist"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	"github.com/attestantio/vouch/services/validatorsmanager"
@@ -41,6 +42,7 @@ type parameters struct {
 	validatorsManager      validatorsmanager.Service
 	farFutureEpochProvider eth2client.FarFutureEpochProvider
 	currentEpochProvider   chaintime.Service
+	dutyBlacklist          dutyblacklist.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -152,6 +154,13 @@ func WithCurrentEpochProvider(provider chaintime.Service) Parameter {
 	})
 }
 
+// WithDutyBlacklist sets the blacklist of validators for which duties are suspended.
+func WithDutyBlacklist(blacklist dutyblacklist.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyBlacklist = blacklist
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
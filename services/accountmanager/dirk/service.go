@@ -18,6 +18,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -28,8 +29,10 @@ import (
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/dutyblacklist"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/validatorsmanager"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -40,6 +43,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -55,12 +59,16 @@ type Service struct {
 	credentials          credentials.TransportCredentials
 	accounts             map[phase0.BLSPubKey]e2wtypes.Account
 	pubKeys              []phase0.BLSPubKey
+	walletAccounts       map[string]map[phase0.BLSPubKey]e2wtypes.Account
 	validatorsManager    validatorsmanager.Service
 	domainProvider       eth2client.DomainProvider
 	farFutureEpoch       phase0.Epoch
 	currentEpochProvider chaintime.Service
+	dutyBlacklist        dutyblacklist.Service
 	wallets              map[string]e2wtypes.Wallet
 	walletsMutex         sync.RWMutex
+	availability         map[string]walletAvailability
+	availabilityMu       sync.Mutex
 }
 
 // module-wide log.
@@ -124,7 +132,9 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		validatorsManager:    parameters.validatorsManager,
 		farFutureEpoch:       farFutureEpoch,
 		currentEpochProvider: parameters.currentEpochProvider,
+		dutyBlacklist:        parameters.dutyBlacklist,
 		wallets:              make(map[string]e2wtypes.Wallet),
+		availability:         make(map[string]walletAvailability),
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -143,7 +153,13 @@ func (s *Service) Refresh(ctx context.Context) {
 	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.dirk").Start(ctx, "Refresh")
 	defer span.End()
 
-	s.refreshAccounts(ctx)
+	endpoints := make([]string, 0, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		endpoints = append(endpoints, endpoint.String())
+	}
+	reachable := s.checkEndpoints(ctx, endpoints)
+	walletAccounts := s.refreshAccounts(ctx)
+	s.updateWalletAvailability(ctx, walletAccounts, reachable)
 
 	s.mutex.RLock()
 	numAccounts := len(s.accounts)
@@ -156,40 +172,183 @@ func (s *Service) Refresh(ctx context.Context) {
 	}
 }
 
-// refreshAccounts refreshes the accounts from Dirk.
-func (s *Service) refreshAccounts(ctx context.Context) {
+// refreshAccounts refreshes the accounts from Dirk, returning the accounts obtained
+// from each wallet.  Wallets that could not be opened have no entry for their accounts.
+func (s *Service) refreshAccounts(ctx context.Context) map[string]map[phase0.BLSPubKey]e2wtypes.Account {
 	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.dirk").Start(ctx, "refreshAccounts")
 	defer span.End()
+	started := time.Now()
+
+	walletAccounts := s.fetchWalletAccounts(ctx, s.accountPaths)
+
+	accounts := make(map[phase0.BLSPubKey]e2wtypes.Account)
+	for _, fetchedAccounts := range walletAccounts {
+		for k, v := range fetchedAccounts {
+			accounts[k] = v
+		}
+	}
+	log.Trace().Int("accounts", len(accounts)).Msg("Obtained accounts")
+
+	s.mutex.Lock()
+	if len(accounts) == 0 && len(s.accounts) != 0 {
+		s.mutex.Unlock()
+		log.Warn().Msg("No accounts obtained; retaining old list")
+		return walletAccounts
+	}
+	added, removed := accountChanges(s.accounts, accounts)
+	s.setAccounts(walletAccounts)
+	s.mutex.Unlock()
+	s.monitor.AccountsRefreshed(started, added, removed)
+
+	return walletAccounts
+}
+
+// RefreshSlice refreshes the accounts in a single slice of the wallets from Dirk.
+// Refreshing the final slice also refreshes account validator state from the
+// validators provider.
+func (s *Service) RefreshSlice(ctx context.Context, slice uint64, slices uint64) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.dirk").Start(ctx, "RefreshSlice", trace.WithAttributes(
+		attribute.Int64("slice", int64(slice)),
+	))
+	defer span.End()
+
+	paths := sliceAccountPaths(s.accountPaths, slice, slices)
+	if len(paths) > 0 {
+		endpoints := make([]string, 0, len(s.endpoints))
+		for _, endpoint := range s.endpoints {
+			endpoints = append(endpoints, endpoint.String())
+		}
+		reachable := s.checkEndpoints(ctx, endpoints)
+		walletAccounts := s.refreshAccountsSlice(ctx, paths)
+		s.updateWalletAvailability(ctx, walletAccounts, reachable)
+	}
+
+	if slice != slices-1 {
+		return
+	}
+
+	s.mutex.RLock()
+	numAccounts := len(s.accounts)
+	s.mutex.RUnlock()
+
+	if numAccounts > 0 {
+		if err := s.refreshValidators(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to refresh validators")
+		}
+	}
+}
+
+// refreshAccountsSlice refreshes the accounts for the wallets in the given paths,
+// retaining the accounts of all other wallets.  It returns the accounts obtained
+// from each refreshed wallet.
+func (s *Service) refreshAccountsSlice(ctx context.Context, paths []string) map[string]map[phase0.BLSPubKey]e2wtypes.Account {
+	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.dirk").Start(ctx, "refreshAccountsSlice")
+	defer span.End()
+	started := time.Now()
+
+	walletAccounts := s.fetchWalletAccounts(ctx, paths)
+
+	s.mutex.Lock()
+	oldAccounts := s.accounts
+	s.setAccounts(mergeWalletAccounts(s.walletAccounts, walletAccounts))
+	added, removed := accountChanges(oldAccounts, s.accounts)
+	s.mutex.Unlock()
+	s.monitor.AccountsRefreshed(started, added, removed)
+
+	return walletAccounts
+}
+
+// setAccounts sets the accounts from the accounts of each wallet.
+// This assumes that the mutex is held.
+func (s *Service) setAccounts(walletAccounts map[string]map[phase0.BLSPubKey]e2wtypes.Account) {
+	accounts := make(map[phase0.BLSPubKey]e2wtypes.Account)
+	pubKeys := make([]phase0.BLSPubKey, 0)
+	for _, walletAccts := range walletAccounts {
+		for k, v := range walletAccts {
+			accounts[k] = v
+			pubKeys = append(pubKeys, k)
+		}
+	}
+	s.walletAccounts = walletAccounts
+	s.accounts = accounts
+	s.pubKeys = pubKeys
+}
+
+// mergeWalletAccounts merges newly fetched wallet accounts in to the existing
+// wallet accounts.  Existing accounts are retained for wallets that could not be
+// opened or that returned no accounts.
+func mergeWalletAccounts(existing map[string]map[phase0.BLSPubKey]e2wtypes.Account,
+	fetched map[string]map[phase0.BLSPubKey]e2wtypes.Account,
+) map[string]map[phase0.BLSPubKey]e2wtypes.Account {
+	res := make(map[string]map[phase0.BLSPubKey]e2wtypes.Account, len(existing)+len(fetched))
+	for wallet, accounts := range existing {
+		res[wallet] = accounts
+	}
+	for wallet, accounts := range fetched {
+		if len(accounts) == 0 && len(existing[wallet]) != 0 {
+			log.Warn().Str("wallet", wallet).Msg("No accounts obtained for wallet; retaining old list")
+			continue
+		}
+		res[wallet] = accounts
+	}
+
+	return res
+}
+
+// sliceAccountPaths returns the account paths for the wallets in the given slice.
+// Wallets are assigned to slices in the order in which they first appear in the paths.
+func sliceAccountPaths(paths []string, slice uint64, slices uint64) []string {
+	walletSlices := make(map[string]uint64)
+	res := make([]string, 0)
+	for _, path := range paths {
+		wallet := strings.Split(path, "/")[0]
+		walletSlice, exists := walletSlices[wallet]
+		if !exists {
+			walletSlice = uint64(len(walletSlices)) % slices
+			walletSlices[wallet] = walletSlice
+		}
+		if walletSlice == slice {
+			res = append(res, path)
+		}
+	}
+
+	return res
+}
+
+// fetchWalletAccounts fetches the accounts from Dirk for the given paths, returning the
+// accounts obtained from each wallet.  Wallets that could not be opened have no entry
+// for their accounts.
+func (s *Service) fetchWalletAccounts(ctx context.Context, paths []string) map[string]map[phase0.BLSPubKey]e2wtypes.Account {
+	started := time.Now()
 
 	// Create the relevant wallets.
-	wallets := make([]e2wtypes.Wallet, 0, len(s.accountPaths))
+	wallets := make([]e2wtypes.Wallet, 0, len(paths))
+	walletAccounts := make(map[string]map[phase0.BLSPubKey]e2wtypes.Account)
 	pathsByWallet := make(map[string][]string)
-	for _, path := range s.accountPaths {
+	for _, path := range paths {
 		pathBits := strings.Split(path, "/")
 
-		var paths []string
+		var walletPaths []string
 		var exists bool
-		if paths, exists = pathsByWallet[pathBits[0]]; !exists {
-			paths = make([]string, 0)
+		if walletPaths, exists = pathsByWallet[pathBits[0]]; !exists {
+			walletPaths = make([]string, 0)
 		}
-		pathsByWallet[pathBits[0]] = append(paths, path)
+		pathsByWallet[pathBits[0]] = append(walletPaths, path)
 		wallet, err := s.openWallet(ctx, pathBits[0])
 		if err != nil {
 			log.Warn().Err(err).Str("wallet", pathBits[0]).Msg("Failed to open wallet")
+			walletAccounts[pathBits[0]] = nil
 		} else {
 			wallets = append(wallets, wallet)
 		}
 	}
 	log.Trace().Int("wallets", len(wallets)).Msg("Fetching accounts for wallets")
 
-	verificationRegexes := accountPathsToVerificationRegexes(s.accountPaths)
+	verificationRegexes := accountPathsToVerificationRegexes(paths)
 	// Fetch accounts for each wallet in parallel.
-	started := time.Now()
-	accounts := make(map[phase0.BLSPubKey]e2wtypes.Account)
-	var accountsMu sync.Mutex
+	var walletAccountsMu sync.Mutex
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	var wg sync.WaitGroup
-	pubKeys := make([]phase0.BLSPubKey, 0)
 	for i := range wallets {
 		wg.Add(1)
 		go func(ctx context.Context, sem *semaphore.Weighted, wg *sync.WaitGroup, i int, mu *sync.Mutex) {
@@ -201,29 +360,84 @@ func (s *Service) refreshAccounts(ctx context.Context) {
 			defer sem.Release(1)
 			log := log.With().Str("wallet", wallets[i].Name()).Logger()
 			log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained semaphore")
-			walletAccounts := s.fetchAccountsForWallet(ctx, wallets[i], verificationRegexes)
-			log.Trace().Dur("elapsed", time.Since(started)).Int("accounts", len(walletAccounts)).Msg("Obtained accounts")
+			fetchedAccounts := s.fetchAccountsForWallet(ctx, wallets[i], verificationRegexes)
+			log.Trace().Dur("elapsed", time.Since(started)).Int("accounts", len(fetchedAccounts)).Msg("Obtained accounts")
 			mu.Lock()
-			for k, v := range walletAccounts {
-				accounts[k] = v
-				pubKeys = append(pubKeys, k)
-			}
+			walletAccounts[wallets[i].Name()] = fetchedAccounts
 			mu.Unlock()
-			log.Trace().Dur("elapsed", time.Since(started)).Int("accounts", len(walletAccounts)).Msg("Imported accounts")
-		}(ctx, sem, &wg, i, &accountsMu)
+			log.Trace().Dur("elapsed", time.Since(started)).Int("accounts", len(fetchedAccounts)).Msg("Imported accounts")
+		}(ctx, sem, &wg, i, &walletAccountsMu)
 	}
 	wg.Wait()
-	log.Trace().Int("accounts", len(accounts)).Msg("Obtained accounts")
 
-	s.mutex.Lock()
-	if len(accounts) == 0 && len(s.accounts) != 0 {
-		s.mutex.Unlock()
-		log.Warn().Msg("No accounts obtained; retaining old list")
-		return
+	return walletAccounts
+}
+
+// accountChanges returns the number of accounts added and removed between two account sets.
+func accountChanges(oldAccounts map[phase0.BLSPubKey]e2wtypes.Account,
+	newAccounts map[phase0.BLSPubKey]e2wtypes.Account,
+) (
+	int,
+	int,
+) {
+	added := 0
+	for pubKey := range newAccounts {
+		if _, exists := oldAccounts[pubKey]; !exists {
+			added++
+		}
 	}
-	s.accounts = accounts
-	s.pubKeys = pubKeys
-	s.mutex.Unlock()
+	removed := 0
+	for pubKey := range oldAccounts {
+		if _, exists := newAccounts[pubKey]; !exists {
+			removed++
+		}
+	}
+
+	return added, removed
+}
+
+// checkEndpoints checks that each Dirk endpoint is reachable, reporting those that are not.
+// It returns the reachability of each endpoint.
+func (s *Service) checkEndpoints(ctx context.Context, endpoints []string) map[string]bool {
+	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.dirk").Start(ctx, "checkEndpoints")
+	defer span.End()
+
+	reachable := make(map[string]bool, len(endpoints))
+	var reachableMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func(ctx context.Context, endpoint string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+
+			conn, err := grpc.DialContext(ctx, endpoint,
+				grpc.WithTransportCredentials(s.credentials),
+				grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+					return util.DialContext(ctx, "tcp", address)
+				}),
+				grpc.WithBlock(),
+			)
+			if err != nil {
+				log.Warn().Str("endpoint", endpoint).Err(err).Msg("Failed to connect to endpoint")
+				s.monitor.AccountsRefreshFailed(endpoint)
+				reachableMu.Lock()
+				reachable[endpoint] = false
+				reachableMu.Unlock()
+				return
+			}
+			if err := conn.Close(); err != nil {
+				log.Debug().Str("endpoint", endpoint).Err(err).Msg("Failed to close connection to endpoint")
+			}
+			reachableMu.Lock()
+			reachable[endpoint] = true
+			reachableMu.Unlock()
+		}(ctx, endpoint)
+	}
+	wg.Wait()
+
+	return reachable
 }
 
 // openWallet opens a wallet, using an existing one if present.
@@ -324,6 +538,9 @@ func (s *Service) ValidatingAccountsForEpoch(ctx context.Context, epoch phase0.E
 		state := api.ValidatorToState(validator, nil, epoch, s.farFutureEpoch)
 		stateCount[state]++
 		if state == api.ValidatorStateActiveOngoing || state == api.ValidatorStateActiveExiting {
+			if s.blacklisted(ctx, epoch, validator.PublicKey) {
+				continue
+			}
 			account := s.accounts[validator.PublicKey]
 			log.Trace().
 				Str("name", account.Name()).
@@ -370,6 +587,9 @@ func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context, epoch p
 		}
 		state := api.ValidatorToState(validator, nil, epoch, s.farFutureEpoch)
 		if state == api.ValidatorStateActiveOngoing || state == api.ValidatorStateActiveExiting {
+			if s.blacklisted(ctx, epoch, validator.PublicKey) {
+				continue
+			}
 			s.mutex.RLock()
 			validatingAccounts[index] = s.accounts[validator.PublicKey]
 			s.mutex.RUnlock()
@@ -379,6 +599,11 @@ func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context, epoch p
 	return validatingAccounts, nil
 }
 
+// blacklisted returns true if duties for the validator are suspended by the duty blacklist.
+func (s *Service) blacklisted(ctx context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool {
+	return s.dutyBlacklist != nil && s.dutyBlacklist.Blacklisted(ctx, epoch, pubKey)
+}
+
 // accountPathsToVerificationRegexes turns account paths in to regexes to allow verification.
 func accountPathsToVerificationRegexes(paths []string) []*regexp.Regexp {
 	regexes := make([]*regexp.Regexp, 0, len(paths))
@@ -172,6 +172,61 @@ func TestAccounts(t *testing.T) {
 	}
 }
 
+func TestAccountChanges(t *testing.T) {
+	tests := []struct {
+		name            string
+		oldAccounts     map[phase0.BLSPubKey]e2wtypes.Account
+		newAccounts     map[phase0.BLSPubKey]e2wtypes.Account
+		expectedAdded   int
+		expectedRemoved int
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "Initial",
+			newAccounts: map[phase0.BLSPubKey]e2wtypes.Account{
+				{0x01}: nil,
+				{0x02}: nil,
+			},
+			expectedAdded: 2,
+		},
+		{
+			name: "Unchanged",
+			oldAccounts: map[phase0.BLSPubKey]e2wtypes.Account{
+				{0x01}: nil,
+				{0x02}: nil,
+			},
+			newAccounts: map[phase0.BLSPubKey]e2wtypes.Account{
+				{0x01}: nil,
+				{0x02}: nil,
+			},
+		},
+		{
+			name: "AddedAndRemoved",
+			oldAccounts: map[phase0.BLSPubKey]e2wtypes.Account{
+				{0x01}: nil,
+				{0x02}: nil,
+			},
+			newAccounts: map[phase0.BLSPubKey]e2wtypes.Account{
+				{0x02}: nil,
+				{0x03}: nil,
+				{0x04}: nil,
+			},
+			expectedAdded:   2,
+			expectedRemoved: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			added, removed := accountChanges(test.oldAccounts, test.newAccounts)
+			require.Equal(t, test.expectedAdded, added)
+			require.Equal(t, test.expectedRemoved, removed)
+		})
+	}
+}
+
 func setupService(ctx context.Context, t *testing.T, endpoints []string, accountPaths []string) (*Service, error) {
 	genesisTime := time.Now()
 	genesisProvider := mock.NewGenesisProvider(genesisTime)
@@ -226,3 +281,70 @@ func setupTestWallets(ctx context.Context, t *testing.T, defs []*walletDef) []e2
 
 	return wallets
 }
+
+func TestSliceAccountPaths(t *testing.T) {
+	paths := []string{"wallet1", "wallet2/account1", "wallet3", "wallet2/account2", "wallet4"}
+
+	tests := []struct {
+		name     string
+		slice    uint64
+		slices   uint64
+		expected []string
+	}{
+		{
+			name:     "Single",
+			slice:    0,
+			slices:   1,
+			expected: paths,
+		},
+		{
+			name:     "First",
+			slice:    0,
+			slices:   2,
+			expected: []string{"wallet1", "wallet3"},
+		},
+		{
+			name:     "Second",
+			slice:    1,
+			slices:   2,
+			expected: []string{"wallet2/account1", "wallet2/account2", "wallet4"},
+		},
+		{
+			name:     "Empty",
+			slice:    5,
+			slices:   8,
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, sliceAccountPaths(paths, test.slice, test.slices))
+		})
+	}
+}
+
+func TestMergeWalletAccounts(t *testing.T) {
+	existing := map[string]map[phase0.BLSPubKey]e2wtypes.Account{
+		"wallet1": {{0x01}: nil},
+		"wallet2": {{0x02}: nil},
+		"wallet3": {{0x03}: nil},
+	}
+	fetched := map[string]map[phase0.BLSPubKey]e2wtypes.Account{
+		// Updated accounts replace existing accounts.
+		"wallet1": {{0x04}: nil},
+		// Wallets that could not be opened retain existing accounts.
+		"wallet2": nil,
+		// Wallets that return no accounts retain existing accounts.
+		"wallet3": {},
+		// New wallets are added.
+		"wallet4": {{0x05}: nil},
+	}
+
+	require.Equal(t, map[string]map[phase0.BLSPubKey]e2wtypes.Account{
+		"wallet1": {{0x04}: nil},
+		"wallet2": {{0x02}: nil},
+		"wallet3": {{0x03}: nil},
+		"wallet4": {{0x05}: nil},
+	}, mergeWalletAccounts(existing, fetched))
+}
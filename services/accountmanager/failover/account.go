@@ -0,0 +1,351 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// account is an account that signs with a primary account, falling back to
+// a secondary account if the primary account fails to sign.  Either account
+// may be nil if the validator is only known to one of the account managers.
+type account struct {
+	pubKey             phase0.BLSPubKey
+	primary            e2wtypes.Account
+	fallback           e2wtypes.Account
+	slashingProtection slashingprotection.Service
+}
+
+// ErrRefused is returned when the failover account manager refuses to sign a
+// message because it could be slashable given the messages already signed.
+var ErrRefused = errors.New("refusing to sign potentially slashable message")
+
+// dirkContextDone is the message of the untyped error returned by Dirk when
+// the context is done before enough signers have responded.
+const dirkContextDone = "context done"
+
+func (s *Service) newAccount(pubKey phase0.BLSPubKey, primary e2wtypes.Account, fallback e2wtypes.Account) *account {
+	return &account{
+		pubKey:             pubKey,
+		primary:            primary,
+		fallback:           fallback,
+		slashingProtection: s.slashingProtection,
+	}
+}
+
+// ID provides the ID for the account.
+func (a *account) ID() uuid.UUID {
+	return a.preferred().ID()
+}
+
+// Name provides the name for the account.
+func (a *account) Name() string {
+	return a.preferred().Name()
+}
+
+// PublicKey provides the public key of the validator for which the account signs.
+func (a *account) PublicKey() e2types.PublicKey {
+	preferred := a.preferred()
+	if provider, isProvider := preferred.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		return provider.CompositePublicKey()
+	}
+
+	return preferred.PublicKey()
+}
+
+// preferred returns the account used to describe this account.
+func (a *account) preferred() e2wtypes.Account {
+	if a.primary != nil {
+		return a.primary
+	}
+
+	return a.fallback
+}
+
+// SignGeneric signs a generic root.
+func (a *account) SignGeneric(ctx context.Context, data []byte, domain []byte) (e2types.Signature, error) {
+	return a.sign(ctx, "generic", func(signer e2wtypes.Account) (e2types.Signature, error) {
+		return signGeneric(ctx, signer, data, domain)
+	})
+}
+
+// SignBeaconProposal signs a beacon proposal.
+func (a *account) SignBeaconProposal(ctx context.Context,
+	slot uint64,
+	proposerIndex uint64,
+	parentRoot []byte,
+	stateRoot []byte,
+	bodyRoot []byte,
+	domain []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	header := &phase0.BeaconBlockHeader{
+		Slot:          phase0.Slot(slot),
+		ProposerIndex: phase0.ValidatorIndex(proposerIndex),
+	}
+	copy(header.ParentRoot[:], parentRoot)
+	copy(header.StateRoot[:], stateRoot)
+	copy(header.BodyRoot[:], bodyRoot)
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate hash tree root")
+	}
+	if err := a.slashingProtection.ProtectProposal(ctx, a.pubKey, header.Slot); err != nil {
+		monitorSignature("proposal", "none", "refused")
+		return nil, errors.Wrap(ErrRefused, err.Error())
+	}
+
+	return a.sign(ctx, "proposal", func(signer e2wtypes.Account) (e2types.Signature, error) {
+		if protectingSigner, isProtectingSigner := signer.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
+			return protectingSigner.SignBeaconProposal(ctx, slot, proposerIndex, parentRoot, stateRoot, bodyRoot, domain)
+		}
+		return signGeneric(ctx, signer, root[:], domain)
+	})
+}
+
+// SignBeaconAttestation signs a beacon attestation.
+func (a *account) SignBeaconAttestation(ctx context.Context,
+	slot uint64,
+	committeeIndex uint64,
+	blockRoot []byte,
+	sourceEpoch uint64,
+	sourceRoot []byte,
+	targetEpoch uint64,
+	targetRoot []byte,
+	domain []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	if err := a.protectAttestations(ctx, []phase0.BLSPubKey{a.pubKey}, sourceEpoch, targetEpoch)[0]; err != nil {
+		monitorSignature("attestation", "none", "refused")
+		return nil, err
+	}
+
+	return a.signAttestation(ctx, slot, committeeIndex, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot, domain)
+}
+
+// protectAttestations checks an attestation with slashing protection for each
+// of the validators.  The returned errors are in the same order as the public
+// keys, and are nil for validators that can sign the attestation.
+func (a *account) protectAttestations(ctx context.Context,
+	pubKeys []phase0.BLSPubKey,
+	sourceEpoch uint64,
+	targetEpoch uint64,
+) []error {
+	res := make([]error, len(pubKeys))
+	permitted, err := a.slashingProtection.ProtectAttestations(ctx, pubKeys, phase0.Epoch(sourceEpoch), phase0.Epoch(targetEpoch))
+	for i := range pubKeys {
+		switch {
+		case err != nil:
+			res[i] = errors.Wrap(ErrRefused, err.Error())
+		case !permitted[i]:
+			res[i] = errors.Wrap(ErrRefused, fmt.Sprintf("attestation with source epoch %d and target epoch %d could be slashable", sourceEpoch, targetEpoch))
+		}
+	}
+
+	return res
+}
+
+// signAttestation signs an attestation that has passed slashing protection.
+func (a *account) signAttestation(ctx context.Context,
+	slot uint64,
+	committeeIndex uint64,
+	blockRoot []byte,
+	sourceEpoch uint64,
+	sourceRoot []byte,
+	targetEpoch uint64,
+	targetRoot []byte,
+	domain []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	return a.sign(ctx, "attestation", attestationSignFunc(ctx, slot, committeeIndex, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot, domain))
+}
+
+// attestationSignFunc returns a function that signs the attestation with a
+// given account.
+func attestationSignFunc(ctx context.Context,
+	slot uint64,
+	committeeIndex uint64,
+	blockRoot []byte,
+	sourceEpoch uint64,
+	sourceRoot []byte,
+	targetEpoch uint64,
+	targetRoot []byte,
+	domain []byte,
+) func(signer e2wtypes.Account) (e2types.Signature, error) {
+	return func(signer e2wtypes.Account) (e2types.Signature, error) {
+		if protectingSigner, isProtectingSigner := signer.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
+			return protectingSigner.SignBeaconAttestation(ctx, slot, committeeIndex, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot, domain)
+		}
+		root, err := attestationRoot(slot, committeeIndex, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot)
+		if err != nil {
+			return nil, err
+		}
+		return signGeneric(ctx, signer, root[:], domain)
+	}
+}
+
+// attestationRoot returns the root of the attestation data.
+func attestationRoot(slot uint64,
+	committeeIndex uint64,
+	blockRoot []byte,
+	sourceEpoch uint64,
+	sourceRoot []byte,
+	targetEpoch uint64,
+	targetRoot []byte,
+) (
+	phase0.Root,
+	error,
+) {
+	data := &phase0.AttestationData{
+		Slot:   phase0.Slot(slot),
+		Index:  phase0.CommitteeIndex(committeeIndex),
+		Source: &phase0.Checkpoint{Epoch: phase0.Epoch(sourceEpoch)},
+		Target: &phase0.Checkpoint{Epoch: phase0.Epoch(targetEpoch)},
+	}
+	copy(data.BeaconBlockRoot[:], blockRoot)
+	copy(data.Source.Root[:], sourceRoot)
+	copy(data.Target.Root[:], targetRoot)
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to generate hash tree root")
+	}
+
+	return root, nil
+}
+
+// sign signs with the primary account, falling back to the fallback account
+// if the primary account is unavailable.  Any other error from the primary
+// account, in particular a denial by its own slashing protection, is returned
+// without requesting the signature from the fallback account.
+func (a *account) sign(ctx context.Context,
+	operation string,
+	signFunc func(signer e2wtypes.Account) (e2types.Signature, error),
+) (
+	e2types.Signature,
+	error,
+) {
+	if a.primary == nil {
+		return a.signFallback(operation, nil, signFunc)
+	}
+
+	sig, err := signFunc(a.primary)
+	if err == nil {
+		monitorSignature(operation, "primary", "succeeded")
+		return sig, nil
+	}
+	if !isUnavailable(err) {
+		monitorSignature(operation, "primary", "denied")
+		return nil, errors.Wrap(err, "primary signer refused signature")
+	}
+	monitorSignature(operation, "primary", "failed")
+
+	return a.signFallback(operation, err, signFunc)
+}
+
+// signFallback signs with the fallback account after the primary account
+// has failed with the supplied error, or is not present.
+func (a *account) signFallback(operation string,
+	primaryErr error,
+	signFunc func(signer e2wtypes.Account) (e2types.Signature, error),
+) (
+	e2types.Signature,
+	error,
+) {
+	if a.fallback == nil {
+		if primaryErr == nil {
+			return nil, errors.New("no signer available")
+		}
+		return nil, errors.Wrap(primaryErr, "primary signer failed and no fallback signer available")
+	}
+	if primaryErr != nil {
+		log.Warn().Str("pubkey", fmt.Sprintf("%#x", a.pubKey)).Str("operation", operation).Err(primaryErr).Msg("Primary signer failed; using fallback signer")
+	}
+
+	sig, err := signFunc(a.fallback)
+	if err != nil {
+		monitorSignature(operation, "fallback", "failed")
+		if primaryErr != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("fallback signer failed after primary signer failed (%v)", primaryErr))
+		}
+		return nil, errors.Wrap(err, "fallback signer failed")
+	}
+	monitorSignature(operation, "fallback", "succeeded")
+
+	return sig, nil
+}
+
+// isUnavailable returns true if the error shows that the signer could not be
+// reached or did not respond in time.  Errors that cannot be identified as
+// such, including denials, are not treated as unavailability.
+func isUnavailable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if grpcStatus, isStatus := status.FromError(err); isStatus {
+		switch grpcStatus.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return true
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// Dirk does not wrap the context error when its signers fail to respond
+	// in time, so this is identified by the innermost error.
+	for ; err != nil; err = errors.Unwrap(err) {
+		if errors.Unwrap(err) == nil && err.Error() == dirkContextDone {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signGeneric signs a root with the given account.
+func signGeneric(ctx context.Context, signer e2wtypes.Account, root []byte, domain []byte) (e2types.Signature, error) {
+	if protectingSigner, isProtectingSigner := signer.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
+		return protectingSigner.SignGeneric(ctx, root, domain)
+	}
+	accountSigner, isSigner := signer.(e2wtypes.AccountSigner)
+	if !isSigner {
+		return nil, errors.New("account cannot sign")
+	}
+	container := &phase0.SigningData{}
+	copy(container.ObjectRoot[:], root)
+	copy(container.Domain[:], domain)
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	return accountSigner.Sign(ctx, signingRoot[:])
+}
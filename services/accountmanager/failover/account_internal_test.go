@@ -0,0 +1,334 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	standardslashingprotection "github.com/attestantio/vouch/services/slashingprotection/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// localAccount creates an unlocked local account with a new key.
+func localAccount(ctx context.Context, t *testing.T, name string) e2wtypes.Account {
+	t.Helper()
+
+	wallet, err := nd.CreateWallet(ctx, name, scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	key, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+	account, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, name, key.Marshal(), []byte("secret"))
+	require.NoError(t, err)
+	require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("secret")))
+
+	return account
+}
+
+// remoteAccount is a protecting account that signs with a local account, or
+// returns an error.
+type remoteAccount struct {
+	e2wtypes.Account
+	err     error
+	nilSigs bool
+	calls   int
+}
+
+func (r *remoteAccount) SignGeneric(ctx context.Context, data []byte, domain []byte) (e2types.Signature, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return signGeneric(ctx, r.Account, data, domain)
+}
+
+func (r *remoteAccount) SignBeaconProposal(ctx context.Context, slot uint64, proposerIndex uint64, parentRoot []byte, stateRoot []byte, bodyRoot []byte, domain []byte) (e2types.Signature, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	header := &phase0.BeaconBlockHeader{
+		Slot:          phase0.Slot(slot),
+		ProposerIndex: phase0.ValidatorIndex(proposerIndex),
+	}
+	copy(header.ParentRoot[:], parentRoot)
+	copy(header.StateRoot[:], stateRoot)
+	copy(header.BodyRoot[:], bodyRoot)
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return signGeneric(ctx, r.Account, root[:], domain)
+}
+
+func (r *remoteAccount) SignBeaconAttestation(ctx context.Context, slot uint64, committeeIndex uint64, blockRoot []byte, sourceEpoch uint64, sourceRoot []byte, targetEpoch uint64, targetRoot []byte, domain []byte) (e2types.Signature, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	root, err := attestationRoot(slot, committeeIndex, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot)
+	if err != nil {
+		return nil, err
+	}
+	return signGeneric(ctx, r.Account, root[:], domain)
+}
+
+func (r *remoteAccount) SignBeaconAttestations(ctx context.Context, slot uint64, accounts []e2wtypes.Account, committeeIndices []uint64, blockRoot []byte, sourceEpoch uint64, sourceRoot []byte, targetEpoch uint64, targetRoot []byte, domain []byte) ([]e2types.Signature, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	sigs := make([]e2types.Signature, len(accounts))
+	if r.nilSigs {
+		return sigs, nil
+	}
+	for i := range accounts {
+		root, err := attestationRoot(slot, committeeIndices[i], blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i], err = signGeneric(ctx, accounts[i].(*remoteAccount).Account, root[:], domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sigs, nil
+}
+
+func testService(t *testing.T) *Service {
+	t.Helper()
+
+	log = zerolog.Nop()
+	ctx := context.Background()
+	slashingProtection, err := standardslashingprotection.New(ctx,
+		standardslashingprotection.WithLogLevel(zerolog.Disabled),
+		standardslashingprotection.WithMonitor(nullmetrics.New(ctx)),
+		standardslashingprotection.WithPath(filepath.Join(t.TempDir(), "slashing-protection.json")),
+		standardslashingprotection.WithGenesisValidatorsRoot(phase0.Root{0x01}),
+	)
+	require.NoError(t, err)
+	return &Service{
+		slashingProtection: slashingProtection,
+	}
+}
+
+func TestSignGeneric(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	local := localAccount(ctx, t, "TestSignGeneric")
+	root := make([]byte, 32)
+	domain := make([]byte, 32)
+	expected, err := signGeneric(ctx, local, root, domain)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		primaryErr    error
+		noFallback    bool
+		err           string
+		fallbackCalls bool
+	}{
+		{
+			name: "PrimarySucceeds",
+		},
+		{
+			name:       "PrimaryUnavailable",
+			primaryErr: fmt.Errorf("failed to obtain signature: %w", status.Error(codes.Unavailable, "connection refused")),
+		},
+		{
+			name:       "PrimaryTimesOut",
+			primaryErr: fmt.Errorf("failed to obtain signature: %w", status.Error(codes.DeadlineExceeded, "deadline exceeded")),
+		},
+		{
+			name:       "PrimaryContextDeadline",
+			primaryErr: context.DeadlineExceeded,
+		},
+		{
+			name:       "PrimaryDirkContextDone",
+			primaryErr: errors.New("context done"),
+		},
+		{
+			name:       "PrimaryDirkContextDoneWrapped",
+			primaryErr: fmt.Errorf("failed to sign: %w", errors.New("context done")),
+		},
+		{
+			name:       "PrimaryUnavailableNoFallback",
+			primaryErr: context.DeadlineExceeded,
+			noFallback: true,
+			err:        "primary signer failed and no fallback signer available: context deadline exceeded",
+		},
+		{
+			name:       "PrimaryDenies",
+			primaryErr: errors.New("request to obtain signature denied"),
+			err:        "primary signer refused signature: request to obtain signature denied",
+		},
+		{
+			name:       "PrimaryFails",
+			primaryErr: errors.New("request to obtain signature failed"),
+			err:        "primary signer refused signature: request to obtain signature failed",
+		},
+		{
+			name:       "PrimaryMentionsContextDone",
+			primaryErr: errors.New("context done: request denied"),
+			err:        "primary signer refused signature: context done: request denied",
+		},
+		{
+			name:       "PrimaryOtherGRPCError",
+			primaryErr: status.Error(codes.PermissionDenied, "not permitted"),
+			err:        "primary signer refused signature: rpc error: code = PermissionDenied desc = not permitted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := testService(t)
+			primary := &remoteAccount{Account: local, err: test.primaryErr}
+			fallback := &remoteAccount{Account: local}
+			var fallbackAccount e2wtypes.Account = fallback
+			if test.noFallback {
+				fallbackAccount = nil
+			}
+			account := s.newAccount(validatorPubKey(local), primary, fallbackAccount)
+			sig, err := account.SignGeneric(ctx, root, domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				require.Equal(t, 0, fallback.calls)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, expected.Marshal(), sig.Marshal())
+			}
+			require.Equal(t, 1, primary.calls)
+		})
+	}
+}
+
+func TestSignBeaconProposalEquivocation(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	local := localAccount(ctx, t, "TestSignBeaconProposalEquivocation")
+	s := testService(t)
+	primary := &remoteAccount{Account: local, err: context.DeadlineExceeded}
+	account := s.newAccount(validatorPubKey(local), primary, local)
+
+	domain := make([]byte, 32)
+	bodyRoot := make([]byte, 32)
+
+	// The primary fails, so the fallback signs.
+	_, err := account.SignBeaconProposal(ctx, 10, 1, make([]byte, 32), make([]byte, 32), bodyRoot, domain)
+	require.NoError(t, err)
+
+	// Signing a different proposal for the same slot is refused by both signers.
+	primary.err = nil
+	calls := primary.calls
+	bodyRoot[0] = 0x01
+	_, err = account.SignBeaconProposal(ctx, 10, 1, make([]byte, 32), make([]byte, 32), bodyRoot, domain)
+	require.ErrorIs(t, err, ErrRefused)
+	require.EqualError(t, err, "proposal for slot 10 at or below previously signed slot 10: refusing to sign potentially slashable message")
+	require.Equal(t, calls, primary.calls)
+
+	// A proposal for a later slot is permitted.
+	_, err = account.SignBeaconProposal(ctx, 11, 1, make([]byte, 32), make([]byte, 32), bodyRoot, domain)
+	require.NoError(t, err)
+
+	// A proposal for an earlier slot is refused.
+	_, err = account.SignBeaconProposal(ctx, 9, 1, make([]byte, 32), make([]byte, 32), bodyRoot, domain)
+	require.ErrorIs(t, err, ErrRefused)
+}
+
+func TestSignBeaconAttestations(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	local1 := localAccount(ctx, t, "TestSignBeaconAttestations1")
+	local2 := localAccount(ctx, t, "TestSignBeaconAttestations2")
+	s := testService(t)
+	primary1 := &remoteAccount{Account: local1, err: context.DeadlineExceeded}
+	primary2 := &remoteAccount{Account: local2, err: context.DeadlineExceeded}
+	account1 := s.newAccount(validatorPubKey(local1), primary1, local1)
+	account2 := s.newAccount(validatorPubKey(local2), primary2, local2)
+
+	root := make([]byte, 32)
+	domain := make([]byte, 32)
+
+	// Primary fails, so attestations are signed individually by the fallback.
+	sigs, err := account1.SignBeaconAttestations(ctx, 1, []e2wtypes.Account{account1, account2}, []uint64{0, 1}, root, 0, root, 1, root, domain)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	require.NotNil(t, sigs[0])
+	require.NotNil(t, sigs[1])
+	require.Equal(t, 1, primary1.calls)
+
+	// A conflicting attestation is refused for the validator.
+	primary1.err = nil
+	primary2.err = nil
+	calls := primary1.calls
+	blockRoot := make([]byte, 32)
+	blockRoot[0] = 0x01
+	sigs, err = account1.SignBeaconAttestations(ctx, 1, []e2wtypes.Account{account1}, []uint64{0}, blockRoot, 0, root, 1, root, domain)
+	require.NoError(t, err)
+	require.Nil(t, sigs[0])
+	require.Equal(t, calls, primary1.calls)
+
+	// Primary succeeds with later attestations.
+	sigs, err = account1.SignBeaconAttestations(ctx, 33, []e2wtypes.Account{account1, account2}, []uint64{0, 1}, root, 0, root, 2, root, domain)
+	require.NoError(t, err)
+	require.NotNil(t, sigs[0])
+	require.NotNil(t, sigs[1])
+	require.Equal(t, calls+1, primary1.calls)
+}
+
+func TestSignBeaconAttestationsPrimaryDenies(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	local := localAccount(ctx, t, "TestSignBeaconAttestationsPrimaryDenies")
+	s := testService(t)
+	primary := &remoteAccount{Account: local, nilSigs: true}
+	fallback := &remoteAccount{Account: local}
+	account := s.newAccount(validatorPubKey(local), primary, fallback)
+
+	root := make([]byte, 32)
+	domain := make([]byte, 32)
+
+	// The primary is available but returns no signature, which is a denial
+	// so the fallback is not asked.
+	sigs, err := account.SignBeaconAttestations(ctx, 1, []e2wtypes.Account{account}, []uint64{0}, root, 0, root, 1, root, domain)
+	require.NoError(t, err)
+	require.Nil(t, sigs[0])
+	require.Equal(t, 1, primary.calls)
+	require.Equal(t, 0, fallback.calls)
+
+	// The primary is unavailable, so the fallback is asked.
+	primary.err = errors.New("context done")
+	sigs, err = account.SignBeaconAttestations(ctx, 33, []e2wtypes.Account{account}, []uint64{0}, root, 0, root, 2, root, domain)
+	require.NoError(t, err)
+	require.NotNil(t, sigs[0])
+	require.Equal(t, 1, fallback.calls)
+}
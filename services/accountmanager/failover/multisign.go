@@ -0,0 +1,135 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// SignBeaconAttestations signs multiple beacon attestations.  Primary
+// accounts that can sign multiple attestations in a single request continue
+// to do so; accounts whose primary signer is unavailable are signed
+// individually by their fallback signer.  Signatures that cannot be obtained,
+// including those the primary signer declines to provide, are nil.
+func (a *account) SignBeaconAttestations(ctx context.Context,
+	slot uint64,
+	accounts []e2wtypes.Account,
+	committeeIndices []uint64,
+	blockRoot []byte,
+	sourceEpoch uint64,
+	sourceRoot []byte,
+	targetEpoch uint64,
+	targetRoot []byte,
+	domain []byte,
+) (
+	[]e2types.Signature,
+	error,
+) {
+	if len(accounts) != len(committeeIndices) {
+		return nil, errors.New("mismatch between accounts and committee indices")
+	}
+
+	sigs := make([]e2types.Signature, len(accounts))
+	// Group accounts by the type of their primary, as distributed and
+	// individual accounts cannot be signed in the same request.
+	groups := make(map[bool][]int)
+	pubKeys := make([]phase0.BLSPubKey, len(accounts))
+	for i := range accounts {
+		failoverAccount, isFailoverAccount := accounts[i].(*account)
+		if !isFailoverAccount {
+			return nil, errors.New("non-failover account provided in list")
+		}
+		pubKeys[i] = failoverAccount.pubKey
+	}
+	checkErrs := a.protectAttestations(ctx, pubKeys, sourceEpoch, targetEpoch)
+	for i := range accounts {
+		failoverAccount := accounts[i].(*account)
+		if checkErrs[i] != nil {
+			monitorSignature("attestation", "none", "refused")
+			log.Warn().Err(checkErrs[i]).Msg("Not signing attestation")
+			continue
+		}
+		if failoverAccount.primary == nil {
+			groups[false] = append(groups[false], i)
+			continue
+		}
+		if _, isMultiSigner := failoverAccount.primary.(e2wtypes.AccountProtectingMultiSigner); !isMultiSigner {
+			groups[false] = append(groups[false], i)
+			continue
+		}
+		_, isDistributed := failoverAccount.primary.(e2wtypes.DistributedAccount)
+		groups[isDistributed] = append(groups[isDistributed], i)
+	}
+
+	for _, group := range groups {
+		individual := group
+		var primaryErr error
+		if multiSigner, isMultiSigner := accounts[group[0]].(*account).primary.(e2wtypes.AccountProtectingMultiSigner); isMultiSigner {
+			primaries := make([]e2wtypes.Account, len(group))
+			groupCommitteeIndices := make([]uint64, len(group))
+			for i, index := range group {
+				primaries[i] = accounts[index].(*account).primary
+				groupCommitteeIndices[i] = committeeIndices[index]
+			}
+			signatures, err := multiSigner.SignBeaconAttestations(ctx, slot, primaries, groupCommitteeIndices, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot, domain)
+			switch {
+			case err == nil:
+				// The primary signer was available, so a missing signature
+				// is a denial and is not requested from the fallback.
+				for i, index := range group {
+					if signatures[i] == nil {
+						monitorSignature("attestation", "primary", "denied")
+						log.Warn().Str("pubkey", fmt.Sprintf("%#x", pubKeys[index])).Msg("Primary signer denied attestation")
+						continue
+					}
+					monitorSignature("attestation", "primary", "succeeded")
+					sigs[index] = signatures[i]
+				}
+				continue
+			case !isUnavailable(err):
+				monitorSignature("attestation", "primary", "denied")
+				log.Warn().Err(err).Msg("Primary signer denied attestations")
+				continue
+			}
+			monitorSignature("attestation", "primary", "failed")
+			// The primary signer has already had its chance to sign these
+			// attestations, so only the fallback is used from here.
+			primaryErr = err
+		}
+		for _, index := range individual {
+			failoverAccount := accounts[index].(*account)
+			signFunc := attestationSignFunc(ctx, slot, committeeIndices[index], blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot, domain)
+			var sig e2types.Signature
+			var err error
+			if primaryErr != nil {
+				sig, err = failoverAccount.signFallback("attestation", primaryErr, signFunc)
+			} else {
+				sig, err = failoverAccount.sign(ctx, "attestation", signFunc)
+			}
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to sign attestation")
+				continue
+			}
+			sigs[index] = sig
+		}
+	}
+
+	return sigs, nil
+}
@@ -0,0 +1,116 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel           zerolog.Level
+	monitor            metrics.Service
+	primary            accountmanager.Service
+	fallback           accountmanager.Service
+	slashingProtection slashingprotection.Service
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithPrimary sets the primary account manager, which is used for signing
+// whenever it is available.
+func WithPrimary(primary accountmanager.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.primary = primary
+	})
+}
+
+// WithFallback sets the fallback account manager, which is used for signing
+// when the primary account manager is unavailable.
+func WithFallback(fallback accountmanager.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallback = fallback
+	})
+}
+
+// WithSlashingProtection sets the slashing protection checked before
+// signing with either account manager.
+func WithSlashingProtection(service slashingprotection.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slashingProtection = service
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.primary == nil {
+		return nil, errors.New("no primary account manager specified")
+	}
+	if _, isManager := parameters.primary.(accountManager); !isManager {
+		return nil, errors.New("primary account manager does not provide accounts")
+	}
+	if parameters.fallback == nil {
+		return nil, errors.New("no fallback account manager specified")
+	}
+	if _, isManager := parameters.fallback.(accountManager); !isManager {
+		return nil, errors.New("fallback account manager does not provide accounts")
+	}
+	if parameters.slashingProtection == nil {
+		return nil, errors.New("no slashing protection specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,177 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failover is an account manager that composes a primary and a
+// fallback account manager, signing with the fallback when the primary fails.
+package failover
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// accountManager is the functionality required of the primary and fallback account managers.
+type accountManager interface {
+	accountmanager.ValidatingAccountsProvider
+	accountmanager.AccountsProvider
+}
+
+// Service is the failover account manager.
+type Service struct {
+	primary            accountManager
+	fallback           accountManager
+	slashingProtection slashingprotection.Service
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new failover account manager.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "accountmanager").Str("impl", "failover").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		primary:            parameters.primary.(accountManager),
+		fallback:           parameters.fallback.(accountManager),
+		slashingProtection: parameters.slashingProtection,
+	}
+
+	return s, nil
+}
+
+// Refresh refreshes the accounts of both the primary and the fallback account managers.
+func (s *Service) Refresh(ctx context.Context) {
+	if refresher, isRefresher := s.primary.(accountmanager.Refresher); isRefresher {
+		refresher.Refresh(ctx)
+	}
+	if refresher, isRefresher := s.fallback.(accountmanager.Refresher); isRefresher {
+		refresher.Refresh(ctx)
+	}
+}
+
+// ValidatingAccountsForEpoch obtains the validating accounts for a given epoch.
+// Accounts known to either account manager are returned.
+func (s *Service) ValidatingAccountsForEpoch(ctx context.Context, epoch phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	primaryAccounts, primaryErr := s.primary.ValidatingAccountsForEpoch(ctx, epoch)
+	if primaryErr != nil {
+		log.Warn().Err(primaryErr).Msg("Failed to obtain validating accounts from primary account manager")
+	}
+	fallbackAccounts, fallbackErr := s.fallback.ValidatingAccountsForEpoch(ctx, epoch)
+	if fallbackErr != nil {
+		log.Warn().Err(fallbackErr).Msg("Failed to obtain validating accounts from fallback account manager")
+	}
+	if primaryErr != nil && fallbackErr != nil {
+		return nil, errors.Wrap(primaryErr, "failed to obtain validating accounts from either account manager")
+	}
+
+	return s.compose(primaryAccounts, fallbackAccounts), nil
+}
+
+// ValidatingAccountsForEpochByIndex obtains the specified validating accounts for a given epoch.
+// Accounts known to either account manager are returned.
+func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	primaryAccounts, primaryErr := s.primary.ValidatingAccountsForEpochByIndex(ctx, epoch, indices)
+	if primaryErr != nil {
+		log.Warn().Err(primaryErr).Msg("Failed to obtain validating accounts from primary account manager")
+	}
+	fallbackAccounts, fallbackErr := s.fallback.ValidatingAccountsForEpochByIndex(ctx, epoch, indices)
+	if fallbackErr != nil {
+		log.Warn().Err(fallbackErr).Msg("Failed to obtain validating accounts from fallback account manager")
+	}
+	if primaryErr != nil && fallbackErr != nil {
+		return nil, errors.Wrap(primaryErr, "failed to obtain validating accounts from either account manager")
+	}
+
+	return s.compose(primaryAccounts, fallbackAccounts), nil
+}
+
+// AccountByPublicKey returns the account for the given public key.
+func (s *Service) AccountByPublicKey(ctx context.Context, pubkey phase0.BLSPubKey) (e2wtypes.Account, error) {
+	primary, primaryErr := s.primary.AccountByPublicKey(ctx, pubkey)
+	fallback, fallbackErr := s.fallback.AccountByPublicKey(ctx, pubkey)
+	if primaryErr != nil {
+		primary = nil
+	}
+	if fallbackErr != nil {
+		fallback = nil
+	}
+	if primary == nil && fallback == nil {
+		if primaryErr != nil {
+			return nil, primaryErr
+		}
+		return nil, accountmanager.ErrAccountNotFound
+	}
+
+	return s.newAccount(pubkey, primary, fallback), nil
+}
+
+// compose composes the accounts of the primary and fallback account managers.
+func (s *Service) compose(primaryAccounts map[phase0.ValidatorIndex]e2wtypes.Account,
+	fallbackAccounts map[phase0.ValidatorIndex]e2wtypes.Account,
+) map[phase0.ValidatorIndex]e2wtypes.Account {
+	accounts := make(map[phase0.ValidatorIndex]e2wtypes.Account, len(primaryAccounts))
+	for index, primary := range primaryAccounts {
+		pubKey := validatorPubKey(primary)
+		fallback, exists := fallbackAccounts[index]
+		if exists && validatorPubKey(fallback) != pubKey {
+			log.Error().Uint64("validator_index", uint64(index)).Msg("Primary and fallback accounts have different public keys; not using fallback")
+			fallback = nil
+		}
+		accounts[index] = s.newAccount(pubKey, primary, fallback)
+	}
+	for index, fallback := range fallbackAccounts {
+		if _, exists := accounts[index]; !exists {
+			accounts[index] = s.newAccount(validatorPubKey(fallback), nil, fallback)
+		}
+	}
+
+	return accounts
+}
+
+// validatorPubKey returns the public key of the validator for which the account signs.
+func validatorPubKey(account e2wtypes.Account) phase0.BLSPubKey {
+	var pubKey phase0.BLSPubKey
+	if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		copy(pubKey[:], provider.CompositePublicKey().Marshal())
+	} else {
+		copy(pubKey[:], account.PublicKey().Marshal())
+	}
+
+	return pubKey
+}
@@ -0,0 +1,305 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/accountmanager/failover"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	standardslashingprotection "github.com/attestantio/vouch/services/slashingprotection/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// accountManager is a simple account manager for testing.
+type accountManager struct {
+	accounts map[phase0.ValidatorIndex]e2wtypes.Account
+	err      error
+}
+
+func (m *accountManager) ValidatingAccountsForEpoch(_ context.Context, _ phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.accounts, nil
+}
+
+func (m *accountManager) ValidatingAccountsForEpochByIndex(_ context.Context, _ phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	accounts := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	for _, index := range indices {
+		if account, exists := m.accounts[index]; exists {
+			accounts[index] = account
+		}
+	}
+	return accounts, nil
+}
+
+func (m *accountManager) AccountByPublicKey(_ context.Context, pubKey phase0.BLSPubKey) (e2wtypes.Account, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, account := range m.accounts {
+		if string(account.PublicKey().Marshal()) == string(pubKey[:]) {
+			return account, nil
+		}
+	}
+	return nil, accountmanager.ErrAccountNotFound
+}
+
+// notAccountManager is an account manager that does not provide accounts.
+type notAccountManager struct{}
+
+func newAccounts(ctx context.Context, t *testing.T, num int) []e2wtypes.Account {
+	t.Helper()
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := nd.CreateWallet(ctx, "test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	accounts := make([]e2wtypes.Account, num)
+	for i := 0; i < num; i++ {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		accounts[i], err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, string(rune('a'+i)), key.Marshal(), []byte("secret"))
+		require.NoError(t, err)
+	}
+
+	return accounts
+}
+
+func newSlashingProtection(ctx context.Context, t *testing.T) slashingprotection.Service {
+	t.Helper()
+
+	slashingProtection, err := standardslashingprotection.New(ctx,
+		standardslashingprotection.WithLogLevel(zerolog.Disabled),
+		standardslashingprotection.WithMonitor(nullmetrics.New(ctx)),
+		standardslashingprotection.WithPath(filepath.Join(t.TempDir(), "slashing-protection.json")),
+		standardslashingprotection.WithGenesisValidatorsRoot(phase0.Root{0x01}),
+	)
+	require.NoError(t, err)
+
+	return slashingProtection
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	primary := &accountManager{}
+	fallback := &accountManager{}
+	slashingProtection := newSlashingProtection(ctx, t)
+
+	tests := []struct {
+		name   string
+		params []failover.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorNil",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nil),
+				failover.WithPrimary(primary),
+				failover.WithFallback(fallback),
+				failover.WithSlashingProtection(slashingProtection),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "PrimaryMissing",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nullmetrics.New(ctx)),
+				failover.WithFallback(fallback),
+				failover.WithSlashingProtection(slashingProtection),
+			},
+			err: "problem with parameters: no primary account manager specified",
+		},
+		{
+			name: "PrimaryBad",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nullmetrics.New(ctx)),
+				failover.WithPrimary(&notAccountManager{}),
+				failover.WithFallback(fallback),
+				failover.WithSlashingProtection(slashingProtection),
+			},
+			err: "problem with parameters: primary account manager does not provide accounts",
+		},
+		{
+			name: "FallbackMissing",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nullmetrics.New(ctx)),
+				failover.WithPrimary(primary),
+			},
+			err: "problem with parameters: no fallback account manager specified",
+		},
+		{
+			name: "FallbackBad",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nullmetrics.New(ctx)),
+				failover.WithPrimary(primary),
+				failover.WithFallback(&notAccountManager{}),
+				failover.WithSlashingProtection(slashingProtection),
+			},
+			err: "problem with parameters: fallback account manager does not provide accounts",
+		},
+		{
+			name: "SlashingProtectionMissing",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nullmetrics.New(ctx)),
+				failover.WithPrimary(primary),
+				failover.WithFallback(fallback),
+			},
+			err: "problem with parameters: no slashing protection specified",
+		},
+		{
+			name: "Good",
+			params: []failover.Parameter{
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithMonitor(nullmetrics.New(ctx)),
+				failover.WithPrimary(primary),
+				failover.WithFallback(fallback),
+				failover.WithSlashingProtection(slashingProtection),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := failover.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatingAccountsForEpoch(t *testing.T) {
+	ctx := context.Background()
+
+	accounts := newAccounts(ctx, t, 3)
+	slashingProtection := newSlashingProtection(ctx, t)
+
+	tests := []struct {
+		name     string
+		primary  *accountManager
+		fallback *accountManager
+		indices  []phase0.ValidatorIndex
+		err      string
+	}{
+		{
+			name: "Union",
+			primary: &accountManager{accounts: map[phase0.ValidatorIndex]e2wtypes.Account{
+				0: accounts[0],
+				1: accounts[1],
+			}},
+			fallback: &accountManager{accounts: map[phase0.ValidatorIndex]e2wtypes.Account{
+				1: accounts[1],
+				2: accounts[2],
+			}},
+			indices: []phase0.ValidatorIndex{0, 1, 2},
+		},
+		{
+			name: "PrimaryErrors",
+			primary: &accountManager{
+				err: errors.New("primary error"),
+			},
+			fallback: &accountManager{accounts: map[phase0.ValidatorIndex]e2wtypes.Account{
+				1: accounts[1],
+			}},
+			indices: []phase0.ValidatorIndex{1},
+		},
+		{
+			name: "BothError",
+			primary: &accountManager{
+				err: errors.New("primary error"),
+			},
+			fallback: &accountManager{
+				err: errors.New("fallback error"),
+			},
+			err: "failed to obtain validating accounts from either account manager: primary error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := failover.New(ctx,
+				failover.WithLogLevel(zerolog.Disabled),
+				failover.WithPrimary(test.primary),
+				failover.WithFallback(test.fallback),
+				failover.WithSlashingProtection(slashingProtection),
+			)
+			require.NoError(t, err)
+			res, err := s.ValidatingAccountsForEpoch(ctx, 1)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res, len(test.indices))
+			for _, index := range test.indices {
+				require.Contains(t, res, index)
+				require.Equal(t, accounts[index].PublicKey().Marshal(), res[index].PublicKey().Marshal())
+			}
+			res, err = s.ValidatingAccountsForEpochByIndex(ctx, 1, test.indices[:1])
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+		})
+	}
+}
+
+func TestAccountByPublicKey(t *testing.T) {
+	ctx := context.Background()
+
+	accounts := newAccounts(ctx, t, 2)
+	slashingProtection := newSlashingProtection(ctx, t)
+
+	s, err := failover.New(ctx,
+		failover.WithLogLevel(zerolog.Disabled),
+		failover.WithPrimary(&accountManager{accounts: map[phase0.ValidatorIndex]e2wtypes.Account{0: accounts[0]}}),
+		failover.WithFallback(&accountManager{accounts: map[phase0.ValidatorIndex]e2wtypes.Account{}}),
+		failover.WithSlashingProtection(slashingProtection),
+	)
+	require.NoError(t, err)
+
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], accounts[0].PublicKey().Marshal())
+	account, err := s.AccountByPublicKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, pubKey[:], account.PublicKey().Marshal())
+
+	copy(pubKey[:], accounts[1].PublicKey().Marshal())
+	_, err = s.AccountByPublicKey(ctx, pubKey)
+	require.ErrorIs(t, err, accountmanager.ErrAccountNotFound)
+}
@@ -0,0 +1,158 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Strategy is the strategy used to assign validators to instances.
+type Strategy int
+
+const (
+	// StrategyIndex assigns validators by their index modulo the number of instances.
+	StrategyIndex Strategy = iota
+	// StrategyHash assigns validators by a hash of their public key modulo the number of instances.
+	StrategyHash
+	// StrategyAssigned assigns validators explicitly, by index ranges and public keys.
+	StrategyAssigned
+)
+
+// ParseStrategy parses a strategy.
+func ParseStrategy(input string) (Strategy, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "index":
+		return StrategyIndex, nil
+	case "hash":
+		return StrategyHash, nil
+	case "assigned":
+		return StrategyAssigned, nil
+	default:
+		return StrategyIndex, fmt.Errorf("unrecognised strategy %q", input)
+	}
+}
+
+// IndexRange is an inclusive range of validator indices.
+type IndexRange struct {
+	From phase0.ValidatorIndex
+	To   phase0.ValidatorIndex
+}
+
+// Assignment is the set of validators explicitly assigned to an instance.
+type Assignment struct {
+	IndexRanges []IndexRange
+	PubKeys     []phase0.BLSPubKey
+}
+
+// assigner assigns validators to instances.
+type assigner interface {
+	// assign returns the position of the instance to which the validator is
+	// assigned, or false if it is not assigned to any instance.
+	assign(index phase0.ValidatorIndex, pubKey phase0.BLSPubKey) (uint64, bool)
+	// usesPubKey returns true if the assignment depends on the public key.
+	usesPubKey() bool
+}
+
+// indexAssigner assigns validators by index.
+type indexAssigner struct {
+	instances uint64
+}
+
+func (a *indexAssigner) assign(index phase0.ValidatorIndex, _ phase0.BLSPubKey) (uint64, bool) {
+	return uint64(index) % a.instances, true
+}
+
+func (*indexAssigner) usesPubKey() bool {
+	return false
+}
+
+// hashAssigner assigns validators by a hash of their public key, so that
+// assignments do not follow the order in which validators were deposited.
+type hashAssigner struct {
+	instances uint64
+}
+
+func (a *hashAssigner) assign(_ phase0.ValidatorIndex, pubKey phase0.BLSPubKey) (uint64, bool) {
+	hash := sha256.Sum256(pubKey[:])
+
+	return binary.BigEndian.Uint64(hash[:8]) % a.instances, true
+}
+
+func (*hashAssigner) usesPubKey() bool {
+	return true
+}
+
+// explicitAssigner assigns validators by index ranges and public keys.
+// Public keys take precedence over index ranges.
+type explicitAssigner struct {
+	ranges  map[uint64][]IndexRange
+	pubKeys map[phase0.BLSPubKey]uint64
+}
+
+func newExplicitAssigner(assignments map[uint64]*Assignment) (*explicitAssigner, error) {
+	a := &explicitAssigner{
+		ranges:  make(map[uint64][]IndexRange),
+		pubKeys: make(map[phase0.BLSPubKey]uint64),
+	}
+	for position, assignment := range assignments {
+		if assignment == nil {
+			continue
+		}
+		for _, indexRange := range assignment.IndexRanges {
+			if indexRange.From > indexRange.To {
+				return nil, fmt.Errorf("index range %d-%d for instance %d is invalid", indexRange.From, indexRange.To, position)
+			}
+			for otherPosition, otherRanges := range a.ranges {
+				for _, otherRange := range otherRanges {
+					if indexRange.From <= otherRange.To && otherRange.From <= indexRange.To && otherPosition != position {
+						return nil, fmt.Errorf("index range %d-%d for instance %d overlaps with instance %d", indexRange.From, indexRange.To, position, otherPosition)
+					}
+				}
+			}
+			a.ranges[position] = append(a.ranges[position], indexRange)
+		}
+		for _, pubKey := range assignment.PubKeys {
+			if otherPosition, exists := a.pubKeys[pubKey]; exists && otherPosition != position {
+				return nil, fmt.Errorf("public key %#x assigned to instances %d and %d", pubKey, otherPosition, position)
+			}
+			a.pubKeys[pubKey] = position
+		}
+	}
+
+	return a, nil
+}
+
+func (a *explicitAssigner) assign(index phase0.ValidatorIndex, pubKey phase0.BLSPubKey) (uint64, bool) {
+	if position, exists := a.pubKeys[pubKey]; exists {
+		return position, true
+	}
+	for position, ranges := range a.ranges {
+		for _, indexRange := range ranges {
+			if index >= indexRange.From && index <= indexRange.To {
+				return position, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func (a *explicitAssigner) usesPubKey() bool {
+	return len(a.pubKeys) > 0
+}
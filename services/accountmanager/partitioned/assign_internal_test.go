@@ -0,0 +1,154 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStrategy(t *testing.T) {
+	tests := []struct {
+		input    string
+		strategy Strategy
+		err      string
+	}{
+		{input: "", strategy: StrategyIndex},
+		{input: "index", strategy: StrategyIndex},
+		{input: " Hash ", strategy: StrategyHash},
+		{input: "assigned", strategy: StrategyAssigned},
+		{input: "random", err: `unrecognised strategy "random"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			strategy, err := ParseStrategy(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.strategy, strategy)
+			}
+		})
+	}
+}
+
+func TestHashAssigner(t *testing.T) {
+	a := &hashAssigner{instances: 4}
+
+	counts := make(map[uint64]int)
+	for i := 0; i < 1000; i++ {
+		var pubKey phase0.BLSPubKey
+		pubKey[0] = byte(i)
+		pubKey[1] = byte(i >> 8)
+		instance, assigned := a.assign(0, pubKey)
+		require.True(t, assigned)
+		require.Less(t, instance, uint64(4))
+		// Assignments are deterministic, and independent of the index.
+		again, _ := a.assign(phase0.ValidatorIndex(i), pubKey)
+		require.Equal(t, instance, again)
+		counts[instance]++
+	}
+	// Validators are spread across all instances.
+	require.Len(t, counts, 4)
+	for _, count := range counts {
+		require.Greater(t, count, 150)
+	}
+}
+
+func TestExplicitAssigner(t *testing.T) {
+	pubKey1 := phase0.BLSPubKey{0x01}
+	pubKey2 := phase0.BLSPubKey{0x02}
+
+	tests := []struct {
+		name        string
+		assignments map[uint64]*Assignment
+		err         string
+		index       phase0.ValidatorIndex
+		pubKey      phase0.BLSPubKey
+		instance    uint64
+		assigned    bool
+	}{
+		{
+			name: "RangeInvalid",
+			assignments: map[uint64]*Assignment{
+				0: {IndexRanges: []IndexRange{{From: 10, To: 5}}},
+			},
+			err: "index range 10-5 for instance 0 is invalid",
+		},
+		{
+			name: "RangesOverlap",
+			assignments: map[uint64]*Assignment{
+				0: {IndexRanges: []IndexRange{{From: 0, To: 10}}},
+				1: {IndexRanges: []IndexRange{{From: 10, To: 20}}},
+			},
+			err: "overlaps with instance",
+		},
+		{
+			name: "PubKeyDuplicate",
+			assignments: map[uint64]*Assignment{
+				0: {PubKeys: []phase0.BLSPubKey{pubKey1}},
+				1: {PubKeys: []phase0.BLSPubKey{pubKey1}},
+			},
+			err: "assigned to instances",
+		},
+		{
+			name: "Range",
+			assignments: map[uint64]*Assignment{
+				0: {IndexRanges: []IndexRange{{From: 0, To: 9}}},
+				1: {IndexRanges: []IndexRange{{From: 10, To: 19}, {From: 30, To: 39}}},
+			},
+			index:    35,
+			instance: 1,
+			assigned: true,
+		},
+		{
+			name: "Unassigned",
+			assignments: map[uint64]*Assignment{
+				0: {IndexRanges: []IndexRange{{From: 0, To: 9}}},
+				1: {IndexRanges: []IndexRange{{From: 10, To: 19}}},
+			},
+			index: 25,
+		},
+		{
+			name: "PubKeyPrecedence",
+			assignments: map[uint64]*Assignment{
+				0: {IndexRanges: []IndexRange{{From: 0, To: 9}}},
+				1: {PubKeys: []phase0.BLSPubKey{pubKey2}},
+			},
+			index:    5,
+			pubKey:   pubKey2,
+			instance: 1,
+			assigned: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := newExplicitAssigner(test.assignments)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			instance, assigned := a.assign(test.index, test.pubKey)
+			require.Equal(t, test.assigned, assigned)
+			if test.assigned {
+				require.Equal(t, test.instance, instance)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	partnerOnline   *prometheus.GaugeVec
+	validatorsGauge prometheus.Gauge
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if partnerOnline != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	partnerOnline = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_partitioned",
+		Name:      "partner_online",
+		Help:      "1 if the partner instance is online, 0 if its validators have been taken over.",
+	}, []string{"partner"})
+	if err := prometheus.Register(partnerOnline); err != nil {
+		return err
+	}
+
+	validatorsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_partitioned",
+		Name:      "validators",
+		Help:      "The number of validating accounts for which this instance is responsible.",
+	})
+	return prometheus.Register(validatorsGauge)
+}
+
+func monitorPartnerOnline(partner uint64, online bool) {
+	if partnerOnline != nil {
+		value := 0.0
+		if online {
+			value = 1
+		}
+		partnerOnline.WithLabelValues(fmt.Sprintf("%d", partner)).Set(value)
+	}
+}
+
+func monitorValidators(validators int) {
+	if validatorsGauge != nil {
+		validatorsGauge.Set(float64(validators))
+	}
+}
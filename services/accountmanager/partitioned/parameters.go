@@ -0,0 +1,212 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	monitor          metrics.Service
+	accountManager   accountmanager.Service
+	instances        uint64
+	instance         uint64
+	strategy         Strategy
+	assignments      map[uint64]*Assignment
+	assigner         assigner
+	partners         map[uint64]string
+	checkInterval    time.Duration
+	takeoverFailures uint64
+	timeout          time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithAccountManager sets the account manager whose accounts are partitioned.
+func WithAccountManager(accountManager accountmanager.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.accountManager = accountManager
+	})
+}
+
+// WithInstances sets the number of instances between which validators are partitioned.
+func WithInstances(instances uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.instances = instances
+	})
+}
+
+// WithInstance sets the position of this instance, from 0 to instances-1.
+func WithInstance(instance uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.instance = instance
+	})
+}
+
+// WithStrategy sets the strategy used to assign validators to instances.
+func WithStrategy(strategy Strategy) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.strategy = strategy
+	})
+}
+
+// WithAssignments sets the validators assigned to each instance, keyed by
+// the position of the instance, for the assigned strategy.
+func WithAssignments(assignments map[uint64]*Assignment) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.assignments = assignments
+	})
+}
+
+// WithPartners sets the URLs used to check that partner instances are online,
+// keyed by the position of the partner.  The validators of a partner that is
+// offline are taken over by this instance.
+func WithPartners(partners map[uint64]string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.partners = partners
+	})
+}
+
+// WithCheckInterval sets the interval between checks of partner instances.
+func WithCheckInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.checkInterval = interval
+	})
+}
+
+// WithTakeoverFailures sets the number of consecutive failed checks after
+// which a partner is considered offline.
+func WithTakeoverFailures(failures uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.takeoverFailures = failures
+	})
+}
+
+// WithTimeout sets the timeout for checks of partner instances.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:         zerolog.GlobalLevel(),
+		monitor:          nullmetrics.New(context.Background()),
+		checkInterval:    12 * time.Second,
+		takeoverFailures: 3,
+		timeout:          2 * time.Second,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.accountManager == nil {
+		return nil, errors.New("no account manager specified")
+	}
+	if _, isManager := parameters.accountManager.(accountManager); !isManager {
+		return nil, errors.New("account manager does not provide accounts")
+	}
+	if parameters.instances == 0 {
+		return nil, errors.New("no instances specified")
+	}
+	if parameters.instance >= parameters.instances {
+		return nil, errors.New("instance must be less than instances")
+	}
+	switch parameters.strategy {
+	case StrategyIndex:
+		parameters.assigner = &indexAssigner{instances: parameters.instances}
+	case StrategyHash:
+		parameters.assigner = &hashAssigner{instances: parameters.instances}
+	case StrategyAssigned:
+		if len(parameters.assignments) == 0 {
+			return nil, errors.New("no assignments specified")
+		}
+		for position := range parameters.assignments {
+			if position >= parameters.instances {
+				return nil, fmt.Errorf("assignment for instance %d must be less than instances", position)
+			}
+		}
+		assigner, err := newExplicitAssigner(parameters.assignments)
+		if err != nil {
+			return nil, err
+		}
+		parameters.assigner = assigner
+	default:
+		return nil, errors.New("unknown strategy")
+	}
+	if parameters.strategy != StrategyAssigned && len(parameters.assignments) > 0 {
+		return nil, errors.New("assignments can only be used with the assigned strategy")
+	}
+	for partner, url := range parameters.partners {
+		if partner >= parameters.instances {
+			return nil, fmt.Errorf("partner %d must be less than instances", partner)
+		}
+		if partner == parameters.instance {
+			return nil, fmt.Errorf("partner %d cannot be this instance", partner)
+		}
+		if url == "" {
+			return nil, fmt.Errorf("no check URL specified for partner %d", partner)
+		}
+	}
+	if parameters.checkInterval <= 0 {
+		return nil, errors.New("check interval must be positive")
+	}
+	if parameters.takeoverFailures == 0 {
+		return nil, errors.New("takeover failures must be at least 1")
+	}
+	if parameters.timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,92 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// partner is another instance whose validators can be taken over.
+type partner struct {
+	position uint64
+	url      string
+	failures uint64
+}
+
+// checkPartners checks the partners periodically until the context is cancelled.
+func (s *Service) checkPartners(ctx context.Context, interval time.Duration, takeoverFailures uint64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, partner := range s.partners {
+				s.checkPartner(ctx, partner, takeoverFailures)
+			}
+		}
+	}
+}
+
+// checkPartner checks a partner, taking over its validators if it is offline
+// and handing them back when it returns.
+func (s *Service) checkPartner(ctx context.Context, partner *partner, takeoverFailures uint64) {
+	if err := s.ping(ctx, partner.url); err != nil {
+		partner.failures++
+		log.Debug().Uint64("partner", partner.position).Uint64("failures", partner.failures).Err(err).Msg("Partner check failed")
+		if partner.failures == takeoverFailures {
+			s.setTakenOver(partner.position, true)
+			log.Warn().Uint64("partner", partner.position).Msg("Partner offline; taking over its validators")
+		}
+		return
+	}
+
+	if partner.failures >= takeoverFailures {
+		s.setTakenOver(partner.position, false)
+		log.Info().Uint64("partner", partner.position).Msg("Partner online; handing back its validators")
+	}
+	partner.failures = 0
+}
+
+// setTakenOver sets if the validators of the partner are taken over.
+func (s *Service) setTakenOver(position uint64, takenOver bool) {
+	s.takenOverMu.Lock()
+	s.takenOver[position] = takenOver
+	s.takenOverMu.Unlock()
+
+	monitorPartnerOnline(position, !takenOver)
+}
+
+// ping returns an error if the URL does not return a successful response.
+func (s *Service) ping(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
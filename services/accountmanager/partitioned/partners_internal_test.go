@@ -0,0 +1,83 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// responsible returns the accounts for which the service is responsible.
+func responsible(s *Service, accounts map[phase0.ValidatorIndex]e2wtypes.Account) map[phase0.ValidatorIndex]e2wtypes.Account {
+	res, _ := s.filter(accounts)
+
+	return res
+}
+
+func TestCheckPartner(t *testing.T) {
+	ctx := context.Background()
+
+	var online atomic.Bool
+	online.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !online.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	s := &Service{
+		instances: 2,
+		instance:  0,
+		assigner:  &indexAssigner{instances: 2},
+		client:    util.NewHTTPClient(time.Second),
+		takenOver: make(map[uint64]bool),
+	}
+	partner := &partner{
+		position: 1,
+		url:      server.URL,
+	}
+	accounts := map[phase0.ValidatorIndex]e2wtypes.Account{
+		0: nil,
+		1: nil,
+	}
+
+	// Online partner keeps its validators.
+	s.checkPartner(ctx, partner, 2)
+	require.Len(t, responsible(s, accounts), 1)
+
+	// Partner is not taken over until it has failed enough checks.
+	online.Store(false)
+	s.checkPartner(ctx, partner, 2)
+	require.Len(t, responsible(s, accounts), 1)
+	s.checkPartner(ctx, partner, 2)
+	require.Len(t, responsible(s, accounts), 2)
+	s.checkPartner(ctx, partner, 2)
+	require.Len(t, responsible(s, accounts), 2)
+
+	// Validators are handed back when the partner returns.
+	online.Store(true)
+	s.checkPartner(ctx, partner, 2)
+	require.Len(t, responsible(s, accounts), 1)
+	require.Equal(t, uint64(0), partner.failures)
+}
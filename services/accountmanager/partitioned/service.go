@@ -0,0 +1,213 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package partitioned is an account manager that restricts the accounts of an
+// underlying account manager to those for which this instance is responsible,
+// allowing multiple instances to share the validators of a single account
+// manager between them.
+package partitioned
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// accountManager is the functionality required of the underlying account manager.
+type accountManager interface {
+	accountmanager.ValidatingAccountsProvider
+	accountmanager.AccountsProvider
+}
+
+// Service is the partitioned account manager.
+type Service struct {
+	accountManager accountManager
+	instances      uint64
+	instance       uint64
+	assigner       assigner
+	partners       map[uint64]*partner
+	client         *http.Client
+
+	// placements caches the placements of validators where the assigner uses public keys.
+	placementsMu sync.RWMutex
+	placements   map[phase0.ValidatorIndex]placement
+	unassigned   atomic.Int64
+
+	takenOverMu sync.RWMutex
+	takenOver   map[uint64]bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new partitioned account manager.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "accountmanager").Str("impl", "partitioned").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		accountManager: parameters.accountManager.(accountManager),
+		instances:      parameters.instances,
+		instance:       parameters.instance,
+		assigner:       parameters.assigner,
+		placements:     make(map[phase0.ValidatorIndex]placement),
+		partners:       make(map[uint64]*partner, len(parameters.partners)),
+		client:         util.NewHTTPClient(parameters.timeout),
+		takenOver:      make(map[uint64]bool),
+	}
+	for position, url := range parameters.partners {
+		s.partners[position] = &partner{
+			position: position,
+			url:      url,
+		}
+		monitorPartnerOnline(position, true)
+	}
+
+	if len(s.partners) > 0 {
+		go s.checkPartners(ctx, parameters.checkInterval, parameters.takeoverFailures)
+	}
+
+	return s, nil
+}
+
+// Refresh refreshes the accounts of the underlying account manager.
+func (s *Service) Refresh(ctx context.Context) {
+	if refresher, isRefresher := s.accountManager.(accountmanager.Refresher); isRefresher {
+		refresher.Refresh(ctx)
+	}
+}
+
+// ValidatingAccountsForEpoch obtains the validating accounts for a given epoch.
+// Only accounts for which this instance is responsible are returned.
+func (s *Service) ValidatingAccountsForEpoch(ctx context.Context, epoch phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	accounts, err := s.accountManager.ValidatingAccountsForEpoch(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, unassigned := s.filter(accounts)
+	monitorValidators(len(accounts))
+	if previous := s.unassigned.Swap(int64(unassigned)); previous != int64(unassigned) && unassigned > 0 {
+		log.Warn().Int("validators", unassigned).Msg("Validators are not assigned to any instance; they will not be validated")
+	}
+
+	return accounts, nil
+}
+
+// ValidatingAccountsForEpochByIndex obtains the specified validating accounts for a given epoch.
+// Only accounts for which this instance is responsible are returned.
+func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	accounts, err := s.accountManager.ValidatingAccountsForEpochByIndex(ctx, epoch, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _ = s.filter(accounts)
+
+	return accounts, nil
+}
+
+// AccountByPublicKey returns the account for the given public key.
+func (s *Service) AccountByPublicKey(ctx context.Context, pubkey phase0.BLSPubKey) (e2wtypes.Account, error) {
+	return s.accountManager.AccountByPublicKey(ctx, pubkey)
+}
+
+// filter returns the accounts for which this instance is responsible, and
+// the number of accounts that are not assigned to any instance.
+func (s *Service) filter(accounts map[phase0.ValidatorIndex]e2wtypes.Account) (map[phase0.ValidatorIndex]e2wtypes.Account, int) {
+	s.takenOverMu.RLock()
+	defer s.takenOverMu.RUnlock()
+
+	res := make(map[phase0.ValidatorIndex]e2wtypes.Account, len(accounts)/int(s.instances)+1)
+	unassigned := 0
+	for index, account := range accounts {
+		placement := s.placement(index, account)
+		if !placement.assigned {
+			unassigned++
+			continue
+		}
+		if placement.instance == s.instance || s.takenOver[placement.instance] {
+			res[index] = account
+		}
+	}
+
+	return res, unassigned
+}
+
+// placement is the position of the instance to which a validator is assigned.
+type placement struct {
+	instance uint64
+	assigned bool
+}
+
+// placement returns the position of the instance to which the validator is assigned.
+func (s *Service) placement(index phase0.ValidatorIndex, account e2wtypes.Account) placement {
+	if !s.assigner.usesPubKey() {
+		instance, assigned := s.assigner.assign(index, phase0.BLSPubKey{})
+		return placement{instance: instance, assigned: assigned}
+	}
+
+	s.placementsMu.RLock()
+	res, exists := s.placements[index]
+	s.placementsMu.RUnlock()
+	if exists {
+		return res
+	}
+
+	instance, assigned := s.assigner.assign(index, validatorPubKey(account))
+	res = placement{instance: instance, assigned: assigned}
+	s.placementsMu.Lock()
+	s.placements[index] = res
+	s.placementsMu.Unlock()
+
+	return res
+}
+
+// validatorPubKey returns the public key of the validator for which the account signs.
+func validatorPubKey(account e2wtypes.Account) phase0.BLSPubKey {
+	var pubKey phase0.BLSPubKey
+	if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		copy(pubKey[:], provider.CompositePublicKey().Marshal())
+	} else {
+		copy(pubKey[:], account.PublicKey().Marshal())
+	}
+
+	return pubKey
+}
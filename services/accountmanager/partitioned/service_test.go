@@ -0,0 +1,429 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitioned_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/accountmanager/partitioned"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// accountManager is a simple account manager for testing.
+type accountManager struct {
+	accounts map[phase0.ValidatorIndex]e2wtypes.Account
+}
+
+func (m *accountManager) ValidatingAccountsForEpoch(_ context.Context, _ phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	return m.accounts, nil
+}
+
+func (m *accountManager) ValidatingAccountsForEpochByIndex(_ context.Context, _ phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	accounts := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	for _, index := range indices {
+		if account, exists := m.accounts[index]; exists {
+			accounts[index] = account
+		}
+	}
+	return accounts, nil
+}
+
+func (*accountManager) AccountByPublicKey(_ context.Context, _ phase0.BLSPubKey) (e2wtypes.Account, error) {
+	return nil, accountmanager.ErrAccountNotFound
+}
+
+// notAccountManager is an account manager that does not provide accounts.
+type notAccountManager struct{}
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		params []partitioned.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorNil",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nil),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "AccountManagerMissing",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithInstances(2),
+			},
+			err: "problem with parameters: no account manager specified",
+		},
+		{
+			name: "AccountManagerInvalid",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&notAccountManager{}),
+				partitioned.WithInstances(2),
+			},
+			err: "problem with parameters: account manager does not provide accounts",
+		},
+		{
+			name: "InstancesMissing",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+			},
+			err: "problem with parameters: no instances specified",
+		},
+		{
+			name: "InstanceTooHigh",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithInstance(2),
+			},
+			err: "problem with parameters: instance must be less than instances",
+		},
+		{
+			name: "StrategyUnknown",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithStrategy(partitioned.Strategy(99)),
+			},
+			err: "problem with parameters: unknown strategy",
+		},
+		{
+			name: "AssignmentsMissing",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithStrategy(partitioned.StrategyAssigned),
+			},
+			err: "problem with parameters: no assignments specified",
+		},
+		{
+			name: "AssignmentTooHigh",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithStrategy(partitioned.StrategyAssigned),
+				partitioned.WithAssignments(map[uint64]*partitioned.Assignment{
+					2: {IndexRanges: []partitioned.IndexRange{{From: 0, To: 10}}},
+				}),
+			},
+			err: "problem with parameters: assignment for instance 2 must be less than instances",
+		},
+		{
+			name: "AssignmentsWrongStrategy",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithStrategy(partitioned.StrategyHash),
+				partitioned.WithAssignments(map[uint64]*partitioned.Assignment{
+					1: {IndexRanges: []partitioned.IndexRange{{From: 0, To: 10}}},
+				}),
+			},
+			err: "problem with parameters: assignments can only be used with the assigned strategy",
+		},
+		{
+			name: "PartnerTooHigh",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithPartners(map[uint64]string{2: "http://localhost:8081/version"}),
+			},
+			err: "problem with parameters: partner 2 must be less than instances",
+		},
+		{
+			name: "PartnerSelf",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithPartners(map[uint64]string{0: "http://localhost:8081/version"}),
+			},
+			err: "problem with parameters: partner 0 cannot be this instance",
+		},
+		{
+			name: "PartnerURLMissing",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithPartners(map[uint64]string{1: ""}),
+			},
+			err: "problem with parameters: no check URL specified for partner 1",
+		},
+		{
+			name: "CheckIntervalZero",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithCheckInterval(0),
+			},
+			err: "problem with parameters: check interval must be positive",
+		},
+		{
+			name: "TakeoverFailuresZero",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithTakeoverFailures(0),
+			},
+			err: "problem with parameters: takeover failures must be at least 1",
+		},
+		{
+			name: "TimeoutZero",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithTimeout(0),
+			},
+			err: "problem with parameters: timeout must be positive",
+		},
+		{
+			name: "Good",
+			params: []partitioned.Parameter{
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(&accountManager{}),
+				partitioned.WithInstances(2),
+				partitioned.WithInstance(1),
+				partitioned.WithPartners(map[uint64]string{0: "http://localhost:8081/version"}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := partitioned.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func newAccounts(ctx context.Context, t *testing.T, num int) []e2wtypes.Account {
+	t.Helper()
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := nd.CreateWallet(ctx, "test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	accounts := make([]e2wtypes.Account, num)
+	for i := 0; i < num; i++ {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		accounts[i], err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, string(rune('a'+i)), key.Marshal(), []byte("secret"))
+		require.NoError(t, err)
+	}
+
+	return accounts
+}
+
+func TestValidatingAccounts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	underlying := &accountManager{
+		accounts: map[phase0.ValidatorIndex]e2wtypes.Account{
+			0: nil,
+			1: nil,
+			2: nil,
+			3: nil,
+			4: nil,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		instances uint64
+		instance  uint64
+		expected  []phase0.ValidatorIndex
+	}{
+		{
+			name:      "Single",
+			instances: 1,
+			instance:  0,
+			expected:  []phase0.ValidatorIndex{0, 1, 2, 3, 4},
+		},
+		{
+			name:      "FirstOfTwo",
+			instances: 2,
+			instance:  0,
+			expected:  []phase0.ValidatorIndex{0, 2, 4},
+		},
+		{
+			name:      "SecondOfTwo",
+			instances: 2,
+			instance:  1,
+			expected:  []phase0.ValidatorIndex{1, 3},
+		},
+		{
+			name:      "ThirdOfThree",
+			instances: 3,
+			instance:  2,
+			expected:  []phase0.ValidatorIndex{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := partitioned.New(ctx,
+				partitioned.WithLogLevel(zerolog.Disabled),
+				partitioned.WithMonitor(nullmetrics.New(ctx)),
+				partitioned.WithAccountManager(underlying),
+				partitioned.WithInstances(test.instances),
+				partitioned.WithInstance(test.instance),
+			)
+			require.NoError(t, err)
+
+			accounts, err := s.ValidatingAccountsForEpoch(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, accounts, len(test.expected))
+			for _, index := range test.expected {
+				require.Contains(t, accounts, index)
+			}
+
+			accounts, err = s.ValidatingAccountsForEpochByIndex(ctx, 0, []phase0.ValidatorIndex{0, 1, 2})
+			require.NoError(t, err)
+			for index := range accounts {
+				require.Contains(t, test.expected, index)
+			}
+		})
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	accounts := newAccounts(ctx, t, 8)
+	underlying := &accountManager{
+		accounts: make(map[phase0.ValidatorIndex]e2wtypes.Account),
+	}
+	for i, account := range accounts {
+		underlying.accounts[phase0.ValidatorIndex(i)] = account
+	}
+	pubKey := func(index int) phase0.BLSPubKey {
+		var res phase0.BLSPubKey
+		copy(res[:], accounts[index].PublicKey().Marshal())
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		strategy    partitioned.Strategy
+		assignments map[uint64]*partitioned.Assignment
+		// expected is the number of validators for each instance, or nil if
+		// only the total is checked.
+		expected []int
+		total    int
+	}{
+		{
+			name:     "Hash",
+			strategy: partitioned.StrategyHash,
+			total:    8,
+		},
+		{
+			name:     "Assigned",
+			strategy: partitioned.StrategyAssigned,
+			assignments: map[uint64]*partitioned.Assignment{
+				0: {
+					IndexRanges: []partitioned.IndexRange{{From: 0, To: 3}},
+				},
+				1: {
+					IndexRanges: []partitioned.IndexRange{{From: 4, To: 5}},
+					PubKeys:     []phase0.BLSPubKey{pubKey(0), pubKey(6)},
+				},
+			},
+			// Validator 0 is assigned to instance 1 by public key, and validator 7 is not assigned.
+			expected: []int{3, 4},
+			total:    7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			total := 0
+			seen := make(map[phase0.ValidatorIndex]bool)
+			for instance := uint64(0); instance < 2; instance++ {
+				s, err := partitioned.New(ctx,
+					partitioned.WithLogLevel(zerolog.Disabled),
+					partitioned.WithMonitor(nullmetrics.New(ctx)),
+					partitioned.WithAccountManager(underlying),
+					partitioned.WithInstances(2),
+					partitioned.WithInstance(instance),
+					partitioned.WithStrategy(test.strategy),
+					partitioned.WithAssignments(test.assignments),
+				)
+				require.NoError(t, err)
+
+				validating, err := s.ValidatingAccountsForEpoch(ctx, 0)
+				require.NoError(t, err)
+				if test.expected != nil {
+					require.Len(t, validating, test.expected[instance])
+				}
+				for index := range validating {
+					// Each validator is the responsibility of a single instance.
+					require.False(t, seen[index])
+					seen[index] = true
+				}
+				total += len(validating)
+			}
+			require.Equal(t, test.total, total)
+		})
+	}
+}
@@ -16,11 +16,21 @@ package accountmanager
 
 import (
 	"context"
+	"errors"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
+// ErrAccountExists is returned when importing an account that is already present.
+var ErrAccountExists = errors.New("account already exists")
+
+// ErrAccountNotFound is returned when an account is not known to the account manager.
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrAccountReadOnly is returned when attempting to delete an account that is not managed at runtime.
+var ErrAccountReadOnly = errors.New("account is read-only")
+
 // Service is the generic accountmanager service.
 type Service interface{}
 
@@ -47,8 +57,39 @@ type Refresher interface {
 	Refresh(ctx context.Context)
 }
 
+// SlicedRefresher refreshes account information from the remote source in slices,
+// to spread the load of refreshing across a period of time.
+type SlicedRefresher interface {
+	// RefreshSlice refreshes a single slice of the accounts from the remote source.
+	// slice is the index of the slice to refresh, from 0 to slices-1.  Refreshing
+	// the final slice also refreshes account validator state from the validators provider.
+	RefreshSlice(ctx context.Context, slice uint64, slices uint64)
+}
+
 // AccountsProvider provides accounts.
 type AccountsProvider interface {
 	// AccountByPublicKey returns the account for the given public key.
 	AccountByPublicKey(ctx context.Context, pubkey phase0.BLSPubKey) (e2wtypes.Account, error)
 }
+
+// Keystore is a keystore known to the account manager.
+type Keystore struct {
+	// PublicKey is the public key of the keystore.
+	PublicKey phase0.BLSPubKey
+	// DerivationPath is the derivation path of the keystore, if known.
+	DerivationPath string
+	// ReadOnly is true if the keystore cannot be deleted at runtime.
+	ReadOnly bool
+}
+
+// KeystoreManager manages keystores at runtime.
+type KeystoreManager interface {
+	// Keystores returns the keystores known to the account manager.
+	Keystores(ctx context.Context) ([]*Keystore, error)
+
+	// ImportKeystore imports an EIP-2335 keystore, decrypting it with the supplied password.
+	ImportKeystore(ctx context.Context, keystore []byte, password string) (phase0.BLSPubKey, error)
+
+	// DeleteKeystore deletes the keystore with the given public key.
+	DeleteKeystore(ctx context.Context, pubkey phase0.BLSPubKey) error
+}
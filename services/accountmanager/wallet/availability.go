@@ -0,0 +1,60 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wallet
+
+// walletAvailability is the availability of the accounts in a wallet.
+type walletAvailability struct {
+	// expected is the number of accounts the wallet is expected to provide.
+	expected int
+	// signable is the number of accounts that could be unlocked.
+	signable int
+}
+
+// updateWalletAvailability reports the availability of the accounts in each wallet.
+// Wallets that could not be found have no availability, and retain their previous
+// expectation.
+func (s *Service) updateWalletAvailability(availabilities map[string]*walletAvailability) {
+	s.availabilityMu.Lock()
+	defer s.availabilityMu.Unlock()
+
+	for wallet, availability := range availabilities {
+		if availability == nil {
+			availability = &walletAvailability{
+				expected: s.availability[wallet].expected,
+			}
+		}
+
+		s.monitor.WalletAccounts(wallet, "expected", availability.expected)
+		s.monitor.WalletAccounts(wallet, "signable", availability.signable)
+
+		previous, exists := s.availability[wallet]
+		s.availability[wallet] = *availability
+		if exists && previous == *availability {
+			continue
+		}
+		switch {
+		case availability.signable < availability.expected:
+			log.Warn().
+				Str("wallet", wallet).
+				Int("expected", availability.expected).
+				Int("signable", availability.signable).
+				Msg("Wallet has accounts that cannot sign")
+		case exists && previous.signable < previous.expected:
+			log.Info().
+				Str("wallet", wallet).
+				Int("expected", availability.expected).
+				Msg("Wallet accounts fully available")
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-bytesutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"github.com/wealdtech/go-indexer"
+	"go.opentelemetry.io/otel"
+)
+
+// keystoreJSON is the subset of an EIP-2335 keystore used when importing.
+type keystoreJSON struct {
+	Crypto map[string]any `json:"crypto"`
+	Path   string         `json:"path"`
+}
+
+// importWalletInAccountPaths returns true if the import wallet is fully covered by the account paths.
+func importWalletInAccountPaths(importWallet string, accountPaths []string) bool {
+	for _, path := range accountPaths {
+		if path == importWallet {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Keystores returns the keystores known to the account manager.
+func (s *Service) Keystores(_ context.Context) ([]*accountmanager.Keystore, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keystores := make([]*accountmanager.Keystore, 0, len(s.accounts))
+	for pubkey, account := range s.accounts {
+		keystore := &accountmanager.Keystore{
+			PublicKey: pubkey,
+			ReadOnly:  !s.inImportWallet(account),
+		}
+		if pathProvider, isProvider := account.(e2wtypes.AccountPathProvider); isProvider {
+			keystore.DerivationPath = pathProvider.Path()
+		}
+		keystores = append(keystores, keystore)
+	}
+
+	return keystores, nil
+}
+
+// ImportKeystore imports an EIP-2335 keystore, decrypting it with the supplied password.
+// The key is re-encrypted with the first configured passphrase and stored in the import wallet.
+func (s *Service) ImportKeystore(ctx context.Context, keystore []byte, password string) (phase0.BLSPubKey, error) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.wallet").Start(ctx, "ImportKeystore")
+	defer span.End()
+
+	if s.importWallet == "" {
+		return phase0.BLSPubKey{}, errors.New("no import wallet configured")
+	}
+
+	data := &keystoreJSON{}
+	if err := json.Unmarshal(keystore, data); err != nil {
+		return phase0.BLSPubKey{}, errors.Wrap(err, "invalid keystore")
+	}
+	if data.Crypto == nil {
+		return phase0.BLSPubKey{}, errors.New("keystore missing crypto")
+	}
+	secret, err := keystorev4.New().Decrypt(data.Crypto, password)
+	if err != nil {
+		return phase0.BLSPubKey{}, errors.Wrap(err, "failed to decrypt keystore")
+	}
+	privateKey, err := e2types.BLSPrivateKeyFromBytes(secret)
+	if err != nil {
+		return phase0.BLSPubKey{}, errors.Wrap(err, "invalid private key")
+	}
+	pubkey := bytesutil.ToBytes48(privateKey.PublicKey().Marshal())
+
+	s.keystoresMu.Lock()
+	defer s.keystoresMu.Unlock()
+
+	s.mutex.RLock()
+	_, exists := s.accounts[pubkey]
+	s.mutex.RUnlock()
+	if exists {
+		return pubkey, accountmanager.ErrAccountExists
+	}
+
+	wallet, err := s.openImportWallet(ctx)
+	if err != nil {
+		return phase0.BLSPubKey{}, err
+	}
+	importer, isImporter := wallet.(e2wtypes.WalletAccountImporter)
+	if !isImporter {
+		return phase0.BLSPubKey{}, errors.New("import wallet does not support importing accounts")
+	}
+	if locker, isLocker := wallet.(e2wtypes.WalletLocker); isLocker {
+		if err := locker.Unlock(ctx, nil); err != nil {
+			return phase0.BLSPubKey{}, errors.Wrap(err, "failed to unlock import wallet")
+		}
+		defer func() {
+			if err := locker.Lock(ctx); err != nil {
+				log.Warn().Err(err).Msg("Failed to lock import wallet")
+			}
+		}()
+	}
+
+	account, err := importer.ImportAccount(ctx, fmt.Sprintf("%#x", pubkey), secret, s.passphrases[0])
+	if err != nil {
+		return phase0.BLSPubKey{}, errors.Wrap(err, "failed to import account")
+	}
+	if locker, isLocker := account.(e2wtypes.AccountLocker); isLocker {
+		if err := locker.Unlock(ctx, s.passphrases[0]); err != nil {
+			return phase0.BLSPubKey{}, errors.Wrap(err, "failed to unlock imported account")
+		}
+	}
+	if data.Path != "" {
+		log.Trace().Str("path", data.Path).Msg("Derivation path of imported keystore is not retained")
+	}
+
+	s.mutex.Lock()
+	s.accounts[pubkey] = account
+	s.mutex.Unlock()
+	log.Info().Str("pubkey", fmt.Sprintf("%#x", pubkey)).Msg("Imported keystore")
+
+	if err := s.refreshValidators(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to refresh validators after import")
+	}
+
+	return pubkey, nil
+}
+
+// DeleteKeystore deletes the keystore with the given public key from the import wallet.
+func (s *Service) DeleteKeystore(ctx context.Context, pubkey phase0.BLSPubKey) error {
+	_, span := otel.Tracer("attestantio.vouch.services.accountmanager.wallet").Start(ctx, "DeleteKeystore")
+	defer span.End()
+
+	s.keystoresMu.Lock()
+	defer s.keystoresMu.Unlock()
+
+	s.mutex.RLock()
+	account, exists := s.accounts[pubkey]
+	s.mutex.RUnlock()
+	if !exists {
+		return accountmanager.ErrAccountNotFound
+	}
+	if !s.inImportWallet(account) {
+		return accountmanager.ErrAccountReadOnly
+	}
+	walletID := account.(e2wtypes.AccountWalletProvider).Wallet().ID()
+
+	// The wallet store has no concept of deletion, so remove the account
+	// from the filesystem and the wallet index directly.
+	store := s.stores[0]
+	locationProvider, isProvider := store.(e2wtypes.StoreLocationProvider)
+	if !isProvider {
+		return errors.New("import wallet store does not provide its location")
+	}
+	if err := os.Remove(filepath.Join(locationProvider.Location(), walletID.String(), account.ID().String())); err != nil {
+		return errors.Wrap(err, "failed to remove account")
+	}
+	serializedIndex, err := store.RetrieveAccountsIndex(walletID)
+	if err == nil {
+		index, err := indexer.Deserialize(serializedIndex)
+		if err != nil {
+			return errors.Wrap(err, "failed to deserialize wallet index")
+		}
+		index.Remove(account.ID(), account.Name())
+		serializedIndex, err = index.Serialize()
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize wallet index")
+		}
+		if err := store.StoreAccountsIndex(walletID, serializedIndex); err != nil {
+			return errors.Wrap(err, "failed to store wallet index")
+		}
+	}
+
+	if locker, isLocker := account.(e2wtypes.AccountLocker); isLocker {
+		if err := locker.Lock(ctx); err != nil {
+			log.Warn().Err(err).Msg("Failed to lock deleted account")
+		}
+	}
+
+	s.mutex.Lock()
+	delete(s.accounts, pubkey)
+	s.mutex.Unlock()
+	log.Info().Str("pubkey", fmt.Sprintf("%#x", pubkey)).Msg("Deleted keystore")
+
+	return nil
+}
+
+// openImportWallet opens the import wallet, creating it in the first store if it does not exist.
+func (s *Service) openImportWallet(ctx context.Context) (e2wtypes.Wallet, error) {
+	wallet, err := e2wallet.OpenWallet(s.importWallet, e2wallet.WithStore(s.stores[0]))
+	if err == nil {
+		return wallet, nil
+	}
+	log.Trace().Err(err).Str("wallet", s.importWallet).Msg("Failed to open import wallet; creating")
+
+	wallet, err = nd.CreateWallet(ctx, s.importWallet, s.stores[0], keystorev4.New())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create import wallet")
+	}
+	log.Info().Str("wallet", s.importWallet).Msg("Created import wallet")
+
+	return wallet, nil
+}
+
+// inImportWallet returns true if the account is held in the import wallet.
+func (s *Service) inImportWallet(account e2wtypes.Account) bool {
+	if s.importWallet == "" {
+		return false
+	}
+	walletProvider, isProvider := account.(e2wtypes.AccountWalletProvider)
+	if !isProvider {
+		return false
+	}
+
+	return walletProvider.Wallet().Name() == s.importWallet
+}
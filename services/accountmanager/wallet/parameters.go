@@ -14,8 +14,11 @@
 package wallet
 
 import (
+	"strings"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/dutyblacklist"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/validatorsmanager"
 	"github.com/pkg/errors"
@@ -29,11 +32,13 @@ type parameters struct {
 	locations              []string
 	accountPaths           []string
 	passphrases            [][]byte
+	importWallet           string
 	validatorsManager      validatorsmanager.Service
 	specProvider           eth2client.SpecProvider
 	domainProvider         eth2client.DomainProvider
 	farFutureEpochProvider eth2client.FarFutureEpochProvider
 	currentEpochProvider   chaintime.Service
+	dutyBlacklist          dutyblacklist.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -89,6 +94,13 @@ func WithPassphrases(passphrases [][]byte) Parameter {
 	})
 }
 
+// WithImportWallet sets the wallet into which keystores are imported at runtime.
+func WithImportWallet(name string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.importWallet = name
+	})
+}
+
 // WithValidatorsManager sets the validator manager.
 func WithValidatorsManager(manager validatorsmanager.Service) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -124,6 +136,13 @@ func WithCurrentEpochProvider(provider chaintime.Service) Parameter {
 	})
 }
 
+// WithDutyBlacklist sets the blacklist of validators for which duties are suspended.
+func WithDutyBlacklist(blacklist dutyblacklist.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyBlacklist = blacklist
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -147,6 +166,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.passphrases) == 0 {
 		return nil, errors.New("no passphrases specified")
 	}
+	if strings.Contains(parameters.importWallet, "/") {
+		return nil, errors.New("import wallet name cannot contain '/'")
+	}
 	if parameters.validatorsManager == nil {
 		return nil, errors.New("no validators manager specified")
 	}
@@ -19,12 +19,14 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/dutyblacklist"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/validatorsmanager"
 	"github.com/pkg/errors"
@@ -48,12 +50,17 @@ type Service struct {
 	stores               []e2wtypes.Store
 	accountPaths         []string
 	passphrases          [][]byte
+	importWallet         string
+	keystoresMu          sync.Mutex
 	accounts             map[phase0.BLSPubKey]e2wtypes.Account
 	validatorsManager    validatorsmanager.Service
 	slotsPerEpoch        phase0.Slot
 	domainProvider       eth2client.DomainProvider
 	farFutureEpoch       phase0.Epoch
 	currentEpochProvider chaintime.Service
+	dutyBlacklist        dutyblacklist.Service
+	availability         map[string]walletAvailability
+	availabilityMu       sync.Mutex
 }
 
 // module-wide log.
@@ -112,11 +119,19 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		stores:               stores,
 		accountPaths:         parameters.accountPaths,
 		passphrases:          parameters.passphrases,
+		importWallet:         parameters.importWallet,
 		validatorsManager:    parameters.validatorsManager,
 		slotsPerEpoch:        phase0.Slot(slotsPerEpoch),
 		domainProvider:       parameters.domainProvider,
 		farFutureEpoch:       farFutureEpoch,
 		currentEpochProvider: parameters.currentEpochProvider,
+		dutyBlacklist:        parameters.dutyBlacklist,
+		availability:         make(map[string]walletAvailability),
+	}
+
+	if s.importWallet != "" && !importWalletInAccountPaths(s.importWallet, s.accountPaths) {
+		// Ensure that imported accounts are picked up on refresh.
+		s.accountPaths = append(s.accountPaths, s.importWallet)
 	}
 
 	s.refreshAccounts(ctx)
@@ -144,9 +159,11 @@ func (s *Service) Refresh(ctx context.Context) {
 func (s *Service) refreshAccounts(ctx context.Context) {
 	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.wallet").Start(ctx, "refreshAccounts")
 	defer span.End()
+	started := time.Now()
 
 	// Find the relevant wallets.
 	wallets := make(map[string]e2wtypes.Wallet)
+	availabilities := make(map[string]*walletAvailability)
 	for _, path := range s.accountPaths {
 		pathBits := strings.Split(path, "/")
 
@@ -165,6 +182,7 @@ func (s *Service) refreshAccounts(ctx context.Context) {
 		}
 		if !found {
 			log.Warn().Str("wallet", pathBits[0]).Msg("Failed to find wallet in any store")
+			availabilities[pathBits[0]] = nil
 		}
 	}
 	if e := log.Trace(); e.Enabled() {
@@ -178,14 +196,40 @@ func (s *Service) refreshAccounts(ctx context.Context) {
 	verificationRegexes := accountPathsToVerificationRegexes(s.accountPaths)
 	// Fetch accounts for each wallet.
 	accounts := make(map[phase0.BLSPubKey]e2wtypes.Account)
-	for _, wallet := range wallets {
-		s.fetchAccountsForWallet(ctx, wallet, accounts, verificationRegexes)
+	for name, wallet := range wallets {
+		availabilities[name] = s.fetchAccountsForWallet(ctx, wallet, accounts, verificationRegexes)
 	}
 	log.Trace().Int("accounts", len(accounts)).Msg("Obtained accounts")
+	s.updateWalletAvailability(availabilities)
 
 	s.mutex.Lock()
+	added, removed := accountChanges(s.accounts, accounts)
 	s.accounts = accounts
 	s.mutex.Unlock()
+	s.monitor.AccountsRefreshed(started, added, removed)
+}
+
+// accountChanges returns the number of accounts added and removed between two account sets.
+func accountChanges(oldAccounts map[phase0.BLSPubKey]e2wtypes.Account,
+	newAccounts map[phase0.BLSPubKey]e2wtypes.Account,
+) (
+	int,
+	int,
+) {
+	added := 0
+	for pubKey := range newAccounts {
+		if _, exists := oldAccounts[pubKey]; !exists {
+			added++
+		}
+	}
+	removed := 0
+	for pubKey := range oldAccounts {
+		if _, exists := newAccounts[pubKey]; !exists {
+			removed++
+		}
+	}
+
+	return added, removed
 }
 
 // refreshValidators refreshes the validator information for our known accounts.
@@ -235,6 +279,9 @@ func (s *Service) ValidatingAccountsForEpoch(ctx context.Context, epoch phase0.E
 		state := apiv1.ValidatorToState(validator, nil, epoch, s.farFutureEpoch)
 		stateCount[state]++
 		if state == apiv1.ValidatorStateActiveOngoing || state == apiv1.ValidatorStateActiveExiting {
+			if s.blacklisted(ctx, epoch, validator.PublicKey) {
+				continue
+			}
 			account := s.accounts[validator.PublicKey]
 			log.Trace().
 				Str("name", account.Name()).
@@ -281,6 +328,9 @@ func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context, epoch p
 		}
 		state := apiv1.ValidatorToState(validator, nil, epoch, s.farFutureEpoch)
 		if state == apiv1.ValidatorStateActiveOngoing || state == apiv1.ValidatorStateActiveExiting {
+			if s.blacklisted(ctx, epoch, validator.PublicKey) {
+				continue
+			}
 			validatingAccounts[index] = s.accounts[validator.PublicKey]
 		}
 	}
@@ -288,6 +338,11 @@ func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context, epoch p
 	return validatingAccounts, nil
 }
 
+// blacklisted returns true if duties for the validator are suspended by the duty blacklist.
+func (s *Service) blacklisted(ctx context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool {
+	return s.dutyBlacklist != nil && s.dutyBlacklist.Blacklisted(ctx, epoch, pubKey)
+}
+
 // accountPathsToVerificationRegexes turns account paths in to regexes to allow verification.
 func accountPathsToVerificationRegexes(paths []string) []*regexp.Regexp {
 	regexes := make([]*regexp.Regexp, 0, len(paths))
@@ -318,12 +373,15 @@ func accountPathsToVerificationRegexes(paths []string) []*regexp.Regexp {
 	return regexes
 }
 
-func (s *Service) fetchAccountsForWallet(ctx context.Context, wallet e2wtypes.Wallet, accounts map[phase0.BLSPubKey]e2wtypes.Account, verificationRegexes []*regexp.Regexp) {
+// fetchAccountsForWallet adds the unlockable accounts in the wallet that match our
+// account paths to the supplied accounts, and returns the availability of the wallet's accounts.
+func (s *Service) fetchAccountsForWallet(ctx context.Context, wallet e2wtypes.Wallet, accounts map[phase0.BLSPubKey]e2wtypes.Account, verificationRegexes []*regexp.Regexp) *walletAvailability {
 	ctx, span := otel.Tracer("attestantio.vouch.services.accountmanager.wallet").Start(ctx, "fetchAccountsForWallet", trace.WithAttributes(
 		attribute.String("wallet", wallet.Name()),
 	))
 	defer span.End()
 
+	availability := &walletAvailability{}
 	var mu sync.Mutex
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	var wg sync.WaitGroup
@@ -349,6 +407,9 @@ func (s *Service) fetchAccountsForWallet(ctx context.Context, wallet e2wtypes.Wa
 				log.Debug().Str("account", name).Msg("Received unwanted account from server; ignoring")
 				return
 			}
+			mu.Lock()
+			availability.expected++
+			mu.Unlock()
 
 			var pubKey []byte
 			if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
@@ -376,10 +437,13 @@ func (s *Service) fetchAccountsForWallet(ctx context.Context, wallet e2wtypes.Wa
 			// Set up account as unknown to beacon chain.
 			mu.Lock()
 			accounts[bytesutil.ToBytes48(pubKey)] = account
+			availability.signable++
 			mu.Unlock()
 		}(ctx, sem, &wg, wallet, account, accounts, &mu)
 	}
 	wg.Wait()
+
+	return availability
 }
 
 // AccountByPublicKey returns the account for the given public key.
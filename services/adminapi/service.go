@@ -0,0 +1,63 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adminapi provides an API for operational control of Vouch.
+package adminapi
+
+import "context"
+
+// Service is the admin API service.
+type Service interface{}
+
+// DutyController pauses and resumes duties.
+type DutyController interface {
+	// PauseAttestations pauses attestations until they are resumed.
+	PauseAttestations(ctx context.Context)
+
+	// ResumeAttestations resumes paused attestations.
+	ResumeAttestations(ctx context.Context)
+
+	// AttestationsPaused returns true if attestations are paused.
+	AttestationsPaused(ctx context.Context) bool
+
+	// PauseProposals pauses beacon block proposals until they are resumed.
+	PauseProposals(ctx context.Context)
+
+	// ResumeProposals resumes paused beacon block proposals.
+	ResumeProposals(ctx context.Context)
+
+	// ProposalsPaused returns true if beacon block proposals are paused.
+	ProposalsPaused(ctx context.Context) bool
+}
+
+// MaintenanceController enters and leaves maintenance mode.
+type MaintenanceController interface {
+	// EnterMaintenance enters maintenance mode, draining duties.
+	EnterMaintenance(ctx context.Context)
+
+	// LeaveMaintenance leaves maintenance mode, resuming duties.
+	LeaveMaintenance(ctx context.Context)
+
+	// InMaintenance returns true if in maintenance mode.
+	InMaintenance(ctx context.Context) bool
+
+	// MaintenanceDrained returns true if in maintenance mode and all duties have
+	// completed, at which point it is safe to stop.
+	MaintenanceDrained(ctx context.Context) bool
+}
+
+// ConfigurationProvider provides the current configuration.
+type ConfigurationProvider interface {
+	// Configuration returns the current configuration, with sensitive values redacted.
+	Configuration(ctx context.Context) map[string]any
+}
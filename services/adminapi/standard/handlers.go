@@ -0,0 +1,263 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type validatorJSON struct {
+	Index      string `json:"index"`
+	Pubkey     string `json:"pubkey"`
+	State      string `json:"state"`
+	Validating bool   `json:"validating"`
+}
+
+type pauseJSON struct {
+	Attestations bool `json:"attestations"`
+	Proposals    bool `json:"proposals"`
+}
+
+type maintenanceJSON struct {
+	Active  bool `json:"active"`
+	Drained bool `json:"drained"`
+}
+
+// handleDuties returns the names of the jobs currently held by the scheduler.
+func (s *Service) handleDuties(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	jobs := s.scheduler.ListJobs(r.Context())
+	sort.Strings(jobs)
+
+	s.sendData(w, http.StatusOK, jobs)
+}
+
+// handleValidators returns the status of the validators that are validating in the current epoch.
+func (s *Service) handleValidators(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	epoch := s.chainTime.CurrentEpoch()
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpoch(r.Context(), epoch)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain validating accounts")
+		s.sendError(w, http.StatusInternalServerError, "failed to obtain validating accounts")
+		return
+	}
+
+	indices := make([]phase0.ValidatorIndex, 0, len(accounts))
+	for index := range accounts {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	validators := make([]*validatorJSON, 0, len(indices))
+	for _, index := range indices {
+		validators = append(validators, s.validator(r, index, accountPubKey(accounts[index]), epoch, true))
+	}
+
+	s.sendData(w, http.StatusOK, validators)
+}
+
+// handleValidator returns the status of a single validator, given its public key.
+func (s *Service) handleValidator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	pubkey, err := parsePubkey(strings.TrimPrefix(r.URL.Path, "/admin/v1/validators/"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	validators := s.validatorsManager.ValidatorsByPubKey(r.Context(), []phase0.BLSPubKey{pubkey})
+	if len(validators) == 0 {
+		s.sendError(w, http.StatusNotFound, "validator not found")
+		return
+	}
+	var index phase0.ValidatorIndex
+	for validatorIndex := range validators {
+		index = validatorIndex
+	}
+
+	epoch := s.chainTime.CurrentEpoch()
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(r.Context(), epoch, []phase0.ValidatorIndex{index})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain validating accounts")
+		s.sendError(w, http.StatusInternalServerError, "failed to obtain validating accounts")
+		return
+	}
+	_, validating := accounts[index]
+
+	s.sendData(w, http.StatusOK, s.validator(r, index, pubkey, epoch, validating))
+}
+
+// validator returns the status of a validator.
+func (s *Service) validator(r *http.Request,
+	index phase0.ValidatorIndex,
+	pubkey phase0.BLSPubKey,
+	epoch phase0.Epoch,
+	validating bool,
+) *validatorJSON {
+	state, err := s.validatorsManager.ValidatorStateAtEpoch(r.Context(), index, epoch)
+	if err != nil {
+		log.Debug().Uint64("validator_index", uint64(index)).Err(err).Msg("Failed to obtain validator state")
+	}
+
+	return &validatorJSON{
+		Index:      strconv.FormatUint(uint64(index), 10),
+		Pubkey:     fmt.Sprintf("%#x", pubkey),
+		State:      state.String(),
+		Validating: validating,
+	}
+}
+
+// handleAccountsRefresh starts a refresh of the accounts.
+func (s *Service) handleAccountsRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if !s.refreshing.CompareAndSwap(false, true) {
+		s.sendError(w, http.StatusConflict, "accounts refresh already in progress")
+		return
+	}
+
+	log.Info().Msg("Refreshing accounts")
+	go func() {
+		defer s.refreshing.Store(false)
+		s.accountsRefresher.Refresh(s.ctx)
+		log.Info().Msg("Refreshed accounts")
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handlePauseStatus returns the duties that are paused.
+func (s *Service) handlePauseStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.sendPauseStatus(w, r)
+}
+
+// handlePause pauses a duty on POST, and resumes it on DELETE.
+func (s *Service) handlePause(w http.ResponseWriter, r *http.Request) {
+	duty := strings.TrimPrefix(r.URL.Path, "/admin/v1/pause/")
+	if duty != "attestations" && duty != "proposals" {
+		s.sendError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	pause := r.Method == http.MethodPost
+
+	switch {
+	case duty == "attestations" && pause:
+		s.dutyController.PauseAttestations(r.Context())
+	case duty == "attestations":
+		s.dutyController.ResumeAttestations(r.Context())
+	case pause:
+		s.dutyController.PauseProposals(r.Context())
+	default:
+		s.dutyController.ResumeProposals(r.Context())
+	}
+
+	s.sendPauseStatus(w, r)
+}
+
+// sendPauseStatus sends the duties that are paused.
+func (s *Service) sendPauseStatus(w http.ResponseWriter, r *http.Request) {
+	s.sendData(w, http.StatusOK, &pauseJSON{
+		Attestations: s.dutyController.AttestationsPaused(r.Context()),
+		Proposals:    s.dutyController.ProposalsPaused(r.Context()),
+	})
+}
+
+// handleMaintenance returns the maintenance status on GET, enters maintenance
+// mode on POST, and leaves it on DELETE.
+func (s *Service) handleMaintenance(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		s.maintenanceController.EnterMaintenance(s.ctx)
+	case http.MethodDelete:
+		s.maintenanceController.LeaveMaintenance(r.Context())
+	default:
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.sendData(w, http.StatusOK, &maintenanceJSON{
+		Active:  s.maintenanceController.InMaintenance(r.Context()),
+		Drained: s.maintenanceController.MaintenanceDrained(r.Context()),
+	})
+}
+
+// handleConfig returns the current configuration.
+func (s *Service) handleConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.sendData(w, http.StatusOK, s.configurationProvider.Configuration(r.Context()))
+}
+
+// accountPubKey returns the public key of an account.
+func accountPubKey(account e2wtypes.Account) phase0.BLSPubKey {
+	var pubkey phase0.BLSPubKey
+	if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		copy(pubkey[:], provider.CompositePublicKey().Marshal())
+	} else {
+		copy(pubkey[:], account.PublicKey().Marshal())
+	}
+
+	return pubkey
+}
+
+// parsePubkey parses a hex-encoded public key.
+func parsePubkey(input string) (phase0.BLSPubKey, error) {
+	pubkey := phase0.BLSPubKey{}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(data) != len(pubkey) {
+		return pubkey, fmt.Errorf("invalid public key %q", input)
+	}
+	copy(pubkey[:], data)
+
+	return pubkey, nil
+}
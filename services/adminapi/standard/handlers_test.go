@@ -0,0 +1,358 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/adminapi/standard"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// account is an account with only a public key.
+type account struct {
+	e2wtypes.Account
+	pubkey e2types.PublicKey
+}
+
+func (a *account) PublicKey() e2types.PublicKey {
+	return a.pubkey
+}
+
+// jobsScheduler is a scheduler that lists a fixed set of jobs.
+type jobsScheduler struct {
+	scheduler.Service
+	jobs []string
+}
+
+func (s *jobsScheduler) ListJobs(_ context.Context) []string {
+	return s.jobs
+}
+
+// validatorsManager is a validators manager that knows about a single validator.
+type validatorsManager struct {
+	index  phase0.ValidatorIndex
+	pubkey phase0.BLSPubKey
+}
+
+func (*validatorsManager) RefreshValidatorsFromBeaconNode(_ context.Context, _ []phase0.BLSPubKey) error {
+	return nil
+}
+
+func (*validatorsManager) ValidatorsByIndex(_ context.Context, _ []phase0.ValidatorIndex) map[phase0.ValidatorIndex]*phase0.Validator {
+	return nil
+}
+
+func (m *validatorsManager) ValidatorsByPubKey(_ context.Context, pubKeys []phase0.BLSPubKey) map[phase0.ValidatorIndex]*phase0.Validator {
+	res := make(map[phase0.ValidatorIndex]*phase0.Validator)
+	for _, pubKey := range pubKeys {
+		if pubKey == m.pubkey {
+			res[m.index] = &phase0.Validator{PublicKey: pubKey}
+		}
+	}
+
+	return res
+}
+
+func (m *validatorsManager) ValidatorStateAtEpoch(_ context.Context, index phase0.ValidatorIndex, _ phase0.Epoch) (apiv1.ValidatorState, error) {
+	if index != m.index {
+		return apiv1.ValidatorStateUnknown, fmt.Errorf("unknown validator %d", index)
+	}
+
+	return apiv1.ValidatorStateActiveOngoing, nil
+}
+
+// dutyController is a duty controller that holds its state in memory.
+type dutyController struct {
+	attestationsPaused bool
+	proposalsPaused    bool
+}
+
+func (c *dutyController) PauseAttestations(_ context.Context)       { c.attestationsPaused = true }
+func (c *dutyController) ResumeAttestations(_ context.Context)      { c.attestationsPaused = false }
+func (c *dutyController) AttestationsPaused(_ context.Context) bool { return c.attestationsPaused }
+func (c *dutyController) PauseProposals(_ context.Context)          { c.proposalsPaused = true }
+func (c *dutyController) ResumeProposals(_ context.Context)         { c.proposalsPaused = false }
+func (c *dutyController) ProposalsPaused(_ context.Context) bool    { return c.proposalsPaused }
+
+// maintenanceController is a maintenance controller that holds its state in memory.
+type maintenanceController struct {
+	maintenance bool
+}
+
+func (c *maintenanceController) EnterMaintenance(_ context.Context)        { c.maintenance = true }
+func (c *maintenanceController) LeaveMaintenance(_ context.Context)        { c.maintenance = false }
+func (c *maintenanceController) InMaintenance(_ context.Context) bool      { return c.maintenance }
+func (c *maintenanceController) MaintenanceDrained(_ context.Context) bool { return c.maintenance }
+
+// configurationProvider is a configuration provider with a fixed configuration.
+type configurationProvider struct{}
+
+func (*configurationProvider) Configuration(_ context.Context) map[string]any {
+	return map[string]any{
+		"beacon-node-address": "localhost:5052",
+	}
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return address
+}
+
+func TestHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, e2types.InitBLS())
+	key, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+	var pubkey phase0.BLSPubKey
+	copy(pubkey[:], key.PublicKey().Marshal())
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(2, &account{pubkey: key.PublicKey()})
+
+	address := freeAddress(t)
+	_, err = standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithListenAddress(address),
+		standard.WithBearerToken("secret"),
+		standard.WithChainTime(chainTime),
+		standard.WithScheduler(&jobsScheduler{jobs: []string{"Sync committee messages for slot 2", "Attestations for slot 1"}}),
+		standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+		standard.WithValidatorsManager(&validatorsManager{index: 2, pubkey: pubkey}),
+		standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+		standard.WithDutyController(&dutyController{}),
+		standard.WithMaintenanceController(&maintenanceController{}),
+		standard.WithConfigurationProvider(&configurationProvider{}),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		token        string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "TokenMissing",
+			method:       http.MethodGet,
+			path:         "/admin/v1/duties",
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"code":401,"message":"missing bearer token"}`,
+		},
+		{
+			name:         "TokenIncorrect",
+			method:       http.MethodGet,
+			path:         "/admin/v1/duties",
+			token:        "wrong",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"code":403,"message":"invalid bearer token"}`,
+		},
+		{
+			name:         "Duties",
+			method:       http.MethodGet,
+			path:         "/admin/v1/duties",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":["Attestations for slot 1","Sync committee messages for slot 2"]}`,
+		},
+		{
+			name:         "DutiesWrongMethod",
+			method:       http.MethodPost,
+			path:         "/admin/v1/duties",
+			token:        "secret",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"code":405,"message":"method not allowed"}`,
+		},
+		{
+			name:         "Validators",
+			method:       http.MethodGet,
+			path:         "/admin/v1/validators",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: fmt.Sprintf(`{"data":[{"index":"2","pubkey":"%#x","state":"active_ongoing","validating":true}]}`, pubkey),
+		},
+		{
+			name:         "Validator",
+			method:       http.MethodGet,
+			path:         fmt.Sprintf("/admin/v1/validators/%#x", pubkey),
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: fmt.Sprintf(`{"data":{"index":"2","pubkey":"%#x","state":"active_ongoing","validating":true}}`, pubkey),
+		},
+		{
+			name:         "ValidatorInvalid",
+			method:       http.MethodGet,
+			path:         "/admin/v1/validators/0x1234",
+			token:        "secret",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":400,"message":"invalid public key \"0x1234\""}`,
+		},
+		{
+			name:         "ValidatorUnknown",
+			method:       http.MethodGet,
+			path:         fmt.Sprintf("/admin/v1/validators/%#x", phase0.BLSPubKey{0x01}),
+			token:        "secret",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"code":404,"message":"validator not found"}`,
+		},
+		{
+			name:         "AccountsRefresh",
+			method:       http.MethodPost,
+			path:         "/admin/v1/accounts/refresh",
+			token:        "secret",
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "PauseStatus",
+			method:       http.MethodGet,
+			path:         "/admin/v1/pause",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"attestations":false,"proposals":false}}`,
+		},
+		{
+			name:         "PauseAttestations",
+			method:       http.MethodPost,
+			path:         "/admin/v1/pause/attestations",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"attestations":true,"proposals":false}}`,
+		},
+		{
+			name:         "PauseProposals",
+			method:       http.MethodPost,
+			path:         "/admin/v1/pause/proposals",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"attestations":true,"proposals":true}}`,
+		},
+		{
+			name:         "ResumeAttestations",
+			method:       http.MethodDelete,
+			path:         "/admin/v1/pause/attestations",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"attestations":false,"proposals":true}}`,
+		},
+		{
+			name:         "PauseUnknown",
+			method:       http.MethodPost,
+			path:         "/admin/v1/pause/aggregations",
+			token:        "secret",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"code":404,"message":"not found"}`,
+		},
+		{
+			name:         "PauseWrongMethod",
+			method:       http.MethodGet,
+			path:         "/admin/v1/pause/proposals",
+			token:        "secret",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"code":405,"message":"method not allowed"}`,
+		},
+		{
+			name:         "MaintenanceStatus",
+			method:       http.MethodGet,
+			path:         "/admin/v1/maintenance",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"active":false,"drained":false}}`,
+		},
+		{
+			name:         "MaintenanceEnter",
+			method:       http.MethodPost,
+			path:         "/admin/v1/maintenance",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"active":true,"drained":true}}`,
+		},
+		{
+			name:         "MaintenanceLeave",
+			method:       http.MethodDelete,
+			path:         "/admin/v1/maintenance",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"active":false,"drained":false}}`,
+		},
+		{
+			name:         "Config",
+			method:       http.MethodGet,
+			path:         "/admin/v1/config",
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"beacon-node-address":"localhost:5052"}}`,
+		},
+	}
+
+	// Wait for the server to start.
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(ctx, test.method, fmt.Sprintf("http://%s%s", address, test.path), nil)
+			require.NoError(t, err)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedCode, resp.StatusCode)
+			if test.expectedBody != "" {
+				require.Equal(t, test.expectedBody, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/adminapi"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/validatorsmanager"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                   zerolog.Level
+	listenAddress              string
+	bearerToken                string
+	chainTime                  chaintime.Service
+	scheduler                  scheduler.Service
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	validatorsManager          validatorsmanager.Service
+	accountsRefresher          accountmanager.Refresher
+	dutyController             adminapi.DutyController
+	maintenanceController      adminapi.MaintenanceController
+	configurationProvider      adminapi.ConfigurationProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithListenAddress sets the listen address for the service.
+func WithListenAddress(listenAddress string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenAddress = listenAddress
+	})
+}
+
+// WithBearerToken sets the bearer token required to access the API.
+func WithBearerToken(bearerToken string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.bearerToken = bearerToken
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithScheduler sets the scheduler.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithValidatingAccountsProvider sets the validating accounts provider.
+func WithValidatingAccountsProvider(provider accountmanager.ValidatingAccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatingAccountsProvider = provider
+	})
+}
+
+// WithValidatorsManager sets the validators manager.
+func WithValidatorsManager(manager validatorsmanager.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorsManager = manager
+	})
+}
+
+// WithAccountsRefresher sets the accounts refresher.
+func WithAccountsRefresher(refresher accountmanager.Refresher) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.accountsRefresher = refresher
+	})
+}
+
+// WithDutyController sets the duty controller.
+func WithDutyController(controller adminapi.DutyController) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyController = controller
+	})
+}
+
+// WithMaintenanceController sets the maintenance controller.
+func WithMaintenanceController(controller adminapi.MaintenanceController) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maintenanceController = controller
+	})
+}
+
+// WithConfigurationProvider sets the configuration provider.
+func WithConfigurationProvider(provider adminapi.ConfigurationProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.configurationProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.listenAddress == "" {
+		return nil, errors.New("no listen address specified")
+	}
+	if parameters.bearerToken == "" {
+		return nil, errors.New("no bearer token specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.validatingAccountsProvider == nil {
+		return nil, errors.New("no validating accounts provider specified")
+	}
+	if parameters.validatorsManager == nil {
+		return nil, errors.New("no validators manager specified")
+	}
+	if parameters.accountsRefresher == nil {
+		return nil, errors.New("no accounts refresher specified")
+	}
+	if parameters.dutyController == nil {
+		return nil, errors.New("no duty controller specified")
+	}
+	if parameters.maintenanceController == nil {
+		return nil, errors.New("no maintenance controller specified")
+	}
+	if parameters.configurationProvider == nil {
+		return nil, errors.New("no configuration provider specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/adminapi"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/validatorsmanager"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is an admin API server.
+type Service struct {
+	bearerToken                []byte
+	chainTime                  chaintime.Service
+	scheduler                  scheduler.Service
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	validatorsManager          validatorsmanager.Service
+	accountsRefresher          accountmanager.Refresher
+	dutyController             adminapi.DutyController
+	maintenanceController      adminapi.MaintenanceController
+	configurationProvider      adminapi.ConfigurationProvider
+	server                     *http.Server
+
+	// ctx is the context of the service, used for work that outlives the
+	// request that triggers it.
+	ctx        context.Context
+	refreshing atomic.Bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new admin API server.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "adminapi").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		bearerToken:                []byte(parameters.bearerToken),
+		chainTime:                  parameters.chainTime,
+		scheduler:                  parameters.scheduler,
+		validatingAccountsProvider: parameters.validatingAccountsProvider,
+		validatorsManager:          parameters.validatorsManager,
+		accountsRefresher:          parameters.accountsRefresher,
+		dutyController:             parameters.dutyController,
+		maintenanceController:      parameters.maintenanceController,
+		configurationProvider:      parameters.configurationProvider,
+		ctx:                        ctx,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/v1/duties", s.authenticated(s.handleDuties))
+	mux.HandleFunc("/admin/v1/validators", s.authenticated(s.handleValidators))
+	mux.HandleFunc("/admin/v1/validators/", s.authenticated(s.handleValidator))
+	mux.HandleFunc("/admin/v1/accounts/refresh", s.authenticated(s.handleAccountsRefresh))
+	mux.HandleFunc("/admin/v1/pause", s.authenticated(s.handlePauseStatus))
+	mux.HandleFunc("/admin/v1/pause/", s.authenticated(s.handlePause))
+	mux.HandleFunc("/admin/v1/maintenance", s.authenticated(s.handleMaintenance))
+	mux.HandleFunc("/admin/v1/config", s.authenticated(s.handleConfig))
+	s.server = &http.Server{
+		Addr:              parameters.listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Info().Str("listen_address", parameters.listenAddress).Msg("Starting admin API server")
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Str("listen_address", parameters.listenAddress).Err(err).Msg("Failed to run admin API server")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("Failed to shut down admin API server")
+		}
+	}()
+
+	return s, nil
+}
+
+// authenticated wraps a handler to ensure that the request carries the bearer token.
+func (s *Service) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Bearer ") {
+			s.sendError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), s.bearerToken) != 1 {
+			s.sendError(w, http.StatusForbidden, "invalid bearer token")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// errorResponse is the body of an error response.
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// dataResponse is the body of a successful response.
+type dataResponse struct {
+	Data any `json:"data"`
+}
+
+// sendError sends an error response.
+func (s *Service) sendError(w http.ResponseWriter, code int, message string) {
+	s.sendResponse(w, code, &errorResponse{
+		Code:    code,
+		Message: message,
+	})
+}
+
+// sendData sends a successful response containing the given data.
+func (s *Service) sendData(w http.ResponseWriter, code int, data any) {
+	s.sendResponse(w, code, &dataResponse{
+		Data: data,
+	})
+}
+
+// sendResponse sends a JSON response.
+func (*Service) sendResponse(w http.ResponseWriter, code int, data any) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to marshal response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(body); err != nil {
+		log.Debug().Err(err).Msg("Failed to write response")
+	}
+}
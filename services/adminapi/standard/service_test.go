@@ -0,0 +1,234 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/adminapi/standard"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "ListenAddressMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no listen address specified",
+		},
+		{
+			name: "BearerTokenMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no bearer token specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no chain time service specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ValidatingAccountsProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no validating accounts provider specified",
+		},
+		{
+			name: "ValidatorsManagerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no validators manager specified",
+		},
+		{
+			name: "AccountsRefresherMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no accounts refresher specified",
+		},
+		{
+			name: "DutyControllerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no duty controller specified",
+		},
+		{
+			name: "MaintenanceControllerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+			err: "problem with parameters: no maintenance controller specified",
+		},
+		{
+			name: "ConfigurationProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+			},
+			err: "problem with parameters: no configuration provider specified",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress(freeAddress(t)),
+				standard.WithBearerToken("secret"),
+				standard.WithChainTime(chainTime),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				standard.WithValidatorsManager(&validatorsManager{}),
+				standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				standard.WithDutyController(&dutyController{}),
+				standard.WithMaintenanceController(&maintenanceController{}),
+				standard.WithConfigurationProvider(&configurationProvider{}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ package attestationaggregator
 
 import (
 	"context"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
@@ -29,6 +30,28 @@ type Duty struct {
 	ValidatorIndex phase0.ValidatorIndex
 	// SlotSignature is the signature of the slot by the validator carrying out the aggregation; reuqired for submitting the aggregate.
 	SlotSignature phase0.BLSSignature
+	// ValidatorCommitteeIndex is the index of the validator carrying out the aggregation in its committee; used to verify the aggregate.
+	ValidatorCommitteeIndex uint64
+	// Deadline is the latest time at which to obtain the aggregate.  If set, the aggregate is obtained as soon as it is
+	// sufficiently complete, up to the deadline; otherwise the aggregate is obtained immediately.
+	Deadline time.Time
+}
+
+type validatorCommitteeIndexKey struct{}
+
+// ContextWithValidatorCommitteeIndex returns a context containing the index of the aggregating
+// validator in its committee, allowing providers of aggregates to confirm that the aggregate
+// contains the validator's attestation.
+func ContextWithValidatorCommitteeIndex(ctx context.Context, index uint64) context.Context {
+	return context.WithValue(ctx, validatorCommitteeIndexKey{}, index)
+}
+
+// ValidatorCommitteeIndexFromContext returns the index of the aggregating validator in its
+// committee, if present in the context.
+func ValidatorCommitteeIndexFromContext(ctx context.Context) (uint64, bool) {
+	index, ok := ctx.Value(validatorCommitteeIndexKey{}).(uint64)
+
+	return index, ok
 }
 
 // IsAggregatorProvider provides information about if a validator is an aggregator.
@@ -0,0 +1,108 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attestationaggregator"
+	"github.com/pkg/errors"
+)
+
+// obtainAggregateAttestation obtains the aggregate attestation for the duty.
+// If the duty has a deadline then the aggregate is polled for until it is
+// sufficiently complete or the deadline passes, in which case the most
+// complete aggregate seen is returned.
+func (s *Service) obtainAggregateAttestation(ctx context.Context,
+	duty *attestationaggregator.Duty,
+) (
+	*phase0.Attestation,
+	error,
+) {
+	if duty.Deadline.IsZero() {
+		return s.aggregateAttestation(ctx, duty)
+	}
+
+	started := time.Now()
+	var best *phase0.Attestation
+	var err error
+	for polls := 1; ; polls++ {
+		var aggregate *phase0.Attestation
+		aggregate, err = s.aggregateAttestation(ctx, duty)
+		if err == nil && (best == nil || aggregate.AggregationBits.Count() > best.AggregationBits.Count()) {
+			best = aggregate
+		}
+		if best != nil && s.sufficientlyComplete(best) {
+			log.Trace().Dur("elapsed", time.Since(started)).Int("polls", polls).Msg("Obtained sufficiently complete aggregate")
+			return best, nil
+		}
+
+		wait := time.Until(duty.Deadline)
+		if wait <= 0 {
+			log.Trace().Dur("elapsed", time.Since(started)).Int("polls", polls).Msg("Deadline reached without sufficiently complete aggregate")
+			break
+		}
+		if wait > s.pollInterval {
+			wait = s.pollInterval
+		}
+		select {
+		case <-ctx.Done():
+			if best != nil {
+				return best, nil
+			}
+			return nil, errors.Wrap(ctx.Err(), "context done whilst waiting for aggregate")
+		case <-time.After(wait):
+		}
+	}
+
+	if best != nil {
+		return best, nil
+	}
+
+	return nil, err
+}
+
+// sufficientlyComplete returns true if the aggregate contains enough of its
+// committee to be used without waiting further.
+func (s *Service) sufficientlyComplete(aggregate *phase0.Attestation) bool {
+	if aggregate.AggregationBits.Len() == 0 {
+		return false
+	}
+
+	return float64(aggregate.AggregationBits.Count())/float64(aggregate.AggregationBits.Len()) >= s.aggregateCompleteness
+}
+
+// aggregateAttestation obtains the aggregate attestation for the duty from the provider.
+func (s *Service) aggregateAttestation(ctx context.Context,
+	duty *attestationaggregator.Duty,
+) (
+	*phase0.Attestation,
+	error,
+) {
+	aggregateAttestationResponse, err := s.aggregateAttestationProvider.AggregateAttestation(attestationaggregator.ContextWithValidatorCommitteeIndex(ctx, duty.ValidatorCommitteeIndex), &api.AggregateAttestationOpts{
+		Slot:                duty.Slot,
+		AttestationDataRoot: duty.AttestationDataRoot,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if aggregateAttestationResponse.Data == nil {
+		return nil, errors.New("aggregate attestation nil")
+	}
+
+	return aggregateAttestationResponse.Data, nil
+}
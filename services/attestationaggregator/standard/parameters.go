@@ -21,6 +21,7 @@ import (
 	"github.com/attestantio/vouch/services/submitter"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"time"
 )
 
 type parameters struct {
@@ -32,6 +33,8 @@ type parameters struct {
 	aggregateAttestationsSubmitter submitter.AggregateAttestationsSubmitter
 	slotSelectionSigner            signer.SlotSelectionSigner
 	aggregateAndProofSigner        signer.AggregateAndProofSigner
+	aggregateCompleteness          float64
+	pollInterval                   time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -101,10 +104,27 @@ func WithAggregateAndProofSigner(signer signer.AggregateAndProofSigner) Paramete
 	})
 }
 
+// WithAggregateCompleteness sets the fraction of the committee that must be present in an
+// aggregate for it to be used before the duty's deadline.
+func WithAggregateCompleteness(completeness float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.aggregateCompleteness = completeness
+	})
+}
+
+// WithPollInterval sets the interval between checks for a sufficiently complete aggregate.
+func WithPollInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pollInterval = interval
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:              zerolog.GlobalLevel(),
+		aggregateCompleteness: 0.9,
+		pollInterval:          500 * time.Millisecond,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -133,6 +153,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.aggregateAndProofSigner == nil {
 		return nil, errors.New("no aggregate and proof signer specified")
 	}
+	if parameters.aggregateCompleteness <= 0 || parameters.aggregateCompleteness > 1 {
+		return nil, errors.New("aggregate completeness must be greater than 0 and at most 1")
+	}
+	if parameters.pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
 
 	return &parameters, nil
 }
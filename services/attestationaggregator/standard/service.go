@@ -28,6 +28,7 @@ import (
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/signer"
 	"github.com/attestantio/vouch/services/submitter"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -44,6 +45,8 @@ type Service struct {
 	aggregateAttestationsSubmitter submitter.AggregateAttestationsSubmitter
 	slotSelectionSigner            signer.SlotSelectionSigner
 	aggregateAndProofSigner        signer.AggregateAndProofSigner
+	aggregateCompleteness          float64
+	pollInterval                   time.Duration
 }
 
 // module-wide log.
@@ -95,6 +98,8 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		aggregateAttestationsSubmitter: parameters.aggregateAttestationsSubmitter,
 		slotSelectionSigner:            parameters.slotSelectionSigner,
 		aggregateAndProofSigner:        parameters.aggregateAndProofSigner,
+		aggregateCompleteness:          parameters.aggregateCompleteness,
+		pollInterval:                   parameters.pollInterval,
 	}
 
 	return s, nil
@@ -116,16 +121,13 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	log.Trace().Msg("Aggregating")
 
 	// Obtain the aggregate attestation.
-	aggregateAttestationResponse, err := s.aggregateAttestationProvider.AggregateAttestation(ctx, &api.AggregateAttestationOpts{
-		Slot:                duty.Slot,
-		AttestationDataRoot: duty.AttestationDataRoot,
-	})
+	aggregateAttestation, err := s.obtainAggregateAttestation(ctx, duty)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to obtain aggregate attestation")
 		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
+		util.MonitorError(s.monitor, "attestationaggregator", util.ProviderError(err))
 		return
 	}
-	aggregateAttestation := aggregateAttestationResponse.Data
 
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained aggregate attestation")
 
@@ -135,6 +137,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to obtain proposing validator account")
 		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
+		util.MonitorError(s.monitor, "attestationaggregator", err)
 		return
 	}
 	if len(accounts) != 1 {
@@ -159,6 +162,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to sign aggregate and proof")
 		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
+		util.MonitorError(s.monitor, "attestationaggregator", util.CategoriseError(util.ErrorCategorySignerUnavailable, err))
 		return
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Signed aggregate attestation")
@@ -173,6 +177,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	if err := s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, signedAggregateAndProofs); err != nil {
 		log.Error().Err(err).Msg("Failed to submit aggregate and proof")
 		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
+		util.MonitorError(s.monitor, "attestationaggregator", util.CategoriseError(util.ErrorCategorySubmissionRejected, err))
 		return
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted aggregate attestation")
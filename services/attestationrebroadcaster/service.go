@@ -0,0 +1,29 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attestationrebroadcaster rebroadcasts attestations whose head block has
+// been reorganised away.
+package attestationrebroadcaster
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service is the attestation rebroadcaster service.
+type Service interface {
+	// AttestationsSubmitted records attestations that have been signed and submitted,
+	// allowing them to be rebroadcast if required.
+	AttestationsSubmitted(ctx context.Context, attestations []*phase0.Attestation)
+}
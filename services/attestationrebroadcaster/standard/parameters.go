@@ -0,0 +1,127 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel               zerolog.Level
+	monitor                metrics.Service
+	chainTime              chaintime.Service
+	eventsProvider         eth2client.EventsProvider
+	attestationsSubmitters map[string]eth2client.AttestationsSubmitter
+	window                 uint64
+	timeout                time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithEventsProvider sets the provider of chain reorganisation events.
+func WithEventsProvider(provider eth2client.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventsProvider = provider
+	})
+}
+
+// WithAttestationsSubmitters sets the beacon nodes to which attestations are rebroadcast.
+func WithAttestationsSubmitters(submitters map[string]eth2client.AttestationsSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationsSubmitters = submitters
+	})
+}
+
+// WithWindow sets the number of slots after an attestation's slot in which a
+// reorganisation results in the attestation being rebroadcast.
+func WithWindow(window uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.window = window
+	})
+}
+
+// WithTimeout sets the timeout for rebroadcasts.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		window:   1,
+		timeout:  2 * time.Second,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chaintime specified")
+	}
+	if parameters.eventsProvider == nil {
+		return nil, errors.New("no events provider specified")
+	}
+	if len(parameters.attestationsSubmitters) == 0 {
+		return nil, errors.New("no attestations submitters specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,193 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service rebroadcasts attestations to additional beacon nodes when the block to
+// which they attest is reorganised away shortly after the attestations were made.
+// Attestations are only ever rebroadcast as originally signed, and at most once.
+type Service struct {
+	chainTime  chaintime.Service
+	submitters map[string]eth2client.AttestationsSubmitter
+	window     phase0.Slot
+	timeout    time.Duration
+
+	mu           sync.Mutex
+	attestations map[phase0.Slot][]*phase0.Attestation
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new attestation rebroadcaster.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "attestationrebroadcaster").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime:    parameters.chainTime,
+		submitters:   parameters.attestationsSubmitters,
+		window:       phase0.Slot(parameters.window),
+		timeout:      parameters.timeout,
+		attestations: make(map[phase0.Slot][]*phase0.Attestation),
+	}
+
+	if err := parameters.eventsProvider.Events(ctx, []string{"chain_reorg"}, s.HandleChainReorgEvent); err != nil {
+		return nil, errors.Wrap(err, "failed to add chain reorg event handler")
+	}
+
+	return s, nil
+}
+
+// AttestationsSubmitted records attestations that have been signed and submitted,
+// allowing them to be rebroadcast if required.
+func (s *Service) AttestationsSubmitted(_ context.Context, attestations []*phase0.Attestation) {
+	currentSlot := s.chainTime.CurrentSlot()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attestation := range attestations {
+		if attestation == nil || attestation.Data == nil {
+			continue
+		}
+		s.attestations[attestation.Data.Slot] = append(s.attestations[attestation.Data.Slot], attestation)
+	}
+
+	// Remove attestations that can no longer be rebroadcast.
+	for slot := range s.attestations {
+		if slot+s.window < currentSlot {
+			delete(s.attestations, slot)
+		}
+	}
+}
+
+// HandleChainReorgEvent handles the "chain_reorg" events from the beacon node.
+func (s *Service) HandleChainReorgEvent(event *apiv1.Event) {
+	if event.Data == nil {
+		return
+	}
+	data, ok := event.Data.(*apiv1.ChainReorgEvent)
+	if !ok {
+		log.Warn().Msg("Chain reorg event with unexpected data; ignoring")
+		return
+	}
+	log := log.With().
+		Uint64("slot", uint64(data.Slot)).
+		Uint64("depth", data.Depth).
+		Str("old_head_block", fmt.Sprintf("%#x", data.OldHeadBlock)).
+		Str("new_head_block", fmt.Sprintf("%#x", data.NewHeadBlock)).
+		Logger()
+	log.Trace().Msg("Received chain reorg event")
+
+	attestations := s.orphanedAttestations(data.Slot, data.OldHeadBlock)
+	if len(attestations) == 0 {
+		log.Trace().Msg("No attestations for orphaned block; not rebroadcasting")
+		return
+	}
+
+	log.Info().Int("attestations", len(attestations)).Msg("Block attested to was orphaned; rebroadcasting attestations")
+	go s.rebroadcast(context.Background(), attestations)
+}
+
+// orphanedAttestations returns the attestations that voted for the given orphaned
+// head block, removing them so that they are not rebroadcast again.
+func (s *Service) orphanedAttestations(reorgSlot phase0.Slot, oldHeadBlock phase0.Root) []*phase0.Attestation {
+	currentSlot := s.chainTime.CurrentSlot()
+	currentEpoch := s.chainTime.CurrentEpoch()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orphaned := make([]*phase0.Attestation, 0)
+	for slot, attestations := range s.attestations {
+		// Only rebroadcast attestations made within the window of both the reorganisation and now.
+		if slot > reorgSlot || slot+s.window < reorgSlot || slot+s.window < currentSlot {
+			continue
+		}
+		remaining := make([]*phase0.Attestation, 0, len(attestations))
+		for _, attestation := range attestations {
+			if attestation.Data.BeaconBlockRoot != oldHeadBlock {
+				remaining = append(remaining, attestation)
+				continue
+			}
+			// Beacon nodes will not gossip attestations for earlier epochs.
+			if attestation.Data.Target.Epoch+1 < currentEpoch {
+				continue
+			}
+			orphaned = append(orphaned, attestation)
+		}
+		s.attestations[slot] = remaining
+	}
+
+	return orphaned
+}
+
+// rebroadcast sends the attestations to each of the rebroadcast beacon nodes.
+func (s *Service) rebroadcast(ctx context.Context, attestations []*phase0.Attestation) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var succeededMu sync.Mutex
+	succeeded := false
+	for address, submitter := range s.submitters {
+		wg.Add(1)
+		go func(ctx context.Context, address string, submitter eth2client.AttestationsSubmitter) {
+			defer wg.Done()
+			if err := submitter.SubmitAttestations(ctx, attestations); err != nil {
+				log.Warn().Str("beacon_node_address", address).Err(err).Msg("Failed to rebroadcast attestations")
+				return
+			}
+			log.Trace().Str("beacon_node_address", address).Msg("Rebroadcast attestations")
+			succeededMu.Lock()
+			succeeded = true
+			succeededMu.Unlock()
+		}(ctx, address, submitter)
+	}
+	wg.Wait()
+
+	if succeeded {
+		monitorRebroadcast("succeeded", len(attestations))
+	} else {
+		log.Error().Int("attestations", len(attestations)).Msg("Failed to rebroadcast attestations to any beacon node")
+		monitorRebroadcast("failed", len(attestations))
+	}
+}
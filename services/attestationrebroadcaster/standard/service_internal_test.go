@@ -0,0 +1,117 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSubmitter records the attestations it is sent.
+type recordingSubmitter struct {
+	mu           sync.Mutex
+	err          error
+	attestations []*phase0.Attestation
+}
+
+func (s *recordingSubmitter) SubmitAttestations(_ context.Context, attestations []*phase0.Attestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.attestations = append(s.attestations, attestations...)
+	return nil
+}
+
+func attestation(slot phase0.Slot, root phase0.Root) *phase0.Attestation {
+	return &phase0.Attestation{
+		Data: &phase0.AttestationData{
+			Slot:            slot,
+			BeaconBlockRoot: root,
+			Source:          &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{
+				Epoch: phase0.Epoch(slot / 32),
+			},
+		},
+	}
+}
+
+func TestRebroadcast(t *testing.T) {
+	ctx := context.Background()
+
+	// Start at slot 100.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-100*12*time.Second-time.Second))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), chainTime.CurrentSlot())
+
+	orphanedRoot := phase0.Root{0x01}
+	canonicalRoot := phase0.Root{0x02}
+
+	good := &recordingSubmitter{}
+	bad := &recordingSubmitter{err: errors.New("failed")}
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithChainTime(chainTime),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"good": good,
+			"bad":  bad,
+		}),
+	)
+	require.NoError(t, err)
+
+	orphaned := attestation(100, orphanedRoot)
+	s.AttestationsSubmitted(ctx, []*phase0.Attestation{
+		orphaned,
+		attestation(100, canonicalRoot),
+		attestation(98, orphanedRoot),
+	})
+	// Attestations outside of the window are not retained.
+	require.Len(t, s.attestations, 1)
+
+	// Reorganisation outside of the window.
+	require.Empty(t, s.orphanedAttestations(102, orphanedRoot))
+
+	// Reorganisation of a different block.
+	require.Empty(t, s.orphanedAttestations(101, phase0.Root{0x03}))
+
+	// Reorganisation of the block attested to.
+	attestations := s.orphanedAttestations(101, orphanedRoot)
+	require.Equal(t, []*phase0.Attestation{orphaned}, attestations)
+
+	// Attestations are only rebroadcast once.
+	require.Empty(t, s.orphanedAttestations(101, orphanedRoot))
+
+	// Rebroadcast sends the identical attestations.
+	s.rebroadcast(ctx, attestations)
+	require.Equal(t, []*phase0.Attestation{orphaned}, good.attestations)
+	require.Empty(t, bad.attestations)
+}
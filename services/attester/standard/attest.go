@@ -0,0 +1,242 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"golang.org/x/sync/semaphore"
+)
+
+// signedChunk is the result of signing a chunk of attestations.
+type signedChunk struct {
+	attestations []*phase0.Attestation
+	err          error
+	// start and end are the range of the accounts signed in this chunk.
+	start int
+	end   int
+}
+
+// attest carries out the internal work of attesting.
+// Attestations are signed in chunks, and each chunk is submitted as soon as it
+// has been signed.
+// skipcq: RVV-B0001
+func (s *Service) attest(
+	ctx context.Context,
+	duty *attester.Duty,
+	accounts []e2wtypes.Account,
+	committeeIndices []phase0.CommitteeIndex,
+	validatorCommitteeIndices []phase0.ValidatorIndex,
+	committeeSizes []uint64,
+	data *phase0.AttestationData,
+	started time.Time,
+) ([]*phase0.Attestation, error) {
+	if s.slashingProtection != nil {
+		var err error
+		accounts, committeeIndices, validatorCommitteeIndices, committeeSizes, err = s.protect(ctx, accounts, committeeIndices, validatorCommitteeIndices, committeeSizes, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(accounts) == 0 {
+			log.Warn().Msg("No attestations safe to sign; not signing")
+			return []*phase0.Attestation{}, nil
+		}
+	}
+
+	chunkSize := s.signingChunkSize
+	if chunkSize == 0 || chunkSize > len(accounts) {
+		chunkSize = len(accounts)
+	}
+	chunks := (len(accounts) + chunkSize - 1) / chunkSize
+
+	// Sign the chunks concurrently.  Each chunk is independent, so a failure
+	// to sign one chunk does not affect the others.
+	phaseCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSign)
+	defer cancel()
+	signedCh := make(chan *signedChunk, chunks)
+	sem := semaphore.NewWeighted(s.processConcurrency)
+	for start := 0; start < len(accounts); start += chunkSize {
+		end := start + chunkSize
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		go func(start int, end int) {
+			if err := sem.Acquire(phaseCtx, 1); err != nil {
+				signedCh <- &signedChunk{err: err, start: start, end: end}
+				return
+			}
+			defer sem.Release(1)
+
+			signCtx := phaseCtx
+			if s.signingTimeout > 0 {
+				var cancel context.CancelFunc
+				signCtx, cancel = context.WithTimeout(phaseCtx, s.signingTimeout)
+				defer cancel()
+			}
+			attestations, err := s.signChunk(signCtx,
+				duty,
+				accounts[start:end],
+				committeeIndices[start:end],
+				validatorCommitteeIndices[start:end],
+				committeeSizes[start:end],
+				data,
+			)
+			signedCh <- &signedChunk{
+				attestations: attestations,
+				err:          err,
+				start:        start,
+				end:          end,
+			}
+		}(start, end)
+	}
+
+	// Submit each chunk as it arrives.
+	attestations := make([]*phase0.Attestation, 0, len(accounts))
+	var signErr error
+	var submitErr error
+	failedChunks := 0
+	rejectedChunks := make([]*signedChunk, 0)
+	for i := 0; i < chunks; i++ {
+		chunk := <-signedCh
+		if chunk.err != nil {
+			log.Warn().Err(chunk.err).Int("validators", chunk.end-chunk.start).Msg("Failed to sign chunk of beacon attestations")
+			signErr = chunk.err
+			failedChunks++
+			continue
+		}
+		if len(chunk.attestations) == 0 {
+			continue
+		}
+		log.Trace().Dur("elapsed", time.Since(started)).Int("attestations", len(chunk.attestations)).Msg("Signed chunk")
+
+		if err := s.submit(ctx, chunk.attestations, started); err != nil {
+			submitErr = err
+			rejectedChunks = append(rejectedChunks, chunk)
+			continue
+		}
+		attestations = append(attestations, chunk.attestations...)
+	}
+
+	if len(rejectedChunks) > 0 && s.retryAttestationsSubmitter != nil {
+		attestations = append(attestations, s.retryRejected(ctx, rejectedChunks, started)...)
+	}
+
+	if len(attestations) == 0 {
+		switch {
+		case signErr != nil:
+			return nil, util.CategoriseError(util.ErrorCategorySignerUnavailable, errors.Wrap(signErr, "failed to sign beacon attestations"))
+		case submitErr != nil:
+			return nil, util.CategoriseError(util.ErrorCategorySubmissionRejected, errors.Wrap(submitErr, "failed to submit attestations"))
+		default:
+			log.Info().Msg("No signed attestations; not submitting")
+			return attestations, nil
+		}
+	}
+	if failedChunks > 0 {
+		log.Warn().Int("failed_chunks", failedChunks).Int("chunks", chunks).Msg("Failed to sign some beacon attestations")
+	}
+	if submitErr != nil {
+		log.Warn().Err(submitErr).Msg("Failed to submit some attestations")
+	}
+
+	return attestations, nil
+}
+
+// signChunk signs a chunk of attestations, returning the attestations for
+// which a signature was obtained.
+func (s *Service) signChunk(ctx context.Context,
+	duty *attester.Duty,
+	accounts []e2wtypes.Account,
+	committeeIndices []phase0.CommitteeIndex,
+	validatorCommitteeIndices []phase0.ValidatorIndex,
+	committeeSizes []uint64,
+	data *phase0.AttestationData,
+) (
+	[]*phase0.Attestation,
+	error,
+) {
+	sigs, err := s.beaconAttestationsSigner.SignBeaconAttestations(ctx,
+		accounts,
+		duty.Slot(),
+		committeeIndices,
+		data.BeaconBlockRoot,
+		data.Source.Epoch,
+		data.Source.Root,
+		data.Target.Epoch,
+		data.Target.Root,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the attestations.
+	zeroSig := phase0.BLSSignature{}
+	attestations := make([]*phase0.Attestation, 0, len(sigs))
+	for i := range sigs {
+		if bytes.Equal(sigs[i][:], zeroSig[:]) {
+			log.Warn().Msg("No signature for validator; not creating attestation")
+			continue
+		}
+		aggregationBits := bitfield.NewBitlist(committeeSizes[i])
+		aggregationBits.SetBitAt(uint64(validatorCommitteeIndices[i]), true)
+		attestation := &phase0.Attestation{
+			AggregationBits: aggregationBits,
+			Data: &phase0.AttestationData{
+				Slot:            duty.Slot(),
+				Index:           committeeIndices[i],
+				BeaconBlockRoot: data.BeaconBlockRoot,
+				Source: &phase0.Checkpoint{
+					Epoch: data.Source.Epoch,
+					Root:  data.Source.Root,
+				},
+				Target: &phase0.Checkpoint{
+					Epoch: data.Target.Epoch,
+					Root:  data.Target.Root,
+				},
+			},
+		}
+		copy(attestation.Signature[:], sigs[i][:])
+		attestations = append(attestations, attestation)
+	}
+
+	return attestations, nil
+}
+
+// submit submits a chunk of signed attestations.
+func (s *Service) submit(ctx context.Context,
+	attestations []*phase0.Attestation,
+	started time.Time,
+) error {
+	submissionStarted := time.Now()
+	submitCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSubmit)
+	defer cancel()
+	if err := s.attestationsSubmitter.SubmitAttestations(submitCtx, attestations); err != nil {
+		return err
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Dur("submission_elapsed", time.Since(submissionStarted)).Int("attestations", len(attestations)).Msg("Submitted attestations")
+
+	if s.attestationRebroadcaster != nil {
+		s.attestationRebroadcaster.AttestationsSubmitted(ctx, attestations)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// chunkSigner is a signer that records the size of each request, failing
+// requests of the given size and blocking requests of the given size until
+// their context is done.
+type chunkSigner struct {
+	mu        sync.Mutex
+	requests  []int
+	failSize  int
+	blockSize int
+}
+
+func (s *chunkSigner) SignBeaconAttestations(ctx context.Context,
+	accounts []e2wtypes.Account,
+	_ phase0.Slot,
+	_ []phase0.CommitteeIndex,
+	_ phase0.Root,
+	_ phase0.Epoch,
+	_ phase0.Root,
+	_ phase0.Epoch,
+	_ phase0.Root,
+) (
+	[]phase0.BLSSignature,
+	error,
+) {
+	s.mu.Lock()
+	s.requests = append(s.requests, len(accounts))
+	s.mu.Unlock()
+
+	if len(accounts) == s.failSize {
+		return nil, errors.New("signer failed")
+	}
+	if len(accounts) == s.blockSize {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	sigs := make([]phase0.BLSSignature, len(accounts))
+	for i := range sigs {
+		sigs[i][0] = 0x01
+	}
+
+	return sigs, nil
+}
+
+// recordingSubmitter is a submitter that records the size of each submission.
+type recordingSubmitter struct {
+	mu          sync.Mutex
+	submissions []int
+	err         error
+}
+
+func (s *recordingSubmitter) SubmitAttestations(_ context.Context, attestations []*phase0.Attestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.submissions = append(s.submissions, len(attestations))
+
+	return nil
+}
+
+func TestAttestChunks(t *testing.T) {
+	ctx := context.Background()
+
+	validators := 10
+	validatorIndices := make([]phase0.ValidatorIndex, validators)
+	committeeIndices := make([]phase0.CommitteeIndex, validators)
+	validatorCommitteeIndices := make([]uint64, validators)
+	accounts := make([]e2wtypes.Account, validators)
+	validatorCommitteeIndexArray := make([]phase0.ValidatorIndex, validators)
+	committeeSizes := make([]uint64, validators)
+	for i := 0; i < validators; i++ {
+		validatorIndices[i] = phase0.ValidatorIndex(i)
+		validatorCommitteeIndices[i] = uint64(i)
+		validatorCommitteeIndexArray[i] = phase0.ValidatorIndex(i)
+		committeeSizes[i] = 64
+	}
+	duty, err := attester.NewDuty(ctx, 1, 1, validatorIndices, committeeIndices, validatorCommitteeIndices, map[phase0.CommitteeIndex]uint64{0: 64})
+	require.NoError(t, err)
+	data := &phase0.AttestationData{
+		Slot:   1,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}
+
+	tests := []struct {
+		name         string
+		chunkSize    int
+		failSize     int
+		blockSize    int
+		timeout      time.Duration
+		submitErr    error
+		attestations int
+		signRequests int
+		submissions  []int
+		err          string
+	}{
+		{
+			name:         "SingleRequest",
+			attestations: 10,
+			signRequests: 1,
+			submissions:  []int{10},
+		},
+		{
+			name:         "ChunkLargerThanValidators",
+			chunkSize:    20,
+			attestations: 10,
+			signRequests: 1,
+			submissions:  []int{10},
+		},
+		{
+			name:         "Chunked",
+			chunkSize:    4,
+			attestations: 10,
+			signRequests: 3,
+			submissions:  []int{2, 4, 4},
+		},
+		{
+			name:         "ChunkSignFailed",
+			chunkSize:    4,
+			failSize:     4,
+			attestations: 2,
+			signRequests: 3,
+			submissions:  []int{2},
+		},
+		{
+			name:         "ChunkSignTimedOut",
+			chunkSize:    4,
+			blockSize:    2,
+			timeout:      50 * time.Millisecond,
+			attestations: 8,
+			signRequests: 3,
+			submissions:  []int{4, 4},
+		},
+		{
+			name:        "SignFailed",
+			failSize:    10,
+			err:         "failed to sign beacon attestations: signer failed",
+			submissions: []int{},
+		},
+		{
+			name:        "SubmitFailed",
+			submitErr:   errors.New("submitter failed"),
+			err:         "failed to submit attestations: submitter failed",
+			submissions: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer := &chunkSigner{failSize: test.failSize, blockSize: test.blockSize}
+			submitter := &recordingSubmitter{err: test.submitErr, submissions: make([]int, 0)}
+			s := &Service{
+				processConcurrency:       2,
+				signingChunkSize:         test.chunkSize,
+				signingTimeout:           test.timeout,
+				beaconAttestationsSigner: signer,
+				attestationsSubmitter:    submitter,
+			}
+
+			attestations, err := s.attest(ctx, duty, accounts, committeeIndices, validatorCommitteeIndexArray, committeeSizes, data, time.Now())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, attestations, test.attestations)
+				require.Len(t, signer.requests, test.signRequests)
+			}
+			require.ElementsMatch(t, test.submissions, submitter.submissions)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// attestationData returns attestation data for the given slot and committee
+// index.  Attestation data is the same for all committees in a slot other than
+// its index, so a single upstream request is made for each slot, with
+// concurrent requests for the same slot sharing the result.
+func (s *Service) attestationData(ctx context.Context,
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+) (
+	*phase0.AttestationData,
+	error,
+) {
+	s.attestationDataCacheMu.Lock()
+	data, exists := s.attestationDataCache[slot]
+	s.attestationDataCacheMu.Unlock()
+	if exists {
+		log.Trace().Uint64("slot", uint64(slot)).Msg("Using cached attestation data")
+
+		return attestationDataForCommittee(data, committeeIndex), nil
+	}
+
+	resCh := s.attestationDataRequests.DoChan(fmt.Sprintf("%d", slot), func() (any, error) {
+		// The request is shared by all callers, so it must not be cancelled
+		// with the context of the caller that happened to start it.  Instead
+		// it is bounded by the end of the slot, after which the data is of
+		// no use.
+		ctx, cancel := context.WithDeadline(context.Background(), s.chainTimeService.StartOfSlot(slot+1))
+		defer cancel()
+
+		response, err := s.attestationDataProvider.AttestationData(ctx, &api.AttestationDataOpts{
+			Slot:           slot,
+			CommitteeIndex: committeeIndex,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if response.Data == nil || response.Data.Source == nil || response.Data.Target == nil {
+			return nil, fmt.Errorf("attestation request for slot %d returned incomplete data", slot)
+		}
+
+		s.attestationDataCacheMu.Lock()
+		s.attestationDataCache[slot] = response.Data
+		// Housekeep the cache, which only needs to hold recent slots.
+		for cachedSlot := range s.attestationDataCache {
+			if cachedSlot+1 < slot {
+				delete(s.attestationDataCache, cachedSlot)
+			}
+		}
+		s.attestationDataCacheMu.Unlock()
+
+		return response.Data, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-resCh:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		if res.Shared {
+			log.Trace().Uint64("slot", uint64(slot)).Msg("Shared attestation data request")
+		}
+
+		return attestationDataForCommittee(res.Val.(*phase0.AttestationData), committeeIndex), nil
+	}
+}
+
+// attestationDataForCommittee returns a copy of the attestation data for the
+// given committee index.
+func attestationDataForCommittee(data *phase0.AttestationData,
+	committeeIndex phase0.CommitteeIndex,
+) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            data.Slot,
+		Index:           committeeIndex,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		Source: &phase0.Checkpoint{
+			Epoch: data.Source.Epoch,
+			Root:  data.Source.Root,
+		},
+		Target: &phase0.Checkpoint{
+			Epoch: data.Target.Epoch,
+			Root:  data.Target.Root,
+		},
+	}
+}
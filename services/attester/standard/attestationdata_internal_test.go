@@ -0,0 +1,176 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider is an attestation data provider that counts requests.
+type countingProvider struct {
+	requests atomic.Int32
+	delay    time.Duration
+}
+
+func (p *countingProvider) AttestationData(ctx context.Context,
+	opts *api.AttestationDataOpts,
+) (
+	*api.Response[*phase0.AttestationData],
+	error,
+) {
+	p.requests.Add(1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(p.delay):
+	}
+
+	return &api.Response[*phase0.AttestationData]{
+		Data: &phase0.AttestationData{
+			Slot:            opts.Slot,
+			Index:           opts.CommitteeIndex,
+			BeaconBlockRoot: phase0.Root{0x01},
+			Source:          &phase0.Checkpoint{Epoch: 1, Root: phase0.Root{0x02}},
+			Target:          &phase0.Checkpoint{Epoch: 2, Root: phase0.Root{0x03}},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func newTestChainTime(t *testing.T) *standardchaintime.Service {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		// Slot 0 has ended, slot 10 has not.
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-2*time.Minute))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	return chainTime
+}
+
+func TestAttestationDataCache(t *testing.T) {
+	ctx := context.Background()
+
+	provider := &countingProvider{delay: 50 * time.Millisecond}
+	s := &Service{
+		chainTimeService:        newTestChainTime(t),
+		attestationDataProvider: provider,
+		attestationDataCache:    make(map[phase0.Slot]*phase0.AttestationData),
+	}
+
+	// Concurrent requests for the same slot share a single upstream request.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(committeeIndex phase0.CommitteeIndex) {
+			defer wg.Done()
+			data, err := s.attestationData(ctx, 10, committeeIndex)
+			require.NoError(t, err)
+			require.Equal(t, committeeIndex, data.Index)
+			require.Equal(t, phase0.Slot(10), data.Slot)
+		}(phase0.CommitteeIndex(i))
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), provider.requests.Load())
+
+	// Later requests for the same slot use the cache.
+	data, err := s.attestationData(ctx, 10, 20)
+	require.NoError(t, err)
+	require.Equal(t, phase0.CommitteeIndex(20), data.Index)
+	require.Equal(t, phase0.Epoch(2), data.Target.Epoch)
+	require.Equal(t, int32(1), provider.requests.Load())
+
+	// Modifying returned data does not affect the cache.
+	data.Target.Epoch = 5
+	data, err = s.attestationData(ctx, 10, 20)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(2), data.Target.Epoch)
+
+	// A new slot makes a new request, and old slots are housekept.
+	_, err = s.attestationData(ctx, 11, 0)
+	require.NoError(t, err)
+	_, err = s.attestationData(ctx, 12, 0)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), provider.requests.Load())
+	require.Len(t, s.attestationDataCache, 2)
+}
+
+func TestAttestationDataCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := &Service{
+		chainTimeService:        newTestChainTime(t),
+		attestationDataProvider: &countingProvider{delay: 50 * time.Millisecond},
+		attestationDataCache:    make(map[phase0.Slot]*phase0.AttestationData),
+	}
+
+	_, err := s.attestationData(ctx, 10, 0)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAttestationDataFirstCallerCancelled(t *testing.T) {
+	provider := &countingProvider{delay: 100 * time.Millisecond}
+	s := &Service{
+		chainTimeService:        newTestChainTime(t),
+		attestationDataProvider: provider,
+		attestationDataCache:    make(map[phase0.Slot]*phase0.AttestationData),
+	}
+
+	// The first caller starts the shared request, then gives up on it.
+	firstCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	firstErrCh := make(chan error, 1)
+	go func() {
+		_, err := s.attestationData(firstCtx, 10, 0)
+		firstErrCh <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// The second caller shares the request, which completes regardless.
+	data, err := s.attestationData(context.Background(), 10, 1)
+	require.NoError(t, err)
+	require.Equal(t, phase0.CommitteeIndex(1), data.Index)
+	require.ErrorIs(t, <-firstErrCh, context.DeadlineExceeded)
+	require.Equal(t, int32(1), provider.requests.Load())
+}
+
+func TestAttestationDataSlotDeadline(t *testing.T) {
+	s := &Service{
+		chainTimeService:        newTestChainTime(t),
+		attestationDataProvider: &countingProvider{delay: time.Second},
+		attestationDataCache:    make(map[phase0.Slot]*phase0.AttestationData),
+	}
+
+	// The request for a slot that has already ended is abandoned, even though
+	// the caller would wait for it.
+	started := time.Now()
+	_, err := s.attestationData(context.Background(), 0, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(started), 500*time.Millisecond)
+}
@@ -1,4 +1,4 @@
-// Copyright © 2020 Attestant Limited.
+// Copyright © 2020, 2024 Attestant Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -14,11 +14,15 @@
 package standard
 
 import (
+	"time"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/attestationrebroadcaster"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/slashingprotection"
 	"github.com/attestantio/vouch/services/submitter"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -34,6 +38,11 @@ type parameters struct {
 	attestationsSubmitter      submitter.AttestationsSubmitter
 	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
 	beaconAttestationsSigner   signer.BeaconAttestationsSigner
+	attestationRebroadcaster   attestationrebroadcaster.Service
+	slashingProtection         slashingprotection.Service
+	signingChunkSize           int
+	signingTimeout             time.Duration
+	retryAttestationsSubmitter eth2client.AttestationsSubmitter
 }
 
 // Parameter is the interface for service parameters.
@@ -110,6 +119,49 @@ func WithBeaconAttestationsSigner(signer signer.BeaconAttestationsSigner) Parame
 	})
 }
 
+// WithAttestationRebroadcaster sets the attestation rebroadcaster.
+func WithAttestationRebroadcaster(rebroadcaster attestationrebroadcaster.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationRebroadcaster = rebroadcaster
+	})
+}
+
+// WithSlashingProtection sets the slashing protection service.
+func WithSlashingProtection(service slashingprotection.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slashingProtection = service
+	})
+}
+
+// WithSigningChunkSize sets the number of attestations signed in each request
+// to the signer.  Each chunk is submitted as soon as it has been signed, rather
+// than waiting for all attestations to be signed.  0 signs all attestations in
+// a single request.
+func WithSigningChunkSize(size int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signingChunkSize = size
+	})
+}
+
+// WithSigningTimeout sets the time allowed to sign each chunk of attestations.
+// A chunk that has not been signed in this time is abandoned without affecting
+// other chunks.  0 allows chunks until the end of the attestation duty.
+func WithSigningTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signingTimeout = timeout
+	})
+}
+
+// WithRetryAttestationsSubmitter sets the submitter used to resubmit
+// attestations that are rejected by the attestations submitter, for example
+// one that submits to different beacon nodes.  If not supplied, rejected
+// attestations are not retried.
+func WithRetryAttestationsSubmitter(submitter eth2client.AttestationsSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryAttestationsSubmitter = submitter
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -145,6 +197,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.beaconAttestationsSigner == nil {
 		return nil, errors.New("no beacon attestations signer specified")
 	}
+	if parameters.signingChunkSize < 0 {
+		return nil, errors.New("signing chunk size cannot be negative")
+	}
+	if parameters.signingTimeout < 0 {
+		return nil, errors.New("signing timeout cannot be negative")
+	}
 
 	return &parameters, nil
 }
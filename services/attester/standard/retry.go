@@ -0,0 +1,54 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// retryRejected resubmits chunks of attestations that were rejected by the
+// attestations submitter using the retry submitter.  The attestations are
+// resubmitted exactly as signed: signing different attestation data for the
+// same target epoch would be a slashable double vote, so rejected
+// attestations are never signed again.  It returns the attestations that were
+// accepted.
+func (s *Service) retryRejected(ctx context.Context,
+	rejected []*signedChunk,
+	started time.Time,
+) []*phase0.Attestation {
+	attestations := make([]*phase0.Attestation, 0)
+	for _, chunk := range rejected {
+		if ctx.Err() != nil {
+			log.Debug().Msg("Attestation deadline passed; not retrying rejected attestations")
+			break
+		}
+
+		submissionStarted := time.Now()
+		if err := s.retryAttestationsSubmitter.SubmitAttestations(ctx, chunk.attestations); err != nil {
+			log.Warn().Err(err).Int("attestations", len(chunk.attestations)).Msg("Retried attestations rejected")
+			continue
+		}
+		log.Info().Dur("elapsed", time.Since(started)).Dur("submission_elapsed", time.Since(submissionStarted)).Int("attestations", len(chunk.attestations)).Msg("Retried attestations accepted")
+
+		if s.attestationRebroadcaster != nil {
+			s.attestationRebroadcaster.AttestationsSubmitted(ctx, chunk.attestations)
+		}
+		attestations = append(attestations, chunk.attestations...)
+	}
+
+	return attestations
+}
@@ -0,0 +1,177 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// keyAccount is an account that only provides a public key.
+type keyAccount struct {
+	e2wtypes.Account
+	pubKey e2types.PublicKey
+}
+
+func (a *keyAccount) PublicKey() e2types.PublicKey {
+	return a.pubKey
+}
+
+// rejectingSubmitter is a submitter that rejects the given number of
+// submissions before accepting, recording the rejected and accepted
+// attestations.
+type rejectingSubmitter struct {
+	mu         sync.Mutex
+	rejections int
+	rejected   []*phase0.Attestation
+	accepted   []*phase0.Attestation
+}
+
+func (s *rejectingSubmitter) SubmitAttestations(_ context.Context, attestations []*phase0.Attestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejections > 0 {
+		s.rejections--
+		s.rejected = append(s.rejected, attestations...)
+		return errors.New("unknown block root")
+	}
+	s.accepted = append(s.accepted, attestations...)
+
+	return nil
+}
+
+func TestRetryRejected(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	validators := 4
+	validatorIndices := make([]phase0.ValidatorIndex, validators)
+	committeeIndices := make([]phase0.CommitteeIndex, validators)
+	validatorCommitteeIndices := make([]uint64, validators)
+	validatorCommitteeIndexArray := make([]phase0.ValidatorIndex, validators)
+	committeeSizes := make([]uint64, validators)
+	accounts := make([]e2wtypes.Account, validators)
+	for i := 0; i < validators; i++ {
+		validatorIndices[i] = phase0.ValidatorIndex(i)
+		validatorCommitteeIndices[i] = uint64(i)
+		validatorCommitteeIndexArray[i] = phase0.ValidatorIndex(i)
+		committeeSizes[i] = 64
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		accounts[i] = &keyAccount{pubKey: key.PublicKey()}
+	}
+	duty, err := attester.NewDuty(ctx, 1, 1, validatorIndices, committeeIndices, validatorCommitteeIndices, map[phase0.CommitteeIndex]uint64{0: 64})
+	require.NoError(t, err)
+
+	data := &phase0.AttestationData{
+		Slot:            1,
+		BeaconBlockRoot: phase0.Root{0x01},
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{},
+	}
+
+	tests := []struct {
+		name            string
+		chunkSize       int
+		rejections      int
+		retry           bool
+		retryRejections int
+		attestations    int
+		signRequests    int
+		err             string
+	}{
+		{
+			name:         "NoRetrySubmitter",
+			rejections:   1,
+			signRequests: 1,
+			err:          "failed to submit attestations: unknown block root",
+		},
+		{
+			name:         "Retried",
+			rejections:   1,
+			retry:        true,
+			attestations: 4,
+			signRequests: 1,
+		},
+		{
+			name:         "RetriedChunked",
+			chunkSize:    2,
+			rejections:   2,
+			retry:        true,
+			attestations: 4,
+			signRequests: 2,
+		},
+		{
+			name:         "RetriedPartial",
+			chunkSize:    2,
+			rejections:   1,
+			retry:        true,
+			attestations: 4,
+			signRequests: 2,
+		},
+		{
+			name:            "RetryRejected",
+			rejections:      1,
+			retry:           true,
+			retryRejections: 1,
+			signRequests:    1,
+			err:             "failed to submit attestations: unknown block root",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer := &chunkSigner{}
+			submitter := &rejectingSubmitter{rejections: test.rejections}
+			retrySubmitter := &rejectingSubmitter{rejections: test.retryRejections}
+			s := &Service{
+				processConcurrency:       2,
+				signingChunkSize:         test.chunkSize,
+				beaconAttestationsSigner: signer,
+				attestationsSubmitter:    submitter,
+			}
+			if test.retry {
+				s.retryAttestationsSubmitter = retrySubmitter
+			}
+
+			attestations, err := s.attest(ctx, duty, accounts, committeeIndices, validatorCommitteeIndexArray, committeeSizes, data, time.Now())
+
+			// Rejected attestations must never be signed again.
+			require.Len(t, signer.requests, test.signRequests)
+
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, attestations, test.attestations)
+			require.Len(t, append(submitter.accepted, retrySubmitter.accepted...), test.attestations)
+
+			// The retried attestations must be the originally signed attestations.
+			require.Equal(t, submitter.rejected, retrySubmitter.accepted)
+			for _, attestation := range retrySubmitter.accepted {
+				require.Equal(t, data, attestation.Data)
+			}
+		})
+	}
+}
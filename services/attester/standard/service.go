@@ -1,4 +1,4 @@
-// Copyright © 2020 - 2023 Attestant Limited.
+// Copyright © 2020 - 2024 Attestant Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -14,7 +14,6 @@
 package standard
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"sync"
@@ -24,18 +23,21 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/attestationrebroadcaster"
 	"github.com/attestantio/vouch/services/attester"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/slashingprotection"
 	"github.com/attestantio/vouch/services/submitter"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/singleflight"
 )
 
 // Service is a beacon block attester.
@@ -48,8 +50,17 @@ type Service struct {
 	attestationDataProvider    eth2client.AttestationDataProvider
 	attestationsSubmitter      submitter.AttestationsSubmitter
 	beaconAttestationsSigner   signer.BeaconAttestationsSigner
+	attestationRebroadcaster   attestationrebroadcaster.Service
+	slashingProtection         slashingprotection.Service
+	signingChunkSize           int
+	signingTimeout             time.Duration
+	retryAttestationsSubmitter eth2client.AttestationsSubmitter
 	attested                   map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}
 	attestedMu                 sync.Mutex
+
+	attestationDataCache    map[phase0.Slot]*phase0.AttestationData
+	attestationDataCacheMu  sync.Mutex
+	attestationDataRequests singleflight.Group
 }
 
 // module-wide log.
@@ -92,7 +103,13 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		attestationDataProvider:    parameters.attestationDataProvider,
 		attestationsSubmitter:      parameters.attestationsSubmitter,
 		beaconAttestationsSigner:   parameters.beaconAttestationsSigner,
+		attestationRebroadcaster:   parameters.attestationRebroadcaster,
+		slashingProtection:         parameters.slashingProtection,
+		signingChunkSize:           parameters.signingChunkSize,
+		signingTimeout:             parameters.signingTimeout,
+		retryAttestationsSubmitter: parameters.retryAttestationsSubmitter,
 		attested:                   make(map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}),
+		attestationDataCache:       make(map[phase0.Slot]*phase0.AttestationData),
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -109,7 +126,9 @@ func (s *Service) Attest(ctx context.Context, data interface{}) ([]*phase0.Attes
 	duty, ok := data.(*attester.Duty)
 	if !ok {
 		s.monitor.AttestationsCompleted(started, 0, len(duty.ValidatorIndices()), "failed")
-		return nil, errors.New("passed invalid data structure")
+		err := errors.New("passed invalid data structure")
+		util.MonitorError(s.monitor, "attester", err)
+		return nil, err
 	}
 	span.SetAttributes(attribute.Int64("slot", int64(duty.Slot())))
 
@@ -138,35 +157,43 @@ func (s *Service) Attest(ctx context.Context, data interface{}) ([]*phase0.Attes
 	log := log.With().Uint64("slot", uint64(duty.Slot())).Uints64("validator_indices", uints).Logger()
 
 	// Fetch the attestation data.
-	attestationDataResponse, err := s.attestationDataProvider.AttestationData(ctx, &api.AttestationDataOpts{
-		Slot:           duty.Slot(),
-		CommitteeIndex: duty.CommitteeIndices()[0],
-	})
+	fetchCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseFetch)
+	attestationData, err := s.attestationData(fetchCtx, duty.Slot(), duty.CommitteeIndices()[0])
+	cancel()
 	if err != nil {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
-		return nil, errors.Wrap(err, "failed to obtain attestation data")
+		err = util.ProviderError(errors.Wrap(err, "failed to obtain attestation data"))
+		util.MonitorError(s.monitor, "attester", err)
+		return nil, err
 	}
-	attestationData := attestationDataResponse.Data
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained attestation data")
 
 	if attestationData.Slot != duty.Slot() {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
-		return nil, fmt.Errorf("attestation request for slot %d returned data for slot %d", duty.Slot(), attestationData.Slot)
+		err := util.CategoriseError(util.ErrorCategoryProviderInvalidData, fmt.Errorf("attestation request for slot %d returned data for slot %d", duty.Slot(), attestationData.Slot))
+		util.MonitorError(s.monitor, "attester", err)
+		return nil, err
 	}
 	if attestationData.Source.Epoch > attestationData.Target.Epoch {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
-		return nil, fmt.Errorf("attestation request for slot %d returned source epoch %d greater than target epoch %d", duty.Slot(), attestationData.Source.Epoch, attestationData.Target.Epoch)
+		err := util.CategoriseError(util.ErrorCategoryProviderInvalidData, fmt.Errorf("attestation request for slot %d returned source epoch %d greater than target epoch %d", duty.Slot(), attestationData.Source.Epoch, attestationData.Target.Epoch))
+		util.MonitorError(s.monitor, "attester", err)
+		return nil, err
 	}
 	if attestationData.Target.Epoch > phase0.Epoch(uint64(duty.Slot())/s.slotsPerEpoch) {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
-		return nil, fmt.Errorf("attestation request for slot %d returned target epoch %d greater than current epoch %d", duty.Slot(), attestationData.Target.Epoch, phase0.Epoch(uint64(duty.Slot())/s.slotsPerEpoch))
+		err := util.CategoriseError(util.ErrorCategoryProviderInvalidData, fmt.Errorf("attestation request for slot %d returned target epoch %d greater than current epoch %d", duty.Slot(), attestationData.Target.Epoch, phase0.Epoch(uint64(duty.Slot())/s.slotsPerEpoch)))
+		util.MonitorError(s.monitor, "attester", err)
+		return nil, err
 	}
 
 	// Fetch the validating accounts.
 	validatingAccounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx, phase0.Epoch(uint64(duty.Slot())/s.slotsPerEpoch), validatorIndices)
 	if err != nil {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
-		return nil, errors.Wrap(err, "failed to obtain attesting validator accounts")
+		err = errors.Wrap(err, "failed to obtain attesting validator accounts")
+		util.MonitorError(s.monitor, "attester", err)
+		return nil, err
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Int("validating_accounts", len(validatingAccounts)).Msg("Obtained validating accounts")
 
@@ -203,6 +230,7 @@ func (s *Service) Attest(ctx context.Context, data interface{}) ([]*phase0.Attes
 	)
 	if err != nil {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
+		util.MonitorError(s.monitor, "attester", err)
 		return nil, err
 	}
 
@@ -222,82 +250,48 @@ func (s *Service) Attest(ctx context.Context, data interface{}) ([]*phase0.Attes
 	return attestations, nil
 }
 
-// attest carries out the internal work of attesting.
-// skipcq: RVV-B0001
-func (s *Service) attest(
-	ctx context.Context,
-	duty *attester.Duty,
+// protect removes the accounts for which signing the attestation could result in
+// the validator being slashed.
+func (s *Service) protect(ctx context.Context,
 	accounts []e2wtypes.Account,
 	committeeIndices []phase0.CommitteeIndex,
 	validatorCommitteeIndices []phase0.ValidatorIndex,
 	committeeSizes []uint64,
 	data *phase0.AttestationData,
-	started time.Time,
-) ([]*phase0.Attestation, error) {
-	// Sign the attestation for all validating accounts.
-	uintCommitteeIndices := make([]uint64, len(committeeIndices))
-	for i := range committeeIndices {
-		uintCommitteeIndices[i] = uint64(committeeIndices[i])
+) (
+	[]e2wtypes.Account,
+	[]phase0.CommitteeIndex,
+	[]phase0.ValidatorIndex,
+	[]uint64,
+	error,
+) {
+	pubKeys := make([]phase0.BLSPubKey, len(accounts))
+	for i, account := range accounts {
+		if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+			copy(pubKeys[i][:], provider.CompositePublicKey().Marshal())
+		} else {
+			copy(pubKeys[i][:], account.PublicKey().Marshal())
+		}
 	}
-	accountsArray := make([]e2wtypes.Account, 0, len(accounts))
-	accountsArray = append(accountsArray, accounts...)
 
-	sigs, err := s.beaconAttestationsSigner.SignBeaconAttestations(ctx,
-		accountsArray,
-		duty.Slot(),
-		committeeIndices,
-		data.BeaconBlockRoot,
-		data.Source.Epoch,
-		data.Source.Root,
-		data.Target.Epoch,
-		data.Target.Root,
-	)
+	safe, err := s.slashingProtection.ProtectAttestations(ctx, pubKeys, data.Source.Epoch, data.Target.Epoch)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to sign beacon attestations")
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to check slashing protection")
 	}
-	log.Trace().Dur("elapsed", time.Since(started)).Msg("Signed")
 
-	// Create the attestations.
-	zeroSig := phase0.BLSSignature{}
-	attestations := make([]*phase0.Attestation, 0, len(sigs))
-	for i := range sigs {
-		if bytes.Equal(sigs[i][:], zeroSig[:]) {
-			log.Warn().Msg("No signature for validator; not creating attestation")
+	safeAccounts := make([]e2wtypes.Account, 0, len(accounts))
+	safeCommitteeIndices := make([]phase0.CommitteeIndex, 0, len(accounts))
+	safeValidatorCommitteeIndices := make([]phase0.ValidatorIndex, 0, len(accounts))
+	safeCommitteeSizes := make([]uint64, 0, len(accounts))
+	for i := range accounts {
+		if !safe[i] {
 			continue
 		}
-		aggregationBits := bitfield.NewBitlist(committeeSizes[i])
-		aggregationBits.SetBitAt(uint64(validatorCommitteeIndices[i]), true)
-		attestation := &phase0.Attestation{
-			AggregationBits: aggregationBits,
-			Data: &phase0.AttestationData{
-				Slot:            duty.Slot(),
-				Index:           committeeIndices[i],
-				BeaconBlockRoot: data.BeaconBlockRoot,
-				Source: &phase0.Checkpoint{
-					Epoch: data.Source.Epoch,
-					Root:  data.Source.Root,
-				},
-				Target: &phase0.Checkpoint{
-					Epoch: data.Target.Epoch,
-					Root:  data.Target.Root,
-				},
-			},
-		}
-		copy(attestation.Signature[:], sigs[i][:])
-		attestations = append(attestations, attestation)
+		safeAccounts = append(safeAccounts, accounts[i])
+		safeCommitteeIndices = append(safeCommitteeIndices, committeeIndices[i])
+		safeValidatorCommitteeIndices = append(safeValidatorCommitteeIndices, validatorCommitteeIndices[i])
+		safeCommitteeSizes = append(safeCommitteeSizes, committeeSizes[i])
 	}
 
-	if len(attestations) == 0 {
-		log.Info().Msg("No signed attestations; not submitting")
-		return attestations, nil
-	}
-
-	// Submit the attestations.
-	submissionStarted := time.Now()
-	if err := s.attestationsSubmitter.SubmitAttestations(ctx, attestations); err != nil {
-		return nil, errors.Wrap(err, "failed to submit attestations")
-	}
-	log.Trace().Dur("elapsed", time.Since(started)).Dur("submission_elapsed", time.Since(submissionStarted)).Msg("Submitted attestations")
-
-	return attestations, nil
+	return safeAccounts, safeCommitteeIndices, safeValidatorCommitteeIndices, safeCommitteeSizes, nil
 }
@@ -33,6 +33,9 @@ type RelayConfig struct {
 	GasLimit     uint64
 	Grace        time.Duration
 	MinValue     decimal.Decimal
+	Location     string
+	// Censoring is true if the relay is labelled as censoring transactions.
+	Censoring bool
 }
 
 type relayConfigJSON struct {
@@ -42,6 +45,8 @@ type relayConfigJSON struct {
 	GasLimit     string `json:"gas_limit"`
 	Grace        string `json:"grace,omitempty"`
 	MinValue     string `json:"min_value,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Censoring    bool   `json:"censoring,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -65,6 +70,8 @@ func (r *RelayConfig) MarshalJSON() ([]byte, error) {
 		GasLimit:     fmt.Sprintf("%d", r.GasLimit),
 		Grace:        grace,
 		MinValue:     minValue,
+		Location:     r.Location,
+		Censoring:    r.Censoring,
 	})
 }
 
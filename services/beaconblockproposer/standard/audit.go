@@ -0,0 +1,80 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	"github.com/attestantio/vouch/services/proposalaudit"
+)
+
+const (
+	// executionPayloadValueHeader is the header in which the beacon node supplies the execution payload value of a proposal.
+	executionPayloadValueHeader = "Eth-Execution-Payload-Value"
+	// consensusBlockValueHeader is the header in which the beacon node supplies the consensus value of a proposal.
+	consensusBlockValueHeader = "Eth-Consensus-Block-Value"
+)
+
+// auditAuctionResults adds the results of an auction to an audit entry.
+func auditAuctionResults(audit *proposalaudit.Proposal,
+	auctionResults *blockauctioneer.Results,
+) {
+	if auctionResults == nil {
+		return
+	}
+
+	audit.Bids = make(map[string]*big.Int, len(auctionResults.Values))
+	for relay, value := range auctionResults.Values {
+		audit.Bids[relay] = value
+	}
+	for _, provider := range auctionResults.Providers {
+		audit.WinningRelays = append(audit.WinningRelays, provider.Address())
+	}
+	if auctionResults.Bid != nil {
+		value, err := auctionResults.Bid.Value()
+		if err == nil {
+			audit.WinningValue = value.ToBig()
+		}
+	}
+}
+
+// auditProposal completes the audit entry for a proposal and passes it to the audit service.
+func (s *Service) auditProposal(ctx context.Context,
+	audit *proposalaudit.Proposal,
+	proposalMetadata map[string]any,
+) {
+	audit.LocalExecutionValue = metadataValue(proposalMetadata, executionPayloadValueHeader)
+	audit.LocalConsensusValue = metadataValue(proposalMetadata, consensusBlockValueHeader)
+
+	s.proposalAudit.AuditProposal(ctx, audit)
+}
+
+// metadataValue obtains a decimal value from the metadata of a proposal response,
+// returning nil if it is not present or invalid.
+func metadataValue(metadata map[string]any, key string) *big.Int {
+	raw, exists := metadata[key]
+	if !exists {
+		return nil
+	}
+	value, success := new(big.Int).SetString(fmt.Sprintf("%v", raw), 10)
+	if !success {
+		log.Debug().Str("key", key).Interface("value", raw).Msg("Invalid value in proposal metadata")
+		return nil
+	}
+
+	return value
+}
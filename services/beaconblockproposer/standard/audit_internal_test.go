@@ -0,0 +1,69 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		key      string
+		expected string
+	}{
+		{
+			name: "MetadataNil",
+			key:  executionPayloadValueHeader,
+		},
+		{
+			name: "KeyMissing",
+			metadata: map[string]any{
+				consensusBlockValueHeader: "12345",
+			},
+			key: executionPayloadValueHeader,
+		},
+		{
+			name: "ValueInvalid",
+			metadata: map[string]any{
+				executionPayloadValueHeader: "0x1234",
+			},
+			key: executionPayloadValueHeader,
+		},
+		{
+			name: "Good",
+			metadata: map[string]any{
+				executionPayloadValueHeader: "123456789012345678901",
+				consensusBlockValueHeader:   "12345",
+			},
+			key:      executionPayloadValueHeader,
+			expected: "123456789012345678901",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value := metadataValue(test.metadata, test.key)
+			if test.expected == "" {
+				require.Nil(t, value)
+			} else {
+				require.NotNil(t, value)
+				require.Equal(t, test.expected, value.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/util"
+)
+
+// prefetchedProposal is a proposal built by the beacon node, obtained in parallel with the auction.
+type prefetchedProposal struct {
+	done     chan struct{}
+	proposal *api.VersionedProposal
+	metadata map[string]any
+}
+
+// prefetchProposal starts to obtain a proposal built by the beacon node.
+func (s *Service) prefetchProposal(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	graffiti [32]byte,
+) *prefetchedProposal {
+	prefetched := &prefetchedProposal{
+		done: make(chan struct{}),
+	}
+	go func(ctx context.Context, duty *beaconblockproposer.Duty, graffiti [32]byte) {
+		defer close(prefetched.done)
+		fetchCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseFetch)
+		defer cancel()
+		proposalResponse, err := s.proposalProvider.Proposal(fetchCtx, &api.ProposalOpts{
+			Slot:         duty.Slot(),
+			RandaoReveal: duty.RANDAOReveal(),
+			Graffiti:     graffiti,
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to pre-obtain proposal data")
+			return
+		}
+		prefetched.proposal = proposalResponse.Data
+		prefetched.metadata = proposalResponse.Metadata
+		log.Trace().Msg("Pre-obtained proposal")
+	}(ctx, duty, graffiti)
+
+	return prefetched
+}
+
+// wait waits for the proposal to be obtained, returning false if the context is done first.
+func (p *prefetchedProposal) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-p.done:
+		return true
+	}
+}
+
+// prefetchedBlindedProposal is a blinded proposal for the best bid, obtained in parallel with the local proposal.
+type prefetchedBlindedProposal struct {
+	done     chan struct{}
+	proposal *api.VersionedBlindedProposal
+	err      error
+}
+
+// prefetchBlindedProposal starts to obtain a blinded proposal for the best bid.
+func (s *Service) prefetchBlindedProposal(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	graffiti [32]byte,
+	auctionResults *blockauctioneer.Results,
+) *prefetchedBlindedProposal {
+	prefetched := &prefetchedBlindedProposal{
+		done: make(chan struct{}),
+	}
+	go func(ctx context.Context, duty *beaconblockproposer.Duty, graffiti [32]byte, auctionResults *blockauctioneer.Results) {
+		defer close(prefetched.done)
+		prefetched.proposal, prefetched.err = s.obtainBlindedProposal(ctx, duty, graffiti, auctionResults)
+	}(ctx, duty, graffiti, auctionResults)
+
+	return prefetched
+}
+
+// wait waits for the blinded proposal to be obtained, returning false if the context is done first.
+func (p *prefetchedBlindedProposal) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-p.done:
+		return true
+	}
+}
+
+// localBlockMoreValuable returns true if the block built by the beacon node is worth more than the block of the
+// best bid, once the builder boost factor has been applied to the value of the bid.
+func (s *Service) localBlockMoreValuable(ctx context.Context,
+	auctionResults *blockauctioneer.Results,
+	prefetched *prefetchedProposal,
+) bool {
+	bidValue, err := auctionResults.Bid.Value()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain value of bid; not comparing with local block")
+		return false
+	}
+
+	if !prefetched.wait(ctx) || prefetched.proposal == nil {
+		log.Debug().Msg("No local block; not comparing with bid")
+		return false
+	}
+	if s.builderBoostFactor == 0 {
+		// A boost factor of 0 means that the local block is always preferred.
+		return true
+	}
+
+	localExecutionValue := metadataValue(prefetched.metadata, executionPayloadValueHeader)
+	if localExecutionValue == nil {
+		log.Debug().Msg("Beacon node did not supply value of local block; not comparing with bid")
+		return false
+	}
+	// The consensus reward does not depend on the source of the execution payload, so applies to both blocks.
+	consensusValue := metadataValue(prefetched.metadata, consensusBlockValueHeader)
+	if consensusValue == nil {
+		consensusValue = big.NewInt(0)
+	}
+
+	builderValue := new(big.Int).Mul(bidValue.ToBig(), new(big.Int).SetUint64(s.builderBoostFactor))
+	builderValue = builderValue.Div(builderValue, big.NewInt(100))
+	builderValue = builderValue.Add(builderValue, consensusValue)
+	localValue := new(big.Int).Add(localExecutionValue, consensusValue)
+
+	log.Debug().
+		Stringer("bid_value", bidValue.ToBig()).
+		Uint64("builder_boost_factor", s.builderBoostFactor).
+		Stringer("builder_value", builderValue).
+		Stringer("local_value", localValue).
+		Msg("Compared value of local block with bid")
+
+	return localValue.Cmp(builderValue) > 0
+}
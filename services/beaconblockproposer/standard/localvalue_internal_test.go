@@ -0,0 +1,151 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	buildercapella "github.com/attestantio/go-builder-client/api/capella"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/go-eth2-client/api"
+	consensusspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlockMoreValuable(t *testing.T) {
+	ctx := context.Background()
+
+	auctionResults := &blockauctioneer.Results{
+		Bid: &builderspec.VersionedSignedBuilderBid{
+			Version: consensusspec.DataVersionCapella,
+			Capella: &buildercapella.SignedBuilderBid{
+				Message: &buildercapella.BuilderBid{
+					Value: uint256.NewInt(1000),
+				},
+			},
+		},
+	}
+
+	prefetched := func(proposal *api.VersionedProposal, metadata map[string]any) *prefetchedProposal {
+		p := &prefetchedProposal{
+			done:     make(chan struct{}),
+			proposal: proposal,
+			metadata: metadata,
+		}
+		close(p.done)
+		return p
+	}
+	proposal := &api.VersionedProposal{}
+
+	tests := []struct {
+		name               string
+		builderBoostFactor uint64
+		prefetched         *prefetchedProposal
+		expected           bool
+	}{
+		{
+			name:               "NoLocalProposal",
+			builderBoostFactor: 100,
+			prefetched:         prefetched(nil, nil),
+		},
+		{
+			name:               "NoLocalValue",
+			builderBoostFactor: 100,
+			prefetched:         prefetched(proposal, map[string]any{}),
+		},
+		{
+			name:               "BoostFactorZero",
+			builderBoostFactor: 0,
+			prefetched:         prefetched(proposal, map[string]any{}),
+			expected:           true,
+		},
+		{
+			name:               "LocalLower",
+			builderBoostFactor: 100,
+			prefetched: prefetched(proposal, map[string]any{
+				executionPayloadValueHeader: "999",
+				consensusBlockValueHeader:   "5000",
+			}),
+		},
+		{
+			name:               "LocalEqual",
+			builderBoostFactor: 100,
+			prefetched: prefetched(proposal, map[string]any{
+				executionPayloadValueHeader: "1000",
+			}),
+		},
+		{
+			name:               "LocalHigher",
+			builderBoostFactor: 100,
+			prefetched: prefetched(proposal, map[string]any{
+				executionPayloadValueHeader: "1001",
+				consensusBlockValueHeader:   "5000",
+			}),
+			expected: true,
+		},
+		{
+			name:               "LocalHigherAfterBoostFactor",
+			builderBoostFactor: 90,
+			prefetched: prefetched(proposal, map[string]any{
+				executionPayloadValueHeader: "901",
+			}),
+			expected: true,
+		},
+		{
+			name:               "LocalLowerAfterBoostFactor",
+			builderBoostFactor: 150,
+			prefetched: prefetched(proposal, map[string]any{
+				executionPayloadValueHeader: "1200",
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				builderBoostFactor: test.builderBoostFactor,
+			}
+			require.Equal(t, test.expected, s.localBlockMoreValuable(ctx, auctionResults, test.prefetched))
+		})
+	}
+}
+
+func TestPrefetchedProposalWait(t *testing.T) {
+	p := &prefetchedProposal{
+		done: make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.False(t, p.wait(ctx))
+
+	close(p.done)
+	require.True(t, p.wait(context.Background()))
+}
+
+func TestPrefetchedBlindedProposalWait(t *testing.T) {
+	p := &prefetchedBlindedProposal{
+		done: make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.False(t, p.wait(ctx))
+
+	close(p.done)
+	require.True(t, p.wait(context.Background()))
+}
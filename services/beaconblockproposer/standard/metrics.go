@@ -29,6 +29,7 @@ var (
 	beaconBlockProposalMarkTimer         prometheus.Histogram
 	beaconBlockProposalProcessLatestSlot prometheus.Gauge
 	beaconBlockProposalSource            *prometheus.CounterVec
+	relayUnblinds                        *prometheus.CounterVec
 )
 
 func registerMetrics(ctx context.Context, monitor metrics.Service) error {
@@ -115,6 +116,16 @@ func registerPrometheusMetrics(_ context.Context) error {
 		return err
 	}
 
+	relayUnblinds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_unblind",
+		Name:      "requests_total",
+		Help:      "The number of requests to relays to reveal an unblinded block.",
+	}, []string{"provider", "result"})
+	if err := prometheus.Register(relayUnblinds); err != nil {
+		return err
+	}
+
 	bestBidRelayCount = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: "vouch",
 		Subsystem: "beaconblockproposer",
@@ -163,3 +174,12 @@ func monitorBeaconBlockProposalSource(source string) {
 
 	beaconBlockProposalSource.WithLabelValues(source).Inc()
 }
+
+// monitorRelayUnblind is called when a relay has been asked to reveal an unblinded block.
+func monitorRelayUnblind(provider string, result string) {
+	if relayUnblinds == nil {
+		return
+	}
+
+	relayUnblinds.WithLabelValues(provider, result).Inc()
+}
@@ -0,0 +1,117 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	builderclient "github.com/attestantio/go-builder-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// unblindingRelay is a relay that returns a fixed result when asked to unblind a proposal.
+type unblindingRelay struct {
+	address  string
+	proposal *api.VersionedSignedProposal
+	err      error
+}
+
+func (*unblindingRelay) Name() string {
+	return "unblinding relay"
+}
+
+func (r *unblindingRelay) Address() string {
+	return r.address
+}
+
+func (*unblindingRelay) Pubkey() *phase0.BLSPubKey {
+	return nil
+}
+
+func (r *unblindingRelay) UnblindProposal(_ context.Context,
+	_ *api.VersionedSignedBlindedProposal,
+) (
+	*api.VersionedSignedProposal,
+	error,
+) {
+	return r.proposal, r.err
+}
+
+func TestMonitorRelayUnblind(t *testing.T) {
+	defer func(unblinds *prometheus.CounterVec) { relayUnblinds = unblinds }(relayUnblinds)
+
+	tests := []struct {
+		name   string
+		relay  *unblindingRelay
+		err    bool
+		result string
+	}{
+		{
+			name: "Succeeded",
+			relay: &unblindingRelay{
+				address:  "relay1",
+				proposal: &api.VersionedSignedProposal{},
+			},
+			result: "succeeded",
+		},
+		{
+			name: "UnknownPayload",
+			relay: &unblindingRelay{
+				address: "relay2",
+				err:     errors.New("POST failed with status 400: unknown payload"),
+			},
+			err:    true,
+			result: "unknown_payload",
+		},
+		{
+			name: "Failed",
+			relay: &unblindingRelay{
+				address: "relay3",
+				err:     errors.New("POST failed with status 500"),
+			},
+			err:    true,
+			result: "failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			relayUnblinds = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "relay_unblind_requests_total",
+			}, []string{"provider", "result"})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			s := &Service{}
+			_, err := s.unblindBlock(ctx, &api.VersionedSignedBlindedProposal{}, []builderclient.UnblindedProposalProvider{test.relay})
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Eventually(t, func() bool {
+				return testutil.ToFloat64(relayUnblinds.WithLabelValues(test.relay.address, test.result)) == 1
+			}, 2*time.Second, 10*time.Millisecond)
+			require.Equal(t, 1, testutil.CollectAndCount(relayUnblinds))
+		})
+	}
+}
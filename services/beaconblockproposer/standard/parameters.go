@@ -21,9 +21,13 @@ import (
 	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/circuitbreaker"
 	"github.com/attestantio/vouch/services/graffitiprovider"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/proposalaudit"
+	"github.com/attestantio/vouch/services/proposalrevenue"
 	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/slashingprotection"
 	"github.com/attestantio/vouch/services/submitter"
 	"github.com/rs/zerolog"
 )
@@ -33,6 +37,9 @@ type parameters struct {
 	monitor                    metrics.Service
 	chainTime                  chaintime.Service
 	blockAuctioneer            blockauctioneer.BlockAuctioneer
+	circuitBreaker             circuitbreaker.Service
+	proposalRevenue            proposalrevenue.Service
+	proposalAudit              proposalaudit.Service
 	proposalProvider           eth2client.ProposalProvider
 	blindedProposalProvider    eth2client.BlindedProposalProvider
 	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
@@ -43,6 +50,9 @@ type parameters struct {
 	beaconBlockSigner          signer.BeaconBlockSigner
 	blobSidecarSigner          signer.BlobSidecarSigner
 	unblindFromAllRelays       bool
+	builderBoostFactor         uint64
+	localGraffiti              string
+	slashingProtection         slashingprotection.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -77,6 +87,30 @@ func WithBlockAuctioneer(auctioneer blockauctioneer.BlockAuctioneer) Parameter {
 	})
 }
 
+// WithCircuitBreaker sets the circuit breaker, which stops the use of the block
+// auctioneer when the chain is unstable.
+func WithCircuitBreaker(circuitBreaker circuitbreaker.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.circuitBreaker = circuitBreaker
+	})
+}
+
+// WithProposalRevenue sets the proposal revenue service, which tracks the
+// revenue realised by proposals.
+func WithProposalRevenue(service proposalrevenue.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalRevenue = service
+	})
+}
+
+// WithProposalAudit sets the proposal audit service, which records the
+// bids and decision for each proposal.
+func WithProposalAudit(service proposalaudit.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalAudit = service
+	})
+}
+
 // WithProposalDataProvider sets the proposal data provider.
 func WithProposalDataProvider(provider eth2client.ProposalProvider) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -154,10 +188,33 @@ func WithUnblindFromAllRelays(unblindFromAll bool) Parameter {
 	})
 }
 
+// WithBuilderBoostFactor sets the percentage applied to the value of the best bid when
+// comparing it with the value of the block built by the beacon node.
+func WithBuilderBoostFactor(factor uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.builderBoostFactor = factor
+	})
+}
+
+// WithLocalGraffiti sets the graffiti used for blocks built by the beacon node rather than obtained through auction.
+func WithLocalGraffiti(graffiti string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.localGraffiti = graffiti
+	})
+}
+
+// WithSlashingProtection sets the slashing protection service.
+func WithSlashingProtection(service slashingprotection.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slashingProtection = service
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:           zerolog.GlobalLevel(),
+		builderBoostFactor: 100,
 	}
 	for _, p := range params {
 		if params != nil {
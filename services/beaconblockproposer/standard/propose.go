@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/attestantio/go-block-relay/services/blockauctioneer"
@@ -37,6 +36,9 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/proposalaudit"
+	"github.com/attestantio/vouch/services/proposalrevenue"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	"go.opentelemetry.io/otel"
@@ -65,6 +67,7 @@ const (
 	auctionResultFailed
 	auctionResultFailedCanTryWithout
 	auctionResultNoBids
+	auctionResultLocalMoreValuable
 )
 
 // Propose proposes a block.
@@ -100,6 +103,7 @@ func (s *Service) Propose(ctx context.Context, data interface{}) {
 
 	if err := s.proposeBlock(ctx, duty, graffiti); err != nil {
 		log.Error().Err(err).Msg("Failed to propose block")
+		util.MonitorError(s.monitor, "beaconblockproposer", err)
 		monitorBeaconBlockProposalCompleted(started, slot, s.chainTime.StartOfSlot(slot), "failed")
 		return
 	}
@@ -146,6 +150,39 @@ func (s *Service) obtainGraffiti(ctx context.Context,
 		return res, errors.Wrap(err, "graffiti provider failed")
 	}
 
+	copy(res[:], s.expandGraffiti(ctx, graffiti, slot, validatorIndex))
+
+	return res, nil
+}
+
+// obtainLocalGraffiti obtains the graffiti for a proposal built by the beacon node.
+// If no local graffiti is configured this is the graffiti for the proposal.
+func (s *Service) obtainLocalGraffiti(ctx context.Context,
+	slot phase0.Slot,
+	validatorIndex phase0.ValidatorIndex,
+	graffiti [32]byte,
+) [32]byte {
+	if s.localGraffiti == "" {
+		return graffiti
+	}
+
+	var res [32]byte
+	copy(res[:], s.expandGraffiti(ctx, []byte(s.localGraffiti), slot, validatorIndex))
+
+	return res
+}
+
+// expandGraffiti replaces variables in the graffiti.
+func (s *Service) expandGraffiti(ctx context.Context,
+	graffiti []byte,
+	slot phase0.Slot,
+	validatorIndex phase0.ValidatorIndex,
+) []byte {
+	graffiti = bytes.ReplaceAll(graffiti, []byte("{{SLOT}}"), []byte(fmt.Sprintf("%d", slot)))
+	graffiti = bytes.ReplaceAll(graffiti, []byte("{{VALIDATORINDEX}}"), []byte(fmt.Sprintf("%d", validatorIndex)))
+	if bytes.Contains(graffiti, []byte("{{EPOCH}}")) && s.chainTime != nil {
+		graffiti = bytes.ReplaceAll(graffiti, []byte("{{EPOCH}}"), []byte(fmt.Sprintf("%d", s.chainTime.SlotToEpoch(slot))))
+	}
 	if bytes.Contains(graffiti, []byte("{{CLIENT}}")) {
 		if nodeClientProvider, isProvider := s.proposalProvider.(consensusclient.NodeClientProvider); isProvider {
 			nodeClientResponse, err := nodeClientProvider.NodeClient(ctx)
@@ -156,10 +193,29 @@ func (s *Service) obtainGraffiti(ctx context.Context,
 			}
 		}
 	}
+	if bytes.Contains(graffiti, []byte("{{CLIENTVERSION}}")) {
+		if nodeVersionProvider, isProvider := s.proposalProvider.(consensusclient.NodeVersionProvider); isProvider {
+			nodeVersionResponse, err := nodeVersionProvider.NodeVersion(ctx, &api.NodeVersionOpts{})
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to obtain node version; not updating graffiti")
+			} else {
+				graffiti = bytes.ReplaceAll(graffiti, []byte("{{CLIENTVERSION}}"), []byte(shortNodeVersion(nodeVersionResponse.Data)))
+			}
+		}
+	}
 
-	copy(res[:], graffiti)
+	return graffiti
+}
 
-	return res, nil
+// shortNodeVersion shortens a node version such as "Lighthouse/v4.5.0-441fc16/x86_64-linux"
+// to its client and version, as graffiti is limited to 32 bytes.
+func shortNodeVersion(version string) string {
+	parts := strings.SplitN(version, "/", 3)
+	if len(parts) < 2 {
+		return version
+	}
+
+	return strings.Join(parts[:2], "/")
 }
 
 // proposeBlock proposes a beacon block.
@@ -167,49 +223,70 @@ func (s *Service) proposeBlock(ctx context.Context,
 	duty *beaconblockproposer.Duty,
 	graffiti [32]byte,
 ) error {
+	// Blocks built by the beacon node can have their own graffiti, to make them attributable.
+	localGraffiti := s.obtainLocalGraffiti(ctx, duty.Slot(), duty.ValidatorIndex(), graffiti)
+
 	// Pre-fetch an unblinded block in parallel with the auction process.
 	// This ensures that we are ready to propose as quickly as possible if the auction is unsuccessful.
-	var wg sync.WaitGroup
-	var proposal *api.VersionedProposal
-	wg.Add(1)
-	go func(ctx context.Context, duty *beaconblockproposer.Duty, graffiti [32]byte) {
-		var err error
-		proposalResponse, err := s.proposalProvider.Proposal(ctx, &api.ProposalOpts{
-			Slot:         duty.Slot(),
-			RandaoReveal: duty.RANDAOReveal(),
-			Graffiti:     graffiti,
-		})
-		if err != nil {
-			log.Warn().Err(err).Msg("Failed to pre-obtain proposal data")
-			return
-		}
-		proposal = proposalResponse.Data
-		log.Trace().Msg("Pre-obtained proposal")
-		wg.Done()
-	}(ctx, duty, graffiti)
+	prefetched := s.prefetchProposal(ctx, duty, localGraffiti)
+
+	audit := &proposalaudit.Proposal{
+		Slot:           duty.Slot(),
+		ValidatorIndex: duty.ValidatorIndex(),
+		Source:         "local",
+		Reason:         "no block auctioneer configured",
+	}
+	if s.proposalAudit != nil {
+		defer func() {
+			// The audit entry includes the value of the locally-built block, so wait for it to be obtained.
+			go func() {
+				<-prefetched.done
+				s.auditProposal(ctx, audit, prefetched.metadata)
+			}()
+		}()
+	}
+
+	useAuction := s.blockAuctioneer != nil
+	if useAuction && s.circuitBreaker != nil && s.circuitBreaker.Tripped(ctx) {
+		log.Info().Uint64("slot", uint64(duty.Slot())).Msg("Circuit breaker tripped; proposing without auction")
+		useAuction = false
+		audit.Reason = "circuit breaker tripped"
+	}
 
-	if s.blockAuctioneer != nil {
+	if useAuction {
 		// There is a block auctioneer specified, try to propose the block with auction.
-		result := s.proposeBlockWithAuction(ctx, duty, graffiti)
+		result, auctionResults := s.proposeBlockWithAuction(ctx, duty, graffiti, prefetched)
+		auditAuctionResults(audit, auctionResults)
 		switch result {
 		case auctionResultSucceeded:
 			monitorBeaconBlockProposalSource("auction")
+			audit.Source = "relay"
+			audit.Reason = "relay bid won the auction"
+			audit.Submitted = true
 			return nil
 		case auctionResultFailedCanTryWithout:
 			log.Warn().Uint64("slot", uint64(duty.Slot())).Msg("Failed to propose with auction; attempting to propose without auction")
+			audit.Reason = "failed to propose with auction"
 		case auctionResultNoBids:
 			log.Debug().Uint64("slot", uint64(duty.Slot())).Msg("No auction bids; attempting to propose without auction")
+			audit.Reason = "no relay bids received"
+		case auctionResultLocalMoreValuable:
+			log.Debug().Uint64("slot", uint64(duty.Slot())).Msg("Local block more valuable than best bid; proposing without auction")
+			audit.Reason = "local block more valuable than best bid"
 		case auctionResultFailed:
+			audit.Source = "relay"
+			audit.Reason = "failed to propose with auction too late in process to fall back"
 			return errors.New("failed to propose with auction too late in process, cannot fall back")
 		}
 	}
 
-	wg.Wait()
+	<-prefetched.done
 
-	err := s.proposeBlockWithoutAuction(ctx, proposal, duty, graffiti)
+	err := s.proposeBlockWithoutAuction(ctx, prefetched.proposal, duty, localGraffiti)
 	if err != nil {
 		return err
 	}
+	audit.Submitted = true
 
 	monitorBeaconBlockProposalSource("direct")
 	return nil
@@ -219,7 +296,11 @@ func (s *Service) proposeBlock(ctx context.Context,
 func (s *Service) proposeBlockWithAuction(ctx context.Context,
 	duty *beaconblockproposer.Duty,
 	graffiti [32]byte,
-) auctionResult {
+	prefetched *prefetchedProposal,
+) (
+	auctionResult,
+	*blockauctioneer.Results,
+) {
 	ctx, span := otel.Tracer("attestantio.vouch.services.beaconblockproposer.standard").Start(ctx, "proposeBlockWithAuction")
 	defer span.End()
 
@@ -228,18 +309,32 @@ func (s *Service) proposeBlockWithAuction(ctx context.Context,
 	auctionResults, err := s.auctionBlock(ctx, duty)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to auction block")
-		return auctionResultFailedCanTryWithout
+		return auctionResultFailedCanTryWithout, nil
 	}
 	if auctionResults.Bid == nil {
-		return auctionResultNoBids
+		return auctionResultNoBids, auctionResults
 	}
 	monitorBestBidRelayCount(len(auctionResults.Providers))
 
-	proposal, err := s.obtainBlindedProposal(ctx, duty, graffiti, auctionResults)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to obtain blinded proposal")
-		return auctionResultFailedCanTryWithout
+	// Obtain the blinded proposal while the local proposal is still being obtained, so that the
+	// local block can be compared with the bid without delaying the blinded proposal.
+	blindedCtx, cancelBlinded := context.WithCancel(ctx)
+	defer cancelBlinded()
+	blinded := s.prefetchBlindedProposal(blindedCtx, duty, graffiti, auctionResults)
+
+	if s.localBlockMoreValuable(ctx, auctionResults, prefetched) {
+		return auctionResultLocalMoreValuable, auctionResults
+	}
+
+	if !blinded.wait(ctx) {
+		log.Error().Msg("Context done before blinded proposal obtained")
+		return auctionResultFailedCanTryWithout, auctionResults
+	}
+	if blinded.err != nil {
+		log.Error().Err(blinded.err).Msg("Failed to obtain blinded proposal")
+		return auctionResultFailedCanTryWithout, auctionResults
 	}
+	proposal := blinded.proposal
 
 	// Select the relays to unblind the proposal.
 	providers := make([]builderclient.UnblindedProposalProvider, 0, len(auctionResults.AllProviders))
@@ -259,29 +354,32 @@ func (s *Service) proposeBlockWithAuction(ctx context.Context,
 	}
 	if len(providers) == 0 {
 		log.Debug().Msg("No relays can unblind the block")
-		return auctionResultFailedCanTryWithout
+		return auctionResultFailedCanTryWithout, auctionResults
 	}
 	log.Trace().Int("providers", len(providers)).Msg("Obtained relays that can unblind the proposal")
 
 	signedBlindedBlock, err := s.signBlindedProposal(ctx, duty, proposal)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to sign blinded proposal")
-		return auctionResultFailed
+		return auctionResultFailed, auctionResults
 	}
 
 	signedProposal, err := s.unblindBlock(ctx, signedBlindedBlock, providers)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to unblind block")
-		return auctionResultFailed
+		return auctionResultFailed, auctionResults
 	}
 
 	// Submit the proposal.
-	if err := s.proposalSubmitter.SubmitProposal(ctx, signedProposal); err != nil {
+	submitCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSubmit)
+	defer cancel()
+	if err := s.proposalSubmitter.SubmitProposal(submitCtx, signedProposal); err != nil {
 		log.Error().Err(err).Msg("Failed to submit beacon block proposal")
-		return auctionResultFailed
+		return auctionResultFailed, auctionResults
 	}
+	s.trackAuctionRevenue(ctx, duty, auctionResults)
 
-	return auctionResultSucceeded
+	return auctionResultSucceeded, auctionResults
 }
 
 func (s *Service) proposeBlockWithoutAuction(ctx context.Context,
@@ -293,20 +391,22 @@ func (s *Service) proposeBlockWithoutAuction(ctx context.Context,
 	defer span.End()
 
 	if proposal == nil {
-		proposalResponse, err := s.proposalProvider.Proposal(ctx, &api.ProposalOpts{
+		fetchCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseFetch)
+		proposalResponse, err := s.proposalProvider.Proposal(fetchCtx, &api.ProposalOpts{
 			Slot:         duty.Slot(),
 			RandaoReveal: duty.RANDAOReveal(),
 			Graffiti:     graffiti,
 		})
+		cancel()
 		if err != nil {
-			return errors.Wrap(err, "failed to obtain proposal data")
+			return util.ProviderError(errors.Wrap(err, "failed to obtain proposal data"))
 		}
 		proposal = proposalResponse.Data
 		log.Trace().Msg("Obtained proposal")
 	}
 
 	if err := s.confirmProposalData(ctx, proposal, duty, graffiti); err != nil {
-		return err
+		return util.CategoriseError(util.ErrorCategoryProviderInvalidData, err)
 	}
 
 	signedProposal, err := s.signProposalData(ctx, proposal, duty)
@@ -314,13 +414,85 @@ func (s *Service) proposeBlockWithoutAuction(ctx context.Context,
 		return err
 	}
 
-	if err := s.proposalSubmitter.SubmitProposal(ctx, signedProposal); err != nil {
-		return errors.Wrap(err, "failed to submit proposal")
+	submitCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSubmit)
+	defer cancel()
+	if err := s.proposalSubmitter.SubmitProposal(submitCtx, signedProposal); err != nil {
+		return util.CategoriseError(util.ErrorCategorySubmissionRejected, errors.Wrap(err, "failed to submit proposal"))
 	}
+	s.trackLocalRevenue(ctx, duty, proposal, signedProposal)
 
 	return nil
 }
 
+// trackAuctionRevenue tracks the revenue of a submitted proposal that used the auction.
+func (s *Service) trackAuctionRevenue(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	auctionResults *blockauctioneer.Results,
+) {
+	if s.proposalRevenue == nil {
+		return
+	}
+
+	blockHash, err := auctionResults.Bid.BlockHash()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain block hash of bid; not tracking revenue")
+		return
+	}
+	feeRecipient, err := auctionResults.Bid.FeeRecipient()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain fee recipient of bid; not tracking revenue")
+		return
+	}
+	value, err := auctionResults.Bid.Value()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain value of bid; not tracking revenue")
+		return
+	}
+	relay := ""
+	if len(auctionResults.Providers) > 0 {
+		relay = auctionResults.Providers[0].Address()
+	}
+
+	s.proposalRevenue.TrackProposal(ctx, &proposalrevenue.Proposal{
+		Slot:           duty.Slot(),
+		ValidatorIndex: duty.ValidatorIndex(),
+		BlockHash:      blockHash,
+		FeeRecipient:   feeRecipient,
+		Relay:          relay,
+		Value:          value.ToBig(),
+	})
+}
+
+// trackLocalRevenue tracks the revenue of a submitted proposal that was built locally.
+func (s *Service) trackLocalRevenue(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	proposal *api.VersionedProposal,
+	signedProposal *api.VersionedSignedProposal,
+) {
+	if s.proposalRevenue == nil {
+		return
+	}
+
+	// Proposals prior to bellatrix do not have an execution payload, so will error here.
+	blockHash, err := signedProposal.ExecutionBlockHash()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain execution block hash of proposal; not tracking revenue")
+		return
+	}
+	feeRecipient, err := proposal.FeeRecipient()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain fee recipient of proposal; not tracking revenue")
+		return
+	}
+
+	s.proposalRevenue.TrackProposal(ctx, &proposalrevenue.Proposal{
+		Slot:           duty.Slot(),
+		ValidatorIndex: duty.ValidatorIndex(),
+		BlockHash:      blockHash,
+		FeeRecipient:   feeRecipient,
+	})
+}
+
 func (*Service) confirmProposalData(_ context.Context,
 	proposal *api.VersionedProposal,
 	duty *beaconblockproposer.Duty,
@@ -367,7 +539,13 @@ func (s *Service) signProposalData(ctx context.Context,
 		return nil, errors.Wrap(err, "failed to obtain state root of block proposal")
 	}
 
-	sig, err := s.beaconBlockSigner.SignBeaconBlockProposal(ctx,
+	if err := s.protectProposal(ctx, duty); err != nil {
+		return nil, err
+	}
+
+	signCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSign)
+	defer cancel()
+	sig, err := s.beaconBlockSigner.SignBeaconBlockProposal(signCtx,
 		duty.Account(),
 		duty.Slot(),
 		duty.ValidatorIndex(),
@@ -375,7 +553,7 @@ func (s *Service) signProposalData(ctx context.Context,
 		stateRoot,
 		bodyRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to sign beacon block proposal")
+		return nil, util.CategoriseError(util.ErrorCategorySignerUnavailable, errors.Wrap(err, "failed to sign beacon block proposal"))
 	}
 
 	signedProposal := &api.VersionedSignedProposal{
@@ -425,12 +603,7 @@ func (s *Service) auctionBlock(ctx context.Context,
 	*blockauctioneer.Results,
 	error,
 ) {
-	var pubkey phase0.BLSPubKey
-	if provider, isProvider := duty.Account().(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
-		copy(pubkey[:], provider.CompositePublicKey().Marshal())
-	} else {
-		copy(pubkey[:], duty.Account().PublicKey().Marshal())
-	}
+	pubkey := dutyPubKey(duty)
 	hash, height := s.executionChainHeadProvider.ExecutionChainHead(ctx)
 	log.Trace().Str("hash", fmt.Sprintf("%#x", hash)).Uint64("height", height).Msg("Current execution chain state")
 	auctionResults, err := s.blockAuctioneer.AuctionBlock(ctx,
@@ -567,8 +740,14 @@ func (s *Service) signBlindedProposal(ctx context.Context,
 		return nil, errors.Wrap(err, "failed to obtain body root")
 	}
 
+	if err := s.protectProposal(ctx, duty); err != nil {
+		return nil, err
+	}
+
 	// Sign the block.
-	sig, err := s.beaconBlockSigner.SignBeaconBlockProposal(ctx,
+	signCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSign)
+	defer cancel()
+	sig, err := s.beaconBlockSigner.SignBeaconBlockProposal(signCtx,
 		duty.Account(),
 		duty.Slot(),
 		duty.ValidatorIndex(),
@@ -644,6 +823,7 @@ func (*Service) unblindBlock(ctx context.Context,
 					log.Debug().Err(err).Int("retries", retries).Msg("Failed to unblind block")
 					if strings.Contains(err.Error(), "POST failed with status 400") {
 						log.Debug().Msg("Responded with 400; not trying again as relay does not know of the payload")
+						monitorRelayUnblind(provider.Address(), "unknown_payload")
 						return
 					}
 					time.Sleep(retryInterval)
@@ -653,8 +833,10 @@ func (*Service) unblindBlock(ctx context.Context,
 			}
 			if signedProposal == nil {
 				log.Debug().Msg("No signed block received")
+				monitorRelayUnblind(provider.Address(), "failed")
 				return
 			}
+			monitorRelayUnblind(provider.Address(), "succeeded")
 
 			log.Trace().Msg("Unblinded block")
 			// Acquire the semaphore to confirm that a block has been received.
@@ -678,3 +860,30 @@ func (*Service) unblindBlock(ctx context.Context,
 		return signedBlock, nil
 	}
 }
+
+// protectProposal checks that signing the proposal cannot result in the
+// validator being slashed.
+func (s *Service) protectProposal(ctx context.Context, duty *beaconblockproposer.Duty) error {
+	if s.slashingProtection == nil {
+		return nil
+	}
+
+	if err := s.slashingProtection.ProtectProposal(ctx, dutyPubKey(duty), duty.Slot()); err != nil {
+		return errors.Wrap(err, "refused by slashing protection")
+	}
+
+	return nil
+}
+
+// dutyPubKey returns the public key of the account for the duty, using the
+// composite public key for distributed accounts.
+func dutyPubKey(duty *beaconblockproposer.Duty) phase0.BLSPubKey {
+	var pubkey phase0.BLSPubKey
+	if provider, isProvider := duty.Account().(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		copy(pubkey[:], provider.CompositePublicKey().Marshal())
+	} else {
+		copy(pubkey[:], duty.Account().PublicKey().Marshal())
+	}
+
+	return pubkey
+}
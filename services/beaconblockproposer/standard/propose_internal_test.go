@@ -16,9 +16,15 @@ package standard
 import (
 	"context"
 	"testing"
+	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -96,3 +102,103 @@ func TestValidateDuty(t *testing.T) {
 		})
 	}
 }
+
+func TestObtainLocalGraffiti(t *testing.T) {
+	ctx := context.Background()
+
+	var graffiti [32]byte
+	copy(graffiti[:], "proposal graffiti")
+	var local [32]byte
+	copy(local[:], "local graffiti")
+
+	tests := []struct {
+		name          string
+		localGraffiti string
+		expected      [32]byte
+	}{
+		{
+			name:     "NotConfigured",
+			expected: graffiti,
+		},
+		{
+			name:          "Configured",
+			localGraffiti: "local graffiti",
+			expected:      local,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				localGraffiti: test.localGraffiti,
+			}
+			require.Equal(t, test.expected, s.obtainLocalGraffiti(ctx, 1, 2, graffiti))
+		})
+	}
+}
+
+// nodeProvider is a proposal provider that also provides node information.
+type nodeProvider struct {
+	eth2client.ProposalProvider
+}
+
+func (*nodeProvider) NodeClient(_ context.Context) (*api.Response[string], error) {
+	return &api.Response[string]{Data: "lighthouse"}, nil
+}
+
+func (*nodeProvider) NodeVersion(_ context.Context, _ *api.NodeVersionOpts) (*api.Response[string], error) {
+	return &api.Response[string]{Data: "Lighthouse/v4.5.0-441fc16/x86_64-linux"}, nil
+}
+
+func TestExpandGraffiti(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		graffiti string
+		expected string
+	}{
+		{
+			name:     "Plain",
+			graffiti: "my graffiti",
+			expected: "my graffiti",
+		},
+		{
+			name:     "Slot",
+			graffiti: "slot {{SLOT}}",
+			expected: "slot 100",
+		},
+		{
+			name:     "Epoch",
+			graffiti: "epoch {{EPOCH}}",
+			expected: "epoch 3",
+		},
+		{
+			name:     "ValidatorIndex",
+			graffiti: "validator {{VALIDATORINDEX}}",
+			expected: "validator 5",
+		},
+		{
+			name:     "Client",
+			graffiti: "{{CLIENT}} {{CLIENTVERSION}}",
+			expected: "lighthouse Lighthouse/v4.5.0-441fc16",
+		},
+	}
+
+	s := &Service{
+		chainTime:        chainTime,
+		proposalProvider: &nodeProvider{},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, string(s.expandGraffiti(ctx, []byte(test.graffiti), 100, 5)))
+		})
+	}
+}
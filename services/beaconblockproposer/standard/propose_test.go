@@ -15,6 +15,7 @@ package standard_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -28,9 +29,11 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	mockcache "github.com/attestantio/vouch/services/cache/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockcircuitbreaker "github.com/attestantio/vouch/services/circuitbreaker/mock"
 	staticgraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/static"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	standardslashingprotection "github.com/attestantio/vouch/services/slashingprotection/standard"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -153,3 +156,139 @@ func TestPropose(t *testing.T) {
 		})
 	}
 }
+
+func TestProposeSlashingProtection(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	signer := mocksigner.New()
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+	graffitiProvider, err := staticgraffitiprovider.New(ctx)
+	require.NoError(t, err)
+	cacheService := mockcache.New(map[phase0.Root]phase0.Slot{})
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), scratch.New(), keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	slashingProtection, err := standardslashingprotection.New(ctx,
+		standardslashingprotection.WithLogLevel(zerolog.Disabled),
+		standardslashingprotection.WithMonitor(nullmetrics.New(ctx)),
+		standardslashingprotection.WithPath(filepath.Join(t.TempDir(), "slashing-protection.json")),
+		standardslashingprotection.WithGenesisValidatorsRoot(phase0.Root{0x01}),
+	)
+	require.NoError(t, err)
+
+	capture := logger.NewLogCapture()
+	s, err := standard.New(ctx,
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithProposalDataProvider(consensusClient),
+		standard.WithChainTime(chainTime),
+		standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+		standard.WithProposalSubmitter(consensusClient),
+		standard.WithRANDAORevealSigner(signer),
+		standard.WithGraffitiProvider(graffitiProvider),
+		standard.WithBeaconBlockSigner(signer),
+		standard.WithBlobSidecarSigner(signer),
+		standard.WithBlindedProposalDataProvider(consensusClient),
+		standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
+		standard.WithSlashingProtection(slashingProtection),
+	)
+	require.NoError(t, err)
+
+	s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+	require.True(t, capture.HasLog(map[string]any{
+		"message": "Submitted proposal",
+	}))
+
+	// A second proposal for the same slot must be refused.
+	s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+	require.True(t, capture.HasLog(map[string]any{
+		"message": "Failed to propose block",
+		"error":   "refused by slashing protection: proposal for slot 0 at or below previously signed slot 0",
+	}))
+}
+
+func TestProposeCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	signer := mocksigner.New()
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+	graffitiProvider, err := staticgraffitiprovider.New(ctx)
+	require.NoError(t, err)
+	cacheService := mockcache.New(map[phase0.Root]phase0.Slot{})
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), scratch.New(), keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		tripped bool
+		logged  bool
+	}{
+		{
+			name:    "NotTripped",
+			tripped: false,
+			logged:  false,
+		},
+		{
+			name:    "Tripped",
+			tripped: true,
+			logged:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			s, err := standard.New(ctx,
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithGraffitiProvider(graffitiProvider),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithBlockAuctioneer(mockblockauctioneer.New()),
+				standard.WithCircuitBreaker(mockcircuitbreaker.New(test.tripped)),
+				standard.WithBlindedProposalDataProvider(consensusClient),
+				standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
+			)
+			require.NoError(t, err)
+
+			s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+
+			require.Equal(t, test.logged, capture.HasLog(map[string]any{
+				"message": "Circuit breaker tripped; proposing without auction",
+			}))
+			require.True(t, capture.HasLog(map[string]any{
+				"message": "Submitted proposal",
+			}))
+		})
+	}
+}
@@ -25,8 +25,13 @@ import (
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/circuitbreaker"
 	"github.com/attestantio/vouch/services/graffitiprovider"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/proposalaudit"
+	"github.com/attestantio/vouch/services/proposalrevenue"
 	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/slashingprotection"
 	"github.com/attestantio/vouch/services/submitter"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -37,8 +42,12 @@ import (
 
 // Service is a beacon block proposer.
 type Service struct {
+	monitor                    metrics.Service
 	chainTime                  chaintime.Service
 	blockAuctioneer            blockauctioneer.BlockAuctioneer
+	circuitBreaker             circuitbreaker.Service
+	proposalRevenue            proposalrevenue.Service
+	proposalAudit              proposalaudit.Service
 	proposalProvider           eth2client.ProposalProvider
 	blindedProposalProvider    eth2client.BlindedProposalProvider
 	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
@@ -49,6 +58,9 @@ type Service struct {
 	beaconBlockSigner          signer.BeaconBlockSigner
 	blobSidecarSigner          signer.BlobSidecarSigner
 	unblindFromAllRelays       bool
+	builderBoostFactor         uint64
+	localGraffiti              string
+	slashingProtection         slashingprotection.Service
 }
 
 // module-wide log.
@@ -74,6 +86,10 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	s := &Service{
 		chainTime:                  parameters.chainTime,
 		blockAuctioneer:            parameters.blockAuctioneer,
+		circuitBreaker:             parameters.circuitBreaker,
+		proposalRevenue:            parameters.proposalRevenue,
+		proposalAudit:              parameters.proposalAudit,
+		monitor:                    parameters.monitor,
 		proposalProvider:           parameters.proposalProvider,
 		blindedProposalProvider:    parameters.blindedProposalProvider,
 		validatingAccountsProvider: parameters.validatingAccountsProvider,
@@ -84,6 +100,9 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		beaconBlockSigner:          parameters.beaconBlockSigner,
 		blobSidecarSigner:          parameters.blobSidecarSigner,
 		unblindFromAllRelays:       parameters.unblindFromAllRelays,
+		builderBoostFactor:         parameters.builderBoostFactor,
+		localGraffiti:              parameters.localGraffiti,
+		slashingProtection:         parameters.slashingProtection,
 	}
 
 	return s, nil
@@ -90,7 +90,7 @@ func (s *Service) Subscribe(ctx context.Context,
 		for slot, slotInfo := range subscriptionInfo {
 			if slot <= currentSlot {
 				log.Trace().Uint64("current_slot", uint64(currentSlot)).Uint64("duty_slot", uint64(slot)).Msg("Subscription not for a future slot; ignoring")
-				return
+				continue
 			}
 			for committeeIndex, info := range slotInfo {
 				subscriptions = append(subscriptions, &apiv1.BeaconCommitteeSubscription{
@@ -102,6 +102,10 @@ func (s *Service) Subscribe(ctx context.Context,
 				})
 			}
 		}
+		if len(subscriptions) == 0 {
+			log.Trace().Msg("No subscriptions for future slots; not submitting")
+			return
+		}
 		if err := s.submitter.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions); err != nil {
 			log.Error().Err(err).Msg("Failed to submit beacon committees")
 			s.monitor.BeaconCommitteeSubscriptionCompleted(started, "failed")
@@ -0,0 +1,43 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"context"
+)
+
+// Service is a mock beacon node quota service.
+type Service struct {
+	deprioritised map[string]struct{}
+}
+
+// New creates a new mock beacon node quota service, with the given
+// addresses deprioritised.
+func New(deprioritised ...string) *Service {
+	s := &Service{
+		deprioritised: make(map[string]struct{}, len(deprioritised)),
+	}
+	for _, address := range deprioritised {
+		s.deprioritised[address] = struct{}{}
+	}
+
+	return s
+}
+
+// Deprioritised is a mock.
+func (s *Service) Deprioritised(_ context.Context, address string) bool {
+	_, exists := s.deprioritised[address]
+
+	return exists
+}
@@ -0,0 +1,34 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beaconnodequota tracks the use of beacon nodes against request quotas.
+package beaconnodequota
+
+import (
+	"context"
+)
+
+// Quota is a limit on the number of requests made to a beacon node.
+// A zero value is no limit.
+type Quota struct {
+	Hourly uint64
+	Daily  uint64
+}
+
+// Service is the beacon node quota service.
+type Service interface {
+	// Deprioritised returns true if the beacon node at the given address has
+	// nearly exhausted its quota, in which case it should only be used for
+	// requests that are critical to duties.
+	Deprioritised(ctx context.Context, address string) bool
+}
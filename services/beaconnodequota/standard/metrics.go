@@ -0,0 +1,77 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	usageGauge         *prometheus.GaugeVec
+	deprioritisedGauge *prometheus.GaugeVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if usageGauge != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	usageGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconnodequota",
+		Name:      "usage_ratio",
+		Help:      "The proportion of the quota used by each beacon node in the current period.",
+	}, []string{"address", "period"})
+	if err := prometheus.Register(usageGauge); err != nil {
+		return err
+	}
+
+	deprioritisedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconnodequota",
+		Name:      "deprioritised",
+		Help:      "1 if the beacon node is deprioritised for non-critical requests, otherwise 0.",
+	}, []string{"address"})
+	return prometheus.Register(deprioritisedGauge)
+}
+
+func monitorUsage(address string, period string, ratio float64) {
+	if usageGauge != nil {
+		usageGauge.WithLabelValues(address, period).Set(ratio)
+	}
+}
+
+func monitorDeprioritised(address string, deprioritised bool) {
+	if deprioritisedGauge != nil {
+		if deprioritised {
+			deprioritisedGauge.WithLabelValues(address).Set(1)
+		} else {
+			deprioritisedGauge.WithLabelValues(address).Set(0)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/vouch/services/beaconnodequota"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	monitor       metrics.Service
+	scheduler     scheduler.Service
+	gatherer      prometheus.Gatherer
+	quotas        map[string]*beaconnodequota.Quota
+	threshold     float64
+	checkInterval time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithGatherer sets the gatherer from which beacon node request counts are obtained.
+func WithGatherer(gatherer prometheus.Gatherer) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.gatherer = gatherer
+	})
+}
+
+// WithQuotas sets the quotas for beacon nodes, keyed by address.
+func WithQuotas(quotas map[string]*beaconnodequota.Quota) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quotas = quotas
+	})
+}
+
+// WithThreshold sets the proportion of a quota above which a beacon node is deprioritised.
+func WithThreshold(threshold float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.threshold = threshold
+	})
+}
+
+// WithCheckInterval sets the interval at which usage is checked against quotas.
+func WithCheckInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.checkInterval = interval
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		gatherer:      prometheus.DefaultGatherer,
+		threshold:     0.9,
+		checkInterval: time.Minute,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.gatherer == nil {
+		return nil, errors.New("no gatherer specified")
+	}
+	if len(parameters.quotas) == 0 {
+		return nil, errors.New("no quotas specified")
+	}
+	for address, quota := range parameters.quotas {
+		if quota == nil || (quota.Hourly == 0 && quota.Daily == 0) {
+			return nil, fmt.Errorf("no quota specified for %s", address)
+		}
+	}
+	if parameters.threshold <= 0 || parameters.threshold > 1 {
+		return nil, errors.New("threshold must be greater than 0 and at most 1")
+	}
+	if parameters.checkInterval <= 0 {
+		return nil, errors.New("check interval must be positive")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,222 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/beaconnodequota"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// requestsMetricName is the name of the metric, maintained by the beacon node
+// client, that counts the requests made to each beacon node.
+const requestsMetricName = "consensusclient_http_requests_total"
+
+// usage is the use of a beacon node in the current periods.
+type usage struct {
+	hourStart     time.Time
+	hourBase      float64
+	hourly        float64
+	dayStart      time.Time
+	dayBase       float64
+	daily         float64
+	deprioritised bool
+}
+
+// Service tracks the requests made to beacon nodes against their quotas.
+type Service struct {
+	gatherer      prometheus.Gatherer
+	quotas        map[string]*beaconnodequota.Quota
+	threshold     float64
+	checkInterval time.Duration
+
+	mu    sync.RWMutex
+	usage map[string]*usage
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new beacon node quota service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "beaconnodequota").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		gatherer:      parameters.gatherer,
+		quotas:        parameters.quotas,
+		threshold:     parameters.threshold,
+		checkInterval: parameters.checkInterval,
+		usage:         make(map[string]*usage, len(parameters.quotas)),
+	}
+
+	// Carry out the initial check inline, to set the baseline for each beacon node.
+	if err := s.check(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"beaconnodequota",
+		"Check beacon node quotas",
+		s.checkRuntime,
+		nil,
+		s.checkJob,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start quota checker")
+	}
+
+	return s, nil
+}
+
+// Deprioritised returns true if the beacon node at the given address has
+// nearly exhausted its quota.
+func (s *Service) Deprioritised(_ context.Context, address string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, exists := s.usage[address]
+	if !exists {
+		return false
+	}
+
+	return u.deprioritised
+}
+
+func (s *Service) checkRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return time.Now().Add(s.checkInterval), nil
+}
+
+func (s *Service) checkJob(_ context.Context, _ interface{}) {
+	if err := s.check(time.Now()); err != nil {
+		log.Error().Err(err).Msg("Failed to check beacon node quotas")
+	}
+}
+
+// check updates the usage of each beacon node with a quota.
+// Periods are aligned to UTC hours and days.
+func (s *Service) check(now time.Time) error {
+	totals, err := s.requestTotals()
+	if err != nil {
+		return err
+	}
+
+	now = now.UTC()
+	hourStart := now.Truncate(time.Hour)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for address, quota := range s.quotas {
+		total := totals[address]
+		u, exists := s.usage[address]
+		if !exists {
+			u = &usage{
+				hourStart: hourStart,
+				hourBase:  total,
+				dayStart:  dayStart,
+				dayBase:   total,
+			}
+			s.usage[address] = u
+		}
+		// Start new periods as required.  A total lower than the base means that
+		// the counter has been reset, in which case the base is reset with it.
+		if !u.hourStart.Equal(hourStart) || total < u.hourBase {
+			u.hourStart = hourStart
+			u.hourBase = total
+		}
+		if !u.dayStart.Equal(dayStart) || total < u.dayBase {
+			u.dayStart = dayStart
+			u.dayBase = total
+		}
+		u.hourly = total - u.hourBase
+		u.daily = total - u.dayBase
+
+		deprioritised := false
+		if quota.Hourly > 0 {
+			ratio := u.hourly / float64(quota.Hourly)
+			monitorUsage(address, "hourly", ratio)
+			if ratio >= s.threshold {
+				deprioritised = true
+			}
+		}
+		if quota.Daily > 0 {
+			ratio := u.daily / float64(quota.Daily)
+			monitorUsage(address, "daily", ratio)
+			if ratio >= s.threshold {
+				deprioritised = true
+			}
+		}
+
+		if deprioritised != u.deprioritised {
+			if deprioritised {
+				log.Warn().Str("address", address).Float64("hourly", u.hourly).Float64("daily", u.daily).Msg("Beacon node quota nearly exhausted; deprioritising for non-critical requests")
+			} else {
+				log.Info().Str("address", address).Msg("Beacon node quota available; no longer deprioritised")
+			}
+			u.deprioritised = deprioritised
+		}
+		monitorDeprioritised(address, deprioritised)
+	}
+
+	return nil
+}
+
+// requestTotals returns the total number of requests made to each beacon node.
+func (s *Service) requestTotals() (map[string]float64, error) {
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to gather metrics")
+	}
+
+	totals := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != requestsMetricName {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "server" {
+					totals[label.GetValue()] += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return totals, nil
+}
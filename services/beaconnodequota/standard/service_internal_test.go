@@ -0,0 +1,85 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/beaconnodequota"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+
+	registry := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "consensusclient",
+		Subsystem: "http",
+		Name:      "requests_total",
+	}, []string{"server", "method", "endpoint", "result"})
+	require.NoError(t, registry.Register(requests))
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithScheduler(mockscheduler.New()),
+		WithGatherer(registry),
+		WithQuotas(map[string]*beaconnodequota.Quota{
+			"hourly:5052": {Hourly: 100},
+			"daily:5052":  {Daily: 1000},
+		}),
+		WithThreshold(0.9),
+	)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	require.NoError(t, s.check(now))
+	require.False(t, s.Deprioritised(ctx, "hourly:5052"))
+	require.False(t, s.Deprioritised(ctx, "daily:5052"))
+	require.False(t, s.Deprioritised(ctx, "unknown:5052"))
+
+	// Requests below the threshold, summed across labels.
+	requests.WithLabelValues("hourly:5052", "GET", "/eth/v1/node/syncing", "succeeded").Add(50)
+	requests.WithLabelValues("hourly:5052", "POST", "/eth/v1/beacon/pool/attestations", "failed").Add(39)
+	requests.WithLabelValues("daily:5052", "GET", "/eth/v1/node/syncing", "succeeded").Add(89)
+	require.NoError(t, s.check(now.Add(time.Minute)))
+	require.False(t, s.Deprioritised(ctx, "hourly:5052"))
+	require.False(t, s.Deprioritised(ctx, "daily:5052"))
+
+	// Requests reach the threshold for the hourly quota.
+	requests.WithLabelValues("hourly:5052", "GET", "/eth/v1/node/syncing", "succeeded").Add(1)
+	require.NoError(t, s.check(now.Add(2*time.Minute)))
+	require.True(t, s.Deprioritised(ctx, "hourly:5052"))
+	require.False(t, s.Deprioritised(ctx, "daily:5052"))
+
+	// A new hour resets the hourly quota.
+	require.NoError(t, s.check(now.Add(time.Hour)))
+	require.False(t, s.Deprioritised(ctx, "hourly:5052"))
+
+	// Requests reach the threshold for the daily quota across hours.
+	requests.WithLabelValues("daily:5052", "GET", "/eth/v1/node/syncing", "succeeded").Add(811)
+	require.NoError(t, s.check(now.Add(2*time.Hour)))
+	require.True(t, s.Deprioritised(ctx, "daily:5052"))
+
+	// A new day resets the daily quota.
+	require.NoError(t, s.check(now.Add(24*time.Hour)))
+	require.False(t, s.Deprioritised(ctx, "daily:5052"))
+}
@@ -0,0 +1,159 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/beaconnodequota"
+	"github.com/attestantio/vouch/services/beaconnodequota/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	monitor := nullmetrics.New(ctx)
+	scheduler := mockscheduler.New()
+	gatherer := prometheus.NewRegistry()
+	quotas := map[string]*beaconnodequota.Quota{
+		"localhost:5052": {Hourly: 1000, Daily: 10000},
+	}
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(quotas),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(quotas),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "GathererNil",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(nil),
+				standard.WithQuotas(quotas),
+			},
+			err: "problem with parameters: no gatherer specified",
+		},
+		{
+			name: "QuotasMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+			},
+			err: "problem with parameters: no quotas specified",
+		},
+		{
+			name: "QuotaEmpty",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(map[string]*beaconnodequota.Quota{
+					"localhost:5052": {},
+				}),
+			},
+			err: "problem with parameters: no quota specified for localhost:5052",
+		},
+		{
+			name: "ThresholdZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(quotas),
+				standard.WithThreshold(0),
+			},
+			err: "problem with parameters: threshold must be greater than 0 and at most 1",
+		},
+		{
+			name: "ThresholdHigh",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(quotas),
+				standard.WithThreshold(1.5),
+			},
+			err: "problem with parameters: threshold must be greater than 0 and at most 1",
+		},
+		{
+			name: "CheckIntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(quotas),
+				standard.WithCheckInterval(0),
+			},
+			err: "problem with parameters: check interval must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithScheduler(scheduler),
+				standard.WithGatherer(gatherer),
+				standard.WithQuotas(quotas),
+				standard.WithThreshold(0.8),
+				standard.WithCheckInterval(time.Minute),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beaconnodeset tracks the configured set of beacon nodes, allowing
+// beacon nodes to be added and removed without restarting.
+package beaconnodeset
+
+import (
+	"context"
+)
+
+// Service is the beacon node set service.
+type Service interface {
+	// Addresses returns the current beacon node addresses.
+	Addresses(ctx context.Context) []string
+}
+
+// Reloader is the interface for reloading the beacon node set.
+type Reloader interface {
+	// Reload reloads the beacon node set from its source, returning true if
+	// the set has changed.
+	Reload(ctx context.Context) (bool, error)
+}
+
+// AddressesProvider is the interface for the source of beacon node addresses.
+type AddressesProvider interface {
+	// BeaconNodeAddresses provides the configured beacon node addresses.
+	BeaconNodeAddresses(ctx context.Context) ([]string, error)
+}
+
+// Handler is the interface for acting on changes to the beacon node set.
+type Handler interface {
+	// OnBeaconNodesChanged is called when the beacon node set changes.  If it
+	// returns an error the change is not applied, and will be retried on the
+	// next reload.
+	OnBeaconNodesChanged(ctx context.Context, addresses []string) error
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	reloadsCounter *prometheus.CounterVec
+	nodesGauge     prometheus.Gauge
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if reloadsCounter != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	reloadsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconnodeset",
+		Name:      "reloads_total",
+		Help:      "The number of changes to the beacon node set.",
+	}, []string{"result"})
+	if err := prometheus.Register(reloadsCounter); err != nil {
+		return err
+	}
+
+	nodesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconnodeset",
+		Name:      "beacon_nodes",
+		Help:      "The number of beacon nodes in the beacon node set.",
+	})
+	return prometheus.Register(nodesGauge)
+}
+
+// monitorReload records the result of a change to the beacon node set.
+func monitorReload(result string, nodes int) {
+	if reloadsCounter != nil {
+		reloadsCounter.WithLabelValues(result).Inc()
+	}
+	if nodesGauge != nil && result == "succeeded" {
+		nodesGauge.Set(float64(nodes))
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/beaconnodeset"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service tracks the set of beacon nodes, periodically checking its source
+// for changes.
+type Service struct {
+	addressesProvider beaconnodeset.AddressesProvider
+	handler           beaconnodeset.Handler
+	checkInterval     time.Duration
+
+	// reloadMu serialises reloads, so that the handler is not called concurrently.
+	reloadMu    sync.Mutex
+	addressesMu sync.RWMutex
+	addresses   []string
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new beacon node set service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "beaconnodeset").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		addressesProvider: parameters.addressesProvider,
+		handler:           parameters.handler,
+		checkInterval:     parameters.checkInterval,
+		addresses:         dedupe(parameters.addresses),
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"beaconnodeset",
+		"Check beacon node set",
+		s.checkRuntime,
+		nil,
+		s.check,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start beacon node set checker")
+	}
+
+	return s, nil
+}
+
+// Addresses returns the current beacon node addresses.
+func (s *Service) Addresses(_ context.Context) []string {
+	s.addressesMu.RLock()
+	defer s.addressesMu.RUnlock()
+
+	res := make([]string, len(s.addresses))
+	copy(res, s.addresses)
+
+	return res
+}
+
+// Reload reloads the beacon node set from its source, returning true if the
+// set has changed.
+func (s *Service) Reload(ctx context.Context) (bool, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	addresses, err := s.addressesProvider.BeaconNodeAddresses(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain beacon node addresses")
+	}
+	addresses = dedupe(addresses)
+	if len(addresses) == 0 {
+		return false, errors.New("no beacon node addresses configured")
+	}
+
+	current := s.Addresses(ctx)
+	if strings.Join(addresses, ",") == strings.Join(current, ",") {
+		return false, nil
+	}
+
+	if err := s.handler.OnBeaconNodesChanged(ctx, addresses); err != nil {
+		monitorReload("failed", len(current))
+		return false, errors.Wrap(err, "failed to apply beacon node set")
+	}
+
+	s.addressesMu.Lock()
+	s.addresses = addresses
+	s.addressesMu.Unlock()
+	monitorReload("succeeded", len(addresses))
+
+	added, removed := diff(current, addresses)
+	log.Info().Strs("added", added).Strs("removed", removed).Strs("beacon_nodes", addresses).Msg("Beacon node set changed")
+
+	return true, nil
+}
+
+func (s *Service) checkRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return time.Now().Add(s.checkInterval), nil
+}
+
+// check checks the source for changes to the beacon node set.
+func (s *Service) check(ctx context.Context, _ interface{}) {
+	if _, err := s.Reload(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to reload beacon node set; continuing with existing beacon nodes")
+	}
+}
+
+// dedupe removes duplicate and empty addresses, retaining order.
+func dedupe(addresses []string) []string {
+	seen := make(map[string]struct{}, len(addresses))
+	res := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address == "" {
+			continue
+		}
+		if _, exists := seen[address]; exists {
+			continue
+		}
+		seen[address] = struct{}{}
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// diff returns the addresses added and removed between two sets.
+func diff(previous []string, current []string) ([]string, []string) {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, address := range previous {
+		previousSet[address] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, address := range current {
+		currentSet[address] = struct{}{}
+	}
+
+	added := make([]string, 0)
+	for _, address := range current {
+		if _, exists := previousSet[address]; !exists {
+			added = append(added, address)
+		}
+	}
+	removed := make([]string, 0)
+	for _, address := range previous {
+		if _, exists := currentSet[address]; !exists {
+			removed = append(removed, address)
+		}
+	}
+
+	return added, removed
+}
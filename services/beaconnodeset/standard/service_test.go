@@ -0,0 +1,215 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/vouch/services/beaconnodeset/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// addressesProvider provides a configurable set of addresses.
+type addressesProvider struct {
+	addresses []string
+	err       error
+}
+
+func (p *addressesProvider) BeaconNodeAddresses(_ context.Context) ([]string, error) {
+	return p.addresses, p.err
+}
+
+// handler records changes, optionally failing.
+type handler struct {
+	changes [][]string
+	err     error
+}
+
+func (h *handler) OnBeaconNodesChanged(_ context.Context, addresses []string) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.changes = append(h.changes, addresses)
+
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithAddresses([]string{"a"}),
+				standard.WithAddressesProvider(&addressesProvider{}),
+				standard.WithHandler(&handler{}),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithAddresses([]string{"a"}),
+				standard.WithAddressesProvider(&addressesProvider{}),
+				standard.WithHandler(&handler{}),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "AddressesMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithAddressesProvider(&addressesProvider{}),
+				standard.WithHandler(&handler{}),
+			},
+			err: "problem with parameters: no addresses specified",
+		},
+		{
+			name: "AddressesProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithAddresses([]string{"a"}),
+				standard.WithHandler(&handler{}),
+			},
+			err: "problem with parameters: no addresses provider specified",
+		},
+		{
+			name: "HandlerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithAddresses([]string{"a"}),
+				standard.WithAddressesProvider(&addressesProvider{}),
+			},
+			err: "problem with parameters: no handler specified",
+		},
+		{
+			name: "CheckIntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithAddresses([]string{"a"}),
+				standard.WithAddressesProvider(&addressesProvider{}),
+				standard.WithHandler(&handler{}),
+				standard.WithCheckInterval(0),
+			},
+			err: "problem with parameters: check interval must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithAddresses([]string{"a"}),
+				standard.WithAddressesProvider(&addressesProvider{}),
+				standard.WithHandler(&handler{}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+
+	provider := &addressesProvider{addresses: []string{"a", "b"}}
+	h := &handler{}
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithAddresses([]string{"a", "b"}),
+		standard.WithAddressesProvider(provider),
+		standard.WithHandler(h),
+	)
+	require.NoError(t, err)
+
+	// Unchanged.
+	changed, err := s.Reload(ctx)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Empty(t, h.changes)
+
+	// Duplicates are ignored.
+	provider.addresses = []string{"a", "b", "a", ""}
+	changed, err = s.Reload(ctx)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// Added.
+	provider.addresses = []string{"a", "b", "c"}
+	changed, err = s.Reload(ctx)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, []string{"a", "b", "c"}, s.Addresses(ctx))
+	require.Equal(t, [][]string{{"a", "b", "c"}}, h.changes)
+
+	// Empty set is rejected.
+	provider.addresses = []string{}
+	_, err = s.Reload(ctx)
+	require.EqualError(t, err, "no beacon node addresses configured")
+	require.Equal(t, []string{"a", "b", "c"}, s.Addresses(ctx))
+
+	// Source failure leaves the set unchanged.
+	provider.addresses = []string{"c"}
+	provider.err = errors.New("unreadable")
+	_, err = s.Reload(ctx)
+	require.EqualError(t, err, "failed to obtain beacon node addresses: unreadable")
+	require.Equal(t, []string{"a", "b", "c"}, s.Addresses(ctx))
+
+	// Handler failure leaves the set unchanged.
+	provider.err = nil
+	h.err = errors.New("failed")
+	_, err = s.Reload(ctx)
+	require.EqualError(t, err, "failed to apply beacon node set: failed")
+	require.Equal(t, []string{"a", "b", "c"}, s.Addresses(ctx))
+
+	// Removed, once the handler succeeds.
+	h.err = nil
+	changed, err = s.Reload(ctx)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, []string{"c"}, s.Addresses(ctx))
+}
@@ -26,11 +26,14 @@ const (
 	ConfigVersionV1 ConfigVersion = iota
 	// ConfigVersionV2 is data applicable for the second version of the configuration.
 	ConfigVersionV2
+	// ConfigVersionV3 is data applicable for the third version of the configuration.
+	ConfigVersionV3
 )
 
 var configVersionStrings = [...]string{
 	"v1",
 	"v2",
+	"v3",
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -46,6 +49,8 @@ func (c *ConfigVersion) UnmarshalJSON(input []byte) error {
 		*c = ConfigVersionV1
 	case `"v2"`:
 		*c = ConfigVersionV2
+	case `"v3"`:
+		*c = ConfigVersionV3
 	default:
 		err = fmt.Errorf("unrecognised config version %s", string(input))
 	}
@@ -19,6 +19,7 @@ import (
 
 	v1 "github.com/attestantio/vouch/services/blockrelay/v1"
 	v2 "github.com/attestantio/vouch/services/blockrelay/v2"
+	v3 "github.com/attestantio/vouch/services/blockrelay/v3"
 	"github.com/pkg/errors"
 )
 
@@ -46,6 +47,12 @@ func UnmarshalJSON(data []byte) (ExecutionConfigurator, error) {
 			return nil, errors.Wrap(err, "failed to unmarshal version 2 execution config")
 		}
 		return &execConfigV2, nil
+	case 3:
+		var execConfigV3 v3.ExecutionConfig
+		if err := json.Unmarshal(data, &execConfigV3); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal version 3 execution config")
+		}
+		return &execConfigV3, nil
 	default:
 		return nil, fmt.Errorf("unhandled execution config version %d", metadata.Version)
 	}
@@ -0,0 +1,79 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// VerifyExecutionConfigSignature verifies a detached signature over the raw
+// execution configuration.
+// The public key is a PEM-encoded PKIX public key, either ed25519 or ECDSA.
+// The signature can be supplied as raw bytes, hex or base64; ECDSA signatures
+// are ASN.1-encoded and over the SHA-256 hash of the configuration.
+func VerifyExecutionConfigSignature(data []byte,
+	signature []byte,
+	publicKey []byte,
+) error {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return errors.New("verification key is not PEM-encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid verification key")
+	}
+
+	sig := decodeSignature(signature)
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, data, sig) {
+			return errors.New("execution configuration signature is invalid")
+		}
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(k, hash[:], sig) {
+			return errors.New("execution configuration signature is invalid")
+		}
+	default:
+		return fmt.Errorf("unsupported verification key type %T", key)
+	}
+
+	return nil
+}
+
+// decodeSignature decodes a signature that may be text-encoded.
+func decodeSignature(signature []byte) []byte {
+	text := strings.TrimSpace(string(signature))
+	if sig, err := hex.DecodeString(strings.TrimPrefix(text, "0x")); err == nil && len(sig) > 0 {
+		return sig
+	}
+	if sig, err := base64.StdEncoding.DecodeString(text); err == nil && len(sig) > 0 {
+		return sig
+	}
+
+	// Assume raw bytes.
+	return bytes.TrimRight(signature, "\n")
+}
@@ -0,0 +1,214 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/stretchr/testify/require"
+	fileconfidant "github.com/wealdtech/go-majordomo/confidants/file"
+	standardmajordomo "github.com/wealdtech/go-majordomo/standard"
+)
+
+func pemPublicKey(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerifyExecutionConfigSignature(t *testing.T) {
+	data := []byte(`{"version":2,"fee_recipient":"0x0102030405060708090a0b0c0d0e0f1011121314"}`)
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edPriv, data)
+
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	hash := sha256.Sum256(data)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecPriv, hash[:])
+	require.NoError(t, err)
+
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		signature []byte
+		publicKey []byte
+		err       string
+	}{
+		{
+			name:      "KeyNotPEM",
+			data:      data,
+			signature: edSig,
+			publicKey: []byte("bad"),
+			err:       "verification key is not PEM-encoded",
+		},
+		{
+			name:      "KeyInvalid",
+			data:      data,
+			signature: edSig,
+			publicKey: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("bad")}),
+			err:       "invalid verification key",
+		},
+		{
+			name:      "KeyUnsupported",
+			data:      data,
+			signature: edSig,
+			publicKey: pemPublicKey(t, &rsaPriv.PublicKey),
+			err:       "unsupported verification key type *rsa.PublicKey",
+		},
+		{
+			name:      "Ed25519Raw",
+			data:      data,
+			signature: edSig,
+			publicKey: pemPublicKey(t, edPub),
+		},
+		{
+			name:      "Ed25519Hex",
+			data:      data,
+			signature: []byte(fmt.Sprintf("%#x\n", edSig)),
+			publicKey: pemPublicKey(t, edPub),
+		},
+		{
+			name:      "Ed25519Base64",
+			data:      data,
+			signature: []byte(base64.StdEncoding.EncodeToString(edSig)),
+			publicKey: pemPublicKey(t, edPub),
+		},
+		{
+			name:      "Ed25519Tampered",
+			data:      []byte(`{"version":2,"fee_recipient":"0xffffffffffffffffffffffffffffffffffffffff"}`),
+			signature: edSig,
+			publicKey: pemPublicKey(t, edPub),
+			err:       "execution configuration signature is invalid",
+		},
+		{
+			name:      "ECDSA",
+			data:      data,
+			signature: []byte(hex.EncodeToString(ecSig)),
+			publicKey: pemPublicKey(t, &ecPriv.PublicKey),
+		},
+		{
+			name:      "ECDSAWrongKey",
+			data:      data,
+			signature: []byte(hex.EncodeToString(edSig)),
+			publicKey: pemPublicKey(t, &ecPriv.PublicKey),
+			err:       "execution configuration signature is invalid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := blockrelay.VerifyExecutionConfigSignature(test.data, test.signature, test.publicKey)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchExecutionConfigSigned(t *testing.T) {
+	ctx := context.Background()
+
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	fileConfidant, err := fileconfidant.New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, majordomoSvc.RegisterConfidant(ctx, fileConfidant))
+
+	data := []byte(`{"version":2,"fee_recipient":"0x0102030405060708090a0b0c0d0e0f1011121314"}`)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(configFile, data, 0o600))
+	require.NoError(t, os.WriteFile(fmt.Sprintf("%s.sig", configFile), []byte(hex.EncodeToString(ed25519.Sign(priv, data))), 0o600))
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pemPublicKey(t, pub), 0o600))
+	otherSigFile := filepath.Join(dir, "other.sig")
+	require.NoError(t, os.WriteFile(otherSigFile, []byte(hex.EncodeToString(ed25519.Sign(priv, []byte("other")))), 0o600))
+
+	tests := []struct {
+		name   string
+		source *blockrelay.ExecutionConfigSource
+		err    string
+	}{
+		{
+			name: "Unverified",
+			source: &blockrelay.ExecutionConfigSource{
+				URL: fmt.Sprintf("file://%s", configFile),
+			},
+		},
+		{
+			name: "DefaultSignature",
+			source: &blockrelay.ExecutionConfigSource{
+				URL:                fmt.Sprintf("file://%s", configFile),
+				VerificationKeyURL: fmt.Sprintf("file://%s", keyFile),
+			},
+		},
+		{
+			name: "SignatureMissing",
+			source: &blockrelay.ExecutionConfigSource{
+				URL:                fmt.Sprintf("file://%s", configFile),
+				VerificationKeyURL: fmt.Sprintf("file://%s", keyFile),
+				SignatureURL:       fmt.Sprintf("file://%s", filepath.Join(dir, "missing.sig")),
+			},
+			err: "failed to obtain execution configuration signature",
+		},
+		{
+			name: "SignatureInvalid",
+			source: &blockrelay.ExecutionConfigSource{
+				URL:                fmt.Sprintf("file://%s", configFile),
+				VerificationKeyURL: fmt.Sprintf("file://%s", keyFile),
+				SignatureURL:       fmt.Sprintf("file://%s", otherSigFile),
+			},
+			err: "execution configuration signature is invalid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := blockrelay.FetchExecutionConfig(ctx, majordomoSvc, test.source, nil)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, data, res)
+			}
+		})
+	}
+}
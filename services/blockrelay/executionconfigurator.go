@@ -19,6 +19,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/shopspring/decimal"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -30,6 +31,7 @@ type ExecutionConfigurator interface {
 		pubkey phase0.BLSPubKey,
 		fallbackFeeRecipient bellatrix.ExecutionAddress,
 		fallbackGasLimit uint64,
+		fallbackMinValue decimal.Decimal,
 	) (
 		*beaconblockproposer.ProposerConfig,
 		error,
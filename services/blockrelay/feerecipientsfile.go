@@ -0,0 +1,146 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FeeRecipientsFile holds per-validator fee recipients read from a local file.
+// Fee recipients in the file take precedence over the execution configuration,
+// but not over runtime overrides.
+type FeeRecipientsFile struct {
+	path string
+
+	mu            sync.RWMutex
+	feeRecipients map[phase0.BLSPubKey]bellatrix.ExecutionAddress
+}
+
+// NewFeeRecipientsFile creates a fee recipients file for the given path.
+// The file is not read until Load is called.
+func NewFeeRecipientsFile(path string) *FeeRecipientsFile {
+	return &FeeRecipientsFile{
+		path:          path,
+		feeRecipients: make(map[phase0.BLSPubKey]bellatrix.ExecutionAddress),
+	}
+}
+
+// Path returns the path of the file.
+func (f *FeeRecipientsFile) Path() string {
+	return f.path
+}
+
+// Load reads the file, replacing the existing fee recipients.  It returns the
+// validators whose fee recipients have been added, changed or removed.
+// If the file cannot be read or parsed the existing fee recipients are retained.
+func (f *FeeRecipientsFile) Load() ([]phase0.BLSPubKey, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read fee recipients file")
+	}
+	feeRecipients, err := ParseFeeRecipients(data)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	changed := make([]phase0.BLSPubKey, 0)
+	for pubkey, feeRecipient := range feeRecipients {
+		if existing, exists := f.feeRecipients[pubkey]; !exists || existing != feeRecipient {
+			changed = append(changed, pubkey)
+		}
+	}
+	for pubkey := range f.feeRecipients {
+		if _, exists := feeRecipients[pubkey]; !exists {
+			changed = append(changed, pubkey)
+		}
+	}
+	f.feeRecipients = feeRecipients
+
+	return changed, nil
+}
+
+// FeeRecipient returns the fee recipient for the given validator, if present.
+func (f *FeeRecipientsFile) FeeRecipient(pubkey phase0.BLSPubKey) (bellatrix.ExecutionAddress, bool) {
+	f.mu.RLock()
+	feeRecipient, exists := f.feeRecipients[pubkey]
+	f.mu.RUnlock()
+
+	return feeRecipient, exists
+}
+
+// Apply applies the fee recipient for the given validator, if present, to the proposer
+// configuration.  The fee recipient replaces that for the proposer and all of its relays.
+func (f *FeeRecipientsFile) Apply(pubkey phase0.BLSPubKey, config *beaconblockproposer.ProposerConfig) {
+	feeRecipient, exists := f.FeeRecipient(pubkey)
+	if !exists {
+		return
+	}
+
+	config.FeeRecipient = feeRecipient
+	for _, relay := range config.Relays {
+		relay.FeeRecipient = feeRecipient
+	}
+}
+
+// ParseFeeRecipients parses fee recipients.  The data is a YAML or JSON mapping
+// of validator public keys to fee recipients.
+func ParseFeeRecipients(data []byte) (map[phase0.BLSPubKey]bellatrix.ExecutionAddress, error) {
+	entries := make(map[string]string)
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrap(err, "invalid fee recipients")
+	}
+
+	feeRecipients := make(map[phase0.BLSPubKey]bellatrix.ExecutionAddress, len(entries))
+	for key, value := range entries {
+		tmp, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(key), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key %q", key))
+		}
+		if len(tmp) != phase0.PublicKeyLength {
+			return nil, errors.Errorf("incorrect length for public key %q", key)
+		}
+		var pubkey phase0.BLSPubKey
+		copy(pubkey[:], tmp)
+
+		tmp, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid fee recipient %q", value))
+		}
+		if len(tmp) != bellatrix.ExecutionAddressLength {
+			return nil, errors.Errorf("incorrect length for fee recipient %q", value)
+		}
+		var feeRecipient bellatrix.ExecutionAddress
+		copy(feeRecipient[:], tmp)
+		if feeRecipient.IsZero() {
+			return nil, errors.Errorf("zero fee recipient for public key %q", key)
+		}
+
+		feeRecipients[pubkey] = feeRecipient
+	}
+
+	return feeRecipients, nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeeRecipients(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+		err      string
+	}{
+		{
+			name:     "Empty",
+			data:     "",
+			expected: 0,
+		},
+		{
+			name:     "YAML",
+			data:     "# Fee recipients\n'0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c': '0x0102030405060708090a0b0c0d0e0f1011121314'\n'b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b': '0x1102030405060708090a0b0c0d0e0f1011121314'\n",
+			expected: 2,
+		},
+		{
+			name:     "JSON",
+			data:     `{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c":"0x0102030405060708090a0b0c0d0e0f1011121314"}`,
+			expected: 1,
+		},
+		{
+			name: "Invalid",
+			data: `{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"`,
+			err:  "invalid fee recipients: yaml: line 1: did not find expected ',' or '}'",
+		},
+		{
+			name: "PublicKeyInvalid",
+			data: `{"0xinvalid":"0x0102030405060708090a0b0c0d0e0f1011121314"}`,
+			err:  "invalid public key \"0xinvalid\": encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "PublicKeyShort",
+			data: `{"0x0102":"0x0102030405060708090a0b0c0d0e0f1011121314"}`,
+			err:  "incorrect length for public key \"0x0102\"",
+		},
+		{
+			name: "FeeRecipientInvalid",
+			data: `{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c":"0xinvalid"}`,
+			err:  "invalid fee recipient \"0xinvalid\": encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "FeeRecipientShort",
+			data: `{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c":"0x0102"}`,
+			err:  "incorrect length for fee recipient \"0x0102\"",
+		},
+		{
+			name: "FeeRecipientZero",
+			data: `{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c":"0x0000000000000000000000000000000000000000"}`,
+			err:  "zero fee recipient for public key \"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := blockrelay.ParseFeeRecipients([]byte(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.expected)
+			}
+		})
+	}
+}
+
+func TestFeeRecipientsFile(t *testing.T) {
+	pubkey := phase0.BLSPubKey{0x01}
+	otherPubkey := phase0.BLSPubKey{0x02}
+	feeRecipient := bellatrix.ExecutionAddress{0x01}
+	fileFeeRecipient := bellatrix.ExecutionAddress{0x02}
+	updatedFeeRecipient := bellatrix.ExecutionAddress{0x03}
+
+	path := filepath.Join(t.TempDir(), "fee-recipients.yaml")
+	write := func(entries map[phase0.BLSPubKey]bellatrix.ExecutionAddress) {
+		data := ""
+		for pubkey, feeRecipient := range entries {
+			data += "'" + pubkey.String() + "': '" + feeRecipient.String() + "'\n"
+		}
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	}
+
+	file := blockrelay.NewFeeRecipientsFile(path)
+	require.Equal(t, path, file.Path())
+
+	// File missing.
+	_, err := file.Load()
+	require.ErrorContains(t, err, "failed to read fee recipients file")
+
+	// Initial load.
+	write(map[phase0.BLSPubKey]bellatrix.ExecutionAddress{pubkey: fileFeeRecipient})
+	changed, err := file.Load()
+	require.NoError(t, err)
+	require.Equal(t, []phase0.BLSPubKey{pubkey}, changed)
+
+	config := &beaconblockproposer.ProposerConfig{
+		FeeRecipient: feeRecipient,
+		Relays: []*beaconblockproposer.RelayConfig{
+			{
+				Address:      "https://relay1.example.com/",
+				FeeRecipient: feeRecipient,
+				GasLimit:     30000000,
+			},
+		},
+	}
+	file.Apply(pubkey, config)
+	require.Equal(t, fileFeeRecipient, config.FeeRecipient)
+	require.Equal(t, fileFeeRecipient, config.Relays[0].FeeRecipient)
+	require.Equal(t, uint64(30000000), config.Relays[0].GasLimit)
+
+	// Other validators are unaffected.
+	config = &beaconblockproposer.ProposerConfig{FeeRecipient: feeRecipient}
+	file.Apply(otherPubkey, config)
+	require.Equal(t, feeRecipient, config.FeeRecipient)
+
+	// Reload without changes.
+	changed, err = file.Load()
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	// Reload with a changed entry and an added entry.
+	write(map[phase0.BLSPubKey]bellatrix.ExecutionAddress{pubkey: updatedFeeRecipient, otherPubkey: fileFeeRecipient})
+	changed, err = file.Load()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []phase0.BLSPubKey{pubkey, otherPubkey}, changed)
+	res, exists := file.FeeRecipient(pubkey)
+	require.True(t, exists)
+	require.Equal(t, updatedFeeRecipient, res)
+
+	// Damaged file retains existing entries.
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = file.Load()
+	require.Error(t, err)
+	_, exists = file.FeeRecipient(otherPubkey)
+	require.True(t, exists)
+
+	// Reload with a removed entry.
+	write(map[phase0.BLSPubKey]bellatrix.ExecutionAddress{pubkey: updatedFeeRecipient})
+	changed, err = file.Load()
+	require.NoError(t, err)
+	require.Equal(t, []phase0.BLSPubKey{otherPubkey}, changed)
+	_, exists = file.FeeRecipient(otherPubkey)
+	require.False(t, exists)
+}
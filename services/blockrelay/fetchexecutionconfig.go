@@ -0,0 +1,145 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-majordomo"
+	httpconfidant "github.com/wealdtech/go-majordomo/confidants/http"
+)
+
+// ExecutionConfigSource is the location of execution configuration.
+type ExecutionConfigSource struct {
+	// URL is the majordomo URL of the execution configuration.
+	URL string
+	// ClientCertURL is the majordomo URL of the client certificate for dynamic sources.
+	ClientCertURL string
+	// ClientKeyURL is the majordomo URL of the client key for dynamic sources.
+	ClientKeyURL string
+	// CACertURL is the majordomo URL of the certificate authority for dynamic sources.
+	CACertURL string
+	// VerificationKeyURL is the majordomo URL of the public key used to verify
+	// the signature of the execution configuration.  If not present the
+	// execution configuration is not verified.
+	VerificationKeyURL string
+	// SignatureURL is the majordomo URL of the detached signature of the
+	// execution configuration.  If not present it defaults to URL with ".sig"
+	// appended.
+	SignatureURL string
+}
+
+// FetchExecutionConfig fetches the raw execution configuration from the given source.
+// Dynamic (HTTP) sources are supplied with the public keys of the validators in
+// the request body; if there are no public keys no configuration is fetched.
+// If the source has a verification key the configuration is only returned if
+// its detached signature is valid.
+func FetchExecutionConfig(ctx context.Context,
+	majordomo majordomo.Service,
+	source *ExecutionConfigSource,
+	pubkeys [][]byte,
+) (
+	[]byte,
+	error,
+) {
+	if !strings.HasPrefix(source.URL, "http") {
+		// We are fetching from a static source.
+		res, err := majordomo.Fetch(ctx, source.URL)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain execution configuration")
+		}
+		if err := verifyExecutionConfig(ctx, majordomo, source, res); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	}
+
+	// We are fetching from a dynamic source, need to provide additional parameters.
+	if len(pubkeys) == 0 {
+		// No results, but no error.
+		return nil, nil
+	}
+
+	if source.ClientCertURL != "" {
+		certPEMBlock, err := majordomo.Fetch(ctx, source.ClientCertURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain client certificate")
+		}
+		ctx = context.WithValue(ctx, &httpconfidant.ClientCert{}, certPEMBlock)
+		keyPEMBlock, err := majordomo.Fetch(ctx, source.ClientKeyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain client key")
+		}
+		ctx = context.WithValue(ctx, &httpconfidant.ClientKey{}, keyPEMBlock)
+		if source.CACertURL != "" {
+			caPEMBlock, err := majordomo.Fetch(ctx, source.CACertURL)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain client CA certificate")
+			}
+			ctx = context.WithValue(ctx, &httpconfidant.CACert{}, caPEMBlock)
+		}
+	}
+
+	ctx = context.WithValue(ctx, &httpconfidant.HTTPMethod{}, http.MethodPost)
+	pubkeyStrs := make([]string, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		pubkeyStrs = append(pubkeyStrs, fmt.Sprintf("%#x", pubkey))
+	}
+	// skipcq: GO-R4002
+	ctx = context.WithValue(ctx, &httpconfidant.Body{}, []byte(fmt.Sprintf(`["%s"]`, strings.Join(pubkeyStrs, `","`))))
+
+	res, err := majordomo.Fetch(ctx, source.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution configuration")
+	}
+	// The signature is fetched with the same request parameters as the
+	// configuration, as dynamic configuration differs between requests.
+	if err := verifyExecutionConfig(ctx, majordomo, source, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// verifyExecutionConfig verifies the detached signature of the execution
+// configuration, if the source has a verification key.
+func verifyExecutionConfig(ctx context.Context,
+	majordomo majordomo.Service,
+	source *ExecutionConfigSource,
+	data []byte,
+) error {
+	if source.VerificationKeyURL == "" {
+		return nil
+	}
+
+	publicKey, err := majordomo.Fetch(ctx, source.VerificationKeyURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution configuration verification key")
+	}
+	signatureURL := source.SignatureURL
+	if signatureURL == "" {
+		signatureURL = fmt.Sprintf("%s.sig", source.URL)
+	}
+	signature, err := majordomo.Fetch(ctx, signatureURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution configuration signature")
+	}
+
+	return VerifyExecutionConfigSignature(data, signature, publicKey)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/shopspring/decimal"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// fetchExecutionConfigRuntime sets the runtime for the next execution configuration call.
+func (s *Service) fetchExecutionConfigRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	// Schedule for the middle of the slot, one-quarter through the epoch.
+	currentEpoch := s.chainTime.CurrentEpoch()
+	epochDuration := s.chainTime.StartOfEpoch(currentEpoch + 1).Sub(s.chainTime.StartOfEpoch(currentEpoch))
+	currentSlot := s.chainTime.CurrentSlot()
+	slotDuration := s.chainTime.StartOfSlot(currentSlot + 1).Sub(s.chainTime.StartOfSlot(currentSlot))
+	offset := int(epochDuration.Seconds()/4.0 + slotDuration.Seconds()/2.0)
+	return s.chainTime.StartOfEpoch(currentEpoch + 1).Add(time.Duration(offset) * time.Second), nil
+}
+
+// fetchExecutionConfig fetches the execution configuration.
+func (s *Service) fetchExecutionConfig(ctx context.Context,
+	_ interface{},
+) {
+	if s.source.URL == "" {
+		log.Trace().Msg("No config URL; using default configuration with fallback")
+		return
+	}
+
+	// Fetch the validating accounts for the next epoch, to ensure that we capture any validators
+	// that are going to start proposing soon.
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpoch(ctx, s.chainTime.CurrentEpoch()+1)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain validating accounts; falling back")
+		return
+	}
+	pubkeys := make([][]byte, 0, len(accounts))
+	for _, account := range accounts {
+		if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+			pubkeys = append(pubkeys, provider.CompositePublicKey().Marshal())
+		} else {
+			pubkeys = append(pubkeys, account.PublicKey().Marshal())
+		}
+	}
+
+	res, err := blockrelay.FetchExecutionConfig(ctx, s.majordomo, s.source, pubkeys)
+	if err != nil {
+		log.Error().Str("config_url", s.source.URL).Err(err).Msg("Failed to obtain execution configuration")
+		return
+	}
+	if res == nil {
+		log.Trace().Msg("No public keys supplied; cannot fetch execution configuration")
+		return
+	}
+	executionConfig, err := blockrelay.UnmarshalJSON(res)
+	if err != nil {
+		log.Error().Str("config_url", s.source.URL).Err(err).Msg("Failed to unmarshal execution configuration")
+		return
+	}
+
+	s.executionConfigMu.Lock()
+	s.executionConfig = executionConfig
+	s.executionConfigMu.Unlock()
+
+	log.Trace().Msg("Obtained configuration")
+}
+
+// ProposerConfig returns the proposer configuration for the given validator.
+// Relays are never returned, regardless of the execution configuration.
+func (s *Service) ProposerConfig(ctx context.Context,
+	account e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	s.executionConfigMu.RLock()
+	executionConfig := s.executionConfig
+	s.executionConfigMu.RUnlock()
+
+	proposerConfig, err := executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit, decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &beaconblockproposer.ProposerConfig{
+		FeeRecipient: proposerConfig.FeeRecipient,
+		Relays:       make([]*beaconblockproposer.RelayConfig, 0),
+	}
+	if s.feeRecipientsFile != nil {
+		s.feeRecipientsFile.Apply(pubkey, config)
+	}
+	s.proposerOverrides.Apply(pubkey, config)
+
+	return config, nil
+}
+
+// GasLimit returns the gas limit for the given validator.
+func (s *Service) GasLimit(ctx context.Context,
+	account e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+) (
+	uint64,
+	error,
+) {
+	if gasLimit, exists := s.proposerOverrides.GasLimit(pubkey); exists {
+		return gasLimit, nil
+	}
+
+	s.executionConfigMu.RLock()
+	executionConfig := s.executionConfig
+	s.executionConfigMu.RUnlock()
+
+	proposerConfig, err := executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit, decimal.Zero)
+	if err != nil {
+		return 0, err
+	}
+	if len(proposerConfig.Relays) > 0 {
+		return proposerConfig.Relays[0].GasLimit, nil
+	}
+
+	return s.fallbackGasLimit, nil
+}
+
+// SetFeeRecipientOverride overrides the fee recipient for the given validator.
+func (s *Service) SetFeeRecipientOverride(_ context.Context, pubkey phase0.BLSPubKey, feeRecipient bellatrix.ExecutionAddress) {
+	s.proposerOverrides.SetFeeRecipient(pubkey, feeRecipient)
+}
+
+// ClearFeeRecipientOverride removes any fee recipient override for the given validator.
+func (s *Service) ClearFeeRecipientOverride(_ context.Context, pubkey phase0.BLSPubKey) {
+	s.proposerOverrides.ClearFeeRecipient(pubkey)
+}
+
+// SetGasLimitOverride overrides the gas limit for the given validator.
+func (s *Service) SetGasLimitOverride(_ context.Context, pubkey phase0.BLSPubKey, gasLimit uint64) {
+	s.proposerOverrides.SetGasLimit(pubkey, gasLimit)
+}
+
+// ClearGasLimitOverride removes any gas limit override for the given validator.
+func (s *Service) ClearGasLimitOverride(_ context.Context, pubkey phase0.BLSPubKey) {
+	s.proposerOverrides.ClearGasLimit(pubkey)
+}
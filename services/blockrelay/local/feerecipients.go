@@ -0,0 +1,73 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
+)
+
+// startFeeRecipientsFile carries out the initial load of the fee recipients
+// file and schedules its periodic reload.
+func (s *Service) startFeeRecipientsFile(ctx context.Context, scheduler scheduler.Service) error {
+	if _, err := s.feeRecipientsFile.Load(); err != nil {
+		return errors.Wrap(err, "failed to load fee recipients file")
+	}
+
+	if err := scheduler.SchedulePeriodicJob(ctx,
+		"blockrelay",
+		"Reload fee recipients file",
+		s.reloadFeeRecipientsRuntime,
+		nil,
+		s.reloadFeeRecipients,
+		nil,
+	); err != nil {
+		return errors.Wrap(err, "failed to start fee recipients file reloader")
+	}
+
+	log.Info().Str("path", s.feeRecipientsFile.Path()).Msg("Using fee recipients file")
+
+	return nil
+}
+
+func (s *Service) reloadFeeRecipientsRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return time.Now().Add(s.feeRecipientsReloadInterval), nil
+}
+
+// reloadFeeRecipients reloads the fee recipients file.  Changed fee recipients
+// are used by subsequent proposals.
+func (s *Service) reloadFeeRecipients(_ context.Context, _ interface{}) {
+	changed, err := s.feeRecipientsFile.Load()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to reload fee recipients file; retaining existing fee recipients")
+		return
+	}
+
+	for _, pubkey := range changed {
+		if feeRecipient, exists := s.feeRecipientsFile.FeeRecipient(pubkey); exists {
+			log.Info().Str("validator", fmt.Sprintf("%#x", pubkey)).Str("fee_recipient", feeRecipient.String()).Msg("Fee recipient set by fee recipients file")
+		} else {
+			log.Info().Str("validator", fmt.Sprintf("%#x", pubkey)).Msg("Fee recipient removed from fee recipients file")
+		}
+	}
+}
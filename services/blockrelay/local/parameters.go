@@ -0,0 +1,199 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"errors"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-majordomo"
+)
+
+type parameters struct {
+	logLevel                    zerolog.Level
+	majordomo                   majordomo.Service
+	scheduler                   scheduler.Service
+	chainTime                   chaintime.Service
+	configURL                   string
+	fallbackFeeRecipient        bellatrix.ExecutionAddress
+	fallbackGasLimit            uint64
+	clientCertURL               string
+	clientKeyURL                string
+	caCertURL                   string
+	verificationKeyURL          string
+	signatureURL                string
+	validatingAccountsProvider  accountmanager.ValidatingAccountsProvider
+	feeRecipientsFile           string
+	feeRecipientsReloadInterval time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMajordomo sets majordomo for the module.
+func WithMajordomo(majordomo majordomo.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.majordomo = majordomo
+	})
+}
+
+// WithScheduler provides the scheduler service.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithConfigURL sets the URL for the config server.
+func WithConfigURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.configURL = url
+	})
+}
+
+// WithFallbackFeeRecipient sets the fallback fee recipient for all validators.
+func WithFallbackFeeRecipient(feeRecipient bellatrix.ExecutionAddress) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackFeeRecipient = feeRecipient
+	})
+}
+
+// WithFallbackGasLimit sets the fallback gas limit for all validators.
+func WithFallbackGasLimit(gasLimit uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackGasLimit = gasLimit
+	})
+}
+
+// WithClientCertURL sets the URL for the client certificate when carrying out dynamic requests.
+func WithClientCertURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientCertURL = url
+	})
+}
+
+// WithClientKeyURL sets the URL for the client key when carrying out dynamic requests.
+func WithClientKeyURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientKeyURL = url
+	})
+}
+
+// WithCACertURL sets the URL for the CA certificate when carrying out dynamic requests.
+func WithCACertURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.caCertURL = url
+	})
+}
+
+// WithVerificationKeyURL sets the URL for the public key used to verify the
+// execution configuration.
+func WithVerificationKeyURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verificationKeyURL = url
+	})
+}
+
+// WithSignatureURL sets the URL for the detached signature of the execution
+// configuration.
+func WithSignatureURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signatureURL = url
+	})
+}
+
+// WithValidatingAccountsProvider sets the validating accounts provider.
+func WithValidatingAccountsProvider(provider accountmanager.ValidatingAccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatingAccountsProvider = provider
+	})
+}
+
+// WithFeeRecipientsFile sets the path of a local file holding per-validator fee recipients.
+func WithFeeRecipientsFile(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.feeRecipientsFile = path
+	})
+}
+
+// WithFeeRecipientsReloadInterval sets the interval at which the fee recipients file is reloaded.
+func WithFeeRecipientsReloadInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.feeRecipientsReloadInterval = interval
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:                    zerolog.GlobalLevel(),
+		feeRecipientsReloadInterval: time.Minute,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.majordomo == nil {
+		return nil, errors.New("no majordomo specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chaintime specified")
+	}
+	if parameters.fallbackFeeRecipient.IsZero() {
+		return nil, errors.New("no fallback fee recipient specified")
+	}
+	if parameters.fallbackGasLimit == 0 {
+		return nil, errors.New("no fallback gas limit specified")
+	}
+	if parameters.validatingAccountsProvider == nil {
+		return nil, errors.New("no validating accounts provider specified")
+	}
+	// fee recipients file can be empty.
+	if parameters.feeRecipientsFile != "" && parameters.feeRecipientsReloadInterval <= 0 {
+		return nil, errors.New("fee recipients reload interval must be positive")
+	}
+	// config URL can be empty.
+
+	return &parameters, nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	v2 "github.com/attestantio/vouch/services/blockrelay/v2"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-majordomo"
+)
+
+// Service provides execution configuration for validators that only propose
+// locally built blocks.  It does not contact relays, does not submit validator
+// registrations and does not run a block relay API server.
+type Service struct {
+	majordomo                  majordomo.Service
+	chainTime                  chaintime.Service
+	source                     *blockrelay.ExecutionConfigSource
+	fallbackFeeRecipient       bellatrix.ExecutionAddress
+	fallbackGasLimit           uint64
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+
+	executionConfig   blockrelay.ExecutionConfigurator
+	executionConfigMu sync.RWMutex
+	proposerOverrides *blockrelay.ProposerConfigOverrides
+
+	feeRecipientsFile           *blockrelay.FeeRecipientsFile
+	feeRecipientsReloadInterval time.Duration
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new local block relay service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "blockrelay").Str("impl", "local").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		majordomo: parameters.majordomo,
+		chainTime: parameters.chainTime,
+		source: &blockrelay.ExecutionConfigSource{
+			URL:                parameters.configURL,
+			ClientCertURL:      parameters.clientCertURL,
+			ClientKeyURL:       parameters.clientKeyURL,
+			CACertURL:          parameters.caCertURL,
+			VerificationKeyURL: parameters.verificationKeyURL,
+			SignatureURL:       parameters.signatureURL,
+		},
+		fallbackFeeRecipient:       parameters.fallbackFeeRecipient,
+		fallbackGasLimit:           parameters.fallbackGasLimit,
+		proposerOverrides:          blockrelay.NewProposerConfigOverrides(),
+		validatingAccountsProvider: parameters.validatingAccountsProvider,
+		executionConfig:            &v2.ExecutionConfig{Version: 2},
+	}
+	if parameters.feeRecipientsFile != "" {
+		s.feeRecipientsFile = blockrelay.NewFeeRecipientsFile(parameters.feeRecipientsFile)
+		s.feeRecipientsReloadInterval = parameters.feeRecipientsReloadInterval
+		if err := s.startFeeRecipientsFile(ctx, parameters.scheduler); err != nil {
+			return nil, err
+		}
+	}
+
+	// Carry out initial fetch of execution configuration.
+	// Need to run this inline, as other modules need this information.
+	s.fetchExecutionConfig(ctx, nil)
+
+	// Periodically fetch the execution configuration.
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Fetch execution configuration",
+		"Fetch execution configuration",
+		s.fetchExecutionConfigRuntime,
+		nil,
+		s.fetchExecutionConfig,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start execution config fetcher")
+	}
+
+	log.Info().Msg("Block relay disabled; only locally built blocks will be proposed")
+
+	return s, nil
+}
@@ -0,0 +1,321 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay/local"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	fileconfidant "github.com/wealdtech/go-majordomo/confidants/file"
+	standardmajordomo "github.com/wealdtech/go-majordomo/standard"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	fileConfidant, err := fileconfidant.New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, majordomoSvc.RegisterConfidant(ctx, fileConfidant))
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	scheduler := mockscheduler.New()
+	fallbackFeeRecipient := bellatrix.ExecutionAddress{0x01}
+	fallbackGasLimit := uint64(30000000)
+
+	tests := []struct {
+		name   string
+		params []local.Parameter
+		err    string
+	}{
+		{
+			name: "MajordomoMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+			},
+			err: "problem with parameters: no majordomo specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "FallbackFeeRecipientMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+			},
+			err: "problem with parameters: no fallback fee recipient specified",
+		},
+		{
+			name: "FallbackGasLimitMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+			},
+			err: "problem with parameters: no fallback gas limit specified",
+		},
+		{
+			name: "ValidatingAccountsProviderMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+			},
+			err: "problem with parameters: no validating accounts provider specified",
+		},
+		{
+			name: "FeeRecipientsReloadIntervalZero",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+				local.WithFeeRecipientsFile("fee-recipients.yaml"),
+				local.WithFeeRecipientsReloadInterval(0),
+			},
+			err: "problem with parameters: fee recipients reload interval must be positive",
+		},
+		{
+			name: "FeeRecipientsFileMissing",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+				local.WithFeeRecipientsFile("/nonexistent/fee-recipients.yaml"),
+			},
+			err: "failed to load fee recipients file: failed to read fee recipients file: open /nonexistent/fee-recipients.yaml: no such file or directory",
+		},
+		{
+			name: "Good",
+			params: []local.Parameter{
+				local.WithLogLevel(zerolog.Disabled),
+				local.WithMajordomo(majordomoSvc),
+				local.WithScheduler(scheduler),
+				local.WithChainTime(chainTime),
+				local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				local.WithFallbackGasLimit(fallbackGasLimit),
+				local.WithValidatingAccountsProvider(validatingAccountsProvider),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := local.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProposerConfig(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), scratch.New(), keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account1, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account 1", []byte("pass"))
+	require.NoError(t, err)
+	account2, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account 2", []byte("pass"))
+	require.NoError(t, err)
+	pubkey1 := phase0.BLSPubKey(account1.PublicKey().Marshal())
+	pubkey2 := phase0.BLSPubKey(account2.PublicKey().Marshal())
+
+	configFile := filepath.Join(t.TempDir(), "execconfig.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(`{"version":2,"relays":{"https://relay1.com/":{}},"proposers":[{"proposer":"%#x","fee_recipient":"0x0202020202020202020202020202020202020202"}]}`, pubkey1[:])), 0o600))
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	fileConfidant, err := fileconfidant.New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, majordomoSvc.RegisterConfidant(ctx, fileConfidant))
+
+	fallbackFeeRecipient := bellatrix.ExecutionAddress{0x01}
+	s, err := local.New(ctx,
+		local.WithLogLevel(zerolog.Disabled),
+		local.WithMajordomo(majordomoSvc),
+		local.WithScheduler(mockscheduler.New()),
+		local.WithChainTime(chainTime),
+		local.WithConfigURL(fmt.Sprintf("file://%s", configFile)),
+		local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+		local.WithFallbackGasLimit(30000000),
+		local.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+	)
+	require.NoError(t, err)
+
+	// Proposer-specific fee recipient, no relays.
+	res, err := s.ProposerConfig(ctx, account1, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, &beaconblockproposer.ProposerConfig{
+		FeeRecipient: bellatrix.ExecutionAddress{0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02},
+		Relays:       []*beaconblockproposer.RelayConfig{},
+	}, res)
+
+	// Fallback fee recipient, no relays.
+	res, err = s.ProposerConfig(ctx, account2, pubkey2)
+	require.NoError(t, err)
+	require.Equal(t, &beaconblockproposer.ProposerConfig{
+		FeeRecipient: fallbackFeeRecipient,
+		Relays:       []*beaconblockproposer.RelayConfig{},
+	}, res)
+}
+
+func TestProposerConfigFeeRecipientsFile(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), scratch.New(), keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account1, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account 1", []byte("pass"))
+	require.NoError(t, err)
+	account2, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account 2", []byte("pass"))
+	require.NoError(t, err)
+	pubkey1 := phase0.BLSPubKey(account1.PublicKey().Marshal())
+	pubkey2 := phase0.BLSPubKey(account2.PublicKey().Marshal())
+
+	configFile := filepath.Join(t.TempDir(), "execconfig.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(`{"version":2,"proposers":[{"proposer":"%#x","fee_recipient":"0x0202020202020202020202020202020202020202"}]}`, pubkey1[:])), 0o600))
+	feeRecipientsFile := filepath.Join(t.TempDir(), "fee-recipients.yaml")
+	require.NoError(t, os.WriteFile(feeRecipientsFile, []byte(fmt.Sprintf("'%#x': '0x0303030303030303030303030303030303030303'\n", pubkey1[:])), 0o600))
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	fileConfidant, err := fileconfidant.New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, majordomoSvc.RegisterConfidant(ctx, fileConfidant))
+
+	fallbackFeeRecipient := bellatrix.ExecutionAddress{0x01}
+	s, err := local.New(ctx,
+		local.WithLogLevel(zerolog.Disabled),
+		local.WithMajordomo(majordomoSvc),
+		local.WithScheduler(mockscheduler.New()),
+		local.WithChainTime(chainTime),
+		local.WithConfigURL(fmt.Sprintf("file://%s", configFile)),
+		local.WithFallbackFeeRecipient(fallbackFeeRecipient),
+		local.WithFallbackGasLimit(30000000),
+		local.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+		local.WithFeeRecipientsFile(feeRecipientsFile),
+	)
+	require.NoError(t, err)
+
+	// Fee recipients file overrides the execution configuration.
+	res, err := s.ProposerConfig(ctx, account1, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, bellatrix.ExecutionAddress{0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03}, res.FeeRecipient)
+
+	// Validators not in the fee recipients file are unaffected.
+	res, err = s.ProposerConfig(ctx, account2, pubkey2)
+	require.NoError(t, err)
+	require.Equal(t, fallbackFeeRecipient, res.FeeRecipient)
+
+	// Runtime overrides take precedence over the fee recipients file.
+	s.SetFeeRecipientOverride(ctx, pubkey1, bellatrix.ExecutionAddress{0x04})
+	res, err = s.ProposerConfig(ctx, account1, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, bellatrix.ExecutionAddress{0x04}, res.FeeRecipient)
+}
@@ -0,0 +1,96 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+)
+
+// ProposerConfigOverrides holds per-validator overrides of proposer configuration
+// that are set at runtime.  Overrides are held in memory only, and take precedence
+// over the execution configuration.
+type ProposerConfigOverrides struct {
+	mu            sync.RWMutex
+	feeRecipients map[phase0.BLSPubKey]bellatrix.ExecutionAddress
+	gasLimits     map[phase0.BLSPubKey]uint64
+}
+
+// NewProposerConfigOverrides creates an empty set of proposer configuration overrides.
+func NewProposerConfigOverrides() *ProposerConfigOverrides {
+	return &ProposerConfigOverrides{
+		feeRecipients: make(map[phase0.BLSPubKey]bellatrix.ExecutionAddress),
+		gasLimits:     make(map[phase0.BLSPubKey]uint64),
+	}
+}
+
+// SetFeeRecipient sets the fee recipient override for the given validator.
+func (o *ProposerConfigOverrides) SetFeeRecipient(pubkey phase0.BLSPubKey, feeRecipient bellatrix.ExecutionAddress) {
+	o.mu.Lock()
+	o.feeRecipients[pubkey] = feeRecipient
+	o.mu.Unlock()
+}
+
+// ClearFeeRecipient removes the fee recipient override for the given validator.
+func (o *ProposerConfigOverrides) ClearFeeRecipient(pubkey phase0.BLSPubKey) {
+	o.mu.Lock()
+	delete(o.feeRecipients, pubkey)
+	o.mu.Unlock()
+}
+
+// SetGasLimit sets the gas limit override for the given validator.
+func (o *ProposerConfigOverrides) SetGasLimit(pubkey phase0.BLSPubKey, gasLimit uint64) {
+	o.mu.Lock()
+	o.gasLimits[pubkey] = gasLimit
+	o.mu.Unlock()
+}
+
+// ClearGasLimit removes the gas limit override for the given validator.
+func (o *ProposerConfigOverrides) ClearGasLimit(pubkey phase0.BLSPubKey) {
+	o.mu.Lock()
+	delete(o.gasLimits, pubkey)
+	o.mu.Unlock()
+}
+
+// GasLimit returns the gas limit override for the given validator, if present.
+func (o *ProposerConfigOverrides) GasLimit(pubkey phase0.BLSPubKey) (uint64, bool) {
+	o.mu.RLock()
+	gasLimit, exists := o.gasLimits[pubkey]
+	o.mu.RUnlock()
+
+	return gasLimit, exists
+}
+
+// Apply applies any overrides for the given validator to the proposer configuration.
+// A fee recipient override replaces the fee recipient for the proposer and all of its relays;
+// a gas limit override replaces the gas limit for all of its relays.
+func (o *ProposerConfigOverrides) Apply(pubkey phase0.BLSPubKey, config *beaconblockproposer.ProposerConfig) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if feeRecipient, exists := o.feeRecipients[pubkey]; exists {
+		config.FeeRecipient = feeRecipient
+		for _, relay := range config.Relays {
+			relay.FeeRecipient = feeRecipient
+		}
+	}
+	if gasLimit, exists := o.gasLimits[pubkey]; exists {
+		for _, relay := range config.Relays {
+			relay.GasLimit = gasLimit
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrelay_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposerConfigOverrides(t *testing.T) {
+	pubkey := phase0.BLSPubKey{0x01}
+	otherPubkey := phase0.BLSPubKey{0x02}
+	feeRecipient := bellatrix.ExecutionAddress{0x01}
+	overrideFeeRecipient := bellatrix.ExecutionAddress{0x02}
+
+	config := func() *beaconblockproposer.ProposerConfig {
+		return &beaconblockproposer.ProposerConfig{
+			FeeRecipient: feeRecipient,
+			Relays: []*beaconblockproposer.RelayConfig{
+				{
+					Address:      "https://relay1.example.com/",
+					FeeRecipient: feeRecipient,
+					GasLimit:     30000000,
+				},
+				{
+					Address:      "https://relay2.example.com/",
+					FeeRecipient: bellatrix.ExecutionAddress{0x03},
+					GasLimit:     30000000,
+				},
+			},
+		}
+	}
+
+	overrides := blockrelay.NewProposerConfigOverrides()
+
+	// No overrides.
+	proposerConfig := config()
+	overrides.Apply(pubkey, proposerConfig)
+	require.Equal(t, config(), proposerConfig)
+	_, exists := overrides.GasLimit(pubkey)
+	require.False(t, exists)
+
+	// Overrides set.
+	overrides.SetFeeRecipient(pubkey, overrideFeeRecipient)
+	overrides.SetGasLimit(pubkey, 36000000)
+	proposerConfig = config()
+	overrides.Apply(pubkey, proposerConfig)
+	require.Equal(t, overrideFeeRecipient, proposerConfig.FeeRecipient)
+	for _, relay := range proposerConfig.Relays {
+		require.Equal(t, overrideFeeRecipient, relay.FeeRecipient)
+		require.Equal(t, uint64(36000000), relay.GasLimit)
+	}
+	gasLimit, exists := overrides.GasLimit(pubkey)
+	require.True(t, exists)
+	require.Equal(t, uint64(36000000), gasLimit)
+
+	// Overrides do not affect other validators.
+	proposerConfig = config()
+	overrides.Apply(otherPubkey, proposerConfig)
+	require.Equal(t, config(), proposerConfig)
+
+	// Overrides cleared.
+	overrides.ClearFeeRecipient(pubkey)
+	overrides.ClearGasLimit(pubkey)
+	proposerConfig = config()
+	overrides.Apply(pubkey, proposerConfig)
+	require.Equal(t, config(), proposerConfig)
+}
@@ -16,6 +16,8 @@ package blockrelay
 import (
 	"context"
 
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -47,3 +49,44 @@ type ExecutionConfigProvider interface {
 		error,
 	)
 }
+
+// ValidatorRegistrationsPreviewer is the interface for previewing validator registrations.
+type ValidatorRegistrationsPreviewer interface {
+	Service
+
+	// ValidatorRegistrationsPreview returns the validator registrations that would be
+	// submitted to each relay for the given validator, keyed by relay address.
+	ValidatorRegistrationsPreview(ctx context.Context,
+		account e2wtypes.Account,
+		pubkey phase0.BLSPubKey,
+	) (
+		map[string]*apiv1.ValidatorRegistration,
+		error,
+	)
+}
+
+// ProposerConfigOverrider is the interface for overriding proposer configuration at runtime.
+type ProposerConfigOverrider interface {
+	Service
+
+	// GasLimit returns the gas limit for the given validator.
+	GasLimit(ctx context.Context,
+		account e2wtypes.Account,
+		pubkey phase0.BLSPubKey,
+	) (
+		uint64,
+		error,
+	)
+
+	// SetFeeRecipientOverride overrides the fee recipient for the given validator.
+	SetFeeRecipientOverride(ctx context.Context, pubkey phase0.BLSPubKey, feeRecipient bellatrix.ExecutionAddress)
+
+	// ClearFeeRecipientOverride removes any fee recipient override for the given validator.
+	ClearFeeRecipientOverride(ctx context.Context, pubkey phase0.BLSPubKey)
+
+	// SetGasLimitOverride overrides the gas limit for the given validator.
+	SetGasLimitOverride(ctx context.Context, pubkey phase0.BLSPubKey, gasLimit uint64)
+
+	// ClearGasLimitOverride removes any gas limit override for the given validator.
+	ClearGasLimitOverride(ctx context.Context, pubkey phase0.BLSPubKey)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/attestantio/go-block-relay/services/blockauctioneer"
 	builderspec "github.com/attestantio/go-builder-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -44,11 +45,15 @@ func (s *Service) AuctionBlock(ctx context.Context,
 		return nil, errors.New("no account found for public key")
 	}
 	s.executionConfigMu.RLock()
-	proposerConfig, err := s.executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit)
+	proposerConfig, err := s.executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit, s.fallbackMinValue)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain proposer configuration")
 	}
 	s.executionConfigMu.RUnlock()
+	if s.feeRecipientsFile != nil {
+		s.feeRecipientsFile.Apply(pubkey, proposerConfig)
+	}
+	s.proposerOverrides.Apply(pubkey, proposerConfig)
 
 	if len(proposerConfig.Relays) == 0 {
 		log.Trace().Msg("No relays in proposer configuration")
@@ -71,29 +76,12 @@ func (s *Service) AuctionBlock(ctx context.Context,
 		s.builderBidsCacheMu.Unlock()
 	}
 
-	selectedProviders := make(map[string]struct{})
-	for _, provider := range res.Providers {
-		selectedProviders[strings.ToLower(provider.Address())] = struct{}{}
-	}
-
 	if res.Bid != nil {
 		val, err := res.Bid.Value()
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to obtain bid value")
 		} else {
-			// Update metrics.
-			for provider, value := range res.Values {
-				delta := new(big.Int).Sub(val.ToBig(), value)
-				_, isSelected := selectedProviders[strings.ToLower(provider)]
-				if !isSelected {
-					monitorBuilderBidDelta(provider, delta)
-				}
-				if s.logResults {
-					log.Info().Uint64("slot", uint64(slot)).Str("provider", provider).Stringer("value", value).Stringer("delta", delta).Bool("selected", isSelected).Msg("Auction participant")
-				} else {
-					log.Trace().Uint64("slot", uint64(slot)).Str("provider", provider).Stringer("value", value).Stringer("delta", delta).Bool("selected", isSelected).Msg("Auction participant")
-				}
-			}
+			selectedProviders := s.monitorAuctionResults(slot, res, val)
 
 			// Add result to trace.
 			// Has to be a string due to the potential size being >maxint64.
@@ -108,3 +96,30 @@ func (s *Service) AuctionBlock(ctx context.Context,
 
 	return res, nil
 }
+
+// monitorAuctionResults updates metrics and logs for the participants in an auction
+// with the given winning value, returning the addresses of the selected providers.
+func (s *Service) monitorAuctionResults(slot phase0.Slot,
+	res *blockauctioneer.Results,
+	val *uint256.Int,
+) map[string]struct{} {
+	selectedProviders := make(map[string]struct{})
+	for _, provider := range res.Providers {
+		selectedProviders[strings.ToLower(provider.Address())] = struct{}{}
+	}
+
+	for provider, value := range res.Values {
+		delta := new(big.Int).Sub(val.ToBig(), value)
+		_, isSelected := selectedProviders[strings.ToLower(provider)]
+		if !isSelected {
+			monitorBuilderBidDelta(provider, delta)
+		}
+		if s.logResults {
+			log.Info().Uint64("slot", uint64(slot)).Str("provider", provider).Stringer("value", value).Stringer("delta", delta).Bool("selected", isSelected).Msg("Auction participant")
+		} else {
+			log.Trace().Uint64("slot", uint64(slot)).Str("provider", provider).Stringer("value", value).Stringer("delta", delta).Bool("selected", isSelected).Msg("Auction participant")
+		}
+	}
+
+	return selectedProviders
+}
@@ -0,0 +1,152 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"go.opentelemetry.io/otel"
+)
+
+// sampleAuctionRuntime runs the auction sampler at the start of each slot.
+func (s *Service) sampleAuctionRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return s.chainTime.StartOfSlot(s.chainTime.CurrentSlot() + 1), nil
+}
+
+// sampleAuction runs a relay auction for a sample of the slots in which our validators
+// do not propose, to keep relay statistics current.  Only headers are requested from
+// the relays; nothing is signed or unblinded.
+func (s *Service) sampleAuction(ctx context.Context,
+	_ interface{},
+) {
+	//nolint:gosec // Secure random number generation not required.
+	if rand.Float64() >= s.auctionSampleRate {
+		return
+	}
+
+	ctx, span := otel.Tracer("attestantio.vouch.services.blockrelay.standard").Start(ctx, "sampleAuction")
+	defer span.End()
+	started := time.Now()
+	slot := s.chainTime.CurrentSlot()
+	log := log.With().Uint64("slot", uint64(slot)).Logger()
+
+	sampled, err := s.runSampleAuction(ctx, slot)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to sample relay auction")
+		monitorAuctionSample(time.Since(started), false)
+		return
+	}
+	if sampled {
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Sampled relay auction")
+		monitorAuctionSample(time.Since(started), true)
+	}
+}
+
+// runSampleAuction runs a relay auction for the given slot, returning false if the slot
+// was not suitable for sampling.
+func (s *Service) runSampleAuction(ctx context.Context,
+	slot phase0.Slot,
+) (
+	bool,
+	error,
+) {
+	epoch := s.chainTime.SlotToEpoch(slot)
+
+	// Relays only provide bids for the proposer of the slot, so find it.
+	dutiesResponse, err := s.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain proposer duties")
+	}
+	var proposer phase0.BLSPubKey
+	found := false
+	for _, duty := range dutiesResponse.Data {
+		if duty.Slot == slot {
+			proposer = duty.PubKey
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false, errors.New("no proposer duty for slot")
+	}
+	if _, err := s.accountsProvider.AccountByPublicKey(ctx, proposer); err == nil {
+		// One of our validators is proposing, so the auction will happen regardless.
+		return false, nil
+	}
+
+	// Use the relays that our validators would use.
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpoch(ctx, epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain validating accounts")
+	}
+	var account e2wtypes.Account
+	for _, validatingAccount := range accounts {
+		account = validatingAccount
+		break
+	}
+	if account == nil {
+		return false, nil
+	}
+	var pubkey phase0.BLSPubKey
+	if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		copy(pubkey[:], provider.CompositePublicKey().Marshal())
+	} else {
+		copy(pubkey[:], account.PublicKey().Marshal())
+	}
+	proposerConfig, err := s.ProposerConfig(ctx, account, pubkey)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain proposer configuration")
+	}
+	if len(proposerConfig.Relays) == 0 {
+		return false, nil
+	}
+
+	blockResponse, err := s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: "head",
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain head block")
+	}
+	parentHash, err := blockResponse.Data.ExecutionBlockHash()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain execution block hash of head")
+	}
+
+	res, err := s.builderBidProvider.BuilderBid(ctx, slot, parentHash, proposer, proposerConfig, s.excludedBuilders)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain builder bid")
+	}
+	if res.Bid != nil {
+		val, err := res.Bid.Value()
+		if err != nil {
+			return false, errors.Wrap(err, "failed to obtain bid value")
+		}
+		s.monitorAuctionResults(slot, res, val)
+	}
+
+	return true, nil
+}
@@ -16,15 +16,11 @@ package standard
 import (
 	"bytes"
 	"context"
-	"fmt"
-	"net/http"
-	"strings"
 	"time"
 
 	"github.com/attestantio/vouch/services/blockrelay"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
-	httpconfidant "github.com/wealdtech/go-majordomo/confidants/http"
 )
 
 // fetchExecutionConfigRuntime sets the runtime for the next execution configuration call.
@@ -118,55 +114,30 @@ func (s *Service) obtainExecutionConfig(ctx context.Context,
 ) {
 	log.Trace().Msg("Obtaining execution configuration")
 
-	var res []byte
-	var err error
-	if !strings.HasPrefix(s.configURL, "http") {
-		// We are fetching from a static source.
-		res, err = s.majordomo.Fetch(ctx, s.configURL)
-	} else {
-		// We are fetching from a dynamic source, need to provide additional parameters.
-		if len(pubkeys) == 0 {
-			// No results, but no error.
-			log.Trace().Msg("no public keys supplied; cannot fetch execution configuation")
-			return nil, nil
-		}
-
-		if s.clientCertURL != "" {
-			certPEMBlock, err := s.majordomo.Fetch(ctx, s.clientCertURL)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to obtain client certificate")
-			}
-			ctx = context.WithValue(ctx, &httpconfidant.ClientCert{}, certPEMBlock)
-			keyPEMBlock, err := s.majordomo.Fetch(ctx, s.clientKeyURL)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to obtain client key")
-			}
-			ctx = context.WithValue(ctx, &httpconfidant.ClientKey{}, keyPEMBlock)
-			var caPEMBlock []byte
-			if s.caCertURL != "" {
-				caPEMBlock, err = s.majordomo.Fetch(ctx, s.caCertURL)
-				if err != nil {
-					return nil, errors.Wrap(err, "failed to obtain client CA certificate")
-				}
-				ctx = context.WithValue(ctx, &httpconfidant.CACert{}, caPEMBlock)
-			}
-		}
-
-		ctx = context.WithValue(ctx, &httpconfidant.HTTPMethod{}, http.MethodPost)
-		pubkeyStrs := make([]string, 0, len(pubkeys))
-		for _, pubkey := range pubkeys {
-			pubkeyStrs = append(pubkeyStrs, fmt.Sprintf("%#x", pubkey))
-		}
-		// skipcq: GO-R4002
-		ctx = context.WithValue(ctx, &httpconfidant.Body{}, []byte(fmt.Sprintf(`["%s"]`, strings.Join(pubkeyStrs, `","`))))
-
-		res, err = s.majordomo.Fetch(ctx, s.configURL)
-	}
+	res, err := blockrelay.FetchExecutionConfig(ctx, s.majordomo, &blockrelay.ExecutionConfigSource{
+		URL:                s.configURL,
+		ClientCertURL:      s.clientCertURL,
+		ClientKeyURL:       s.clientKeyURL,
+		CACertURL:          s.caCertURL,
+		VerificationKeyURL: s.verificationKeyURL,
+		SignatureURL:       s.signatureURL,
+	}, pubkeys)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain execution configuration")
+		return nil, err
+	}
+	if res == nil {
+		// No results, but no error.
+		log.Trace().Msg("no public keys supplied; cannot fetch execution configuation")
+		return nil, nil
 	}
 
-	log.Trace().RawJSON("res", bytes.ReplaceAll(res, []byte("\n"), []byte(""))).Msg("Received response")
+	if e := log.Trace(); e.Enabled() {
+		if len(res) > maxTraceJSONSize {
+			e.Int("size", len(res)).Bool("summarised", true).Msg("Received response")
+		} else {
+			e.RawJSON("res", bytes.ReplaceAll(res, []byte("\n"), []byte(""))).Msg("Received response")
+		}
+	}
 
 	executionConfig, err := blockrelay.UnmarshalJSON(res)
 	if err != nil {
@@ -23,6 +23,8 @@ import (
 )
 
 var (
+	auctionSampleCounter             *prometheus.CounterVec
+	auctionSampleTimer               prometheus.Histogram
 	builderBidCounter                *prometheus.CounterVec
 	builderBidTimer                  prometheus.Histogram
 	builderBidDeltas                 *prometheus.HistogramVec
@@ -30,6 +32,9 @@ var (
 	executionConfigTimer             prometheus.Histogram
 	validatorRegistrationsCounter    *prometheus.CounterVec
 	validatorRegistrationsGeneration *prometheus.CounterVec
+	validatorRegistrationsPending    prometheus.Gauge
+	validatorRegistrationsRelays     *prometheus.CounterVec
+	validatorRegistrationsRetries    *prometheus.CounterVec
 	validatorRegistrationsTimer      prometheus.Histogram
 )
 
@@ -116,6 +121,34 @@ func registerPrometheusMetrics(_ context.Context) error {
 		return err
 	}
 
+	auctionSampleCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_auction_sample",
+		Name:      "total",
+		Help:      "The number of sampled relay auctions",
+	}, []string{"result"})
+	if err := prometheus.Register(auctionSampleCounter); err != nil {
+		return err
+	}
+	auctionSampleCounter.WithLabelValues("succeeded").Add(0)
+	auctionSampleCounter.WithLabelValues("failed").Add(0)
+
+	auctionSampleTimer = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_auction_sample",
+		Name:      "duration_seconds",
+		Help:      "The time vouch spends in sampled relay auctions.",
+		Buckets: []float64{
+			0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0,
+			1.1, 1.2, 1.3, 1.4, 1.5, 1.6, 1.7, 1.8, 1.9, 2.0,
+			2.1, 2.2, 2.3, 2.4, 2.5, 2.6, 2.7, 2.8, 2.9, 3.0,
+			3.1, 3.2, 3.3, 3.4, 3.5, 3.6, 3.7, 3.8, 3.9, 4.0,
+		},
+	})
+	if err := prometheus.Register(auctionSampleTimer); err != nil {
+		return err
+	}
+
 	validatorRegistrationsTimer = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: "vouch",
 		Subsystem: "relay_validator_registrations",
@@ -154,6 +187,38 @@ func registerPrometheusMetrics(_ context.Context) error {
 	validatorRegistrationsCounter.WithLabelValues("succeeded").Add(0)
 	validatorRegistrationsCounter.WithLabelValues("failed").Add(0)
 
+	validatorRegistrationsRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_validator_registrations",
+		Name:      "retries_total",
+		Help:      "The number of retried submissions of validator registrations to relays",
+	}, []string{"result"})
+	if err := prometheus.Register(validatorRegistrationsRetries); err != nil {
+		return err
+	}
+	validatorRegistrationsRetries.WithLabelValues("succeeded").Add(0)
+	validatorRegistrationsRetries.WithLabelValues("failed").Add(0)
+
+	validatorRegistrationsPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_validator_registrations",
+		Name:      "pending_relays",
+		Help:      "The number of relays that have yet to accept the current validator registrations",
+	})
+	if err := prometheus.Register(validatorRegistrationsPending); err != nil {
+		return err
+	}
+
+	validatorRegistrationsRelays = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_validator_registrations",
+		Name:      "relay_total",
+		Help:      "The number of validator registrations submitted to each relay",
+	}, []string{"relay", "result"})
+	if err := prometheus.Register(validatorRegistrationsRelays); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -172,6 +237,21 @@ func monitorBuilderBid(duration time.Duration, succeeded bool) {
 	}
 }
 
+// monitorAuctionSample provides metrics for a sampled relay auction.
+func monitorAuctionSample(duration time.Duration, succeeded bool) {
+	if auctionSampleTimer == nil {
+		// Not yet registered.
+		return
+	}
+
+	auctionSampleTimer.Observe(duration.Seconds())
+	if succeeded {
+		auctionSampleCounter.WithLabelValues("succeeded").Add(1)
+	} else {
+		auctionSampleCounter.WithLabelValues("failed").Add(1)
+	}
+}
+
 // monitorExecutionConfig provides metrics for an execution config operation.
 func monitorExecutionConfig(duration time.Duration, succeeded bool) {
 	if executionConfigTimer == nil {
@@ -208,6 +288,38 @@ func monitorRegistrationsGeneration(source string) {
 	validatorRegistrationsGeneration.WithLabelValues(source).Inc()
 }
 
+// monitorRegistrationRetry provides metrics for a retried submission of registrations.
+func monitorRegistrationRetry(succeeded bool) {
+	if validatorRegistrationsRetries == nil {
+		return
+	}
+	if succeeded {
+		validatorRegistrationsRetries.WithLabelValues("succeeded").Inc()
+	} else {
+		validatorRegistrationsRetries.WithLabelValues("failed").Inc()
+	}
+}
+
+// monitorPendingRegistrations provides the number of relays with pending registrations.
+func monitorPendingRegistrations(relays int) {
+	if validatorRegistrationsPending == nil {
+		return
+	}
+	validatorRegistrationsPending.Set(float64(relays))
+}
+
+// monitorRelayRegistrations provides per-relay metrics for submitted registrations.
+func monitorRelayRegistrations(relay string, registrations int, succeeded bool) {
+	if validatorRegistrationsRelays == nil {
+		return
+	}
+	if succeeded {
+		validatorRegistrationsRelays.WithLabelValues(relay, "succeeded").Add(float64(registrations))
+	} else {
+		validatorRegistrationsRelays.WithLabelValues(relay, "failed").Add(float64(registrations))
+	}
+}
+
 // monitorBuilderBidDelta provides builder bid deltas for blocks.
 func monitorBuilderBidDelta(source string, delta *big.Int) {
 	if builderBidDeltas == nil {
@@ -16,6 +16,7 @@ package standard
 import (
 	"bytes"
 	"net"
+	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -28,6 +29,7 @@ import (
 	"github.com/attestantio/vouch/strategies/builderbid"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 	"github.com/wealdtech/go-majordomo"
 )
 
@@ -41,9 +43,12 @@ type parameters struct {
 	configURL                                 string
 	fallbackFeeRecipient                      bellatrix.ExecutionAddress
 	fallbackGasLimit                          uint64
+	fallbackMinValue                          decimal.Decimal
 	clientCertURL                             string
 	clientKeyURL                              string
 	caCertURL                                 string
+	verificationKeyURL                        string
+	signatureURL                              string
 	accountsProvider                          accountmanager.AccountsProvider
 	validatingAccountsProvider                accountmanager.ValidatingAccountsProvider
 	validatorRegistrationSigner               signer.ValidatorRegistrationSigner
@@ -52,6 +57,15 @@ type parameters struct {
 	releaseVersion                            string
 	builderBidProvider                        builderbid.Provider
 	excludedBuilders                          []phase0.BLSPubKey
+	excludedProposers                         []phase0.BLSPubKey
+	auctionSampleRate                         float64
+	proposerDutiesProvider                    consensusclient.ProposerDutiesProvider
+	signedBeaconBlockProvider                 consensusclient.SignedBeaconBlockProvider
+	feeRecipientsFile                         string
+	feeRecipientsReloadInterval               time.Duration
+	registrationRetryInterval                 time.Duration
+	registrationBatchSize                     int
+	registrationBatchInterval                 time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -128,6 +142,13 @@ func WithFallbackGasLimit(gasLimit uint64) Parameter {
 	})
 }
 
+// WithFallbackMinValue sets the fallback minimum value, in wei, of relay bids for all validators.
+func WithFallbackMinValue(minValue decimal.Decimal) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackMinValue = minValue
+	})
+}
+
 // WithClientCertURL sets the URL for the client certificate when carrying out dynamic requests.
 func WithClientCertURL(url string) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -149,6 +170,22 @@ func WithCACertURL(url string) Parameter {
 	})
 }
 
+// WithVerificationKeyURL sets the URL for the public key used to verify the
+// execution configuration.
+func WithVerificationKeyURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verificationKeyURL = url
+	})
+}
+
+// WithSignatureURL sets the URL for the detached signature of the execution
+// configuration.
+func WithSignatureURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signatureURL = url
+	})
+}
+
 // WithAccountsProvider sets the accounts provider.
 func WithAccountsProvider(provider accountmanager.AccountsProvider) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -205,13 +242,80 @@ func WithExcludedBuilders(builders []phase0.BLSPubKey) Parameter {
 	})
 }
 
+// WithExcludedProposers is the list of validators for which validator registrations will not be submitted.
+func WithExcludedProposers(proposers []phase0.BLSPubKey) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.excludedProposers = proposers
+	})
+}
+
 // zeroExecutionAddress is used for comparison purposes.
 var zeroExecutionAddress bellatrix.ExecutionAddress
 
+// WithAuctionSampleRate sets the proportion of slots in which our validators do not
+// propose for which a relay auction is run, to keep relay statistics current.
+func WithAuctionSampleRate(rate float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.auctionSampleRate = rate
+	})
+}
+
+// WithProposerDutiesProvider sets the proposer duties provider.
+func WithProposerDutiesProvider(provider consensusclient.ProposerDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposerDutiesProvider = provider
+	})
+}
+
+// WithSignedBeaconBlockProvider sets the signed beacon block provider.
+func WithSignedBeaconBlockProvider(provider consensusclient.SignedBeaconBlockProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signedBeaconBlockProvider = provider
+	})
+}
+
+// WithFeeRecipientsFile sets the path of a local file holding per-validator fee recipients.
+func WithFeeRecipientsFile(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.feeRecipientsFile = path
+	})
+}
+
+// WithFeeRecipientsReloadInterval sets the interval at which the fee recipients file is reloaded.
+func WithFeeRecipientsReloadInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.feeRecipientsReloadInterval = interval
+	})
+}
+
+// WithRegistrationRetryInterval sets the base interval at which failed relay registrations are retried.
+func WithRegistrationRetryInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.registrationRetryInterval = interval
+	})
+}
+
+// WithRegistrationBatchSize sets the maximum number of validator registrations submitted to a relay in a single request.
+func WithRegistrationBatchSize(size int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.registrationBatchSize = size
+	})
+}
+
+// WithRegistrationBatchInterval sets the minimum interval between requests submitting validator registrations to a relay.
+func WithRegistrationBatchInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.registrationBatchInterval = interval
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:                    zerolog.GlobalLevel(),
+		feeRecipientsReloadInterval: time.Minute,
+		registrationRetryInterval:   30 * time.Second,
+		registrationBatchSize:       500,
 	}
 	for _, p := range params {
 		p.apply(&parameters)
@@ -235,6 +339,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.fallbackGasLimit == 0 {
 		return nil, errors.New("no fallback gas limit specified")
 	}
+	if parameters.fallbackMinValue.Sign() == -1 {
+		return nil, errors.New("fallback minimum value cannot be negative")
+	}
 	if parameters.accountsProvider == nil {
 		return nil, errors.New("no accounts provider specified")
 	}
@@ -257,6 +364,30 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.builderBidProvider == nil {
 		return nil, errors.New("no builder bid provider specified")
 	}
+	// fee recipients file can be empty.
+	if parameters.feeRecipientsFile != "" && parameters.feeRecipientsReloadInterval <= 0 {
+		return nil, errors.New("fee recipients reload interval must be positive")
+	}
+	if parameters.registrationRetryInterval <= 0 {
+		return nil, errors.New("registration retry interval must be positive")
+	}
+	if parameters.registrationBatchSize <= 0 {
+		return nil, errors.New("registration batch size must be positive")
+	}
+	if parameters.registrationBatchInterval < 0 {
+		return nil, errors.New("registration batch interval cannot be negative")
+	}
+	if parameters.auctionSampleRate < 0 || parameters.auctionSampleRate > 1 {
+		return nil, errors.New("auction sample rate must be between 0 and 1")
+	}
+	if parameters.auctionSampleRate > 0 {
+		if parameters.proposerDutiesProvider == nil {
+			return nil, errors.New("no proposer duties provider specified")
+		}
+		if parameters.signedBeaconBlockProvider == nil {
+			return nil, errors.New("no signed beacon block provider specified")
+		}
+	}
 
 	return &parameters, nil
 }
@@ -16,6 +16,7 @@ package standard
 import (
 	"context"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -31,12 +32,67 @@ func (s *Service) ProposerConfig(ctx context.Context,
 ) {
 	s.executionConfigMu.RLock()
 	defer s.executionConfigMu.RUnlock()
+	var proposerConfig *beaconblockproposer.ProposerConfig
 	if s.executionConfig == nil {
 		log.Warn().Msg("No execution configuration available; using fallback information")
-		return &beaconblockproposer.ProposerConfig{
+		proposerConfig = &beaconblockproposer.ProposerConfig{
 			FeeRecipient: s.fallbackFeeRecipient,
 			Relays:       make([]*beaconblockproposer.RelayConfig, 0),
-		}, nil
+		}
+	} else {
+		var err error
+		proposerConfig, err = s.executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit, s.fallbackMinValue)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return s.executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit)
+	if s.feeRecipientsFile != nil {
+		s.feeRecipientsFile.Apply(pubkey, proposerConfig)
+	}
+	s.proposerOverrides.Apply(pubkey, proposerConfig)
+
+	return proposerConfig, nil
+}
+
+// GasLimit returns the gas limit for the given validator.
+func (s *Service) GasLimit(ctx context.Context,
+	account e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+) (
+	uint64,
+	error,
+) {
+	if gasLimit, exists := s.proposerOverrides.GasLimit(pubkey); exists {
+		return gasLimit, nil
+	}
+
+	proposerConfig, err := s.ProposerConfig(ctx, account, pubkey)
+	if err != nil {
+		return 0, err
+	}
+	if len(proposerConfig.Relays) > 0 {
+		return proposerConfig.Relays[0].GasLimit, nil
+	}
+
+	return s.fallbackGasLimit, nil
+}
+
+// SetFeeRecipientOverride overrides the fee recipient for the given validator.
+func (s *Service) SetFeeRecipientOverride(_ context.Context, pubkey phase0.BLSPubKey, feeRecipient bellatrix.ExecutionAddress) {
+	s.proposerOverrides.SetFeeRecipient(pubkey, feeRecipient)
+}
+
+// ClearFeeRecipientOverride removes any fee recipient override for the given validator.
+func (s *Service) ClearFeeRecipientOverride(_ context.Context, pubkey phase0.BLSPubKey) {
+	s.proposerOverrides.ClearFeeRecipient(pubkey)
+}
+
+// SetGasLimitOverride overrides the gas limit for the given validator.
+func (s *Service) SetGasLimitOverride(_ context.Context, pubkey phase0.BLSPubKey, gasLimit uint64) {
+	s.proposerOverrides.SetGasLimit(pubkey, gasLimit)
+}
+
+// ClearGasLimitOverride removes any gas limit override for the given validator.
+func (s *Service) ClearGasLimitOverride(_ context.Context, pubkey phase0.BLSPubKey) {
+	s.proposerOverrides.ClearGasLimit(pubkey)
 }
@@ -96,6 +96,8 @@ func TestProposerConfig(t *testing.T) {
 	defer os.RemoveAll(base)
 	configFile := filepath.Join(base, "config.json")
 	require.NoError(t, os.WriteFile(configFile, []byte(`{"default_config":{"fee_recipient":"0x0200000000000000000000000000000000000000","gas_limit":"20000000","builder":{"enabled":false}}}`), 0o600))
+	perValidatorConfigFile := filepath.Join(base, "pervalidatorconfig.json")
+	require.NoError(t, os.WriteFile(perValidatorConfigFile, []byte(`{"proposer_config":{"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000":{"fee_recipient":"0x0300000000000000000000000000000000000000","gas_limit":"30000000","builder":{"enabled":true,"relays":["https://relay1.example.com/"]}}},"default_config":{"fee_recipient":"0x0200000000000000000000000000000000000000","gas_limit":"20000000","builder":{"enabled":true,"relays":["https://relay1.example.com/"]}}}`), 0o600))
 	badConfigFile := filepath.Join(base, "badconfig.json")
 	require.NoError(t, os.WriteFile(badConfigFile, []byte(`bad`), 0o600))
 
@@ -149,6 +151,25 @@ func TestProposerConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "PerValidatorGasLimit",
+			params: []standard.Parameter{
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(fmt.Sprintf("file://%s", perValidatorConfigFile)),
+				standard.WithFallbackFeeRecipient(bellatrix.ExecutionAddress{0x01}),
+				standard.WithFallbackGasLimit(10000000),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(mock.BuilderBidProvider{}),
+			},
+			proposerConfig: `{"fee_recipient":"0x0300000000000000000000000000000000000000","relays":[{"address":"https://relay1.example.com/","fee_recipient":"0x0300000000000000000000000000000000000000","gas_limit":"30000000"}]}`,
+		},
 		{
 			name: "BadFile",
 			params: []standard.Parameter{
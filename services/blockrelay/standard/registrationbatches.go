@@ -0,0 +1,93 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	"github.com/attestantio/vouch/services/metrics"
+)
+
+// submitRelayRegistrationsInBatches submits validator registrations to a
+// single relay in batches, respecting the minimum interval between batches
+// for the relay, and records the results.
+// If a batch fails the remaining batches are not submitted, and are recorded
+// as pending along with the failed batch so that they are retried later.
+func (s *Service) submitRelayRegistrationsInBatches(ctx context.Context,
+	relay string,
+	registrations []*builderapi.VersionedSignedValidatorRegistration,
+	monitor metrics.Service,
+) error {
+	batchSize := s.registrationBatchSize
+	if batchSize <= 0 {
+		batchSize = len(registrations)
+	}
+	for start := 0; start < len(registrations); start += batchSize {
+		end := start + batchSize
+		if end > len(registrations) {
+			end = len(registrations)
+		}
+		batch := registrations[start:end]
+
+		err := s.waitForRelaySubmission(ctx, relay)
+		if err == nil {
+			log.Trace().Str("relay", relay).Int("start", start).Int("registrations", len(batch)).Msg("Submitting batch of validator registrations")
+			err = s.submitRelayRegistrations(ctx, relay, batch, monitor)
+		}
+		if err != nil {
+			monitorRelayRegistrations(relay, len(batch), false)
+			s.recordRelayRegistrations(relay, registrations[start:], err)
+			return err
+		}
+		monitorRelayRegistrations(relay, len(batch), true)
+		s.recordRelayRegistrations(relay, batch, nil)
+	}
+
+	return nil
+}
+
+// waitForRelaySubmission waits until the relay can be sent another batch of
+// registrations.
+func (s *Service) waitForRelaySubmission(ctx context.Context,
+	relay string,
+) error {
+	if s.registrationBatchInterval == 0 {
+		return nil
+	}
+
+	// Reserve the next slot for the relay.
+	s.relaySubmissionsMu.Lock()
+	now := time.Now()
+	next := s.relaySubmissions[relay].Add(s.registrationBatchInterval)
+	if next.Before(now) {
+		next = now
+	}
+	s.relaySubmissions[relay] = next
+	s.relaySubmissionsMu.Unlock()
+
+	wait := time.Until(next)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// batchRelay is a relay that records the sizes of the registration batches
+// it receives, failing from a given request onwards.
+type batchRelay struct {
+	mu        sync.Mutex
+	batches   []int
+	times     []time.Time
+	failAfter int
+}
+
+func (r *batchRelay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var registrations []json.RawMessage
+	if err := json.NewDecoder(req.Body).Decode(&registrations); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, len(registrations))
+	r.times = append(r.times, time.Now())
+	if r.failAfter > 0 && len(r.batches) > r.failAfter {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestSubmitRelayRegistrationsInBatches(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Reset()
+
+	registrations := make([]*builderapi.VersionedSignedValidatorRegistration, 0, 5)
+	for i := byte(1); i <= 5; i++ {
+		registrations = append(registrations, testRegistration(phase0.BLSPubKey{i}))
+	}
+
+	tests := []struct {
+		name          string
+		batchSize     int
+		interval      time.Duration
+		failAfter     int
+		expected      []int
+		pending       int
+		succeeded     float64
+		failed        float64
+		err           bool
+		minimumSpread time.Duration
+	}{
+		{
+			name:      "Single",
+			batchSize: 10,
+			expected:  []int{5},
+			succeeded: 5,
+		},
+		{
+			name:      "Batches",
+			batchSize: 2,
+			expected:  []int{2, 2, 1},
+			succeeded: 5,
+		},
+		{
+			name:          "RateLimited",
+			batchSize:     2,
+			interval:      50 * time.Millisecond,
+			expected:      []int{2, 2, 1},
+			succeeded:     5,
+			minimumSpread: 90 * time.Millisecond,
+		},
+		{
+			name:      "Failure",
+			batchSize: 2,
+			failAfter: 1,
+			expected:  []int{2, 2},
+			pending:   3,
+			succeeded: 2,
+			failed:    2,
+			err:       true,
+		},
+	}
+
+	// Use an unregistered metric so that counts are isolated to this test.
+	defer func(relays *prometheus.CounterVec) { validatorRegistrationsRelays = relays }(validatorRegistrationsRelays)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validatorRegistrationsRelays = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "relay_registrations_total",
+			}, []string{"relay", "result"})
+			relay := &batchRelay{failAfter: test.failAfter}
+			server := httptest.NewServer(relay)
+			defer server.Close()
+
+			s := &Service{
+				registrationRetryInterval: time.Minute,
+				pendingRegistrations:      make(map[string]*pendingRelayRegistrations),
+				registrationBatchSize:     test.batchSize,
+				registrationBatchInterval: test.interval,
+				relaySubmissions:          make(map[string]time.Time),
+				releaseVersion:            "test",
+			}
+			err := s.submitRelayRegistrationsInBatches(ctx, server.URL, registrations, nullmetrics.New(ctx))
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expected, relay.batches)
+			if test.pending == 0 {
+				require.Empty(t, s.pendingRegistrations)
+			} else {
+				require.Len(t, s.pendingRegistrations[server.URL].registrations, test.pending)
+				require.Equal(t, 1, s.pendingRegistrations[server.URL].failures)
+			}
+			require.Equal(t, test.succeeded, testutil.ToFloat64(validatorRegistrationsRelays.WithLabelValues(server.URL, "succeeded")))
+			require.Equal(t, test.failed, testutil.ToFloat64(validatorRegistrationsRelays.WithLabelValues(server.URL, "failed")))
+			if test.minimumSpread > 0 {
+				require.GreaterOrEqual(t, relay.times[len(relay.times)-1].Sub(relay.times[0]), test.minimumSpread)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"time"
+
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// registrationKey identifies the registrations of a validator with a relay.
+type registrationKey struct {
+	pubkey phase0.BLSPubKey
+	relay  string
+}
+
+// latestRegistration is the registration most recently generated for a
+// validator with a relay.
+type latestRegistration struct {
+	root      phase0.Root
+	timestamp time.Time
+}
+
+// cachedValidatorRegistration returns a signed registration with the given
+// root that can be sent to the relay in place of signing a new registration,
+// or nil if there is none.
+// Relays ignore registrations older than the latest they have received for a
+// validator, so a signed registration can only be reused if it is the latest
+// for the relay or newer than the latest for the relay.
+func (s *Service) cachedValidatorRegistration(key registrationKey,
+	root phase0.Root,
+) *apiv1.SignedValidatorRegistration {
+	s.signedValidatorRegistrationsMu.RLock()
+	signedRegistration, exists := s.signedValidatorRegistrations[root]
+	s.signedValidatorRegistrationsMu.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	s.latestValidatorRegistrationsMu.RLock()
+	latest, hasLatest := s.latestValidatorRegistrations[key]
+	s.latestValidatorRegistrationsMu.RUnlock()
+
+	switch {
+	case !hasLatest:
+		// The relay has not been sent a registration for this validator.
+		return signedRegistration
+	case latest.root == root:
+		return signedRegistration
+	case signedRegistration.Message.Timestamp.After(latest.timestamp):
+		return signedRegistration
+	default:
+		return nil
+	}
+}
+
+// recordValidatorRegistration records the signed registration as the latest
+// for the validator with the relay.
+func (s *Service) recordValidatorRegistration(key registrationKey,
+	root phase0.Root,
+	signedRegistration *apiv1.SignedValidatorRegistration,
+	signed bool,
+) {
+	if signed {
+		s.signedValidatorRegistrationsMu.Lock()
+		s.signedValidatorRegistrations[root] = signedRegistration
+		s.signedValidatorRegistrationsMu.Unlock()
+	}
+
+	s.latestValidatorRegistrationsMu.Lock()
+	s.latestValidatorRegistrations[key] = &latestRegistration{
+		root:      root,
+		timestamp: signedRegistration.Message.Timestamp,
+	}
+	s.latestValidatorRegistrationsMu.Unlock()
+}
+
+// pruneValidatorRegistrations removes cached registrations for validators
+// that are no longer validating, along with signed registrations that are no
+// longer the latest for any relay.
+func (s *Service) pruneValidatorRegistrations(pubkeys map[phase0.BLSPubKey]struct{}) {
+	s.latestValidatorRegistrationsMu.Lock()
+	defer s.latestValidatorRegistrationsMu.Unlock()
+
+	roots := make(map[phase0.Root]struct{}, len(s.latestValidatorRegistrations))
+	for key, latest := range s.latestValidatorRegistrations {
+		if _, exists := pubkeys[key.pubkey]; !exists {
+			delete(s.latestValidatorRegistrations, key)
+			continue
+		}
+		roots[latest.root] = struct{}{}
+	}
+
+	s.signedValidatorRegistrationsMu.Lock()
+	defer s.signedValidatorRegistrationsMu.Unlock()
+	for root := range s.signedValidatorRegistrations {
+		if _, exists := roots[root]; !exists {
+			delete(s.signedValidatorRegistrations, root)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorRegistrationCache(t *testing.T) {
+	s := &Service{
+		latestValidatorRegistrations: make(map[registrationKey]*latestRegistration),
+		signedValidatorRegistrations: make(map[phase0.Root]*apiv1.SignedValidatorRegistration),
+	}
+
+	pubkey1 := phase0.BLSPubKey{0x01}
+	pubkey2 := phase0.BLSPubKey{0x02}
+	relay1 := registrationKey{pubkey: pubkey1, relay: "https://relay1.example.com/"}
+	relay2 := registrationKey{pubkey: pubkey1, relay: "https://relay2.example.com/"}
+	rootA := phase0.Root{0x0a}
+	rootB := phase0.Root{0x0b}
+	signedA := &apiv1.SignedValidatorRegistration{
+		Message: &apiv1.ValidatorRegistration{Pubkey: pubkey1, Timestamp: time.Unix(1000, 0)},
+	}
+	signedB := &apiv1.SignedValidatorRegistration{
+		Message: &apiv1.ValidatorRegistration{Pubkey: pubkey1, Timestamp: time.Unix(2000, 0)},
+	}
+
+	// Nothing cached.
+	require.Nil(t, s.cachedValidatorRegistration(relay1, rootA))
+
+	// Registration A signed for relay 1 is reused for both relay 1 and relay 2,
+	// as relay 2 has not been sent a registration.
+	s.recordValidatorRegistration(relay1, rootA, signedA, true)
+	require.Equal(t, signedA, s.cachedValidatorRegistration(relay1, rootA))
+	require.Equal(t, signedA, s.cachedValidatorRegistration(relay2, rootA))
+	s.recordValidatorRegistration(relay2, rootA, signedA, false)
+
+	// Relay 2 moves to registration B.
+	require.Nil(t, s.cachedValidatorRegistration(relay2, rootB))
+	s.recordValidatorRegistration(relay2, rootB, signedB, true)
+	require.Equal(t, signedB, s.cachedValidatorRegistration(relay2, rootB))
+
+	// Relay 2 cannot move back to registration A without re-signing, as it is
+	// older than registration B.
+	require.Nil(t, s.cachedValidatorRegistration(relay2, rootA))
+	// Relay 1 can still use registration A, and can move to the newer registration B.
+	require.Equal(t, signedA, s.cachedValidatorRegistration(relay1, rootA))
+	require.Equal(t, signedB, s.cachedValidatorRegistration(relay1, rootB))
+
+	// Pruning with the validator still present keeps everything referenced.
+	s.pruneValidatorRegistrations(map[phase0.BLSPubKey]struct{}{pubkey1: {}})
+	require.Len(t, s.latestValidatorRegistrations, 2)
+	require.Len(t, s.signedValidatorRegistrations, 2)
+
+	// Relay 1 moves to registration B, leaving registration A unreferenced.
+	s.recordValidatorRegistration(relay1, rootB, signedB, false)
+	s.pruneValidatorRegistrations(map[phase0.BLSPubKey]struct{}{pubkey1: {}})
+	require.Len(t, s.signedValidatorRegistrations, 1)
+	require.Nil(t, s.cachedValidatorRegistration(relay1, rootA))
+
+	// Pruning without the validator removes everything.
+	s.pruneValidatorRegistrations(map[phase0.BLSPubKey]struct{}{pubkey2: {}})
+	require.Empty(t, s.latestValidatorRegistrations)
+	require.Empty(t, s.signedValidatorRegistrations)
+}
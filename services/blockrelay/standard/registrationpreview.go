@@ -0,0 +1,77 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// ValidatorRegistrationsPreview returns the validator registrations that would be
+// submitted to each relay for the given validator, keyed by relay address.
+// The registrations are neither signed nor submitted.
+func (s *Service) ValidatorRegistrationsPreview(ctx context.Context,
+	account e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+) (
+	map[string]*apiv1.ValidatorRegistration,
+	error,
+) {
+	if _, excluded := s.excludedProposers[pubkey]; excluded {
+		return nil, errors.New("validator is excluded from proposing; no registrations would be submitted")
+	}
+	s.executionConfigMu.RLock()
+	executionConfig := s.executionConfig
+	s.executionConfigMu.RUnlock()
+	if executionConfig == nil {
+		return nil, errors.New("no execution configuration; no registrations would be submitted")
+	}
+
+	proposerConfig, err := s.ProposerConfig(ctx, account, pubkey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer configuration")
+	}
+
+	registrations := make(map[string]*apiv1.ValidatorRegistration, len(proposerConfig.Relays))
+	for _, relay := range proposerConfig.Relays {
+		registration := &apiv1.ValidatorRegistration{
+			FeeRecipient: relay.FeeRecipient,
+			GasLimit:     relay.GasLimit,
+			Pubkey:       pubkey,
+		}
+		registrationRoot, err := registration.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain hash tree root of registration")
+		}
+
+		// If a signed registration would be reused it is sent along with its timestamp.
+		registration.Timestamp = time.Now().Round(time.Second)
+		signedRegistration := s.cachedValidatorRegistration(registrationKey{
+			pubkey: pubkey,
+			relay:  relay.Address,
+		}, registrationRoot)
+		if signedRegistration != nil {
+			registration.Timestamp = signedRegistration.Message.Timestamp
+		}
+
+		registrations[relay.Address] = registration
+	}
+
+	return registrations, nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type staticExecutionConfig struct {
+	proposerConfig *beaconblockproposer.ProposerConfig
+}
+
+func (c *staticExecutionConfig) ProposerConfig(_ context.Context,
+	_ e2wtypes.Account,
+	_ phase0.BLSPubKey,
+	_ bellatrix.ExecutionAddress,
+	_ uint64,
+	_ decimal.Decimal,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	return c.proposerConfig, nil
+}
+
+func TestValidatorRegistrationsPreview(t *testing.T) {
+	ctx := context.Background()
+
+	pubkey := phase0.BLSPubKey{0x01}
+	excludedPubkey := phase0.BLSPubKey{0x02}
+	feeRecipient := bellatrix.ExecutionAddress{0x03}
+
+	s := &Service{
+		proposerOverrides:            blockrelay.NewProposerConfigOverrides(),
+		excludedProposers:            map[phase0.BLSPubKey]struct{}{excludedPubkey: {}},
+		latestValidatorRegistrations: make(map[registrationKey]*latestRegistration),
+		signedValidatorRegistrations: make(map[phase0.Root]*apiv1.SignedValidatorRegistration),
+	}
+
+	// No execution configuration.
+	_, err := s.ValidatorRegistrationsPreview(ctx, nil, pubkey)
+	require.EqualError(t, err, "no execution configuration; no registrations would be submitted")
+
+	s.executionConfig = &staticExecutionConfig{
+		proposerConfig: &beaconblockproposer.ProposerConfig{
+			FeeRecipient: feeRecipient,
+			Relays: []*beaconblockproposer.RelayConfig{
+				{
+					Address:      "https://relay1.example.com/",
+					FeeRecipient: feeRecipient,
+					GasLimit:     30000000,
+				},
+				{
+					Address:      "https://relay2.example.com/",
+					FeeRecipient: feeRecipient,
+					GasLimit:     36000000,
+				},
+			},
+		},
+	}
+
+	// Excluded validator.
+	_, err = s.ValidatorRegistrationsPreview(ctx, nil, excludedPubkey)
+	require.EqualError(t, err, "validator is excluded from proposing; no registrations would be submitted")
+
+	// New registrations.
+	registrations, err := s.ValidatorRegistrationsPreview(ctx, nil, pubkey)
+	require.NoError(t, err)
+	require.Len(t, registrations, 2)
+	require.Equal(t, feeRecipient, registrations["https://relay1.example.com/"].FeeRecipient)
+	require.Equal(t, uint64(30000000), registrations["https://relay1.example.com/"].GasLimit)
+	require.Equal(t, pubkey, registrations["https://relay1.example.com/"].Pubkey)
+	require.Equal(t, uint64(36000000), registrations["https://relay2.example.com/"].GasLimit)
+	require.WithinDuration(t, time.Now(), registrations["https://relay1.example.com/"].Timestamp, 2*time.Second)
+
+	// An existing signed registration is reused with its timestamp.
+	signedTimestamp := time.Unix(1700000000, 0)
+	registration := &apiv1.ValidatorRegistration{
+		FeeRecipient: feeRecipient,
+		GasLimit:     30000000,
+		Pubkey:       pubkey,
+	}
+	root, err := registration.HashTreeRoot()
+	require.NoError(t, err)
+	s.signedValidatorRegistrations[root] = &apiv1.SignedValidatorRegistration{
+		Message: &apiv1.ValidatorRegistration{
+			FeeRecipient: feeRecipient,
+			GasLimit:     30000000,
+			Timestamp:    signedTimestamp,
+			Pubkey:       pubkey,
+		},
+	}
+	s.latestValidatorRegistrations[registrationKey{pubkey: pubkey, relay: "https://relay1.example.com/"}] = &latestRegistration{
+		root:      root,
+		timestamp: signedTimestamp,
+	}
+	registrations, err = s.ValidatorRegistrationsPreview(ctx, nil, pubkey)
+	require.NoError(t, err)
+	require.Equal(t, signedTimestamp, registrations["https://relay1.example.com/"].Timestamp)
+	require.NotEqual(t, signedTimestamp, registrations["https://relay2.example.com/"].Timestamp)
+}
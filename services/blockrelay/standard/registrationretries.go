@@ -0,0 +1,178 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxRegistrationRetryDoublings is the maximum number of times that the
+// retry interval for a relay is doubled after consecutive failures.
+const maxRegistrationRetryDoublings = 5
+
+// pendingRelayRegistrations are registrations that a relay has yet to accept.
+type pendingRelayRegistrations struct {
+	registrations map[phase0.BLSPubKey]*builderapi.VersionedSignedValidatorRegistration
+	failures      int
+	nextAttempt   time.Time
+}
+
+// recordRelayRegistrations records the result of submitting registrations to a relay.
+func (s *Service) recordRelayRegistrations(relay string,
+	registrations []*builderapi.VersionedSignedValidatorRegistration,
+	err error,
+) {
+	s.pendingRegistrationsMu.Lock()
+	defer s.pendingRegistrationsMu.Unlock()
+	defer monitorPendingRegistrations(len(s.pendingRegistrations))
+
+	pending, exists := s.pendingRegistrations[relay]
+	if err == nil {
+		if !exists {
+			return
+		}
+		for _, registration := range registrations {
+			if registration.V1 != nil && registration.V1.Message != nil {
+				delete(pending.registrations, registration.V1.Message.Pubkey)
+			}
+		}
+		if len(pending.registrations) == 0 {
+			log.Info().Str("relay", relay).Msg("Relay has accepted all outstanding validator registrations")
+			delete(s.pendingRegistrations, relay)
+			return
+		}
+		// The relay is accepting registrations again, so retry the remainder promptly.
+		pending.failures = 0
+		pending.nextAttempt = time.Now()
+		return
+	}
+
+	if !exists {
+		pending = &pendingRelayRegistrations{
+			registrations: make(map[phase0.BLSPubKey]*builderapi.VersionedSignedValidatorRegistration),
+		}
+		s.pendingRegistrations[relay] = pending
+	}
+	for _, registration := range registrations {
+		if registration.V1 != nil && registration.V1.Message != nil {
+			pending.registrations[registration.V1.Message.Pubkey] = registration
+		}
+	}
+	pending.failures++
+	pending.nextAttempt = time.Now().Add(s.registrationRetryBackoff(pending.failures))
+}
+
+// registrationRetryBackoff returns the time to wait before retrying a relay
+// that has failed the given number of consecutive times.
+func (s *Service) registrationRetryBackoff(failures int) time.Duration {
+	backoff := s.registrationRetryInterval
+	for i := 1; i < failures && i <= maxRegistrationRetryDoublings; i++ {
+		backoff *= 2
+	}
+
+	return backoff
+}
+
+// prunePendingRegistrations drops pending registrations for relays that are
+// no longer in use.
+func (s *Service) prunePendingRegistrations(relayRegistrations map[string][]*builderapi.VersionedSignedValidatorRegistration) {
+	s.pendingRegistrationsMu.Lock()
+	defer s.pendingRegistrationsMu.Unlock()
+
+	for relay := range s.pendingRegistrations {
+		if _, exists := relayRegistrations[relay]; !exists {
+			log.Debug().Str("relay", relay).Msg("Relay no longer in use; dropping pending validator registrations")
+			delete(s.pendingRegistrations, relay)
+		}
+	}
+	monitorPendingRegistrations(len(s.pendingRegistrations))
+}
+
+// dueRegistrations returns the pending registrations for relays that are due a retry.
+func (s *Service) dueRegistrations(now time.Time) map[string][]*builderapi.VersionedSignedValidatorRegistration {
+	s.pendingRegistrationsMu.Lock()
+	defer s.pendingRegistrationsMu.Unlock()
+
+	due := make(map[string][]*builderapi.VersionedSignedValidatorRegistration)
+	for relay, pending := range s.pendingRegistrations {
+		if pending.nextAttempt.After(now) {
+			continue
+		}
+		registrations := make([]*builderapi.VersionedSignedValidatorRegistration, 0, len(pending.registrations))
+		for _, registration := range pending.registrations {
+			registrations = append(registrations, registration)
+		}
+		due[relay] = registrations
+	}
+
+	return due
+}
+
+func (s *Service) retryValidatorRegistrationsRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return time.Now().Add(s.registrationRetryInterval), nil
+}
+
+// retryValidatorRegistrations resubmits registrations to relays that have
+// previously failed to accept them.
+func (s *Service) retryValidatorRegistrations(ctx context.Context,
+	_ interface{},
+) {
+	// Do not run alongside a full submission, which will update the pending registrations itself.
+	if !s.activitySem.TryAcquire(1) {
+		log.Trace().Msg("Validator registration submission in progress; skipping retry")
+		return
+	}
+	defer s.activitySem.Release(1)
+
+	due := s.dueRegistrations(time.Now())
+	if len(due) == 0 {
+		return
+	}
+
+	ctx, span := otel.Tracer("attestantio.vouch.services.blockrelay.standard").Start(ctx, "retryValidatorRegistrations")
+	defer span.End()
+
+	var wg sync.WaitGroup
+	for relay, registrations := range due {
+		wg.Add(1)
+		go func(ctx context.Context, relay string, registrations []*builderapi.VersionedSignedValidatorRegistration) {
+			defer wg.Done()
+			ctx, span := otel.Tracer("attestantio.vouch.services.blockrelay.standard").Start(ctx, "(retry relay registrations)", trace.WithAttributes(
+				attribute.String("relay", relay),
+			))
+			defer span.End()
+
+			log.Trace().Str("relay", relay).Int("registrations", len(registrations)).Msg("Retrying validator registrations")
+			err := s.submitRelayRegistrationsInBatches(ctx, relay, registrations, s.monitor)
+			if err != nil {
+				log.Warn().Err(err).Str("relay", relay).Msg("Retry of validator registrations failed")
+			}
+			monitorRegistrationRetry(err == nil)
+		}(ctx, relay, registrations)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistration(pubkey phase0.BLSPubKey) *builderapi.VersionedSignedValidatorRegistration {
+	return &builderapi.VersionedSignedValidatorRegistration{
+		Version: builderspec.BuilderVersionV1,
+		V1: &apiv1.SignedValidatorRegistration{
+			Message: &apiv1.ValidatorRegistration{
+				Pubkey: pubkey,
+			},
+		},
+	}
+}
+
+func TestRegistrationRetryBackoff(t *testing.T) {
+	s := &Service{
+		registrationRetryInterval: time.Second,
+	}
+
+	require.Equal(t, time.Second, s.registrationRetryBackoff(1))
+	require.Equal(t, 2*time.Second, s.registrationRetryBackoff(2))
+	require.Equal(t, 4*time.Second, s.registrationRetryBackoff(3))
+	require.Equal(t, 32*time.Second, s.registrationRetryBackoff(6))
+	require.Equal(t, 32*time.Second, s.registrationRetryBackoff(100))
+}
+
+func TestRecordRelayRegistrations(t *testing.T) {
+	s := &Service{
+		registrationRetryInterval: time.Minute,
+		pendingRegistrations:      make(map[string]*pendingRelayRegistrations),
+	}
+	registrations := []*builderapi.VersionedSignedValidatorRegistration{
+		testRegistration(phase0.BLSPubKey{0x01}),
+		testRegistration(phase0.BLSPubKey{0x02}),
+	}
+
+	// Success with nothing pending does nothing.
+	s.recordRelayRegistrations("relay1", registrations, nil)
+	require.Empty(t, s.pendingRegistrations)
+
+	// Failure records the registrations, with a backoff.
+	s.recordRelayRegistrations("relay1", registrations, errors.New("failed"))
+	require.Len(t, s.pendingRegistrations["relay1"].registrations, 2)
+	require.Equal(t, 1, s.pendingRegistrations["relay1"].failures)
+	require.Empty(t, s.dueRegistrations(time.Now()))
+	require.Len(t, s.dueRegistrations(time.Now().Add(time.Minute))["relay1"], 2)
+
+	// A second failure increases the backoff.
+	s.recordRelayRegistrations("relay1", registrations, errors.New("failed"))
+	require.Equal(t, 2, s.pendingRegistrations["relay1"].failures)
+	require.Empty(t, s.dueRegistrations(time.Now().Add(time.Minute)))
+	require.Len(t, s.dueRegistrations(time.Now().Add(2 * time.Minute))["relay1"], 2)
+
+	// Partial success leaves the remainder due immediately.
+	s.recordRelayRegistrations("relay1", registrations[:1], nil)
+	require.Len(t, s.pendingRegistrations["relay1"].registrations, 1)
+	require.Len(t, s.dueRegistrations(time.Now())["relay1"], 1)
+
+	// Full success clears the relay.
+	s.recordRelayRegistrations("relay1", registrations[1:], nil)
+	require.Empty(t, s.pendingRegistrations)
+}
+
+func TestPrunePendingRegistrations(t *testing.T) {
+	s := &Service{
+		registrationRetryInterval: time.Minute,
+		pendingRegistrations:      make(map[string]*pendingRelayRegistrations),
+	}
+	registrations := []*builderapi.VersionedSignedValidatorRegistration{
+		testRegistration(phase0.BLSPubKey{0x01}),
+	}
+	s.recordRelayRegistrations("relay1", registrations, errors.New("failed"))
+	s.recordRelayRegistrations("relay2", registrations, errors.New("failed"))
+
+	s.prunePendingRegistrations(map[string][]*builderapi.VersionedSignedValidatorRegistration{
+		"relay2": registrations,
+	})
+	require.Len(t, s.pendingRegistrations, 1)
+	require.Contains(t, s.pendingRegistrations, "relay2")
+}
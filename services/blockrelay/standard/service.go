@@ -16,6 +16,7 @@ package standard
 import (
 	"context"
 	"sync"
+	"time"
 
 	restdaemon "github.com/attestantio/go-block-relay/services/daemon/rest"
 	apiv1 "github.com/attestantio/go-builder-client/api/v1"
@@ -33,6 +34,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
 	"github.com/wealdtech/go-majordomo"
 	"golang.org/x/sync/semaphore"
 )
@@ -45,15 +47,18 @@ type Service struct {
 	configURL                                 string
 	fallbackFeeRecipient                      bellatrix.ExecutionAddress
 	fallbackGasLimit                          uint64
+	fallbackMinValue                          decimal.Decimal
 	clientCertURL                             string
 	clientKeyURL                              string
 	caCertURL                                 string
+	verificationKeyURL                        string
+	signatureURL                              string
 	accountsProvider                          accountmanager.AccountsProvider
 	validatingAccountsProvider                accountmanager.ValidatingAccountsProvider
 	validatorRegistrationSigner               signer.ValidatorRegistrationSigner
 	builderBidsCache                          map[string]map[string]*builderspec.VersionedSignedBuilderBid
 	builderBidsCacheMu                        sync.RWMutex
-	latestValidatorRegistrations              map[phase0.BLSPubKey]phase0.Root
+	latestValidatorRegistrations              map[registrationKey]*latestRegistration
 	latestValidatorRegistrationsMu            sync.RWMutex
 	signedValidatorRegistrations              map[phase0.Root]*apiv1.SignedValidatorRegistration
 	signedValidatorRegistrationsMu            sync.RWMutex
@@ -62,11 +67,30 @@ type Service struct {
 	releaseVersion                            string
 	builderBidProvider                        builderbid.Provider
 	excludedBuilders                          []phase0.BLSPubKey
+	excludedProposers                         map[phase0.BLSPubKey]struct{}
+	auctionSampleRate                         float64
+	proposerDutiesProvider                    consensusclient.ProposerDutiesProvider
+	signedBeaconBlockProvider                 consensusclient.SignedBeaconBlockProvider
 
 	executionConfig   blockrelay.ExecutionConfigurator
 	executionConfigMu sync.RWMutex
+	proposerOverrides *blockrelay.ProposerConfigOverrides
+
+	feeRecipientsFile           *blockrelay.FeeRecipientsFile
+	feeRecipientsReloadInterval time.Duration
 
 	activitySem *semaphore.Weighted
+
+	registrationRetryInterval time.Duration
+	pendingRegistrations      map[string]*pendingRelayRegistrations
+	pendingRegistrationsMu    sync.Mutex
+	registrationBatchSize     int
+	registrationBatchInterval time.Duration
+	relaySubmissions          map[string]time.Time
+	relaySubmissionsMu        sync.Mutex
+
+	traceJSONDumps   map[string]time.Time
+	traceJSONDumpsMu sync.Mutex
 }
 
 // module-wide log.
@@ -97,21 +121,45 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		clientCertURL:                parameters.clientCertURL,
 		clientKeyURL:                 parameters.clientKeyURL,
 		caCertURL:                    parameters.caCertURL,
+		verificationKeyURL:           parameters.verificationKeyURL,
+		signatureURL:                 parameters.signatureURL,
 		fallbackFeeRecipient:         parameters.fallbackFeeRecipient,
 		fallbackGasLimit:             parameters.fallbackGasLimit,
+		fallbackMinValue:             parameters.fallbackMinValue,
+		proposerOverrides:            blockrelay.NewProposerConfigOverrides(),
 		accountsProvider:             parameters.accountsProvider,
 		validatingAccountsProvider:   parameters.validatingAccountsProvider,
 		validatorRegistrationSigner:  parameters.validatorRegistrationSigner,
-		latestValidatorRegistrations: make(map[phase0.BLSPubKey]phase0.Root),
+		latestValidatorRegistrations: make(map[registrationKey]*latestRegistration),
 		signedValidatorRegistrations: make(map[phase0.Root]*apiv1.SignedValidatorRegistration),
 		secondaryValidatorRegistrationsSubmitters: parameters.secondaryValidatorRegistrationsSubmitters,
-		logResults:         parameters.logResults,
-		releaseVersion:     parameters.releaseVersion,
-		builderBidsCache:   make(map[string]map[string]*builderspec.VersionedSignedBuilderBid),
-		executionConfig:    &v2.ExecutionConfig{Version: 2},
-		activitySem:        semaphore.NewWeighted(1),
-		builderBidProvider: parameters.builderBidProvider,
-		excludedBuilders:   parameters.excludedBuilders,
+		logResults:                parameters.logResults,
+		releaseVersion:            parameters.releaseVersion,
+		builderBidsCache:          make(map[string]map[string]*builderspec.VersionedSignedBuilderBid),
+		executionConfig:           &v2.ExecutionConfig{Version: 2},
+		activitySem:               semaphore.NewWeighted(1),
+		builderBidProvider:        parameters.builderBidProvider,
+		excludedBuilders:          parameters.excludedBuilders,
+		traceJSONDumps:            make(map[string]time.Time),
+		excludedProposers:         make(map[phase0.BLSPubKey]struct{}, len(parameters.excludedProposers)),
+		auctionSampleRate:         parameters.auctionSampleRate,
+		proposerDutiesProvider:    parameters.proposerDutiesProvider,
+		signedBeaconBlockProvider: parameters.signedBeaconBlockProvider,
+		registrationRetryInterval: parameters.registrationRetryInterval,
+		pendingRegistrations:      make(map[string]*pendingRelayRegistrations),
+		registrationBatchSize:     parameters.registrationBatchSize,
+		registrationBatchInterval: parameters.registrationBatchInterval,
+		relaySubmissions:          make(map[string]time.Time),
+	}
+	for _, pubKey := range parameters.excludedProposers {
+		s.excludedProposers[pubKey] = struct{}{}
+	}
+	if parameters.feeRecipientsFile != "" {
+		s.feeRecipientsFile = blockrelay.NewFeeRecipientsFile(parameters.feeRecipientsFile)
+		s.feeRecipientsReloadInterval = parameters.feeRecipientsReloadInterval
+		if err := s.startFeeRecipientsFile(ctx, parameters.scheduler); err != nil {
+			return nil, err
+		}
 	}
 
 	// Carry out initial fetch of execution configuration.
@@ -147,6 +195,32 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		return nil, errors.Wrap(err, "failed to start validator registration submitter")
 	}
 
+	// Periodically retry registrations that relays failed to accept.
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"blockrelay",
+		"Retry validator registrations",
+		s.retryValidatorRegistrationsRuntime,
+		nil,
+		s.retryValidatorRegistrations,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start validator registration retrier")
+	}
+
+	if s.auctionSampleRate > 0 {
+		// Periodically sample relay auctions.
+		if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+			"blockrelay",
+			"Sample relay auction",
+			s.sampleAuctionRuntime,
+			nil,
+			s.sampleAuction,
+			nil,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to start relay auction sampler")
+		}
+	}
+
 	// Create the API daemon.
 	_, err = restdaemon.New(ctx,
 		restdaemon.WithLogLevel(parameters.logLevel),
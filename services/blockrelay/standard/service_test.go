@@ -28,6 +28,7 @@ import (
 	mocksigner "github.com/attestantio/vouch/services/signer/mock"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	directconfidant "github.com/wealdtech/go-majordomo/confidants/direct"
 	standardmajordomo "github.com/wealdtech/go-majordomo/standard"
@@ -339,6 +340,183 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no builder bid provider specified",
 		},
+		{
+			name: "AuctionSampleRateInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithAuctionSampleRate(1.5),
+			},
+			err: "problem with parameters: auction sample rate must be between 0 and 1",
+		},
+		{
+			name: "ProposerDutiesProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithAuctionSampleRate(0.1),
+			},
+			err: "problem with parameters: no proposer duties provider specified",
+		},
+		{
+			name: "FeeRecipientsReloadIntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithFeeRecipientsFile("fee-recipients.yaml"),
+				standard.WithFeeRecipientsReloadInterval(0),
+			},
+			err: "problem with parameters: fee recipients reload interval must be positive",
+		},
+		{
+			name: "RegistrationRetryIntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithRegistrationRetryInterval(0),
+			},
+			err: "problem with parameters: registration retry interval must be positive",
+		},
+		{
+			name: "RegistrationBatchSizeZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithRegistrationBatchSize(0),
+			},
+			err: "problem with parameters: registration batch size must be positive",
+		},
+		{
+			name: "RegistrationBatchIntervalNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithRegistrationBatchInterval(-time.Second),
+			},
+			err: "problem with parameters: registration batch interval cannot be negative",
+		},
+		{
+			name: "FallbackMinValueNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithFallbackMinValue(decimal.New(-1, 0)),
+			},
+			err: "problem with parameters: fallback minimum value cannot be negative",
+		},
+		{
+			name: "FeeRecipientsFileMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(mockScheduler),
+				standard.WithListenAddress(listenAddress),
+				standard.WithChainTime(chainTime),
+				standard.WithConfigURL(configURL),
+				standard.WithFallbackFeeRecipient(fallbackFeeRecipient),
+				standard.WithFallbackGasLimit(fallbackGasLimit),
+				standard.WithAccountsProvider(mockAccountsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithValidatorRegistrationSigner(mockSigner),
+				standard.WithLogResults(true),
+				standard.WithReleaseVersion("test"),
+				standard.WithBuilderBidProvider(builderBidProvider),
+				standard.WithFeeRecipientsFile("/nonexistent/fee-recipients.yaml"),
+			},
+			err: "failed to load fee recipients file: failed to read fee recipients file: open /nonexistent/fee-recipients.yaml: no such file or directory",
+		},
 		{
 			name: "Good",
 			params: []standard.Parameter{
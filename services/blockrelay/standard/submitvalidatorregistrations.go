@@ -14,9 +14,7 @@
 package standard
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -33,7 +31,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	"github.com/attestantio/vouch/services/metrics"
-	"github.com/attestantio/vouch/util"
+	"github.com/attestantio/vouch/util/builders"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	"go.opentelemetry.io/otel"
@@ -60,7 +58,7 @@ func (s *Service) submitValidatorRegistrationsRuntime(_ context.Context,
 func (s *Service) SubmitValidatorRegistrations(ctx context.Context,
 	accounts map[phase0.ValidatorIndex]e2wtypes.Account,
 ) error {
-	return s.submitValidatorRegistrationsForAccounts(ctx, accounts)
+	return s.submitValidatorRegistrationsForAccounts(ctx, accounts, false)
 }
 
 // submitValidatorRegistrations submits validator registrations.
@@ -103,15 +101,19 @@ func (s *Service) submitValidatorRegistrations(ctx context.Context,
 		return
 	}
 
-	if err := s.submitValidatorRegistrationsForAccounts(ctx, accounts); err != nil {
+	if err := s.submitValidatorRegistrationsForAccounts(ctx, accounts, true); err != nil {
 		log.Error().Err(err).Msg("Failed to submit validator registrations")
 	}
 
 	monitorValidatorRegistrations(true, time.Since(started))
 }
 
+// submitValidatorRegistrationsForAccounts submits validator registrations for the given accounts.
+// If allAccounts is true then the accounts are the full set of validating accounts, and any
+// pending retries for relays that are no longer in use are dropped.
 func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 	accounts map[phase0.ValidatorIndex]e2wtypes.Account,
+	allAccounts bool,
 ) error {
 	ctx, span := otel.Tracer("attestantio.vouch.services.blockrelay.standard").Start(ctx, "submitValidatorRegistrationsForAccounts")
 	defer span.End()
@@ -122,6 +124,7 @@ func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 
 	consensusRegistrations := make([]*consensusapi.VersionedSignedValidatorRegistration, 0, len(accounts))
 	relayRegistrations := make(map[string][]*builderapi.VersionedSignedValidatorRegistration)
+	pubkeys := make(map[phase0.BLSPubKey]struct{}, len(accounts))
 	var pubkey phase0.BLSPubKey
 	for _, account := range accounts {
 		if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
@@ -129,10 +132,19 @@ func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 		} else {
 			copy(pubkey[:], account.PublicKey().Marshal())
 		}
-		proposerConfig, err := s.executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit)
+		pubkeys[pubkey] = struct{}{}
+		if _, excluded := s.excludedProposers[pubkey]; excluded {
+			log.Trace().Stringer("validator", pubkey).Msg("Validator is excluded from proposing; not generating validator registration")
+			continue
+		}
+		proposerConfig, err := s.executionConfig.ProposerConfig(ctx, account, pubkey, s.fallbackFeeRecipient, s.fallbackGasLimit, s.fallbackMinValue)
 		if err != nil {
 			return errors.Wrap(err, "No proposer configuration; cannot submit validator registrations")
 		}
+		if s.feeRecipientsFile != nil {
+			s.feeRecipientsFile.Apply(pubkey, proposerConfig)
+		}
+		s.proposerOverrides.Apply(pubkey, proposerConfig)
 		if proposerConfig.FeeRecipient.IsZero() {
 			log.Error().Stringer("validator", pubkey).Msg("Received 0 execution address for validator registration; using fallback")
 			proposerConfig.FeeRecipient = s.fallbackFeeRecipient
@@ -145,10 +157,7 @@ func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 				continue
 			}
 			if e := log.Trace(); e.Enabled() {
-				data, err := json.Marshal(relayRegistration)
-				if err == nil {
-					e.Str("pubkey", fmt.Sprintf("%#x", pubkey)).Str("relay", relay.Address).RawJSON("registration", data).Msg("Registration")
-				}
+				s.traceJSON(e.Str("pubkey", fmt.Sprintf("%#x", pubkey)).Str("relay", relay.Address), "Registration", "registration", relayRegistration, 1)
 			}
 			// Add the relay registration to the appropriate queue.
 			if _, exists := relayRegistrations[relay.Address]; !exists {
@@ -165,16 +174,19 @@ func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 	span.AddEvent("Generated registrations")
 
 	if e := log.Trace(); e.Enabled() {
-		data, err := json.Marshal(relayRegistrations)
-		if err == nil {
-			e.RawJSON("registrations", data).Msg("Generated registrations")
+		items := 0
+		for _, registrations := range relayRegistrations {
+			items += len(registrations)
 		}
+		s.traceJSON(e, "Generated registrations", "registrations", relayRegistrations, items)
 	}
 	if e := log.Trace(); e.Enabled() {
-		data, err := json.Marshal(consensusRegistrations)
-		if err == nil {
-			e.RawJSON("registrations", data).Msg("Generated consensus registrations")
-		}
+		s.traceJSON(e, "Generated consensus registrations", "registrations", consensusRegistrations, len(consensusRegistrations))
+	}
+
+	if allAccounts {
+		s.prunePendingRegistrations(relayRegistrations)
+		s.pruneValidatorRegistrations(pubkeys)
 	}
 
 	// Submit registrations in parallel to the builders.
@@ -188,19 +200,8 @@ func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 			))
 			defer span.End()
 
-			client, err := util.FetchBuilderClient(ctx, builder, monitor, s.releaseVersion)
-			if err != nil {
-				log.Error().Err(err).Str("builder", builder).Msg("Failed to fetch builder client")
-				return
-			}
-			submitter, isSubmitter := client.(builderclient.ValidatorRegistrationsSubmitter)
-			if !isSubmitter {
-				log.Error().Str("builder", builder).Msg("Builder client does not accept validator registrations")
-				return
-			}
-			if err := submitter.SubmitValidatorRegistrations(ctx, providerRegistrations); err != nil {
-				log.Error().Err(err).Str("builder", builder).Msg("Failed to submit validator registrations")
-				return
+			if err := s.submitRelayRegistrationsInBatches(ctx, builder, providerRegistrations, monitor); err != nil {
+				log.Error().Err(err).Str("builder", builder).Msg("Failed to submit validator registrations; will retry")
 			}
 		}(ctx, builder, providerRegistrations, s.monitor)
 	}
@@ -230,6 +231,27 @@ func (s *Service) submitValidatorRegistrationsForAccounts(ctx context.Context,
 	return nil
 }
 
+// submitRelayRegistrations submits validator registrations to a single relay.
+func (s *Service) submitRelayRegistrations(ctx context.Context,
+	relay string,
+	registrations []*builderapi.VersionedSignedValidatorRegistration,
+	monitor metrics.Service,
+) error {
+	client, err := builders.FetchClient(ctx, relay, monitor, s.releaseVersion)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch builder client")
+	}
+	submitter, isSubmitter := client.(builderclient.ValidatorRegistrationsSubmitter)
+	if !isSubmitter {
+		return errors.New("builder client does not accept validator registrations")
+	}
+	if err := submitter.SubmitValidatorRegistrations(ctx, registrations); err != nil {
+		return errors.Wrap(err, "failed to submit validator registrations")
+	}
+
+	return nil
+}
+
 func (s *Service) generateValidatorRegistrationForRelay(ctx context.Context,
 	account e2wtypes.Account,
 	pubkey phase0.BLSPubKey,
@@ -254,16 +276,13 @@ func (s *Service) generateValidatorRegistrationForRelay(ctx context.Context,
 	registration.Timestamp = time.Now().Round(time.Second)
 
 	// See if we already have a signed registration that matches this configuration.
-	s.signedValidatorRegistrationsMu.RLock()
-	signedRegistration, exists := s.signedValidatorRegistrations[registrationRoot]
-	s.signedValidatorRegistrationsMu.RUnlock()
-
-	// See if the latest registration matches this configuration.
-	s.latestValidatorRegistrationsMu.RLock()
-	latestRoot := s.latestValidatorRegistrations[pubkey]
-	s.latestValidatorRegistrationsMu.RUnlock()
-
-	if exists && bytes.Equal(latestRoot[:], registrationRoot[:]) {
+	key := registrationKey{
+		pubkey: pubkey,
+		relay:  relayConfig.Address,
+	}
+	signedRegistration := s.cachedValidatorRegistration(key, registrationRoot)
+	if signedRegistration != nil {
+		s.recordValidatorRegistration(key, registrationRoot, signedRegistration, false)
 		monitorRegistrationsGeneration("cache")
 	} else {
 		log.Trace().Msg("Signing a new or updated validator registration")
@@ -279,12 +298,7 @@ func (s *Service) generateValidatorRegistrationForRelay(ctx context.Context,
 			Message:   registration,
 			Signature: sig,
 		}
-		s.signedValidatorRegistrationsMu.Lock()
-		s.signedValidatorRegistrations[registrationRoot] = signedRegistration
-		s.signedValidatorRegistrationsMu.Unlock()
-		s.latestValidatorRegistrationsMu.Lock()
-		s.latestValidatorRegistrations[pubkey] = registrationRoot
-		s.latestValidatorRegistrationsMu.Unlock()
+		s.recordValidatorRegistration(key, registrationRoot, signedRegistration, true)
 		monitorRegistrationsGeneration("generation")
 	}
 
@@ -0,0 +1,94 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestSubmitValidatorRegistrationsExcludedProposers(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Reset()
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := nd.CreateWallet(ctx, "test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	included, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "included", []byte("pass"))
+	require.NoError(t, err)
+	excluded, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "excluded", []byte("pass"))
+	require.NoError(t, err)
+	var includedPubkey phase0.BLSPubKey
+	copy(includedPubkey[:], included.PublicKey().Marshal())
+	var excludedPubkey phase0.BLSPubKey
+	copy(excludedPubkey[:], excluded.PublicKey().Marshal())
+
+	relay := &batchRelay{}
+	server := httptest.NewServer(relay)
+	defer server.Close()
+
+	feeRecipient := bellatrix.ExecutionAddress{0x01}
+	s := &Service{
+		monitor:                      nullmetrics.New(ctx),
+		releaseVersion:               "test",
+		validatorRegistrationSigner:  mocksigner.New(),
+		proposerOverrides:            blockrelay.NewProposerConfigOverrides(),
+		excludedProposers:            map[phase0.BLSPubKey]struct{}{excludedPubkey: {}},
+		latestValidatorRegistrations: make(map[registrationKey]*latestRegistration),
+		signedValidatorRegistrations: make(map[phase0.Root]*apiv1.SignedValidatorRegistration),
+		pendingRegistrations:         make(map[string]*pendingRelayRegistrations),
+		relaySubmissions:             make(map[string]time.Time),
+		executionConfig: &staticExecutionConfig{
+			proposerConfig: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      server.URL,
+						FeeRecipient: feeRecipient,
+						GasLimit:     30000000,
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, s.submitValidatorRegistrationsForAccounts(ctx, map[phase0.ValidatorIndex]e2wtypes.Account{
+		1: included,
+		2: excluded,
+	}, false))
+
+	// Only the validator that is not excluded is registered.
+	require.Equal(t, []int{1}, relay.batches)
+	require.Contains(t, s.latestValidatorRegistrations, registrationKey{pubkey: includedPubkey, relay: server.URL})
+	require.NotContains(t, s.latestValidatorRegistrations, registrationKey{pubkey: excludedPubkey, relay: server.URL})
+}
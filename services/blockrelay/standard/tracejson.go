@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// maxTraceJSONItems is the maximum number of items in a payload that will be
+	// marshalled for trace logging; larger payloads are summarised.
+	maxTraceJSONItems = 64
+	// maxTraceJSONSize is the maximum size of JSON that will be trace logged.
+	maxTraceJSONSize = 64 * 1024
+	// traceJSONInterval is the minimum interval between full dumps of the same message.
+	traceJSONInterval = time.Minute
+)
+
+// traceJSON adds the JSON representation of data to the trace event and sends it
+// with the given message.
+// Payloads with more than maxTraceJSONItems items, or whose JSON is larger than
+// maxTraceJSONSize, are summarised rather than dumped, and each message is dumped
+// at most once per traceJSONInterval, so that enabling trace logging on large
+// instances does not hold up duties.
+func (s *Service) traceJSON(e *zerolog.Event, msg string, key string, data any, items int) {
+	if !s.allowTraceJSON(msg) {
+		e.Int("items", items).Msg(msg)
+		return
+	}
+
+	if items > maxTraceJSONItems {
+		e.Int("items", items).Bool("summarised", true).Msg(msg)
+		return
+	}
+
+	res, err := json.Marshal(data)
+	if err != nil {
+		e.Err(err).Msg(msg)
+		return
+	}
+	if len(res) > maxTraceJSONSize {
+		e.Int("items", items).Int("size", len(res)).Bool("summarised", true).Msg(msg)
+		return
+	}
+
+	e.RawJSON(key, res).Msg(msg)
+}
+
+// allowTraceJSON returns true if a full dump of the given message is allowed at current.
+func (s *Service) allowTraceJSON(msg string) bool {
+	s.traceJSONDumpsMu.Lock()
+	defer s.traceJSONDumpsMu.Unlock()
+
+	if last, exists := s.traceJSONDumps[msg]; exists && time.Since(last) < traceJSONInterval {
+		return false
+	}
+	s.traceJSONDumps[msg] = time.Now()
+
+	return true
+}
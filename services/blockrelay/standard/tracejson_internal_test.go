@@ -0,0 +1,34 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowTraceJSON(t *testing.T) {
+	s := &Service{
+		traceJSONDumps: make(map[string]time.Time),
+	}
+
+	require.True(t, s.allowTraceJSON("first"))
+	require.False(t, s.allowTraceJSON("first"))
+	require.True(t, s.allowTraceJSON("second"))
+
+	s.traceJSONDumps["first"] = time.Now().Add(-traceJSONInterval)
+	require.True(t, s.allowTraceJSON("first"))
+}
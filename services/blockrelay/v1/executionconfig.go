@@ -89,6 +89,7 @@ func (e *ExecutionConfig) ProposerConfig(_ context.Context,
 	pubkey phase0.BLSPubKey,
 	fallbackFeeRecipient bellatrix.ExecutionAddress,
 	fallbackGasLimit uint64,
+	fallbackMinValue decimal.Decimal,
 ) (
 	*beaconblockproposer.ProposerConfig,
 	error,
@@ -129,7 +130,7 @@ func (e *ExecutionConfig) ProposerConfig(_ context.Context,
 				GasLimit:     proposerConfig.GasLimit,
 				Grace:        proposerConfig.Builder.Grace,
 				// MinValue is not available in V1.
-				MinValue: decimal.Zero,
+				MinValue: fallbackMinValue,
 			})
 		}
 	}
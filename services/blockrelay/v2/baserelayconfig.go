@@ -36,6 +36,8 @@ type BaseRelayConfig struct {
 	GasLimit     *uint64
 	Grace        *time.Duration
 	MinValue     *decimal.Decimal
+	Location     string
+	Censoring    bool
 }
 
 type baseRelayConfigJSON struct {
@@ -44,6 +46,8 @@ type baseRelayConfigJSON struct {
 	GasLimit     string `json:"gas_limit,omitempty"`
 	Grace        string `json:"grace,omitempty"`
 	MinValue     string `json:"min_value,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Censoring    bool   `json:"censoring,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -74,6 +78,8 @@ func (c *BaseRelayConfig) MarshalJSON() ([]byte, error) {
 		GasLimit:     gasLimit,
 		Grace:        grace,
 		MinValue:     minValue,
+		Location:     c.Location,
+		Censoring:    c.Censoring,
 	})
 }
 
@@ -134,6 +140,8 @@ func (c *BaseRelayConfig) UnmarshalJSON(input []byte) error {
 		minValue = minValue.Mul(weiPerETH)
 		c.MinValue = &minValue
 	}
+	c.Location = data.Location
+	c.Censoring = data.Censoring
 
 	return nil
 }
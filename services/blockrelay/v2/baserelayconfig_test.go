@@ -101,6 +101,14 @@ func TestBaseRelayConfig(t *testing.T) {
 			name:  "Good",
 			input: []byte(`{"fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","grace":"1000","min_value":"0.5"}`),
 		},
+		{
+			name:  "Location",
+			input: []byte(`{"fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","grace":"1000","min_value":"0.5","location":"eu"}`),
+		},
+		{
+			name:  "Censoring",
+			input: []byte(`{"fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","censoring":true}`),
+		},
 		{
 			name:  "Empty",
 			input: []byte(`{}`),
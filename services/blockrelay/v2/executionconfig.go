@@ -148,6 +148,7 @@ func (e *ExecutionConfig) ProposerConfig(_ context.Context,
 	pubkey phase0.BLSPubKey,
 	fallbackFeeRecipient bellatrix.ExecutionAddress,
 	fallbackGasLimit uint64,
+	fallbackMinValue decimal.Decimal,
 ) (
 	*beaconblockproposer.ProposerConfig,
 	error,
@@ -173,7 +174,7 @@ func (e *ExecutionConfig) ProposerConfig(_ context.Context,
 			configRelay.Grace = *e.Grace
 		}
 		if e.MinValue == nil {
-			configRelay.MinValue = decimal.Zero
+			configRelay.MinValue = fallbackMinValue
 		} else {
 			configRelay.MinValue = *e.MinValue
 		}
@@ -251,10 +252,10 @@ func (e *ExecutionConfig) ProposerConfig(_ context.Context,
 		// Add new relays.
 		for address, proposerRelayConfig := range proposerConfig.Relays {
 			if _, alreadyUpdated := updated[address]; !alreadyUpdated {
-				relays = append(relays, e.generateRelayConfig(address, proposerConfig, proposerRelayConfig, fallbackFeeRecipient, fallbackGasLimit))
+				relays = append(relays, e.generateRelayConfig(address, proposerConfig, proposerRelayConfig, fallbackFeeRecipient, fallbackGasLimit, fallbackMinValue))
 			}
 		}
-		config.Relays = relays
+		config.Relays = filterRelays(relays, proposerConfig)
 
 		// Once we have a match we are done.
 		break
@@ -263,6 +264,40 @@ func (e *ExecutionConfig) ProposerConfig(_ context.Context,
 	return config, nil
 }
 
+// filterRelays removes relays that are not permitted by the proposer's
+// allowed or denied relays.
+func filterRelays(relays []*beaconblockproposer.RelayConfig,
+	proposerConfig *ProposerConfig,
+) []*beaconblockproposer.RelayConfig {
+	if len(proposerConfig.AllowedRelays) == 0 && len(proposerConfig.DeniedRelays) == 0 {
+		return relays
+	}
+
+	allowed := make(map[string]struct{}, len(proposerConfig.AllowedRelays))
+	for _, address := range proposerConfig.AllowedRelays {
+		allowed[address] = struct{}{}
+	}
+	denied := make(map[string]struct{}, len(proposerConfig.DeniedRelays))
+	for _, address := range proposerConfig.DeniedRelays {
+		denied[address] = struct{}{}
+	}
+
+	res := make([]*beaconblockproposer.RelayConfig, 0, len(relays))
+	for _, relay := range relays {
+		if len(allowed) > 0 {
+			if _, exists := allowed[relay.Address]; !exists {
+				continue
+			}
+		}
+		if _, exists := denied[relay.Address]; exists {
+			continue
+		}
+		res = append(res, relay)
+	}
+
+	return res
+}
+
 // generateRelayConfig generates a relay configuration from the various
 // tiers of existing information.
 func (e *ExecutionConfig) generateRelayConfig(
@@ -271,6 +306,7 @@ func (e *ExecutionConfig) generateRelayConfig(
 	proposerRelayConfig *ProposerRelayConfig,
 	fallbackFeeRecipient bellatrix.ExecutionAddress,
 	fallbackGasLimit uint64,
+	fallbackMinValue decimal.Decimal,
 ) *beaconblockproposer.RelayConfig {
 	relayConfig := &beaconblockproposer.RelayConfig{
 		Address:   address,
@@ -335,8 +371,8 @@ func (e *ExecutionConfig) generateRelayConfig(
 		// Fetch from execution config.
 		relayConfig.MinValue = *e.MinValue
 	default:
-		// No value; set to zero.
-		relayConfig.MinValue = decimal.Zero
+		// No value; set to default.
+		relayConfig.MinValue = fallbackMinValue
 	}
 
 	return relayConfig
@@ -371,6 +407,9 @@ func setRelayConfig(config *beaconblockproposer.RelayConfig,
 	if relayConfig.MinValue != nil {
 		config.MinValue = *relayConfig.MinValue
 	}
+
+	config.Location = relayConfig.Location
+	config.Censoring = relayConfig.Censoring
 }
 
 // updateRelayConfig updates the configuration for a relay with proposer-specific overrides.
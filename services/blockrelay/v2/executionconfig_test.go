@@ -190,6 +190,7 @@ func TestConfig(t *testing.T) {
 		pubkey               phase0.BLSPubKey
 		fallbackFeeRecipient bellatrix.ExecutionAddress
 		fallbackGasLimit     uint64
+		fallbackMinValue     *decimal.Decimal
 		expected             *beaconblockproposer.ProposerConfig
 		err                  string
 	}{
@@ -229,6 +230,77 @@ func TestConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "FallbackMinValue",
+			executionConfig: &v2.ExecutionConfig{
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+				},
+				Proposers: []*v2.ProposerConfig{
+					{
+						Validator: pubkey1,
+						Relays: map[string]*v2.ProposerRelayConfig{
+							"https://relay2.com/": {},
+						},
+					},
+				},
+			},
+			account:              account1,
+			pubkey:               pubkey1,
+			fallbackFeeRecipient: feeRecipient1,
+			fallbackGasLimit:     gasLimit1,
+			fallbackMinValue:     &minValue1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient1,
+						GasLimit:     gasLimit1,
+						Grace:        0,
+						MinValue:     minValue1,
+					},
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient1,
+						GasLimit:     gasLimit1,
+						Grace:        0,
+						MinValue:     minValue1,
+					},
+				},
+			},
+		},
+		{
+			name: "FallbackMinValueOverridden",
+			executionConfig: &v2.ExecutionConfig{
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+				},
+				Proposers: []*v2.ProposerConfig{
+					{
+						Validator: pubkey1,
+						MinValue:  &minValue0,
+					},
+				},
+			},
+			account:              account1,
+			pubkey:               pubkey1,
+			fallbackFeeRecipient: feeRecipient1,
+			fallbackGasLimit:     gasLimit1,
+			fallbackMinValue:     &minValue1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient1,
+						GasLimit:     gasLimit1,
+						Grace:        0,
+						MinValue:     minValue0,
+					},
+				},
+			},
+		},
 		{
 			name: "BaseNoRelays",
 			executionConfig: &v2.ExecutionConfig{
@@ -673,6 +745,100 @@ func TestConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ProposerValidatorAllowedRelays",
+			executionConfig: &v2.ExecutionConfig{
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+					"https://relay2.com/": {},
+				},
+				Proposers: []*v2.ProposerConfig{
+					{
+						Validator: pubkey1,
+						Relays: map[string]*v2.ProposerRelayConfig{
+							"https://relay3.com/": {},
+						},
+						AllowedRelays: []string{"https://relay2.com/"},
+					},
+				},
+			},
+			account:              account1,
+			pubkey:               pubkey1,
+			fallbackFeeRecipient: feeRecipient1,
+			fallbackGasLimit:     gasLimit1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient1,
+						GasLimit:     gasLimit1,
+						MinValue:     decimal.Zero,
+					},
+				},
+			},
+		},
+		{
+			name: "ProposerValidatorDeniedRelays",
+			executionConfig: &v2.ExecutionConfig{
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+				},
+				Proposers: []*v2.ProposerConfig{
+					{
+						Validator: pubkey1,
+						Relays: map[string]*v2.ProposerRelayConfig{
+							"https://relay2.com/": {},
+						},
+						DeniedRelays: []string{"https://relay1.com/"},
+					},
+				},
+			},
+			account:              account1,
+			pubkey:               pubkey1,
+			fallbackFeeRecipient: feeRecipient1,
+			fallbackGasLimit:     gasLimit1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient1,
+						GasLimit:     gasLimit1,
+						MinValue:     decimal.Zero,
+					},
+				},
+			},
+		},
+		{
+			name: "ProposerValidatorDeniedRelaysNoMatch",
+			executionConfig: &v2.ExecutionConfig{
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+				},
+				Proposers: []*v2.ProposerConfig{
+					{
+						Validator:    pubkey2,
+						DeniedRelays: []string{"https://relay1.com/"},
+					},
+				},
+			},
+			account:              account1,
+			pubkey:               pubkey1,
+			fallbackFeeRecipient: feeRecipient1,
+			fallbackGasLimit:     gasLimit1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient1,
+						GasLimit:     gasLimit1,
+						MinValue:     decimal.Zero,
+					},
+				},
+			},
+		},
 		{
 			name: "InvalidProposerConfig",
 			executionConfig: &v2.ExecutionConfig{
@@ -703,7 +869,11 @@ func TestConfig(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			res, err := test.executionConfig.ProposerConfig(ctx, test.account, test.pubkey, test.fallbackFeeRecipient, test.fallbackGasLimit)
+			fallbackMinValue := decimal.Zero
+			if test.fallbackMinValue != nil {
+				fallbackMinValue = *test.fallbackMinValue
+			}
+			res, err := test.executionConfig.ProposerConfig(ctx, test.account, test.pubkey, test.fallbackFeeRecipient, test.fallbackGasLimit, fallbackMinValue)
 			if test.err != "" {
 				require.EqualError(t, err, test.err)
 			} else {
@@ -31,24 +31,28 @@ import (
 // ProposerConfig contains proposer-specific configuration for validators
 // proposing execution payloads.
 type ProposerConfig struct {
-	Validator    phase0.BLSPubKey
-	Account      *regexp.Regexp
-	FeeRecipient *bellatrix.ExecutionAddress
-	GasLimit     *uint64
-	Grace        *time.Duration
-	MinValue     *decimal.Decimal
-	ResetRelays  bool
-	Relays       map[string]*ProposerRelayConfig
+	Validator     phase0.BLSPubKey
+	Account       *regexp.Regexp
+	FeeRecipient  *bellatrix.ExecutionAddress
+	GasLimit      *uint64
+	Grace         *time.Duration
+	MinValue      *decimal.Decimal
+	ResetRelays   bool
+	Relays        map[string]*ProposerRelayConfig
+	AllowedRelays []string
+	DeniedRelays  []string
 }
 
 type proposerConfigJSON struct {
-	Proposer     string                          `json:"proposer"`
-	FeeRecipient string                          `json:"fee_recipient,omitempty"`
-	GasLimit     string                          `json:"gas_limit,omitempty"`
-	Grace        string                          `json:"grace,omitempty"`
-	MinValue     string                          `json:"min_value,omitempty"`
-	ResetRelays  bool                            `json:"reset_relays,omitempty"`
-	Relays       map[string]*ProposerRelayConfig `json:"relays,omitempty"`
+	Proposer      string                          `json:"proposer"`
+	FeeRecipient  string                          `json:"fee_recipient,omitempty"`
+	GasLimit      string                          `json:"gas_limit,omitempty"`
+	Grace         string                          `json:"grace,omitempty"`
+	MinValue      string                          `json:"min_value,omitempty"`
+	ResetRelays   bool                            `json:"reset_relays,omitempty"`
+	Relays        map[string]*ProposerRelayConfig `json:"relays,omitempty"`
+	AllowedRelays []string                        `json:"allowed_relays,omitempty"`
+	DeniedRelays  []string                        `json:"denied_relays,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -77,13 +81,15 @@ func (p *ProposerConfig) MarshalJSON() ([]byte, error) {
 	}
 
 	return json.Marshal(&proposerConfigJSON{
-		Proposer:     proposer,
-		FeeRecipient: feeRecipient,
-		GasLimit:     gasLimit,
-		Grace:        grace,
-		MinValue:     minValue,
-		ResetRelays:  p.ResetRelays,
-		Relays:       p.Relays,
+		Proposer:      proposer,
+		FeeRecipient:  feeRecipient,
+		GasLimit:      gasLimit,
+		Grace:         grace,
+		MinValue:      minValue,
+		ResetRelays:   p.ResetRelays,
+		Relays:        p.Relays,
+		AllowedRelays: p.AllowedRelays,
+		DeniedRelays:  p.DeniedRelays,
 	})
 }
 
@@ -160,6 +166,11 @@ func (p *ProposerConfig) UnmarshalJSON(input []byte) error {
 	}
 	p.ResetRelays = data.ResetRelays
 	p.Relays = data.Relays
+	if len(data.AllowedRelays) > 0 && len(data.DeniedRelays) > 0 {
+		return errors.New("cannot specify both allowed and denied relays")
+	}
+	p.AllowedRelays = data.AllowedRelays
+	p.DeniedRelays = data.DeniedRelays
 
 	return nil
 }
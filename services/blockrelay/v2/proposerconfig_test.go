@@ -122,6 +122,19 @@ func TestProposerConfig(t *testing.T) {
 			input: []byte(`{"proposer":"^Wallet/Account$","fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","grace":"1000","min_value":"-1"}`),
 			err:   "min value cannot be negative",
 		},
+		{
+			name:  "AllowedAndDeniedRelays",
+			input: []byte(`{"proposer":"^Wallet/Account$","allowed_relays":["https://relay1.com/"],"denied_relays":["https://relay2.com/"]}`),
+			err:   "cannot specify both allowed and denied relays",
+		},
+		{
+			name:  "GoodAllowedRelays",
+			input: []byte(`{"proposer":"^Wallet/Account$","allowed_relays":["https://relay1.com/"]}`),
+		},
+		{
+			name:  "GoodDeniedRelays",
+			input: []byte(`{"proposer":"^Wallet/Account$","denied_relays":["https://relay2.com/"]}`),
+		},
 		{
 			name:  "Good",
 			input: []byte(`{"proposer":"^Wallet/Account$","fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","grace":"1000","min_value":"0.5"}`),
@@ -0,0 +1,134 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// AccountConfig contains execution configuration for the accounts whose
+// names match a glob.
+type AccountConfig struct {
+	// Account is the glob matched against "wallet/account" names, where
+	// '*' matches any sequence of characters and '?' any single character.
+	Account string
+	Config
+	pattern *regexp.Regexp
+}
+
+type accountConfigJSON struct {
+	Account string `json:"account"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a *AccountConfig) MarshalJSON() ([]byte, error) {
+	account, err := json.Marshal(&accountConfigJSON{
+		Account: a.Account,
+	})
+	if err != nil {
+		return nil, err
+	}
+	config, err := json.Marshal(a.Config.toJSON())
+	if err != nil {
+		return nil, err
+	}
+	if len(config) == 2 {
+		// No configuration beyond the account.
+		return account, nil
+	}
+
+	// Combine the account and configuration in to a single object.
+	res := make([]byte, 0, len(account)+len(config))
+	res = append(res, account[:len(account)-1]...)
+	res = append(res, ',')
+	res = append(res, config[1:]...)
+
+	return res, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *AccountConfig) UnmarshalJSON(input []byte) error {
+	var data accountConfigJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Account == "" {
+		return errors.New("account is missing")
+	}
+	pattern, err := globToRegexp(data.Account)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid account %s", data.Account))
+	}
+	a.Account = data.Account
+	a.pattern = pattern
+
+	var config configJSON
+	if err := json.Unmarshal(input, &config); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+	if err := a.Config.fromJSON(&config); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid configuration for account %s", data.Account))
+	}
+
+	return nil
+}
+
+// String provides a string representation of the struct.
+func (a *AccountConfig) String() string {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Sprintf("ERR: %v\n", err)
+	}
+	return string(data)
+}
+
+// matches returns true if the account name matches the glob.
+func (a *AccountConfig) matches(accountName string) (bool, error) {
+	pattern := a.pattern
+	if pattern == nil {
+		// Configuration was created directly rather than unmarshalled.
+		var err error
+		pattern, err = globToRegexp(a.Account)
+		if err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("invalid account %s", a.Account))
+		}
+	}
+
+	return pattern.MatchString(accountName), nil
+}
+
+// globToRegexp converts a glob to an anchored regular expression.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			builder.WriteString(".*")
+		case '?':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	builder.WriteString("$")
+
+	return regexp.Compile(builder.String())
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	v3 "github.com/attestantio/vouch/services/blockrelay/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name: "Empty",
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name:  "JSONBad",
+			input: []byte("[]"),
+			err:   "invalid JSON: json: cannot unmarshal array into Go value of type v3.accountConfigJSON",
+		},
+		{
+			name:  "AccountMissing",
+			input: []byte(`{"fee_recipient":"0x1111111111111111111111111111111111111111"}`),
+			err:   "account is missing",
+		},
+		{
+			name:  "FeeRecipientInvalid",
+			input: []byte(`{"account":"Wallet 1/*","fee_recipient":"true"}`),
+			err:   "invalid configuration for account Wallet 1/*: failed to decode fee recipient: encoding/hex: invalid byte: U+0074 't'",
+		},
+		{
+			name:  "Minimal",
+			input: []byte(`{"account":"Wallet 1/*"}`),
+		},
+		{
+			name:  "Good",
+			input: []byte(`{"account":"Wallet 1/Account ?","fee_recipient":"0x1111111111111111111111111111111111111111","builder_enabled":false}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res v3.AccountConfig
+			err := json.Unmarshal(test.input, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, string(test.input), res.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Config contains a single level of execution configuration.  It is used
+// for the defaults, account overrides and validator overrides, with each
+// level inheriting any values it does not set from the level before.
+type Config struct {
+	FeeRecipient   *bellatrix.ExecutionAddress
+	GasLimit       *uint64
+	Grace          *time.Duration
+	MinValue       *decimal.Decimal
+	BuilderEnabled *bool
+	ResetRelays    bool
+	Relays         map[string]*RelayConfig
+	AllowedRelays  []string
+	DeniedRelays   []string
+}
+
+type configJSON struct {
+	FeeRecipient   string                  `json:"fee_recipient,omitempty"`
+	GasLimit       string                  `json:"gas_limit,omitempty"`
+	Grace          string                  `json:"grace,omitempty"`
+	MinValue       string                  `json:"min_value,omitempty"`
+	BuilderEnabled *bool                   `json:"builder_enabled,omitempty"`
+	ResetRelays    bool                    `json:"reset_relays,omitempty"`
+	Relays         map[string]*RelayConfig `json:"relays,omitempty"`
+	AllowedRelays  []string                `json:"allowed_relays,omitempty"`
+	DeniedRelays   []string                `json:"denied_relays,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toJSON())
+}
+
+func (c *Config) toJSON() *configJSON {
+	var feeRecipient string
+	if c.FeeRecipient != nil {
+		feeRecipient = fmt.Sprintf("%#x", *c.FeeRecipient)
+	}
+	var gasLimit string
+	if c.GasLimit != nil {
+		gasLimit = fmt.Sprintf("%d", *c.GasLimit)
+	}
+	var grace string
+	if c.Grace != nil {
+		grace = fmt.Sprintf("%d", c.Grace.Milliseconds())
+	}
+	var minValue string
+	if c.MinValue != nil {
+		minValue = fmt.Sprintf("%v", c.MinValue.Div(weiPerETH))
+	}
+
+	return &configJSON{
+		FeeRecipient:   feeRecipient,
+		GasLimit:       gasLimit,
+		Grace:          grace,
+		MinValue:       minValue,
+		BuilderEnabled: c.BuilderEnabled,
+		ResetRelays:    c.ResetRelays,
+		Relays:         c.Relays,
+		AllowedRelays:  c.AllowedRelays,
+		DeniedRelays:   c.DeniedRelays,
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Config) UnmarshalJSON(input []byte) error {
+	var data configJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	return c.fromJSON(&data)
+}
+
+func (c *Config) fromJSON(data *configJSON) error {
+	if data.FeeRecipient != "" {
+		feeRecipient, err := parseFeeRecipient(data.FeeRecipient)
+		if err != nil {
+			return err
+		}
+		c.FeeRecipient = feeRecipient
+	}
+	if data.GasLimit != "" {
+		gasLimit, err := strconv.ParseUint(data.GasLimit, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid gas limit")
+		}
+		c.GasLimit = &gasLimit
+	}
+	if data.Grace != "" {
+		grace, err := parseGrace(data.Grace)
+		if err != nil {
+			return err
+		}
+		c.Grace = grace
+	}
+	if data.MinValue != "" {
+		minValue, err := parseMinValue(data.MinValue)
+		if err != nil {
+			return err
+		}
+		c.MinValue = minValue
+	}
+	c.BuilderEnabled = data.BuilderEnabled
+	c.ResetRelays = data.ResetRelays
+	c.Relays = data.Relays
+	if len(data.AllowedRelays) > 0 && len(data.DeniedRelays) > 0 {
+		return errors.New("cannot specify both allowed and denied relays")
+	}
+	c.AllowedRelays = data.AllowedRelays
+	c.DeniedRelays = data.DeniedRelays
+
+	return nil
+}
+
+// String provides a string representation of the struct.
+func (c *Config) String() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Sprintf("ERR: %v\n", err)
+	}
+	return string(data)
+}
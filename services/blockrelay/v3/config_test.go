@@ -0,0 +1,90 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	v3 "github.com/attestantio/vouch/services/blockrelay/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name: "Empty",
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name:  "JSONBad",
+			input: []byte("[]"),
+			err:   "invalid JSON: json: cannot unmarshal array into Go value of type v3.configJSON",
+		},
+		{
+			name:  "FeeRecipientInvalid",
+			input: []byte(`{"fee_recipient":"true"}`),
+			err:   "failed to decode fee recipient: encoding/hex: invalid byte: U+0074 't'",
+		},
+		{
+			name:  "GasLimitInvalid",
+			input: []byte(`{"gas_limit":"true"}`),
+			err:   "invalid gas limit: strconv.ParseUint: parsing \"true\": invalid syntax",
+		},
+		{
+			name:  "BuilderEnabledWrongType",
+			input: []byte(`{"builder_enabled":"no"}`),
+			err:   "invalid JSON: json: cannot unmarshal string into Go struct field configJSON.builder_enabled of type bool",
+		},
+		{
+			name:  "RelayInvalid",
+			input: []byte(`{"relays":{"https://relay1.com/":{"gas_limit":"true"}}}`),
+			err:   "invalid JSON: invalid gas limit: strconv.ParseUint: parsing \"true\": invalid syntax",
+		},
+		{
+			name:  "AllowedAndDeniedRelays",
+			input: []byte(`{"allowed_relays":["https://relay1.com/"],"denied_relays":["https://relay2.com/"]}`),
+			err:   "cannot specify both allowed and denied relays",
+		},
+		{
+			name:  "Minimal",
+			input: []byte(`{}`),
+		},
+		{
+			name:  "BuilderDisabled",
+			input: []byte(`{"builder_enabled":false}`),
+		},
+		{
+			name:  "Good",
+			input: []byte(`{"fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","grace":"1000","min_value":"0.5","builder_enabled":true,"reset_relays":true,"relays":{"https://relay1.com/":{"min_value":"0.2"},"https://relay2.com/":{"disabled":true}},"denied_relays":["https://relay3.com/"]}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res v3.Config
+			err := json.Unmarshal(test.input, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, string(test.input), res.String())
+			}
+		})
+	}
+}
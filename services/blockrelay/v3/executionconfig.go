@@ -0,0 +1,294 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+var version = 3
+
+// ExecutionConfig contains layered configuration for validators proposing
+// execution payloads.  Configuration is built up from the defaults, then
+// each matching account override in turn, then the validator override.
+type ExecutionConfig struct {
+	Version    int
+	Defaults   *Config
+	Accounts   []*AccountConfig
+	Validators map[phase0.BLSPubKey]*Config
+}
+
+type executionConfigJSON struct {
+	Version    int                `json:"version"`
+	Defaults   *Config            `json:"defaults,omitempty"`
+	Accounts   []*AccountConfig   `json:"accounts,omitempty"`
+	Validators map[string]*Config `json:"validators,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *ExecutionConfig) MarshalJSON() ([]byte, error) {
+	var validators map[string]*Config
+	if len(e.Validators) > 0 {
+		validators = make(map[string]*Config, len(e.Validators))
+		for pubkey, config := range e.Validators {
+			validators[fmt.Sprintf("%#x", pubkey)] = config
+		}
+	}
+
+	return json.Marshal(&executionConfigJSON{
+		Version:    version,
+		Defaults:   e.Defaults,
+		Accounts:   e.Accounts,
+		Validators: validators,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ExecutionConfig) UnmarshalJSON(input []byte) error {
+	var data executionConfigJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Version != version {
+		return fmt.Errorf("unexpected version %d", data.Version)
+	}
+
+	e.Version = data.Version
+	e.Defaults = data.Defaults
+	for i, accountConfig := range data.Accounts {
+		if accountConfig == nil {
+			return fmt.Errorf("account %d is missing configuration", i)
+		}
+	}
+	e.Accounts = data.Accounts
+	if len(data.Validators) > 0 {
+		e.Validators = make(map[phase0.BLSPubKey]*Config, len(data.Validators))
+		for key, config := range data.Validators {
+			tmp, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to decode validator %s", key))
+			}
+			if len(tmp) != phase0.PublicKeyLength {
+				return fmt.Errorf("incorrect length for validator %s", key)
+			}
+			var pubkey phase0.BLSPubKey
+			copy(pubkey[:], tmp)
+			if config == nil {
+				return fmt.Errorf("validator %s is missing configuration", key)
+			}
+			if _, exists := e.Validators[pubkey]; exists {
+				return fmt.Errorf("duplicate validator %s", key)
+			}
+			e.Validators[pubkey] = config
+		}
+	}
+
+	return nil
+}
+
+// resolution holds the configuration as it is built up through the layers.
+type resolution struct {
+	feeRecipient   bellatrix.ExecutionAddress
+	gasLimit       uint64
+	grace          time.Duration
+	minValue       decimal.Decimal
+	builderEnabled bool
+	relays         map[string]*RelayConfig
+}
+
+// ProposerConfig returns the proposer configuration for the given validator.
+func (e *ExecutionConfig) ProposerConfig(_ context.Context,
+	account e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+	fallbackFeeRecipient bellatrix.ExecutionAddress,
+	fallbackGasLimit uint64,
+	fallbackMinValue decimal.Decimal,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	res := &resolution{
+		feeRecipient:   fallbackFeeRecipient,
+		gasLimit:       fallbackGasLimit,
+		minValue:       fallbackMinValue,
+		builderEnabled: true,
+		relays:         make(map[string]*RelayConfig),
+	}
+
+	if e.Defaults != nil {
+		res.apply(e.Defaults)
+	}
+
+	if account != nil {
+		var accountName string
+		if provider, isProvider := account.(e2wtypes.AccountWalletProvider); isProvider {
+			accountName = fmt.Sprintf("%s/%s", provider.Wallet().Name(), account.Name())
+		} else {
+			accountName = fmt.Sprintf("<unknown>/%s", account.Name())
+		}
+		for _, accountConfig := range e.Accounts {
+			match, err := accountConfig.matches(accountName)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				res.apply(&accountConfig.Config)
+			}
+		}
+	}
+
+	if validatorConfig, exists := e.Validators[pubkey]; exists {
+		res.apply(validatorConfig)
+	}
+
+	return res.proposerConfig(), nil
+}
+
+// apply applies a layer of configuration to the resolution.
+func (r *resolution) apply(config *Config) {
+	// Values set at this level override relay-specific values from
+	// earlier levels.
+	if config.FeeRecipient != nil {
+		r.feeRecipient = *config.FeeRecipient
+		for _, relay := range r.relays {
+			relay.FeeRecipient = nil
+		}
+	}
+	if config.GasLimit != nil {
+		r.gasLimit = *config.GasLimit
+		for _, relay := range r.relays {
+			relay.GasLimit = nil
+		}
+	}
+	if config.Grace != nil {
+		r.grace = *config.Grace
+		for _, relay := range r.relays {
+			relay.Grace = nil
+		}
+	}
+	if config.MinValue != nil {
+		r.minValue = *config.MinValue
+		for _, relay := range r.relays {
+			relay.MinValue = nil
+		}
+	}
+	if config.BuilderEnabled != nil {
+		r.builderEnabled = *config.BuilderEnabled
+	}
+
+	if config.ResetRelays {
+		r.relays = make(map[string]*RelayConfig)
+	}
+	for address, relayConfig := range config.Relays {
+		if relayConfig == nil {
+			relayConfig = &RelayConfig{}
+		}
+		if relayConfig.Disabled {
+			delete(r.relays, address)
+			continue
+		}
+		relay, exists := r.relays[address]
+		if !exists {
+			relay = &RelayConfig{}
+			r.relays[address] = relay
+		}
+		relay.merge(relayConfig)
+	}
+
+	if len(config.AllowedRelays) > 0 {
+		allowed := make(map[string]struct{}, len(config.AllowedRelays))
+		for _, address := range config.AllowedRelays {
+			allowed[address] = struct{}{}
+		}
+		for address := range r.relays {
+			if _, exists := allowed[address]; !exists {
+				delete(r.relays, address)
+			}
+		}
+	}
+	for _, address := range config.DeniedRelays {
+		delete(r.relays, address)
+	}
+}
+
+// proposerConfig generates the proposer configuration from the resolution.
+func (r *resolution) proposerConfig() *beaconblockproposer.ProposerConfig {
+	config := &beaconblockproposer.ProposerConfig{
+		FeeRecipient: r.feeRecipient,
+		Relays:       make([]*beaconblockproposer.RelayConfig, 0, len(r.relays)),
+	}
+	if !r.builderEnabled {
+		return config
+	}
+
+	addresses := make([]string, 0, len(r.relays))
+	for address := range r.relays {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		relay := r.relays[address]
+		relayConfig := &beaconblockproposer.RelayConfig{
+			Address:      address,
+			PublicKey:    relay.PublicKey,
+			FeeRecipient: r.feeRecipient,
+			GasLimit:     r.gasLimit,
+			Grace:        r.grace,
+			MinValue:     r.minValue,
+			Location:     relay.Location,
+		}
+		if relay.FeeRecipient != nil {
+			relayConfig.FeeRecipient = *relay.FeeRecipient
+		}
+		if relay.Censoring != nil {
+			relayConfig.Censoring = *relay.Censoring
+		}
+		if relay.GasLimit != nil {
+			relayConfig.GasLimit = *relay.GasLimit
+		}
+		if relay.Grace != nil {
+			relayConfig.Grace = *relay.Grace
+		}
+		if relay.MinValue != nil {
+			relayConfig.MinValue = *relay.MinValue
+		}
+		config.Relays = append(config.Relays, relayConfig)
+	}
+
+	return config
+}
+
+// String provides a string representation of the struct.
+func (e *ExecutionConfig) String() string {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Sprintf("ERR: %v\n", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,493 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	v3 "github.com/attestantio/vouch/services/blockrelay/v3"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestExecutionConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name: "Empty",
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name:  "JSONBad",
+			input: []byte("[]"),
+			err:   "invalid JSON: json: cannot unmarshal array into Go value of type v3.executionConfigJSON",
+		},
+		{
+			name:  "VersionMissing",
+			input: []byte(`{"defaults":{"fee_recipient":"0x1111111111111111111111111111111111111111"}}`),
+			err:   "unexpected version 0",
+		},
+		{
+			name:  "VersionIncorrect",
+			input: []byte(`{"version":2,"defaults":{"fee_recipient":"0x1111111111111111111111111111111111111111"}}`),
+			err:   "unexpected version 2",
+		},
+		{
+			name:  "DefaultsInvalid",
+			input: []byte(`{"version":3,"defaults":{"fee_recipient":"true"}}`),
+			err:   "invalid JSON: failed to decode fee recipient: encoding/hex: invalid byte: U+0074 't'",
+		},
+		{
+			name:  "AccountMissing",
+			input: []byte(`{"version":3,"accounts":[{"fee_recipient":"0x1111111111111111111111111111111111111111"}]}`),
+			err:   "invalid JSON: account is missing",
+		},
+		{
+			name:  "AccountNull",
+			input: []byte(`{"version":3,"accounts":[null]}`),
+			err:   "account 0 is missing configuration",
+		},
+		{
+			name:  "ValidatorInvalid",
+			input: []byte(`{"version":3,"validators":{"true":{}}}`),
+			err:   "failed to decode validator true: encoding/hex: invalid byte: U+0074 't'",
+		},
+		{
+			name:  "ValidatorIncorrectLength",
+			input: []byte(`{"version":3,"validators":{"0x0101":{}}}`),
+			err:   "incorrect length for validator 0x0101",
+		},
+		{
+			name:  "ValidatorNull",
+			input: []byte(`{"version":3,"validators":{"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101":null}}`),
+			err:   "validator 0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101 is missing configuration",
+		},
+		{
+			name:  "ValidatorDuplicate",
+			input: []byte(`{"version":3,"validators":{"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101":{},"010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101":{}}}`),
+			err:   "duplicate validator 010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{
+			name:  "Minimal",
+			input: []byte(`{"version":3}`),
+		},
+		{
+			name:  "Good",
+			input: []byte(`{"version":3,"defaults":{"fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","min_value":"0.1","relays":{"https://relay1.com/":{"public_key":"0xac6e77dfe25ecd6110b8e780608cce0dab71fdd5ebea22a16c0205200f2f8e2e3ad3b71d3499c54ad14d6c21b41a37ae"}}},"accounts":[{"account":"Wallet 1/*","fee_recipient":"0x2222222222222222222222222222222222222222"},{"account":"Wallet 2/*","builder_enabled":false}],"validators":{"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101":{"min_value":"0.5"}}}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res v3.ExecutionConfig
+			err := json.Unmarshal(test.input, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, string(test.input), res.String())
+			}
+		})
+	}
+}
+
+func TestProposerConfig(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account1, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account 1", []byte("pass"))
+	require.NoError(t, err)
+	account2, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account 2", []byte("pass"))
+	require.NoError(t, err)
+
+	feeRecipient1 := bellatrix.ExecutionAddress{0x01}
+	feeRecipient2 := bellatrix.ExecutionAddress{0x02}
+	feeRecipient3 := bellatrix.ExecutionAddress{0x03}
+	feeRecipient4 := bellatrix.ExecutionAddress{0x04}
+
+	gasLimit1 := uint64(1000000)
+	gasLimit2 := uint64(2000000)
+	gasLimit3 := uint64(3000000)
+
+	grace1 := time.Second
+
+	minValue1 := decimal.New(1, 0)
+	minValue2 := decimal.New(2, 0)
+	minValue3 := decimal.New(3, 0)
+
+	builderDisabled := false
+	builderEnabled := true
+
+	censoring := true
+	notCensoring := false
+
+	pubkey1 := phase0.BLSPubKey{0x01}
+	pubkey2 := phase0.BLSPubKey{0x02}
+
+	defaults := &v3.Config{
+		FeeRecipient: &feeRecipient2,
+		GasLimit:     &gasLimit2,
+		MinValue:     &minValue1,
+		Relays: map[string]*v3.RelayConfig{
+			"https://relay1.com/": {
+				Grace: &grace1,
+			},
+			"https://relay2.com/": {
+				MinValue: &minValue2,
+				Location: "eu",
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		executionConfig *v3.ExecutionConfig
+		account         e2wtypes.Account
+		pubkey          phase0.BLSPubKey
+		expected        *beaconblockproposer.ProposerConfig
+		err             string
+	}{
+		{
+			name:            "Fallbacks",
+			executionConfig: &v3.ExecutionConfig{},
+			account:         account1,
+			pubkey:          pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays:       []*beaconblockproposer.RelayConfig{},
+			},
+		},
+		{
+			name: "Defaults",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient2,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						Grace:        grace1,
+						MinValue:     minValue1,
+					},
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						MinValue:     minValue2,
+						Location:     "eu",
+					},
+				},
+			},
+		},
+		{
+			name: "AccountNoMatch",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+				Accounts: []*v3.AccountConfig{
+					{
+						Account: "other wallet/*",
+						Config: v3.Config{
+							BuilderEnabled: &builderDisabled,
+						},
+					},
+				},
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient2,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						Grace:        grace1,
+						MinValue:     minValue1,
+					},
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						MinValue:     minValue2,
+						Location:     "eu",
+					},
+				},
+			},
+		},
+		{
+			name: "AccountsInherit",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+				Accounts: []*v3.AccountConfig{
+					{
+						Account: "test wallet/*",
+						Config: v3.Config{
+							FeeRecipient: &feeRecipient3,
+							MinValue:     &minValue3,
+						},
+					},
+					{
+						Account: "test wallet/test account ?",
+						Config: v3.Config{
+							GasLimit: &gasLimit3,
+							Relays: map[string]*v3.RelayConfig{
+								"https://relay2.com/": {
+									Disabled: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient3,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient3,
+						GasLimit:     gasLimit3,
+						Grace:        grace1,
+						MinValue:     minValue3,
+					},
+				},
+			},
+		},
+		{
+			name: "Validator",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+				Accounts: []*v3.AccountConfig{
+					{
+						Account: "test wallet/*",
+						Config: v3.Config{
+							FeeRecipient: &feeRecipient3,
+						},
+					},
+				},
+				Validators: map[phase0.BLSPubKey]*v3.Config{
+					pubkey1: {
+						FeeRecipient: &feeRecipient4,
+						ResetRelays:  true,
+						Relays: map[string]*v3.RelayConfig{
+							"https://relay3.com/": {
+								GasLimit: &gasLimit1,
+							},
+						},
+					},
+				},
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient4,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay3.com/",
+						FeeRecipient: feeRecipient4,
+						GasLimit:     gasLimit1,
+						MinValue:     minValue1,
+					},
+				},
+			},
+		},
+		{
+			name: "Censoring",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: &v3.Config{
+					FeeRecipient: &feeRecipient2,
+					GasLimit:     &gasLimit2,
+					MinValue:     &minValue1,
+					Relays: map[string]*v3.RelayConfig{
+						"https://relay1.com/": {
+							Censoring: &censoring,
+						},
+						"https://relay2.com/": {
+							Censoring: &censoring,
+						},
+					},
+				},
+				Validators: map[phase0.BLSPubKey]*v3.Config{
+					pubkey1: {
+						Relays: map[string]*v3.RelayConfig{
+							"https://relay2.com/": {
+								Censoring: &notCensoring,
+							},
+						},
+					},
+				},
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient2,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay1.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						MinValue:     minValue1,
+						Censoring:    true,
+					},
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						MinValue:     minValue1,
+					},
+				},
+			},
+		},
+		{
+			name: "ValidatorOther",
+			executionConfig: &v3.ExecutionConfig{
+				Validators: map[phase0.BLSPubKey]*v3.Config{
+					pubkey1: {
+						FeeRecipient: &feeRecipient4,
+					},
+				},
+			},
+			account: account2,
+			pubkey:  pubkey2,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient1,
+				Relays:       []*beaconblockproposer.RelayConfig{},
+			},
+		},
+		{
+			name: "BuilderDisabled",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+				Accounts: []*v3.AccountConfig{
+					{
+						Account: "test wallet/*",
+						Config: v3.Config{
+							FeeRecipient:   &feeRecipient3,
+							BuilderEnabled: &builderDisabled,
+						},
+					},
+				},
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient3,
+				Relays:       []*beaconblockproposer.RelayConfig{},
+			},
+		},
+		{
+			name: "BuilderReenabled",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+				Accounts: []*v3.AccountConfig{
+					{
+						Account: "test wallet/*",
+						Config: v3.Config{
+							BuilderEnabled: &builderDisabled,
+						},
+					},
+				},
+				Validators: map[phase0.BLSPubKey]*v3.Config{
+					pubkey1: {
+						BuilderEnabled: &builderEnabled,
+						AllowedRelays:  []string{"https://relay2.com/"},
+					},
+				},
+			},
+			account: account1,
+			pubkey:  pubkey1,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient2,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						MinValue:     minValue2,
+						Location:     "eu",
+					},
+				},
+			},
+		},
+		{
+			name: "DeniedRelays",
+			executionConfig: &v3.ExecutionConfig{
+				Defaults: defaults,
+				Accounts: []*v3.AccountConfig{
+					{
+						Account: "test wallet/test account 2",
+						Config: v3.Config{
+							DeniedRelays: []string{"https://relay1.com/"},
+						},
+					},
+				},
+			},
+			account: account2,
+			pubkey:  pubkey2,
+			expected: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient2,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "https://relay2.com/",
+						FeeRecipient: feeRecipient2,
+						GasLimit:     gasLimit2,
+						MinValue:     minValue2,
+						Location:     "eu",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.executionConfig.ProposerConfig(ctx, test.account, test.pubkey, feeRecipient1, gasLimit1, decimal.Zero)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+
+	// Ensure that resolution does not alter the configuration.
+	require.Equal(t, &grace1, defaults.Relays["https://relay1.com/"].Grace)
+	require.Equal(t, &minValue2, defaults.Relays["https://relay2.com/"].MinValue)
+}
@@ -0,0 +1,216 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var weiPerETH = decimal.New(1e18, 0)
+
+// RelayConfig contains the configuration for a relay at any level of the
+// execution configuration.  Values that are not present are inherited from
+// the enclosing configuration.
+type RelayConfig struct {
+	Disabled     bool
+	PublicKey    *phase0.BLSPubKey
+	FeeRecipient *bellatrix.ExecutionAddress
+	GasLimit     *uint64
+	Grace        *time.Duration
+	MinValue     *decimal.Decimal
+	Location     string
+	Censoring    *bool
+}
+
+type relayConfigJSON struct {
+	Disabled     bool   `json:"disabled,omitempty"`
+	PublicKey    string `json:"public_key,omitempty"`
+	FeeRecipient string `json:"fee_recipient,omitempty"`
+	GasLimit     string `json:"gas_limit,omitempty"`
+	Grace        string `json:"grace,omitempty"`
+	MinValue     string `json:"min_value,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Censoring    *bool  `json:"censoring,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *RelayConfig) MarshalJSON() ([]byte, error) {
+	var publicKey string
+	if c.PublicKey != nil {
+		publicKey = fmt.Sprintf("%#x", *c.PublicKey)
+	}
+	var feeRecipient string
+	if c.FeeRecipient != nil {
+		feeRecipient = fmt.Sprintf("%#x", *c.FeeRecipient)
+	}
+	var gasLimit string
+	if c.GasLimit != nil {
+		gasLimit = fmt.Sprintf("%d", *c.GasLimit)
+	}
+	var grace string
+	if c.Grace != nil {
+		grace = fmt.Sprintf("%d", c.Grace.Milliseconds())
+	}
+	var minValue string
+	if c.MinValue != nil {
+		minValue = fmt.Sprintf("%v", c.MinValue.Div(weiPerETH))
+	}
+
+	return json.Marshal(&relayConfigJSON{
+		Disabled:     c.Disabled,
+		PublicKey:    publicKey,
+		FeeRecipient: feeRecipient,
+		GasLimit:     gasLimit,
+		Grace:        grace,
+		MinValue:     minValue,
+		Location:     c.Location,
+		Censoring:    c.Censoring,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *RelayConfig) UnmarshalJSON(input []byte) error {
+	var data relayConfigJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	c.Disabled = data.Disabled
+	if data.PublicKey != "" {
+		tmp, err := hex.DecodeString(strings.TrimPrefix(data.PublicKey, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "failed to decode public key")
+		}
+		if len(tmp) != phase0.PublicKeyLength {
+			return errors.New("incorrect length for public key")
+		}
+		var publicKey phase0.BLSPubKey
+		copy(publicKey[:], tmp)
+		c.PublicKey = &publicKey
+	}
+	if data.FeeRecipient != "" {
+		feeRecipient, err := parseFeeRecipient(data.FeeRecipient)
+		if err != nil {
+			return err
+		}
+		c.FeeRecipient = feeRecipient
+	}
+	if data.GasLimit != "" {
+		gasLimit, err := strconv.ParseUint(data.GasLimit, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid gas limit")
+		}
+		c.GasLimit = &gasLimit
+	}
+	if data.Grace != "" {
+		grace, err := parseGrace(data.Grace)
+		if err != nil {
+			return err
+		}
+		c.Grace = grace
+	}
+	if data.MinValue != "" {
+		minValue, err := parseMinValue(data.MinValue)
+		if err != nil {
+			return err
+		}
+		c.MinValue = minValue
+	}
+	c.Location = data.Location
+	c.Censoring = data.Censoring
+
+	return nil
+}
+
+// String provides a string representation of the struct.
+func (c *RelayConfig) String() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Sprintf("ERR: %v\n", err)
+	}
+	return string(data)
+}
+
+// merge merges the values that are present in the supplied relay
+// configuration in to this relay configuration.
+func (c *RelayConfig) merge(other *RelayConfig) {
+	if other.PublicKey != nil {
+		c.PublicKey = other.PublicKey
+	}
+	if other.FeeRecipient != nil {
+		c.FeeRecipient = other.FeeRecipient
+	}
+	if other.GasLimit != nil {
+		c.GasLimit = other.GasLimit
+	}
+	if other.Grace != nil {
+		c.Grace = other.Grace
+	}
+	if other.MinValue != nil {
+		c.MinValue = other.MinValue
+	}
+	if other.Location != "" {
+		c.Location = other.Location
+	}
+	if other.Censoring != nil {
+		c.Censoring = other.Censoring
+	}
+}
+
+func parseFeeRecipient(input string) (*bellatrix.ExecutionAddress, error) {
+	tmp, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode fee recipient")
+	}
+	if len(tmp) != bellatrix.ExecutionAddressLength {
+		return nil, errors.New("incorrect length for fee recipient")
+	}
+	var feeRecipient bellatrix.ExecutionAddress
+	copy(feeRecipient[:], tmp)
+
+	return &feeRecipient, nil
+}
+
+func parseGrace(input string) (*time.Duration, error) {
+	tmp, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "grace invalid")
+	}
+	grace := time.Duration(tmp) * time.Millisecond
+
+	return &grace, nil
+}
+
+func parseMinValue(input string) (*decimal.Decimal, error) {
+	minValue, err := decimal.NewFromString(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "min value invalid")
+	}
+	if minValue.Sign() == -1 {
+		return nil, errors.New("min value cannot be negative")
+	}
+	minValue = minValue.Mul(weiPerETH)
+
+	return &minValue, nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	v3 "github.com/attestantio/vouch/services/blockrelay/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name: "Empty",
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name:  "JSONBad",
+			input: []byte("[]"),
+			err:   "invalid JSON: json: cannot unmarshal array into Go value of type v3.relayConfigJSON",
+		},
+		{
+			name:  "PublicKeyInvalid",
+			input: []byte(`{"public_key":"true"}`),
+			err:   "failed to decode public key: encoding/hex: invalid byte: U+0074 't'",
+		},
+		{
+			name:  "PublicKeyIncorrectLength",
+			input: []byte(`{"public_key":"0x1111"}`),
+			err:   "incorrect length for public key",
+		},
+		{
+			name:  "FeeRecipientInvalid",
+			input: []byte(`{"fee_recipient":"true"}`),
+			err:   "failed to decode fee recipient: encoding/hex: invalid byte: U+0074 't'",
+		},
+		{
+			name:  "FeeRecipientIncorrectLength",
+			input: []byte(`{"fee_recipient":"0x11111111111111111111111111111111111111"}`),
+			err:   "incorrect length for fee recipient",
+		},
+		{
+			name:  "GasLimitInvalid",
+			input: []byte(`{"gas_limit":"true"}`),
+			err:   "invalid gas limit: strconv.ParseUint: parsing \"true\": invalid syntax",
+		},
+		{
+			name:  "GraceInvalid",
+			input: []byte(`{"grace":"true"}`),
+			err:   "grace invalid: strconv.ParseUint: parsing \"true\": invalid syntax",
+		},
+		{
+			name:  "MinValueInvalid",
+			input: []byte(`{"min_value":"true"}`),
+			err:   "min value invalid: can't convert true to decimal: exponent is not numeric",
+		},
+		{
+			name:  "MinValueNegative",
+			input: []byte(`{"min_value":"-1"}`),
+			err:   "min value cannot be negative",
+		},
+		{
+			name:  "Good",
+			input: []byte(`{"public_key":"0xac6e77dfe25ecd6110b8e780608cce0dab71fdd5ebea22a16c0205200f2f8e2e3ad3b71d3499c54ad14d6c21b41a37ae","fee_recipient":"0x1111111111111111111111111111111111111111","gas_limit":"30000000","grace":"1000","min_value":"0.5","location":"eu"}`),
+		},
+		{
+			name:  "Minimal",
+			input: []byte(`{}`),
+		},
+		{
+			name:  "Censoring",
+			input: []byte(`{"censoring":true}`),
+		},
+		{
+			name:  "NotCensoring",
+			input: []byte(`{"censoring":false}`),
+		},
+		{
+			name:  "Disabled",
+			input: []byte(`{"disabled":true}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res v3.RelayConfig
+			err := json.Unmarshal(test.input, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, string(test.input), res.String())
+			}
+		})
+	}
+}
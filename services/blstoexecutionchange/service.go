@@ -0,0 +1,52 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blstoexecutionchange
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Service is the BLS to execution change service.
+type Service interface {
+	// PrepareChanges generates unsigned BLS to execution changes for the
+	// validators with the given public keys, changing their withdrawal
+	// credentials to the given execution address.  The withdrawal credentials
+	// of each validator are checked against the supplied withdrawal accounts,
+	// so this can be used as a dry run before signing.
+	PrepareChanges(ctx context.Context,
+		pubKeys []phase0.BLSPubKey,
+		withdrawalAccounts []e2wtypes.Account,
+		address bellatrix.ExecutionAddress,
+	) (
+		[]*capella.BLSToExecutionChange,
+		error,
+	)
+
+	// SignChanges signs BLS to execution changes with the matching withdrawal accounts.
+	SignChanges(ctx context.Context,
+		changes []*capella.BLSToExecutionChange,
+		withdrawalAccounts []e2wtypes.Account,
+	) (
+		[]*capella.SignedBLSToExecutionChange,
+		error,
+	)
+
+	// SubmitChanges broadcasts signed BLS to execution changes.
+	SubmitChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error
+}
@@ -0,0 +1,79 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// keystoreJSON is the subset of an EIP-2335 keystore used when loading withdrawal keys.
+type keystoreJSON struct {
+	Crypto map[string]any `json:"crypto"`
+}
+
+// WithdrawalAccounts decrypts EIP-2335 keystores holding withdrawal keys and
+// returns unlocked accounts for them.  The accounts are held in memory only.
+func WithdrawalAccounts(ctx context.Context, keystores [][]byte, passphrase string) ([]e2wtypes.Account, error) {
+	if len(keystores) == 0 {
+		return nil, errors.New("no withdrawal keystores supplied")
+	}
+
+	encryptor := keystorev4.New()
+	wallet, err := nd.CreateWallet(ctx, "withdrawal", scratch.New(), encryptor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create withdrawal wallet")
+	}
+	if err := wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to unlock withdrawal wallet")
+	}
+	importer := wallet.(e2wtypes.WalletAccountImporter)
+
+	accounts := make([]e2wtypes.Account, 0, len(keystores))
+	for i, keystore := range keystores {
+		data := &keystoreJSON{}
+		if err := json.Unmarshal(keystore, data); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid withdrawal keystore %d", i))
+		}
+		if data.Crypto == nil {
+			return nil, fmt.Errorf("withdrawal keystore %d missing crypto", i)
+		}
+		secret, err := encryptor.Decrypt(data.Crypto, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to decrypt withdrawal keystore %d", i))
+		}
+		privateKey, err := e2types.BLSPrivateKeyFromBytes(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid private key in withdrawal keystore %d", i))
+		}
+		account, err := importer.ImportAccount(ctx, fmt.Sprintf("%#x", privateKey.PublicKey().Marshal()), secret, []byte(passphrase))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to import withdrawal keystore %d", i))
+		}
+		if err := account.(e2wtypes.AccountLocker).Unlock(ctx, []byte(passphrase)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to unlock withdrawal keystore %d", i))
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
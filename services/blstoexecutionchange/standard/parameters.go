@@ -0,0 +1,99 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                       zerolog.Level
+	validatorsProvider             eth2client.ValidatorsProvider
+	blsToExecutionChangeSigner     signer.BLSToExecutionChangeSigner
+	blsToExecutionChangesSubmitter submitter.BLSToExecutionChangesSubmitter
+	batchSize                      int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithValidatorsProvider sets the validators provider.
+func WithValidatorsProvider(provider eth2client.ValidatorsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorsProvider = provider
+	})
+}
+
+// WithBLSToExecutionChangeSigner sets the BLS to execution change signer.
+// This is only required to sign changes.
+func WithBLSToExecutionChangeSigner(signer signer.BLSToExecutionChangeSigner) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blsToExecutionChangeSigner = signer
+	})
+}
+
+// WithBLSToExecutionChangesSubmitter sets the BLS to execution changes submitter.
+// This is only required to submit changes.
+func WithBLSToExecutionChangesSubmitter(submitter submitter.BLSToExecutionChangesSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blsToExecutionChangesSubmitter = submitter
+	})
+}
+
+// WithBatchSize sets the maximum number of changes submitted in a single request.
+func WithBatchSize(batchSize int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.batchSize = batchSize
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:  zerolog.GlobalLevel(),
+		batchSize: 256,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.validatorsProvider == nil {
+		return nil, errors.New("no validators provider specified")
+	}
+	if parameters.batchSize <= 0 {
+		return nil, errors.New("batch size must be greater than 0")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// blsWithdrawalPrefix is the first byte of BLS withdrawal credentials.
+const blsWithdrawalPrefix = byte(0x00)
+
+// Service generates, signs and broadcasts BLS to execution changes.
+type Service struct {
+	validatorsProvider             eth2client.ValidatorsProvider
+	blsToExecutionChangeSigner     signer.BLSToExecutionChangeSigner
+	blsToExecutionChangesSubmitter submitter.BLSToExecutionChangesSubmitter
+	batchSize                      int
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new BLS to execution change service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "blstoexecutionchange").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		validatorsProvider:             parameters.validatorsProvider,
+		blsToExecutionChangeSigner:     parameters.blsToExecutionChangeSigner,
+		blsToExecutionChangesSubmitter: parameters.blsToExecutionChangesSubmitter,
+		batchSize:                      parameters.batchSize,
+	}
+
+	return s, nil
+}
+
+// PrepareChanges generates unsigned BLS to execution changes for the
+// validators with the given public keys, changing their withdrawal
+// credentials to the given execution address.  All problems found with the
+// validators are reported together, so that they can be fixed in one pass.
+func (s *Service) PrepareChanges(ctx context.Context,
+	pubKeys []phase0.BLSPubKey,
+	withdrawalAccounts []e2wtypes.Account,
+	address bellatrix.ExecutionAddress,
+) (
+	[]*capella.BLSToExecutionChange,
+	error,
+) {
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no validators specified")
+	}
+	if address.IsZero() {
+		return nil, errors.New("no execution address specified")
+	}
+	accounts := accountsByCredentials(withdrawalAccounts)
+
+	validatorsResponse, err := s.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		PubKeys: pubKeys,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := make(map[phase0.BLSPubKey]*apiValidator, len(validatorsResponse.Data))
+	for index, validator := range validatorsResponse.Data {
+		validators[validator.Validator.PublicKey] = &apiValidator{
+			index:       index,
+			credentials: validator.Validator.WithdrawalCredentials,
+		}
+	}
+
+	problems := make([]string, 0)
+	changes := make([]*capella.BLSToExecutionChange, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		validator, exists := validators[pubKey]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("validator %#x is not known to the beacon node", pubKey))
+			continue
+		}
+		if !hasBLSCredentials(validator.credentials) {
+			problems = append(problems, fmt.Sprintf("validator %#x does not have BLS withdrawal credentials", pubKey))
+			continue
+		}
+		account, exists := accounts[string(validator.credentials)]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("no withdrawal key supplied for validator %#x (withdrawal credentials %#x)", pubKey, validator.credentials))
+			continue
+		}
+		change := &capella.BLSToExecutionChange{
+			ValidatorIndex:     validator.index,
+			ToExecutionAddress: address,
+		}
+		copy(change.FromBLSPubkey[:], account.PublicKey().Marshal())
+		changes = append(changes, change)
+		log.Trace().Str("pubkey", fmt.Sprintf("%#x", pubKey)).Uint64("validator_index", uint64(validator.index)).Msg("Withdrawal credentials match withdrawal key")
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("withdrawal credentials check failed: %s", strings.Join(problems, "; "))
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ValidatorIndex < changes[j].ValidatorIndex
+	})
+
+	return changes, nil
+}
+
+// SignChanges signs BLS to execution changes with the matching withdrawal accounts.
+func (s *Service) SignChanges(ctx context.Context,
+	changes []*capella.BLSToExecutionChange,
+	withdrawalAccounts []e2wtypes.Account,
+) (
+	[]*capella.SignedBLSToExecutionChange,
+	error,
+) {
+	if s.blsToExecutionChangeSigner == nil {
+		return nil, errors.New("signing not configured; cannot sign changes")
+	}
+
+	accounts := make(map[phase0.BLSPubKey]e2wtypes.Account, len(withdrawalAccounts))
+	for _, account := range withdrawalAccounts {
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], account.PublicKey().Marshal())
+		accounts[pubKey] = account
+	}
+
+	signedChanges := make([]*capella.SignedBLSToExecutionChange, 0, len(changes))
+	for _, change := range changes {
+		account, exists := accounts[change.FromBLSPubkey]
+		if !exists {
+			return nil, fmt.Errorf("no withdrawal account for %#x", change.FromBLSPubkey)
+		}
+		sig, err := s.blsToExecutionChangeSigner.SignBLSToExecutionChange(ctx, account, change)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to sign change for validator %d", change.ValidatorIndex))
+		}
+		signedChanges = append(signedChanges, &capella.SignedBLSToExecutionChange{
+			Message:   change,
+			Signature: sig,
+		})
+	}
+
+	return signedChanges, nil
+}
+
+// SubmitChanges broadcasts signed BLS to execution changes in batches.
+// All batches are submitted, even if earlier submissions fail.
+func (s *Service) SubmitChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	if s.blsToExecutionChangesSubmitter == nil {
+		return errors.New("submission not configured; cannot submit changes")
+	}
+	if len(changes) == 0 {
+		return errors.New("no changes supplied")
+	}
+
+	failed := 0
+	for start := 0; start < len(changes); start += s.batchSize {
+		end := start + s.batchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		if err := s.blsToExecutionChangesSubmitter.SubmitBLSToExecutionChanges(ctx, changes[start:end]); err != nil {
+			log.Warn().Int("batch_start", start).Int("batch_size", end-start).Err(err).Msg("Failed to submit BLS to execution changes")
+			failed += end - start
+			continue
+		}
+		log.Info().Int("batch_start", start).Int("batch_size", end-start).Msg("Submitted BLS to execution changes")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to submit %d of %d BLS to execution changes", failed, len(changes))
+	}
+
+	return nil
+}
+
+// apiValidator holds the information about a validator required to generate a change.
+type apiValidator struct {
+	index       phase0.ValidatorIndex
+	credentials []byte
+}
+
+// accountsByCredentials maps withdrawal accounts by the BLS withdrawal
+// credentials that they generate.
+func accountsByCredentials(accounts []e2wtypes.Account) map[string]e2wtypes.Account {
+	res := make(map[string]e2wtypes.Account, len(accounts))
+	for _, account := range accounts {
+		res[string(WithdrawalCredentials(account.PublicKey().Marshal()))] = account
+	}
+
+	return res
+}
+
+// WithdrawalCredentials returns the BLS withdrawal credentials for the given
+// withdrawal public key.
+func WithdrawalCredentials(pubKey []byte) []byte {
+	hash := sha256.Sum256(pubKey)
+	hash[0] = blsWithdrawalPrefix
+
+	return hash[:]
+}
+
+// hasBLSCredentials returns true if the credentials are BLS withdrawal credentials.
+func hasBLSCredentials(credentials []byte) bool {
+	return len(credentials) == 32 && credentials[0] == blsWithdrawalPrefix
+}
@@ -0,0 +1,292 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/blstoexecutionchange/standard"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// validatorsProvider provides a fixed set of validators.
+type validatorsProvider struct {
+	validators map[phase0.ValidatorIndex]*apiv1.Validator
+}
+
+// Validators provides the validators that match the requested public keys.
+func (p *validatorsProvider) Validators(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for index, validator := range p.validators {
+		for _, pubKey := range opts.PubKeys {
+			if validator.Validator.PublicKey == pubKey {
+				res[index] = validator
+			}
+		}
+	}
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data:     res,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func validator(pubKey phase0.BLSPubKey, credentials []byte) *apiv1.Validator {
+	return &apiv1.Validator{
+		Validator: &phase0.Validator{
+			PublicKey:             pubKey,
+			WithdrawalCredentials: credentials,
+		},
+	}
+}
+
+// withdrawalAccount creates a withdrawal account from a new key.
+func withdrawalAccount(ctx context.Context, t *testing.T) e2wtypes.Account {
+	t.Helper()
+
+	key, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+	crypto, err := keystorev4.New().Encrypt(key.Marshal(), "secret")
+	require.NoError(t, err)
+	keystore, err := json.Marshal(map[string]any{"crypto": crypto})
+	require.NoError(t, err)
+	accounts, err := standard.WithdrawalAccounts(ctx, [][]byte{keystore}, "secret")
+	require.NoError(t, err)
+
+	return accounts[0]
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "ValidatorsProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no validators provider specified",
+		},
+		{
+			name: "BatchSizeZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithValidatorsProvider(&validatorsProvider{}),
+				standard.WithBatchSize(0),
+			},
+			err: "problem with parameters: batch size must be greater than 0",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithValidatorsProvider(&validatorsProvider{}),
+				standard.WithBLSToExecutionChangeSigner(mocksigner.New()),
+				standard.WithBLSToExecutionChangesSubmitter(mock.NewBLSToExecutionChangesSubmitter()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPrepareChanges(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	account1 := withdrawalAccount(ctx, t)
+	account2 := withdrawalAccount(ctx, t)
+	executionCredentials := make([]byte, 32)
+	executionCredentials[0] = 0x01
+
+	provider := &validatorsProvider{
+		validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+			1: validator(phase0.BLSPubKey{0x01}, standard.WithdrawalCredentials(account1.PublicKey().Marshal())),
+			2: validator(phase0.BLSPubKey{0x02}, standard.WithdrawalCredentials(account2.PublicKey().Marshal())),
+			3: validator(phase0.BLSPubKey{0x03}, executionCredentials),
+		},
+	}
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithValidatorsProvider(provider),
+	)
+	require.NoError(t, err)
+
+	address := bellatrix.ExecutionAddress{0x01}
+
+	tests := []struct {
+		name     string
+		pubKeys  []phase0.BLSPubKey
+		accounts []e2wtypes.Account
+		address  bellatrix.ExecutionAddress
+		expected []phase0.ValidatorIndex
+		err      string
+	}{
+		{
+			name:     "NoValidators",
+			accounts: []e2wtypes.Account{account1},
+			address:  address,
+			err:      "no validators specified",
+		},
+		{
+			name:     "NoAddress",
+			pubKeys:  []phase0.BLSPubKey{{0x01}},
+			accounts: []e2wtypes.Account{account1},
+			err:      "no execution address specified",
+		},
+		{
+			name:     "UnknownValidator",
+			pubKeys:  []phase0.BLSPubKey{{0x04}},
+			accounts: []e2wtypes.Account{account1},
+			address:  address,
+			err:      "withdrawal credentials check failed: validator 0x040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000 is not known to the beacon node",
+		},
+		{
+			name:     "ExecutionCredentials",
+			pubKeys:  []phase0.BLSPubKey{{0x03}},
+			accounts: []e2wtypes.Account{account1},
+			address:  address,
+			err:      "withdrawal credentials check failed: validator 0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000 does not have BLS withdrawal credentials",
+		},
+		{
+			name:     "MissingWithdrawalKey",
+			pubKeys:  []phase0.BLSPubKey{{0x01}, {0x02}},
+			accounts: []e2wtypes.Account{account1},
+			address:  address,
+			err:      "withdrawal credentials check failed: no withdrawal key supplied for validator 0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:     "Good",
+			pubKeys:  []phase0.BLSPubKey{{0x02}, {0x01}},
+			accounts: []e2wtypes.Account{account1, account2},
+			address:  address,
+			expected: []phase0.ValidatorIndex{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := s.PrepareChanges(ctx, test.pubKeys, test.accounts, test.address)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				indices := make([]phase0.ValidatorIndex, 0, len(changes))
+				for _, change := range changes {
+					require.Equal(t, test.address, change.ToExecutionAddress)
+					indices = append(indices, change.ValidatorIndex)
+				}
+				require.Equal(t, test.expected, indices)
+				require.Equal(t, account1.PublicKey().Marshal(), changes[0].FromBLSPubkey[:])
+			}
+		})
+	}
+}
+
+func TestSignChanges(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	account := withdrawalAccount(ctx, t)
+	change := &capella.BLSToExecutionChange{
+		ValidatorIndex: 1,
+	}
+	copy(change.FromBLSPubkey[:], account.PublicKey().Marshal())
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithValidatorsProvider(&validatorsProvider{}),
+	)
+	require.NoError(t, err)
+	_, err = s.SignChanges(ctx, []*capella.BLSToExecutionChange{change}, []e2wtypes.Account{account})
+	require.EqualError(t, err, "signing not configured; cannot sign changes")
+
+	s, err = standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithValidatorsProvider(&validatorsProvider{}),
+		standard.WithBLSToExecutionChangeSigner(mocksigner.New()),
+	)
+	require.NoError(t, err)
+	_, err = s.SignChanges(ctx, []*capella.BLSToExecutionChange{change}, []e2wtypes.Account{})
+	require.ErrorContains(t, err, "no withdrawal account for")
+
+	signedChanges, err := s.SignChanges(ctx, []*capella.BLSToExecutionChange{change}, []e2wtypes.Account{account})
+	require.NoError(t, err)
+	require.Len(t, signedChanges, 1)
+	require.Equal(t, change, signedChanges[0].Message)
+}
+
+func TestSubmitChanges(t *testing.T) {
+	ctx := context.Background()
+
+	changes := []*capella.SignedBLSToExecutionChange{
+		{Message: &capella.BLSToExecutionChange{ValidatorIndex: 1}},
+		{Message: &capella.BLSToExecutionChange{ValidatorIndex: 2}},
+		{Message: &capella.BLSToExecutionChange{ValidatorIndex: 3}},
+	}
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithValidatorsProvider(&validatorsProvider{}),
+	)
+	require.NoError(t, err)
+	require.EqualError(t, s.SubmitChanges(ctx, changes), "submission not configured; cannot submit changes")
+
+	submitter := mock.NewBLSToExecutionChangesSubmitter()
+	s, err = standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithValidatorsProvider(&validatorsProvider{}),
+		standard.WithBLSToExecutionChangesSubmitter(submitter),
+		standard.WithBatchSize(2),
+	)
+	require.NoError(t, err)
+	require.EqualError(t, s.SubmitChanges(ctx, nil), "no changes supplied")
+	require.NoError(t, s.SubmitChanges(ctx, changes))
+	require.Equal(t, changes, submitter.Changes())
+
+	s, err = standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithValidatorsProvider(&validatorsProvider{}),
+		standard.WithBLSToExecutionChangesSubmitter(mock.NewErroringBLSToExecutionChangesSubmitter()),
+		standard.WithBatchSize(2),
+	)
+	require.NoError(t, err)
+	require.EqualError(t, s.SubmitChanges(ctx, changes), "failed to submit 3 of 3 BLS to execution changes")
+}
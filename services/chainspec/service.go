@@ -0,0 +1,35 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspec
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+)
+
+// Service provides the chain specification and fork schedule.
+type Service interface {
+	eth2client.SpecProvider
+	eth2client.ForkScheduleProvider
+}
+
+// ChangeHandler is called when the chain specification or fork schedule changes.
+type ChangeHandler func(ctx context.Context)
+
+// ChangeNotifier notifies interested parties of changes to the chain specification or fork schedule.
+type ChangeNotifier interface {
+	// AddChangeHandler adds a handler to be called when the chain specification or fork schedule changes.
+	AddChangeHandler(handler ChangeHandler)
+}
@@ -0,0 +1,110 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// applicationDomainType is the domain type for application domains, which do
+// not include the genesis validators root.
+var applicationDomainType = phase0.DomainType{0x00, 0x00, 0x00, 0x01}
+
+// Domain provides a domain for a given domain type at a given epoch, using
+// the cached fork schedule.
+func (s *Service) Domain(ctx context.Context,
+	domainType phase0.DomainType,
+	epoch phase0.Epoch,
+) (
+	phase0.Domain,
+	error,
+) {
+	s.mu.RLock()
+	if len(s.forkSchedule) == 0 {
+		s.mu.RUnlock()
+		return phase0.Domain{}, errors.New("no fork schedule")
+	}
+	fork := s.forkSchedule[0]
+	for _, scheduledFork := range s.forkSchedule {
+		if scheduledFork.Epoch > epoch {
+			break
+		}
+		fork = scheduledFork
+	}
+	forkVersion := fork.CurrentVersion
+	if epoch < fork.Epoch {
+		forkVersion = fork.PreviousVersion
+	}
+	s.mu.RUnlock()
+
+	return s.domain(ctx, domainType, forkVersion)
+}
+
+// GenesisDomain provides a domain for a given domain type at genesis.
+func (s *Service) GenesisDomain(ctx context.Context,
+	domainType phase0.DomainType,
+) (
+	phase0.Domain,
+	error,
+) {
+	s.mu.RLock()
+	if len(s.forkSchedule) == 0 {
+		s.mu.RUnlock()
+		return phase0.Domain{}, errors.New("no fork schedule")
+	}
+	forkVersion := s.forkSchedule[0].CurrentVersion
+	s.mu.RUnlock()
+
+	return s.domain(ctx, domainType, forkVersion)
+}
+
+func (s *Service) domain(ctx context.Context,
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+) (
+	phase0.Domain,
+	error,
+) {
+	forkData := &phase0.ForkData{
+		CurrentVersion: forkVersion,
+	}
+
+	if !bytes.Equal(domainType[:], applicationDomainType[:]) {
+		// Use the chain's genesis validators root for non-application domain types.
+		if s.genesisProvider == nil {
+			return phase0.Domain{}, errors.New("no genesis provider")
+		}
+		response, err := s.genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+		if err != nil {
+			return phase0.Domain{}, errors.Wrap(err, "failed to obtain genesis")
+		}
+		forkData.GenesisValidatorsRoot = response.Data.GenesisValidatorsRoot
+	}
+
+	root, err := forkData.HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate signature domain")
+	}
+
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], root[:])
+
+	return domain, nil
+}
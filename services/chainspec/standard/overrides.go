@@ -0,0 +1,107 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// applyForkEpochOverrides returns copies of the specification and fork
+// schedule with the fork epoch overrides applied.
+func (s *Service) applyForkEpochOverrides(spec map[string]any,
+	forkSchedule []*phase0.Fork,
+) (
+	map[string]any,
+	[]*phase0.Fork,
+	error,
+) {
+	if len(s.forkEpochOverrides) == 0 {
+		return spec, forkSchedule, nil
+	}
+
+	// Copy the specification and fork schedule, as they may be shared with the providers.
+	res := make(map[string]any, len(spec))
+	for k, v := range spec {
+		res[k] = v
+	}
+	schedule := make([]*phase0.Fork, len(forkSchedule))
+	for i, fork := range forkSchedule {
+		schedule[i] = &phase0.Fork{
+			PreviousVersion: fork.PreviousVersion,
+			CurrentVersion:  fork.CurrentVersion,
+			Epoch:           fork.Epoch,
+		}
+	}
+
+	for fork, epoch := range s.forkEpochOverrides {
+		if fork == "GENESIS" {
+			return nil, nil, fmt.Errorf("cannot override epoch of fork %s", fork)
+		}
+		tmp, exists := res[fmt.Sprintf("%s_FORK_VERSION", fork)]
+		if !exists {
+			return nil, nil, fmt.Errorf("unknown fork %s in fork epoch overrides", fork)
+		}
+		version, isVersion := tmp.(phase0.Version)
+		if !isVersion {
+			return nil, nil, fmt.Errorf("%s_FORK_VERSION of unexpected type", fork)
+		}
+
+		res[fmt.Sprintf("%s_FORK_EPOCH", fork)] = epoch
+
+		found := false
+		for _, scheduledFork := range schedule {
+			if scheduledFork.CurrentVersion == version {
+				scheduledFork.Epoch = epoch
+				found = true
+			}
+		}
+		if !found {
+			// The fork is not in the provider's schedule, so add it.
+			schedule = append(schedule, &phase0.Fork{
+				CurrentVersion: version,
+				Epoch:          epoch,
+			})
+		}
+	}
+
+	// Overrides can change the order of forks, so rebuild the chain of versions.
+	sort.SliceStable(schedule, func(i int, j int) bool {
+		return schedule[i].Epoch < schedule[j].Epoch
+	})
+	for i := range schedule {
+		if i == 0 {
+			schedule[i].PreviousVersion = schedule[i].CurrentVersion
+		} else {
+			schedule[i].PreviousVersion = schedule[i-1].CurrentVersion
+		}
+	}
+
+	return res, schedule, nil
+}
+
+// logForkEpochOverrides logs the fork epoch overrides that are active.
+func (s *Service) logForkEpochOverrides() {
+	forks := make([]string, 0, len(s.forkEpochOverrides))
+	for fork := range s.forkEpochOverrides {
+		forks = append(forks, fork)
+	}
+	sort.Strings(forks)
+
+	for _, fork := range forks {
+		log.Warn().Str("fork", fork).Uint64("epoch", uint64(s.forkEpochOverrides[fork])).Msg("Fork epoch override active; epoch supplied by beacon node is ignored")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chainspec"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service caches the chain specification and fork schedule, refreshing them
+// periodically and notifying handlers of any changes.
+type Service struct {
+	specProvider         eth2client.SpecProvider
+	forkScheduleProvider eth2client.ForkScheduleProvider
+	genesisProvider      eth2client.GenesisProvider
+	refreshInterval      time.Duration
+	forkEpochOverrides   map[string]phase0.Epoch
+
+	mu           sync.RWMutex
+	spec         map[string]any
+	forkSchedule []*phase0.Fork
+
+	handlersMu sync.Mutex
+	handlers   []chainspec.ChangeHandler
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new chain specification service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "chainspec").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	forkEpochOverrides := make(map[string]phase0.Epoch, len(parameters.forkEpochOverrides))
+	for fork, epoch := range parameters.forkEpochOverrides {
+		forkEpochOverrides[strings.ToUpper(fork)] = epoch
+	}
+
+	s := &Service{
+		specProvider:         parameters.specProvider,
+		forkScheduleProvider: parameters.forkScheduleProvider,
+		genesisProvider:      parameters.genesisProvider,
+		refreshInterval:      parameters.refreshInterval,
+		forkEpochOverrides:   forkEpochOverrides,
+		handlers:             make([]chainspec.ChangeHandler, 0),
+	}
+
+	// Carry out initial fetch inline, as other modules need this information.
+	if _, err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.logForkEpochOverrides()
+
+	go s.refresher(ctx)
+
+	return s, nil
+}
+
+// Spec provides the cached chain specification.
+func (s *Service) Spec(_ context.Context,
+	_ *api.SpecOpts,
+) (
+	*api.Response[map[string]any],
+	error,
+) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Return a copy, so that callers cannot alter the cached values.
+	spec := make(map[string]any, len(s.spec))
+	for k, v := range s.spec {
+		spec[k] = v
+	}
+
+	return &api.Response[map[string]any]{
+		Data:     spec,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// ForkSchedule provides the cached fork schedule.
+func (s *Service) ForkSchedule(_ context.Context,
+	_ *api.ForkScheduleOpts,
+) (
+	*api.Response[[]*phase0.Fork],
+	error,
+) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	forkSchedule := make([]*phase0.Fork, len(s.forkSchedule))
+	for i, fork := range s.forkSchedule {
+		forkSchedule[i] = &phase0.Fork{
+			PreviousVersion: fork.PreviousVersion,
+			CurrentVersion:  fork.CurrentVersion,
+			Epoch:           fork.Epoch,
+		}
+	}
+
+	return &api.Response[[]*phase0.Fork]{
+		Data:     forkSchedule,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// AddChangeHandler adds a handler to be called when the chain specification or fork schedule changes.
+func (s *Service) AddChangeHandler(handler chainspec.ChangeHandler) {
+	s.handlersMu.Lock()
+	s.handlers = append(s.handlers, handler)
+	s.handlersMu.Unlock()
+}
+
+// refresher periodically refreshes the chain specification and fork schedule.
+func (s *Service) refresher(ctx context.Context) {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.refresh(ctx)
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to refresh chain specification")
+				continue
+			}
+			if changed {
+				s.notifyHandlers(ctx)
+			}
+		}
+	}
+}
+
+// refresh fetches the chain specification and fork schedule, returning true
+// if either has changed since the previous fetch.
+func (s *Service) refresh(ctx context.Context) (bool, error) {
+	specResponse, err := s.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain spec")
+	}
+	forkScheduleResponse, err := s.forkScheduleProvider.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain fork schedule")
+	}
+	spec, forkSchedule, err := s.applyForkEpochOverrides(specResponse.Data, forkScheduleResponse.Data)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.spec == nil {
+		// Initial fetch.
+		s.spec = spec
+		s.forkSchedule = forkSchedule
+		log.Trace().Int("spec_items", len(s.spec)).Int("forks", len(s.forkSchedule)).Msg("Obtained chain specification")
+
+		return false, nil
+	}
+
+	changed := false
+	if changedKeys := changedSpecKeys(s.spec, spec); len(changedKeys) > 0 {
+		log.Warn().Strs("keys", changedKeys).Msg("Chain specification changed; restart Vouch to ensure that all services use the new values")
+		s.spec = spec
+		changed = true
+	}
+	if !forkSchedulesEqual(s.forkSchedule, forkSchedule) {
+		log.Warn().Msg("Fork schedule changed")
+		s.forkSchedule = forkSchedule
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// notifyHandlers notifies the handlers of a change.
+func (s *Service) notifyHandlers(ctx context.Context) {
+	s.handlersMu.Lock()
+	handlers := make([]chainspec.ChangeHandler, len(s.handlers))
+	copy(handlers, s.handlers)
+	s.handlersMu.Unlock()
+
+	for _, handler := range handlers {
+		handler(ctx)
+	}
+}
+
+// changedSpecKeys returns the sorted keys that differ between two specifications.
+func changedSpecKeys(oldSpec map[string]any, newSpec map[string]any) []string {
+	keys := make([]string, 0)
+	for k, v := range newSpec {
+		if oldV, exists := oldSpec[k]; !exists || !reflect.DeepEqual(oldV, v) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range oldSpec {
+		if _, exists := newSpec[k]; !exists {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// forkSchedulesEqual returns true if the two fork schedules are the same.
+func forkSchedulesEqual(schedule1 []*phase0.Fork, schedule2 []*phase0.Fork) bool {
+	if len(schedule1) != len(schedule2) {
+		return false
+	}
+	for i := range schedule1 {
+		if schedule1[i].Epoch != schedule2[i].Epoch ||
+			!bytes.Equal(schedule1[i].PreviousVersion[:], schedule2[i].PreviousVersion[:]) ||
+			!bytes.Equal(schedule1[i].CurrentVersion[:], schedule2[i].CurrentVersion[:]) {
+			return false
+		}
+	}
+
+	return true
+}
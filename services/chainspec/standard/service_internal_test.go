@@ -0,0 +1,449 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// changingProvider provides a spec and fork schedule that can be altered.
+type changingProvider struct {
+	mu           sync.Mutex
+	spec         map[string]any
+	forkSchedule []*phase0.Fork
+}
+
+func (p *changingProvider) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	spec := make(map[string]any, len(p.spec))
+	for k, v := range p.spec {
+		spec[k] = v
+	}
+
+	return &api.Response[map[string]any]{Data: spec}, nil
+}
+
+func (*changingProvider) Genesis(_ context.Context, _ *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+	return &api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisValidatorsRoot: phase0.Root{0x01, 0x02, 0x03},
+		},
+	}, nil
+}
+
+func (p *changingProvider) ForkSchedule(_ context.Context, _ *api.ForkScheduleOpts) (*api.Response[[]*phase0.Fork], error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	forkSchedule := make([]*phase0.Fork, len(p.forkSchedule))
+	copy(forkSchedule, p.forkSchedule)
+
+	return &api.Response[[]*phase0.Fork]{Data: forkSchedule}, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	provider := &changingProvider{}
+
+	tests := []struct {
+		name   string
+		params []Parameter
+		err    string
+	}{
+		{
+			name: "SpecProviderMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithForkScheduleProvider(provider),
+			},
+			err: "problem with parameters: no spec provider specified",
+		},
+		{
+			name: "ForkScheduleProviderMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(provider),
+			},
+			err: "problem with parameters: no fork schedule provider specified",
+		},
+		{
+			name: "RefreshIntervalNegative",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(provider),
+				WithForkScheduleProvider(provider),
+				WithRefreshInterval(-time.Second),
+			},
+			err: "problem with parameters: refresh interval must be positive",
+		},
+		{
+			name: "ForkEpochOverrideEmptyName",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(provider),
+				WithForkScheduleProvider(provider),
+				WithGenesisProvider(provider),
+				WithForkEpochOverrides(map[string]phase0.Epoch{"": 10}),
+			},
+			err: "problem with parameters: fork epoch override with empty fork name",
+		},
+		{
+			name: "ForkEpochOverrideGenesisProviderMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(provider),
+				WithForkScheduleProvider(provider),
+				WithForkEpochOverrides(map[string]phase0.Epoch{"altair": 10}),
+			},
+			err: "problem with parameters: no genesis provider specified",
+		},
+		{
+			name: "Good",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(provider),
+				WithForkScheduleProvider(provider),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	provider := &changingProvider{
+		spec: map[string]any{
+			"SECONDS_PER_SLOT":  12 * time.Second,
+			"SLOTS_PER_EPOCH":   uint64(32),
+			"GENESIS_FORK_NAME": []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		forkSchedule: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x00},
+				Epoch:           0,
+			},
+		},
+	}
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithSpecProvider(provider),
+		WithForkScheduleProvider(provider),
+		WithRefreshInterval(time.Hour),
+	)
+	require.NoError(t, err)
+
+	notifications := 0
+	s.AddChangeHandler(func(_ context.Context) {
+		notifications++
+	})
+
+	// No changes.
+	changed, err := s.refresh(ctx)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// Altering the returned spec should not alter the cached spec.
+	specResponse, err := s.Spec(ctx, &api.SpecOpts{})
+	require.NoError(t, err)
+	specResponse.Data["SLOTS_PER_EPOCH"] = uint64(1)
+	specResponse, err = s.Spec(ctx, &api.SpecOpts{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), specResponse.Data["SLOTS_PER_EPOCH"])
+
+	// Change the spec.
+	provider.mu.Lock()
+	provider.spec["ALTAIR_FORK_EPOCH"] = phase0.Epoch(10)
+	provider.mu.Unlock()
+	changed, err = s.refresh(ctx)
+	require.NoError(t, err)
+	require.True(t, changed)
+	specResponse, err = s.Spec(ctx, &api.SpecOpts{})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(10), specResponse.Data["ALTAIR_FORK_EPOCH"])
+
+	// Change the fork schedule.
+	provider.mu.Lock()
+	provider.forkSchedule = append(provider.forkSchedule, &phase0.Fork{
+		PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+		CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x00},
+		Epoch:           10,
+	})
+	provider.mu.Unlock()
+	changed, err = s.refresh(ctx)
+	require.NoError(t, err)
+	require.True(t, changed)
+	forkScheduleResponse, err := s.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	require.NoError(t, err)
+	require.Len(t, forkScheduleResponse.Data, 2)
+
+	s.notifyHandlers(ctx)
+	require.Equal(t, 1, notifications)
+}
+
+func TestChangedSpecKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldSpec  map[string]any
+		newSpec  map[string]any
+		expected []string
+	}{
+		{
+			name:     "Empty",
+			oldSpec:  map[string]any{},
+			newSpec:  map[string]any{},
+			expected: []string{},
+		},
+		{
+			name:     "Same",
+			oldSpec:  map[string]any{"A": uint64(1), "B": []byte{0x01}},
+			newSpec:  map[string]any{"A": uint64(1), "B": []byte{0x01}},
+			expected: []string{},
+		},
+		{
+			name:     "Changed",
+			oldSpec:  map[string]any{"A": uint64(1), "B": []byte{0x01}},
+			newSpec:  map[string]any{"A": uint64(1), "B": []byte{0x02}},
+			expected: []string{"B"},
+		},
+		{
+			name:     "AddedAndRemoved",
+			oldSpec:  map[string]any{"A": uint64(1), "C": uint64(3)},
+			newSpec:  map[string]any{"A": uint64(1), "B": uint64(2)},
+			expected: []string{"B", "C"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, changedSpecKeys(test.oldSpec, test.newSpec))
+		})
+	}
+}
+
+func TestForkEpochOverrides(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genesisVersion := phase0.Version{0x00, 0x00, 0x00, 0x00}
+	altairVersion := phase0.Version{0x01, 0x00, 0x00, 0x00}
+	bellatrixVersion := phase0.Version{0x02, 0x00, 0x00, 0x00}
+	capellaVersion := phase0.Version{0x03, 0x00, 0x00, 0x00}
+	newProvider := func() *changingProvider {
+		return &changingProvider{
+			spec: map[string]any{
+				"GENESIS_FORK_VERSION":   genesisVersion,
+				"ALTAIR_FORK_VERSION":    altairVersion,
+				"ALTAIR_FORK_EPOCH":      phase0.Epoch(10),
+				"BELLATRIX_FORK_VERSION": bellatrixVersion,
+				"BELLATRIX_FORK_EPOCH":   phase0.Epoch(20),
+				"CAPELLA_FORK_VERSION":   capellaVersion,
+				"CAPELLA_FORK_EPOCH":     phase0.Epoch(0xffffffffffffffff),
+			},
+			forkSchedule: []*phase0.Fork{
+				{PreviousVersion: genesisVersion, CurrentVersion: genesisVersion, Epoch: 0},
+				{PreviousVersion: genesisVersion, CurrentVersion: altairVersion, Epoch: 10},
+				{PreviousVersion: altairVersion, CurrentVersion: bellatrixVersion, Epoch: 20},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		overrides            map[string]phase0.Epoch
+		err                  string
+		expectedEpochs       map[string]phase0.Epoch
+		expectedForkSchedule []*phase0.Fork
+	}{
+		{
+			name: "None",
+			expectedEpochs: map[string]phase0.Epoch{
+				"ALTAIR_FORK_EPOCH":    10,
+				"BELLATRIX_FORK_EPOCH": 20,
+			},
+			expectedForkSchedule: []*phase0.Fork{
+				{PreviousVersion: genesisVersion, CurrentVersion: genesisVersion, Epoch: 0},
+				{PreviousVersion: genesisVersion, CurrentVersion: altairVersion, Epoch: 10},
+				{PreviousVersion: altairVersion, CurrentVersion: bellatrixVersion, Epoch: 20},
+			},
+		},
+		{
+			name:      "UnknownFork",
+			overrides: map[string]phase0.Epoch{"unknown": 5},
+			err:       "unknown fork UNKNOWN in fork epoch overrides",
+		},
+		{
+			name:      "Genesis",
+			overrides: map[string]phase0.Epoch{"genesis": 5},
+			err:       "cannot override epoch of fork GENESIS",
+		},
+		{
+			name:      "Scheduled",
+			overrides: map[string]phase0.Epoch{"bellatrix": 15},
+			expectedEpochs: map[string]phase0.Epoch{
+				"ALTAIR_FORK_EPOCH":    10,
+				"BELLATRIX_FORK_EPOCH": 15,
+			},
+			expectedForkSchedule: []*phase0.Fork{
+				{PreviousVersion: genesisVersion, CurrentVersion: genesisVersion, Epoch: 0},
+				{PreviousVersion: genesisVersion, CurrentVersion: altairVersion, Epoch: 10},
+				{PreviousVersion: altairVersion, CurrentVersion: bellatrixVersion, Epoch: 15},
+			},
+		},
+		{
+			name:      "Unscheduled",
+			overrides: map[string]phase0.Epoch{"CAPELLA": 30},
+			expectedEpochs: map[string]phase0.Epoch{
+				"BELLATRIX_FORK_EPOCH": 20,
+				"CAPELLA_FORK_EPOCH":   30,
+			},
+			expectedForkSchedule: []*phase0.Fork{
+				{PreviousVersion: genesisVersion, CurrentVersion: genesisVersion, Epoch: 0},
+				{PreviousVersion: genesisVersion, CurrentVersion: altairVersion, Epoch: 10},
+				{PreviousVersion: altairVersion, CurrentVersion: bellatrixVersion, Epoch: 20},
+				{PreviousVersion: bellatrixVersion, CurrentVersion: capellaVersion, Epoch: 30},
+			},
+		},
+		{
+			name:      "Reordered",
+			overrides: map[string]phase0.Epoch{"altair": 0, "bellatrix": 0},
+			expectedEpochs: map[string]phase0.Epoch{
+				"ALTAIR_FORK_EPOCH":    0,
+				"BELLATRIX_FORK_EPOCH": 0,
+			},
+			expectedForkSchedule: []*phase0.Fork{
+				{PreviousVersion: genesisVersion, CurrentVersion: genesisVersion, Epoch: 0},
+				{PreviousVersion: genesisVersion, CurrentVersion: altairVersion, Epoch: 0},
+				{PreviousVersion: altairVersion, CurrentVersion: bellatrixVersion, Epoch: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := newProvider()
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(provider),
+				WithForkScheduleProvider(provider),
+				WithGenesisProvider(provider),
+				WithForkEpochOverrides(test.overrides),
+			)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			specResponse, err := s.Spec(ctx, &api.SpecOpts{})
+			require.NoError(t, err)
+			for k, v := range test.expectedEpochs {
+				require.Equal(t, v, specResponse.Data[k], k)
+			}
+			forkScheduleResponse, err := s.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+			require.NoError(t, err)
+			require.Equal(t, test.expectedForkSchedule, forkScheduleResponse.Data)
+
+			// Provider values should not have been altered.
+			require.Equal(t, phase0.Epoch(20), provider.spec["BELLATRIX_FORK_EPOCH"])
+			require.Equal(t, phase0.Epoch(20), provider.forkSchedule[2].Epoch)
+
+			// Changes from the provider to overridden forks should not be seen.
+			provider.mu.Lock()
+			provider.spec["BELLATRIX_FORK_EPOCH"] = phase0.Epoch(25)
+			provider.forkSchedule[2].Epoch = 25
+			provider.mu.Unlock()
+			changed, err := s.refresh(ctx)
+			require.NoError(t, err)
+			_, bellatrixOverridden := test.overrides["bellatrix"]
+			require.Equal(t, !bellatrixOverridden, changed)
+		})
+	}
+}
+
+func TestDomain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genesisVersion := phase0.Version{0x00, 0x00, 0x00, 0x00}
+	altairVersion := phase0.Version{0x01, 0x00, 0x00, 0x00}
+	provider := &changingProvider{
+		spec: map[string]any{
+			"GENESIS_FORK_VERSION": genesisVersion,
+			"ALTAIR_FORK_VERSION":  altairVersion,
+			"ALTAIR_FORK_EPOCH":    phase0.Epoch(10),
+		},
+		forkSchedule: []*phase0.Fork{
+			{PreviousVersion: genesisVersion, CurrentVersion: genesisVersion, Epoch: 0},
+			{PreviousVersion: genesisVersion, CurrentVersion: altairVersion, Epoch: 10},
+		},
+	}
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithSpecProvider(provider),
+		WithForkScheduleProvider(provider),
+		WithGenesisProvider(provider),
+		WithForkEpochOverrides(map[string]phase0.Epoch{"altair": 5}),
+	)
+	require.NoError(t, err)
+
+	expectedDomain := func(domainType phase0.DomainType, version phase0.Version, genesisValidatorsRoot phase0.Root) phase0.Domain {
+		root, err := (&phase0.ForkData{CurrentVersion: version, GenesisValidatorsRoot: genesisValidatorsRoot}).HashTreeRoot()
+		require.NoError(t, err)
+		var domain phase0.Domain
+		copy(domain[:], domainType[:])
+		copy(domain[4:], root[:])
+		return domain
+	}
+
+	attesterDomainType := phase0.DomainType{0x01, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := phase0.Root{0x01, 0x02, 0x03}
+
+	// Before the overridden fork epoch.
+	domain, err := s.Domain(ctx, attesterDomainType, 4)
+	require.NoError(t, err)
+	require.Equal(t, expectedDomain(attesterDomainType, genesisVersion, genesisValidatorsRoot), domain)
+
+	// At the overridden fork epoch.
+	domain, err = s.Domain(ctx, attesterDomainType, 5)
+	require.NoError(t, err)
+	require.Equal(t, expectedDomain(attesterDomainType, altairVersion, genesisValidatorsRoot), domain)
+
+	// Application domains use the genesis fork version and no genesis validators root.
+	domain, err = s.GenesisDomain(ctx, applicationDomainType)
+	require.NoError(t, err)
+	require.Equal(t, expectedDomain(applicationDomainType, genesisVersion, phase0.Root{}), domain)
+}
@@ -0,0 +1,36 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mock is a mock circuit breaker.
+package mock
+
+import (
+	"context"
+)
+
+// Service is a mock circuit breaker.
+type Service struct {
+	tripped bool
+}
+
+// New creates a new mock circuit breaker, tripped as specified.
+func New(tripped bool) *Service {
+	return &Service{
+		tripped: tripped,
+	}
+}
+
+// Tripped returns true if the circuit breaker has tripped.
+func (s *Service) Tripped(_ context.Context) bool {
+	return s.tripped
+}
@@ -0,0 +1,26 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circuitbreaker stops the use of MEV relays when the chain is unstable.
+package circuitbreaker
+
+import (
+	"context"
+)
+
+// Service is the circuit breaker service.
+type Service interface {
+	// Tripped returns true if the chain is unstable, in which case blocks
+	// should be built locally rather than obtained from MEV relays.
+	Tripped(ctx context.Context) bool
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	trippedGauge prometheus.Gauge
+	tripsCounter prometheus.Counter
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if trippedGauge != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	trippedGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "circuitbreaker",
+		Name:      "tripped",
+		Help:      "1 if the circuit breaker has tripped and blocks are built locally, otherwise 0.",
+	})
+	if err := prometheus.Register(trippedGauge); err != nil {
+		return err
+	}
+
+	tripsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "circuitbreaker",
+		Name:      "trips_total",
+		Help:      "The number of times the circuit breaker has tripped.",
+	})
+	return prometheus.Register(tripsCounter)
+}
+
+func monitorTripped(tripped bool) {
+	if trippedGauge != nil {
+		if tripped {
+			trippedGauge.Set(1)
+		} else {
+			trippedGauge.Set(0)
+		}
+	}
+}
+
+func monitorTrip() {
+	if tripsCounter != nil {
+		tripsCounter.Inc()
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel            zerolog.Level
+	monitor             metrics.Service
+	scheduler           scheduler.Service
+	chainTime           chaintime.Service
+	eventsProvider      consensusclient.EventsProvider
+	finalityProvider    consensusclient.FinalityProvider
+	missedSlots         uint64
+	window              uint64
+	maxFinalityDistance uint64
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithEventsProvider sets the events provider, used to track blocks.
+func WithEventsProvider(provider consensusclient.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventsProvider = provider
+	})
+}
+
+// WithFinalityProvider sets the finality provider.
+func WithFinalityProvider(provider consensusclient.FinalityProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.finalityProvider = provider
+	})
+}
+
+// WithMissedSlots sets the number of missed slots in the window at which the circuit breaker trips.
+func WithMissedSlots(missedSlots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.missedSlots = missedSlots
+	})
+}
+
+// WithWindow sets the number of recent slots over which missed slots are counted.
+func WithWindow(window uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.window = window
+	})
+}
+
+// WithMaxFinalityDistance sets the number of epochs between the current epoch and
+// the finalized epoch at which the circuit breaker trips.
+func WithMaxFinalityDistance(distance uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxFinalityDistance = distance
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:            zerolog.GlobalLevel(),
+		missedSlots:         8,
+		window:              32,
+		maxFinalityDistance: 4,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chaintime specified")
+	}
+	if parameters.eventsProvider == nil {
+		return nil, errors.New("no events provider specified")
+	}
+	if parameters.finalityProvider == nil {
+		return nil, errors.New("no finality provider specified")
+	}
+	if parameters.window == 0 {
+		return nil, errors.New("window must be positive")
+	}
+	if parameters.missedSlots == 0 || parameters.missedSlots > parameters.window {
+		return nil, errors.New("missed slots must be positive and no more than the window")
+	}
+	if parameters.maxFinalityDistance < 2 {
+		return nil, errors.New("max finality distance must be at least 2")
+	}
+
+	return &parameters, nil
+}
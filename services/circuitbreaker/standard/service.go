@@ -0,0 +1,206 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a circuit breaker that trips when the chain is unstable, as
+// measured by missed slots and distance from finality.
+type Service struct {
+	chainTime           chaintime.Service
+	finalityProvider    consensusclient.FinalityProvider
+	missedSlots         uint64
+	window              uint64
+	maxFinalityDistance uint64
+
+	mu               sync.RWMutex
+	startSlot        phase0.Slot
+	seenSlots        map[phase0.Slot]struct{}
+	finalityDistance uint64
+	tripped          bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new circuit breaker.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "circuitbreaker").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime:           parameters.chainTime,
+		finalityProvider:    parameters.finalityProvider,
+		missedSlots:         parameters.missedSlots,
+		window:              parameters.window,
+		maxFinalityDistance: parameters.maxFinalityDistance,
+		// Slots before we started cannot be counted as missed, as we did not see them.
+		startSlot: parameters.chainTime.CurrentSlot(),
+		seenSlots: make(map[phase0.Slot]struct{}),
+	}
+	monitorTripped(false)
+
+	if err := parameters.eventsProvider.Events(ctx, []string{"block"}, s.handleBlock); err != nil {
+		return nil, errors.Wrap(err, "failed to configure block event")
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"circuitbreaker",
+		"Check chain stability",
+		s.checkRuntime,
+		nil,
+		s.checkJob,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start circuit breaker checker")
+	}
+
+	return s, nil
+}
+
+// Tripped returns true if the chain is unstable.
+func (s *Service) Tripped(_ context.Context) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tripped
+}
+
+// handleBlock handles a block event.
+func (s *Service) handleBlock(event *apiv1.Event) {
+	if event.Data == nil {
+		return
+	}
+
+	data, isBlockEvent := event.Data.(*apiv1.BlockEvent)
+	if !isBlockEvent {
+		return
+	}
+	log.Trace().Uint64("slot", uint64(data.Slot)).Msg("Received block event")
+
+	s.mu.Lock()
+	s.seenSlots[data.Slot] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *Service) checkRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	// Check at the start of each slot.
+	return s.chainTime.StartOfSlot(s.chainTime.CurrentSlot() + 1), nil
+}
+
+func (s *Service) checkJob(ctx context.Context, _ interface{}) {
+	s.check(ctx)
+}
+
+// check updates the state of the circuit breaker.
+func (s *Service) check(ctx context.Context) {
+	currentEpoch := s.chainTime.CurrentEpoch()
+	response, err := s.finalityProvider.Finality(ctx, &api.FinalityOpts{
+		State: "head",
+	})
+	switch {
+	case err != nil:
+		log.Warn().Err(err).Msg("Failed to obtain finality; retaining previous finality distance")
+	case response.Data == nil || response.Data.Finalized == nil:
+		log.Warn().Msg("Finality response missing finalized checkpoint; retaining previous finality distance")
+	default:
+		distance := uint64(0)
+		if currentEpoch > response.Data.Finalized.Epoch {
+			distance = uint64(currentEpoch - response.Data.Finalized.Epoch)
+		}
+		s.mu.Lock()
+		s.finalityDistance = distance
+		s.mu.Unlock()
+	}
+
+	s.evaluate(s.chainTime.CurrentSlot())
+}
+
+// evaluate sets the state of the circuit breaker given the current slot.
+func (s *Service) evaluate(currentSlot phase0.Slot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Count missed slots in the window, excluding the current slot as its block may not yet have arrived.
+	windowStart := s.startSlot
+	if uint64(currentSlot) > s.window && currentSlot-phase0.Slot(s.window) > windowStart {
+		windowStart = currentSlot - phase0.Slot(s.window)
+	}
+	missed := uint64(0)
+	for slot := windowStart; slot < currentSlot; slot++ {
+		if _, exists := s.seenSlots[slot]; !exists {
+			missed++
+		}
+	}
+
+	// Remove slots that have dropped out of the window.
+	for slot := range s.seenSlots {
+		if slot < windowStart {
+			delete(s.seenSlots, slot)
+		}
+	}
+
+	tripped := missed >= s.missedSlots || s.finalityDistance >= s.maxFinalityDistance
+	switch {
+	case tripped && !s.tripped:
+		log.Warn().
+			Uint64("missed_slots", missed).
+			Uint64("finality_distance", s.finalityDistance).
+			Msg("Chain unstable; circuit breaker tripped, blocks will be built locally")
+		monitorTrip()
+	case !tripped && s.tripped:
+		log.Info().
+			Uint64("missed_slots", missed).
+			Uint64("finality_distance", s.finalityDistance).
+			Msg("Chain stable; circuit breaker reset, relays will be used")
+	default:
+		log.Trace().
+			Uint64("missed_slots", missed).
+			Uint64("finality_distance", s.finalityDistance).
+			Bool("tripped", tripped).
+			Msg("Checked chain stability")
+	}
+	s.tripped = tripped
+	monitorTripped(tripped)
+}
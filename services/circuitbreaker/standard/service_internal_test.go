@@ -0,0 +1,95 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	mockconsensusclient "github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+
+	genesisProvider := mock.NewGenesisProvider(time.Now())
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithScheduler(mockscheduler.New()),
+		WithChainTime(chainTime),
+		WithEventsProvider(consensusClient),
+		WithFinalityProvider(consensusClient),
+		WithMissedSlots(2),
+		WithWindow(4),
+		WithMaxFinalityDistance(3),
+	)
+	require.NoError(t, err)
+	s.startSlot = 100
+
+	block := func(slot phase0.Slot) {
+		s.handleBlock(&apiv1.Event{Topic: "block", Data: &apiv1.BlockEvent{Slot: slot}})
+	}
+
+	// Slots before the service started are not counted as missed.
+	s.evaluate(101)
+	require.False(t, s.Tripped(ctx))
+
+	// A single missed slot does not trip the breaker.
+	block(100)
+	block(102)
+	s.evaluate(103)
+	require.False(t, s.Tripped(ctx))
+
+	// A second missed slot in the window trips the breaker.
+	s.evaluate(104)
+	require.True(t, s.Tripped(ctx))
+
+	// The breaker resets once missed slots leave the window.
+	block(104)
+	block(105)
+	block(106)
+	s.evaluate(105)
+	require.True(t, s.Tripped(ctx))
+	s.evaluate(107)
+	require.False(t, s.Tripped(ctx))
+
+	// Distance from finality trips the breaker.
+	s.finalityDistance = 3
+	s.evaluate(107)
+	require.True(t, s.Tripped(ctx))
+	s.finalityDistance = 2
+	s.evaluate(107)
+	require.False(t, s.Tripped(ctx))
+}
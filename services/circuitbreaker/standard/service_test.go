@@ -0,0 +1,172 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockconsensusclient "github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/circuitbreaker/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "EventsProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithFinalityProvider(consensusClient),
+			},
+			err: "problem with parameters: no events provider specified",
+		},
+		{
+			name: "FinalityProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+			},
+			err: "problem with parameters: no finality provider specified",
+		},
+		{
+			name: "WindowZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+				standard.WithWindow(0),
+			},
+			err: "problem with parameters: window must be positive",
+		},
+		{
+			name: "MissedSlotsTooHigh",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+				standard.WithWindow(8),
+				standard.WithMissedSlots(9),
+			},
+			err: "problem with parameters: missed slots must be positive and no more than the window",
+		},
+		{
+			name: "MaxFinalityDistanceTooLow",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+				standard.WithMaxFinalityDistance(1),
+			},
+			err: "problem with parameters: max finality distance must be at least 2",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithEventsProvider(consensusClient),
+				standard.WithFinalityProvider(consensusClient),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.False(t, s.Tripped(ctx))
+			}
+		})
+	}
+}
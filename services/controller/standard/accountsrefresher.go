@@ -17,16 +17,25 @@ import (
 	"context"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 )
 
 // startAccountsRefresher starts a periodic job that refreshes the accounts known by Vouch.
 func (s *Service) startAccountsRefresher(ctx context.Context) error {
+	if s.accountsRefreshSlices > 1 {
+		if _, isSlicedRefresher := s.accountsRefresher.(accountmanager.SlicedRefresher); isSlicedRefresher {
+			return s.startSlicedAccountsRefresher(ctx)
+		}
+		log.Warn().Msg("Account manager does not support sliced refreshes; refreshing all accounts together")
+	}
+
 	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
-		if s.activeValidators == 0 {
-			log.Trace().Msg("No active validators; refreshing accounts next slot")
-			return time.Now().Add(s.slotDuration), nil
+		if s.activeValidators.Load() == 0 {
+			log.Trace().Dur("interval", s.idleAccountsRefreshInterval).Msg("No active validators; refreshing accounts at idle interval")
+			return time.Now().Add(s.idleAccountsRefreshInterval), nil
 		}
 
 		// Schedule for the middle of the slot, quarter through the epoch.
@@ -58,4 +67,69 @@ func (s *Service) refreshAccounts(ctx context.Context, _ interface{}) {
 	started := time.Now()
 	s.accountsRefresher.Refresh(ctx)
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Refreshed accounts")
+
+	s.leaveLowActivityMode(ctx)
+	s.scheduleSyncCommitteeMessagesForRefreshedAccounts(ctx)
+}
+
+// startSlicedAccountsRefresher starts a periodic job that refreshes a slice of the
+// accounts known by Vouch, with slices spread evenly across the epoch.
+func (s *Service) startSlicedAccountsRefresher(ctx context.Context) error {
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		if s.activeValidators.Load() == 0 {
+			log.Trace().Dur("interval", s.idleAccountsRefreshInterval).Msg("No active validators; refreshing accounts at idle interval")
+			return time.Now().Add(s.idleAccountsRefreshInterval), nil
+		}
+		runtime, slice := s.nextAccountsRefreshSlice(time.Now())
+		s.accountsRefreshSlice.Store(slice)
+		return runtime, nil
+	}
+	if err := s.scheduler.SchedulePeriodicJob(ctx,
+		"Refresh accounts",
+		"Sliced account refresh ticker",
+		runtimeFunc,
+		nil,
+		s.refreshAccountsSlice,
+		nil,
+	); err != nil {
+		return errors.Wrap(err, "Failed to schedule sliced accounts refresher")
+	}
+
+	return nil
+}
+
+// nextAccountsRefreshSlice returns the time at which the next slice of accounts
+// should be refreshed, and the slice to refresh.
+// Each slice is refreshed in the middle of its slot, to avoid the start of slot
+// activity of proposals and attestations.
+func (s *Service) nextAccountsRefreshSlice(now time.Time) (time.Time, uint64) {
+	firstSlot := s.chainTimeService.FirstSlotOfEpoch(s.chainTimeService.CurrentEpoch())
+	for {
+		for slice := uint64(0); slice < s.accountsRefreshSlices; slice++ {
+			slot := firstSlot + phase0.Slot(slice*s.slotsPerEpoch/s.accountsRefreshSlices)
+			runtime := s.chainTimeService.StartOfSlot(slot).Add(s.slotDuration / 2)
+			if runtime.After(now) {
+				return runtime, slice
+			}
+		}
+		firstSlot += phase0.Slot(s.slotsPerEpoch)
+	}
+}
+
+// refreshAccountsSlice refreshes a slice of accounts.
+// Without active validators all accounts are refreshed, as refreshes are infrequent.
+func (s *Service) refreshAccountsSlice(ctx context.Context, _ interface{}) {
+	if s.activeValidators.Load() == 0 {
+		s.refreshAccounts(ctx, nil)
+		return
+	}
+
+	ctx, span := otel.Tracer("attestantio.vouch.services.controller.standard").Start(ctx, "refreshAccountsSlice")
+	defer span.End()
+	started := time.Now()
+	slice := s.accountsRefreshSlice.Load()
+	s.accountsRefresher.(accountmanager.SlicedRefresher).RefreshSlice(ctx, slice, s.accountsRefreshSlices)
+	log.Trace().Dur("elapsed", time.Since(started)).Uint64("slice", slice).Msg("Refreshed accounts slice")
+
+	s.scheduleSyncCommitteeMessagesForRefreshedAccounts(ctx)
 }
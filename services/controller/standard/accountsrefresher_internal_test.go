@@ -0,0 +1,86 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextAccountsRefreshSlice(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is exactly 10 epochs ago, so the current epoch starts now.
+	genesisTime := time.Now().Truncate(time.Second).Add(-10 * 32 * 12 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	epochStart := chainTime.StartOfEpoch(10)
+
+	s := &Service{
+		chainTimeService:      chainTime,
+		slotDuration:          12 * time.Second,
+		slotsPerEpoch:         32,
+		accountsRefreshSlices: 4,
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		runtime time.Time
+		slice   uint64
+	}{
+		{
+			name:    "StartOfEpoch",
+			now:     epochStart,
+			runtime: epochStart.Add(6 * time.Second),
+			slice:   0,
+		},
+		{
+			name:    "SecondSlice",
+			now:     epochStart.Add(6 * time.Second),
+			runtime: epochStart.Add(8*12*time.Second + 6*time.Second),
+			slice:   1,
+		},
+		{
+			name:    "LastSlice",
+			now:     epochStart.Add(20 * 12 * time.Second),
+			runtime: epochStart.Add(24*12*time.Second + 6*time.Second),
+			slice:   3,
+		},
+		{
+			name:    "NextEpoch",
+			now:     epochStart.Add(24*12*time.Second + 6*time.Second),
+			runtime: epochStart.Add(32*12*time.Second + 6*time.Second),
+			slice:   0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runtime, slice := s.nextAccountsRefreshSlice(test.now)
+			require.Equal(t, test.runtime, runtime)
+			require.Equal(t, test.slice, slice)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// attestationHeadPollInterval is the interval between checks of the beacon node's head.
+const attestationHeadPollInterval = 100 * time.Millisecond
+
+// awaitAttestationHead waits, up to the configured limit, for the beacon node to
+// have processed the parent slot of an attestation.  This avoids voting for a stale
+// head when the beacon node is momentarily behind.  It returns true if the head was
+// up to date.
+//
+// Note that if the parent slot was empty then the head will never reach it, in which
+// case this waits for the full limit.
+func (s *Service) awaitAttestationHead(ctx context.Context, slot phase0.Slot) bool {
+	if s.attestationHeadWait == 0 || slot == 0 {
+		return true
+	}
+
+	log := log.With().Uint64("slot", uint64(slot)).Logger()
+	ctx, cancel := context.WithTimeout(ctx, s.attestationHeadWait)
+	defer cancel()
+	for {
+		headerResponse, err := s.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+			Block: "head",
+		})
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to obtain head to check before attesting")
+		} else if headerResponse.Data.Header.Message.Slot >= slot-1 {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Debug().Dur("wait", s.attestationHeadWait).Msg("Beacon node head is behind the parent slot; attesting regardless")
+			return false
+		case <-time.After(attestationHeadPollInterval):
+		}
+	}
+}
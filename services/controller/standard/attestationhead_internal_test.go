@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwaitAttestationHead(t *testing.T) {
+	ctx := context.Background()
+
+	// The mock beacon block headers provider always returns a head at slot 123.
+	tests := []struct {
+		name     string
+		wait     time.Duration
+		slot     phase0.Slot
+		expected bool
+		minTime  time.Duration
+	}{
+		{
+			name:     "Disabled",
+			slot:     200,
+			expected: true,
+		},
+		{
+			name:     "HeadAtParent",
+			wait:     time.Second,
+			slot:     124,
+			expected: true,
+		},
+		{
+			name:     "HeadAtSlot",
+			wait:     time.Second,
+			slot:     123,
+			expected: true,
+		},
+		{
+			name:     "HeadBehind",
+			wait:     250 * time.Millisecond,
+			slot:     200,
+			expected: false,
+			minTime:  250 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				beaconBlockHeadersProvider: mock.NewBeaconBlockHeadersProvider(),
+				attestationHeadWait:        test.wait,
+			}
+			started := time.Now()
+			require.Equal(t, test.expected, s.awaitAttestationHead(ctx, test.slot))
+			require.GreaterOrEqual(t, time.Since(started), test.minTime)
+		})
+	}
+}
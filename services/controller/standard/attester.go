@@ -23,6 +23,9 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/attestationaggregator"
 	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
 )
 
 // scheduleAttestations schedules attestations for the given epoch and validator indices.
@@ -107,16 +110,23 @@ func (s *Service) scheduleAttestations(ctx context.Context,
 		s.pendingAttestationsMutex.Unlock()
 
 		go func(duty *attester.Duty) {
-			jobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.maxAttestationDelay)
+			jobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.tunedMaxAttestationDelay())
 			if err := s.scheduler.ScheduleJob(ctx,
 				"Attest",
-				fmt.Sprintf("Attestations for slot %d", duty.Slot()),
+				fmt.Sprintf(attestationsJobFormat, duty.Slot()),
 				jobTime,
 				s.AttestAndScheduleAggregate,
 				duty,
 			); err != nil {
+				if errors.Is(err, scheduler.ErrJobRunning) {
+					// Duties have been refreshed whilst the attestation is in progress; do not attest again.
+					log.Debug().Uint64("attestation_slot", uint64(duty.Slot())).Msg("Attestation already running; not rescheduling")
+					return
+				}
 				// Don't return here; we want to try to set up as many attester jobs as possible.
 				log.Error().Err(err).Msg("Failed to schedule attestation")
+			} else {
+				s.publishDutyEvent(ctx, dutyevents.DutyAttestation, dutyevents.StageScheduled, duty.Slot(), duty.ValidatorIndices(), nil)
 			}
 		}(duty)
 	}
@@ -142,12 +152,31 @@ func (s *Service) AttestAndScheduleAggregate(ctx context.Context, data interface
 		s.pendingAttestationsMutex.Unlock()
 	}()
 
-	attestations, err := s.attester.Attest(ctx, duty)
+	if !s.startDuty(ctx, duty.Slot()) {
+		return
+	}
+	defer s.endDuty()
+
+	if s.attestationsPaused.Load() {
+		log.Info().Msg("Attestations are paused; not attesting")
+		return
+	}
+
+	s.publishDutyEvent(ctx, dutyevents.DutyAttestation, dutyevents.StageStarted, duty.Slot(), duty.ValidatorIndices(), nil)
+	dutyCtx, cancel := s.dutyContext(ctx, duty.Slot())
+	defer cancel()
+	s.awaitAttestationHead(dutyCtx, duty.Slot())
+	attestations, err := s.attester.Attest(dutyCtx, duty)
+	s.checkCanaryAttestations(duty, attestations, err)
 	if err != nil {
+		s.publishDutyEvent(ctx, dutyevents.DutyAttestation, dutyevents.StageFailed, duty.Slot(), duty.ValidatorIndices(), err)
 		log.Warn().Err(err).Msg("Failed to attest")
 		return
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Attested")
+	s.recordAttestations(duty, attestations)
+	s.recordAttestedHead(duty.Slot(), attestations)
+	s.scheduleInclusionCheck(ctx, attestations)
 
 	if len(attestations) == 0 || attestations[0].Data == nil {
 		log.Debug().Msg("No attestations; nothing to aggregate")
@@ -203,22 +232,30 @@ func (s *Service) AttestAndScheduleAggregate(ctx context.Context, data interface
 				continue
 			}
 			aggregatorDuty := &attestationaggregator.Duty{
-				Slot:                info.Duty.Slot,
-				AttestationDataRoot: attestationDataRoot,
-				ValidatorIndex:      info.Duty.ValidatorIndex,
-				SlotSignature:       info.Signature,
+				Slot:                    info.Duty.Slot,
+				AttestationDataRoot:     attestationDataRoot,
+				ValidatorIndex:          info.Duty.ValidatorIndex,
+				SlotSignature:           info.Signature,
+				ValidatorCommitteeIndex: info.Duty.ValidatorCommitteeIndex,
+			}
+			jobTime := s.chainTimeService.StartOfSlot(attestation.Data.Slot).Add(s.tunedAttestationAggregationDelay())
+			if s.minAttestationAggregationDelay > 0 {
+				// Start early, and allow the aggregator to wait for a sufficiently complete aggregate.
+				aggregatorDuty.Deadline = jobTime
+				jobTime = s.chainTimeService.StartOfSlot(attestation.Data.Slot).Add(s.minAttestationAggregationDelay)
 			}
 			if err := s.scheduler.ScheduleJob(ctx,
 				"Aggregate attestations",
-				fmt.Sprintf("Beacon block attestation aggregation for slot %d committee %d", attestation.Data.Slot, attestation.Data.Index),
-				s.chainTimeService.StartOfSlot(attestation.Data.Slot).Add(s.attestationAggregationDelay),
-				s.attestationAggregator.Aggregate,
+				fmt.Sprintf(attestationAggregationJobFormat, attestation.Data.Slot, attestation.Data.Index),
+				jobTime,
+				s.aggregateAttestations,
 				aggregatorDuty,
 			); err != nil {
 				// Don't return here; we want to try to set up as many aggregator jobs as possible.
 				log.Error().Err(err).Msg("Failed to schedule beacon block attestation aggregation job")
 				continue
 			}
+			s.publishDutyEvent(ctx, dutyevents.DutyAttestationAggregation, dutyevents.StageScheduled, info.Duty.Slot, []phase0.ValidatorIndex{info.Duty.ValidatorIndex}, nil)
 			// We are set up as an aggregator for this slot and committee.  It is possible that another validator has also been
 			// assigned as an aggregator, but we're already carrying out the task so do not need to go any further.
 			return
@@ -0,0 +1,198 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Canary validators are ordinary validators, ideally of low value, whose
+// duties run through the same code paths and signers as every other
+// validator.  The result of each of their duties is reported separately, so
+// that a failure of the validating pipeline can be alerted on directly.
+
+// updateCanaryIndices records the validator indices of any canary validators
+// in the supplied accounts.
+func (s *Service) updateCanaryIndices(accounts map[phase0.ValidatorIndex]e2wtypes.Account) {
+	if len(s.canaryValidators) == 0 {
+		return
+	}
+
+	for index, account := range accounts {
+		var pubKey phase0.BLSPubKey
+		if provider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+			copy(pubKey[:], provider.CompositePublicKey().Marshal())
+		} else {
+			copy(pubKey[:], account.PublicKey().Marshal())
+		}
+		if _, isCanary := s.canaryValidators[pubKey]; !isCanary {
+			continue
+		}
+		s.canaryIndicesMu.Lock()
+		if _, exists := s.canaryIndices[index]; !exists {
+			log.Info().Uint64("validator_index", uint64(index)).Str("pubkey", fmt.Sprintf("%#x", pubKey)).Msg("Tracking canary validator")
+			s.canaryIndices[index] = struct{}{}
+		}
+		s.canaryIndicesMu.Unlock()
+	}
+}
+
+// isCanary returns true if the validator is a canary.
+func (s *Service) isCanary(index phase0.ValidatorIndex) bool {
+	s.canaryIndicesMu.RLock()
+	_, isCanary := s.canaryIndices[index]
+	s.canaryIndicesMu.RUnlock()
+
+	return isCanary
+}
+
+// canaryDuty reports the result of a duty for a canary validator.
+func (s *Service) canaryDuty(duty string, slot phase0.Slot, index phase0.ValidatorIndex, succeeded bool, err error) {
+	if succeeded {
+		log.Trace().Str("duty", duty).Uint64("slot", uint64(slot)).Uint64("validator_index", uint64(index)).Msg("Canary duty succeeded")
+		s.monitor.CanaryDuty(duty, "succeeded")
+		return
+	}
+
+	e := log.Error().Str("duty", duty).Uint64("slot", uint64(slot)).Uint64("validator_index", uint64(index))
+	if err != nil {
+		e = e.Err(err)
+	}
+	e.Msg("Canary duty failed")
+	s.monitor.CanaryDuty(duty, "failed")
+}
+
+// checkCanaryAttestations reports the results of attestations for canary validators.
+func (s *Service) checkCanaryAttestations(duty *attester.Duty, attestations []*phase0.Attestation, err error) {
+	if len(s.canaryValidators) == 0 {
+		return
+	}
+
+	attested := make(map[phase0.ValidatorIndex]struct{})
+	if err == nil {
+		for _, index := range attestedValidators(duty, attestations) {
+			attested[index] = struct{}{}
+		}
+	}
+	for _, index := range duty.ValidatorIndices() {
+		if !s.isCanary(index) {
+			continue
+		}
+		_, succeeded := attested[index]
+		s.canaryDuty("attestation", duty.Slot(), index, succeeded, err)
+	}
+}
+
+// checkCanarySyncCommitteeMessages reports the results of sync committee messages for canary validators.
+func (s *Service) checkCanarySyncCommitteeMessages(duty *synccommitteemessenger.Duty, messages []*altair.SyncCommitteeMessage, err error) {
+	if len(s.canaryValidators) == 0 {
+		return
+	}
+
+	messaged := make(map[phase0.ValidatorIndex]struct{})
+	if err == nil {
+		for _, message := range messages {
+			messaged[message.ValidatorIndex] = struct{}{}
+		}
+	}
+	for _, index := range duty.ValidatorIndices() {
+		if !s.isCanary(index) {
+			continue
+		}
+		_, succeeded := messaged[index]
+		s.canaryDuty("sync_committee", duty.Slot(), index, succeeded, err)
+	}
+}
+
+// scheduleProposalCheck schedules a check that a proposal made it on to the
+// chain, if the proposer is a canary or duty events are enabled.  Proposals do
+// not return their result, so the chain is checked half-way through the
+// following slot.
+func (s *Service) scheduleProposalCheck(ctx context.Context, duty *beaconblockproposer.Duty) {
+	if !s.isCanary(duty.ValidatorIndex()) && s.dutyEvents == nil {
+		return
+	}
+
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Proposal check",
+		fmt.Sprintf(proposalCheckJobFormat, duty.Slot()),
+		s.chainTimeService.StartOfSlot(duty.Slot()+1).Add(s.slotDuration/2),
+		s.checkProposal,
+		duty,
+	); err != nil {
+		log.Error().Err(err).Uint64("slot", uint64(duty.Slot())).Msg("Failed to schedule proposal check")
+	}
+}
+
+// checkProposal checks that a proposal made it on to the chain.
+func (s *Service) checkProposal(ctx context.Context, data interface{}) {
+	duty, ok := data.(*beaconblockproposer.Duty)
+	if !ok {
+		log.Error().Msg("Passed invalid data")
+		return
+	}
+
+	blockResponse, err := s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%d", duty.Slot()),
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			s.proposalChecked(ctx, duty, false, errors.New("no block on chain for slot"))
+			return
+		}
+		s.proposalChecked(ctx, duty, false, err)
+		return
+	}
+	if blockResponse == nil || blockResponse.Data == nil {
+		s.proposalChecked(ctx, duty, false, errors.New("no block on chain for slot"))
+		return
+	}
+	proposerIndex, err := blockResponse.Data.ProposerIndex()
+	if err != nil {
+		s.proposalChecked(ctx, duty, false, err)
+		return
+	}
+	if proposerIndex != duty.ValidatorIndex() {
+		s.proposalChecked(ctx, duty, false, fmt.Errorf("block on chain proposed by validator %d", proposerIndex))
+		return
+	}
+
+	s.proposalChecked(ctx, duty, true, nil)
+}
+
+// proposalChecked reports the result of a proposal check.
+func (s *Service) proposalChecked(ctx context.Context, duty *beaconblockproposer.Duty, succeeded bool, err error) {
+	if s.isCanary(duty.ValidatorIndex()) {
+		s.canaryDuty("proposal", duty.Slot(), duty.ValidatorIndex(), succeeded, err)
+	}
+
+	stage := dutyevents.StageConfirmed
+	if !succeeded {
+		stage = dutyevents.StageUnconfirmed
+	}
+	s.publishDutyEvent(ctx, dutyevents.DutyProposal, stage, duty.Slot(), []phase0.ValidatorIndex{duty.ValidatorIndex()}, err)
+}
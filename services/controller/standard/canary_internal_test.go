@@ -0,0 +1,115 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// canaryMonitor records the results of canary duties.
+type canaryMonitor struct {
+	*nullmetrics.Service
+	mu      sync.Mutex
+	results map[string][]string
+}
+
+func (m *canaryMonitor) CanaryDuty(duty string, result string) {
+	m.mu.Lock()
+	m.results[duty] = append(m.results[duty], result)
+	m.mu.Unlock()
+}
+
+func TestCanaryDuties(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	monitor := &canaryMonitor{
+		Service: nullmetrics.New(ctx),
+		results: make(map[string][]string),
+	}
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := nd.CreateWallet(ctx, "test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	canary, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "canary", []byte("pass"))
+	require.NoError(t, err)
+	other, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "other", []byte("pass"))
+	require.NoError(t, err)
+	var canaryPubKey phase0.BLSPubKey
+	copy(canaryPubKey[:], canary.PublicKey().Marshal())
+
+	s := &Service{
+		monitor:                   monitor,
+		signedBeaconBlockProvider: mock.NewSignedBeaconBlockProvider(),
+		canaryValidators:          map[phase0.BLSPubKey]struct{}{canaryPubKey: {}},
+		canaryIndices:             make(map[phase0.ValidatorIndex]struct{}),
+	}
+
+	// Only the canary's index is tracked.
+	s.updateCanaryIndices(map[phase0.ValidatorIndex]e2wtypes.Account{0: canary, 1: other})
+	require.True(t, s.isCanary(0))
+	require.False(t, s.isCanary(1))
+
+	// Validators 0 and 1 are in committee 1 at positions 2 and 5.
+	attesterDuty, err := attester.NewDuty(ctx, 40, 4,
+		[]phase0.ValidatorIndex{0, 1},
+		[]phase0.CommitteeIndex{1, 1},
+		[]uint64{2, 5},
+		map[phase0.CommitteeIndex]uint64{1: 8},
+	)
+	require.NoError(t, err)
+	canaryBits := bitfield.NewBitlist(8)
+	canaryBits.SetBitAt(2, true)
+	otherBits := bitfield.NewBitlist(8)
+	otherBits.SetBitAt(5, true)
+	s.checkCanaryAttestations(attesterDuty, []*phase0.Attestation{
+		{AggregationBits: canaryBits, Data: &phase0.AttestationData{Slot: 40, Index: 1}},
+	}, nil)
+	s.checkCanaryAttestations(attesterDuty, []*phase0.Attestation{
+		{AggregationBits: otherBits, Data: &phase0.AttestationData{Slot: 40, Index: 1}},
+	}, nil)
+	s.checkCanaryAttestations(attesterDuty, nil, errors.New("failed"))
+	require.Equal(t, []string{"succeeded", "failed", "failed"}, monitor.results["attestation"])
+
+	syncCommitteeDuty := synccommitteemessenger.NewDuty(40, map[phase0.ValidatorIndex][]phase0.CommitteeIndex{0: {1}, 1: {2}})
+	s.checkCanarySyncCommitteeMessages(syncCommitteeDuty, []*altair.SyncCommitteeMessage{{Slot: 40, ValidatorIndex: 0}}, nil)
+	s.checkCanarySyncCommitteeMessages(syncCommitteeDuty, []*altair.SyncCommitteeMessage{{Slot: 40, ValidatorIndex: 1}}, nil)
+	require.Equal(t, []string{"succeeded", "failed"}, monitor.results["sync_committee"])
+
+	// The mock block provider returns blocks proposed by validator 0.
+	s.checkProposal(ctx, beaconblockproposer.NewDuty(123, 0))
+	s.canaryIndices[2] = struct{}{}
+	s.checkProposal(ctx, beaconblockproposer.NewDuty(123, 2))
+	require.Equal(t, []string{"succeeded", "failed"}, monitor.results["proposal"])
+}
@@ -0,0 +1,62 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attestationaggregator"
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/synccommitteeaggregator"
+	"github.com/attestantio/vouch/util"
+)
+
+// dutyContext returns a context that expires at the deadline for duties in the given slot,
+// allowing providers, signers and submitters to fit their timeouts within the slot.
+// The context also carries the budget for the phases of the duty.  The returned
+// function should be called once the duty completes.
+func (s *Service) dutyContext(ctx context.Context, slot phase0.Slot) (context.Context, context.CancelFunc) {
+	return util.WithDutyDeadline(util.WithDutyBudget(ctx, s.dutyBudget), s.chainTimeService.StartOfSlot(slot).Add(s.dutyDeadline))
+}
+
+// aggregateAttestations aggregates attestations within the duty deadline.
+func (s *Service) aggregateAttestations(ctx context.Context, data interface{}) {
+	if duty, ok := data.(*attestationaggregator.Duty); ok {
+		if !s.startDuty(ctx, duty.Slot) {
+			return
+		}
+		defer s.endDuty()
+		s.publishDutyEvent(ctx, dutyevents.DutyAttestationAggregation, dutyevents.StageStarted, duty.Slot, []phase0.ValidatorIndex{duty.ValidatorIndex}, nil)
+		var cancel context.CancelFunc
+		ctx, cancel = s.dutyContext(ctx, duty.Slot)
+		defer cancel()
+	}
+	s.attestationAggregator.Aggregate(ctx, data)
+}
+
+// aggregateSyncCommitteeMessages aggregates sync committee messages within the duty deadline.
+func (s *Service) aggregateSyncCommitteeMessages(ctx context.Context, data interface{}) {
+	if duty, ok := data.(*synccommitteeaggregator.Duty); ok {
+		if !s.startDuty(ctx, duty.Slot) {
+			return
+		}
+		defer s.endDuty()
+		s.publishDutyEvent(ctx, dutyevents.DutySyncCommitteeAggregation, dutyevents.StageStarted, duty.Slot, duty.ValidatorIndices, nil)
+		var cancel context.CancelFunc
+		ctx, cancel = s.dutyContext(ctx, duty.Slot)
+		defer cancel()
+	}
+	s.syncCommitteeAggregator.Aggregate(ctx, data)
+}
@@ -0,0 +1,344 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// delayTunerMargin is the time allowed for a block to propagate after it
+// has been received, in addition to its observed arrival time.
+const delayTunerMargin = 200 * time.Millisecond
+
+// delayTunerGranularity is the granularity of tuned delays, to avoid small
+// changes from one slot to the next.
+const delayTunerGranularity = 100 * time.Millisecond
+
+// delayTunerMinObservations is the number of observations required before
+// delays are tuned.
+const delayTunerMinObservations = 8
+
+// delayTunerPercentile is the percentile of block arrival times that tuned
+// delays aim to cover.
+const delayTunerPercentile = 0.95
+
+// delayTunerMissThreshold is the proportion of included attestations with an
+// incorrect head vote above which delays are increased.  Delays are decreased
+// again once the proportion over a full window is at most half of this.
+const delayTunerMissThreshold = 0.05
+
+// DelayTuning configures automatic tuning of duty delays.
+type DelayTuning struct {
+	// Window is the number of recent block arrivals, and of recent attestation
+	// inclusions, that are used.
+	Window int
+	// MinDelay is the lowest value to which the maximum attestation delay can be tuned.
+	MinDelay time.Duration
+	// MaxDelay is the highest value to which the maximum attestation delay can be tuned.
+	MaxDelay time.Duration
+}
+
+// blockArrival is an observation of the arrival of a block.
+type blockArrival struct {
+	delay time.Duration
+	late  bool
+}
+
+// delayTuner tunes the maximum attestation delay from the arrival times of
+// recent blocks, adjusted by the head votes of recent attestations that were
+// included on chain.  Other duty delays move by the same amount, keeping the
+// gaps between them as configured.
+type delayTuner struct {
+	configured time.Duration
+	minDelay   time.Duration
+	maxDelay   time.Duration
+
+	mu         sync.Mutex
+	arrivals   []blockArrival
+	next       int
+	inclusions []bool
+	adjustment time.Duration
+	offsetNs   atomic.Int64
+}
+
+// newDelayTuner creates a new delay tuner for the given configured maximum attestation delay.
+func newDelayTuner(configured time.Duration, tuning *DelayTuning) *delayTuner {
+	return &delayTuner{
+		configured: configured,
+		minDelay:   tuning.MinDelay,
+		maxDelay:   tuning.MaxDelay,
+		arrivals:   make([]blockArrival, 0, tuning.Window),
+		inclusions: make([]bool, 0, tuning.Window),
+	}
+}
+
+// offset returns the amount by which configured delays are currently moved.
+func (t *delayTuner) offset() time.Duration {
+	return time.Duration(t.offsetNs.Load())
+}
+
+// observe records the arrival of a block, and whether it arrived after attestations
+// for its slot had already been made, and retunes the delays.  It returns the
+// tuned maximum attestation delay.
+func (t *delayTuner) observe(delay time.Duration, late bool) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.arrivals) < cap(t.arrivals) {
+		t.arrivals = append(t.arrivals, blockArrival{delay: delay, late: late})
+	} else {
+		t.arrivals[t.next] = blockArrival{delay: delay, late: late}
+		t.next = (t.next + 1) % len(t.arrivals)
+	}
+
+	return t.retune()
+}
+
+// observeInclusion records whether included attestations voted for the correct
+// head, adjusting the delays if too many did not, and retunes the delays.  It
+// returns the tuned maximum attestation delay.
+func (t *delayTuner) observeInclusion(correctHead bool) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inclusions = append(t.inclusions, correctHead)
+	if len(t.inclusions) >= delayTunerMinObservations {
+		misses := 0
+		for _, inclusion := range t.inclusions {
+			if !inclusion {
+				misses++
+			}
+		}
+		missRate := float64(misses) / float64(len(t.inclusions))
+		switch {
+		case missRate > delayTunerMissThreshold:
+			// Attesting later gives blocks more time to arrive.
+			if t.adjustment < t.maxDelay-t.minDelay {
+				t.adjustment += delayTunerGranularity
+			}
+			// Start afresh, so that the next decision reflects the new delay.
+			t.inclusions = t.inclusions[:0]
+		case len(t.inclusions) == cap(t.inclusions):
+			if missRate <= delayTunerMissThreshold/2 && t.adjustment > 0 {
+				t.adjustment -= delayTunerGranularity
+			}
+			t.inclusions = t.inclusions[:0]
+		}
+	}
+
+	return t.retune()
+}
+
+// retune calculates the tuned delays from the current observations.  It
+// returns the tuned maximum attestation delay.
+// This assumes that the tuner mutex is held.
+func (t *delayTuner) retune() time.Duration {
+	if len(t.arrivals) < delayTunerMinObservations {
+		return t.configured + t.offset()
+	}
+
+	delays := make([]time.Duration, len(t.arrivals))
+	// Late blocks cost attestations their head vote, so the delay must cover them.
+	lateDelay := time.Duration(0)
+	for i := range t.arrivals {
+		delays[i] = t.arrivals[i].delay
+		if t.arrivals[i].late && t.arrivals[i].delay > lateDelay {
+			lateDelay = t.arrivals[i].delay
+		}
+	}
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+	target := delays[int(float64(len(delays)-1)*delayTunerPercentile)]
+	if lateDelay > target {
+		target = lateDelay
+	}
+	target += delayTunerMargin + t.adjustment
+	// Round up to avoid churn.
+	target = (target + delayTunerGranularity - 1).Truncate(delayTunerGranularity)
+	if target < t.minDelay {
+		target = t.minDelay
+	}
+	if target > t.maxDelay {
+		target = t.maxDelay
+	}
+	t.offsetNs.Store(int64(target - t.configured))
+
+	return target
+}
+
+// delayOffset returns the amount by which configured duty delays are moved by tuning.
+func (s *Service) delayOffset() time.Duration {
+	if s.delayTuner == nil {
+		return 0
+	}
+
+	return s.delayTuner.offset()
+}
+
+// tunedMaxAttestationDelay returns the current maximum attestation delay.
+func (s *Service) tunedMaxAttestationDelay() time.Duration {
+	return s.maxAttestationDelay + s.delayOffset()
+}
+
+// tunedMaxSyncCommitteeMessageDelay returns the current maximum sync committee message delay.
+func (s *Service) tunedMaxSyncCommitteeMessageDelay() time.Duration {
+	delay := s.maxSyncCommitteeMessageDelay + s.delayOffset()
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay
+}
+
+// tunedAttestationAggregationDelay returns the current attestation aggregation delay.
+func (s *Service) tunedAttestationAggregationDelay() time.Duration {
+	delay := s.attestationAggregationDelay + s.delayOffset()
+	if delay < s.minAttestationAggregationDelay {
+		delay = s.minAttestationAggregationDelay
+	}
+
+	return delay
+}
+
+// tunedSyncCommitteeAggregationDelay returns the current sync committee aggregation delay.
+func (s *Service) tunedSyncCommitteeAggregationDelay() time.Duration {
+	return s.syncCommitteeAggregationDelay + s.delayOffset()
+}
+
+// observeBlockArrival feeds the arrival of a block to the delay tuner, if present.
+func (s *Service) observeBlockArrival(delay time.Duration, late bool) {
+	if s.delayTuner == nil {
+		return
+	}
+
+	previous := s.tunedMaxAttestationDelay()
+	s.logTunedDelay(previous, s.delayTuner.observe(delay, late))
+}
+
+// observeInclusion feeds the head vote of included attestations to the delay tuner, if present.
+func (s *Service) observeInclusion(correctHead bool) {
+	if s.delayTuner == nil {
+		return
+	}
+
+	previous := s.tunedMaxAttestationDelay()
+	s.logTunedDelay(previous, s.delayTuner.observeInclusion(correctHead))
+}
+
+// logTunedDelay logs a change in the tuned maximum attestation delay.
+func (s *Service) logTunedDelay(previous time.Duration, tuned time.Duration) {
+	if tuned != previous {
+		log.Debug().
+			Dur("previous", previous).
+			Dur("tuned", tuned).
+			Dur("offset", s.delayOffset()).
+			Msg("Tuned maximum attestation delay")
+	}
+}
+
+// scheduleInclusionCheck schedules a check of the inclusion of attestations in
+// the block for the following slot, if delays are being tuned.  The block is
+// checked half-way through the slot.
+func (s *Service) scheduleInclusionCheck(ctx context.Context, attestations []*phase0.Attestation) {
+	if s.delayTuner == nil || len(attestations) == 0 || attestations[0].Data == nil {
+		return
+	}
+
+	slot := attestations[0].Data.Slot
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Inclusion check",
+		fmt.Sprintf(inclusionCheckJobFormat, slot),
+		s.chainTimeService.StartOfSlot(slot+1).Add(s.slotDuration/2),
+		s.checkInclusion,
+		attestations,
+	); err != nil {
+		log.Error().Err(err).Uint64("slot", uint64(slot)).Msg("Failed to schedule inclusion check")
+	}
+}
+
+// checkInclusion checks if attestations were included in the block for the
+// following slot and, if so, whether they voted for the head on which that
+// block was built, feeding the result to the delay tuner.
+func (s *Service) checkInclusion(ctx context.Context, data interface{}) {
+	attestations, ok := data.([]*phase0.Attestation)
+	if !ok {
+		log.Error().Msg("Passed invalid data")
+		return
+	}
+	slot := attestations[0].Data.Slot
+	log := log.With().Uint64("slot", uint64(slot)).Logger()
+
+	blockResponse, err := s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%d", slot+1),
+	})
+	if err != nil {
+		// Includes there being no block for the slot, in which case there is nothing to learn.
+		log.Debug().Err(err).Msg("Failed to obtain block for inclusion check")
+		return
+	}
+	if blockResponse == nil || blockResponse.Data == nil {
+		log.Debug().Msg("No block for inclusion check")
+		return
+	}
+	parentRoot, err := blockResponse.Data.ParentRoot()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain parent root for inclusion check")
+		return
+	}
+	blockAttestations, err := blockResponse.Data.Attestations()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain attestations for inclusion check")
+		return
+	}
+
+	if !attestationsIncluded(attestations, blockAttestations) {
+		log.Trace().Msg("Attestations not included in following block")
+		return
+	}
+	correctHead := attestations[0].Data.BeaconBlockRoot == parentRoot
+	log.Trace().Bool("correct_head", correctHead).Msg("Attestations included in following block")
+	s.observeInclusion(correctHead)
+}
+
+// attestationsIncluded returns true if any of the attestations are included in
+// the block attestations.
+func attestationsIncluded(attestations []*phase0.Attestation, blockAttestations []*phase0.Attestation) bool {
+	for _, attestation := range attestations {
+		if attestation == nil || attestation.Data == nil {
+			continue
+		}
+		for _, blockAttestation := range blockAttestations {
+			if blockAttestation.Data == nil ||
+				blockAttestation.Data.Slot != attestation.Data.Slot ||
+				blockAttestation.Data.Index != attestation.Data.Index ||
+				blockAttestation.Data.BeaconBlockRoot != attestation.Data.BeaconBlockRoot {
+				continue
+			}
+			for i := uint64(0); i < attestation.AggregationBits.Len() && i < blockAttestation.AggregationBits.Len(); i++ {
+				if attestation.AggregationBits.BitAt(i) && blockAttestation.AggregationBits.BitAt(i) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,280 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelayTuner(t *testing.T) {
+	tests := []struct {
+		name     string
+		arrivals []blockArrival
+		expected time.Duration
+	}{
+		{
+			name: "InsufficientObservations",
+			arrivals: []blockArrival{
+				{delay: time.Second},
+				{delay: time.Second},
+			},
+			expected: 4 * time.Second,
+		},
+		{
+			name: "EarlyBlocks",
+			arrivals: []blockArrival{
+				{delay: 1000 * time.Millisecond},
+				{delay: 1100 * time.Millisecond},
+				{delay: 1200 * time.Millisecond},
+				{delay: 1250 * time.Millisecond},
+				{delay: 1300 * time.Millisecond},
+				{delay: 1400 * time.Millisecond},
+				{delay: 1500 * time.Millisecond},
+				{delay: 1550 * time.Millisecond},
+			},
+			// 1.5s at the 95th percentile, plus margin.
+			expected: 1700 * time.Millisecond,
+		},
+		{
+			name: "MinDelay",
+			arrivals: []blockArrival{
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+				{delay: 100 * time.Millisecond},
+			},
+			expected: time.Second,
+		},
+		{
+			name: "MaxDelay",
+			arrivals: []blockArrival{
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+				{delay: 7 * time.Second},
+			},
+			expected: 6 * time.Second,
+		},
+		{
+			name: "LateBlock",
+			arrivals: []blockArrival{
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 3010 * time.Millisecond, late: true},
+			},
+			expected: 3300 * time.Millisecond,
+		},
+		{
+			name: "Window",
+			arrivals: []blockArrival{
+				{delay: 5000 * time.Millisecond, late: true},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+				{delay: 1000 * time.Millisecond},
+			},
+			// The late block has left the window.
+			expected: 1200 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				maxAttestationDelay:           4 * time.Second,
+				maxSyncCommitteeMessageDelay:  4 * time.Second,
+				attestationAggregationDelay:   8 * time.Second,
+				syncCommitteeAggregationDelay: 8 * time.Second,
+				delayTuner: newDelayTuner(4*time.Second, &DelayTuning{
+					Window:   16,
+					MinDelay: time.Second,
+					MaxDelay: 6 * time.Second,
+				}),
+			}
+			for _, arrival := range test.arrivals {
+				s.observeBlockArrival(arrival.delay, arrival.late)
+			}
+			require.Equal(t, test.expected, s.tunedMaxAttestationDelay())
+			// Other delays move by the same amount.
+			offset := test.expected - 4*time.Second
+			require.Equal(t, 4*time.Second+offset, s.tunedMaxSyncCommitteeMessageDelay())
+			require.Equal(t, 8*time.Second+offset, s.tunedAttestationAggregationDelay())
+			require.Equal(t, 8*time.Second+offset, s.tunedSyncCommitteeAggregationDelay())
+		})
+	}
+}
+
+func TestTunedDelaysWithoutTuner(t *testing.T) {
+	s := &Service{
+		maxAttestationDelay:            4 * time.Second,
+		maxSyncCommitteeMessageDelay:   3 * time.Second,
+		attestationAggregationDelay:    8 * time.Second,
+		minAttestationAggregationDelay: 6 * time.Second,
+		syncCommitteeAggregationDelay:  7 * time.Second,
+	}
+	s.observeBlockArrival(time.Second, true)
+	require.Equal(t, 4*time.Second, s.tunedMaxAttestationDelay())
+	require.Equal(t, 3*time.Second, s.tunedMaxSyncCommitteeMessageDelay())
+	require.Equal(t, 8*time.Second, s.tunedAttestationAggregationDelay())
+	require.Equal(t, 7*time.Second, s.tunedSyncCommitteeAggregationDelay())
+}
+
+func TestDelayTunerInclusion(t *testing.T) {
+	s := &Service{
+		maxAttestationDelay: 4 * time.Second,
+		delayTuner: newDelayTuner(4*time.Second, &DelayTuning{
+			Window:   16,
+			MinDelay: time.Second,
+			MaxDelay: 6 * time.Second,
+		}),
+	}
+	for i := 0; i < 8; i++ {
+		s.observeBlockArrival(1000*time.Millisecond, false)
+	}
+	require.Equal(t, 1200*time.Millisecond, s.tunedMaxAttestationDelay())
+
+	// Included attestations with correct head votes do not move the delay.
+	for i := 0; i < 8; i++ {
+		s.observeInclusion(true)
+	}
+	require.Equal(t, 1200*time.Millisecond, s.tunedMaxAttestationDelay())
+
+	// Too many incorrect head votes increase the delay.
+	s.observeInclusion(false)
+	require.Equal(t, 1300*time.Millisecond, s.tunedMaxAttestationDelay())
+	for i := 0; i < 7; i++ {
+		s.observeInclusion(true)
+	}
+	s.observeInclusion(false)
+	require.Equal(t, 1400*time.Millisecond, s.tunedMaxAttestationDelay())
+
+	// A full window of correct head votes decreases it again.
+	for i := 0; i < 15; i++ {
+		s.observeInclusion(true)
+	}
+	require.Equal(t, 1400*time.Millisecond, s.tunedMaxAttestationDelay())
+	s.observeInclusion(true)
+	require.Equal(t, 1300*time.Millisecond, s.tunedMaxAttestationDelay())
+
+	// The adjustment remains within the tuning bounds.
+	for i := 0; i < 1000; i++ {
+		s.observeInclusion(false)
+	}
+	require.Equal(t, 6*time.Second, s.tunedMaxAttestationDelay())
+}
+
+func TestAttestationsIncluded(t *testing.T) {
+	bits := func(set ...uint64) bitfield.Bitlist {
+		res := bitfield.NewBitlist(8)
+		for _, i := range set {
+			res.SetBitAt(i, true)
+		}
+		return res
+	}
+	attestation := func(slot phase0.Slot, index phase0.CommitteeIndex, root phase0.Root, aggregationBits bitfield.Bitlist) *phase0.Attestation {
+		return &phase0.Attestation{
+			AggregationBits: aggregationBits,
+			Data: &phase0.AttestationData{
+				Slot:            slot,
+				Index:           index,
+				BeaconBlockRoot: root,
+				Source:          &phase0.Checkpoint{},
+				Target:          &phase0.Checkpoint{},
+			},
+		}
+	}
+	ours := []*phase0.Attestation{
+		attestation(10, 1, phase0.Root{0x01}, bits(2)),
+		attestation(10, 3, phase0.Root{0x01}, bits(5)),
+	}
+
+	tests := []struct {
+		name              string
+		blockAttestations []*phase0.Attestation
+		included          bool
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "Included",
+			blockAttestations: []*phase0.Attestation{
+				attestation(10, 3, phase0.Root{0x01}, bits(1, 5, 7)),
+			},
+			included: true,
+		},
+		{
+			name: "OtherValidators",
+			blockAttestations: []*phase0.Attestation{
+				attestation(10, 1, phase0.Root{0x01}, bits(1, 3)),
+			},
+		},
+		{
+			name: "OtherCommittee",
+			blockAttestations: []*phase0.Attestation{
+				attestation(10, 2, phase0.Root{0x01}, bits(2)),
+			},
+		},
+		{
+			name: "OtherSlot",
+			blockAttestations: []*phase0.Attestation{
+				attestation(9, 1, phase0.Root{0x01}, bits(2)),
+			},
+		},
+		{
+			name: "OtherHead",
+			blockAttestations: []*phase0.Attestation{
+				attestation(10, 1, phase0.Root{0x02}, bits(2)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.included, attestationsIncluded(ours, test.blockAttestations))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyevents"
+)
+
+// publishDutyEvent publishes an event in the lifecycle of a duty, if duty
+// events are enabled.
+func (s *Service) publishDutyEvent(ctx context.Context,
+	duty string,
+	stage string,
+	slot phase0.Slot,
+	validatorIndices []phase0.ValidatorIndex,
+	err error,
+) {
+	if s.dutyEvents == nil {
+		return
+	}
+
+	event := &dutyevents.Event{
+		Duty:             duty,
+		Stage:            stage,
+		Slot:             slot,
+		ValidatorIndices: validatorIndices,
+		Timestamp:        time.Now(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	s.dutyEvents.Publish(ctx, event)
+}
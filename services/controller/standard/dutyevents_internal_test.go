@@ -0,0 +1,65 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/dutyevents"
+	mockdutyevents "github.com/attestantio/vouch/services/dutyevents/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyEvents(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	// Publishing without duty events should not fail.
+	s := &Service{
+		canaryIndices: make(map[phase0.ValidatorIndex]struct{}),
+	}
+	s.publishDutyEvent(ctx, dutyevents.DutyAttestation, dutyevents.StageScheduled, 1, nil, nil)
+
+	dutyEvents := mockdutyevents.New()
+	s = &Service{
+		signedBeaconBlockProvider: mock.NewSignedBeaconBlockProvider(),
+		canaryIndices:             make(map[phase0.ValidatorIndex]struct{}),
+		dutyEvents:                dutyEvents,
+	}
+
+	s.publishDutyEvent(ctx, dutyevents.DutyAttestation, dutyevents.StageFailed, 1, []phase0.ValidatorIndex{1, 2}, errors.New("failed"))
+	events := dutyEvents.Events()
+	require.Len(t, events, 1)
+	require.Equal(t, dutyevents.DutyAttestation, events[0].Duty)
+	require.Equal(t, dutyevents.StageFailed, events[0].Stage)
+	require.Equal(t, phase0.Slot(1), events[0].Slot)
+	require.Equal(t, []phase0.ValidatorIndex{1, 2}, events[0].ValidatorIndices)
+	require.Equal(t, "failed", events[0].Error)
+	require.False(t, events[0].Timestamp.IsZero())
+
+	// The mock block provider returns blocks proposed by validator 0.
+	s.checkProposal(ctx, beaconblockproposer.NewDuty(123, 0))
+	s.checkProposal(ctx, beaconblockproposer.NewDuty(123, 2))
+	events = dutyEvents.Events()
+	require.Len(t, events, 3)
+	require.Equal(t, dutyevents.StageConfirmed, events[1].Stage)
+	require.Equal(t, dutyevents.StageUnconfirmed, events[2].Stage)
+	require.Equal(t, "block on chain proposed by validator 0", events[2].Error)
+}
@@ -0,0 +1,140 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// DutyPlan describes the jobs that the controller carries out for a slot.
+type DutyPlan struct {
+	Slot phase0.Slot    `json:"slot,string"`
+	Jobs []*DutyPlanJob `json:"jobs"`
+}
+
+// DutyPlanJob describes a single job in a duty plan.
+type DutyPlanJob struct {
+	// Name is the name of the job as known to the scheduler.
+	Name string `json:"name"`
+	// Runtime is the latest time at which the job will run.
+	Runtime time.Time `json:"runtime"`
+	// Trigger describes the conditions under which the job runs.
+	Trigger string `json:"trigger"`
+	// DependsOn lists the jobs that must complete for this job to be scheduled.
+	DependsOn []string `json:"depends_on,omitempty"`
+	// Scheduled is true if the job is currently waiting to run.  A job that is not
+	// scheduled has either already run, or was never scheduled because it was not
+	// required or one of its dependencies did not complete.
+	Scheduled bool `json:"scheduled"`
+}
+
+// DutyPlan returns the plan of jobs for the given slot.
+func (s *Service) DutyPlan(ctx context.Context, slot phase0.Slot) *DutyPlan {
+	startOfSlot := s.chainTimeService.StartOfSlot(slot)
+	attestationJob := fmt.Sprintf(attestationsJobFormat, slot)
+	prepareSyncCommitteeJob := fmt.Sprintf(prepareSyncCommitteeMessagesJobFormat, slot)
+	syncCommitteeJob := fmt.Sprintf(syncCommitteeMessagesJobFormat, slot)
+
+	jobs := []*DutyPlanJob{
+		{
+			Name:    fmt.Sprintf(proposalJobFormat, slot),
+			Runtime: startOfSlot.Add(s.maxProposalDelay),
+			Trigger: fmt.Sprintf("block for slot %d received, head up to date at start of slot, or max proposal delay passed", slot-1),
+		},
+		{
+			Name:    attestationJob,
+			Runtime: startOfSlot.Add(s.tunedMaxAttestationDelay()),
+			Trigger: "block for slot received, or max attestation delay passed",
+		},
+	}
+	if s.maxProposalDelay > 0 {
+		jobs = append(jobs, &DutyPlanJob{
+			Name:    fmt.Sprintf(earlyProposalJobFormat, slot),
+			Runtime: startOfSlot,
+			Trigger: "start of slot",
+		})
+	}
+	if s.proposalReadinessChecker != nil {
+		jobs = append(jobs, &DutyPlanJob{
+			Name:    fmt.Sprintf(proposalReadinessJobFormat, slot),
+			Runtime: startOfSlot.Add(-s.slotDuration * time.Duration(s.proposalReadinessSlots)),
+			Trigger: fmt.Sprintf("%d slots before start of slot, if proposing", s.proposalReadinessSlots),
+		})
+	}
+	if s.handlingAltair {
+		jobs = append(jobs,
+			&DutyPlanJob{
+				Name:    prepareSyncCommitteeJob,
+				Runtime: startOfSlot.Add(-s.slotDuration * 6 / 4),
+				Trigger: "1.5 slots before start of slot",
+			},
+			&DutyPlanJob{
+				Name:      syncCommitteeJob,
+				Runtime:   startOfSlot.Add(s.tunedMaxSyncCommitteeMessageDelay()),
+				Trigger:   "block for slot received, or max sync committee message delay passed",
+				DependsOn: []string{prepareSyncCommitteeJob},
+			},
+			&DutyPlanJob{
+				Name:      fmt.Sprintf(syncCommitteeAggregationJobFormat, slot),
+				Runtime:   startOfSlot.Add(s.tunedSyncCommitteeAggregationDelay()),
+				Trigger:   "sync committee aggregation delay passed",
+				DependsOn: []string{syncCommitteeJob},
+			},
+		)
+	}
+
+	scheduledJobs := make(map[string]bool)
+	for _, name := range s.scheduler.ListJobs(ctx) {
+		scheduledJobs[name] = true
+	}
+	for _, job := range jobs {
+		job.Scheduled = scheduledJobs[job.Name]
+	}
+
+	// Attestation aggregation jobs are per-committee, so are only known once scheduled.
+	aggregationRuntime := startOfSlot.Add(s.tunedAttestationAggregationDelay())
+	aggregationTrigger := "attestation aggregation delay passed"
+	if s.minAttestationAggregationDelay > 0 {
+		aggregationRuntime = startOfSlot.Add(s.minAttestationAggregationDelay)
+		aggregationTrigger = "minimum attestation aggregation delay passed"
+	}
+	for name := range scheduledJobs {
+		if aggregationSlot, isAggregation := jobSlot(attestationAggregationJobFormat, name); isAggregation && aggregationSlot == slot {
+			jobs = append(jobs, &DutyPlanJob{
+				Name:      name,
+				Runtime:   aggregationRuntime,
+				Trigger:   aggregationTrigger,
+				DependsOn: []string{attestationJob},
+				Scheduled: true,
+			})
+		}
+	}
+
+	sort.SliceStable(jobs, func(i int, j int) bool {
+		if jobs[i].Runtime.Equal(jobs[j].Runtime) {
+			return jobs[i].Name < jobs[j].Name
+		}
+		return jobs[i].Runtime.Before(jobs[j].Runtime)
+	})
+
+	return &DutyPlan{
+		Slot: slot,
+		Jobs: jobs,
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/scheduler/advanced"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now().Add(-time.Hour)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	slot := phase0.Slot(10)
+	startOfSlot := chainTime.StartOfSlot(slot)
+
+	tests := []struct {
+		name      string
+		service   *Service
+		scheduled []string
+		expected  []*DutyPlanJob
+	}{
+		{
+			name: "Minimal",
+			service: &Service{
+				chainTimeService:    chainTime,
+				maxAttestationDelay: 4 * time.Second,
+			},
+			scheduled: []string{
+				"Attestations for slot 10",
+			},
+			expected: []*DutyPlanJob{
+				{
+					Name:    "Beacon block proposal for slot 10",
+					Runtime: startOfSlot,
+					Trigger: "block for slot 9 received, head up to date at start of slot, or max proposal delay passed",
+				},
+				{
+					Name:      "Attestations for slot 10",
+					Runtime:   startOfSlot.Add(4 * time.Second),
+					Trigger:   "block for slot received, or max attestation delay passed",
+					Scheduled: true,
+				},
+			},
+		},
+		{
+			name: "Full",
+			service: &Service{
+				chainTimeService:               chainTime,
+				slotDuration:                   12 * time.Second,
+				maxProposalDelay:               time.Second,
+				maxAttestationDelay:            4 * time.Second,
+				attestationAggregationDelay:    8 * time.Second,
+				minAttestationAggregationDelay: 9 * time.Second,
+				maxSyncCommitteeMessageDelay:   4 * time.Second,
+				syncCommitteeAggregationDelay:  8 * time.Second,
+				handlingAltair:                 true,
+			},
+			scheduled: []string{
+				"Beacon block proposal for slot 10",
+				"Attestations for slot 10",
+				"Sync committee messages for slot 10",
+				"Beacon block attestation aggregation for slot 10 committee 2",
+				"Beacon block attestation aggregation for slot 10 committee 1",
+				// Other slots.
+				"Attestations for slot 11",
+				"Beacon block attestation aggregation for slot 100 committee 1",
+			},
+			expected: []*DutyPlanJob{
+				{
+					Name:    "Prepare sync committee messages for slot 10",
+					Runtime: startOfSlot.Add(-18 * time.Second),
+					Trigger: "1.5 slots before start of slot",
+				},
+				{
+					Name:    "Early beacon block proposal for slot 10",
+					Runtime: startOfSlot,
+					Trigger: "start of slot",
+				},
+				{
+					Name:      "Beacon block proposal for slot 10",
+					Runtime:   startOfSlot.Add(time.Second),
+					Trigger:   "block for slot 9 received, head up to date at start of slot, or max proposal delay passed",
+					Scheduled: true,
+				},
+				{
+					Name:      "Attestations for slot 10",
+					Runtime:   startOfSlot.Add(4 * time.Second),
+					Trigger:   "block for slot received, or max attestation delay passed",
+					Scheduled: true,
+				},
+				{
+					Name:      "Sync committee messages for slot 10",
+					Runtime:   startOfSlot.Add(4 * time.Second),
+					Trigger:   "block for slot received, or max sync committee message delay passed",
+					DependsOn: []string{"Prepare sync committee messages for slot 10"},
+					Scheduled: true,
+				},
+				{
+					Name:      "Sync committee aggregation for slot 10",
+					Runtime:   startOfSlot.Add(8 * time.Second),
+					Trigger:   "sync committee aggregation delay passed",
+					DependsOn: []string{"Sync committee messages for slot 10"},
+				},
+				{
+					Name:      "Beacon block attestation aggregation for slot 10 committee 1",
+					Runtime:   startOfSlot.Add(9 * time.Second),
+					Trigger:   "minimum attestation aggregation delay passed",
+					DependsOn: []string{"Attestations for slot 10"},
+					Scheduled: true,
+				},
+				{
+					Name:      "Beacon block attestation aggregation for slot 10 committee 2",
+					Runtime:   startOfSlot.Add(9 * time.Second),
+					Trigger:   "minimum attestation aggregation delay passed",
+					DependsOn: []string{"Attestations for slot 10"},
+					Scheduled: true,
+				},
+			},
+		},
+	}
+
+	noop := func(_ context.Context, _ interface{}) {}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheduler, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled))
+			require.NoError(t, err)
+			for _, name := range test.scheduled {
+				require.NoError(t, scheduler.ScheduleJob(ctx, "Test", name, time.Now().Add(time.Hour), noop, nil))
+			}
+			test.service.scheduler = scheduler
+
+			plan := test.service.DutyPlan(ctx, slot)
+			require.Equal(t, slot, plan.Slot)
+			require.Equal(t, test.expected, plan.Jobs)
+
+			// Slots are encoded as strings, in line with the beacon API.
+			data, err := json.Marshal(plan)
+			require.NoError(t, err)
+			require.Contains(t, string(data), `"slot":"10"`)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/pkg/errors"
+)
+
+// DutyStatement is a summary of the duties carried out by Vouch in an epoch.
+type DutyStatement struct {
+	Epoch phase0.Epoch `json:"epoch,string"`
+	// Attestations are the validators for which attestations were made.
+	Attestations []phase0.ValidatorIndex `json:"attestations"`
+	// Proposals are the block proposals that were attempted.
+	Proposals []*DutyStatementProposal `json:"proposals"`
+	// SyncCommitteeMessages are the validators for which sync committee messages were
+	// made, keyed by slot.
+	SyncCommitteeMessages map[phase0.Slot][]phase0.ValidatorIndex `json:"sync_committee_messages,omitempty"`
+}
+
+// DutyStatementProposal is an attempted block proposal in a duty statement.
+type DutyStatementProposal struct {
+	Slot           phase0.Slot           `json:"slot,string"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index,string"`
+}
+
+// SignedDutyStatement is a duty statement signed by an operational key.
+type SignedDutyStatement struct {
+	// Statement is the JSON encoding of the duty statement, as signed.
+	Statement json.RawMessage `json:"statement"`
+	// PublicKey is the ed25519 public key that signed the statement.
+	PublicKey string `json:"public_key"`
+	// Signature is the ed25519 signature of the statement.
+	Signature string `json:"signature"`
+}
+
+// dutyStatement returns the duty statement for the epoch, creating it if required.
+// The duty statements mutex must be held when calling this.
+func (s *Service) dutyStatement(epoch phase0.Epoch) *DutyStatement {
+	statement, exists := s.dutyStatements[epoch]
+	if !exists {
+		statement = &DutyStatement{
+			Epoch:                 epoch,
+			Attestations:          make([]phase0.ValidatorIndex, 0),
+			Proposals:             make([]*DutyStatementProposal, 0),
+			SyncCommitteeMessages: make(map[phase0.Slot][]phase0.ValidatorIndex),
+		}
+		s.dutyStatements[epoch] = statement
+	}
+
+	return statement
+}
+
+// recordAttestations records the validators for which attestations were made.
+func (s *Service) recordAttestations(duty *attester.Duty, attestations []*phase0.Attestation) {
+	if s.dutyStatementKey == nil {
+		return
+	}
+
+	attested := attestedValidators(duty, attestations)
+
+	s.dutyStatementsMu.Lock()
+	statement := s.dutyStatement(s.chainTimeService.SlotToEpoch(duty.Slot()))
+	statement.Attestations = append(statement.Attestations, attested...)
+	s.dutyStatementsMu.Unlock()
+}
+
+// attestedValidators returns the validators in the duty for which attestations
+// were made, matching them by committee and position.
+func attestedValidators(duty *attester.Duty, attestations []*phase0.Attestation) []phase0.ValidatorIndex {
+	validatorIndices := duty.ValidatorIndices()
+	committeeIndices := duty.CommitteeIndices()
+	validatorCommitteeIndices := duty.ValidatorCommitteeIndices()
+	attested := make([]phase0.ValidatorIndex, 0, len(attestations))
+	for _, attestation := range attestations {
+		if attestation == nil || attestation.Data == nil {
+			continue
+		}
+		for i := range validatorIndices {
+			if committeeIndices[i] == attestation.Data.Index &&
+				validatorCommitteeIndices[i] < attestation.AggregationBits.Len() &&
+				attestation.AggregationBits.BitAt(validatorCommitteeIndices[i]) {
+				attested = append(attested, validatorIndices[i])
+			}
+		}
+	}
+
+	return attested
+}
+
+// recordProposal records an attempted block proposal.
+func (s *Service) recordProposal(duty *beaconblockproposer.Duty) {
+	if s.dutyStatementKey == nil {
+		return
+	}
+
+	s.dutyStatementsMu.Lock()
+	statement := s.dutyStatement(s.chainTimeService.SlotToEpoch(duty.Slot()))
+	statement.Proposals = append(statement.Proposals, &DutyStatementProposal{
+		Slot:           duty.Slot(),
+		ValidatorIndex: duty.ValidatorIndex(),
+	})
+	s.dutyStatementsMu.Unlock()
+}
+
+// recordSyncCommitteeMessages records the validators for which sync committee messages were made.
+func (s *Service) recordSyncCommitteeMessages(slot phase0.Slot, messages []*altair.SyncCommitteeMessage) {
+	if s.dutyStatementKey == nil || len(messages) == 0 {
+		return
+	}
+
+	s.dutyStatementsMu.Lock()
+	statement := s.dutyStatement(s.chainTimeService.SlotToEpoch(slot))
+	for _, message := range messages {
+		statement.SyncCommitteeMessages[slot] = append(statement.SyncCommitteeMessages[slot], message.ValidatorIndex)
+	}
+	s.dutyStatementsMu.Unlock()
+}
+
+// propose proposes a block within the duty deadline, recording the attempt.
+func (s *Service) propose(ctx context.Context, data interface{}) {
+	if s.proposalsPaused.Load() {
+		log.Info().Msg("Beacon block proposals are paused; not proposing")
+		return
+	}
+
+	duty, ok := data.(*beaconblockproposer.Duty)
+	if ok {
+		if !s.startDuty(ctx, duty.Slot()) {
+			return
+		}
+		defer s.endDuty()
+		s.recordProposal(duty)
+		s.publishDutyEvent(ctx, dutyevents.DutyProposal, dutyevents.StageStarted, duty.Slot(), []phase0.ValidatorIndex{duty.ValidatorIndex()}, nil)
+		dutyCtx, cancel := s.dutyContext(ctx, duty.Slot())
+		s.beaconBlockProposer.Propose(dutyCtx, data)
+		cancel()
+		s.scheduleProposalCheck(ctx, duty)
+		return
+	}
+	s.beaconBlockProposer.Propose(ctx, data)
+}
+
+// issueDutyStatements issues statements for epochs whose duties are complete.
+func (s *Service) issueDutyStatements(currentEpoch phase0.Epoch) {
+	if s.dutyStatementKey == nil {
+		return
+	}
+
+	// Duties for an epoch can run in to the start of the following epoch, so
+	// only issue statements for epochs prior to that.
+	statements := make([]*DutyStatement, 0)
+	s.dutyStatementsMu.Lock()
+	for epoch, statement := range s.dutyStatements {
+		if epoch+1 < currentEpoch {
+			statements = append(statements, statement)
+			delete(s.dutyStatements, epoch)
+		}
+	}
+	s.dutyStatementsMu.Unlock()
+
+	for _, statement := range statements {
+		if err := s.issueDutyStatement(statement); err != nil {
+			log.Error().Err(err).Uint64("epoch", uint64(statement.Epoch)).Msg("Failed to issue duty statement")
+			continue
+		}
+		log.Debug().Uint64("epoch", uint64(statement.Epoch)).Msg("Issued duty statement")
+	}
+}
+
+// issueDutyStatement signs a duty statement and writes it to the statement directory.
+func (s *Service) issueDutyStatement(statement *DutyStatement) error {
+	sort.Slice(statement.Attestations, func(i int, j int) bool {
+		return statement.Attestations[i] < statement.Attestations[j]
+	})
+	sort.Slice(statement.Proposals, func(i int, j int) bool {
+		return statement.Proposals[i].Slot < statement.Proposals[j].Slot
+	})
+
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal statement")
+	}
+	signed, err := json.Marshal(&SignedDutyStatement{
+		Statement: data,
+		PublicKey: fmt.Sprintf("%#x", []byte(s.dutyStatementKey.Public().(ed25519.PublicKey))),
+		Signature: fmt.Sprintf("%#x", ed25519.Sign(s.dutyStatementKey, data)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signed statement")
+	}
+
+	if err := os.MkdirAll(s.dutyStatementDir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create statement directory")
+	}
+	path := filepath.Join(s.dutyStatementDir, fmt.Sprintf("%d.json", statement.Epoch))
+	if err := os.WriteFile(path, signed, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write statement")
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyStatements(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	s := &Service{
+		chainTimeService: chainTime,
+		dutyStatementKey: key,
+		dutyStatementDir: dir,
+		dutyStatements:   make(map[phase0.Epoch]*DutyStatement),
+	}
+
+	// Validators 10 and 11 are in committee 1 at positions 2 and 5; validator 12 is
+	// in committee 2 at position 0.  Only validators 10 and 12 attest.
+	attesterDuty, err := attester.NewDuty(ctx, 40, 4,
+		[]phase0.ValidatorIndex{10, 11, 12},
+		[]phase0.CommitteeIndex{1, 1, 2},
+		[]uint64{2, 5, 0},
+		map[phase0.CommitteeIndex]uint64{1: 8, 2: 8},
+	)
+	require.NoError(t, err)
+	bits1 := bitfield.NewBitlist(8)
+	bits1.SetBitAt(2, true)
+	bits2 := bitfield.NewBitlist(8)
+	bits2.SetBitAt(0, true)
+	s.recordAttestations(attesterDuty, []*phase0.Attestation{
+		{AggregationBits: bits2, Data: &phase0.AttestationData{Slot: 40, Index: 2}},
+		{AggregationBits: bits1, Data: &phase0.AttestationData{Slot: 40, Index: 1}},
+	})
+	s.recordProposal(beaconblockproposer.NewDuty(35, 20))
+	s.recordSyncCommitteeMessages(33, []*altair.SyncCommitteeMessage{{Slot: 33, ValidatorIndex: 30}})
+
+	// Statement for epoch 1 should not be issued until epoch 3.
+	s.issueDutyStatements(2)
+	_, err = os.Stat(filepath.Join(dir, "1.json"))
+	require.True(t, os.IsNotExist(err))
+
+	s.issueDutyStatements(3)
+	data, err := os.ReadFile(filepath.Join(dir, "1.json"))
+	require.NoError(t, err)
+	require.Empty(t, s.dutyStatements)
+
+	var signed SignedDutyStatement
+	require.NoError(t, json.Unmarshal(data, &signed))
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(signed.PublicKey, "0x"))
+	require.NoError(t, err)
+	signature, err := hex.DecodeString(strings.TrimPrefix(signed.Signature, "0x"))
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(pubKey, signed.Statement, signature))
+
+	var statement DutyStatement
+	require.NoError(t, json.Unmarshal(signed.Statement, &statement))
+	require.Equal(t, phase0.Epoch(1), statement.Epoch)
+	require.Equal(t, []phase0.ValidatorIndex{10, 12}, statement.Attestations)
+	require.Equal(t, []*DutyStatementProposal{{Slot: 35, ValidatorIndex: 20}}, statement.Proposals)
+	require.Equal(t, map[phase0.Slot][]phase0.ValidatorIndex{33: {30}}, statement.SyncCommitteeMessages)
+}
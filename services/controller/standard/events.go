@@ -47,23 +47,149 @@ func (s *Service) HandleHeadEvent(event *api.Event) {
 		return
 	}
 
-	var zeroRoot phase0.Root
-
 	data := event.Data.(*api.HeadEvent)
 	log := log.With().Uint64("slot", uint64(data.Slot)).Logger()
 	log.Trace().Msg("Received head event")
 
+	if !s.processHeadEvent(ctx, data) {
+		return
+	}
+
+	_, late := s.checkLateBlock(data.Slot, data.Block)
+	s.observeBlockArrival(time.Since(s.chainTimeService.StartOfSlot(data.Slot)), late)
+
+	// Events for earlier slots, for example a block that arrived late or a
+	// reorganisation to a shorter chain, update our view of the chain but
+	// are too late to trigger duties.
 	if data.Slot != s.chainTimeService.CurrentSlot() {
 		return
 	}
 
+	s.triggerSyncCommitteeMessages(ctx, data.Slot)
+
+	// We give the block some time to propagate around the rest of the
+	// nodes before kicking off attestations for the block's slot.
+	time.Sleep(200 * time.Millisecond)
+	s.triggerAttestations(ctx, data.Slot)
+
+	// Remove old subscriptions if present.
+	delete(s.subscriptionInfos, s.chainTimeService.SlotToEpoch(data.Slot)-2)
+}
+
+// HandleChainReorgEvent handles the "chain_reorg" events from the beacon node.
+func (s *Service) HandleChainReorgEvent(event *api.Event) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.controller.standard").Start(context.Background(), "HandleChainReorgEvent")
+	defer span.End()
+
+	if event.Data == nil {
+		return
+	}
+
+	data, ok := event.Data.(*api.ChainReorgEvent)
+	if !ok {
+		log.Warn().Msg("Chain reorg event with unexpected data; ignoring")
+		return
+	}
+	log := log.With().Uint64("slot", uint64(data.Slot)).Uint64("depth", data.Depth).Logger()
+	log.Trace().Msg("Received chain reorg event")
+
+	s.headEventsMu.Lock()
+	reason := s.checkChainReorgEventSequence(data.Slot, data.NewHeadBlock)
+	s.headEventsMu.Unlock()
+	if reason != "" {
+		log.Debug().Stringer("block", data.NewHeadBlock).Str("reason", reason).Msg("Chain reorg event already processed; ignoring")
+		return
+	}
+
+	// The head event for the new head carries its dependent roots, but duties
+	// are refreshed here as well in case the reorganisation replaced the block
+	// at a dependent root and the head event is missed.
+	var ancestor phase0.Slot
+	if uint64(data.Slot) > data.Depth {
+		ancestor = data.Slot - phase0.Slot(data.Depth)
+	}
+	epoch := s.chainTimeService.CurrentEpoch()
+	if ancestor < s.chainTimeService.FirstSlotOfEpoch(epoch) {
+		log.Debug().Msg("Chain reorg could change current dependent root")
+		go s.handleCurrentDependentRootChanged(ctx)
+	}
+	if epoch > 0 && ancestor < s.chainTimeService.FirstSlotOfEpoch(epoch-1) {
+		log.Debug().Msg("Chain reorg could change previous dependent root")
+		go s.handlePreviousDependentRootChanged(ctx)
+	}
+}
+
+// triggerAttestations starts attestations for the slot following the arrival
+// of its block, if configured to do so.  Attestations are held back until the
+// minimum attestation delay has passed.
+func (s *Service) triggerAttestations(ctx context.Context, slot phase0.Slot) {
+	if s.attestationTrigger != "block" {
+		return
+	}
+
+	jobName := fmt.Sprintf(attestationsJobFormat, slot)
+	if !s.scheduler.JobExists(ctx, jobName) {
+		return
+	}
+
+	wait := time.Until(s.chainTimeService.StartOfSlot(slot).Add(s.minAttestationDelay))
+	if wait <= 0 {
+		log.Trace().Uint64("slot", uint64(slot)).Msg("Kicking off attestations for slot early due to receiving relevant block")
+		s.scheduler.RunJobIfExists(ctx, jobName)
+		return
+	}
+
+	log.Trace().Uint64("slot", uint64(slot)).Dur("wait", wait).Msg("Kicking off attestations for slot after minimum attestation delay")
+	time.AfterFunc(wait, func() {
+		s.scheduler.RunJobIfExists(ctx, jobName)
+	})
+}
+
+// triggerSyncCommitteeMessages starts sync committee messages for the slot
+// once the grace period following the arrival of its block has passed.
+func (s *Service) triggerSyncCommitteeMessages(ctx context.Context, slot phase0.Slot) {
+	jobName := fmt.Sprintf(syncCommitteeMessagesJobFormat, slot)
+	if !s.scheduler.JobExists(ctx, jobName) {
+		return
+	}
+
+	if s.syncCommitteeMessageGrace == 0 {
+		log.Trace().Uint64("slot", uint64(slot)).Msg("Kicking off sync committee messages for slot early due to receiving relevant block")
+		s.scheduler.RunJobIfExists(ctx, jobName)
+		return
+	}
+
+	log.Trace().Uint64("slot", uint64(slot)).Dur("grace", s.syncCommitteeMessageGrace).Msg("Kicking off sync committee messages for slot after grace period")
+	time.AfterFunc(s.syncCommitteeMessageGrace, func() {
+		s.scheduler.RunJobIfExists(ctx, jobName)
+	})
+}
+
+// processHeadEvent updates the controller's view of the chain given a head event,
+// returning false if the event should be ignored.
+// Events are processed one at a time; duplicate events, for example those replayed
+// by a beacon node after its event stream reconnects, are ignored.  Events for slots
+// earlier than that of the latest processed event are processed, as they can be the
+// result of a reorganisation to a shorter chain.
+func (s *Service) processHeadEvent(ctx context.Context, data *api.HeadEvent) bool {
+	var zeroRoot phase0.Root
+	log := log.With().Uint64("slot", uint64(data.Slot)).Logger()
+
+	s.headEventsMu.Lock()
+	defer s.headEventsMu.Unlock()
+
+	if reason := s.checkHeadEventSequence(data.Slot, data.Block); reason != "" {
+		log.Debug().Stringer("block", data.Block).Str("reason", reason).Msg("Head event already processed; ignoring")
+		return false
+	}
+
 	// Old versions of teku send a synthetic head event when they don't receive a block
 	// by a certain time after start of the slot.  We only care about real block updates
 	// for the purposes of this function, so ignore them.
 	if !bytes.Equal(s.lastBlockRoot[:], zeroRoot[:]) &&
 		bytes.Equal(s.lastBlockRoot[:], data.Block[:]) {
 		log.Trace().Msg("Synthetic head event; ignoring")
-		return
+		return false
 	}
 	s.lastBlockRoot = data.Block
 	epoch := s.chainTimeService.SlotToEpoch(data.Slot)
@@ -73,7 +199,7 @@ func (s *Service) HandleHeadEvent(event *api.Event) {
 	// If this block is for the prior slot and we may have a proposal waiting then kick
 	// off any proposal for this slot.
 	if data.Slot == s.chainTimeService.CurrentSlot()-1 && s.maxProposalDelay > 0 {
-		proposalJobName := fmt.Sprintf("Beacon block proposal for slot %d", s.chainTimeService.CurrentSlot())
+		proposalJobName := fmt.Sprintf(proposalJobFormat, s.chainTimeService.CurrentSlot())
 		if s.scheduler.JobExists(ctx, proposalJobName) {
 			log.Trace().Msg("Kicking off proposal for slot now that parent block for last slot has arrived")
 			s.scheduler.RunJobIfExists(ctx, proposalJobName)
@@ -124,22 +250,71 @@ func (s *Service) HandleHeadEvent(event *api.Event) {
 	s.previousDutyDependentRoot = data.PreviousDutyDependentRoot
 	s.currentDutyDependentRoot = data.CurrentDutyDependentRoot
 
-	// We give the block some time to propagate around the rest of the
-	// nodes before kicking off attestations and sync committees for the block's slot.
-	time.Sleep(200 * time.Millisecond)
-	jobName := fmt.Sprintf("Attestations for slot %d", data.Slot)
-	if s.scheduler.JobExists(ctx, jobName) {
-		log.Trace().Msg("Kicking off attestations for slot early due to receiving relevant block")
-		s.scheduler.RunJobIfExists(ctx, jobName)
+	return true
+}
+
+// headEventRetention is the number of slots before that of the latest head
+// event for which processed head events are remembered.
+const headEventRetention = 64
+
+// headEvent is the slot and block of a processed head or chain reorg event.
+type headEvent struct {
+	reorg bool
+	slot  phase0.Slot
+	block phase0.Root
+}
+
+// checkHeadEventSequence checks that a head event has not already been processed,
+// recording it if not.  Only events with the same slot and block are considered to
+// be the same; an event for an earlier slot than that of the latest event is a
+// reorganisation rather than a duplicate.
+// It returns an empty string if the event should be processed, otherwise the reason it
+// should not.
+// This assumes that the head events mutex is held.
+func (s *Service) checkHeadEventSequence(slot phase0.Slot, block phase0.Root) string {
+	return s.checkEventSequence(headEvent{
+		slot:  slot,
+		block: block,
+	})
+}
+
+// checkChainReorgEventSequence checks that a chain reorg event to the given new head
+// has not already been processed, recording it if not.
+// This assumes that the head events mutex is held.
+func (s *Service) checkChainReorgEventSequence(slot phase0.Slot, newHeadBlock phase0.Root) string {
+	return s.checkEventSequence(headEvent{
+		reorg: true,
+		slot:  slot,
+		block: newHeadBlock,
+	})
+}
+
+// checkEventSequence checks that an event has not already been processed,
+// recording it if not.
+// This assumes that the head events mutex is held.
+func (s *Service) checkEventSequence(event headEvent) string {
+	if s.headEvents == nil {
+		s.headEvents = make(map[headEvent]struct{})
 	}
-	jobName = fmt.Sprintf("Sync committee messages for slot %d", data.Slot)
-	if s.scheduler.JobExists(ctx, jobName) {
-		log.Trace().Msg("Kicking off sync committee contributions for slot early due to receiving relevant block")
-		s.scheduler.RunJobIfExists(ctx, jobName)
+
+	slot := event.slot
+	if _, exists := s.headEvents[event]; exists {
+		return "duplicate"
+	}
+	s.headEvents[event] = struct{}{}
+
+	if slot > s.headEventsSlot {
+		s.headEventsSlot = slot
+		if slot > headEventRetention {
+			for processed := range s.headEvents {
+				if processed.slot < slot-headEventRetention {
+					delete(s.headEvents, processed)
+				}
+			}
+		}
 	}
 
-	// Remove old subscriptions if present.
-	delete(s.subscriptionInfos, s.chainTimeService.SlotToEpoch(data.Slot)-2)
+	return ""
 }
 
 // handlePreviousDependentRootChanged handles the situation where the previous
@@ -175,8 +350,8 @@ func (s *Service) refreshProposerDutiesForEpoch(ctx context.Context, epoch phase
 
 	// First thing we do is cancel all scheduled beacon bock proposal jobs for the epoch.
 	for slot := s.chainTimeService.FirstSlotOfEpoch(epoch); slot < s.chainTimeService.FirstSlotOfEpoch(epoch+1); slot++ {
-		s.scheduler.CancelJobIfExists(ctx, fmt.Sprintf("Early beacon block proposal for slot %d", slot))
-		s.scheduler.CancelJobIfExists(ctx, fmt.Sprintf("Beacon block proposal for slot %d", slot))
+		s.scheduler.CancelJobIfExists(ctx, fmt.Sprintf(earlyProposalJobFormat, slot))
+		s.scheduler.CancelJobIfExists(ctx, fmt.Sprintf(proposalJobFormat, slot))
 	}
 
 	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch)
@@ -209,7 +384,7 @@ func (s *Service) refreshAttesterDutiesForEpoch(ctx context.Context, epoch phase
 	cancelledJobs := make(map[phase0.Slot]bool)
 	// First thing we do is cancel all scheduled attestations jobs.
 	for slot := s.chainTimeService.FirstSlotOfEpoch(epoch); slot < s.chainTimeService.FirstSlotOfEpoch(epoch+1); slot++ {
-		if err := s.scheduler.CancelJob(ctx, fmt.Sprintf("Attestations for slot %d", slot)); err == nil {
+		if err := s.scheduler.CancelJob(ctx, fmt.Sprintf(attestationsJobFormat, slot)); err == nil {
 			cancelledJobs[slot] = true
 		}
 	}
@@ -268,15 +443,15 @@ func (s *Service) refreshSyncCommitteeDutiesForEpochPeriod(ctx context.Context,
 
 	// First thing we do is cancel all scheduled sync committee message jobs.
 	for slot := firstSlot; slot <= lastSlot; slot++ {
-		prepareJobName := fmt.Sprintf("Prepare sync committee messages for slot %d", slot)
+		prepareJobName := fmt.Sprintf(prepareSyncCommitteeMessagesJobFormat, slot)
 		if err := s.scheduler.CancelJob(ctx, prepareJobName); err != nil {
 			log.Debug().Str("job_name", prepareJobName).Err(err).Msg("Failed to cancel prepare sync committee message job")
 		}
-		messageJobName := fmt.Sprintf("Sync committee messages for slot %d", slot)
+		messageJobName := fmt.Sprintf(syncCommitteeMessagesJobFormat, slot)
 		if err := s.scheduler.CancelJob(ctx, messageJobName); err != nil {
 			log.Debug().Str("job_name", messageJobName).Err(err).Msg("Failed to cancel sync committee message job")
 		}
-		aggregateJobName := fmt.Sprintf("Sync committee aggregation for slot %d", slot)
+		aggregateJobName := fmt.Sprintf(syncCommitteeAggregationJobFormat, slot)
 		if err := s.scheduler.CancelJob(ctx, aggregateJobName); err != nil {
 			log.Debug().Str("job_name", aggregateJobName).Err(err).Msg("Failed to cancel sync committee aggregate job")
 		}
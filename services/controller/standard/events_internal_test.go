@@ -0,0 +1,258 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/scheduler/advanced"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHeadEventSequence(t *testing.T) {
+	s := &Service{}
+
+	// First event.
+	require.Equal(t, "", s.checkHeadEventSequence(10, phase0.Root{0x01}))
+	// Duplicate event.
+	require.Equal(t, "duplicate", s.checkHeadEventSequence(10, phase0.Root{0x01}))
+	// Reorg within the same slot.
+	require.Equal(t, "", s.checkHeadEventSequence(10, phase0.Root{0x02}))
+	// Replay of the earlier head for the slot.
+	require.Equal(t, "duplicate", s.checkHeadEventSequence(10, phase0.Root{0x01}))
+	// Next slot.
+	require.Equal(t, "", s.checkHeadEventSequence(11, phase0.Root{0x03}))
+	// Reorg to a head at an earlier slot.
+	require.Equal(t, "", s.checkHeadEventSequence(10, phase0.Root{0x04}))
+	// Replay of an event for an earlier slot.
+	require.Equal(t, "duplicate", s.checkHeadEventSequence(10, phase0.Root{0x02}))
+	require.Equal(t, "duplicate", s.checkHeadEventSequence(11, phase0.Root{0x03}))
+	// Same block in a later slot, as sent by some beacon nodes for empty slots.
+	require.Equal(t, "", s.checkHeadEventSequence(12, phase0.Root{0x03}))
+	// Events are forgotten once they are outside the retention window.
+	require.Equal(t, "", s.checkHeadEventSequence(10+headEventRetention+1, phase0.Root{0x05}))
+	require.Equal(t, "", s.checkHeadEventSequence(10, phase0.Root{0x01}))
+	require.Equal(t, "duplicate", s.checkHeadEventSequence(11, phase0.Root{0x03}))
+}
+
+func TestCheckChainReorgEventSequence(t *testing.T) {
+	s := &Service{}
+
+	// Head event for the new head does not hide the chain reorg event.
+	require.Equal(t, "", s.checkHeadEventSequence(10, phase0.Root{0x01}))
+	require.Equal(t, "", s.checkChainReorgEventSequence(10, phase0.Root{0x01}))
+	// Duplicate event.
+	require.Equal(t, "duplicate", s.checkChainReorgEventSequence(10, phase0.Root{0x01}))
+	// Reorg to a different head.
+	require.Equal(t, "", s.checkChainReorgEventSequence(10, phase0.Root{0x02}))
+}
+
+func TestHandleHeadEventPreviousSlot(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	slot := chainTime.CurrentSlot()
+	scheduler, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled))
+	require.NoError(t, err)
+
+	s := &Service{
+		monitor:            nullmetrics.New(ctx),
+		chainTimeService:   chainTime,
+		scheduler:          scheduler,
+		slotsPerEpoch:      32,
+		maxProposalDelay:   time.Second,
+		attestationTrigger: "block",
+	}
+
+	proposed := make(chan struct{})
+	proposalJobName := fmt.Sprintf("Beacon block proposal for slot %d", slot)
+	require.NoError(t, scheduler.ScheduleJob(ctx, "Propose", proposalJobName, time.Now().Add(time.Hour), func(_ context.Context, _ interface{}) { close(proposed) }, nil))
+	defer scheduler.CancelJobIfExists(ctx, proposalJobName)
+	attested := make(chan struct{})
+	attestationsJobName := fmt.Sprintf("Attestations for slot %d", slot-1)
+	require.NoError(t, scheduler.ScheduleJob(ctx, "Attest", attestationsJobName, time.Now().Add(time.Hour), func(_ context.Context, _ interface{}) { close(attested) }, nil))
+	defer scheduler.CancelJobIfExists(ctx, attestationsJobName)
+
+	// The parent block for the current slot arrives.
+	s.HandleHeadEvent(&api.Event{
+		Topic: "head",
+		Data: &api.HeadEvent{
+			Slot:  slot - 1,
+			Block: phase0.Root{0x01},
+		},
+	})
+
+	select {
+	case <-proposed:
+	case <-time.After(time.Second):
+		require.Fail(t, "proposal not triggered")
+	}
+	require.Equal(t, phase0.Root{0x01}, s.lastBlockRoot)
+
+	// Attestations for the earlier slot are not triggered.
+	select {
+	case <-attested:
+		require.Fail(t, "attestations triggered for earlier slot")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestTriggerAttestations(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	slot := chainTime.CurrentSlot()
+	scheduler, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled))
+	require.NoError(t, err)
+	jobName := fmt.Sprintf("Attestations for slot %d", slot)
+
+	tests := []struct {
+		name    string
+		trigger string
+		// minDelayFromNow is the minimum attestation delay relative to now.
+		minDelayFromNow time.Duration
+		runBefore       time.Duration
+		runAfter        time.Duration
+	}{
+		{
+			name:     "Delay",
+			trigger:  "delay",
+			runAfter: -1,
+		},
+		{
+			name:     "Block",
+			trigger:  "block",
+			runAfter: 0,
+		},
+		{
+			name:            "BlockMinDelayPassed",
+			trigger:         "block",
+			minDelayFromNow: -time.Millisecond,
+			runAfter:        0,
+		},
+		{
+			name:            "BlockMinDelay",
+			trigger:         "block",
+			minDelayFromNow: 200 * time.Millisecond,
+			runBefore:       100 * time.Millisecond,
+			runAfter:        200 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				chainTimeService:   chainTime,
+				scheduler:          scheduler,
+				attestationTrigger: test.trigger,
+			}
+			if test.minDelayFromNow != 0 {
+				s.minAttestationDelay = time.Since(chainTime.StartOfSlot(slot)) + test.minDelayFromNow
+			}
+
+			ran := make(chan struct{})
+			job := func(_ context.Context, _ interface{}) { close(ran) }
+			require.NoError(t, scheduler.ScheduleJob(ctx, "Attest", jobName, time.Now().Add(time.Hour), job, nil))
+			defer scheduler.CancelJobIfExists(ctx, jobName)
+
+			s.triggerAttestations(ctx, slot)
+
+			if test.runBefore > 0 {
+				select {
+				case <-ran:
+					require.Fail(t, "attestations triggered before minimum delay")
+				case <-time.After(test.runBefore):
+				}
+			}
+			select {
+			case <-ran:
+				require.GreaterOrEqual(t, test.runAfter, time.Duration(0), "attestations triggered unexpectedly")
+			case <-time.After(test.runAfter + 500*time.Millisecond):
+				require.Less(t, test.runAfter, time.Duration(0), "attestations not triggered")
+			}
+		})
+	}
+}
+
+func TestTriggerSyncCommitteeMessages(t *testing.T) {
+	ctx := context.Background()
+
+	scheduler, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled))
+	require.NoError(t, err)
+	slot := phase0.Slot(100)
+	jobName := fmt.Sprintf("Sync committee messages for slot %d", slot)
+
+	tests := []struct {
+		name      string
+		grace     time.Duration
+		runBefore time.Duration
+	}{
+		{
+			name: "NoGrace",
+		},
+		{
+			name:      "Grace",
+			grace:     200 * time.Millisecond,
+			runBefore: 100 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				scheduler:                 scheduler,
+				syncCommitteeMessageGrace: test.grace,
+			}
+
+			ran := make(chan struct{})
+			job := func(_ context.Context, _ interface{}) { close(ran) }
+			require.NoError(t, scheduler.ScheduleJob(ctx, "Generate sync committee messages", jobName, time.Now().Add(time.Hour), job, nil))
+			defer scheduler.CancelJobIfExists(ctx, jobName)
+
+			s.triggerSyncCommitteeMessages(ctx, slot)
+
+			if test.runBefore > 0 {
+				select {
+				case <-ran:
+					require.Fail(t, "sync committee messages triggered before grace period")
+				case <-time.After(test.runBefore):
+				}
+			}
+			select {
+			case <-ran:
+			case <-time.After(test.grace + 500*time.Millisecond):
+				require.Fail(t, "sync committee messages not triggered")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Formats of the names of the jobs scheduled by the controller.  Each format
+// takes the slot of the job as its first argument.
+const (
+	attestationsJobFormat                 = "Attestations for slot %d"
+	attestationAggregationJobFormat       = "Beacon block attestation aggregation for slot %d committee %d"
+	proposalJobFormat                     = "Beacon block proposal for slot %d"
+	earlyProposalJobFormat                = "Early beacon block proposal for slot %d"
+	proposalReadinessJobFormat            = "Proposal readiness check for slot %d"
+	prepareSyncCommitteeMessagesJobFormat = "Prepare sync committee messages for slot %d"
+	syncCommitteeMessagesJobFormat        = "Sync committee messages for slot %d"
+	syncCommitteeAggregationJobFormat     = "Sync committee aggregation for slot %d"
+	proposalCheckJobFormat                = "Proposal check for slot %d"
+	inclusionCheckJobFormat               = "Inclusion check for slot %d"
+)
+
+// jobSlot returns the slot of the job with the given name, if the name was
+// created from the given format.
+func jobSlot(format string, name string) (phase0.Slot, bool) {
+	values := make([]uint64, strings.Count(format, "%d"))
+	if len(values) == 0 {
+		return 0, false
+	}
+	args := make([]interface{}, len(values))
+	for i := range values {
+		args[i] = &values[i]
+	}
+	if _, err := fmt.Sscanf(name, format, args...); err != nil {
+		return 0, false
+	}
+	// Sscanf accepts trailing input and variant spacing, so confirm the match.
+	for i := range values {
+		args[i] = values[i]
+	}
+	if fmt.Sprintf(format, args...) != name {
+		return 0, false
+	}
+
+	return phase0.Slot(values[0]), true
+}
@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSlot(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		jobName string
+		slot    phase0.Slot
+		found   bool
+	}{
+		{
+			name:    "Match",
+			format:  proposalJobFormat,
+			jobName: "Beacon block proposal for slot 12",
+			slot:    12,
+			found:   true,
+		},
+		{
+			name:    "MultipleValues",
+			format:  attestationAggregationJobFormat,
+			jobName: "Beacon block attestation aggregation for slot 12 committee 3",
+			slot:    12,
+			found:   true,
+		},
+		{
+			name:    "OtherFormat",
+			format:  proposalJobFormat,
+			jobName: "Early beacon block proposal for slot 12",
+		},
+		{
+			name:    "TrailingText",
+			format:  syncCommitteeMessagesJobFormat,
+			jobName: "Sync committee messages for slot 12 extra",
+		},
+		{
+			name:    "NotNumeric",
+			format:  attestationsJobFormat,
+			jobName: "Attestations for slot twelve",
+		},
+		{
+			name:    "NoValues",
+			format:  "Fixed job",
+			jobName: "Fixed job",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slot, found := jobSlot(test.format, test.jobName)
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.slot, slot)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// attestedHead is the head block voted for by attestations for a slot.
+type attestedHead struct {
+	root       phase0.Root
+	attestedAt time.Time
+}
+
+// recordAttestedHead records the head block voted for by attestations for a
+// slot, allowing a block for the slot that arrives afterwards to be detected.
+func (s *Service) recordAttestedHead(slot phase0.Slot, attestations []*phase0.Attestation) {
+	for _, attestation := range attestations {
+		if attestation == nil || attestation.Data == nil {
+			continue
+		}
+
+		s.attestedHeadsMu.Lock()
+		if s.attestedHeads == nil {
+			s.attestedHeads = make(map[phase0.Slot]*attestedHead)
+		}
+		s.attestedHeads[slot] = &attestedHead{
+			root:       attestation.Data.BeaconBlockRoot,
+			attestedAt: time.Now(),
+		}
+		// Only the most recent slots are of interest.
+		for attestedSlot := range s.attestedHeads {
+			if attestedSlot+2 < slot {
+				delete(s.attestedHeads, attestedSlot)
+			}
+		}
+		s.attestedHeadsMu.Unlock()
+
+		return
+	}
+}
+
+// checkLateBlock checks if the block for a slot has arrived after attestations
+// for the slot voted for a different head, in which case the attestations are
+// likely to have voted for the wrong head.  Vouch does not attest again, as a
+// second attestation for the slot would be slashable, but records the miss.
+// It returns the time between attesting and the arrival of the block, and
+// true if the block was late.
+func (s *Service) checkLateBlock(slot phase0.Slot, root phase0.Root) (time.Duration, bool) {
+	s.attestedHeadsMu.Lock()
+	head, exists := s.attestedHeads[slot]
+	s.attestedHeadsMu.Unlock()
+	if !exists || head.root == root {
+		return 0, false
+	}
+	delta := time.Since(head.attestedAt)
+
+	s.monitor.LateBlock()
+	if s.logLateBlocks {
+		log.Info().
+			Uint64("slot", uint64(slot)).
+			Stringer("block", root).
+			Stringer("attested_head", head.root).
+			Dur("delta", delta).
+			Dur("block_delay", time.Since(s.chainTimeService.StartOfSlot(slot))).
+			Msg("Block for slot arrived after attesting to a different head")
+	}
+
+	return delta, true
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// lateBlockMonitor counts late blocks.
+type lateBlockMonitor struct {
+	*nullmetrics.Service
+	lateBlocks atomic.Int32
+}
+
+func (m *lateBlockMonitor) LateBlock() {
+	m.lateBlocks.Add(1)
+}
+
+func TestCheckLateBlock(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	monitor := &lateBlockMonitor{
+		Service: nullmetrics.New(ctx),
+	}
+	s := &Service{
+		chainTimeService: chainTime,
+		monitor:          monitor,
+		logLateBlocks:    true,
+	}
+
+	// No attestations for the slot.
+	_, late := s.checkLateBlock(10, phase0.Root{0x01})
+	require.False(t, late)
+
+	// Attestations without data are ignored.
+	s.recordAttestedHead(10, []*phase0.Attestation{nil, {}})
+	_, late = s.checkLateBlock(10, phase0.Root{0x01})
+	require.False(t, late)
+
+	// Attested to the block.
+	s.recordAttestedHead(10, []*phase0.Attestation{{Data: &phase0.AttestationData{BeaconBlockRoot: phase0.Root{0x01}}}})
+	_, late = s.checkLateBlock(10, phase0.Root{0x01})
+	require.False(t, late)
+
+	// Attested to the prior head.
+	s.recordAttestedHead(11, []*phase0.Attestation{{Data: &phase0.AttestationData{BeaconBlockRoot: phase0.Root{0x01}}}})
+	delta, late := s.checkLateBlock(11, phase0.Root{0x02})
+	require.True(t, late)
+	require.GreaterOrEqual(t, delta, time.Duration(0))
+	require.Equal(t, int32(1), monitor.lateBlocks.Load())
+
+	// Old slots are removed.
+	s.recordAttestedHead(14, []*phase0.Attestation{{Data: &phase0.AttestationData{BeaconBlockRoot: phase0.Root{0x03}}}})
+	require.NotContains(t, s.attestedHeads, phase0.Slot(11))
+	require.Contains(t, s.attestedHeads, phase0.Slot(14))
+}
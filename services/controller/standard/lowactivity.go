@@ -0,0 +1,89 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// An instance without active validators, for example a standby shard, runs in
+// low-activity mode: the epoch ticker schedules no duties, per-slot tickers run
+// once per epoch, and accounts are refreshed at the idle interval.  When
+// validators become active the controller ramps back up immediately, rather
+// than waiting for the following epoch.
+
+// leaveLowActivityMode checks for active validators if the controller is in
+// low-activity mode, and if found schedules their duties for the remainder of
+// the current epoch and the next epoch.
+func (s *Service) leaveLowActivityMode(ctx context.Context) {
+	if s.activeValidators.Load() != 0 {
+		return
+	}
+
+	epoch := s.chainTimeService.CurrentEpoch()
+	accounts, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch)
+	if err != nil {
+		log.Error().Err(err).Uint64("epoch", uint64(epoch)).Msg("Failed to obtain active validators for epoch")
+		return
+	}
+	if len(validatorIndices) == 0 {
+		return
+	}
+	if !s.activeValidators.CompareAndSwap(0, int64(len(validatorIndices))) {
+		// The epoch ticker has already ramped up.
+		return
+	}
+
+	log.Info().Int("validators", len(validatorIndices)).Msg("Active validators found; leaving low-activity mode")
+	s.scheduleRampUp(ctx, epoch, accounts, validatorIndices)
+	go s.scheduleProposals(ctx, epoch, validatorIndices, true /* notCurrentSlot */)
+	// Duties for the next epoch are already available, so prepare for it now.
+	go s.prepareForEpoch(ctx, &prepareForEpochData{
+		epoch: epoch + 1,
+	})
+}
+
+// scheduleRampUp schedules the duties for the given epoch that would have been
+// set up in the previous epoch had the validators been active then.
+func (s *Service) scheduleRampUp(ctx context.Context,
+	epoch phase0.Epoch,
+	accounts map[phase0.ValidatorIndex]e2wtypes.Account,
+	validatorIndices []phase0.ValidatorIndex,
+) {
+	go s.scheduleAttestations(ctx, epoch, validatorIndices, true /* notCurrentSlot */)
+	if s.handlingAltair {
+		thisSyncCommitteePeriodStartEpoch := s.firstEpochOfSyncPeriod(uint64(epoch) / s.epochsPerSyncCommitteePeriod)
+		go s.scheduleSyncCommitteeMessages(ctx, thisSyncCommitteePeriodStartEpoch, validatorIndices, true /* notCurrentSlot */)
+		nextSyncCommitteePeriodStartEpoch := s.firstEpochOfSyncPeriod(uint64(epoch)/s.epochsPerSyncCommitteePeriod + 1)
+		if uint64(nextSyncCommitteePeriodStartEpoch-epoch) <= syncCommitteePreparationEpochs {
+			go s.scheduleSyncCommitteeMessages(ctx, nextSyncCommitteePeriodStartEpoch, validatorIndices, true /* notCurrentSlot */)
+		}
+	}
+	go func() {
+		subscriptionInfo, err := s.beaconCommitteeSubscriber.Subscribe(ctx, epoch, accounts)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to subscribe to beacon committees")
+			return
+		}
+		s.subscriptionInfosMutex.Lock()
+		s.subscriptionInfos[epoch] = subscriptionInfo
+		s.subscriptionInfosMutex.Unlock()
+	}()
+	if s.handlingBellatrix {
+		go s.prepareProposals(ctx, nil)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mockattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/mock"
+	mockattester "github.com/attestantio/vouch/services/attester/mock"
+	mockbeaconblockproposer "github.com/attestantio/vouch/services/beaconblockproposer/mock"
+	mockbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/mock"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestLowActivityMode(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now().Add(-10 * 32 * 12 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithSpecProvider(mock.NewSpecProvider()),
+		WithChainTimeService(chainTime),
+		WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithValidatingAccountsProvider(validatingAccountsProvider),
+		WithProposalsPreparer(mockproposalpreparer.New()),
+		WithScheduler(mockscheduler.New()),
+		WithAttester(mockattester.New()),
+		WithBeaconBlockProposer(mockbeaconblockproposer.New()),
+		WithBeaconCommitteeSubscriber(mockbeaconcommitteesubscriber.New()),
+		WithAttestationAggregator(mockattestationaggregator.New()),
+		WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+		WithBlockToSlotSetter(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotSetter)),
+		WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+	)
+	require.NoError(t, err)
+
+	// No validators, so in low-activity mode with the default idle interval.
+	require.Equal(t, int64(0), s.activeValidators.Load())
+	require.Equal(t, 32*12*time.Second, s.idleAccountsRefreshInterval)
+
+	// A refresh that finds no validators remains in low-activity mode.
+	s.refreshAccounts(ctx, nil)
+	require.Equal(t, int64(0), s.activeValidators.Load())
+
+	// A refresh that finds validators leaves low-activity mode.
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := nd.CreateWallet(ctx, "test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test", []byte("pass"))
+	require.NoError(t, err)
+	validatingAccountsProvider.AddAccount(1, account)
+	s.refreshAccounts(ctx, nil)
+	require.Equal(t, int64(1), s.activeValidators.Load())
+}
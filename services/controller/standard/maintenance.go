@@ -0,0 +1,144 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// EnterMaintenance enters maintenance mode.  Duties for the current slot are
+// completed, but no duties are started for later slots.  If proposal-free slots
+// are configured then duties continue until they are followed by that many
+// slots without proposals.
+func (s *Service) EnterMaintenance(ctx context.Context) {
+	s.maintenanceMu.Lock()
+	if s.maintenance {
+		s.maintenanceMu.Unlock()
+		return
+	}
+	s.maintenance = true
+	s.maintenanceEndSlot = s.chainTimeService.CurrentSlot()
+	s.extendMaintenance(ctx)
+	endSlot := s.maintenanceEndSlot
+	s.maintenanceMu.Unlock()
+
+	log.Warn().Uint64("end_slot", uint64(endSlot)).Msg("Entering maintenance mode")
+
+	go s.awaitMaintenanceDrained(ctx)
+}
+
+// LeaveMaintenance leaves maintenance mode, resuming duties.
+func (s *Service) LeaveMaintenance(_ context.Context) {
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+
+	if s.maintenance {
+		s.maintenance = false
+		log.Info().Msg("Leaving maintenance mode")
+	}
+}
+
+// InMaintenance returns true if the controller is in maintenance mode.
+func (s *Service) InMaintenance(_ context.Context) bool {
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+
+	return s.maintenance
+}
+
+// MaintenanceDrained returns true if the controller is in maintenance mode and
+// all of its duties have completed, at which point it is safe to stop Vouch.
+func (s *Service) MaintenanceDrained(ctx context.Context) bool {
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+
+	if !s.maintenance {
+		return false
+	}
+	s.extendMaintenance(ctx)
+
+	return s.chainTimeService.CurrentSlot() > s.maintenanceEndSlot && s.runningDuties.Load() == 0
+}
+
+// awaitMaintenanceDrained logs when duties have drained in maintenance mode.
+func (s *Service) awaitMaintenanceDrained(ctx context.Context) {
+	for {
+		if !s.InMaintenance(ctx) {
+			return
+		}
+		if s.MaintenanceDrained(ctx) {
+			log.Info().Msg("Duties drained; safe to stop")
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// extendMaintenance extends the end of duties in maintenance mode until the
+// following proposal-free slots have no scheduled proposals.
+// This assumes that the maintenance lock is held.
+func (s *Service) extendMaintenance(ctx context.Context) {
+	if s.maintenanceProposalFreeSlots == 0 {
+		return
+	}
+
+	proposalSlots := make(map[phase0.Slot]struct{})
+	for _, name := range s.scheduler.ListJobs(ctx) {
+		if slot, isProposal := jobSlot(proposalJobFormat, name); isProposal {
+			proposalSlots[slot] = struct{}{}
+		}
+	}
+
+	for slot := s.maintenanceEndSlot + 1; slot <= s.maintenanceEndSlot+phase0.Slot(s.maintenanceProposalFreeSlots); slot++ {
+		if _, exists := proposalSlots[slot]; exists {
+			log.Debug().Uint64("proposal_slot", uint64(slot)).Msg("Proposal scheduled; extending maintenance duties")
+			s.maintenanceEndSlot = slot
+		}
+	}
+}
+
+// startDuty returns true if a duty for the given slot can start, in which
+// case endDuty must be called when the duty has completed.
+func (s *Service) startDuty(ctx context.Context, slot phase0.Slot) bool {
+	if s.coordinator != nil && !s.coordinator.IsLeader(ctx) {
+		log.Debug().Uint64("duty_slot", uint64(slot)).Msg("Not the leader; not starting duty")
+		return false
+	}
+
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+
+	if s.maintenance {
+		s.extendMaintenance(ctx)
+		if slot > s.maintenanceEndSlot {
+			log.Info().Uint64("duty_slot", uint64(slot)).Msg("In maintenance mode; not starting duty")
+			return false
+		}
+	}
+	s.runningDuties.Add(1)
+
+	return true
+}
+
+// endDuty notes that a duty started with startDuty has completed.
+func (s *Service) endDuty() {
+	s.runningDuties.Add(-1)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mockattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/mock"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	mockbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/mock"
+	"github.com/attestantio/vouch/services/scheduler"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// jobsScheduler is a scheduler that lists a fixed set of jobs.
+type jobsScheduler struct {
+	scheduler.Service
+	jobs []string
+}
+
+func (s *jobsScheduler) ListJobs(_ context.Context) []string {
+	return s.jobs
+}
+
+func TestMaintenance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	slot := chainTime.CurrentSlot()
+
+	tests := []struct {
+		name              string
+		proposalFreeSlots uint64
+		jobs              []string
+		endSlot           phase0.Slot
+	}{
+		{
+			name:    "Immediate",
+			endSlot: slot,
+		},
+		{
+			name:              "NoProposals",
+			proposalFreeSlots: 2,
+			jobs:              []string{fmt.Sprintf("Beacon block proposal for slot %d", slot+3)},
+			endSlot:           slot,
+		},
+		{
+			name:              "Proposals",
+			proposalFreeSlots: 2,
+			jobs: []string{
+				fmt.Sprintf("Beacon block proposal for slot %d", slot+2),
+				fmt.Sprintf("Beacon block proposal for slot %d", slot+4),
+				fmt.Sprintf("Beacon block proposal for slot %d", slot+7),
+			},
+			endSlot: slot + 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attesterSvc := &countingAttester{}
+			proposerSvc := &countingProposer{}
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithSpecProvider(mock.NewSpecProvider()),
+				WithChainTimeService(chainTime),
+				WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+				WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+				WithEventsProvider(mock.NewEventsProvider()),
+				WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				WithProposalsPreparer(mockproposalpreparer.New()),
+				WithScheduler(&jobsScheduler{Service: mockscheduler.New(), jobs: test.jobs}),
+				WithAttester(attesterSvc),
+				WithBeaconBlockProposer(proposerSvc),
+				WithBeaconCommitteeSubscriber(mockbeaconcommitteesubscriber.New()),
+				WithAttestationAggregator(mockattestationaggregator.New()),
+				WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+				WithBlockToSlotSetter(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotSetter)),
+				WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				WithMaintenanceProposalFreeSlots(test.proposalFreeSlots),
+			)
+			require.NoError(t, err)
+
+			require.False(t, s.InMaintenance(ctx))
+			require.False(t, s.MaintenanceDrained(ctx))
+
+			s.EnterMaintenance(ctx)
+			require.True(t, s.InMaintenance(ctx))
+			require.Equal(t, test.endSlot, s.maintenanceEndSlot)
+			// The current slot has not finished, so duties have not drained.
+			require.False(t, s.MaintenanceDrained(ctx))
+
+			// Duties up to the end slot are carried out; those after are not.
+			s.propose(ctx, beaconblockproposer.NewDuty(test.endSlot, 1))
+			s.propose(ctx, beaconblockproposer.NewDuty(test.endSlot+1, 1))
+			require.Equal(t, int32(1), proposerSvc.proposals.Load())
+			duty, err := attester.NewDuty(ctx, test.endSlot+1, 1, []phase0.ValidatorIndex{1}, []phase0.CommitteeIndex{0}, []uint64{0}, map[phase0.CommitteeIndex]uint64{0: 1})
+			require.NoError(t, err)
+			s.AttestAndScheduleAggregate(ctx, duty)
+			require.Equal(t, int32(0), attesterSvc.attestations.Load())
+			require.False(t, s.HasPendingAttestations(ctx, test.endSlot+1))
+
+			// Duties drain once the end slot has passed and no duties are running.
+			s.maintenanceMu.Lock()
+			s.maintenanceEndSlot = slot - 1
+			s.maintenanceMu.Unlock()
+			require.True(t, s.startDuty(ctx, slot-1))
+			require.False(t, s.MaintenanceDrained(ctx))
+			s.endDuty()
+			require.True(t, s.MaintenanceDrained(ctx))
+
+			s.LeaveMaintenance(ctx)
+			require.False(t, s.InMaintenance(ctx))
+			require.False(t, s.MaintenanceDrained(ctx))
+			s.propose(ctx, beaconblockproposer.NewDuty(test.endSlot+1, 1))
+			require.Equal(t, int32(2), proposerSvc.proposals.Load())
+		})
+	}
+}
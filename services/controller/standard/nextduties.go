@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// startNextDutiesTicker starts a ticker that updates the times of the next duties
+// at the start of each slot, or of each epoch if there are no active validators.
+func (s *Service) startNextDutiesTicker(ctx context.Context) error {
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		if s.activeValidators.Load() == 0 {
+			// No duties to track, so schedule for the beginning of the next epoch.
+			return s.chainTimeService.StartOfEpoch(s.chainTimeService.CurrentEpoch() + 1), nil
+		}
+		// Schedule for the beginning of the next slot.
+		return s.chainTimeService.StartOfSlot(s.chainTimeService.CurrentSlot() + 1), nil
+	}
+	if err := s.scheduler.SchedulePeriodicJob(ctx,
+		"Next duties",
+		"Next duties ticker",
+		runtimeFunc,
+		nil,
+		s.updateNextDuties,
+		nil,
+	); err != nil {
+		return errors.Wrap(err, "Failed to schedule next duties ticker")
+	}
+
+	return nil
+}
+
+// updateNextDuties updates the times of the next attestation, proposal and sync
+// committee duties from the jobs that are scheduled.
+func (s *Service) updateNextDuties(ctx context.Context, _ interface{}) {
+	nextDuties := s.nextDuties(ctx, time.Now())
+	for _, duty := range []string{"attestation", "proposal", "sync_committee"} {
+		s.monitor.NextDuty(duty, nextDuties[duty])
+	}
+}
+
+// nextDuties returns the times of the next scheduled duties after the given time,
+// keyed by duty.
+func (s *Service) nextDuties(ctx context.Context, now time.Time) map[string]time.Time {
+	// Each duty is identified by the formats of the names of its jobs, and
+	// takes place at the latest runtime of its main job.
+	dutyJobs := []struct {
+		duty    string
+		formats []string
+		delay   time.Duration
+	}{
+		{
+			duty:    "attestation",
+			formats: []string{attestationsJobFormat},
+			delay:   s.tunedMaxAttestationDelay(),
+		},
+		{
+			duty:    "proposal",
+			formats: []string{proposalJobFormat},
+			delay:   s.maxProposalDelay,
+		},
+		{
+			duty:    "sync_committee",
+			formats: []string{prepareSyncCommitteeMessagesJobFormat, syncCommitteeMessagesJobFormat},
+			delay:   s.tunedMaxSyncCommitteeMessageDelay(),
+		},
+	}
+
+	nextDuties := make(map[string]time.Time)
+	for _, name := range s.scheduler.ListJobs(ctx) {
+		for _, dutyJob := range dutyJobs {
+			for _, format := range dutyJob.formats {
+				slot, isDutyJob := jobSlot(format, name)
+				if !isDutyJob {
+					continue
+				}
+				dutyTime := s.chainTimeService.StartOfSlot(slot).Add(dutyJob.delay)
+				if !dutyTime.After(now) {
+					continue
+				}
+				if next, exists := nextDuties[dutyJob.duty]; !exists || dutyTime.Before(next) {
+					nextDuties[dutyJob.duty] = dutyTime
+				}
+			}
+		}
+	}
+
+	return nextDuties
+}
@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/scheduler/advanced"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDuties(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now().Add(-time.Hour)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	scheduler, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled))
+	require.NoError(t, err)
+
+	s := &Service{
+		chainTimeService:             chainTime,
+		scheduler:                    scheduler,
+		maxAttestationDelay:          4 * time.Second,
+		maxSyncCommitteeMessageDelay: 4 * time.Second,
+	}
+
+	noop := func(_ context.Context, _ interface{}) {}
+	currentSlot := chainTime.CurrentSlot()
+	jobs := []string{
+		// Past duty.
+		"Attestations for slot 1",
+		"Attestations for slot 10000000",
+		"Beacon block proposal for slot 10000005",
+		"Beacon block proposal for slot 10000003",
+		// Not a duty.
+		"Early beacon block proposal for slot 100",
+		"Prepare sync committee messages for slot 10000010",
+	}
+	for _, name := range jobs {
+		require.NoError(t, scheduler.ScheduleJob(ctx, "Test", name, time.Now().Add(time.Hour), noop, nil))
+	}
+
+	now := chainTime.StartOfSlot(currentSlot)
+	nextDuties := s.nextDuties(ctx, now)
+	require.Equal(t, chainTime.StartOfSlot(10000000).Add(4*time.Second), nextDuties["attestation"])
+	require.Equal(t, chainTime.StartOfSlot(10000003), nextDuties["proposal"])
+	require.Equal(t, chainTime.StartOfSlot(10000010).Add(4*time.Second), nextDuties["sync_committee"])
+
+	scheduler.CancelJobs(ctx, "Prepare sync committee messages")
+	nextDuties = s.nextDuties(ctx, now)
+	_, exists := nextDuties["sync_committee"]
+	require.False(t, exists)
+}
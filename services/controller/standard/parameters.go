@@ -15,10 +15,13 @@ package standard
 
 import (
 	"context"
+	"crypto/ed25519"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/attestationaggregator"
 	"github.com/attestantio/vouch/services/attester"
@@ -26,45 +29,71 @@ import (
 	"github.com/attestantio/vouch/services/beaconcommitteesubscriber"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/coordination"
+	"github.com/attestantio/vouch/services/dutyevents"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/proposalpreparer"
+	"github.com/attestantio/vouch/services/proposalreadiness"
 	"github.com/attestantio/vouch/services/scheduler"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
 	"github.com/attestantio/vouch/services/synccommitteesubscriber"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
 type parameters struct {
-	logLevel                      zerolog.Level
-	monitor                       metrics.ControllerMonitor
-	specProvider                  eth2client.SpecProvider
-	chainTimeService              chaintime.Service
-	waitedForGenesis              bool
-	proposerDutiesProvider        eth2client.ProposerDutiesProvider
-	attesterDutiesProvider        eth2client.AttesterDutiesProvider
-	syncCommitteeDutiesProvider   eth2client.SyncCommitteeDutiesProvider
-	syncCommitteesSubscriber      synccommitteesubscriber.Service
-	validatingAccountsProvider    accountmanager.ValidatingAccountsProvider
-	proposalsPreparer             proposalpreparer.Service
-	scheduler                     scheduler.Service
-	eventsProvider                eth2client.EventsProvider
-	attester                      attester.Service
-	syncCommitteeMessenger        synccommitteemessenger.Service
-	syncCommitteeAggregator       synccommitteeaggregator.Service
-	beaconBlockProposer           beaconblockproposer.Service
-	beaconBlockHeadersProvider    eth2client.BeaconBlockHeadersProvider
-	signedBeaconBlockProvider     eth2client.SignedBeaconBlockProvider
-	attestationAggregator         attestationaggregator.Service
-	beaconCommitteeSubscriber     beaconcommitteesubscriber.Service
-	accountsRefresher             accountmanager.Refresher
-	blockToSlotSetter             cache.BlockRootToSlotSetter
-	maxProposalDelay              time.Duration
-	maxAttestationDelay           time.Duration
-	attestationAggregationDelay   time.Duration
-	maxSyncCommitteeMessageDelay  time.Duration
-	syncCommitteeAggregationDelay time.Duration
+	logLevel                       zerolog.Level
+	monitor                        metrics.ControllerMonitor
+	specProvider                   eth2client.SpecProvider
+	chainTimeService               chaintime.Service
+	waitedForGenesis               bool
+	proposerDutiesProvider         eth2client.ProposerDutiesProvider
+	attesterDutiesProvider         eth2client.AttesterDutiesProvider
+	syncCommitteeDutiesProvider    eth2client.SyncCommitteeDutiesProvider
+	syncCommitteesSubscriber       synccommitteesubscriber.Service
+	validatingAccountsProvider     accountmanager.ValidatingAccountsProvider
+	proposalsPreparer              proposalpreparer.Service
+	scheduler                      scheduler.Service
+	eventsProvider                 eth2client.EventsProvider
+	attester                       attester.Service
+	syncCommitteeMessenger         synccommitteemessenger.Service
+	syncCommitteeAggregator        synccommitteeaggregator.Service
+	beaconBlockProposer            beaconblockproposer.Service
+	beaconBlockHeadersProvider     eth2client.BeaconBlockHeadersProvider
+	signedBeaconBlockProvider      eth2client.SignedBeaconBlockProvider
+	attestationAggregator          attestationaggregator.Service
+	beaconCommitteeSubscriber      beaconcommitteesubscriber.Service
+	accountsRefresher              accountmanager.Refresher
+	blockToSlotSetter              cache.BlockRootToSlotSetter
+	maxProposalDelay               time.Duration
+	maxAttestationDelay            time.Duration
+	attestationTrigger             string
+	minAttestationDelay            time.Duration
+	attestationAggregationDelay    time.Duration
+	minAttestationAggregationDelay time.Duration
+	startupReconciliationEpochs    uint64
+	maxSyncCommitteeMessageDelay   time.Duration
+	syncCommitteeMessageGrace      time.Duration
+	syncCommitteeAggregationDelay  time.Duration
+	dutyDeadline                   time.Duration
+	dutyBudget                     *util.DutyBudget
+	attestationHeadWait            time.Duration
+	logLateBlocks                  bool
+	delayTuning                    *DelayTuning
+	excludedProposers              []phase0.BLSPubKey
+	proposalNotificationURL        string
+	dutyStatementKey               ed25519.PrivateKey
+	dutyStatementDir               string
+	proposalReadinessChecker       proposalreadiness.Service
+	proposalReadinessSlots         uint64
+	accountsRefreshSlices          uint64
+	idleAccountsRefreshInterval    time.Duration
+	canaryValidators               []phase0.BLSPubKey
+	dutyEvents                     dutyevents.Service
+	maintenanceProposalFreeSlots   uint64
+	coordinator                    coordination.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -260,6 +289,23 @@ func WithAttestationAggregationDelay(delay time.Duration) Parameter {
 	})
 }
 
+// WithMinAttestationAggregationDelay sets the earliest delay before aggregating attestations.
+// If set, aggregation starts at this delay and completes as soon as a sufficiently complete
+// aggregate is available, up to the attestation aggregation delay.
+func WithMinAttestationAggregationDelay(delay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.minAttestationAggregationDelay = delay
+	})
+}
+
+// WithStartupReconciliationEpochs sets the number of epochs prior to startup
+// for which missed duties are reported.  0 disables the check.
+func WithStartupReconciliationEpochs(epochs uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.startupReconciliationEpochs = epochs
+	})
+}
+
 // WithMaxSyncCommitteeMessageDelay sets the maximum delay before generating sync committee messages.
 func WithMaxSyncCommitteeMessageDelay(delay time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -267,6 +313,14 @@ func WithMaxSyncCommitteeMessageDelay(delay time.Duration) Parameter {
 	})
 }
 
+// WithSyncCommitteeMessageGracePeriod sets the time to wait after receiving the
+// block for a slot before generating sync committee messages.
+func WithSyncCommitteeMessageGracePeriod(grace time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncCommitteeMessageGrace = grace
+	})
+}
+
 // WithSyncCommitteeAggregationDelay sets the delay before aggregating sync committee messages.
 func WithSyncCommitteeAggregationDelay(delay time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -274,10 +328,159 @@ func WithSyncCommitteeAggregationDelay(delay time.Duration) Parameter {
 	})
 }
 
+// WithDutyDeadline sets the time after the start of a slot by which duties for the slot must complete.
+func WithDutyDeadline(deadline time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyDeadline = deadline
+	})
+}
+
+// WithDutyBudget sets the relative share of the time before the duty deadline given to each phase of a duty.
+func WithDutyBudget(budget *util.DutyBudget) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyBudget = budget
+	})
+}
+
+// WithAttestationTrigger sets the event that triggers attestations.  "block"
+// attests as soon as the block for the slot arrives, or at the maximum
+// attestation delay if it has not arrived by then; "delay" always attests at
+// the maximum attestation delay.
+func WithAttestationTrigger(trigger string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationTrigger = trigger
+	})
+}
+
+// WithMinAttestationDelay sets the minimum delay from the start of a slot
+// before attesting when triggered by the arrival of the block for the slot.
+func WithMinAttestationDelay(delay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.minAttestationDelay = delay
+	})
+}
+
+// WithDelayTuning sets the configuration for automatic tuning of duty delays.
+// This is optional; if not supplied duty delays are as configured.
+func WithDelayTuning(tuning *DelayTuning) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.delayTuning = tuning
+	})
+}
+
+// WithLogLateBlocks logs blocks that arrive after attestations for their
+// slot have voted for a different head.
+func WithLogLateBlocks(logLateBlocks bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLateBlocks = logLateBlocks
+	})
+}
+
+// WithAttestationHeadWait sets the maximum time to wait for the beacon node to process
+// the parent slot before attesting.
+func WithAttestationHeadWait(wait time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationHeadWait = wait
+	})
+}
+
+// WithExcludedProposers sets the validators for which proposals will not be made.
+func WithExcludedProposers(proposers []phase0.BLSPubKey) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.excludedProposers = proposers
+	})
+}
+
+// WithProposalNotificationURL sets a URL to which upcoming proposals are posted when proposer duties are obtained.
+func WithProposalNotificationURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalNotificationURL = url
+	})
+}
+
+// WithDutyStatementKey sets the key used to sign per-epoch duty statements.
+func WithDutyStatementKey(key ed25519.PrivateKey) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyStatementKey = key
+	})
+}
+
+// WithDutyStatementDir sets the directory to which duty statements are written.
+func WithDutyStatementDir(dir string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyStatementDir = dir
+	})
+}
+
+// WithProposalReadinessChecker sets the checker for the readiness of upcoming proposals.
+func WithProposalReadinessChecker(checker proposalreadiness.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalReadinessChecker = checker
+	})
+}
+
+// WithProposalReadinessSlots sets the number of slots ahead of a proposal at which its readiness is checked.
+func WithProposalReadinessSlots(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalReadinessSlots = slots
+	})
+}
+
+// WithAccountsRefreshSlices sets the number of slices into which account refreshes
+// are split, spread across the epoch.
+func WithAccountsRefreshSlices(slices uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.accountsRefreshSlices = slices
+	})
+}
+
+// WithIdleAccountsRefreshInterval sets the interval between account refreshes
+// when there are no active validators.
+func WithIdleAccountsRefreshInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.idleAccountsRefreshInterval = interval
+	})
+}
+
+// WithCanaryValidators sets the validators whose duties are tracked as canaries
+// of the health of the validating pipeline.
+func WithCanaryValidators(validators []phase0.BLSPubKey) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.canaryValidators = validators
+	})
+}
+
+// WithMaintenanceProposalFreeSlots sets the number of slots without proposals
+// that must follow the end of duties in maintenance mode.
+func WithMaintenanceProposalFreeSlots(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maintenanceProposalFreeSlots = slots
+	})
+}
+
+// WithCoordinator sets the coordination service, which decides if this
+// instance should carry out duties.
+func WithCoordinator(coordinator coordination.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.coordinator = coordinator
+	})
+}
+
+// WithDutyEvents sets the service to which duty lifecycle events are published.
+// This is optional; if not supplied duty events are not published.
+func WithDutyEvents(service dutyevents.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyEvents = service
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:                  zerolog.GlobalLevel(),
+		accountsRefreshSlices:     1,
+		attestationTrigger:        "block",
+		syncCommitteeMessageGrace: 200 * time.Millisecond,
 	}
 	for _, p := range params {
 		p.apply(&parameters)
@@ -347,20 +550,98 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if !ok {
 		return nil, errors.New("SECONDS_PER_SLOT of unexpected type")
 	}
+	if parameters.dutyDeadline == 0 {
+		parameters.dutyDeadline = slotDuration
+	}
+	if parameters.dutyBudget != nil && (parameters.dutyBudget.Wait < 0 || parameters.dutyBudget.Fetch < 0 || parameters.dutyBudget.Sign < 0 || parameters.dutyBudget.Submit < 0) {
+		return nil, errors.New("duty budget shares cannot be negative")
+	}
+	// Delays that are not set explicitly come from the wait share of the duty budget.
+	waitDelay := parameters.dutyBudget.WaitDuration(parameters.dutyDeadline)
+	if waitDelay == 0 {
+		waitDelay = slotDuration / 3
+	}
 	// maxProposalDelay can be 0, so no check for it here.
 	if parameters.maxAttestationDelay == 0 {
-		parameters.maxAttestationDelay = slotDuration / 3
+		parameters.maxAttestationDelay = waitDelay
+	}
+	switch parameters.attestationTrigger {
+	case "block", "delay":
+	default:
+		return nil, fmt.Errorf("unknown attestation trigger %q", parameters.attestationTrigger)
+	}
+	if parameters.minAttestationDelay < 0 {
+		return nil, errors.New("minimum attestation delay cannot be negative")
+	}
+	if parameters.minAttestationDelay > parameters.maxAttestationDelay {
+		return nil, errors.New("minimum attestation delay cannot be greater than maximum attestation delay")
 	}
 	if parameters.attestationAggregationDelay == 0 {
 		parameters.attestationAggregationDelay = slotDuration * 2 / 3
 	}
+	if parameters.minAttestationAggregationDelay < 0 {
+		return nil, errors.New("minimum attestation aggregation delay cannot be negative")
+	}
+	if parameters.minAttestationAggregationDelay > parameters.attestationAggregationDelay {
+		return nil, errors.New("minimum attestation aggregation delay cannot be greater than attestation aggregation delay")
+	}
 	if parameters.maxSyncCommitteeMessageDelay == 0 {
-		parameters.maxSyncCommitteeMessageDelay = slotDuration / 3
+		parameters.maxSyncCommitteeMessageDelay = waitDelay
+	}
+	if parameters.syncCommitteeMessageGrace < 0 {
+		return nil, errors.New("sync committee message grace period cannot be negative")
 	}
 	if parameters.syncCommitteeAggregationDelay == 0 {
 		parameters.syncCommitteeAggregationDelay = slotDuration * 2 / 3
 	}
+	if parameters.attestationHeadWait < 0 {
+		return nil, errors.New("attestation head wait cannot be negative")
+	}
+	if parameters.delayTuning != nil {
+		if parameters.delayTuning.MaxDelay == 0 {
+			parameters.delayTuning.MaxDelay = parameters.maxAttestationDelay
+		}
+		if parameters.delayTuning.Window <= 0 {
+			return nil, errors.New("delay tuning window must be positive")
+		}
+		if parameters.delayTuning.MinDelay <= 0 {
+			return nil, errors.New("delay tuning minimum delay must be positive")
+		}
+		if parameters.delayTuning.MinDelay > parameters.delayTuning.MaxDelay {
+			return nil, errors.New("delay tuning minimum delay cannot be greater than maximum delay")
+		}
+		if parameters.delayTuning.MinDelay < parameters.minAttestationDelay {
+			return nil, errors.New("delay tuning minimum delay cannot be less than minimum attestation delay")
+		}
+		maxOffset := parameters.delayTuning.MaxDelay - parameters.maxAttestationDelay
+		if parameters.attestationAggregationDelay+maxOffset >= slotDuration ||
+			parameters.syncCommitteeAggregationDelay+maxOffset >= slotDuration {
+			return nil, errors.New("delay tuning maximum delay would move aggregation beyond the end of the slot")
+		}
+	}
 	// Sync committee duties provider/messenger/aggregator/subscriber are optional so no checks here.
+	if parameters.dutyStatementKey != nil && parameters.dutyStatementDir == "" {
+		return nil, errors.New("no duty statement directory specified")
+	}
+	if parameters.proposalReadinessChecker != nil && parameters.proposalReadinessSlots == 0 {
+		return nil, errors.New("proposal readiness slots must be positive")
+	}
+	if parameters.accountsRefreshSlices == 0 {
+		return nil, errors.New("accounts refresh slices must be positive")
+	}
+	if slotsPerEpoch, isSlotsPerEpoch := spec["SLOTS_PER_EPOCH"].(uint64); isSlotsPerEpoch && parameters.accountsRefreshSlices > slotsPerEpoch {
+		return nil, errors.New("accounts refresh slices cannot be more than slots per epoch")
+	}
+	if parameters.idleAccountsRefreshInterval < 0 {
+		return nil, errors.New("idle accounts refresh interval cannot be negative")
+	}
+	if parameters.idleAccountsRefreshInterval == 0 {
+		// Default to once per epoch.
+		parameters.idleAccountsRefreshInterval = slotDuration
+		if slotsPerEpoch, isSlotsPerEpoch := spec["SLOTS_PER_EPOCH"].(uint64); isSlotsPerEpoch {
+			parameters.idleAccountsRefreshInterval = slotDuration * time.Duration(slotsPerEpoch)
+		}
+	}
 
 	return &parameters, nil
 }
@@ -0,0 +1,58 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+)
+
+// PauseAttestations pauses attestations until they are resumed.  Attestations
+// that have already started are not affected.
+func (s *Service) PauseAttestations(_ context.Context) {
+	if !s.attestationsPaused.Swap(true) {
+		log.Warn().Msg("Pausing attestations")
+	}
+}
+
+// ResumeAttestations resumes paused attestations.
+func (s *Service) ResumeAttestations(_ context.Context) {
+	if s.attestationsPaused.Swap(false) {
+		log.Info().Msg("Resuming attestations")
+	}
+}
+
+// AttestationsPaused returns true if attestations are paused.
+func (s *Service) AttestationsPaused(_ context.Context) bool {
+	return s.attestationsPaused.Load()
+}
+
+// PauseProposals pauses beacon block proposals until they are resumed.
+// Proposals that have already started are not affected.
+func (s *Service) PauseProposals(_ context.Context) {
+	if !s.proposalsPaused.Swap(true) {
+		log.Warn().Msg("Pausing beacon block proposals")
+	}
+}
+
+// ResumeProposals resumes paused beacon block proposals.
+func (s *Service) ResumeProposals(_ context.Context) {
+	if s.proposalsPaused.Swap(false) {
+		log.Info().Msg("Resuming beacon block proposals")
+	}
+}
+
+// ProposalsPaused returns true if beacon block proposals are paused.
+func (s *Service) ProposalsPaused(_ context.Context) bool {
+	return s.proposalsPaused.Load()
+}
@@ -0,0 +1,126 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mockattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/mock"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	mockbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/mock"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// countingAttester counts the attestations it is asked to carry out.
+type countingAttester struct {
+	attestations atomic.Int32
+}
+
+func (a *countingAttester) Attest(_ context.Context, _ interface{}) ([]*phase0.Attestation, error) {
+	a.attestations.Add(1)
+
+	return nil, nil
+}
+
+// countingProposer counts the proposals it is asked to carry out.
+type countingProposer struct {
+	proposals atomic.Int32
+}
+
+func (*countingProposer) Prepare(_ context.Context, _ interface{}) error {
+	return nil
+}
+
+func (p *countingProposer) Propose(_ context.Context, _ interface{}) {
+	p.proposals.Add(1)
+}
+
+func TestPause(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	attesterSvc := &countingAttester{}
+	proposerSvc := &countingProposer{}
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithSpecProvider(mock.NewSpecProvider()),
+		WithChainTimeService(chainTime),
+		WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+		WithProposalsPreparer(mockproposalpreparer.New()),
+		WithScheduler(mockscheduler.New()),
+		WithAttester(attesterSvc),
+		WithBeaconBlockProposer(proposerSvc),
+		WithBeaconCommitteeSubscriber(mockbeaconcommitteesubscriber.New()),
+		WithAttestationAggregator(mockattestationaggregator.New()),
+		WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+		WithBlockToSlotSetter(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotSetter)),
+		WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+	)
+	require.NoError(t, err)
+
+	slot := chainTime.CurrentSlot()
+	attesterDuty, err := attester.NewDuty(ctx, slot, 1, []phase0.ValidatorIndex{1}, []phase0.CommitteeIndex{0}, []uint64{0}, map[phase0.CommitteeIndex]uint64{0: 1})
+	require.NoError(t, err)
+	proposerDuty := beaconblockproposer.NewDuty(slot, 1)
+
+	require.False(t, s.AttestationsPaused(ctx))
+	require.False(t, s.ProposalsPaused(ctx))
+
+	s.PauseAttestations(ctx)
+	require.True(t, s.AttestationsPaused(ctx))
+	require.False(t, s.ProposalsPaused(ctx))
+	s.AttestAndScheduleAggregate(ctx, attesterDuty)
+	s.propose(ctx, proposerDuty)
+	require.Equal(t, int32(0), attesterSvc.attestations.Load())
+	require.Equal(t, int32(1), proposerSvc.proposals.Load())
+
+	s.PauseProposals(ctx)
+	require.True(t, s.ProposalsPaused(ctx))
+	s.propose(ctx, proposerDuty)
+	require.Equal(t, int32(1), proposerSvc.proposals.Load())
+
+	s.ResumeAttestations(ctx)
+	s.ResumeProposals(ctx)
+	require.False(t, s.AttestationsPaused(ctx))
+	require.False(t, s.ProposalsPaused(ctx))
+	s.AttestAndScheduleAggregate(ctx, attesterDuty)
+	s.propose(ctx, proposerDuty)
+	require.Equal(t, int32(1), attesterSvc.attestations.Load())
+	require.Equal(t, int32(2), proposerSvc.proposals.Load())
+}
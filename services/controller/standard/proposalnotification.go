@@ -0,0 +1,118 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+)
+
+// proposalNotificationTimeout is the maximum time allowed to post a proposal notification.
+const proposalNotificationTimeout = 10 * time.Second
+
+// upcomingProposals is the notification of upcoming proposals for an epoch.
+type upcomingProposals struct {
+	Epoch     phase0.Epoch        `json:"epoch,string"`
+	Proposals []*upcomingProposal `json:"proposals"`
+}
+
+// upcomingProposal is a single upcoming proposal.
+type upcomingProposal struct {
+	Slot           phase0.Slot           `json:"slot,string"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index,string"`
+	StartTime      time.Time             `json:"start_time"`
+}
+
+// notifyUpcomingProposals notifies operators of the proposals that our validators will make
+// in the given epoch, so that they can ensure infrastructure is healthy ahead of them.
+func (s *Service) notifyUpcomingProposals(ctx context.Context,
+	epoch phase0.Epoch,
+	duties []*beaconblockproposer.Duty,
+	currentSlot phase0.Slot,
+) {
+	notification := &upcomingProposals{
+		Epoch:     epoch,
+		Proposals: make([]*upcomingProposal, 0, len(duties)),
+	}
+	for _, duty := range duties {
+		if duty.Slot() < currentSlot {
+			continue
+		}
+		notification.Proposals = append(notification.Proposals, &upcomingProposal{
+			Slot:           duty.Slot(),
+			ValidatorIndex: duty.ValidatorIndex(),
+			StartTime:      s.chainTimeService.StartOfSlot(duty.Slot()),
+		})
+	}
+
+	s.monitor.UpcomingProposals(len(notification.Proposals))
+	if len(notification.Proposals) == 0 {
+		return
+	}
+
+	slots := make([]uint64, len(notification.Proposals))
+	validatorIndices := make([]uint64, len(notification.Proposals))
+	for i, proposal := range notification.Proposals {
+		slots[i] = uint64(proposal.Slot)
+		validatorIndices[i] = uint64(proposal.ValidatorIndex)
+	}
+	log.Info().
+		Uint64("epoch", uint64(epoch)).
+		Uints64("slots", slots).
+		Uints64("validator_indices", validatorIndices).
+		Msg("Upcoming proposals")
+
+	if s.proposalNotificationURL != "" {
+		go func() {
+			if err := s.postProposalNotification(ctx, notification); err != nil {
+				log.Warn().Err(err).Uint64("epoch", uint64(epoch)).Msg("Failed to post upcoming proposals notification")
+			}
+		}()
+	}
+}
+
+// postProposalNotification posts a notification of upcoming proposals to the configured URL.
+func (s *Service) postProposalNotification(ctx context.Context, notification *upcomingProposals) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, proposalNotificationTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.proposalNotificationURL, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.proposalNotificationClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
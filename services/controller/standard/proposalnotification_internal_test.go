@@ -0,0 +1,205 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// proposalsMonitor records the numbers of upcoming proposals.
+type proposalsMonitor struct {
+	*nullmetrics.Service
+	mu        sync.Mutex
+	proposals []int
+}
+
+func (m *proposalsMonitor) UpcomingProposals(proposals int) {
+	m.mu.Lock()
+	m.proposals = append(m.proposals, proposals)
+	m.mu.Unlock()
+}
+
+// notificationEndpoint records the notifications it receives.
+type notificationEndpoint struct {
+	mu            sync.Mutex
+	status        int
+	notifications []*upcomingProposals
+}
+
+func (e *notificationEndpoint) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	notification := &upcomingProposals{}
+	if req.Header.Get("Content-Type") != "application/json" || json.NewDecoder(req.Body).Decode(notification) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.notifications = append(e.notifications, notification)
+	w.WriteHeader(e.status)
+}
+
+func (e *notificationEndpoint) received() []*upcomingProposals {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]*upcomingProposals{}, e.notifications...)
+}
+
+func TestNotifyUpcomingProposals(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	epoch := chainTime.CurrentEpoch()
+	firstSlot := chainTime.FirstSlotOfEpoch(epoch)
+	duties := []*beaconblockproposer.Duty{
+		beaconblockproposer.NewDuty(firstSlot, 1),
+		beaconblockproposer.NewDuty(firstSlot+2, 2),
+		beaconblockproposer.NewDuty(firstSlot+5, 3),
+	}
+
+	tests := []struct {
+		name      string
+		duties    []*beaconblockproposer.Duty
+		url       bool
+		proposals []phase0.ValidatorIndex
+	}{
+		{
+			name:      "NoDuties",
+			url:       true,
+			proposals: []phase0.ValidatorIndex{},
+		},
+		{
+			name:      "NoURL",
+			duties:    duties,
+			proposals: []phase0.ValidatorIndex{2, 3},
+		},
+		{
+			name:      "Notified",
+			duties:    duties,
+			url:       true,
+			proposals: []phase0.ValidatorIndex{2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			endpoint := &notificationEndpoint{status: http.StatusOK}
+			server := httptest.NewServer(endpoint)
+			defer server.Close()
+
+			monitor := &proposalsMonitor{Service: nullmetrics.New(ctx)}
+			s := &Service{
+				monitor:                    monitor,
+				chainTimeService:           chainTime,
+				proposalNotificationClient: server.Client(),
+			}
+			if test.url {
+				s.proposalNotificationURL = server.URL
+			}
+
+			// Proposals before the current slot are not notified.
+			s.notifyUpcomingProposals(ctx, epoch, test.duties, firstSlot+1)
+			require.Equal(t, []int{len(test.proposals)}, monitor.proposals)
+
+			if !test.url || len(test.proposals) == 0 {
+				time.Sleep(50 * time.Millisecond)
+				require.Empty(t, endpoint.received())
+				return
+			}
+			require.Eventually(t, func() bool {
+				return len(endpoint.received()) == 1
+			}, time.Second, 10*time.Millisecond)
+			notification := endpoint.received()[0]
+			require.Equal(t, epoch, notification.Epoch)
+			require.Len(t, notification.Proposals, len(test.proposals))
+			for i, proposal := range notification.Proposals {
+				require.Equal(t, test.proposals[i], proposal.ValidatorIndex)
+				require.True(t, chainTime.StartOfSlot(proposal.Slot).Equal(proposal.StartTime))
+			}
+		})
+	}
+}
+
+func TestPostProposalNotification(t *testing.T) {
+	ctx := context.Background()
+
+	notification := &upcomingProposals{
+		Epoch: 10,
+		Proposals: []*upcomingProposal{
+			{Slot: 320, ValidatorIndex: 1, StartTime: time.Unix(1700000000, 0)},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		status int
+		err    string
+	}{
+		{
+			name:   "Good",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Accepted",
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "ServerError",
+			status: http.StatusInternalServerError,
+			err:    "notification endpoint returned status 500",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			endpoint := &notificationEndpoint{status: test.status}
+			server := httptest.NewServer(endpoint)
+			defer server.Close()
+
+			s := &Service{
+				proposalNotificationURL:    server.URL,
+				proposalNotificationClient: server.Client(),
+			}
+			err := s.postProposalNotification(ctx, notification)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, endpoint.received(), 1)
+			require.Equal(t, notification.Proposals[0].Slot, endpoint.received()[0].Proposals[0].Slot)
+		})
+	}
+}
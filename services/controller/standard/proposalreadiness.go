@@ -0,0 +1,78 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
+)
+
+// scheduleProposalReadinessCheck schedules a check of the readiness of the
+// infrastructure for a proposal, a number of slots ahead of the proposal.
+func (s *Service) scheduleProposalReadinessCheck(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+) {
+	if s.proposalReadinessChecker == nil {
+		return
+	}
+
+	startOfSlot := s.chainTimeService.StartOfSlot(duty.Slot())
+	if !startOfSlot.After(time.Now()) {
+		// Too late to be useful.
+		return
+	}
+	runtime := startOfSlot.Add(-s.slotDuration * time.Duration(s.proposalReadinessSlots))
+	if runtime.Before(time.Now()) {
+		// Already within the check window, so check immediately.
+		runtime = time.Now()
+	}
+
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Propose",
+		fmt.Sprintf(proposalReadinessJobFormat, duty.Slot()),
+		runtime,
+		s.checkProposalReadiness,
+		duty,
+	); err != nil {
+		if errors.Is(err, scheduler.ErrJobRunning) {
+			log.Debug().Uint64("proposal_slot", uint64(duty.Slot())).Msg("Proposal readiness check already running; not rescheduling")
+			return
+		}
+		log.Error().Uint64("proposal_slot", uint64(duty.Slot())).Err(err).Msg("Failed to schedule proposal readiness check")
+	}
+}
+
+// checkProposalReadiness checks the readiness of the infrastructure for a proposal.
+func (s *Service) checkProposalReadiness(ctx context.Context, data interface{}) {
+	duty, ok := data.(*beaconblockproposer.Duty)
+	if !ok {
+		log.Error().Msg("Invalid duty data for proposal readiness check")
+		return
+	}
+
+	if err := s.proposalReadinessChecker.CheckReadiness(ctx, duty); err != nil {
+		log.Error().
+			Uint64("proposal_slot", uint64(duty.Slot())).
+			Uint64("validator_index", uint64(duty.ValidatorIndex())).
+			Err(err).
+			Msg("Proposal is not ready")
+		return
+	}
+	log.Trace().Uint64("proposal_slot", uint64(duty.Slot())).Msg("Proposal is ready")
+}
@@ -21,6 +21,9 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -62,12 +65,26 @@ func (s *Service) scheduleProposals(ctx context.Context,
 				Msg("Proposer duty has invalid slot for requested epoch; ignoring")
 			continue
 		}
+		if _, excluded := s.excludedProposers[respDuty.PubKey]; excluded {
+			log.Info().
+				Uint64("duty_slot", uint64(respDuty.Slot)).
+				Uint64("validator_index", uint64(respDuty.ValidatorIndex)).
+				Msg("Validator is excluded from proposing; ignoring proposer duty")
+			continue
+		}
 		duties = append(duties, beaconblockproposer.NewDuty(respDuty.Slot, respDuty.ValidatorIndex))
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(duties)).Msg("Filtered proposer duties")
 
 	currentSlot := s.chainTimeService.CurrentSlot()
+	s.notifyUpcomingProposals(ctx, epoch, duties, currentSlot)
 	for _, duty := range duties {
+		if s.proposalsStoppedAt(duty.Slot()) {
+			log.Warn().
+				Uint64("proposal_slot", uint64(duty.Slot())).
+				Msg("Beacon block proposals are stopped; not scheduling")
+			continue
+		}
 		// Do not schedule proposals for past slots (or the current slot if so instructed).
 		if duty.Slot() < currentSlot {
 			log.Debug().
@@ -83,6 +100,7 @@ func (s *Service) scheduleProposals(ctx context.Context,
 				Msg("Beacon block proposal for the current slot; not scheduling")
 			continue
 		}
+		s.scheduleProposalReadinessCheck(ctx, duty)
 		go func(duty *beaconblockproposer.Duty) {
 			if err := s.beaconBlockProposer.Prepare(ctx, duty); err != nil {
 				log.Error().Uint64("proposal_slot", uint64(duty.Slot())).Err(err).Msg("Failed to prepare beacon block proposal")
@@ -92,7 +110,7 @@ func (s *Service) scheduleProposals(ctx context.Context,
 			if s.maxProposalDelay > 0 {
 				if err := s.scheduler.ScheduleJob(ctx,
 					"Propose check",
-					fmt.Sprintf("Early beacon block proposal for slot %d", duty.Slot()),
+					fmt.Sprintf(earlyProposalJobFormat, duty.Slot()),
 					s.chainTimeService.StartOfSlot(duty.Slot()),
 					s.proposeEarly,
 					duty,
@@ -103,13 +121,20 @@ func (s *Service) scheduleProposals(ctx context.Context,
 			}
 			if err := s.scheduler.ScheduleJob(ctx,
 				"Propose",
-				fmt.Sprintf("Beacon block proposal for slot %d", duty.Slot()),
+				fmt.Sprintf(proposalJobFormat, duty.Slot()),
 				s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.maxProposalDelay),
-				s.beaconBlockProposer.Propose,
+				s.propose,
 				duty,
 			); err != nil {
+				if errors.Is(err, scheduler.ErrJobRunning) {
+					// Duties have been refreshed whilst the proposal is in progress; do not propose again.
+					log.Debug().Uint64("proposal_slot", uint64(duty.Slot())).Msg("Beacon block proposal already running; not rescheduling")
+					return
+				}
 				// Don't return here; we want to try to set up as many proposer jobs as possible.
 				log.Error().Err(err).Msg("Failed to schedule beacon block proposal")
+			} else {
+				s.publishDutyEvent(ctx, dutyevents.DutyProposal, dutyevents.StageScheduled, duty.Slot(), []phase0.ValidatorIndex{duty.ValidatorIndex()}, nil)
 			}
 		}(duty)
 	}
@@ -142,8 +167,45 @@ func (s *Service) proposeEarly(ctx context.Context, data interface{}) {
 	// If the current head is up to the prior slot then we can propose immediately.
 	if header.Header.Message.Slot == duty.Slot()-1 {
 		log.Trace().Uint64("slot", uint64(duty.Slot())).Uint64("header_slot", uint64(header.Header.Message.Slot)).Uint64("validator_index", uint64(duty.ValidatorIndex())).Str("header", header.String()).Msg("Head of chain is up to date; proposing immediately")
-		s.scheduler.RunJobIfExists(ctx, fmt.Sprintf("Beacon block proposal for slot %d", duty.Slot()))
+		s.scheduler.RunJobIfExists(ctx, fmt.Sprintf(proposalJobFormat, duty.Slot()))
 	} else {
 		log.Trace().Uint64("slot", uint64(duty.Slot())).Uint64("header_slot", uint64(header.Header.Message.Slot)).Uint64("validator_index", uint64(duty.ValidatorIndex())).Str("header", header.String()).Msg("Head of chain is not up to date; not proposing immediately")
 	}
 }
+
+// StopProposals stops the controller from proposing blocks from the given epoch
+// onwards, including any proposals that are already scheduled.
+func (s *Service) StopProposals(ctx context.Context, epoch phase0.Epoch) {
+	s.proposalsStopEpochMu.Lock()
+	if s.proposalsStopped && s.proposalsStopEpoch <= epoch {
+		s.proposalsStopEpochMu.Unlock()
+		return
+	}
+	s.proposalsStopped = true
+	s.proposalsStopEpoch = epoch
+	s.proposalsStopEpochMu.Unlock()
+
+	log.Warn().Uint64("epoch", uint64(epoch)).Msg("Stopping beacon block proposals")
+
+	// Cancel any proposals that have already been scheduled.
+	for _, name := range s.scheduler.ListJobs(ctx) {
+		slot, isProposal := jobSlot(proposalJobFormat, name)
+		if !isProposal {
+			slot, isProposal = jobSlot(earlyProposalJobFormat, name)
+		}
+		if !isProposal {
+			continue
+		}
+		if s.proposalsStoppedAt(slot) {
+			s.scheduler.CancelJobIfExists(ctx, name)
+		}
+	}
+}
+
+// proposalsStoppedAt returns true if proposals are stopped at the given slot.
+func (s *Service) proposalsStoppedAt(slot phase0.Slot) bool {
+	s.proposalsStopEpochMu.RLock()
+	defer s.proposalsStopEpochMu.RUnlock()
+
+	return s.proposalsStopped && s.chainTimeService.SlotToEpoch(slot) >= s.proposalsStopEpoch
+}
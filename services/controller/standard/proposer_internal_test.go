@@ -0,0 +1,252 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mockattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/mock"
+	mockattester "github.com/attestantio/vouch/services/attester/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	mockbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/mock"
+	"github.com/attestantio/vouch/services/scheduler/advanced"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedProposerDutiesProvider returns a fixed set of proposer duties.
+type fixedProposerDutiesProvider struct {
+	duties []*apiv1.ProposerDuty
+}
+
+func (p *fixedProposerDutiesProvider) ProposerDuties(_ context.Context,
+	_ *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	return &api.Response[[]*apiv1.ProposerDuty]{
+		Data:     p.duties,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// preparingProposer records the validators for which proposals are prepared.
+type preparingProposer struct {
+	mu       sync.Mutex
+	prepared []phase0.ValidatorIndex
+}
+
+func (p *preparingProposer) Prepare(_ context.Context, data interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prepared = append(p.prepared, data.(*beaconblockproposer.Duty).ValidatorIndex())
+
+	return nil
+}
+
+func (*preparingProposer) Propose(_ context.Context, _ interface{}) {}
+
+func (p *preparingProposer) preparedValidators() []phase0.ValidatorIndex {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]phase0.ValidatorIndex{}, p.prepared...)
+}
+
+// newProposerTestService creates a controller for proposer tests.
+func newProposerTestService(ctx context.Context,
+	t *testing.T,
+	chainTime *standardchaintime.Service,
+	duties []*apiv1.ProposerDuty,
+	proposerSvc *preparingProposer,
+	excludedProposers []phase0.BLSPubKey,
+) *Service {
+	t.Helper()
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithSpecProvider(mock.NewSpecProvider()),
+		WithChainTimeService(chainTime),
+		WithProposerDutiesProvider(&fixedProposerDutiesProvider{
+			duties: duties,
+		}),
+		WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+		WithProposalsPreparer(mockproposalpreparer.New()),
+		WithScheduler(mockscheduler.New()),
+		WithAttester(mockattester.New()),
+		WithBeaconBlockProposer(proposerSvc),
+		WithBeaconCommitteeSubscriber(mockbeaconcommitteesubscriber.New()),
+		WithAttestationAggregator(mockattestationaggregator.New()),
+		WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+		WithBlockToSlotSetter(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotSetter)),
+		WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		WithExcludedProposers(excludedProposers),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestScheduleProposalsExcludedProposers(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	epoch := chainTime.CurrentEpoch() + 1
+	firstSlot := chainTime.FirstSlotOfEpoch(epoch)
+
+	includedPubKey := phase0.BLSPubKey{0x01}
+	excludedPubKey := phase0.BLSPubKey{0x02}
+	proposerSvc := &preparingProposer{}
+	s := newProposerTestService(ctx, t, chainTime,
+		[]*apiv1.ProposerDuty{
+			{PubKey: includedPubKey, Slot: firstSlot, ValidatorIndex: 1},
+			{PubKey: excludedPubKey, Slot: firstSlot + 1, ValidatorIndex: 2},
+		},
+		proposerSvc,
+		[]phase0.BLSPubKey{excludedPubKey},
+	)
+
+	s.scheduleProposals(ctx, epoch, []phase0.ValidatorIndex{1, 2}, false)
+
+	// Proposals are prepared asynchronously, so wait for the included validator.
+	require.Eventually(t, func() bool {
+		return len(proposerSvc.preparedValidators()) > 0
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, []phase0.ValidatorIndex{1}, proposerSvc.preparedValidators())
+}
+
+func TestScheduleProposalsStopped(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	epoch := chainTime.CurrentEpoch() + 1
+	duties := []*apiv1.ProposerDuty{
+		{PubKey: phase0.BLSPubKey{0x01}, Slot: chainTime.FirstSlotOfEpoch(epoch), ValidatorIndex: 1},
+	}
+
+	// Stopped from a later epoch.
+	proposerSvc := &preparingProposer{}
+	s := newProposerTestService(ctx, t, chainTime, duties, proposerSvc, nil)
+	s.StopProposals(ctx, epoch+1)
+	s.scheduleProposals(ctx, epoch, []phase0.ValidatorIndex{1}, false)
+	require.Eventually(t, func() bool {
+		return len(proposerSvc.preparedValidators()) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Stopped from this epoch.
+	proposerSvc = &preparingProposer{}
+	s = newProposerTestService(ctx, t, chainTime, duties, proposerSvc, nil)
+	s.StopProposals(ctx, epoch)
+	s.scheduleProposals(ctx, epoch, []phase0.ValidatorIndex{1}, false)
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, proposerSvc.preparedValidators())
+}
+
+func TestStopProposals(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	scheduler, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled))
+	require.NoError(t, err)
+
+	s := &Service{
+		chainTimeService: chainTime,
+		scheduler:        scheduler,
+	}
+
+	noop := func(_ context.Context, _ interface{}) {}
+	for _, name := range []string{
+		// Epoch 1.
+		"Beacon block proposal for slot 63",
+		"Early beacon block proposal for slot 63",
+		// Epoch 2.
+		"Beacon block proposal for slot 64",
+		"Early beacon block proposal for slot 64",
+		// Epoch 3.
+		"Beacon block proposal for slot 100",
+		// Not proposals.
+		"Attestations for slot 64",
+		"Proposal readiness check for slot 64",
+	} {
+		require.NoError(t, scheduler.ScheduleJob(ctx, "Test", name, time.Now().Add(time.Hour), noop, nil))
+	}
+
+	// Not stopped.
+	require.False(t, s.proposalsStoppedAt(0))
+	require.False(t, s.proposalsStoppedAt(1000))
+
+	s.StopProposals(ctx, 2)
+	require.False(t, s.proposalsStoppedAt(63))
+	require.True(t, s.proposalsStoppedAt(64))
+	require.True(t, s.proposalsStoppedAt(1000))
+	jobs := scheduler.ListJobs(ctx)
+	sort.Strings(jobs)
+	require.Equal(t, []string{
+		"Attestations for slot 64",
+		"Beacon block proposal for slot 63",
+		"Early beacon block proposal for slot 63",
+		"Proposal readiness check for slot 64",
+	}, jobs)
+
+	// A later epoch does not move the stop.
+	s.StopProposals(ctx, 3)
+	require.True(t, s.proposalsStoppedAt(64))
+
+	// An earlier epoch does.
+	s.StopProposals(ctx, 1)
+	require.True(t, s.proposalsStoppedAt(32))
+	require.False(t, s.proposalsStoppedAt(31))
+	jobs = scheduler.ListJobs(ctx)
+	sort.Strings(jobs)
+	require.Equal(t, []string{
+		"Attestations for slot 64",
+		"Proposal readiness check for slot 64",
+	}, jobs)
+}
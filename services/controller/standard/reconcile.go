@@ -0,0 +1,190 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// reconciliation is the result of reconciling duties against the chain.
+type reconciliation struct {
+	attestations       int
+	missedAttestations []*apiv1.AttesterDuty
+	proposals          int
+	missedProposals    []*apiv1.ProposerDuty
+}
+
+// attestationKey identifies the attestations for a committee at a slot.
+type attestationKey struct {
+	slot           phase0.Slot
+	committeeIndex phase0.CommitteeIndex
+}
+
+// reconcileRecentDuties reports the duties of our validators that were
+// missed in the epochs prior to startup, giving an immediate view of the
+// impact of any downtime.
+func (s *Service) reconcileRecentDuties(ctx context.Context,
+	currentEpoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) {
+	if s.startupReconciliationEpochs == 0 || len(validatorIndices) == 0 {
+		return
+	}
+
+	started := time.Now()
+	firstEpoch := phase0.Epoch(0)
+	if uint64(currentEpoch) > s.startupReconciliationEpochs {
+		firstEpoch = currentEpoch - phase0.Epoch(s.startupReconciliationEpochs)
+	}
+	firstSlot := s.chainTimeService.FirstSlotOfEpoch(firstEpoch)
+	currentSlot := s.chainTimeService.CurrentSlot()
+	log := log.With().Uint64("first_epoch", uint64(firstEpoch)).Uint64("current_slot", uint64(currentSlot)).Logger()
+	log.Trace().Msg("Reconciling recent duties")
+
+	attesterDuties := make([]*apiv1.AttesterDuty, 0)
+	proposerDuties := make([]*apiv1.ProposerDuty, 0)
+	for epoch := firstEpoch; epoch <= currentEpoch; epoch++ {
+		// Attestations for the current epoch may not have been made yet.
+		if epoch != currentEpoch {
+			attesterDutiesResponse, err := s.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+				Epoch:   epoch,
+				Indices: validatorIndices,
+			})
+			if err != nil {
+				log.Warn().Uint64("epoch", uint64(epoch)).Err(err).Msg("Failed to obtain attester duties; cannot reconcile")
+				return
+			}
+			attesterDuties = append(attesterDuties, attesterDutiesResponse.Data...)
+		}
+
+		proposerDutiesResponse, err := s.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		if err != nil {
+			log.Warn().Uint64("epoch", uint64(epoch)).Err(err).Msg("Failed to obtain proposer duties; cannot reconcile")
+			return
+		}
+		proposerDuties = append(proposerDuties, proposerDutiesResponse.Data...)
+	}
+
+	blocks := make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock)
+	for slot := firstSlot; slot < currentSlot; slot++ {
+		blockResponse, err := s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// Empty slot.
+				continue
+			}
+			log.Warn().Uint64("block_slot", uint64(slot)).Err(err).Msg("Failed to obtain block; cannot reconcile")
+			return
+		}
+		if blockResponse != nil && blockResponse.Data != nil {
+			blocks[slot] = blockResponse.Data
+		}
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("blocks", len(blocks)).Msg("Obtained recent blocks")
+
+	result := reconcileDuties(attesterDuties, proposerDuties, blocks, currentSlot)
+	for _, duty := range result.missedProposals {
+		log.Warn().Uint64("proposal_slot", uint64(duty.Slot)).Uint64("validator_index", uint64(duty.ValidatorIndex)).Msg("Proposal missed prior to startup")
+	}
+	for _, duty := range result.missedAttestations {
+		log.Debug().Uint64("attestation_slot", uint64(duty.Slot)).Uint64("validator_index", uint64(duty.ValidatorIndex)).Msg("Attestation missed prior to startup")
+	}
+	log.Info().
+		Dur("elapsed", time.Since(started)).
+		Int("attestations", result.attestations).
+		Int("missed_attestations", len(result.missedAttestations)).
+		Int("proposals", result.proposals).
+		Int("missed_proposals", len(result.missedProposals)).
+		Msg("Reconciled recent duties")
+}
+
+// reconcileDuties checks the supplied duties against the blocks on the chain.
+// Attestations are only checked if there has been at least one slot in
+// which they could have been included, and proposals only for past slots.
+func reconcileDuties(attesterDuties []*apiv1.AttesterDuty,
+	proposerDuties []*apiv1.ProposerDuty,
+	blocks map[phase0.Slot]*spec.VersionedSignedBeaconBlock,
+	currentSlot phase0.Slot,
+) *reconciliation {
+	res := &reconciliation{
+		missedAttestations: make([]*apiv1.AttesterDuty, 0),
+		missedProposals:    make([]*apiv1.ProposerDuty, 0),
+	}
+
+	// Gather the attestations included in the blocks.
+	included := make(map[attestationKey][]*phase0.Attestation)
+	for _, block := range blocks {
+		attestations, err := block.Attestations()
+		if err != nil {
+			continue
+		}
+		for _, attestation := range attestations {
+			if attestation.Data == nil {
+				continue
+			}
+			key := attestationKey{slot: attestation.Data.Slot, committeeIndex: attestation.Data.Index}
+			included[key] = append(included[key], attestation)
+		}
+	}
+
+	for _, duty := range attesterDuties {
+		if duty.Slot+1 >= currentSlot {
+			continue
+		}
+		res.attestations++
+		attested := false
+		for _, attestation := range included[attestationKey{slot: duty.Slot, committeeIndex: duty.CommitteeIndex}] {
+			if duty.ValidatorCommitteeIndex < attestation.AggregationBits.Len() &&
+				attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+				attested = true
+				break
+			}
+		}
+		if !attested {
+			res.missedAttestations = append(res.missedAttestations, duty)
+		}
+	}
+
+	for _, duty := range proposerDuties {
+		if duty.Slot >= currentSlot {
+			continue
+		}
+		res.proposals++
+		proposed := false
+		if block, exists := blocks[duty.Slot]; exists {
+			proposerIndex, err := block.ProposerIndex()
+			proposed = err == nil && proposerIndex == duty.ValidatorIndex
+		}
+		if !proposed {
+			res.missedProposals = append(res.missedProposals, duty)
+		}
+	}
+
+	return res
+}
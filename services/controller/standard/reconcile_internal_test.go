@@ -0,0 +1,76 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileDuties(t *testing.T) {
+	bits := bitfield.NewBitlist(8)
+	bits.SetBitAt(2, true)
+	blocks := map[phase0.Slot]*spec.VersionedSignedBeaconBlock{
+		11: {
+			Version: spec.DataVersionPhase0,
+			Phase0: &phase0.SignedBeaconBlock{
+				Message: &phase0.BeaconBlock{
+					Slot:          11,
+					ProposerIndex: 1,
+					Body: &phase0.BeaconBlockBody{
+						Attestations: []*phase0.Attestation{
+							{
+								AggregationBits: bits,
+								Data:            &phase0.AttestationData{Slot: 10, Index: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	attesterDuties := []*apiv1.AttesterDuty{
+		// Included.
+		{Slot: 10, ValidatorIndex: 1, CommitteeIndex: 1, ValidatorCommitteeIndex: 2},
+		// Missed: not in the aggregation bits.
+		{Slot: 10, ValidatorIndex: 2, CommitteeIndex: 1, ValidatorCommitteeIndex: 3},
+		// Missed: no attestations for the committee.
+		{Slot: 10, ValidatorIndex: 3, CommitteeIndex: 2, ValidatorCommitteeIndex: 2},
+		// Too recent to check.
+		{Slot: 13, ValidatorIndex: 4, CommitteeIndex: 1, ValidatorCommitteeIndex: 2},
+	}
+	proposerDuties := []*apiv1.ProposerDuty{
+		// Proposed.
+		{Slot: 11, ValidatorIndex: 1},
+		// Missed: empty slot.
+		{Slot: 12, ValidatorIndex: 2},
+		// In the future.
+		{Slot: 15, ValidatorIndex: 3},
+	}
+
+	res := reconcileDuties(attesterDuties, proposerDuties, blocks, 14)
+	require.Equal(t, 3, res.attestations)
+	require.Len(t, res.missedAttestations, 2)
+	require.Equal(t, phase0.ValidatorIndex(2), res.missedAttestations[0].ValidatorIndex)
+	require.Equal(t, phase0.ValidatorIndex(3), res.missedAttestations[1].ValidatorIndex)
+	require.Equal(t, 2, res.proposals)
+	require.Len(t, res.missedProposals, 1)
+	require.Equal(t, phase0.Slot(12), res.missedProposals[0].Slot)
+}
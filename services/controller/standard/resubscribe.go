@@ -0,0 +1,90 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// HandleBeaconNodeRestart re-issues beacon committee and sync committee subscriptions
+// after a beacon node restarts, as the node loses its subscriptions when it restarts.
+func (s *Service) HandleBeaconNodeRestart(ctx context.Context, address string) {
+	epoch := s.chainTimeService.CurrentEpoch()
+	log := log.With().Str("address", address).Uint64("epoch", uint64(epoch)).Logger()
+	log.Info().Msg("Re-issuing subscriptions after beacon node restart")
+
+	// Beacon committee subscriptions for this and the next epoch.
+	for _, subscriptionEpoch := range []phase0.Epoch{epoch, epoch + 1} {
+		accounts, _, err := s.accountsAndIndicesForEpoch(ctx, subscriptionEpoch)
+		if err != nil {
+			log.Warn().Err(err).Uint64("subscription_epoch", uint64(subscriptionEpoch)).Msg("Failed to obtain accounts for beacon committee subscriptions")
+			continue
+		}
+		subscriptionInfo, err := s.beaconCommitteeSubscriber.Subscribe(ctx, subscriptionEpoch, accounts)
+		if err != nil {
+			log.Warn().Err(err).Uint64("subscription_epoch", uint64(subscriptionEpoch)).Msg("Failed to resubscribe to beacon committees")
+			continue
+		}
+		s.subscriptionInfosMutex.Lock()
+		s.subscriptionInfos[subscriptionEpoch] = subscriptionInfo
+		s.subscriptionInfosMutex.Unlock()
+	}
+
+	// Sync committee subscriptions for this period, and the next if it is close.
+	if !s.handlingAltair || s.syncCommitteesSubscriber == nil || epoch < s.altairForkEpoch {
+		return
+	}
+	period := uint64(epoch) / s.epochsPerSyncCommitteePeriod
+	s.resubscribeSyncCommittees(ctx, epoch, period)
+	nextPeriodStartEpoch := s.firstEpochOfSyncPeriod(period + 1)
+	if uint64(nextPeriodStartEpoch-epoch) <= syncCommitteePreparationEpochs {
+		s.resubscribeSyncCommittees(ctx, nextPeriodStartEpoch, period+1)
+	}
+}
+
+// resubscribeSyncCommittees re-issues sync committee subscriptions for the given period.
+func (s *Service) resubscribeSyncCommittees(ctx context.Context, epoch phase0.Epoch, period uint64) {
+	log := log.With().Uint64("period", period).Logger()
+
+	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain accounts for sync committee subscriptions")
+		return
+	}
+	if len(validatorIndices) == 0 {
+		return
+	}
+
+	dutiesResponse, err := s.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+		Epoch:   epoch,
+		Indices: validatorIndices,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain sync committee duties")
+		return
+	}
+	if len(dutiesResponse.Data) == 0 {
+		return
+	}
+
+	lastEpoch := s.firstEpochOfSyncPeriod(period+1) - 1
+	if err := s.syncCommitteesSubscriber.Subscribe(ctx, lastEpoch+1, dutiesResponse.Data); err != nil {
+		log.Warn().Err(err).Msg("Failed to resubscribe to sync committees")
+		return
+	}
+	log.Trace().Int("duties", len(dutiesResponse.Data)).Msg("Resubscribed to sync committees")
+}
@@ -15,8 +15,11 @@ package standard
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -29,12 +32,16 @@ import (
 	"github.com/attestantio/vouch/services/beaconcommitteesubscriber"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/coordination"
+	"github.com/attestantio/vouch/services/dutyevents"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/proposalpreparer"
+	"github.com/attestantio/vouch/services/proposalreadiness"
 	"github.com/attestantio/vouch/services/scheduler"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
 	"github.com/attestantio/vouch/services/synccommitteesubscriber"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -49,37 +56,65 @@ var syncCommitteePreparationEpochs = uint64(5)
 // It runs purely against clock events, setting up jobs for the validator's processes of block proposal, attestation
 // creation and attestation aggregation.
 type Service struct {
-	monitor                       metrics.ControllerMonitor
-	slotDuration                  time.Duration
-	slotsPerEpoch                 uint64
-	epochsPerSyncCommitteePeriod  uint64
-	chainTimeService              chaintime.Service
-	waitedForGenesis              bool
-	proposerDutiesProvider        eth2client.ProposerDutiesProvider
-	attesterDutiesProvider        eth2client.AttesterDutiesProvider
-	syncCommitteeDutiesProvider   eth2client.SyncCommitteeDutiesProvider
-	validatingAccountsProvider    accountmanager.ValidatingAccountsProvider
-	proposalsPreparer             proposalpreparer.Service
-	scheduler                     scheduler.Service
-	attester                      attester.Service
-	syncCommitteeMessenger        synccommitteemessenger.Service
-	syncCommitteeAggregator       synccommitteeaggregator.Service
-	syncCommitteesSubscriber      synccommitteesubscriber.Service
-	beaconBlockProposer           beaconblockproposer.Service
-	beaconBlockHeadersProvider    eth2client.BeaconBlockHeadersProvider
-	signedBeaconBlockProvider     eth2client.SignedBeaconBlockProvider
-	attestationAggregator         attestationaggregator.Service
-	beaconCommitteeSubscriber     beaconcommitteesubscriber.Service
-	activeValidators              int
-	subscriptionInfos             map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription
-	subscriptionInfosMutex        sync.Mutex
-	accountsRefresher             accountmanager.Refresher
-	blockToSlotSetter             cache.BlockRootToSlotSetter
-	maxProposalDelay              time.Duration
-	maxAttestationDelay           time.Duration
-	attestationAggregationDelay   time.Duration
-	maxSyncCommitteeMessageDelay  time.Duration
-	syncCommitteeAggregationDelay time.Duration
+	monitor                        metrics.ControllerMonitor
+	slotDuration                   time.Duration
+	slotsPerEpoch                  uint64
+	epochsPerSyncCommitteePeriod   uint64
+	chainTimeService               chaintime.Service
+	waitedForGenesis               bool
+	proposerDutiesProvider         eth2client.ProposerDutiesProvider
+	attesterDutiesProvider         eth2client.AttesterDutiesProvider
+	syncCommitteeDutiesProvider    eth2client.SyncCommitteeDutiesProvider
+	validatingAccountsProvider     accountmanager.ValidatingAccountsProvider
+	proposalsPreparer              proposalpreparer.Service
+	scheduler                      scheduler.Service
+	attester                       attester.Service
+	syncCommitteeMessenger         synccommitteemessenger.Service
+	syncCommitteeAggregator        synccommitteeaggregator.Service
+	syncCommitteesSubscriber       synccommitteesubscriber.Service
+	beaconBlockProposer            beaconblockproposer.Service
+	beaconBlockHeadersProvider     eth2client.BeaconBlockHeadersProvider
+	signedBeaconBlockProvider      eth2client.SignedBeaconBlockProvider
+	attestationAggregator          attestationaggregator.Service
+	beaconCommitteeSubscriber      beaconcommitteesubscriber.Service
+	activeValidators               atomic.Int64
+	subscriptionInfos              map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription
+	subscriptionInfosMutex         sync.Mutex
+	accountsRefresher              accountmanager.Refresher
+	blockToSlotSetter              cache.BlockRootToSlotSetter
+	maxProposalDelay               time.Duration
+	maxAttestationDelay            time.Duration
+	attestationTrigger             string
+	minAttestationDelay            time.Duration
+	attestationAggregationDelay    time.Duration
+	minAttestationAggregationDelay time.Duration
+	startupReconciliationEpochs    uint64
+	maxSyncCommitteeMessageDelay   time.Duration
+	syncCommitteeMessageGrace      time.Duration
+	syncCommitteeAggregationDelay  time.Duration
+	dutyDeadline                   time.Duration
+	dutyBudget                     *util.DutyBudget
+	attestationHeadWait            time.Duration
+	excludedProposers              map[phase0.BLSPubKey]struct{}
+	proposalNotificationURL        string
+	proposalNotificationClient     *http.Client
+	dutyStatementKey               ed25519.PrivateKey
+	dutyStatementDir               string
+	dutyStatements                 map[phase0.Epoch]*DutyStatement
+	dutyStatementsMu               sync.Mutex
+	proposalReadinessChecker       proposalreadiness.Service
+	proposalReadinessSlots         uint64
+	accountsRefreshSlices          uint64
+	accountsRefreshSlice           atomic.Uint64
+	idleAccountsRefreshInterval    time.Duration
+	canaryValidators               map[phase0.BLSPubKey]struct{}
+	canaryIndices                  map[phase0.ValidatorIndex]struct{}
+	canaryIndicesMu                sync.RWMutex
+	dutyEvents                     dutyevents.Service
+	maintenanceProposalFreeSlots   uint64
+	coordinator                    coordination.Service
+	logLateBlocks                  bool
+	delayTuner                     *delayTuner
 
 	// Hard fork control
 	handlingAltair     bool
@@ -89,6 +124,9 @@ type Service struct {
 	capellaForkEpoch   phase0.Epoch
 
 	// Tracking for reorgs.
+	headEventsMu              sync.Mutex
+	headEventsSlot            phase0.Slot
+	headEvents                map[headEvent]struct{}
 	lastBlockRoot             phase0.Root
 	lastBlockEpoch            phase0.Epoch
 	currentDutyDependentRoot  phase0.Root
@@ -97,6 +135,27 @@ type Service struct {
 	// Tracking for attestations.
 	pendingAttestations      map[phase0.Slot]bool
 	pendingAttestationsMutex sync.RWMutex
+	attestedHeads            map[phase0.Slot]*attestedHead
+	attestedHeadsMu          sync.Mutex
+
+	// Tracking for sync committee duties.
+	syncCommitteeIndices   map[uint64]map[phase0.ValidatorIndex]struct{}
+	syncCommitteeIndicesMu sync.Mutex
+
+	// Stopping proposals.
+	proposalsStopped     bool
+	proposalsStopEpoch   phase0.Epoch
+	proposalsStopEpochMu sync.RWMutex
+
+	// Pausing duties.
+	attestationsPaused atomic.Bool
+	proposalsPaused    atomic.Bool
+
+	// Maintenance mode.
+	maintenance        bool
+	maintenanceEndSlot phase0.Slot
+	maintenanceMu      sync.Mutex
+	runningDuties      atomic.Int64
 }
 
 // module-wide log.
@@ -163,40 +222,75 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	}
 
 	s := &Service{
-		monitor:                       parameters.monitor,
-		slotDuration:                  slotDuration,
-		slotsPerEpoch:                 slotsPerEpoch,
-		epochsPerSyncCommitteePeriod:  epochsPerSyncCommitteePeriod,
-		chainTimeService:              parameters.chainTimeService,
-		proposerDutiesProvider:        parameters.proposerDutiesProvider,
-		attesterDutiesProvider:        parameters.attesterDutiesProvider,
-		syncCommitteeDutiesProvider:   parameters.syncCommitteeDutiesProvider,
-		syncCommitteesSubscriber:      parameters.syncCommitteesSubscriber,
-		validatingAccountsProvider:    parameters.validatingAccountsProvider,
-		proposalsPreparer:             parameters.proposalsPreparer,
-		scheduler:                     parameters.scheduler,
-		attester:                      parameters.attester,
-		syncCommitteeMessenger:        parameters.syncCommitteeMessenger,
-		syncCommitteeAggregator:       parameters.syncCommitteeAggregator,
-		beaconBlockProposer:           parameters.beaconBlockProposer,
-		beaconBlockHeadersProvider:    parameters.beaconBlockHeadersProvider,
-		signedBeaconBlockProvider:     parameters.signedBeaconBlockProvider,
-		attestationAggregator:         parameters.attestationAggregator,
-		beaconCommitteeSubscriber:     parameters.beaconCommitteeSubscriber,
-		accountsRefresher:             parameters.accountsRefresher,
-		blockToSlotSetter:             parameters.blockToSlotSetter,
-		maxProposalDelay:              parameters.maxProposalDelay,
-		maxAttestationDelay:           parameters.maxAttestationDelay,
-		attestationAggregationDelay:   parameters.attestationAggregationDelay,
-		maxSyncCommitteeMessageDelay:  parameters.maxSyncCommitteeMessageDelay,
-		syncCommitteeAggregationDelay: parameters.syncCommitteeAggregationDelay,
-		subscriptionInfos:             make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription),
-		handlingAltair:                handlingAltair,
-		altairForkEpoch:               altairForkEpoch,
-		handlingBellatrix:             handlingBellatrix,
-		bellatrixForkEpoch:            bellatrixForkEpoch,
-		capellaForkEpoch:              capellaForkEpoch,
-		pendingAttestations:           make(map[phase0.Slot]bool),
+		monitor:                        parameters.monitor,
+		slotDuration:                   slotDuration,
+		slotsPerEpoch:                  slotsPerEpoch,
+		epochsPerSyncCommitteePeriod:   epochsPerSyncCommitteePeriod,
+		chainTimeService:               parameters.chainTimeService,
+		proposerDutiesProvider:         parameters.proposerDutiesProvider,
+		attesterDutiesProvider:         parameters.attesterDutiesProvider,
+		syncCommitteeDutiesProvider:    parameters.syncCommitteeDutiesProvider,
+		syncCommitteesSubscriber:       parameters.syncCommitteesSubscriber,
+		validatingAccountsProvider:     parameters.validatingAccountsProvider,
+		proposalsPreparer:              parameters.proposalsPreparer,
+		scheduler:                      parameters.scheduler,
+		attester:                       parameters.attester,
+		syncCommitteeMessenger:         parameters.syncCommitteeMessenger,
+		syncCommitteeAggregator:        parameters.syncCommitteeAggregator,
+		beaconBlockProposer:            parameters.beaconBlockProposer,
+		beaconBlockHeadersProvider:     parameters.beaconBlockHeadersProvider,
+		signedBeaconBlockProvider:      parameters.signedBeaconBlockProvider,
+		attestationAggregator:          parameters.attestationAggregator,
+		beaconCommitteeSubscriber:      parameters.beaconCommitteeSubscriber,
+		accountsRefresher:              parameters.accountsRefresher,
+		blockToSlotSetter:              parameters.blockToSlotSetter,
+		maxProposalDelay:               parameters.maxProposalDelay,
+		maxAttestationDelay:            parameters.maxAttestationDelay,
+		attestationTrigger:             parameters.attestationTrigger,
+		minAttestationDelay:            parameters.minAttestationDelay,
+		attestationAggregationDelay:    parameters.attestationAggregationDelay,
+		minAttestationAggregationDelay: parameters.minAttestationAggregationDelay,
+		startupReconciliationEpochs:    parameters.startupReconciliationEpochs,
+		maxSyncCommitteeMessageDelay:   parameters.maxSyncCommitteeMessageDelay,
+		syncCommitteeMessageGrace:      parameters.syncCommitteeMessageGrace,
+		syncCommitteeAggregationDelay:  parameters.syncCommitteeAggregationDelay,
+		dutyDeadline:                   parameters.dutyDeadline,
+		dutyBudget:                     parameters.dutyBudget,
+		attestationHeadWait:            parameters.attestationHeadWait,
+		logLateBlocks:                  parameters.logLateBlocks,
+		subscriptionInfos:              make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription),
+		handlingAltair:                 handlingAltair,
+		altairForkEpoch:                altairForkEpoch,
+		handlingBellatrix:              handlingBellatrix,
+		bellatrixForkEpoch:             bellatrixForkEpoch,
+		capellaForkEpoch:               capellaForkEpoch,
+		pendingAttestations:            make(map[phase0.Slot]bool),
+		attestedHeads:                  make(map[phase0.Slot]*attestedHead),
+		syncCommitteeIndices:           make(map[uint64]map[phase0.ValidatorIndex]struct{}),
+		excludedProposers:              make(map[phase0.BLSPubKey]struct{}, len(parameters.excludedProposers)),
+		proposalNotificationURL:        parameters.proposalNotificationURL,
+		proposalNotificationClient:     util.NewHTTPClient(proposalNotificationTimeout),
+		dutyStatementKey:               parameters.dutyStatementKey,
+		dutyStatementDir:               parameters.dutyStatementDir,
+		dutyStatements:                 make(map[phase0.Epoch]*DutyStatement),
+		proposalReadinessChecker:       parameters.proposalReadinessChecker,
+		proposalReadinessSlots:         parameters.proposalReadinessSlots,
+		accountsRefreshSlices:          parameters.accountsRefreshSlices,
+		idleAccountsRefreshInterval:    parameters.idleAccountsRefreshInterval,
+		canaryValidators:               make(map[phase0.BLSPubKey]struct{}, len(parameters.canaryValidators)),
+		canaryIndices:                  make(map[phase0.ValidatorIndex]struct{}),
+		dutyEvents:                     parameters.dutyEvents,
+		maintenanceProposalFreeSlots:   parameters.maintenanceProposalFreeSlots,
+		coordinator:                    parameters.coordinator,
+	}
+	for _, pubKey := range parameters.excludedProposers {
+		s.excludedProposers[pubKey] = struct{}{}
+	}
+	for _, pubKey := range parameters.canaryValidators {
+		s.canaryValidators[pubKey] = struct{}{}
+	}
+	if parameters.delayTuning != nil {
+		s.delayTuner = newDelayTuner(parameters.maxAttestationDelay, parameters.delayTuning)
 	}
 
 	// Subscribe to head events.  This allows us to go early for attestations if a block arrives, as well as
@@ -206,26 +300,37 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		return nil, errors.Wrap(err, "failed to add head event handler")
 	}
 
+	// Subscribe to chain reorg events.  This allows us to re-request duties if a reorganisation
+	// replaces the blocks on which they depend.
+	if err := parameters.eventsProvider.Events(ctx, []string{"chain_reorg"}, s.HandleChainReorgEvent); err != nil {
+		return nil, errors.Wrap(err, "failed to add chain reorg event handler")
+	}
+
 	// Subscribe to block events.  This allows us to keep the cache for the block roots to slot number up to date.
 	if err := parameters.eventsProvider.Events(ctx, []string{"block"}, s.HandleBlockEvent); err != nil {
 		return nil, errors.Wrap(err, "failed to add block event handler")
 	}
 
-	// Start tickers, to carry out periodic operations.
-	if err := s.startTickers(ctx, handlingBellatrix); err != nil {
-		return nil, errors.Wrap(err, "failed to start controller tickers")
-	}
-
 	// Run specific actions now so we can carry out duties for the remainder of this epoch.
 	epoch := s.chainTimeService.CurrentEpoch()
 	accounts, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain active validator indices for the current epoch")
 	}
-	if len(validatorIndices) != s.activeValidators {
-		log.Info().Int("old_validators", s.activeValidators).Int("new_validators", len(validatorIndices)).Msg("Change in number of active validators")
-		s.activeValidators = len(validatorIndices)
+	if len(validatorIndices) != int(s.activeValidators.Load()) {
+		log.Info().Int64("old_validators", s.activeValidators.Load()).Int("new_validators", len(validatorIndices)).Msg("Change in number of active validators")
+		s.activeValidators.Store(int64(len(validatorIndices)))
+	}
+	if len(validatorIndices) == 0 {
+		log.Info().Msg("No active validators; starting in low-activity mode")
+	}
+
+	// Start tickers, to carry out periodic operations.  This happens after the
+	// active validators are known, as the tickers run less often without them.
+	if err := s.startTickers(ctx, handlingBellatrix); err != nil {
+		return nil, errors.Wrap(err, "failed to start controller tickers")
 	}
+
 	nextEpochAccounts, nextEpochValidatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch+1)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain active validator indices for the next epoch")
@@ -269,6 +374,11 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		s.prepareProposals(ctx, nil)
 	}()
 
+	// Report duties missed whilst we were not running.
+	if !s.waitedForGenesis {
+		go s.reconcileRecentDuties(ctx, epoch, validatorIndices)
+	}
+
 	return s, nil
 }
 
@@ -282,6 +392,12 @@ func (s *Service) startTickers(ctx context.Context,
 		return errors.Wrap(err, "failed to start epoch ticker")
 	}
 
+	// Start next duties ticker.
+	log.Trace().Msg("Starting next duties ticker")
+	if err := s.startNextDutiesTicker(ctx); err != nil {
+		return errors.Wrap(err, "failed to start next duties ticker")
+	}
+
 	// Start account refresher.
 	log.Trace().Msg("Starting accounts refresher")
 	if err := s.startAccountsRefresher(ctx); err != nil {
@@ -344,23 +460,36 @@ func (s *Service) epochTicker(ctx context.Context, data interface{}) {
 	epochTickerData.latestEpochRan = int64(currentEpoch)
 	epochTickerData.mutex.Unlock()
 	s.monitor.NewEpoch()
+	s.issueDutyStatements(currentEpoch)
 
 	// We wait for the beacon node to update, but keep ourselves busy in the meantime.
 	waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
 
-	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, currentEpoch)
+	accounts, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, currentEpoch)
 	if err != nil {
 		log.Error().Err(err).Uint64("epoch", uint64(currentEpoch)).Msg("Failed to obtain active validators for epoch")
 		cancel()
 		return
 	}
 
-	// Expect at least one validator.
+	// Without active validators there is nothing to schedule.
+	previousValidators := s.activeValidators.Swap(int64(len(validatorIndices)))
 	if len(validatorIndices) == 0 {
-		log.Warn().Msg("No active validators; not validating")
+		if previousValidators != 0 {
+			log.Info().Msg("No active validators; entering low-activity mode")
+		} else {
+			log.Trace().Msg("No active validators; not validating")
+		}
 		cancel()
 		return
 	}
+	rampedUp := previousValidators == 0
+	if rampedUp {
+		// Validators have become active without a refresh of accounts, for
+		// example by reaching their activation epoch.
+		log.Info().Int("validators", len(validatorIndices)).Msg("Active validators found; leaving low-activity mode")
+		s.scheduleRampUp(ctx, currentEpoch, accounts, validatorIndices)
+	}
 
 	// Done the preparation work available to us; wait for the end of the timer.
 	<-waitCtx.Done()
@@ -375,9 +504,15 @@ func (s *Service) epochTicker(ctx context.Context, data interface{}) {
 		}
 
 		// Update the _next_ period if we close to an EPOCHS_PER_SYNC_COMMITTEE_PERIOD boundary.
-		if uint64(currentEpoch)%s.epochsPerSyncCommitteePeriod == s.epochsPerSyncCommitteePeriod-syncCommitteePreparationEpochs {
+		// If we have just ramped up this has already been done.
+		if !rampedUp && uint64(currentEpoch)%s.epochsPerSyncCommitteePeriod == s.epochsPerSyncCommitteePeriod-syncCommitteePreparationEpochs {
 			go s.scheduleSyncCommitteeMessages(ctx, currentEpoch+phase0.Epoch(syncCommitteePreparationEpochs), validatorIndices, false /* notCurrentSlot */)
 		}
+
+		// Pick up validators that have become active part-way through the period.
+		if !rampedUp {
+			go s.scheduleSyncCommitteeMessagesForNewValidators(ctx, currentEpoch, validatorIndices)
+		}
 	}
 
 	if s.handlingBellatrix {
@@ -458,6 +593,7 @@ func (s *Service) accountsAndIndicesForEpoch(ctx context.Context,
 	for index := range accounts {
 		validatorIndices = append(validatorIndices, index)
 	}
+	s.updateCanaryIndices(accounts)
 
 	return accounts, validatorIndices, nil
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyevents"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -69,6 +70,7 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 	}
 	duties := dutiesResponse.Data
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(duties)).Msg("Fetched sync committee message duties")
+	s.recordSyncCommitteeIndices(period, validatorIndices)
 	if len(duties) == 0 {
 		// No duties; nothing to do.
 		return
@@ -112,7 +114,7 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 			prepareJobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(-s.slotDuration * 6 / 4)
 			if err := s.scheduler.ScheduleJob(ctx,
 				"Prepare for sync committee messages",
-				fmt.Sprintf("Prepare sync committee messages for slot %d", duty.Slot()),
+				fmt.Sprintf(prepareSyncCommitteeMessagesJobFormat, duty.Slot()),
 				prepareJobTime,
 				s.prepareMessageSyncCommittee,
 				duty,
@@ -131,6 +133,113 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted sync committee subscribers")
 }
 
+// scheduleSyncCommitteeMessagesForNewValidators schedules sync committee messages for validators
+// that have been activated or added part-way through a sync committee period, rather than waiting
+// for duties to be scheduled at the next period boundary.
+func (s *Service) scheduleSyncCommitteeMessagesForNewValidators(ctx context.Context,
+	epoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) {
+	if !s.handlingAltair || epoch < s.altairForkEpoch {
+		return
+	}
+
+	currentPeriod := uint64(epoch) / s.epochsPerSyncCommitteePeriod
+	// The next period is only checked if its duties have already been scheduled.
+	for _, period := range []uint64{currentPeriod, currentPeriod + 1} {
+		newIndices := s.newSyncCommitteeIndices(period, validatorIndices)
+		if len(newIndices) == 0 {
+			continue
+		}
+		log := log.With().Uint64("period", period).Int("new_validators", len(newIndices)).Logger()
+
+		dutiesEpoch := s.firstEpochOfSyncPeriod(period)
+		if dutiesEpoch < epoch {
+			dutiesEpoch = epoch
+		}
+		dutiesResponse, err := s.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   dutiesEpoch,
+			Indices: newIndices,
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to fetch sync committee duties for new validators")
+			continue
+		}
+		s.recordSyncCommitteeIndices(period, newIndices)
+		if len(dutiesResponse.Data) == 0 {
+			log.Trace().Msg("New validators not in sync committee")
+			continue
+		}
+
+		// Messages for a slot are generated for all validators together, so reschedule the period.
+		log.Info().Int("duties", len(dutiesResponse.Data)).Msg("New validators in sync committee; rescheduling sync committee messages")
+		s.refreshSyncCommitteeDutiesForEpochPeriod(ctx, dutiesEpoch)
+	}
+}
+
+// scheduleSyncCommitteeMessagesForRefreshedAccounts schedules sync committee messages for
+// validators that have been found by a refresh of accounts.
+func (s *Service) scheduleSyncCommitteeMessagesForRefreshedAccounts(ctx context.Context) {
+	if !s.handlingAltair || s.activeValidators.Load() == 0 {
+		// Low-activity mode schedules duties when it is left.
+		return
+	}
+
+	epoch := s.chainTimeService.CurrentEpoch()
+	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch)
+	if err != nil {
+		log.Error().Err(err).Uint64("epoch", uint64(epoch)).Msg("Failed to obtain active validators for epoch")
+		return
+	}
+
+	s.scheduleSyncCommitteeMessagesForNewValidators(ctx, epoch, validatorIndices)
+}
+
+// recordSyncCommitteeIndices records the validators for which sync committee duties have been
+// obtained for the given period.
+func (s *Service) recordSyncCommitteeIndices(period uint64, validatorIndices []phase0.ValidatorIndex) {
+	s.syncCommitteeIndicesMu.Lock()
+	defer s.syncCommitteeIndicesMu.Unlock()
+
+	indices, exists := s.syncCommitteeIndices[period]
+	if !exists {
+		indices = make(map[phase0.ValidatorIndex]struct{}, len(validatorIndices))
+		s.syncCommitteeIndices[period] = indices
+	}
+	for _, validatorIndex := range validatorIndices {
+		indices[validatorIndex] = struct{}{}
+	}
+
+	// Remove periods that have finished.
+	for recordedPeriod := range s.syncCommitteeIndices {
+		if recordedPeriod+1 < period {
+			delete(s.syncCommitteeIndices, recordedPeriod)
+		}
+	}
+}
+
+// newSyncCommitteeIndices returns the validators for which sync committee duties have not been
+// obtained for the given period.  If duties have not been obtained for any validators then the
+// period has yet to be scheduled, and no validators are returned.
+func (s *Service) newSyncCommitteeIndices(period uint64, validatorIndices []phase0.ValidatorIndex) []phase0.ValidatorIndex {
+	s.syncCommitteeIndicesMu.Lock()
+	defer s.syncCommitteeIndicesMu.Unlock()
+
+	indices, exists := s.syncCommitteeIndices[period]
+	if !exists {
+		return nil
+	}
+
+	newIndices := make([]phase0.ValidatorIndex, 0)
+	for _, validatorIndex := range validatorIndices {
+		if _, exists := indices[validatorIndex]; !exists {
+			newIndices = append(newIndices, validatorIndex)
+		}
+	}
+
+	return newIndices
+}
+
 func (s *Service) prepareMessageSyncCommittee(ctx context.Context, data interface{}) {
 	started := time.Now()
 	duty, ok := data.(*synccommitteemessenger.Duty)
@@ -139,6 +248,10 @@ func (s *Service) prepareMessageSyncCommittee(ctx context.Context, data interfac
 		return
 	}
 	log := log.With().Uint64("slot", uint64(s.chainTimeService.CurrentSlot())).Logger()
+	if !s.startDuty(ctx, duty.Slot()) {
+		return
+	}
+	defer s.endDuty()
 
 	if err := s.syncCommitteeMessenger.Prepare(ctx, duty); err != nil {
 		log.Error().Uint64("sync_committee_slot", uint64(duty.Slot())).Err(err).Msg("Failed to prepare sync committee message")
@@ -146,10 +259,10 @@ func (s *Service) prepareMessageSyncCommittee(ctx context.Context, data interfac
 	}
 
 	// At this point we can schedule the message job.
-	jobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.maxSyncCommitteeMessageDelay)
+	jobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.tunedMaxSyncCommitteeMessageDelay())
 	if err := s.scheduler.ScheduleJob(ctx,
 		"Generate sync committee messages",
-		fmt.Sprintf("Sync committee messages for slot %d", duty.Slot()),
+		fmt.Sprintf(syncCommitteeMessagesJobFormat, duty.Slot()),
 		jobTime,
 		s.messageSyncCommittee,
 		duty,
@@ -157,6 +270,7 @@ func (s *Service) prepareMessageSyncCommittee(ctx context.Context, data interfac
 		log.Error().Err(err).Msg("Failed to schedule sync committee messages")
 		return
 	}
+	s.publishDutyEvent(ctx, dutyevents.DutySyncCommittee, dutyevents.StageScheduled, duty.Slot(), duty.ValidatorIndices(), nil)
 
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Prepared")
 }
@@ -169,12 +283,23 @@ func (s *Service) messageSyncCommittee(ctx context.Context, data interface{}) {
 		return
 	}
 	log := log.With().Uint64("slot", uint64(s.chainTimeService.CurrentSlot())).Logger()
+	if !s.startDuty(ctx, duty.Slot()) {
+		return
+	}
+	defer s.endDuty()
 
-	_, err := s.syncCommitteeMessenger.Message(ctx, duty)
+	s.publishDutyEvent(ctx, dutyevents.DutySyncCommittee, dutyevents.StageStarted, duty.Slot(), duty.ValidatorIndices(), nil)
+	dutyCtx, cancel := s.dutyContext(ctx, duty.Slot())
+	defer cancel()
+	messages, err := s.syncCommitteeMessenger.Message(dutyCtx, duty)
+	s.recordSyncCommitteeMessageTiming(duty.Slot(), started, err)
+	s.checkCanarySyncCommitteeMessages(duty, messages, err)
 	if err != nil {
+		s.publishDutyEvent(ctx, dutyevents.DutySyncCommittee, dutyevents.StageFailed, duty.Slot(), duty.ValidatorIndices(), err)
 		log.Warn().Err(err).Msg("Failed to submit sync committee message")
 		return
 	}
+	s.recordSyncCommitteeMessages(duty.Slot(), messages)
 
 	// At this point we can schedule an aggregation job if reqiured.
 	aggregateValidatorIndices := make([]phase0.ValidatorIndex, 0)
@@ -195,12 +320,14 @@ func (s *Service) messageSyncCommittee(ctx context.Context, data interface{}) {
 		}
 		if err := s.scheduler.ScheduleJob(ctx,
 			"Aggregate sync committee messages",
-			fmt.Sprintf("Sync committee aggregation for slot %d", duty.Slot()),
-			s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.syncCommitteeAggregationDelay),
-			s.syncCommitteeAggregator.Aggregate,
+			fmt.Sprintf(syncCommitteeAggregationJobFormat, duty.Slot()),
+			s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.tunedSyncCommitteeAggregationDelay()),
+			s.aggregateSyncCommitteeMessages,
 			aggregatorDuty,
 		); err != nil {
 			log.Error().Err(err).Msg("Failed to schedule sync committee attestation aggregation job")
+		} else {
+			s.publishDutyEvent(ctx, dutyevents.DutySyncCommitteeAggregation, dutyevents.StageScheduled, duty.Slot(), aggregateValidatorIndices, nil)
 		}
 	}
 
@@ -215,3 +342,19 @@ func (s *Service) firstEpochOfSyncPeriod(period uint64) phase0.Epoch {
 	}
 	return epoch
 }
+
+// recordSyncCommitteeMessageTiming records the time at which sync committee
+// messages for a slot completed, along with whether they were triggered by the
+// arrival of the block for the slot or by reaching the maximum delay.
+func (s *Service) recordSyncCommitteeMessageTiming(slot phase0.Slot, started time.Time, err error) {
+	startOfSlot := s.chainTimeService.StartOfSlot(slot)
+	trigger := "deadline"
+	if started.Before(startOfSlot.Add(s.tunedMaxSyncCommitteeMessageDelay())) {
+		trigger = "block"
+	}
+	result := "succeeded"
+	if err != nil {
+		result = "failed"
+	}
+	s.monitor.SyncCommitteeMessagesTiming(trigger, time.Since(startOfSlot), result)
+}
@@ -0,0 +1,125 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSyncCommitteeDutiesProvider records the validators for which duties are requested.
+type recordingSyncCommitteeDutiesProvider struct {
+	requested [][]phase0.ValidatorIndex
+}
+
+func (p *recordingSyncCommitteeDutiesProvider) SyncCommitteeDuties(_ context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	p.requested = append(p.requested, opts.Indices)
+
+	return &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data:     make([]*apiv1.SyncCommitteeDuty, 0),
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+var _ eth2client.SyncCommitteeDutiesProvider = (*recordingSyncCommitteeDutiesProvider)(nil)
+
+func TestScheduleSyncCommitteeMessagesForNewValidators(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	dutiesProvider := &recordingSyncCommitteeDutiesProvider{}
+	s := &Service{
+		handlingAltair:               true,
+		epochsPerSyncCommitteePeriod: 256,
+		syncCommitteeDutiesProvider:  dutiesProvider,
+		syncCommitteeIndices:         make(map[uint64]map[phase0.ValidatorIndex]struct{}),
+	}
+
+	// Period not yet scheduled, so nothing to do.
+	s.scheduleSyncCommitteeMessagesForNewValidators(ctx, 10, []phase0.ValidatorIndex{1, 2, 3})
+	require.Empty(t, dutiesProvider.requested)
+
+	// Period scheduled with some validators; duties are only fetched for the new validator.
+	s.recordSyncCommitteeIndices(0, []phase0.ValidatorIndex{1, 2})
+	s.scheduleSyncCommitteeMessagesForNewValidators(ctx, 10, []phase0.ValidatorIndex{1, 2, 3})
+	require.Equal(t, [][]phase0.ValidatorIndex{{3}}, dutiesProvider.requested)
+
+	// The new validator has now been handled.
+	s.scheduleSyncCommitteeMessagesForNewValidators(ctx, 11, []phase0.ValidatorIndex{1, 2, 3})
+	require.Len(t, dutiesProvider.requested, 1)
+	require.Empty(t, s.newSyncCommitteeIndices(0, []phase0.ValidatorIndex{1, 2, 3}))
+
+	// Finished periods are removed.
+	s.recordSyncCommitteeIndices(1, []phase0.ValidatorIndex{1})
+	s.recordSyncCommitteeIndices(2, []phase0.ValidatorIndex{1})
+	require.NotContains(t, s.syncCommitteeIndices, uint64(0))
+	require.Contains(t, s.syncCommitteeIndices, uint64(1))
+}
+
+// syncCommitteeTimingMonitor records the timing of sync committee messages.
+type syncCommitteeTimingMonitor struct {
+	*nullmetrics.Service
+	mu      sync.Mutex
+	results []string
+}
+
+func (m *syncCommitteeTimingMonitor) SyncCommitteeMessagesTiming(trigger string, _ time.Duration, result string) {
+	m.mu.Lock()
+	m.results = append(m.results, trigger+"/"+result)
+	m.mu.Unlock()
+}
+
+func TestRecordSyncCommitteeMessageTiming(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	monitor := &syncCommitteeTimingMonitor{
+		Service: nullmetrics.New(ctx),
+	}
+	s := &Service{
+		chainTimeService:             chainTime,
+		monitor:                      monitor,
+		maxSyncCommitteeMessageDelay: 4 * time.Second,
+	}
+
+	slot := phase0.Slot(100)
+	startOfSlot := chainTime.StartOfSlot(slot)
+	s.recordSyncCommitteeMessageTiming(slot, startOfSlot.Add(time.Second), nil)
+	s.recordSyncCommitteeMessageTiming(slot, startOfSlot.Add(4*time.Second), nil)
+	s.recordSyncCommitteeMessageTiming(slot, startOfSlot.Add(time.Second), errors.New("failed"))
+	require.Equal(t, []string{"block/succeeded", "deadline/succeeded", "block/failed"}, monitor.results)
+}
@@ -0,0 +1,206 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// sessionRequest is the body of a request to create a session.
+type sessionRequest struct {
+	Name      string `json:"Name"`
+	TTL       string `json:"TTL"`
+	LockDelay string `json:"LockDelay"`
+	Behavior  string `json:"Behavior"`
+}
+
+// sessionResponse is the body of the response to a request to create a session.
+type sessionResponse struct {
+	ID string `json:"ID"`
+}
+
+// kvEntry is an entry in the key/value store.
+type kvEntry struct {
+	LockIndex uint64 `json:"LockIndex"`
+	Session   string `json:"Session"`
+	Value     []byte `json:"Value"`
+}
+
+// createSession creates a session, returning its ID.
+func (s *Service) createSession(ctx context.Context) (string, error) {
+	body, err := json.Marshal(&sessionRequest{
+		Name:      fmt.Sprintf("vouch-%s", s.instanceID),
+		TTL:       s.sessionTTL.String(),
+		LockDelay: s.lockDelay.String(),
+		Behavior:  "release",
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal session request")
+	}
+
+	data, status, err := s.request(ctx, http.MethodPut, "/v1/session/create", nil, body)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to create session: %d: %s", status, strings.TrimSpace(string(data)))
+	}
+
+	res := &sessionResponse{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return "", errors.Wrap(err, "invalid session response")
+	}
+	if res.ID == "" {
+		return "", errors.New("no session ID returned")
+	}
+
+	return res.ID, nil
+}
+
+// renewSession renews a session, returning false if the session no longer exists.
+func (s *Service) renewSession(ctx context.Context, id string) (bool, error) {
+	data, status, err := s.request(ctx, http.MethodPut, "/v1/session/renew/"+id, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	switch status {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to renew session: %d: %s", status, strings.TrimSpace(string(data)))
+	}
+}
+
+// destroySession destroys a session.
+func (s *Service) destroySession(ctx context.Context, id string) error {
+	data, status, err := s.request(ctx, http.MethodPut, "/v1/session/destroy/"+id, nil, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to destroy session: %d: %s", status, strings.TrimSpace(string(data)))
+	}
+
+	return nil
+}
+
+// acquire attempts to acquire the lock with the given session, returning true if it is held.
+func (s *Service) acquire(ctx context.Context, id string) (bool, error) {
+	return s.lock(ctx, "acquire", id, []byte(s.instanceID))
+}
+
+// release releases the lock held by the given session.
+func (s *Service) release(ctx context.Context, id string) error {
+	_, err := s.lock(ctx, "release", id, nil)
+
+	return err
+}
+
+// lock acquires or releases the lock.
+func (s *Service) lock(ctx context.Context, operation string, id string, value []byte) (bool, error) {
+	data, status, err := s.request(ctx, http.MethodPut, "/v1/kv/"+s.key, url.Values{operation: []string{id}}, value)
+	if err != nil {
+		return false, err
+	}
+	if status != http.StatusOK {
+		return false, fmt.Errorf("failed to %s lock: %d: %s", operation, status, strings.TrimSpace(string(data)))
+	}
+
+	var res bool
+	if err := json.Unmarshal(data, &res); err != nil {
+		return false, errors.Wrap(err, "invalid lock response")
+	}
+
+	return res, nil
+}
+
+// lockEntry returns the entry for the lock, or nil if it does not exist.
+func (s *Service) lockEntry(ctx context.Context) (*kvEntry, error) {
+	data, status, err := s.request(ctx, http.MethodGet, "/v1/kv/"+s.key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to obtain lock: %d: %s", status, strings.TrimSpace(string(data)))
+	}
+
+	entries := make([]*kvEntry, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrap(err, "invalid lock entry")
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries[0], nil
+}
+
+// request sends a request to Consul, returning the body and status code of the response.
+func (s *Service) request(ctx context.Context,
+	method string,
+	path string,
+	query url.Values,
+	body []byte,
+) (
+	[]byte,
+	int,
+	error,
+) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	reqURL := s.address + path
+	if len(query) > 0 {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, query.Encode())
+	}
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to create request")
+	}
+	if s.token != "" {
+		req.Header.Set("X-Consul-Token", s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to read response")
+	}
+
+	return data, resp.StatusCode, nil
+}
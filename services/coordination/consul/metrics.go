@@ -0,0 +1,79 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	leaderGauge        prometheus.Gauge
+	transitionsCounter *prometheus.CounterVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if leaderGauge != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	leaderGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "coordination",
+		Name:      "leader",
+		Help:      "1 if this instance is the leader, otherwise 0.",
+	})
+	if err := prometheus.Register(leaderGauge); err != nil {
+		return err
+	}
+
+	transitionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "coordination",
+		Name:      "transitions_total",
+		Help:      "The number of times this instance has become or stopped being the leader.",
+	}, []string{"transition"})
+	return prometheus.Register(transitionsCounter)
+}
+
+// monitorLeader records the leadership of this instance.
+func monitorLeader(leader bool, changed bool) {
+	if leaderGauge != nil {
+		if leader {
+			leaderGauge.Set(1)
+		} else {
+			leaderGauge.Set(0)
+		}
+	}
+	if transitionsCounter != nil && changed {
+		if leader {
+			transitionsCounter.WithLabelValues("elected").Inc()
+		} else {
+			transitionsCounter.WithLabelValues("deposed").Inc()
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel   zerolog.Level
+	monitor    metrics.Service
+	address    string
+	token      string
+	key        string
+	instanceID string
+	sessionTTL time.Duration
+	lockDelay  time.Duration
+	timeout    time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithAddress sets the address of the Consul agent, for example 'http://localhost:8500'.
+func WithAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.address = address
+	})
+}
+
+// WithToken sets the ACL token used to access Consul.
+func WithToken(token string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.token = token
+	})
+}
+
+// WithKey sets the key of the lock held by the leader.
+func WithKey(key string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.key = key
+	})
+}
+
+// WithInstanceID sets the ID of this instance, stored in the lock when this instance is the leader.
+func WithInstanceID(instanceID string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.instanceID = instanceID
+	})
+}
+
+// WithSessionTTL sets the time for which the leader holds the lock without renewing it.
+func WithSessionTTL(ttl time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.sessionTTL = ttl
+	})
+}
+
+// WithLockDelay sets the time after the leader loses the lock before another instance can obtain it.
+func WithLockDelay(delay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.lockDelay = delay
+	})
+}
+
+// WithTimeout sets the timeout for requests to Consul.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:   zerolog.GlobalLevel(),
+		key:        "vouch/leader",
+		sessionTTL: 15 * time.Second,
+		lockDelay:  30 * time.Second,
+		timeout:    2 * time.Second,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.address == "" {
+		return nil, errors.New("no address specified")
+	}
+	if parameters.key == "" {
+		return nil, errors.New("no key specified")
+	}
+	if parameters.instanceID == "" {
+		return nil, errors.New("no instance ID specified")
+	}
+	if parameters.sessionTTL < 10*time.Second {
+		// Consul does not accept session TTLs below 10 seconds.
+		return nil, errors.New("session TTL must be at least 10s")
+	}
+	if parameters.lockDelay < 0 {
+		return nil, errors.New("lock delay cannot be negative")
+	}
+	if parameters.timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	if parameters.timeout >= parameters.sessionTTL/3 {
+		return nil, errors.New("timeout must be less than a third of the session TTL")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,252 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/coordination"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a coordination service that elects a leader using a Consul lock.
+type Service struct {
+	address    string
+	token      string
+	key        string
+	instanceID string
+	sessionTTL time.Duration
+	lockDelay  time.Duration
+	timeout    time.Duration
+	client     *http.Client
+
+	// sessionID is only accessed by the election loop.
+	sessionID string
+
+	leaderMu    sync.RWMutex
+	leader      bool
+	leaderUntil time.Time
+	// lockSession and lockIndex identify the lock with which this instance
+	// was elected, to fence signing against a lock that has since changed.
+	lockSession string
+	lockIndex   uint64
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new Consul coordination service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "coordination").Str("impl", "consul").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		address:    strings.TrimSuffix(parameters.address, "/"),
+		token:      parameters.token,
+		key:        strings.TrimPrefix(parameters.key, "/"),
+		instanceID: parameters.instanceID,
+		sessionTTL: parameters.sessionTTL,
+		lockDelay:  parameters.lockDelay,
+		timeout:    parameters.timeout,
+		client:     util.NewHTTPClient(parameters.timeout),
+	}
+	monitorLeader(false, false)
+
+	go s.run(ctx)
+
+	return s, nil
+}
+
+// IsLeader returns true if this instance is the leader.
+// Leadership lapses if the lock is not renewed within the session TTL, so an
+// instance that cannot reach Consul stops acting as the leader before another
+// instance can obtain the lock.
+func (s *Service) IsLeader(_ context.Context) bool {
+	s.leaderMu.RLock()
+	defer s.leaderMu.RUnlock()
+
+	return s.leader && time.Now().Before(s.leaderUntil)
+}
+
+// VerifyLeader confirms with Consul that the lock is still held by the session
+// and at the lock index with which this instance was elected.  If it is not
+// then this instance stops acting as the leader immediately, rather than when
+// its leadership lapses.
+func (s *Service) VerifyLeader(ctx context.Context) error {
+	s.leaderMu.RLock()
+	leader := s.leader && time.Now().Before(s.leaderUntil)
+	lockSession := s.lockSession
+	lockIndex := s.lockIndex
+	s.leaderMu.RUnlock()
+	if !leader {
+		return coordination.ErrNotLeader
+	}
+
+	entry, err := s.lockEntry(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain lock")
+	}
+	if entry == nil || entry.Session != lockSession || entry.LockIndex != lockIndex {
+		log.Warn().Uint64("lock_index", lockIndex).Msg("Lock no longer held")
+		s.setLeader(false, time.Time{}, nil)
+		return coordination.ErrNotLeader
+	}
+
+	return nil
+}
+
+// run runs the election loop until the context is cancelled.
+func (s *Service) run(ctx context.Context) {
+	// Renew well within the session TTL, to allow for failed requests.
+	ticker := time.NewTicker(s.sessionTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		s.elect(ctx)
+		select {
+		case <-ctx.Done():
+			s.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// elect attempts to obtain or retain the lock.
+func (s *Service) elect(ctx context.Context) {
+	// Leadership is only valid for the session TTL from before the session is
+	// renewed, as Consul may expire the session any time after that.
+	started := time.Now()
+	s.lapse(started)
+
+	if s.sessionID == "" {
+		sessionID, err := s.createSession(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to create session")
+			return
+		}
+		s.sessionID = sessionID
+		log.Trace().Str("session", sessionID).Msg("Created session")
+	} else {
+		alive, err := s.renewSession(ctx, s.sessionID)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to renew session")
+			return
+		}
+		if !alive {
+			log.Warn().Str("session", s.sessionID).Msg("Session expired")
+			s.sessionID = ""
+			s.setLeader(false, time.Time{}, nil)
+			return
+		}
+	}
+
+	acquired, err := s.acquire(ctx, s.sessionID)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to acquire lock")
+		return
+	}
+	if !acquired {
+		s.setLeader(false, time.Time{}, nil)
+		return
+	}
+
+	// Confirm that the lock is held by this session before acting as leader.
+	entry, err := s.lockEntry(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain lock")
+		return
+	}
+	if entry == nil || entry.Session != s.sessionID {
+		s.setLeader(false, time.Time{}, nil)
+		return
+	}
+
+	s.setLeader(true, started.Add(s.sessionTTL), entry)
+}
+
+// lapse stops this instance acting as the leader if its leadership has expired.
+func (s *Service) lapse(now time.Time) {
+	s.leaderMu.RLock()
+	expired := s.leader && !now.Before(s.leaderUntil)
+	s.leaderMu.RUnlock()
+
+	if expired {
+		s.setLeader(false, time.Time{}, nil)
+	}
+}
+
+// setLeader sets the leadership of this instance, along with the lock entry
+// that confers it.
+func (s *Service) setLeader(leader bool, until time.Time, entry *kvEntry) {
+	s.leaderMu.Lock()
+	changed := s.leader != leader
+	s.leader = leader
+	s.leaderUntil = until
+	s.lockSession = ""
+	s.lockIndex = 0
+	if entry != nil {
+		s.lockSession = entry.Session
+		s.lockIndex = entry.LockIndex
+	}
+	lockIndex := s.lockIndex
+	s.leaderMu.Unlock()
+
+	if changed {
+		if leader {
+			log.Info().Uint64("lock_index", lockIndex).Msg("Elected leader")
+		} else {
+			log.Warn().Msg("No longer leader")
+		}
+	}
+	monitorLeader(leader, changed)
+}
+
+// resign gives up the lock, allowing another instance to become the leader.
+func (s *Service) resign() {
+	s.setLeader(false, time.Time{}, nil)
+	if s.sessionID == "" {
+		return
+	}
+
+	// The service context has been cancelled, so use a new context.
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.release(ctx, s.sessionID); err != nil {
+		log.Warn().Err(err).Msg("Failed to release lock")
+	}
+	if err := s.destroySession(ctx, s.sessionID); err != nil {
+		log.Warn().Err(err).Msg("Failed to destroy session")
+	}
+	s.sessionID = ""
+}
@@ -0,0 +1,214 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/coordination"
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsul implements the parts of the Consul API used for leader election.
+type fakeConsul struct {
+	mu        sync.Mutex
+	sessions  map[string]bool
+	nextID    int
+	holder    string
+	value     []byte
+	lockIndex uint64
+}
+
+func newFakeConsul() *fakeConsul {
+	return &fakeConsul{
+		sessions: make(map[string]bool),
+	}
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/v1/session/create":
+		f.nextID++
+		id := fmt.Sprintf("session-%d", f.nextID)
+		f.sessions[id] = true
+		_ = json.NewEncoder(w).Encode(&sessionResponse{ID: id})
+	case strings.HasPrefix(r.URL.Path, "/v1/session/renew/"):
+		if !f.sessions[strings.TrimPrefix(r.URL.Path, "/v1/session/renew/")] {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case strings.HasPrefix(r.URL.Path, "/v1/session/destroy/"):
+		id := strings.TrimPrefix(r.URL.Path, "/v1/session/destroy/")
+		delete(f.sessions, id)
+		if f.holder == id {
+			f.holder = ""
+		}
+		_, _ = w.Write([]byte("true"))
+	case strings.HasPrefix(r.URL.Path, "/v1/kv/") && r.Method == http.MethodGet:
+		if f.value == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]*kvEntry{{LockIndex: f.lockIndex, Session: f.holder, Value: f.value}})
+	case strings.HasPrefix(r.URL.Path, "/v1/kv/") && r.URL.Query().Has("acquire"):
+		id := r.URL.Query().Get("acquire")
+		if !f.sessions[id] || (f.holder != "" && f.holder != id) {
+			_, _ = w.Write([]byte("false"))
+			return
+		}
+		if f.holder != id {
+			f.lockIndex++
+		}
+		f.holder = id
+		f.value, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("true"))
+	case strings.HasPrefix(r.URL.Path, "/v1/kv/") && r.URL.Query().Has("release"):
+		if f.holder != r.URL.Query().Get("release") {
+			_, _ = w.Write([]byte("false"))
+			return
+		}
+		f.holder = ""
+		_, _ = w.Write([]byte("true"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// expire expires all sessions, as Consul would if they were not renewed.
+func (f *fakeConsul) expire() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sessions = make(map[string]bool)
+	f.holder = ""
+}
+
+func newTestService(address string, instanceID string) *Service {
+	return &Service{
+		address:    address,
+		key:        "vouch/leader",
+		instanceID: instanceID,
+		sessionTTL: 15 * time.Second,
+		lockDelay:  0,
+		timeout:    time.Second,
+		client:     util.NewHTTPClient(time.Second),
+	}
+}
+
+func TestElection(t *testing.T) {
+	ctx := context.Background()
+
+	consul := newFakeConsul()
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	s1 := newTestService(server.URL, "instance-1")
+	s2 := newTestService(server.URL, "instance-2")
+
+	// First instance obtains the lock.
+	s1.elect(ctx)
+	s2.elect(ctx)
+	require.True(t, s1.IsLeader(ctx))
+	require.False(t, s2.IsLeader(ctx))
+
+	// Renewal keeps the first instance as leader.
+	s1.elect(ctx)
+	s2.elect(ctx)
+	require.True(t, s1.IsLeader(ctx))
+	require.False(t, s2.IsLeader(ctx))
+
+	// Second instance takes over when the first resigns.
+	s1.resign()
+	require.False(t, s1.IsLeader(ctx))
+	s2.elect(ctx)
+	s1.elect(ctx)
+	require.False(t, s1.IsLeader(ctx))
+	require.True(t, s2.IsLeader(ctx))
+
+	// Losing the session stands the leader down.
+	consul.expire()
+	s2.elect(ctx)
+	require.False(t, s2.IsLeader(ctx))
+	// First instance notices its session has expired, then obtains the lock with a new session.
+	s1.elect(ctx)
+	require.False(t, s1.IsLeader(ctx))
+	s1.elect(ctx)
+	require.True(t, s1.IsLeader(ctx))
+}
+
+func TestElectionUnreachable(t *testing.T) {
+	ctx := context.Background()
+
+	consul := newFakeConsul()
+	server := httptest.NewServer(consul)
+
+	s := newTestService(server.URL, "instance-1")
+	s.elect(ctx)
+	require.True(t, s.IsLeader(ctx))
+
+	// Leadership is retained until it lapses, even if Consul is unreachable.
+	server.Close()
+	s.elect(ctx)
+	require.True(t, s.IsLeader(ctx))
+
+	s.leaderMu.Lock()
+	s.leaderUntil = time.Now().Add(-time.Second)
+	s.leaderMu.Unlock()
+	require.False(t, s.IsLeader(ctx))
+	s.elect(ctx)
+	require.False(t, s.IsLeader(ctx))
+}
+
+func TestVerifyLeader(t *testing.T) {
+	ctx := context.Background()
+
+	consul := newFakeConsul()
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	s1 := newTestService(server.URL, "instance-1")
+	s2 := newTestService(server.URL, "instance-2")
+
+	s1.elect(ctx)
+	s2.elect(ctx)
+	require.NoError(t, s1.VerifyLeader(ctx))
+	require.ErrorIs(t, s2.VerifyLeader(ctx), coordination.ErrNotLeader)
+
+	// Another instance obtains the lock before the first notices that its
+	// session has expired.
+	consul.expire()
+	s2.elect(ctx)
+	s2.elect(ctx)
+	require.True(t, s2.IsLeader(ctx))
+	require.True(t, s1.IsLeader(ctx))
+	require.ErrorIs(t, s1.VerifyLeader(ctx), coordination.ErrNotLeader)
+	require.False(t, s1.IsLeader(ctx))
+	require.NoError(t, s2.VerifyLeader(ctx))
+
+	// Leadership cannot be verified if Consul is unreachable.
+	server.Close()
+	require.ErrorContains(t, s2.VerifyLeader(ctx), "failed to obtain lock")
+}
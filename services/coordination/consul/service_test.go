@@ -0,0 +1,141 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/coordination/consul"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		params []consul.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nil),
+				consul.WithAddress("http://localhost:8500"),
+				consul.WithInstanceID("test"),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "AddressMissing",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithInstanceID("test"),
+			},
+			err: "problem with parameters: no address specified",
+		},
+		{
+			name: "KeyMissing",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:8500"),
+				consul.WithKey(""),
+				consul.WithInstanceID("test"),
+			},
+			err: "problem with parameters: no key specified",
+		},
+		{
+			name: "InstanceIDMissing",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:8500"),
+			},
+			err: "problem with parameters: no instance ID specified",
+		},
+		{
+			name: "SessionTTLTooShort",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:8500"),
+				consul.WithInstanceID("test"),
+				consul.WithSessionTTL(5 * time.Second),
+			},
+			err: "problem with parameters: session TTL must be at least 10s",
+		},
+		{
+			name: "LockDelayNegative",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:8500"),
+				consul.WithInstanceID("test"),
+				consul.WithLockDelay(-1 * time.Second),
+			},
+			err: "problem with parameters: lock delay cannot be negative",
+		},
+		{
+			name: "TimeoutZero",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:8500"),
+				consul.WithInstanceID("test"),
+				consul.WithTimeout(0),
+			},
+			err: "problem with parameters: timeout must be positive",
+		},
+		{
+			name: "TimeoutTooLong",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:8500"),
+				consul.WithInstanceID("test"),
+				consul.WithSessionTTL(15 * time.Second),
+				consul.WithTimeout(5 * time.Second),
+			},
+			err: "problem with parameters: timeout must be less than a third of the session TTL",
+		},
+		{
+			name: "Good",
+			params: []consul.Parameter{
+				consul.WithLogLevel(zerolog.Disabled),
+				consul.WithMonitor(nullmetrics.New(ctx)),
+				consul.WithAddress("http://localhost:1"),
+				consul.WithInstanceID("test"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := consul.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mock is a mock coordination service.
+package mock
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/attestantio/vouch/services/coordination"
+)
+
+// Service is a mock coordination service.
+type Service struct {
+	leader atomic.Bool
+}
+
+// New creates a new mock coordination service, leader as specified.
+func New(leader bool) *Service {
+	s := &Service{}
+	s.leader.Store(leader)
+
+	return s
+}
+
+// SetLeader sets whether this instance is the leader.
+func (s *Service) SetLeader(leader bool) {
+	s.leader.Store(leader)
+}
+
+// IsLeader returns true if this instance is the leader.
+func (s *Service) IsLeader(_ context.Context) bool {
+	return s.leader.Load()
+}
+
+// VerifyLeader returns an error if this instance is not the leader.
+func (s *Service) VerifyLeader(_ context.Context) error {
+	if !s.leader.Load() {
+		return coordination.ErrNotLeader
+	}
+
+	return nil
+}
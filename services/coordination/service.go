@@ -0,0 +1,36 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package coordination coordinates multiple Vouch instances that share the same
+// validators, so that only one of them carries out duties at any time.
+package coordination
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotLeader is returned when this instance is not the leader.
+var ErrNotLeader = errors.New("not the leader")
+
+// Service is the coordination service.
+type Service interface {
+	// IsLeader returns true if this instance is the leader, and so can carry out duties.
+	IsLeader(ctx context.Context) bool
+
+	// VerifyLeader confirms with the coordination backend that this instance
+	// still holds the leadership it was elected with, returning ErrNotLeader
+	// if it does not.  It should be called immediately before signing, as
+	// leadership can be lost between the start of a duty and its signature.
+	VerifyLeader(ctx context.Context) error
+}
@@ -0,0 +1,33 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package doppelganger detects other instances validating with the same keys.
+package doppelganger
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service is the doppelgänger detection service.
+type Service interface {
+	// Blacklisted returns true if duties for the validator with the given public key
+	// must not be carried out in the given epoch, either because detection has yet to
+	// complete or because another instance has been seen validating with its key.
+	Blacklisted(ctx context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool
+
+	// Detected returns the public keys of the validators for which another instance
+	// has been seen validating.
+	Detected(ctx context.Context) []phase0.BLSPubKey
+}
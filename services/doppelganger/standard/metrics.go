@@ -0,0 +1,94 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	validatorsGauge  *prometheus.GaugeVec
+	detectionCounter prometheus.Counter
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if validatorsGauge != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	validatorsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "doppelganger",
+		Name:      "validators",
+		Help:      "The number of validators in each doppelgänger detection state.",
+	}, []string{"state"})
+	if err := prometheus.Register(validatorsGauge); err != nil {
+		return err
+	}
+
+	detectionCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "doppelganger",
+		Name:      "detections_total",
+		Help:      "The number of validators seen validating elsewhere.",
+	})
+	return prometheus.Register(detectionCounter)
+}
+
+// updateMetrics updates the counts of validators in each detection state.
+func (s *Service) updateMetrics() {
+	if validatorsGauge == nil {
+		return
+	}
+
+	s.mu.RLock()
+	pending := 0
+	clear := 0
+	detected := 0
+	for _, w := range s.watches {
+		switch {
+		case w.detected:
+			detected++
+		case s.nextScanEpoch < w.start+s.epochs:
+			pending++
+		default:
+			clear++
+		}
+	}
+	s.mu.RUnlock()
+
+	validatorsGauge.WithLabelValues("pending").Set(float64(pending))
+	validatorsGauge.WithLabelValues("clear").Set(float64(clear))
+	validatorsGauge.WithLabelValues("detected").Set(float64(detected))
+}
+
+func monitorDetection() {
+	if detectionCounter != nil {
+		detectionCounter.Inc()
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// scanEpoch scans the blocks of the given epoch for proposals and attestations made
+// by watched validators within their detection windows.
+// Attestations can be included in the epoch after that which they target, so a
+// validator's blocks are scanned for one epoch beyond the end of its window.
+func (s *Service) scanEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	s.mu.RLock()
+	windows := make(map[phase0.BLSPubKey]phase0.Epoch)
+	for pubKey, w := range s.watches {
+		if w.detected || w.start > epoch || w.start+s.epochs < epoch {
+			continue
+		}
+		windows[pubKey] = w.start
+	}
+	s.mu.RUnlock()
+
+	if len(windows) == 0 {
+		log.Trace().Uint64("epoch", uint64(epoch)).Msg("No validators in detection window; not scanning")
+		return nil
+	}
+
+	pubKeys := make([]phase0.BLSPubKey, 0, len(windows))
+	for pubKey := range windows {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	validators := make(map[phase0.ValidatorIndex]phase0.BLSPubKey)
+	for index, validator := range s.validatorsManager.ValidatorsByPubKey(ctx, pubKeys) {
+		validators[index] = validator.PublicKey
+	}
+	if len(validators) == 0 {
+		log.Trace().Uint64("epoch", uint64(epoch)).Msg("No watched validators known to the chain; not scanning")
+		return nil
+	}
+
+	inWindow := func(pubKey phase0.BLSPubKey, target phase0.Epoch) bool {
+		start := windows[pubKey]
+		return target >= start && target < start+s.epochs
+	}
+
+	committees := make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	log.Trace().Uint64("epoch", uint64(epoch)).Int("validators", len(validators)).Msg("Scanning epoch for doppelgängers")
+	for slot := s.chainTime.FirstSlotOfEpoch(epoch); slot < s.chainTime.FirstSlotOfEpoch(epoch+1); slot++ {
+		blockResponse, err := s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// Empty slot.
+				continue
+			}
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if blockResponse == nil || blockResponse.Data == nil {
+			// Empty slot.
+			continue
+		}
+
+		proposerIndex, err := blockResponse.Data.ProposerIndex()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain proposer index")
+		}
+		if pubKey, exists := validators[proposerIndex]; exists && inWindow(pubKey, epoch) {
+			s.detected(pubKey, slot, "proposal")
+		}
+
+		attestations, err := blockResponse.Data.Attestations()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestations")
+		}
+		for _, attestation := range attestations {
+			target := attestation.Data.Target.Epoch
+			if _, exists := committees[target]; !exists {
+				committees[target], err = s.epochCommittees(ctx, target)
+				if err != nil {
+					return err
+				}
+			}
+			committee, exists := committees[target][attestation.Data.Slot][attestation.Data.Index]
+			if !exists {
+				continue
+			}
+			for i := uint64(0); i < attestation.AggregationBits.Len() && i < uint64(len(committee)); i++ {
+				if !attestation.AggregationBits.BitAt(i) {
+					continue
+				}
+				if pubKey, exists := validators[committee[i]]; exists && inWindow(pubKey, target) {
+					s.detected(pubKey, attestation.Data.Slot, "attestation")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// epochCommittees obtains the beacon committees for the given epoch.
+func (s *Service) epochCommittees(ctx context.Context,
+	epoch phase0.Epoch,
+) (
+	map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex,
+	error,
+) {
+	committeesResponse, err := s.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: "head",
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain beacon committees for epoch %d", epoch))
+	}
+
+	committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	for _, committee := range committeesResponse.Data {
+		if _, exists := committees[committee.Slot]; !exists {
+			committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		committees[committee.Slot][committee.Index] = committee.Validators
+	}
+
+	return committees, nil
+}
+
+// detected marks a validator as having been seen validating elsewhere.
+func (s *Service) detected(pubKey phase0.BLSPubKey, slot phase0.Slot, activity string) {
+	s.mu.Lock()
+	w := s.watches[pubKey]
+	alreadyDetected := w.detected
+	w.detected = true
+	s.mu.Unlock()
+
+	if !alreadyDetected {
+		log.Error().
+			Str("public_key", fmt.Sprintf("%#x", pubKey)).
+			Uint64("slot", uint64(slot)).
+			Str("activity", activity).
+			Msg("Doppelgänger detected; validator is active elsewhere and its duties are suspended")
+		monitorDetection()
+	}
+}
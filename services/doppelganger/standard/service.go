@@ -0,0 +1,203 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/validatorsmanager"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// watch is the doppelgänger detection state for a single validator.
+type watch struct {
+	// start is the first epoch of the detection window.
+	start phase0.Epoch
+	// detected is true if another instance has been seen validating.
+	detected bool
+	// suppressed is the latest epoch for which suppression of duties has been logged.
+	suppressed phase0.Epoch
+}
+
+// Service watches the chain for activity by validators before allowing them to
+// carry out duties.
+// Each validator is watched from the epoch after Vouch first learns of it; if it is
+// seen attesting or proposing in the following epochs its duties are suspended
+// until Vouch is restarted.
+type Service struct {
+	chainTime                 chaintime.Service
+	validatorsManager         validatorsmanager.Service
+	beaconCommitteesProvider  eth2client.BeaconCommitteesProvider
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+	epochs                    phase0.Epoch
+	excluded                  map[phase0.BLSPubKey]struct{}
+
+	mu            sync.RWMutex
+	watches       map[phase0.BLSPubKey]*watch
+	nextScanEpoch phase0.Epoch
+	scanFailed    bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new doppelgänger detection service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "doppelganger").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	excluded := make(map[phase0.BLSPubKey]struct{}, len(parameters.excludedValidators))
+	for _, pubKey := range parameters.excludedValidators {
+		excluded[pubKey] = struct{}{}
+	}
+
+	s := &Service{
+		chainTime:                 parameters.chainTime,
+		validatorsManager:         parameters.validatorsManager,
+		beaconCommitteesProvider:  parameters.beaconCommitteesProvider,
+		signedBeaconBlockProvider: parameters.signedBeaconBlockProvider,
+		epochs:                    phase0.Epoch(parameters.epochs),
+		excluded:                  excluded,
+		watches:                   make(map[phase0.BLSPubKey]*watch),
+		nextScanEpoch:             parameters.chainTime.CurrentEpoch() + 1,
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"doppelganger",
+		"Doppelgänger detection",
+		s.scanRuntime,
+		nil,
+		s.scanJob,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start doppelgänger detection")
+	}
+	log.Info().Uint64("epochs", parameters.epochs).Msg("Doppelgänger detection enabled; duties will not start until detection completes")
+
+	return s, nil
+}
+
+// Blacklisted returns true if duties for the validator with the given public key
+// must not be carried out in the given epoch, either because detection has yet to
+// complete or because another instance has been seen validating with its key.
+// Validators are watched from the epoch after the first call for their key.
+func (s *Service) Blacklisted(_ context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool {
+	if _, excluded := s.excluded[pubKey]; excluded {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, exists := s.watches[pubKey]
+	if !exists {
+		w = &watch{
+			start: s.chainTime.CurrentEpoch() + 1,
+		}
+		s.watches[pubKey] = w
+		log.Trace().Str("public_key", fmt.Sprintf("%#x", pubKey)).Uint64("start_epoch", uint64(w.start)).Msg("Watching validator")
+	}
+
+	if w.detected {
+		if w.suppressed < epoch {
+			w.suppressed = epoch
+			log.Error().
+				Str("public_key", fmt.Sprintf("%#x", pubKey)).
+				Uint64("epoch", uint64(epoch)).
+				Msg("Suppressing duties for validator seen validating elsewhere")
+		}
+		return true
+	}
+
+	// Duties can start once the blocks of the detection window have been scanned.
+	end := w.start + s.epochs
+	return epoch < end || s.nextScanEpoch < end
+}
+
+// Detected returns the public keys of the validators for which another instance
+// has been seen validating.
+func (s *Service) Detected(_ context.Context) []phase0.BLSPubKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	detected := make([]phase0.BLSPubKey, 0)
+	for pubKey, w := range s.watches {
+		if w.detected {
+			detected = append(detected, pubKey)
+		}
+	}
+
+	return detected
+}
+
+func (s *Service) scanRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	s.mu.RLock()
+	nextScanEpoch := s.nextScanEpoch
+	scanFailed := s.scanFailed
+	s.mu.RUnlock()
+
+	if scanFailed {
+		// Retry in the next slot.
+		return s.chainTime.StartOfSlot(s.chainTime.CurrentSlot() + 1), nil
+	}
+
+	// Scan an epoch once the first slot of the following epoch has passed, allowing
+	// for the final block of the epoch to arrive.
+	return s.chainTime.StartOfSlot(s.chainTime.FirstSlotOfEpoch(nextScanEpoch+1) + 1), nil
+}
+
+func (s *Service) scanJob(ctx context.Context, _ interface{}) {
+	s.mu.RLock()
+	epoch := s.nextScanEpoch
+	s.mu.RUnlock()
+
+	err := s.scanEpoch(ctx, epoch)
+
+	s.mu.Lock()
+	if err != nil {
+		log.Error().Err(err).Uint64("epoch", uint64(epoch)).Msg("Failed to scan epoch for doppelgängers; will retry")
+		s.scanFailed = true
+	} else {
+		s.scanFailed = false
+		s.nextScanEpoch = epoch + 1
+	}
+	s.mu.Unlock()
+
+	s.updateMetrics()
+}
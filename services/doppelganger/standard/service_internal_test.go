@@ -0,0 +1,398 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// validatorsManager knows about a fixed set of validators.
+type validatorsManager struct {
+	validators map[phase0.ValidatorIndex]*phase0.Validator
+}
+
+func (*validatorsManager) RefreshValidatorsFromBeaconNode(_ context.Context, _ []phase0.BLSPubKey) error {
+	return nil
+}
+
+func (m *validatorsManager) ValidatorsByIndex(_ context.Context, indices []phase0.ValidatorIndex) map[phase0.ValidatorIndex]*phase0.Validator {
+	res := make(map[phase0.ValidatorIndex]*phase0.Validator)
+	for _, index := range indices {
+		if validator, exists := m.validators[index]; exists {
+			res[index] = validator
+		}
+	}
+	return res
+}
+
+func (m *validatorsManager) ValidatorsByPubKey(_ context.Context, pubKeys []phase0.BLSPubKey) map[phase0.ValidatorIndex]*phase0.Validator {
+	res := make(map[phase0.ValidatorIndex]*phase0.Validator)
+	for index, validator := range m.validators {
+		for _, pubKey := range pubKeys {
+			if validator.PublicKey == pubKey {
+				res[index] = validator
+			}
+		}
+	}
+	return res
+}
+
+func (*validatorsManager) ValidatorStateAtEpoch(_ context.Context, _ phase0.ValidatorIndex, _ phase0.Epoch) (apiv1.ValidatorState, error) {
+	return apiv1.ValidatorStateActiveOngoing, nil
+}
+
+// committeesProvider provides a single committee for every slot.
+type committeesProvider struct {
+	chainTime  *standardchaintime.Service
+	validators []phase0.ValidatorIndex
+}
+
+func (p *committeesProvider) BeaconCommittees(_ context.Context,
+	opts *api.BeaconCommitteesOpts,
+) (
+	*api.Response[[]*apiv1.BeaconCommittee],
+	error,
+) {
+	committees := make([]*apiv1.BeaconCommittee, 0)
+	for slot := p.chainTime.FirstSlotOfEpoch(*opts.Epoch); slot < p.chainTime.FirstSlotOfEpoch(*opts.Epoch+1); slot++ {
+		committees = append(committees, &apiv1.BeaconCommittee{
+			Slot:       slot,
+			Index:      0,
+			Validators: p.validators,
+		})
+	}
+
+	return &api.Response[[]*apiv1.BeaconCommittee]{
+		Data: committees,
+	}, nil
+}
+
+// blocksProvider provides blocks for a fixed set of slots.
+type blocksProvider struct {
+	blocks map[phase0.Slot]*phase0.SignedBeaconBlock
+}
+
+func (p *blocksProvider) SignedBeaconBlock(_ context.Context,
+	opts *api.SignedBeaconBlockOpts,
+) (
+	*api.Response[*spec.VersionedSignedBeaconBlock],
+	error,
+) {
+	for slot, block := range p.blocks {
+		if opts.Block == fmt.Sprintf("%d", slot) {
+			return &api.Response[*spec.VersionedSignedBeaconBlock]{
+				Data: &spec.VersionedSignedBeaconBlock{
+					Version: spec.DataVersionPhase0,
+					Phase0:  block,
+				},
+			}, nil
+		}
+	}
+
+	return nil, &api.Error{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+func block(slot phase0.Slot, proposer phase0.ValidatorIndex, attestations ...*phase0.Attestation) *phase0.SignedBeaconBlock {
+	return &phase0.SignedBeaconBlock{
+		Message: &phase0.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			Body: &phase0.BeaconBlockBody{
+				Attestations: attestations,
+			},
+		},
+	}
+}
+
+func attestation(slot phase0.Slot, target phase0.Epoch, bits ...uint64) *phase0.Attestation {
+	aggregationBits := bitfield.NewBitlist(4)
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+
+	return &phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Index:  0,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{
+				Epoch: target,
+			},
+		},
+	}
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ValidatorsManagerMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+			},
+			err: "problem with parameters: no validators manager specified",
+		},
+		{
+			name: "BeaconCommitteesProviderMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+			},
+			err: "problem with parameters: no beacon committees provider specified",
+		},
+		{
+			name: "SignedBeaconBlockProviderMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+			},
+			err: "problem with parameters: no signed beacon block provider specified",
+		},
+		{
+			name: "EpochsZero",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				WithEpochs(0),
+			},
+			err: "problem with parameters: no epochs specified",
+		},
+		{
+			name: "Good",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(mock.NewValidatorsManager()),
+				WithBeaconCommitteesProvider(&committeesProvider{}),
+				WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDetection(t *testing.T) {
+	ctx := context.Background()
+
+	// Start in the middle of epoch 10.
+	genesisTime := time.Now().Add(-(10*32 + 16) * 12 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(10), chainTime.CurrentEpoch())
+
+	pubKeys := []phase0.BLSPubKey{{0x01}, {0x02}, {0x03}, {0x04}}
+	validators := &validatorsManager{
+		validators: make(map[phase0.ValidatorIndex]*phase0.Validator),
+	}
+	for i, pubKey := range pubKeys {
+		validators.validators[phase0.ValidatorIndex(i)] = &phase0.Validator{PublicKey: pubKey}
+	}
+	committees := &committeesProvider{
+		chainTime:  chainTime,
+		validators: []phase0.ValidatorIndex{0, 1, 2, 3},
+	}
+
+	tests := []struct {
+		name     string
+		blocks   map[phase0.Slot]*phase0.SignedBeaconBlock
+		excluded []phase0.BLSPubKey
+		detected []phase0.BLSPubKey
+	}{
+		{
+			name:   "NoBlocks",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{},
+		},
+		{
+			name: "Proposal",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				11*32 + 3: block(11*32+3, 1),
+			},
+			detected: []phase0.BLSPubKey{pubKeys[1]},
+		},
+		{
+			name: "Attestation",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				12*32 + 5: block(12*32+5, 10, attestation(12*32+4, 12, 2)),
+			},
+			detected: []phase0.BLSPubKey{pubKeys[2]},
+		},
+		{
+			name: "AttestationIncludedAfterWindow",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				13*32 + 1: block(13*32+1, 10, attestation(12*32+31, 12, 3)),
+			},
+			detected: []phase0.BLSPubKey{pubKeys[3]},
+		},
+		{
+			name: "AttestationBeforeWindow",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				11*32 + 1: block(11*32+1, 10, attestation(10*32+31, 10, 0, 1, 2, 3)),
+			},
+		},
+		{
+			name: "Excluded",
+			blocks: map[phase0.Slot]*phase0.SignedBeaconBlock{
+				11*32 + 3: block(11*32+3, 1),
+			},
+			excluded: []phase0.BLSPubKey{pubKeys[1]},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithScheduler(mockscheduler.New()),
+				WithValidatorsManager(validators),
+				WithBeaconCommitteesProvider(committees),
+				WithSignedBeaconBlockProvider(&blocksProvider{blocks: test.blocks}),
+				WithEpochs(2),
+				WithExcludedValidators(test.excluded),
+			)
+			require.NoError(t, err)
+
+			// Validators are watched from epoch 11 and blocked until their window has been scanned.
+			for _, pubKey := range pubKeys {
+				if len(test.excluded) > 0 && pubKey == test.excluded[0] {
+					require.False(t, s.Blacklisted(ctx, 11, pubKey))
+					continue
+				}
+				require.True(t, s.Blacklisted(ctx, 11, pubKey))
+				require.True(t, s.Blacklisted(ctx, 13, pubKey))
+			}
+
+			// Scan epochs 11 through 13.
+			for i := 0; i < 3; i++ {
+				s.scanJob(ctx, nil)
+			}
+			require.Equal(t, phase0.Epoch(14), s.nextScanEpoch)
+
+			// Duties are released from the end of the window unless a doppelgänger was detected.
+			require.ElementsMatch(t, test.detected, s.Detected(ctx))
+			for _, pubKey := range pubKeys {
+				detected := false
+				for _, detectedPubKey := range test.detected {
+					if pubKey == detectedPubKey {
+						detected = true
+					}
+				}
+				require.Equal(t, detected, s.Blacklisted(ctx, 13, pubKey))
+			}
+		})
+	}
+}
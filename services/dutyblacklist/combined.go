@@ -0,0 +1,59 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dutyblacklist
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type combined struct {
+	services []Service
+}
+
+// Combine returns a duty blacklist that suspends duties for a validator if any of
+// the supplied blacklists do.  Nil blacklists are ignored, and nil is returned if
+// no blacklists remain.
+func Combine(services ...Service) Service {
+	members := make([]Service, 0, len(services))
+	for _, service := range services {
+		if service != nil {
+			members = append(members, service)
+		}
+	}
+
+	switch len(members) {
+	case 0:
+		return nil
+	case 1:
+		return members[0]
+	default:
+		return &combined{services: members}
+	}
+}
+
+// Blacklisted returns true if duties for the validator with the given public key
+// are suspended in the given epoch by any of the blacklists.
+func (c *combined) Blacklisted(ctx context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool {
+	blacklisted := false
+	for _, service := range c.services {
+		// Consult every blacklist, as blacklists may track the validators about which they are asked.
+		if service.Blacklisted(ctx, epoch, pubKey) {
+			blacklisted = true
+		}
+	}
+
+	return blacklisted
+}
@@ -0,0 +1,46 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dutyblacklist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyblacklist"
+	"github.com/stretchr/testify/require"
+)
+
+type staticBlacklist map[phase0.BLSPubKey]struct{}
+
+func (b staticBlacklist) Blacklisted(_ context.Context, _ phase0.Epoch, pubKey phase0.BLSPubKey) bool {
+	_, exists := b[pubKey]
+	return exists
+}
+
+func TestCombine(t *testing.T) {
+	ctx := context.Background()
+
+	require.Nil(t, dutyblacklist.Combine())
+	require.Nil(t, dutyblacklist.Combine(nil, nil))
+
+	first := staticBlacklist{{0x01}: {}}
+	second := staticBlacklist{{0x02}: {}}
+	require.Equal(t, first, dutyblacklist.Combine(nil, first))
+
+	combined := dutyblacklist.Combine(first, nil, second)
+	require.True(t, combined.Blacklisted(ctx, 1, phase0.BLSPubKey{0x01}))
+	require.True(t, combined.Blacklisted(ctx, 1, phase0.BLSPubKey{0x02}))
+	require.False(t, combined.Blacklisted(ctx, 1, phase0.BLSPubKey{0x03}))
+}
@@ -0,0 +1,28 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dutyblacklist provides an externally maintained list of validators for which duties are suspended.
+package dutyblacklist
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service is the duty blacklist service.
+type Service interface {
+	// Blacklisted returns true if duties for the validator with the given public key
+	// are suspended in the given epoch.
+	Blacklisted(ctx context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool
+}
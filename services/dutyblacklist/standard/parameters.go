@@ -0,0 +1,105 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+	"time"
+
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-majordomo"
+)
+
+type parameters struct {
+	logLevel       zerolog.Level
+	majordomo      majordomo.Service
+	scheduler      scheduler.Service
+	location       string
+	reloadInterval time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMajordomo sets majordomo for the module.
+func WithMajordomo(majordomo majordomo.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.majordomo = majordomo
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithLocation sets the location from which to fetch the blacklist.
+func WithLocation(location string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.location = location
+	})
+}
+
+// WithReloadInterval sets the interval between reloads of the blacklist.
+func WithReloadInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.reloadInterval = interval
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:       zerolog.GlobalLevel(),
+		reloadInterval: time.Minute,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.majordomo == nil {
+		return nil, errors.New("no majordomo specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.location == "" {
+		return nil, errors.New("no location specified")
+	}
+	if parameters.reloadInterval <= 0 {
+		return nil, errors.New("reload interval must be positive")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,203 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-majordomo"
+)
+
+// Service provides a duty blacklist fetched from an external location,
+// reloading it periodically.
+type Service struct {
+	majordomo      majordomo.Service
+	location       string
+	reloadInterval time.Duration
+
+	mu          sync.RWMutex
+	blacklisted map[phase0.BLSPubKey]struct{}
+
+	// suppressedMu protects suppressed, which holds the latest epoch for
+	// which the suppression of each validator's duties has been logged.
+	suppressedMu sync.Mutex
+	suppressed   map[phase0.BLSPubKey]phase0.Epoch
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new duty blacklist service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutyblacklist").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		majordomo:      parameters.majordomo,
+		location:       parameters.location,
+		reloadInterval: parameters.reloadInterval,
+		blacklisted:    make(map[phase0.BLSPubKey]struct{}),
+		suppressed:     make(map[phase0.BLSPubKey]phase0.Epoch),
+	}
+
+	// Carry out the initial load inline, as duties must not be carried out
+	// for blacklisted validators.
+	if err := s.reload(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to load blacklist")
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"dutyblacklist",
+		"Reload duty blacklist",
+		s.reloadRuntime,
+		nil,
+		s.reloadJob,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start blacklist reloader")
+	}
+
+	return s, nil
+}
+
+// Blacklisted returns true if duties for the validator with the given public key
+// are suspended in the given epoch.
+func (s *Service) Blacklisted(_ context.Context, epoch phase0.Epoch, pubKey phase0.BLSPubKey) bool {
+	s.mu.RLock()
+	_, blacklisted := s.blacklisted[pubKey]
+	s.mu.RUnlock()
+
+	if !blacklisted {
+		return false
+	}
+
+	// Audit the suppression, once per validator per epoch.
+	s.suppressedMu.Lock()
+	loggedEpoch, logged := s.suppressed[pubKey]
+	if !logged || loggedEpoch < epoch {
+		s.suppressed[pubKey] = epoch
+		log.Info().
+			Str("public_key", fmt.Sprintf("%#x", pubKey)).
+			Uint64("epoch", uint64(epoch)).
+			Msg("Suppressing duties for blacklisted validator")
+	}
+	s.suppressedMu.Unlock()
+
+	return true
+}
+
+func (s *Service) reloadRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return time.Now().Add(s.reloadInterval), nil
+}
+
+func (s *Service) reloadJob(ctx context.Context, _ interface{}) {
+	if err := s.reload(ctx); err != nil {
+		// Retain the existing blacklist, as a missing or damaged list should
+		// not cause duties to restart for blacklisted validators.
+		log.Error().Err(err).Msg("Failed to reload blacklist; retaining existing blacklist")
+	}
+}
+
+// reload fetches the blacklist, replacing the existing blacklist and logging
+// any changes.
+func (s *Service) reload(ctx context.Context) error {
+	data, err := s.majordomo.Fetch(ctx, s.location)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch blacklist")
+	}
+	blacklisted, err := ParseBlacklist(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	for pubKey := range blacklisted {
+		if _, exists := s.blacklisted[pubKey]; !exists {
+			log.Info().Str("public_key", fmt.Sprintf("%#x", pubKey)).Msg("Validator added to duty blacklist")
+		}
+	}
+	for pubKey := range s.blacklisted {
+		if _, exists := blacklisted[pubKey]; !exists {
+			log.Info().Str("public_key", fmt.Sprintf("%#x", pubKey)).Msg("Validator removed from duty blacklist")
+		}
+	}
+	s.blacklisted = blacklisted
+	s.mu.Unlock()
+
+	log.Trace().Int("validators", len(blacklisted)).Msg("Loaded duty blacklist")
+
+	return nil
+}
+
+// ParseBlacklist parses a blacklist.  The blacklist is either a JSON array of
+// public keys, or a list of public keys one per line, with '#' starting a comment.
+func ParseBlacklist(data []byte) (map[phase0.BLSPubKey]struct{}, error) {
+	var entries []string
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, errors.Wrap(err, "invalid JSON blacklist")
+		}
+	} else {
+		entries = make([]string, 0)
+		for _, line := range strings.Split(string(data), "\n") {
+			if comment := strings.Index(line, "#"); comment != -1 {
+				line = line[:comment]
+			}
+			line = strings.TrimSpace(line)
+			if line != "" {
+				entries = append(entries, line)
+			}
+		}
+	}
+
+	blacklisted := make(map[phase0.BLSPubKey]struct{}, len(entries))
+	for _, entry := range entries {
+		tmp, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(entry), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key %q", entry))
+		}
+		if len(tmp) != phase0.PublicKeyLength {
+			return nil, errors.Errorf("incorrect length for public key %q", entry)
+		}
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], tmp)
+		blacklisted[pubKey] = struct{}{}
+	}
+
+	return blacklisted, nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	fileconfidant "github.com/wealdtech/go-majordomo/confidants/file"
+	standardmajordomo "github.com/wealdtech/go-majordomo/standard"
+)
+
+func TestParseBlacklist(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+		err      string
+	}{
+		{
+			name:     "Empty",
+			data:     "",
+			expected: 0,
+		},
+		{
+			name:     "Lines",
+			data:     "# Blacklist\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n\nb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b # Validator 2\n",
+			expected: 2,
+		},
+		{
+			name:     "JSON",
+			data:     `["0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"]`,
+			expected: 1,
+		},
+		{
+			name: "JSONInvalid",
+			data: `["0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"`,
+			err:  "invalid JSON blacklist: unexpected end of JSON input",
+		},
+		{
+			name: "PublicKeyInvalid",
+			data: "0xinvalid",
+			err:  "invalid public key \"0xinvalid\": encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "PublicKeyShort",
+			data: "0x0102",
+			err:  "incorrect length for public key \"0x0102\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := ParseBlacklist([]byte(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.expected)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	fileConfidant, err := fileconfidant.New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, majordomoSvc.RegisterConfidant(ctx, fileConfidant))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blacklist.txt")
+	key1 := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	key2 := "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+	require.NoError(t, os.WriteFile(path, []byte(key1+"\n"), 0o600))
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMajordomo(majordomoSvc),
+		WithScheduler(mockscheduler.New()),
+		WithLocation("file://"+path),
+	)
+	require.NoError(t, err)
+
+	var pubKey1, pubKey2 phase0.BLSPubKey
+	tmp, err := hex.DecodeString(strings.TrimPrefix(key1, "0x"))
+	require.NoError(t, err)
+	copy(pubKey1[:], tmp)
+	tmp, err = hex.DecodeString(strings.TrimPrefix(key2, "0x"))
+	require.NoError(t, err)
+	copy(pubKey2[:], tmp)
+
+	require.True(t, s.Blacklisted(ctx, 1, pubKey1))
+	require.False(t, s.Blacklisted(ctx, 1, pubKey2))
+	require.False(t, s.Blacklisted(ctx, 1, phase0.BLSPubKey{0x01}))
+
+	// Hot reload with an updated list.
+	require.NoError(t, os.WriteFile(path, []byte(key2+"\n"), 0o600))
+	s.reloadJob(ctx, nil)
+	require.False(t, s.Blacklisted(ctx, 2, pubKey1))
+	require.True(t, s.Blacklisted(ctx, 2, pubKey2))
+
+	// A damaged list retains the existing blacklist.
+	require.NoError(t, os.WriteFile(path, []byte("damaged\n"), 0o600))
+	s.reloadJob(ctx, nil)
+	require.True(t, s.Blacklisted(ctx, 3, pubKey2))
+}
@@ -0,0 +1,113 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/dutyblacklist/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	directconfidant "github.com/wealdtech/go-majordomo/confidants/direct"
+	standardmajordomo "github.com/wealdtech/go-majordomo/standard"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	directConfidant, err := directconfidant.New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, majordomoSvc.RegisterConfidant(ctx, directConfidant))
+	scheduler := mockscheduler.New()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MajordomoMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(scheduler),
+				standard.WithLocation("direct://"),
+			},
+			err: "problem with parameters: no majordomo specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithLocation("direct://"),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "LocationMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(scheduler),
+			},
+			err: "problem with parameters: no location specified",
+		},
+		{
+			name: "ReloadIntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(scheduler),
+				standard.WithLocation("direct://"),
+				standard.WithReloadInterval(0),
+			},
+			err: "problem with parameters: reload interval must be positive",
+		},
+		{
+			name: "BlacklistInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(scheduler),
+				standard.WithLocation("direct:///invalid"),
+			},
+			err: "failed to load blacklist: invalid public key \"invalid\": encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMajordomo(majordomoSvc),
+				standard.WithScheduler(scheduler),
+				standard.WithLocation("direct:///0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+				standard.WithReloadInterval(time.Hour),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/attestantio/vouch/services/dutyevents"
+)
+
+// Service is a mock that records the events published.
+type Service struct {
+	mu     sync.Mutex
+	events []*dutyevents.Event
+}
+
+// New creates a new mock duty events service.
+func New() *Service {
+	return &Service{
+		events: make([]*dutyevents.Event, 0),
+	}
+}
+
+// Publish publishes an event.
+func (s *Service) Publish(_ context.Context, event *dutyevents.Event) {
+	s.mu.Lock()
+	s.events = append(s.events, event)
+	s.mu.Unlock()
+}
+
+// Events returns the events published.
+func (s *Service) Events() []*dutyevents.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*dutyevents.Event{}, s.events...)
+}
@@ -0,0 +1,89 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel        zerolog.Level
+	url             string
+	subject         string
+	credentialsFile string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithURL sets the URL of the NATS server.
+func WithURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.url = url
+	})
+}
+
+// WithSubject sets the subject prefix for published events.
+// Events are published to <subject>.<duty>.<stage>.
+func WithSubject(subject string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.subject = subject
+	})
+}
+
+// WithCredentialsFile sets the credentials file used to authenticate with the NATS server.
+// This is optional.
+func WithCredentialsFile(credentialsFile string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.credentialsFile = credentialsFile
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		subject:  "vouch.duties",
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.url == "" {
+		return nil, errors.New("no URL specified")
+	}
+	if parameters.subject == "" {
+		return nil, errors.New("no subject specified")
+	}
+
+	return &parameters, nil
+}
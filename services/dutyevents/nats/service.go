@@ -0,0 +1,109 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service publishes duty events to a NATS server.
+type Service struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new NATS duty events service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutyevents").Str("impl", "nats").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	opts := []nats.Option{
+		nats.Name("vouch"),
+		// Do not stop Vouch from starting if the NATS server is unavailable.
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Warn().Err(err).Msg("Disconnected from NATS server")
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Info().Str("server", conn.ConnectedUrlRedacted()).Msg("Reconnected to NATS server")
+		}),
+	}
+	if parameters.credentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(parameters.credentialsFile))
+	}
+
+	conn, err := nats.Connect(parameters.url, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to NATS server")
+	}
+
+	s := &Service{
+		conn:    conn,
+		subject: parameters.subject,
+	}
+
+	go func(ctx context.Context, conn *nats.Conn) {
+		<-ctx.Done()
+		if err := conn.Drain(); err != nil {
+			log.Debug().Err(err).Msg("Failed to drain NATS connection")
+		}
+	}(ctx, conn)
+
+	return s, nil
+}
+
+// Publish publishes an event.
+func (s *Service) Publish(_ context.Context, event *dutyevents.Event) {
+	if event == nil {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal duty event")
+		return
+	}
+
+	// Publishing is buffered by the client, so does not block.
+	if err := s.conn.Publish(s.subjectFor(event), data); err != nil {
+		log.Warn().Err(err).Str("duty", event.Duty).Str("stage", event.Stage).Msg("Failed to publish duty event")
+	}
+}
+
+// subjectFor returns the subject to which the event is published.
+func (s *Service) subjectFor(event *dutyevents.Event) string {
+	return fmt.Sprintf("%s.%s.%s", s.subject, event.Duty, event.Stage)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/dutyevents/nats"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		params []nats.Parameter
+		err    string
+	}{
+		{
+			name: "URLMissing",
+			params: []nats.Parameter{
+				nats.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no URL specified",
+		},
+		{
+			name: "SubjectMissing",
+			params: []nats.Parameter{
+				nats.WithLogLevel(zerolog.Disabled),
+				nats.WithURL("nats://localhost:1"),
+				nats.WithSubject(""),
+			},
+			err: "problem with parameters: no subject specified",
+		},
+		{
+			name: "CredentialsFileMissing",
+			params: []nats.Parameter{
+				nats.WithLogLevel(zerolog.Disabled),
+				nats.WithURL("nats://localhost:1"),
+				nats.WithCredentialsFile("/does/not/exist"),
+			},
+			err: "failed to connect to NATS server: nats: open /does/not/exist: no such file or directory",
+		},
+		{
+			// Server is not available, but the service should start regardless.
+			name: "Good",
+			params: []nats.Parameter{
+				nats.WithLogLevel(zerolog.Disabled),
+				nats.WithURL("nats://localhost:1"),
+				nats.WithSubject("test.duties"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := nats.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				// Should buffer whilst disconnected.
+				s.Publish(ctx, &dutyevents.Event{
+					Duty:      dutyevents.DutyAttestation,
+					Stage:     dutyevents.StageScheduled,
+					Slot:      1,
+					Timestamp: time.Now(),
+				})
+				s.Publish(ctx, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dutyevents
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Duties for which events are published.
+const (
+	DutyAttestation              = "attestation"
+	DutyAttestationAggregation   = "attestation_aggregation"
+	DutyProposal                 = "proposal"
+	DutySyncCommittee            = "sync_committee"
+	DutySyncCommitteeAggregation = "sync_committee_aggregation"
+)
+
+// Stages in the lifecycle of a duty.
+const (
+	// StageScheduled is when the duty has been scheduled.
+	StageScheduled = "scheduled"
+	// StageStarted is when the duty has started.
+	StageStarted = "started"
+	// StageSigned is when the duty has been signed and is about to be submitted.
+	StageSigned = "signed"
+	// StageSubmitted is when the duty has been submitted to the beacon nodes.
+	StageSubmitted = "submitted"
+	// StageFailed is when the duty has failed.
+	StageFailed = "failed"
+	// StageConfirmed is when the result of the duty has been found on chain.
+	StageConfirmed = "confirmed"
+	// StageUnconfirmed is when the result of the duty was expected on chain but not found.
+	StageUnconfirmed = "unconfirmed"
+)
+
+// Event is an event in the lifecycle of a duty.
+type Event struct {
+	Duty             string                  `json:"duty"`
+	Stage            string                  `json:"stage"`
+	Slot             phase0.Slot             `json:"slot,string"`
+	ValidatorIndices []phase0.ValidatorIndex `json:"validator_indices,omitempty"`
+	Timestamp        time.Time               `json:"timestamp"`
+	Error            string                  `json:"error,omitempty"`
+}
+
+// Service is the duty events service.
+type Service interface {
+	// Publish publishes an event.
+	// Publishing is best-effort, and does not block the duty.
+	Publish(ctx context.Context, event *Event)
+}
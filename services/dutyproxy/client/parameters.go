@@ -0,0 +1,151 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                            zerolog.Level
+	address                             string
+	timeout                             time.Duration
+	clientCert                          []byte
+	clientKey                           []byte
+	caCert                              []byte
+	fallbackAttesterDutiesProvider      eth2client.AttesterDutiesProvider
+	fallbackProposerDutiesProvider      eth2client.ProposerDutiesProvider
+	fallbackSyncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	fallbackAttestationDataProvider     eth2client.AttestationDataProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAddress sets the address of the duty proxy server.
+func WithAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.address = address
+	})
+}
+
+// WithTimeout sets the timeout for requests to the duty proxy server.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithClientCert sets the client certificate.
+func WithClientCert(cert []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientCert = cert
+	})
+}
+
+// WithClientKey sets the client key.
+func WithClientKey(key []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientKey = key
+	})
+}
+
+// WithCACert sets the certificate authority used to verify the server.
+func WithCACert(cert []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.caCert = cert
+	})
+}
+
+// WithFallbackAttesterDutiesProvider sets the attester duties provider used if the server is unavailable.
+func WithFallbackAttesterDutiesProvider(provider eth2client.AttesterDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackAttesterDutiesProvider = provider
+	})
+}
+
+// WithFallbackProposerDutiesProvider sets the proposer duties provider used if the server is unavailable.
+func WithFallbackProposerDutiesProvider(provider eth2client.ProposerDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackProposerDutiesProvider = provider
+	})
+}
+
+// WithFallbackSyncCommitteeDutiesProvider sets the sync committee duties provider used if the server is unavailable.
+func WithFallbackSyncCommitteeDutiesProvider(provider eth2client.SyncCommitteeDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackSyncCommitteeDutiesProvider = provider
+	})
+}
+
+// WithFallbackAttestationDataProvider sets the attestation data provider used if the server is unavailable.
+func WithFallbackAttestationDataProvider(provider eth2client.AttestationDataProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackAttestationDataProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		timeout:  2 * time.Second,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.address == "" {
+		return nil, errors.New("no address specified")
+	}
+	if parameters.timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	if (parameters.clientCert == nil) != (parameters.clientKey == nil) {
+		return nil, errors.New("client certificate and key must be supplied together")
+	}
+	if parameters.fallbackAttesterDutiesProvider == nil {
+		return nil, errors.New("no fallback attester duties provider specified")
+	}
+	if parameters.fallbackProposerDutiesProvider == nil {
+		return nil, errors.New("no fallback proposer duties provider specified")
+	}
+	if parameters.fallbackSyncCommitteeDutiesProvider == nil {
+		return nil, errors.New("no fallback sync committee duties provider specified")
+	}
+	if parameters.fallbackAttestationDataProvider == nil {
+		return nil, errors.New("no fallback attestation data provider specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,240 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyproxy"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Service is a duty proxy client.  It obtains duties and attestation data
+// from a duty proxy server, falling back to local providers if the server
+// cannot be reached.
+type Service struct {
+	address                             string
+	timeout                             time.Duration
+	conn                                *grpc.ClientConn
+	fallbackAttesterDutiesProvider      eth2client.AttesterDutiesProvider
+	fallbackProposerDutiesProvider      eth2client.ProposerDutiesProvider
+	fallbackSyncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	fallbackAttestationDataProvider     eth2client.AttestationDataProvider
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new duty proxy client.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutyproxy").Str("impl", "client").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	var creds credentials.TransportCredentials
+	if parameters.clientCert != nil || parameters.caCert != nil {
+		creds, err = clientCredentials(parameters.clientCert, parameters.clientKey, parameters.caCert)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		log.Warn().Msg("No certificates supplied; duty proxy connections are not encrypted")
+		creds = insecure.NewCredentials()
+	}
+
+	// Connections are established lazily, so a server that is not yet
+	// available does not prevent startup.
+	conn, err := grpc.DialContext(ctx, parameters.address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(dutyproxy.Codec{})),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create connection to duty proxy")
+	}
+	go func() {
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			log.Debug().Err(err).Msg("Failed to close connection to duty proxy")
+		}
+	}()
+
+	return &Service{
+		address:                             parameters.address,
+		timeout:                             parameters.timeout,
+		conn:                                conn,
+		fallbackAttesterDutiesProvider:      parameters.fallbackAttesterDutiesProvider,
+		fallbackProposerDutiesProvider:      parameters.fallbackProposerDutiesProvider,
+		fallbackSyncCommitteeDutiesProvider: parameters.fallbackSyncCommitteeDutiesProvider,
+		fallbackAttestationDataProvider:     parameters.fallbackAttestationDataProvider,
+	}, nil
+}
+
+// clientCredentials creates TLS credentials for the client.
+func clientCredentials(clientCert []byte, clientKey []byte, caCert []byte) (credentials.TransportCredentials, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS13,
+	}
+	if clientCert != nil {
+		clientPair, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client keypair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientPair}
+	}
+	if caCert != nil {
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to add CA certificate")
+		}
+		tlsCfg.RootCAs = cp
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// invoke calls a method on the duty proxy server.
+func (s *Service) invoke(ctx context.Context, method string, req any, resp any) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := s.conn.Invoke(ctx, dutyproxy.FullMethod(method), req, resp); err != nil {
+		log.Warn().Str("address", s.address).Str("method", method).Err(err).Msg("Duty proxy request failed; falling back to beacon node")
+		return err
+	}
+
+	return nil
+}
+
+// AttesterDuties obtains attester duties.
+func (s *Service) AttesterDuties(ctx context.Context,
+	opts *api.AttesterDutiesOpts,
+) (
+	*api.Response[[]*apiv1.AttesterDuty],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	resp := &dutyproxy.AttesterDutiesResponse{}
+	if err := s.invoke(ctx, dutyproxy.AttesterDutiesMethod, &dutyproxy.DutiesRequest{
+		Epoch:   opts.Epoch,
+		Indices: opts.Indices,
+	}, resp); err != nil {
+		return s.fallbackAttesterDutiesProvider.AttesterDuties(ctx, opts)
+	}
+
+	return &api.Response[[]*apiv1.AttesterDuty]{
+		Data:     resp.Duties,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// ProposerDuties obtains proposer duties.
+func (s *Service) ProposerDuties(ctx context.Context,
+	opts *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	resp := &dutyproxy.ProposerDutiesResponse{}
+	if err := s.invoke(ctx, dutyproxy.ProposerDutiesMethod, &dutyproxy.DutiesRequest{
+		Epoch:   opts.Epoch,
+		Indices: opts.Indices,
+	}, resp); err != nil {
+		return s.fallbackProposerDutiesProvider.ProposerDuties(ctx, opts)
+	}
+
+	return &api.Response[[]*apiv1.ProposerDuty]{
+		Data:     resp.Duties,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// SyncCommitteeDuties obtains sync committee duties.
+func (s *Service) SyncCommitteeDuties(ctx context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	resp := &dutyproxy.SyncCommitteeDutiesResponse{}
+	if err := s.invoke(ctx, dutyproxy.SyncCommitteeDutiesMethod, &dutyproxy.DutiesRequest{
+		Epoch:   opts.Epoch,
+		Indices: opts.Indices,
+	}, resp); err != nil {
+		return s.fallbackSyncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, opts)
+	}
+
+	return &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data:     resp.Duties,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// AttestationData obtains attestation data.
+func (s *Service) AttestationData(ctx context.Context,
+	opts *api.AttestationDataOpts,
+) (
+	*api.Response[*phase0.AttestationData],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	resp := &dutyproxy.AttestationDataResponse{}
+	if err := s.invoke(ctx, dutyproxy.AttestationDataMethod, &dutyproxy.AttestationDataRequest{
+		Slot:           opts.Slot,
+		CommitteeIndex: opts.CommitteeIndex,
+	}, resp); err != nil {
+		return s.fallbackAttestationDataProvider.AttestationData(ctx, opts)
+	}
+	if resp.AttestationData == nil {
+		log.Warn().Str("address", s.address).Msg("Duty proxy returned no attestation data; falling back to beacon node")
+		return s.fallbackAttestationDataProvider.AttestationData(ctx, opts)
+	}
+
+	return &api.Response[*phase0.AttestationData]{
+		Data:     resp.AttestationData,
+		Metadata: make(map[string]any),
+	}, nil
+}
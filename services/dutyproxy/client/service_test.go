@@ -0,0 +1,211 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/dutyproxy/client"
+	"github.com/attestantio/vouch/services/dutyproxy/server"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/testing/resources"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attesterDutiesProvider := mock.NewAttesterDutiesProvider()
+	proposerDutiesProvider := mock.NewProposerDutiesProvider()
+	syncCommitteeDutiesProvider := mock.NewSyncCommitteeDutiesProvider()
+	attestationDataProvider := mock.NewAttestationDataProvider()
+
+	tests := []struct {
+		name   string
+		params []client.Parameter
+		err    string
+	}{
+		{
+			name: "AddressMissing",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithFallbackAttesterDutiesProvider(attesterDutiesProvider),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				client.WithFallbackAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: no address specified",
+		},
+		{
+			name: "TimeoutZero",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithAddress("localhost:1"),
+				client.WithTimeout(0),
+				client.WithFallbackAttesterDutiesProvider(attesterDutiesProvider),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				client.WithFallbackAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: timeout must be positive",
+		},
+		{
+			name: "ClientKeyMissing",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithAddress("localhost:1"),
+				client.WithClientCert([]byte(resources.SignerTest01Crt)),
+				client.WithFallbackAttesterDutiesProvider(attesterDutiesProvider),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				client.WithFallbackAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: client certificate and key must be supplied together",
+		},
+		{
+			name: "FallbackAttesterDutiesProviderMissing",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithAddress("localhost:1"),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				client.WithFallbackAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: no fallback attester duties provider specified",
+		},
+		{
+			name: "FallbackAttestationDataProviderMissing",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithAddress("localhost:1"),
+				client.WithFallbackAttesterDutiesProvider(attesterDutiesProvider),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+			},
+			err: "problem with parameters: no fallback attestation data provider specified",
+		},
+		{
+			name: "ClientKeyInvalid",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithAddress("localhost:1"),
+				client.WithClientCert([]byte(resources.SignerTest01Crt)),
+				client.WithClientKey([]byte(resources.SignerTest02Key)),
+				client.WithFallbackAttesterDutiesProvider(attesterDutiesProvider),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				client.WithFallbackAttestationDataProvider(attestationDataProvider),
+			},
+			err: "failed to load client keypair: tls: private key does not match public key",
+		},
+		{
+			name: "Good",
+			params: []client.Parameter{
+				client.WithLogLevel(zerolog.Disabled),
+				client.WithAddress("localhost:1"),
+				client.WithFallbackAttesterDutiesProvider(attesterDutiesProvider),
+				client.WithFallbackProposerDutiesProvider(proposerDutiesProvider),
+				client.WithFallbackSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				client.WithFallbackAttestationDataProvider(attestationDataProvider),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv, err := server.New(ctx,
+		server.WithLogLevel(zerolog.Disabled),
+		server.WithMonitor(nullmetrics.New(ctx)),
+		server.WithListenAddress("127.0.0.1:0"),
+		server.WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		server.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		server.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+		server.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+	)
+	require.NoError(t, err)
+
+	// The fallback attestation data provider errors, so attestation data must come from the server.
+	s, err := client.New(ctx,
+		client.WithLogLevel(zerolog.Disabled),
+		client.WithAddress(srv.Address()),
+		client.WithFallbackAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		client.WithFallbackProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		client.WithFallbackSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+		client.WithFallbackAttestationDataProvider(mock.NewErroringAttestationDataProvider()),
+	)
+	require.NoError(t, err)
+
+	attestationDataResponse, err := s.AttestationData(ctx, &api.AttestationDataOpts{Slot: 100, CommitteeIndex: 2})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), attestationDataResponse.Data.Slot)
+	require.Equal(t, phase0.CommitteeIndex(2), attestationDataResponse.Data.Index)
+	require.Equal(t, phase0.Epoch(3), attestationDataResponse.Data.Target.Epoch)
+
+	attesterDutiesResponse, err := s.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: 3, Indices: []phase0.ValidatorIndex{1, 2}})
+	require.NoError(t, err)
+	require.NotNil(t, attesterDutiesResponse.Data)
+
+	proposerDutiesResponse, err := s.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: 3})
+	require.NoError(t, err)
+	require.NotNil(t, proposerDutiesResponse.Data)
+
+	syncCommitteeDutiesResponse, err := s.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{Epoch: 3, Indices: []phase0.ValidatorIndex{1}})
+	require.NoError(t, err)
+	require.NotNil(t, syncCommitteeDutiesResponse.Data)
+}
+
+func TestFallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// No server is listening at this address.
+	s, err := client.New(ctx,
+		client.WithLogLevel(zerolog.Disabled),
+		client.WithAddress("127.0.0.1:1"),
+		client.WithTimeout(100*time.Millisecond),
+		client.WithFallbackAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		client.WithFallbackProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		client.WithFallbackSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+		client.WithFallbackAttestationDataProvider(mock.NewAttestationDataProvider()),
+	)
+	require.NoError(t, err)
+
+	attestationDataResponse, err := s.AttestationData(ctx, &api.AttestationDataOpts{Slot: 100, CommitteeIndex: 2})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), attestationDataResponse.Data.Slot)
+
+	_, err = s.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: 3})
+	require.NoError(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cacheEntry is a single cached response.  done is closed once the value is
+// available, allowing concurrent requests for the same key to share a single
+// fetch from the beacon node.
+type cacheEntry struct {
+	done    chan struct{}
+	value   any
+	err     error
+	expires time.Time
+}
+
+// cache is a time-limited cache of beacon node responses.
+type cache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// get returns the value for the key, calling fetch if it is not present.
+// The returned boolean is true if the value was served from the cache.
+// Errors are returned to all waiting callers but are not cached.
+func (c *cache) get(ctx context.Context,
+	key string,
+	fetch func() (any, error),
+) (
+	any,
+	bool,
+	error,
+) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, exists := c.entries[key]
+	if exists && entry.expires.Before(now) {
+		delete(c.entries, key)
+		exists = false
+	}
+	if exists {
+		c.mu.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if entry.err != nil {
+			return nil, false, entry.err
+		}
+		return entry.value, true, nil
+	}
+	c.pruneLocked(now)
+	entry = &cacheEntry{
+		done:    make(chan struct{}),
+		expires: now.Add(c.ttl),
+	}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.value, entry.err = fetch()
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	close(entry.done)
+
+	return entry.value, false, entry.err
+}
+
+// pruneLocked removes expired entries.  The mutex must be held.
+func (c *cache) pruneLocked(now time.Time) {
+	for key, entry := range c.entries {
+		if entry.expires.Before(now) {
+			delete(c.entries, key)
+		}
+	}
+}
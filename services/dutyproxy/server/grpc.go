@@ -0,0 +1,87 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/dutyproxy"
+	"google.golang.org/grpc"
+)
+
+// dutyProxyServer is the interface implemented by the gRPC service.
+type dutyProxyServer interface {
+	AttesterDuties(ctx context.Context, req *dutyproxy.DutiesRequest) (*dutyproxy.AttesterDutiesResponse, error)
+	ProposerDuties(ctx context.Context, req *dutyproxy.DutiesRequest) (*dutyproxy.ProposerDutiesResponse, error)
+	SyncCommitteeDuties(ctx context.Context, req *dutyproxy.DutiesRequest) (*dutyproxy.SyncCommitteeDutiesResponse, error)
+	AttestationData(ctx context.Context, req *dutyproxy.AttestationDataRequest) (*dutyproxy.AttestationDataResponse, error)
+}
+
+// serviceDesc describes the gRPC service.  It is written by hand rather than
+// generated, as messages are encoded with the duty proxy's JSON codec.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: dutyproxy.ServiceName,
+	HandlerType: (*dutyProxyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: dutyproxy.AttesterDutiesMethod,
+			Handler: unaryHandler(dutyproxy.AttesterDutiesMethod, func(srv dutyProxyServer, ctx context.Context, req *dutyproxy.DutiesRequest) (any, error) {
+				return srv.AttesterDuties(ctx, req)
+			}),
+		},
+		{
+			MethodName: dutyproxy.ProposerDutiesMethod,
+			Handler: unaryHandler(dutyproxy.ProposerDutiesMethod, func(srv dutyProxyServer, ctx context.Context, req *dutyproxy.DutiesRequest) (any, error) {
+				return srv.ProposerDuties(ctx, req)
+			}),
+		},
+		{
+			MethodName: dutyproxy.SyncCommitteeDutiesMethod,
+			Handler: unaryHandler(dutyproxy.SyncCommitteeDutiesMethod, func(srv dutyProxyServer, ctx context.Context, req *dutyproxy.DutiesRequest) (any, error) {
+				return srv.SyncCommitteeDuties(ctx, req)
+			}),
+		},
+		{
+			MethodName: dutyproxy.AttestationDataMethod,
+			Handler: unaryHandler(dutyproxy.AttestationDataMethod, func(srv dutyProxyServer, ctx context.Context, req *dutyproxy.AttestationDataRequest) (any, error) {
+				return srv.AttestationData(ctx, req)
+			}),
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dutyproxy",
+}
+
+// unaryHandler creates a gRPC method handler for the given request type.
+func unaryHandler[T any](method string,
+	handle func(srv dutyProxyServer, ctx context.Context, req *T) (any, error),
+) func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(T)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return handle(srv.(dutyProxyServer), ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: dutyproxy.FullMethod(method),
+		}
+
+		return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return handle(srv.(dutyProxyServer), ctx, req.(*T))
+		})
+	}
+}
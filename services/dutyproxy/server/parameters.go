@@ -0,0 +1,179 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                    zerolog.Level
+	monitor                     metrics.Service
+	listenAddress               string
+	serverCert                  []byte
+	serverKey                   []byte
+	caCert                      []byte
+	timeout                     time.Duration
+	cacheTTL                    time.Duration
+	attesterDutiesProvider      eth2client.AttesterDutiesProvider
+	proposerDutiesProvider      eth2client.ProposerDutiesProvider
+	syncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	attestationDataProvider     eth2client.AttestationDataProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithListenAddress sets the address on which to listen for requests.
+func WithListenAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenAddress = address
+	})
+}
+
+// WithServerCert sets the server certificate.
+func WithServerCert(cert []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.serverCert = cert
+	})
+}
+
+// WithServerKey sets the server key.
+func WithServerKey(key []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.serverKey = key
+	})
+}
+
+// WithCACert sets the certificate authority used to verify client certificates.
+// If supplied, clients must present a certificate signed by the authority.
+func WithCACert(cert []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.caCert = cert
+	})
+}
+
+// WithTimeout sets the timeout for requests to the beacon node.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithCacheTTL sets the time for which responses are cached.
+func WithCacheTTL(ttl time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.cacheTTL = ttl
+	})
+}
+
+// WithAttesterDutiesProvider sets the attester duties provider.
+func WithAttesterDutiesProvider(provider eth2client.AttesterDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attesterDutiesProvider = provider
+	})
+}
+
+// WithProposerDutiesProvider sets the proposer duties provider.
+func WithProposerDutiesProvider(provider eth2client.ProposerDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposerDutiesProvider = provider
+	})
+}
+
+// WithSyncCommitteeDutiesProvider sets the sync committee duties provider.
+func WithSyncCommitteeDutiesProvider(provider eth2client.SyncCommitteeDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncCommitteeDutiesProvider = provider
+	})
+}
+
+// WithAttestationDataProvider sets the attestation data provider.
+func WithAttestationDataProvider(provider eth2client.AttestationDataProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationDataProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		timeout:  2 * time.Second,
+		cacheTTL: 12 * time.Second,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.listenAddress == "" {
+		return nil, errors.New("no listen address specified")
+	}
+	if (parameters.serverCert == nil) != (parameters.serverKey == nil) {
+		return nil, errors.New("server certificate and key must be supplied together")
+	}
+	if parameters.caCert != nil && parameters.serverCert == nil {
+		return nil, errors.New("CA certificate requires server certificate and key")
+	}
+	if parameters.timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	if parameters.cacheTTL <= 0 {
+		return nil, errors.New("cache TTL must be positive")
+	}
+	if parameters.attesterDutiesProvider == nil {
+		return nil, errors.New("no attester duties provider specified")
+	}
+	if parameters.proposerDutiesProvider == nil {
+		return nil, errors.New("no proposer duties provider specified")
+	}
+	if parameters.syncCommitteeDutiesProvider == nil {
+		return nil, errors.New("no sync committee duties provider specified")
+	}
+	if parameters.attestationDataProvider == nil {
+		return nil, errors.New("no attestation data provider specified")
+	}
+
+	return &parameters, nil
+}
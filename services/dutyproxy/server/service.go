@@ -0,0 +1,302 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyproxy"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Service is a duty proxy server.
+type Service struct {
+	// ctx is the context of the service, used for beacon node requests so that
+	// a single client cancelling its request does not fail other clients
+	// waiting on the same response.
+	ctx                         context.Context
+	timeout                     time.Duration
+	cache                       *cache
+	attesterDutiesProvider      eth2client.AttesterDutiesProvider
+	proposerDutiesProvider      eth2client.ProposerDutiesProvider
+	syncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	attestationDataProvider     eth2client.AttestationDataProvider
+	server                      *grpc.Server
+	listener                    net.Listener
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new duty proxy server.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutyproxy").Str("impl", "server").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Wrap(err, "failed to register metrics")
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ForceServerCodec(dutyproxy.Codec{}),
+	}
+	if parameters.serverCert != nil {
+		creds, err := serverCredentials(parameters.serverCert, parameters.serverKey, parameters.caCert)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	} else {
+		log.Warn().Msg("No server certificate supplied; duty proxy connections are not encrypted")
+	}
+
+	listener, err := net.Listen("tcp", parameters.listenAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen")
+	}
+
+	s := &Service{
+		ctx:                         ctx,
+		timeout:                     parameters.timeout,
+		cache:                       newCache(parameters.cacheTTL),
+		attesterDutiesProvider:      parameters.attesterDutiesProvider,
+		proposerDutiesProvider:      parameters.proposerDutiesProvider,
+		syncCommitteeDutiesProvider: parameters.syncCommitteeDutiesProvider,
+		attestationDataProvider:     parameters.attestationDataProvider,
+		server:                      grpc.NewServer(serverOpts...),
+		listener:                    listener,
+	}
+	s.server.RegisterService(&serviceDesc, s)
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			log.Error().Err(err).Msg("Duty proxy server stopped")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		s.server.GracefulStop()
+	}()
+	log.Info().Str("address", listener.Addr().String()).Msg("Duty proxy listening")
+
+	return s, nil
+}
+
+// Address returns the address on which the server is listening.
+func (s *Service) Address() string {
+	return s.listener.Addr().String()
+}
+
+// serverCredentials creates TLS credentials for the server.  If a CA
+// certificate is supplied then clients must present a certificate signed by it.
+func serverCredentials(serverCert []byte, serverKey []byte, caCert []byte) (credentials.TransportCredentials, error) {
+	serverPair, err := tls.X509KeyPair(serverCert, serverKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load server keypair")
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{serverPair},
+		MinVersion:   tls.VersionTLS13,
+	}
+	if caCert != nil {
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to add CA certificate")
+		}
+		tlsCfg.ClientCAs = cp
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// fetch obtains the value for the key from the cache, fetching it if required.
+func (s *Service) fetch(ctx context.Context,
+	method string,
+	key string,
+	fetch func(ctx context.Context) (any, error),
+) (
+	any,
+	error,
+) {
+	value, hit, err := s.cache.get(ctx, key, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(s.ctx, s.timeout)
+		defer cancel()
+
+		return fetch(fetchCtx)
+	})
+	switch {
+	case err != nil:
+		log.Debug().Str("method", method).Str("key", key).Err(err).Msg("Failed to obtain response")
+		monitorRequest(method, "failed")
+	case hit:
+		log.Trace().Str("method", method).Str("key", key).Msg("Served response from cache")
+		monitorRequest(method, "hit")
+	default:
+		log.Trace().Str("method", method).Str("key", key).Msg("Served response from beacon node")
+		monitorRequest(method, "miss")
+	}
+
+	return value, err
+}
+
+// AttesterDuties serves attester duties.
+func (s *Service) AttesterDuties(ctx context.Context, req *dutyproxy.DutiesRequest) (*dutyproxy.AttesterDutiesResponse, error) {
+	key := fmt.Sprintf("attester/%d/%s", req.Epoch, indicesKey(req.Indices))
+	value, err := s.fetch(ctx, dutyproxy.AttesterDutiesMethod, key, func(ctx context.Context) (any, error) {
+		response, err := s.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+			Epoch:   req.Epoch,
+			Indices: req.Indices,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return response.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dutyproxy.AttesterDutiesResponse{
+		Duties: value.([]*apiv1.AttesterDuty),
+	}, nil
+}
+
+// ProposerDuties serves proposer duties.
+// Proposer duties for all validators are fetched and cached, and filtered for
+// each request, so a single beacon node request serves all clients.
+func (s *Service) ProposerDuties(ctx context.Context, req *dutyproxy.DutiesRequest) (*dutyproxy.ProposerDutiesResponse, error) {
+	key := fmt.Sprintf("proposer/%d", req.Epoch)
+	value, err := s.fetch(ctx, dutyproxy.ProposerDutiesMethod, key, func(ctx context.Context) (any, error) {
+		response, err := s.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch: req.Epoch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return response.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	duties := value.([]*apiv1.ProposerDuty)
+	if len(req.Indices) > 0 {
+		indices := make(map[phase0.ValidatorIndex]struct{}, len(req.Indices))
+		for _, index := range req.Indices {
+			indices[index] = struct{}{}
+		}
+		filteredDuties := make([]*apiv1.ProposerDuty, 0)
+		for _, duty := range duties {
+			if _, exists := indices[duty.ValidatorIndex]; exists {
+				filteredDuties = append(filteredDuties, duty)
+			}
+		}
+		duties = filteredDuties
+	}
+
+	return &dutyproxy.ProposerDutiesResponse{
+		Duties: duties,
+	}, nil
+}
+
+// SyncCommitteeDuties serves sync committee duties.
+func (s *Service) SyncCommitteeDuties(ctx context.Context, req *dutyproxy.DutiesRequest) (*dutyproxy.SyncCommitteeDutiesResponse, error) {
+	key := fmt.Sprintf("synccommittee/%d/%s", req.Epoch, indicesKey(req.Indices))
+	value, err := s.fetch(ctx, dutyproxy.SyncCommitteeDutiesMethod, key, func(ctx context.Context) (any, error) {
+		response, err := s.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   req.Epoch,
+			Indices: req.Indices,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return response.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dutyproxy.SyncCommitteeDutiesResponse{
+		Duties: value.([]*apiv1.SyncCommitteeDuty),
+	}, nil
+}
+
+// AttestationData serves attestation data.
+func (s *Service) AttestationData(ctx context.Context, req *dutyproxy.AttestationDataRequest) (*dutyproxy.AttestationDataResponse, error) {
+	key := fmt.Sprintf("attestationdata/%d/%d", req.Slot, req.CommitteeIndex)
+	value, err := s.fetch(ctx, dutyproxy.AttestationDataMethod, key, func(ctx context.Context) (any, error) {
+		response, err := s.attestationDataProvider.AttestationData(ctx, &api.AttestationDataOpts{
+			Slot:           req.Slot,
+			CommitteeIndex: req.CommitteeIndex,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if response.Data == nil {
+			return nil, errors.New("attestation data not returned")
+		}
+
+		return response.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dutyproxy.AttestationDataResponse{
+		AttestationData: value.(*phase0.AttestationData),
+	}, nil
+}
+
+// indicesKey returns a canonical cache key component for a set of indices.
+func indicesKey(indices []phase0.ValidatorIndex) string {
+	sorted := make([]phase0.ValidatorIndex, len(indices))
+	copy(sorted, indices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	parts := make([]string, len(sorted))
+	for i := range sorted {
+		parts[i] = fmt.Sprintf("%d", sorted[i])
+	}
+
+	return strings.Join(parts, ",")
+}
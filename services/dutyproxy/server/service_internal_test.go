@@ -0,0 +1,154 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/dutyproxy"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProposerDutiesProvider returns a proposer duty per slot, counting requests.
+type countingProposerDutiesProvider struct {
+	requests atomic.Int32
+}
+
+func (p *countingProposerDutiesProvider) ProposerDuties(_ context.Context,
+	opts *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	p.requests.Add(1)
+	if len(opts.Indices) > 0 {
+		return nil, errors.New("unexpected indices")
+	}
+	duties := make([]*apiv1.ProposerDuty, 0, 32)
+	for i := 0; i < 32; i++ {
+		duties = append(duties, &apiv1.ProposerDuty{
+			Slot:           phase0.Slot(uint64(opts.Epoch)*32 + uint64(i)),
+			ValidatorIndex: phase0.ValidatorIndex(i),
+		})
+	}
+
+	return &api.Response[[]*apiv1.ProposerDuty]{
+		Data:     duties,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestProposerDuties(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	proposerDutiesProvider := &countingProposerDutiesProvider{}
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithListenAddress("127.0.0.1:0"),
+		WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		WithProposerDutiesProvider(proposerDutiesProvider),
+		WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+		WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+	)
+	require.NoError(t, err)
+
+	resp, err := s.ProposerDuties(ctx, &dutyproxy.DutiesRequest{Epoch: 2, Indices: []phase0.ValidatorIndex{3, 5}})
+	require.NoError(t, err)
+	require.Len(t, resp.Duties, 2)
+	require.Equal(t, phase0.Slot(67), resp.Duties[0].Slot)
+	require.Equal(t, phase0.Slot(69), resp.Duties[1].Slot)
+
+	// A request for different indices in the same epoch is served from the cache.
+	resp, err = s.ProposerDuties(ctx, &dutyproxy.DutiesRequest{Epoch: 2, Indices: []phase0.ValidatorIndex{7}})
+	require.NoError(t, err)
+	require.Len(t, resp.Duties, 1)
+	require.Equal(t, int32(1), proposerDutiesProvider.requests.Load())
+
+	// A request without indices returns all duties.
+	resp, err = s.ProposerDuties(ctx, &dutyproxy.DutiesRequest{Epoch: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Duties, 32)
+
+	// A different epoch is fetched.
+	_, err = s.ProposerDuties(ctx, &dutyproxy.DutiesRequest{Epoch: 3})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), proposerDutiesProvider.requests.Load())
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	c := newCache(50 * time.Millisecond)
+
+	// Concurrent requests share a single fetch.
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	hits := atomic.Int32{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, hit, err := c.get(ctx, "key", func() (any, error) {
+				fetches.Add(1)
+				<-release
+				return 1, nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, value)
+			if hit {
+				hits.Add(1)
+			}
+		}()
+	}
+	// Give the goroutines time to start before releasing the fetch.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), fetches.Load())
+	require.Equal(t, int32(4), hits.Load())
+
+	// Values expire.
+	time.Sleep(60 * time.Millisecond)
+	_, hit, err := c.get(ctx, "key", func() (any, error) {
+		return 2, nil
+	})
+	require.NoError(t, err)
+	require.False(t, hit)
+
+	// Errors are not cached.
+	_, _, err = c.get(ctx, "error", func() (any, error) {
+		return nil, errors.New("failed")
+	})
+	require.EqualError(t, err, "failed")
+	value, hit, err := c.get(ctx, "error", func() (any, error) {
+		return 3, nil
+	})
+	require.NoError(t, err)
+	require.False(t, hit)
+	require.Equal(t, 3, value)
+}
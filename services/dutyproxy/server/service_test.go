@@ -0,0 +1,188 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/dutyproxy/server"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/testing/resources"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor := nullmetrics.New(ctx)
+	attesterDutiesProvider := mock.NewAttesterDutiesProvider()
+	proposerDutiesProvider := mock.NewProposerDutiesProvider()
+	syncCommitteeDutiesProvider := mock.NewSyncCommitteeDutiesProvider()
+	attestationDataProvider := mock.NewAttestationDataProvider()
+
+	tests := []struct {
+		name   string
+		params []server.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ListenAddressMissing",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: no listen address specified",
+		},
+		{
+			name: "ServerKeyMissing",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithServerCert([]byte(resources.SignerTest01Crt)),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: server certificate and key must be supplied together",
+		},
+		{
+			name: "CACertWithoutServerCert",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithCACert([]byte(resources.CACrt)),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: CA certificate requires server certificate and key",
+		},
+		{
+			name: "CacheTTLZero",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithCacheTTL(0),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: cache TTL must be positive",
+		},
+		{
+			name: "AttesterDutiesProviderMissing",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "problem with parameters: no attester duties provider specified",
+		},
+		{
+			name: "AttestationDataProviderMissing",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+			},
+			err: "problem with parameters: no attestation data provider specified",
+		},
+		{
+			name: "ServerKeyInvalid",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithServerCert([]byte(resources.SignerTest01Crt)),
+				server.WithServerKey([]byte(resources.SignerTest02Key)),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+			err: "failed to load server keypair: tls: private key does not match public key",
+		},
+		{
+			name: "Good",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+		},
+		{
+			name: "GoodTLS",
+			params: []server.Parameter{
+				server.WithLogLevel(zerolog.Disabled),
+				server.WithMonitor(monitor),
+				server.WithListenAddress("127.0.0.1:0"),
+				server.WithServerCert([]byte(resources.SignerTest01Crt)),
+				server.WithServerKey([]byte(resources.SignerTest01Key)),
+				server.WithCACert([]byte(resources.CACrt)),
+				server.WithAttesterDutiesProvider(attesterDutiesProvider),
+				server.WithProposerDutiesProvider(proposerDutiesProvider),
+				server.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				server.WithAttestationDataProvider(attestationDataProvider),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := server.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotEmpty(t, s.Address())
+			}
+		})
+	}
+}
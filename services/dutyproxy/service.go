@@ -0,0 +1,92 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dutyproxy allows a Vouch instance to serve duties and attestation
+// data to sibling instances over gRPC, caching responses so that a fleet of
+// instances presents a single instance's load to the shared beacon nodes.
+// Signing remains local to each instance.
+package dutyproxy
+
+import (
+	"encoding/json"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ServiceName is the gRPC name of the duty proxy service.
+const ServiceName = "vouch.dutyproxy.v1.DutyProxy"
+
+// Method names of the duty proxy service.
+const (
+	AttesterDutiesMethod      = "AttesterDuties"
+	ProposerDutiesMethod      = "ProposerDuties"
+	SyncCommitteeDutiesMethod = "SyncCommitteeDuties"
+	AttestationDataMethod     = "AttestationData"
+)
+
+// FullMethod returns the full gRPC name of the given method.
+func FullMethod(method string) string {
+	return "/" + ServiceName + "/" + method
+}
+
+// DutiesRequest is a request for duties.
+type DutiesRequest struct {
+	Epoch   phase0.Epoch            `json:"epoch"`
+	Indices []phase0.ValidatorIndex `json:"indices"`
+}
+
+// AttestationDataRequest is a request for attestation data.
+type AttestationDataRequest struct {
+	Slot           phase0.Slot           `json:"slot"`
+	CommitteeIndex phase0.CommitteeIndex `json:"committee_index"`
+}
+
+// AttesterDutiesResponse is a response containing attester duties.
+type AttesterDutiesResponse struct {
+	Duties []*apiv1.AttesterDuty `json:"duties"`
+}
+
+// ProposerDutiesResponse is a response containing proposer duties.
+type ProposerDutiesResponse struct {
+	Duties []*apiv1.ProposerDuty `json:"duties"`
+}
+
+// SyncCommitteeDutiesResponse is a response containing sync committee duties.
+type SyncCommitteeDutiesResponse struct {
+	Duties []*apiv1.SyncCommitteeDuty `json:"duties"`
+}
+
+// AttestationDataResponse is a response containing attestation data.
+type AttestationDataResponse struct {
+	AttestationData *phase0.AttestationData `json:"attestation_data"`
+}
+
+// Codec encodes duty proxy messages as JSON, avoiding the need for generated
+// protocol buffer code.
+type Codec struct{}
+
+// Marshal marshals a message.
+func (Codec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal unmarshals a message.
+func (Codec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name returns the name of the codec.
+func (Codec) Name() string {
+	return "json"
+}
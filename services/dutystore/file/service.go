@@ -0,0 +1,130 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// tmpSuffix is the suffix of files that are being written.
+const tmpSuffix = ".tmp"
+
+// validKey matches keys that can be used as file names.
+var validKey = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Service is a store that keeps each item of data in its own file.
+type Service struct {
+	baseDir string
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new file store.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutystore").Str("impl", "file").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := os.MkdirAll(parameters.baseDir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create base directory")
+	}
+
+	return &Service{
+		baseDir: parameters.baseDir,
+	}, nil
+}
+
+// Put stores data under the given key, replacing any existing data.
+func (s *Service) Put(_ context.Context, key string, data []byte) error {
+	if !validKey.MatchString(key) {
+		return errors.New("invalid key")
+	}
+
+	// Write to a temporary file and rename it, so that a partially written file
+	// is never read.
+	path := filepath.Join(s.baseDir, key)
+	if err := os.WriteFile(path+tmpSuffix, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write data")
+	}
+	if err := os.Rename(path+tmpSuffix, path); err != nil {
+		return errors.Wrap(err, "failed to rename data")
+	}
+
+	return nil
+}
+
+// Get returns the data stored under the given key, or nil if there is none.
+func (s *Service) Get(_ context.Context, key string) ([]byte, error) {
+	if !validKey.MatchString(key) {
+		return nil, errors.New("invalid key")
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.baseDir, key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to read data")
+	}
+
+	return data, nil
+}
+
+// Delete removes the data stored under the given key, if present.
+func (s *Service) Delete(_ context.Context, key string) error {
+	if !validKey.MatchString(key) {
+		return errors.New("invalid key")
+	}
+
+	if err := os.Remove(filepath.Join(s.baseDir, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "failed to delete data")
+	}
+
+	return nil
+}
+
+// Keys returns the keys of all stored data.
+func (s *Service) Keys(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read base directory")
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), tmpSuffix) || !validKey.MatchString(entry.Name()) {
+			continue
+		}
+		keys = append(keys, entry.Name())
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/vouch/services/dutystore/file"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []file.Parameter
+		err    string
+	}{
+		{
+			name: "BaseDirMissing",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no base directory specified",
+		},
+		{
+			name: "Good",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+				file.WithBaseDir(t.TempDir()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := file.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := file.New(ctx,
+		file.WithLogLevel(zerolog.Disabled),
+		file.WithBaseDir(t.TempDir()),
+	)
+	require.NoError(t, err)
+
+	// Missing data.
+	data, err := s.Get(ctx, "attester-1-00")
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	// Invalid keys.
+	require.EqualError(t, s.Put(ctx, "../attester", []byte("data")), "invalid key")
+	_, err = s.Get(ctx, "")
+	require.EqualError(t, err, "invalid key")
+
+	// Stored data.
+	require.NoError(t, s.Put(ctx, "attester-1-00", []byte("data 1")))
+	require.NoError(t, s.Put(ctx, "attester-2-00", []byte("data 2")))
+	require.NoError(t, s.Put(ctx, "attester-1-00", []byte("data 1 updated")))
+	data, err = s.Get(ctx, "attester-1-00")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data 1 updated"), data)
+	keys, err := s.Keys(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"attester-1-00", "attester-2-00"}, keys)
+
+	// Deleted data.
+	require.NoError(t, s.Delete(ctx, "attester-1-00"))
+	require.NoError(t, s.Delete(ctx, "attester-1-00"))
+	data, err = s.Get(ctx, "attester-1-00")
+	require.NoError(t, err)
+	require.Nil(t, data)
+	keys, err = s.Keys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"attester-2-00"}, keys)
+}
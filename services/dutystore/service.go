@@ -0,0 +1,41 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dutystore
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+)
+
+// Store persists data for the duty store.
+type Store interface {
+	// Put stores data under the given key, replacing any existing data.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data stored under the given key, or nil if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the data stored under the given key, if present.
+	Delete(ctx context.Context, key string) error
+	// Keys returns the keys of all stored data.
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// Service is the duty store service.  It provides duties, persisting them so
+// that after a restart they can be served without being recomputed by the
+// beacon node.
+type Service interface {
+	eth2client.AttesterDutiesProvider
+	eth2client.ProposerDutiesProvider
+	eth2client.SyncCommitteeDutiesProvider
+}
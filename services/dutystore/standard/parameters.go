@@ -0,0 +1,126 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/dutystore"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                    zerolog.Level
+	chainTime                   chaintime.Service
+	store                       dutystore.Store
+	attesterDutiesProvider      eth2client.AttesterDutiesProvider
+	proposerDutiesProvider      eth2client.ProposerDutiesProvider
+	syncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	beaconBlockHeadersProvider  eth2client.BeaconBlockHeadersProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithStore sets the store in which duties are persisted.
+func WithStore(store dutystore.Store) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.store = store
+	})
+}
+
+// WithAttesterDutiesProvider sets the provider of attester duties.
+func WithAttesterDutiesProvider(provider eth2client.AttesterDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attesterDutiesProvider = provider
+	})
+}
+
+// WithProposerDutiesProvider sets the provider of proposer duties.
+func WithProposerDutiesProvider(provider eth2client.ProposerDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposerDutiesProvider = provider
+	})
+}
+
+// WithSyncCommitteeDutiesProvider sets the provider of sync committee duties.
+func WithSyncCommitteeDutiesProvider(provider eth2client.SyncCommitteeDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncCommitteeDutiesProvider = provider
+	})
+}
+
+// WithBeaconBlockHeadersProvider sets the provider of beacon block headers, used
+// to check that stored duties are still valid.
+func WithBeaconBlockHeadersProvider(provider eth2client.BeaconBlockHeadersProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconBlockHeadersProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chaintime service specified")
+	}
+	if parameters.store == nil {
+		return nil, errors.New("no store specified")
+	}
+	if parameters.attesterDutiesProvider == nil {
+		return nil, errors.New("no attester duties provider specified")
+	}
+	if parameters.proposerDutiesProvider == nil {
+		return nil, errors.New("no proposer duties provider specified")
+	}
+	if parameters.syncCommitteeDutiesProvider == nil {
+		return nil, errors.New("no sync committee duties provider specified")
+	}
+	if parameters.beaconBlockHeadersProvider == nil {
+		return nil, errors.New("no beacon block headers provider specified")
+	}
+
+	return &parameters, nil
+}
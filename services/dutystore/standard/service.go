@@ -0,0 +1,330 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/dutystore"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Types of duty, used as the prefix of stored keys.
+const (
+	attesterDuty      = "attester"
+	proposerDuty      = "proposer"
+	syncCommitteeDuty = "synccommittee"
+)
+
+// Service provides duties, persisting them in a store.  Attester and proposer
+// duties are only served from the store if the block on which they depend is
+// unchanged, so a reorganisation whilst Vouch was not running results in the
+// duties being obtained again.
+type Service struct {
+	chainTime                   chaintime.Service
+	store                       dutystore.Store
+	attesterDutiesProvider      eth2client.AttesterDutiesProvider
+	proposerDutiesProvider      eth2client.ProposerDutiesProvider
+	syncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	beaconBlockHeadersProvider  eth2client.BeaconBlockHeadersProvider
+}
+
+// storedDuties are duties as persisted in the store.
+type storedDuties struct {
+	DependentRoot *phase0.Root    `json:"dependent_root,omitempty"`
+	Duties        json.RawMessage `json:"duties"`
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new duty store.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutystore").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		chainTime:                   parameters.chainTime,
+		store:                       parameters.store,
+		attesterDutiesProvider:      parameters.attesterDutiesProvider,
+		proposerDutiesProvider:      parameters.proposerDutiesProvider,
+		syncCommitteeDutiesProvider: parameters.syncCommitteeDutiesProvider,
+		beaconBlockHeadersProvider:  parameters.beaconBlockHeadersProvider,
+	}, nil
+}
+
+// AttesterDuties obtains attester duties.
+func (s *Service) AttesterDuties(ctx context.Context,
+	opts *api.AttesterDutiesOpts,
+) (
+	*api.Response[[]*apiv1.AttesterDuty],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	// Attester duties for an epoch depend on the last block of the epoch two before it.
+	if opts.Epoch < 2 || opts.Epoch < s.chainTime.CurrentEpoch() {
+		return s.attesterDutiesProvider.AttesterDuties(ctx, opts)
+	}
+	dependentSlot := s.chainTime.FirstSlotOfEpoch(opts.Epoch-1) - 1
+
+	return duties(ctx, s, key(attesterDuty, opts.Epoch, opts.Indices), &dependentSlot, func() (*api.Response[[]*apiv1.AttesterDuty], error) {
+		return s.attesterDutiesProvider.AttesterDuties(ctx, opts)
+	})
+}
+
+// ProposerDuties obtains proposer duties.
+func (s *Service) ProposerDuties(ctx context.Context,
+	opts *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	// Proposer duties for an epoch depend on the last block of the epoch before it.
+	if opts.Epoch < 1 || opts.Epoch < s.chainTime.CurrentEpoch() {
+		return s.proposerDutiesProvider.ProposerDuties(ctx, opts)
+	}
+	dependentSlot := s.chainTime.FirstSlotOfEpoch(opts.Epoch) - 1
+
+	return duties(ctx, s, key(proposerDuty, opts.Epoch, opts.Indices), &dependentSlot, func() (*api.Response[[]*apiv1.ProposerDuty], error) {
+		return s.proposerDutiesProvider.ProposerDuties(ctx, opts)
+	})
+}
+
+// SyncCommitteeDuties obtains sync committee duties.
+func (s *Service) SyncCommitteeDuties(ctx context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	// Sync committees are fixed a period in advance, so have no dependent block.
+	return duties(ctx, s, key(syncCommitteeDuty, opts.Epoch, opts.Indices), nil, func() (*api.Response[[]*apiv1.SyncCommitteeDuty], error) {
+		return s.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, opts)
+	})
+}
+
+// duties obtains duties from the store if they are present and still valid,
+// otherwise from the upstream provider, storing the result.
+func duties[T any](ctx context.Context,
+	s *Service,
+	key string,
+	dependentSlot *phase0.Slot,
+	upstream func() (*api.Response[T], error),
+) (
+	*api.Response[T],
+	error,
+) {
+	log := log.With().Str("key", key).Logger()
+
+	if response, ok := storedResponse[T](ctx, s, key, dependentSlot); ok {
+		log.Debug().Msg("Obtained duties from store")
+		return response, nil
+	}
+
+	response, err := upstream()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &storedDuties{}
+	if dependentSlot != nil {
+		root, isRoot := response.Metadata["dependent_root"].(phase0.Root)
+		if !isRoot {
+			log.Trace().Msg("No dependent root for duties; not storing")
+			return response, nil
+		}
+		stored.DependentRoot = &root
+	}
+	stored.Duties, err = json.Marshal(response.Data)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to marshal duties for store")
+		return response, nil
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to marshal stored duties")
+		return response, nil
+	}
+	if err := s.store.Put(ctx, key, data); err != nil {
+		log.Warn().Err(err).Msg("Failed to store duties")
+		return response, nil
+	}
+	s.prune(ctx)
+
+	return response, nil
+}
+
+// storedResponse returns the duties for the given key from the store, if
+// present and still valid.
+func storedResponse[T any](ctx context.Context,
+	s *Service,
+	key string,
+	dependentSlot *phase0.Slot,
+) (
+	*api.Response[T],
+	bool,
+) {
+	log := log.With().Str("key", key).Logger()
+
+	data, err := s.store.Get(ctx, key)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain duties from store")
+		return nil, false
+	}
+	if data == nil {
+		return nil, false
+	}
+	stored := &storedDuties{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		log.Warn().Err(err).Msg("Failed to unmarshal stored duties")
+		return nil, false
+	}
+
+	metadata := make(map[string]any)
+	if dependentSlot != nil {
+		headerResponse, err := s.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+			Block: fmt.Sprintf("%d", *dependentSlot),
+		})
+		if err != nil {
+			// This includes the dependent slot being empty, in which case the
+			// duties are obtained from the upstream provider.
+			log.Debug().Err(err).Msg("Failed to obtain dependent block for stored duties")
+			return nil, false
+		}
+		if stored.DependentRoot == nil || *stored.DependentRoot != headerResponse.Data.Root {
+			log.Debug().Stringer("block_root", headerResponse.Data.Root).Msg("Dependent root of stored duties has changed")
+			return nil, false
+		}
+		metadata["dependent_root"] = *stored.DependentRoot
+	}
+
+	var duties T
+	if err := json.Unmarshal(stored.Duties, &duties); err != nil {
+		log.Warn().Err(err).Msg("Failed to unmarshal stored duties")
+		return nil, false
+	}
+
+	return &api.Response[T]{
+		Data:     duties,
+		Metadata: metadata,
+	}, true
+}
+
+// prune removes duties that are no longer required from the store.  Attester
+// and proposer duties are removed once their epoch has passed; sync committee
+// duties are retained for the latest two periods, being the current and next.
+func (s *Service) prune(ctx context.Context) {
+	keys, err := s.store.Keys(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain keys to prune")
+		return
+	}
+
+	currentEpoch := s.chainTime.CurrentEpoch()
+	syncCommitteeEpochs := make(map[phase0.Epoch]struct{})
+	for _, key := range keys {
+		duty, epoch, ok := parseKey(key)
+		if ok && duty == syncCommitteeDuty {
+			syncCommitteeEpochs[epoch] = struct{}{}
+		}
+	}
+	retainedSyncCommitteeEpochs := make([]phase0.Epoch, 0, len(syncCommitteeEpochs))
+	for epoch := range syncCommitteeEpochs {
+		retainedSyncCommitteeEpochs = append(retainedSyncCommitteeEpochs, epoch)
+	}
+	sort.Slice(retainedSyncCommitteeEpochs, func(i, j int) bool {
+		return retainedSyncCommitteeEpochs[i] > retainedSyncCommitteeEpochs[j]
+	})
+	minSyncCommitteeEpoch := phase0.Epoch(0)
+	if len(retainedSyncCommitteeEpochs) > 2 {
+		minSyncCommitteeEpoch = retainedSyncCommitteeEpochs[1]
+	}
+
+	for _, key := range keys {
+		duty, epoch, ok := parseKey(key)
+		if !ok {
+			continue
+		}
+		if (duty == syncCommitteeDuty && epoch < minSyncCommitteeEpoch) ||
+			(duty != syncCommitteeDuty && epoch < currentEpoch) {
+			if err := s.store.Delete(ctx, key); err != nil {
+				log.Warn().Str("key", key).Err(err).Msg("Failed to prune duties")
+			}
+		}
+	}
+}
+
+// key returns the store key for duties of the given type, epoch and validators.
+func key(duty string, epoch phase0.Epoch, indices []phase0.ValidatorIndex) string {
+	sortedIndices := make([]phase0.ValidatorIndex, len(indices))
+	copy(sortedIndices, indices)
+	sort.Slice(sortedIndices, func(i, j int) bool { return sortedIndices[i] < sortedIndices[j] })
+
+	hash := sha256.New()
+	buf := make([]byte, 8)
+	for _, index := range sortedIndices {
+		binary.LittleEndian.PutUint64(buf, uint64(index))
+		hash.Write(buf)
+	}
+
+	return fmt.Sprintf("%s-%d-%s", duty, epoch, hex.EncodeToString(hash.Sum(nil)[:8]))
+}
+
+// parseKey returns the type of duty and epoch for the given store key.
+func parseKey(key string) (string, phase0.Epoch, bool) {
+	parts := strings.Split(key, "-")
+	if len(parts) != 3 {
+		return "", 0, false
+	}
+	epoch, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return parts[0], phase0.Epoch(epoch), true
+}
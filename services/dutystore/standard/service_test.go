@@ -0,0 +1,406 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/dutystore/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore is an in-memory duty store.
+type memoryStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = data
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data[key], nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+
+	return nil
+}
+
+func (s *memoryStore) Keys(_ context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// dutiesProvider counts requests for duties.
+type dutiesProvider struct {
+	dependentRoot *phase0.Root
+	requests      int
+}
+
+func (p *dutiesProvider) metadata() map[string]any {
+	metadata := make(map[string]any)
+	if p.dependentRoot != nil {
+		metadata["dependent_root"] = *p.dependentRoot
+	}
+
+	return metadata
+}
+
+func (p *dutiesProvider) AttesterDuties(_ context.Context,
+	opts *api.AttesterDutiesOpts,
+) (
+	*api.Response[[]*apiv1.AttesterDuty],
+	error,
+) {
+	p.requests++
+
+	return &api.Response[[]*apiv1.AttesterDuty]{
+		Data: []*apiv1.AttesterDuty{
+			{
+				ValidatorIndex:   opts.Indices[0],
+				Slot:             phase0.Slot(uint64(opts.Epoch) * 32),
+				CommitteeIndex:   1,
+				CommitteeLength:  128,
+				CommitteesAtSlot: 4,
+			},
+		},
+		Metadata: p.metadata(),
+	}, nil
+}
+
+func (p *dutiesProvider) ProposerDuties(_ context.Context,
+	opts *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	p.requests++
+
+	return &api.Response[[]*apiv1.ProposerDuty]{
+		Data: []*apiv1.ProposerDuty{
+			{
+				ValidatorIndex: opts.Indices[0],
+				Slot:           phase0.Slot(uint64(opts.Epoch) * 32),
+			},
+		},
+		Metadata: p.metadata(),
+	}, nil
+}
+
+func (p *dutiesProvider) SyncCommitteeDuties(_ context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	p.requests++
+
+	return &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data: []*apiv1.SyncCommitteeDuty{
+			{
+				ValidatorIndex:                opts.Indices[0],
+				ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{3},
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// headersProvider returns headers with a given root.
+type headersProvider struct {
+	root phase0.Root
+	err  error
+}
+
+func (p *headersProvider) BeaconBlockHeader(_ context.Context,
+	_ *api.BeaconBlockHeaderOpts,
+) (
+	*api.Response[*apiv1.BeaconBlockHeader],
+	error,
+) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &api.Response[*apiv1.BeaconBlockHeader]{
+		Data: &apiv1.BeaconBlockHeader{
+			Root: p.root,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	store := &memoryStore{data: make(map[string][]byte)}
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "ChainTimeMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithStore(store),
+				standard.WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+				standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+				standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+				standard.WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+			},
+			err: "problem with parameters: no chaintime service specified",
+		},
+		{
+			name: "StoreMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+				standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+				standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+				standard.WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+			},
+			err: "problem with parameters: no store specified",
+		},
+		{
+			name: "AttesterDutiesProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithStore(store),
+				standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+				standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+				standard.WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+			},
+			err: "problem with parameters: no attester duties provider specified",
+		},
+		{
+			name: "BeaconBlockHeadersProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithStore(store),
+				standard.WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+				standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+				standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+			},
+			err: "problem with parameters: no beacon block headers provider specified",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithStore(store),
+				standard.WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+				standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+				standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+				standard.WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// newService creates a duty store, as it would be on each start of Vouch.
+func newService(ctx context.Context,
+	t *testing.T,
+	store *memoryStore,
+	provider *dutiesProvider,
+	headers *headersProvider,
+) *standard.Service {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-time.Hour))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithChainTime(chainTime),
+		standard.WithStore(store),
+		standard.WithAttesterDutiesProvider(provider),
+		standard.WithProposerDutiesProvider(provider),
+		standard.WithSyncCommitteeDutiesProvider(provider),
+		standard.WithBeaconBlockHeadersProvider(headers),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestAttesterDuties(t *testing.T) {
+	ctx := context.Background()
+
+	dependentRoot := phase0.Root{0x01}
+	store := &memoryStore{data: make(map[string][]byte)}
+	provider := &dutiesProvider{dependentRoot: &dependentRoot}
+	headers := &headersProvider{root: dependentRoot}
+	opts := &api.AttesterDutiesOpts{
+		Epoch:   20,
+		Indices: []phase0.ValidatorIndex{1, 2},
+	}
+
+	// First start obtains duties from the provider.
+	s := newService(ctx, t, store, provider, headers)
+	response, err := s.AttesterDuties(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, provider.requests)
+	expected := response.Data
+
+	// Restart obtains duties from the store.
+	s = newService(ctx, t, store, provider, headers)
+	response, err = s.AttesterDuties(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, provider.requests)
+	require.Equal(t, expected, response.Data)
+	require.Equal(t, dependentRoot, response.Metadata["dependent_root"])
+
+	// Validators in a different order are the same duties.
+	_, err = s.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   20,
+		Indices: []phase0.ValidatorIndex{2, 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, provider.requests)
+
+	// Different validators are not.
+	_, err = s.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   20,
+		Indices: []phase0.ValidatorIndex{1, 2, 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, provider.requests)
+
+	// Reorganisation changes the dependent root, so duties are obtained from the provider.
+	headers.root = phase0.Root{0x02}
+	_, err = s.AttesterDuties(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 3, provider.requests)
+
+	// Failure to obtain the dependent block obtains duties from the provider.
+	headers.err = errors.New("not found")
+	_, err = s.AttesterDuties(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 4, provider.requests)
+}
+
+func TestNoDependentRoot(t *testing.T) {
+	ctx := context.Background()
+
+	store := &memoryStore{data: make(map[string][]byte)}
+	provider := &dutiesProvider{}
+	headers := &headersProvider{root: phase0.Root{0x01}}
+	opts := &api.ProposerDutiesOpts{
+		Epoch:   20,
+		Indices: []phase0.ValidatorIndex{1},
+	}
+
+	// Duties without a dependent root cannot be checked so are not stored.
+	s := newService(ctx, t, store, provider, headers)
+	_, err := s.ProposerDuties(ctx, opts)
+	require.NoError(t, err)
+	_, err = s.ProposerDuties(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 2, provider.requests)
+	require.Empty(t, store.data)
+}
+
+func TestPastEpoch(t *testing.T) {
+	ctx := context.Background()
+
+	dependentRoot := phase0.Root{0x01}
+	store := &memoryStore{data: make(map[string][]byte)}
+	provider := &dutiesProvider{dependentRoot: &dependentRoot}
+	headers := &headersProvider{root: dependentRoot}
+
+	// Duties for past epochs are not stored.
+	s := newService(ctx, t, store, provider, headers)
+	_, err := s.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   2,
+		Indices: []phase0.ValidatorIndex{1},
+	})
+	require.NoError(t, err)
+	require.Empty(t, store.data)
+}
+
+func TestSyncCommitteeDuties(t *testing.T) {
+	ctx := context.Background()
+
+	store := &memoryStore{data: make(map[string][]byte)}
+	provider := &dutiesProvider{}
+	headers := &headersProvider{err: errors.New("not used")}
+
+	s := newService(ctx, t, store, provider, headers)
+	for _, epoch := range []phase0.Epoch{0, 256, 512, 256} {
+		_, err := s.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   epoch,
+			Indices: []phase0.ValidatorIndex{1},
+		})
+		require.NoError(t, err)
+	}
+	// The final request is served from the store.
+	require.Equal(t, 3, provider.requests)
+	// Only the latest two periods are retained.
+	require.Len(t, store.data, 2)
+}
@@ -24,6 +24,7 @@ type parameters struct {
 	logLevel  zerolog.Level
 	location  string
 	majordomo majordomo.Service
+	selection Selection
 }
 
 // Parameter is the interface for service parameters.
@@ -58,6 +59,13 @@ func WithMajordomo(majordomo majordomo.Service) Parameter {
 	})
 }
 
+// WithSelection sets how a line is selected when multiple lines of graffiti are available.
+func WithSelection(selection Selection) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.selection = selection
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -75,6 +83,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.location == "" {
 		return nil, errors.New("no location specified")
 	}
+	if parameters.selection != SelectionRandom && parameters.selection != SelectionRotate {
+		return nil, errors.New("unknown selection")
+	}
 
 	return &parameters, nil
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -27,10 +28,37 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+// Selection is how a line is selected when multiple lines of graffiti are available.
+type Selection int
+
+const (
+	// SelectionRandom selects a line at random.
+	SelectionRandom Selection = iota
+	// SelectionRotate selects each line in turn.
+	SelectionRotate
+)
+
+// ParseSelection parses a selection.
+func ParseSelection(input string) (Selection, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "random":
+		return SelectionRandom, nil
+	case "rotate":
+		return SelectionRotate, nil
+	default:
+		return SelectionRandom, fmt.Errorf("unrecognised selection %q", input)
+	}
+}
+
 // Service is a graffiti provider service.
 type Service struct {
 	location  string
 	majordomo majordomo.Service
+	selection Selection
+
+	// rotations holds the next line to select for each location when rotating.
+	rotationsMu sync.Mutex
+	rotations   map[string]int
 }
 
 // module-wide log.
@@ -52,6 +80,8 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	s := &Service{
 		location:  parameters.location,
 		majordomo: parameters.majordomo,
+		selection: parameters.selection,
+		rotations: make(map[string]int),
 	}
 
 	return s, nil
@@ -86,10 +116,8 @@ func (s *Service) Graffiti(ctx context.Context, slot phase0.Slot, validatorIndex
 		return []byte{}, nil
 	}
 
-	// Pick a single line.  If multiple lines are available choose one at random.
-	// #nosec G404
-	graffitiIdx := rand.Intn(graffitiEntries)
-	graffiti := graffitiLines[graffitiIdx]
+	// Pick a single line.  If multiple lines are available choose one according to the selection.
+	graffiti := graffitiLines[s.selectLine(location, graffitiEntries)]
 
 	// Replace graffiti parameters with values.
 	graffiti = strings.ReplaceAll(graffiti, "{{SLOT}}", fmt.Sprintf("%d", slot))
@@ -98,3 +126,19 @@ func (s *Service) Graffiti(ctx context.Context, slot phase0.Slot, validatorIndex
 	log.Trace().Str("graffiti", graffiti).Msg("Resolved graffiti")
 	return []byte(graffiti), nil
 }
+
+// selectLine selects the index of the line to use from the given number of lines.
+func (s *Service) selectLine(location string, lines int) int {
+	if s.selection == SelectionRotate {
+		s.rotationsMu.Lock()
+		defer s.rotationsMu.Unlock()
+		// The number of lines can change between fetches, so wrap on use.
+		idx := s.rotations[location] % lines
+		s.rotations[location] = idx + 1
+
+		return idx
+	}
+
+	// #nosec G404
+	return rand.Intn(lines)
+}
@@ -44,6 +44,7 @@ func TestService(t *testing.T) {
 		name      string
 		majordomo majordomo.Service
 		location  string
+		selection dynamic.Selection
 		err       string
 	}{
 		{
@@ -56,6 +57,13 @@ func TestService(t *testing.T) {
 			majordomo: majordomoSvc,
 			err:       "problem with parameters: no location specified",
 		},
+		{
+			name:      "SelectionUnknown",
+			majordomo: majordomoSvc,
+			location:  "direct://static",
+			selection: dynamic.Selection(99),
+			err:       "problem with parameters: unknown selection",
+		},
 		{
 			name:      "Good",
 			majordomo: majordomoSvc,
@@ -68,7 +76,8 @@ func TestService(t *testing.T) {
 			_, err := dynamic.New(ctx,
 				dynamic.WithLogLevel(zerolog.Disabled),
 				dynamic.WithMajordomo(test.majordomo),
-				dynamic.WithLocation(test.location))
+				dynamic.WithLocation(test.location),
+				dynamic.WithSelection(test.selection))
 
 			if test.err != "" {
 				require.EqualError(t, err, test.err)
@@ -309,3 +318,47 @@ func TestMultiline(t *testing.T) {
 		})
 	}
 }
+
+func TestRotate(t *testing.T) {
+	ctx := context.Background()
+	majordomoSvc, err := standardmajordomo.New(ctx)
+	require.NoError(t, err)
+	fileConfidant, err := fileconfidant.New(ctx)
+	require.NoError(t, err)
+	err = majordomoSvc.RegisterConfidant(ctx, fileConfidant)
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "graffiti.txt"), []byte("Line 1\nLine 2\nLine 3\n"), 0o600))
+
+	svc, err := dynamic.New(ctx,
+		dynamic.WithLogLevel(zerolog.Disabled),
+		dynamic.WithMajordomo(majordomoSvc),
+		dynamic.WithLocation(fmt.Sprintf("file://%s/graffiti.txt", tmpDir)),
+		dynamic.WithSelection(dynamic.SelectionRotate))
+	require.NoError(t, err)
+
+	// Lines are selected in turn, wrapping at the end.
+	for _, expected := range []string{"Line 1", "Line 2", "Line 3", "Line 1"} {
+		graffiti, err := svc.Graffiti(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(graffiti))
+	}
+
+	// Rotation continues if the number of lines changes.
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "graffiti.txt"), []byte("Line A\nLine B\n"), 0o600))
+	graffiti, err := svc.Graffiti(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, "Line B", string(graffiti))
+}
+
+func TestParseSelection(t *testing.T) {
+	selection, err := dynamic.ParseSelection("")
+	require.NoError(t, err)
+	require.Equal(t, dynamic.SelectionRandom, selection)
+	selection, err = dynamic.ParseSelection("Rotate")
+	require.NoError(t, err)
+	require.Equal(t, dynamic.SelectionRotate, selection)
+	_, err = dynamic.ParseSelection("sequential")
+	require.EqualError(t, err, `unrecognised selection "sequential"`)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package override
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/graffitiprovider"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	overrides map[phase0.ValidatorIndex][]byte
+	provider  graffitiprovider.Service
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithOverrides sets the graffiti for individual validators.
+func WithOverrides(overrides map[phase0.ValidatorIndex][]byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.overrides = overrides
+	})
+}
+
+// WithProvider sets the graffiti provider for validators without an override.
+func WithProvider(provider graffitiprovider.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.provider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.provider == nil {
+		return nil, errors.New("no provider specified")
+	}
+	for validatorIndex, graffiti := range parameters.overrides {
+		if len(graffiti) > 32 {
+			return nil, fmt.Errorf("graffiti for validator %d has a maximum size of 32 bytes", validatorIndex)
+		}
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package override is a graffiti provider that provides graffiti for
+// individual validators, using another graffiti provider for all others.
+package override
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/graffitiprovider"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a graffiti provider service.
+type Service struct {
+	overrides map[phase0.ValidatorIndex][]byte
+	provider  graffitiprovider.Service
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new graffiti provider service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "graffitiprovider").Str("impl", "override").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		overrides: parameters.overrides,
+		provider:  parameters.provider,
+	}
+
+	return s, nil
+}
+
+// Graffiti provides graffiti.
+func (s *Service) Graffiti(ctx context.Context, slot phase0.Slot, validatorIndex phase0.ValidatorIndex) ([]byte, error) {
+	if graffiti, exists := s.overrides[validatorIndex]; exists {
+		log.Trace().Uint64("validator_index", uint64(validatorIndex)).Msg("Using graffiti override")
+		return graffiti, nil
+	}
+
+	return s.provider.Graffiti(ctx, slot, validatorIndex)
+}
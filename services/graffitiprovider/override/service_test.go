@@ -0,0 +1,115 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package override_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/graffitiprovider/override"
+	"github.com/attestantio/vouch/services/graffitiprovider/static"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := static.New(ctx,
+		static.WithLogLevel(zerolog.Disabled),
+		static.WithGraffiti([]byte("default")),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []override.Parameter
+		err    string
+	}{
+		{
+			name: "ProviderMissing",
+			params: []override.Parameter{
+				override.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no provider specified",
+		},
+		{
+			name: "OverrideLong",
+			params: []override.Parameter{
+				override.WithLogLevel(zerolog.Disabled),
+				override.WithProvider(provider),
+				override.WithOverrides(map[phase0.ValidatorIndex][]byte{
+					5: []byte("123456789012345678901234567890123"),
+				}),
+			},
+			err: "problem with parameters: graffiti for validator 5 has a maximum size of 32 bytes",
+		},
+		{
+			name: "OverridesMissing",
+			params: []override.Parameter{
+				override.WithLogLevel(zerolog.Disabled),
+				override.WithProvider(provider),
+			},
+		},
+		{
+			name: "Good",
+			params: []override.Parameter{
+				override.WithLogLevel(zerolog.Disabled),
+				override.WithProvider(provider),
+				override.WithOverrides(map[phase0.ValidatorIndex][]byte{
+					5: []byte("override"),
+				}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := override.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGraffiti(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := static.New(ctx,
+		static.WithLogLevel(zerolog.Disabled),
+		static.WithGraffiti([]byte("default")),
+	)
+	require.NoError(t, err)
+
+	s, err := override.New(ctx,
+		override.WithLogLevel(zerolog.Disabled),
+		override.WithProvider(provider),
+		override.WithOverrides(map[phase0.ValidatorIndex][]byte{
+			5: []byte("validator {{VALIDATORINDEX}}"),
+		}),
+	)
+	require.NoError(t, err)
+
+	graffiti, err := s.Graffiti(ctx, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, "validator {{VALIDATORINDEX}}", string(graffiti))
+
+	graffiti, err = s.Graffiti(ctx, 1, 6)
+	require.NoError(t, err)
+	require.Equal(t, "default", string(graffiti))
+}
@@ -0,0 +1,195 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remote is a graffiti provider that fetches graffiti from a remote
+// HTTP endpoint, allowing graffiti to be managed centrally.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+// maxResponseSize is the maximum size of a response that will be read.
+const maxResponseSize = 4096
+
+// cacheEntry is graffiti fetched from a URL.
+type cacheEntry struct {
+	graffiti   []byte
+	expires    time.Time
+	refreshing bool
+}
+
+// graffitiJSON is the JSON form of a graffiti response.
+type graffitiJSON struct {
+	Graffiti string `json:"graffiti"`
+}
+
+// Service is a graffiti provider service.
+type Service struct {
+	url      string
+	timeout  time.Duration
+	ttl      time.Duration
+	fallback []byte
+	client   *http.Client
+
+	cacheMu sync.Mutex
+	cache   map[string]*cacheEntry
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new graffiti provider service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "graffitiprovider").Str("impl", "remote").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		url:      parameters.url,
+		timeout:  parameters.timeout,
+		ttl:      parameters.ttl,
+		fallback: parameters.fallback,
+		client:   util.NewHTTPClient(parameters.timeout),
+		cache:    make(map[string]*cacheEntry),
+	}
+
+	return s, nil
+}
+
+// Graffiti provides graffiti.
+// Cached graffiti is returned immediately, and refreshed in the background
+// once it has expired, so that a slow or unavailable endpoint does not delay
+// a proposal.  If no graffiti has been fetched the fallback graffiti is used.
+func (s *Service) Graffiti(ctx context.Context, slot phase0.Slot, validatorIndex phase0.ValidatorIndex) ([]byte, error) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.graffitiprovider.remote").Start(ctx, "Graffiti")
+	defer span.End()
+
+	url := strings.ReplaceAll(s.url, "{{SLOT}}", fmt.Sprintf("%d", slot))
+	url = strings.ReplaceAll(url, "{{VALIDATORINDEX}}", fmt.Sprintf("%d", validatorIndex))
+
+	s.cacheMu.Lock()
+	entry, exists := s.cache[url]
+	if exists {
+		graffiti := entry.graffiti
+		if time.Now().After(entry.expires) && !entry.refreshing {
+			entry.refreshing = true
+			// The request context ends with the proposal, so refresh with a new context.
+			go s.refresh(context.Background(), url)
+		}
+		s.cacheMu.Unlock()
+		return graffiti, nil
+	}
+	s.cacheMu.Unlock()
+
+	graffiti, err := s.fetch(ctx, url)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch graffiti; using fallback")
+		return s.fallback, nil
+	}
+	s.store(url, graffiti)
+
+	return graffiti, nil
+}
+
+// refresh refreshes the cached graffiti for the URL.
+func (s *Service) refresh(ctx context.Context, url string) {
+	graffiti, err := s.fetch(ctx, url)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to refresh graffiti; continuing to use previous graffiti")
+		s.cacheMu.Lock()
+		s.cache[url].refreshing = false
+		s.cacheMu.Unlock()
+		return
+	}
+	s.store(url, graffiti)
+}
+
+// store stores graffiti in the cache, removing entries that have not been
+// used since they expired.
+func (s *Service) store(url string, graffiti []byte) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	now := time.Now()
+	for cachedURL, entry := range s.cache {
+		if !entry.refreshing && now.After(entry.expires.Add(s.ttl)) {
+			delete(s.cache, cachedURL)
+		}
+	}
+	s.cache[url] = &cacheEntry{
+		graffiti: graffiti,
+		expires:  now.Add(s.ttl),
+	}
+}
+
+// fetch fetches graffiti from the URL.
+func (s *Service) fetch(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+
+	var graffiti string
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		res := &graffitiJSON{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return nil, errors.Wrap(err, "invalid JSON response")
+		}
+		graffiti = res.Graffiti
+	} else {
+		// Use the first line of a plain text response.
+		graffiti, _, _ = strings.Cut(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	}
+	graffiti = strings.TrimSpace(graffiti)
+	log.Trace().Str("url", url).Str("graffiti", graffiti).Msg("Fetched graffiti")
+
+	return []byte(graffiti), nil
+}
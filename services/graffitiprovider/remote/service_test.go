@@ -0,0 +1,207 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/graffitiprovider/remote"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []remote.Parameter
+		err    string
+	}{
+		{
+			name: "URLMissing",
+			params: []remote.Parameter{
+				remote.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no URL specified",
+		},
+		{
+			name: "TimeoutZero",
+			params: []remote.Parameter{
+				remote.WithLogLevel(zerolog.Disabled),
+				remote.WithURL("http://localhost/graffiti"),
+				remote.WithTimeout(0),
+			},
+			err: "problem with parameters: timeout must be positive",
+		},
+		{
+			name: "TTLZero",
+			params: []remote.Parameter{
+				remote.WithLogLevel(zerolog.Disabled),
+				remote.WithURL("http://localhost/graffiti"),
+				remote.WithTTL(0),
+			},
+			err: "problem with parameters: TTL must be positive",
+		},
+		{
+			name: "FallbackLong",
+			params: []remote.Parameter{
+				remote.WithLogLevel(zerolog.Disabled),
+				remote.WithURL("http://localhost/graffiti"),
+				remote.WithFallback([]byte("123456789012345678901234567890123")),
+			},
+			err: "problem with parameters: fallback graffiti has a maximum size of 32 bytes",
+		},
+		{
+			name: "Good",
+			params: []remote.Parameter{
+				remote.WithLogLevel(zerolog.Disabled),
+				remote.WithURL("http://localhost/graffiti"),
+				remote.WithFallback([]byte("fallback")),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := remote.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGraffiti(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/text/5", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("graffiti for 5\r\nsecond line\r\n"))
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"graffiti":"json graffiti"}`))
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "Text",
+			url:      server.URL + "/text/{{VALIDATORINDEX}}",
+			expected: "graffiti for 5",
+		},
+		{
+			name:     "JSON",
+			url:      server.URL + "/json",
+			expected: "json graffiti",
+		},
+		{
+			name:     "Error",
+			url:      server.URL + "/error",
+			expected: "fallback",
+		},
+		{
+			name:     "Unreachable",
+			url:      "http://localhost:1/graffiti",
+			expected: "fallback",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := remote.New(ctx,
+				remote.WithLogLevel(zerolog.Disabled),
+				remote.WithURL(test.url),
+				remote.WithFallback([]byte("fallback")),
+			)
+			require.NoError(t, err)
+
+			graffiti, err := s.Graffiti(ctx, 1, 5)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(graffiti))
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if requests.Add(1) == 1 {
+			_, _ = w.Write([]byte("first"))
+		} else {
+			_, _ = w.Write([]byte("second"))
+		}
+	}))
+	defer server.Close()
+
+	s, err := remote.New(ctx,
+		remote.WithLogLevel(zerolog.Disabled),
+		remote.WithURL(server.URL),
+		remote.WithTTL(100*time.Millisecond),
+		remote.WithFallback([]byte("fallback")),
+	)
+	require.NoError(t, err)
+
+	// Graffiti is cached until the TTL expires.
+	graffiti, err := s.Graffiti(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(graffiti))
+	graffiti, err = s.Graffiti(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(graffiti))
+	require.Equal(t, int32(1), requests.Load())
+
+	// Expired graffiti is returned whilst it is refreshed in the background.
+	time.Sleep(150 * time.Millisecond)
+	graffiti, err = s.Graffiti(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(graffiti))
+	require.Eventually(t, func() bool {
+		graffiti, err := s.Graffiti(ctx, 1, 1)
+		return err == nil && string(graffiti) == "second"
+	}, time.Second, 10*time.Millisecond)
+
+	// Previous graffiti continues to be used if a refresh fails.
+	failing.Store(true)
+	time.Sleep(150 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		graffiti, err = s.Graffiti(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, "second", string(graffiti))
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/accountmanager"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/keymanagerapi/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// keystoreManager is a keystore manager that holds public keys in memory.
+type keystoreManager struct {
+	keystores map[phase0.BLSPubKey]bool
+}
+
+func newKeystoreManager() *keystoreManager {
+	return &keystoreManager{
+		keystores: map[phase0.BLSPubKey]bool{
+			{0x01}: true,
+		},
+	}
+}
+
+func (m *keystoreManager) Keystores(_ context.Context) ([]*accountmanager.Keystore, error) {
+	res := make([]*accountmanager.Keystore, 0, len(m.keystores))
+	for pubkey, readOnly := range m.keystores {
+		res = append(res, &accountmanager.Keystore{PublicKey: pubkey, ReadOnly: readOnly})
+	}
+
+	return res, nil
+}
+
+func (m *keystoreManager) ImportKeystore(_ context.Context, keystore []byte, password string) (phase0.BLSPubKey, error) {
+	if password != "pass" {
+		return phase0.BLSPubKey{}, fmt.Errorf("incorrect password")
+	}
+	pubkey := phase0.BLSPubKey{keystore[0]}
+	if _, exists := m.keystores[pubkey]; exists {
+		return pubkey, accountmanager.ErrAccountExists
+	}
+	m.keystores[pubkey] = false
+
+	return pubkey, nil
+}
+
+func (m *keystoreManager) DeleteKeystore(_ context.Context, pubkey phase0.BLSPubKey) error {
+	readOnly, exists := m.keystores[pubkey]
+	if !exists {
+		return accountmanager.ErrAccountNotFound
+	}
+	if readOnly {
+		return accountmanager.ErrAccountReadOnly
+	}
+	delete(m.keystores, pubkey)
+
+	return nil
+}
+
+// proposerConfigOverrider is a proposer config overrider that holds overrides in memory.
+type proposerConfigOverrider struct {
+	feeRecipients map[phase0.BLSPubKey]bellatrix.ExecutionAddress
+	gasLimits     map[phase0.BLSPubKey]uint64
+}
+
+func newProposerConfigOverrider() *proposerConfigOverrider {
+	return &proposerConfigOverrider{
+		feeRecipients: make(map[phase0.BLSPubKey]bellatrix.ExecutionAddress),
+		gasLimits:     make(map[phase0.BLSPubKey]uint64),
+	}
+}
+
+func (o *proposerConfigOverrider) ProposerConfig(_ context.Context,
+	_ e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	feeRecipient, exists := o.feeRecipients[pubkey]
+	if !exists {
+		feeRecipient = bellatrix.ExecutionAddress{0xff}
+	}
+
+	return &beaconblockproposer.ProposerConfig{FeeRecipient: feeRecipient}, nil
+}
+
+func (o *proposerConfigOverrider) GasLimit(_ context.Context, _ e2wtypes.Account, pubkey phase0.BLSPubKey) (uint64, error) {
+	gasLimit, exists := o.gasLimits[pubkey]
+	if !exists {
+		gasLimit = 30000000
+	}
+
+	return gasLimit, nil
+}
+
+func (o *proposerConfigOverrider) SetFeeRecipientOverride(_ context.Context, pubkey phase0.BLSPubKey, feeRecipient bellatrix.ExecutionAddress) {
+	o.feeRecipients[pubkey] = feeRecipient
+}
+
+func (o *proposerConfigOverrider) ClearFeeRecipientOverride(_ context.Context, pubkey phase0.BLSPubKey) {
+	delete(o.feeRecipients, pubkey)
+}
+
+func (o *proposerConfigOverrider) SetGasLimitOverride(_ context.Context, pubkey phase0.BLSPubKey, gasLimit uint64) {
+	o.gasLimits[pubkey] = gasLimit
+}
+
+func (o *proposerConfigOverrider) ClearGasLimitOverride(_ context.Context, pubkey phase0.BLSPubKey) {
+	delete(o.gasLimits, pubkey)
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return address
+}
+
+func TestHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	address := freeAddress(t)
+	overrider := newProposerConfigOverrider()
+	_, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithListenAddress(address),
+		standard.WithBearerToken("secret"),
+		standard.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standard.WithAccountsProvider(mockaccountmanager.NewAccountsProvider()),
+		standard.WithKeystoreManager(newKeystoreManager()),
+		standard.WithExecutionConfigProvider(overrider),
+		standard.WithProposerConfigOverrider(overrider),
+	)
+	require.NoError(t, err)
+
+	pubkey := fmt.Sprintf("%#x", phase0.BLSPubKey{0x02})
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		token        string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "TokenMissing",
+			method:       http.MethodGet,
+			path:         "/eth/v1/keystores",
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"code":401,"message":"missing bearer token"}`,
+		},
+		{
+			name:         "TokenIncorrect",
+			method:       http.MethodGet,
+			path:         "/eth/v1/keystores",
+			token:        "wrong",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"code":403,"message":"invalid bearer token"}`,
+		},
+		{
+			name:         "ImportMismatchedPasswords",
+			method:       http.MethodPost,
+			path:         "/eth/v1/keystores",
+			token:        "secret",
+			body:         `{"keystores":["\u0002"],"passwords":[]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":400,"message":"number of keystores and passwords differ"}`,
+		},
+		{
+			name:         "Import",
+			method:       http.MethodPost,
+			path:         "/eth/v1/keystores",
+			token:        "secret",
+			body:         `{"keystores":["\u0002","\u0001","\u0003"],"passwords":["pass","pass","bad"]}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":[{"status":"imported"},{"status":"duplicate"},{"status":"error","message":"incorrect password"}]}`,
+		},
+		{
+			name:         "Delete",
+			method:       http.MethodDelete,
+			path:         "/eth/v1/keystores",
+			token:        "secret",
+			body:         fmt.Sprintf(`{"pubkeys":["%s","%#x","%#x","0x1234"]}`, pubkey, phase0.BLSPubKey{0x01}, phase0.BLSPubKey{0x03}),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":[{"status":"deleted"},{"status":"error","message":"account is read-only"},{"status":"not_found"},{"status":"error","message":"invalid public key \"0x1234\""}],"slashing_protection":"{\"metadata\":{\"interchange_format_version\":\"5\",\"genesis_validators_root\":\"0x0000000000000000000000000000000000000000000000000000000000000000\"},\"data\":[]}"}`,
+		},
+		{
+			name:         "FeeRecipientDefault",
+			method:       http.MethodGet,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/feerecipient", pubkey),
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: fmt.Sprintf(`{"data":{"pubkey":"%s","ethaddress":"0xfF00000000000000000000000000000000000000"}}`, pubkey),
+		},
+		{
+			name:         "FeeRecipientSetInvalid",
+			method:       http.MethodPost,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/feerecipient", pubkey),
+			token:        "secret",
+			body:         `{"ethaddress":"0x1234"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":400,"message":"invalid ethaddress"}`,
+		},
+		{
+			name:         "FeeRecipientSet",
+			method:       http.MethodPost,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/feerecipient", pubkey),
+			token:        "secret",
+			body:         `{"ethaddress":"0x0101010101010101010101010101010101010101"}`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "FeeRecipientOverridden",
+			method:       http.MethodGet,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/feerecipient", pubkey),
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: fmt.Sprintf(`{"data":{"pubkey":"%s","ethaddress":"0x0101010101010101010101010101010101010101"}}`, pubkey),
+		},
+		{
+			name:         "FeeRecipientDelete",
+			method:       http.MethodDelete,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/feerecipient", pubkey),
+			token:        "secret",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "GasLimitSetInvalid",
+			method:       http.MethodPost,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/gaslimit", pubkey),
+			token:        "secret",
+			body:         `{"gas_limit":"0"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":400,"message":"invalid gas_limit"}`,
+		},
+		{
+			name:         "GasLimitSet",
+			method:       http.MethodPost,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/gaslimit", pubkey),
+			token:        "secret",
+			body:         `{"gas_limit":"36000000"}`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "GasLimitOverridden",
+			method:       http.MethodGet,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/gaslimit", pubkey),
+			token:        "secret",
+			expectedCode: http.StatusOK,
+			expectedBody: fmt.Sprintf(`{"data":{"pubkey":"%s","gas_limit":"36000000"}}`, pubkey),
+		},
+		{
+			name:         "ValidatorPathUnknown",
+			method:       http.MethodGet,
+			path:         fmt.Sprintf("/eth/v1/validator/%s/graffiti", pubkey),
+			token:        "secret",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"code":404,"message":"not found"}`,
+		},
+	}
+
+	// Wait for the server to start.
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(ctx, test.method, fmt.Sprintf("http://%s%s", address, test.path), bytes.NewBufferString(test.body))
+			require.NoError(t, err)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, test.expectedCode, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			if test.expectedBody != "" {
+				require.JSONEq(t, test.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestListKeystores(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	address := freeAddress(t)
+	overrider := newProposerConfigOverrider()
+	_, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithListenAddress(address),
+		standard.WithBearerToken("secret"),
+		standard.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standard.WithAccountsProvider(mockaccountmanager.NewAccountsProvider()),
+		standard.WithKeystoreManager(newKeystoreManager()),
+		standard.WithExecutionConfigProvider(overrider),
+		standard.WithProposerConfigOverrider(overrider),
+	)
+	require.NoError(t, err)
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/eth/v1/keystores", address), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err = http.DefaultClient.Do(req)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	res := struct {
+		Data []struct {
+			ValidatingPubkey string `json:"validating_pubkey"`
+			ReadOnly         bool   `json:"readonly"`
+		} `json:"data"`
+	}{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.Len(t, res.Data, 1)
+	require.Equal(t, fmt.Sprintf("%#x", phase0.BLSPubKey{0x01}), res.Data[0].ValidatingPubkey)
+	require.True(t, res.Data[0].ReadOnly)
+}
@@ -0,0 +1,216 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/pkg/errors"
+)
+
+// Statuses for keystore operations, as defined by the keymanager API.
+const (
+	statusImported  = "imported"
+	statusDuplicate = "duplicate"
+	statusDeleted   = "deleted"
+	statusNotFound  = "not_found"
+	statusError     = "error"
+)
+
+// interchangeFormatVersion is the EIP-3076 interchange format version.
+const interchangeFormatVersion = "5"
+
+type keystoreJSON struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path"`
+	ReadOnly         bool   `json:"readonly"`
+}
+
+type listKeystoresResponse struct {
+	Data []*keystoreJSON `json:"data"`
+}
+
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+type keystoreStatusJSON struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type importKeystoresResponse struct {
+	Data []*keystoreStatusJSON `json:"data"`
+}
+
+type deleteKeystoresResponse struct {
+	Data               []*keystoreStatusJSON `json:"data"`
+	SlashingProtection string                `json:"slashing_protection"`
+}
+
+type interchangeMetadataJSON struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type interchangeJSON struct {
+	Metadata *interchangeMetadataJSON `json:"metadata"`
+	Data     []any                    `json:"data"`
+}
+
+// handleKeystores handles requests to the keystores endpoint.
+func (s *Service) handleKeystores(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listKeystores(w, r)
+	case http.MethodPost:
+		s.importKeystores(w, r)
+	case http.MethodDelete:
+		s.deleteKeystores(w, r)
+	default:
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Service) listKeystores(w http.ResponseWriter, r *http.Request) {
+	keystores, err := s.keystoreManager.Keystores(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain keystores")
+		s.sendError(w, http.StatusInternalServerError, "failed to obtain keystores")
+		return
+	}
+
+	res := &listKeystoresResponse{
+		Data: make([]*keystoreJSON, 0, len(keystores)),
+	}
+	for _, keystore := range keystores {
+		res.Data = append(res.Data, &keystoreJSON{
+			ValidatingPubkey: fmt.Sprintf("%#x", keystore.PublicKey),
+			DerivationPath:   keystore.DerivationPath,
+			ReadOnly:         keystore.ReadOnly,
+		})
+	}
+	s.sendResponse(w, http.StatusOK, res)
+}
+
+func (s *Service) importKeystores(w http.ResponseWriter, r *http.Request) {
+	req := &importKeystoresRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		s.sendError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		s.sendError(w, http.StatusBadRequest, "number of keystores and passwords differ")
+		return
+	}
+	if req.SlashingProtection != "" {
+		log.Warn().Msg("Slashing protection data supplied with imported keystores is not retained")
+	}
+
+	res := &importKeystoresResponse{
+		Data: make([]*keystoreStatusJSON, 0, len(req.Keystores)),
+	}
+	for i := range req.Keystores {
+		pubkey, err := s.keystoreManager.ImportKeystore(r.Context(), []byte(req.Keystores[i]), req.Passwords[i])
+		switch {
+		case errors.Is(err, accountmanager.ErrAccountExists):
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusDuplicate})
+		case err != nil:
+			log.Debug().Err(err).Msg("Failed to import keystore")
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusError, Message: err.Error()})
+		default:
+			log.Trace().Stringer("pubkey", pubkey).Msg("Imported keystore")
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusImported})
+		}
+	}
+	s.sendResponse(w, http.StatusOK, res)
+}
+
+func (s *Service) deleteKeystores(w http.ResponseWriter, r *http.Request) {
+	req := &deleteKeystoresRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		s.sendError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	genesisResponse, err := s.genesisProvider.Genesis(r.Context(), &api.GenesisOpts{})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain genesis")
+		s.sendError(w, http.StatusInternalServerError, "failed to obtain genesis")
+		return
+	}
+
+	res := &deleteKeystoresResponse{
+		Data: make([]*keystoreStatusJSON, 0, len(req.Pubkeys)),
+	}
+	for _, input := range req.Pubkeys {
+		pubkey, err := parsePubkey(input)
+		if err != nil {
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusError, Message: err.Error()})
+			continue
+		}
+		err = s.keystoreManager.DeleteKeystore(r.Context(), pubkey)
+		switch {
+		case errors.Is(err, accountmanager.ErrAccountNotFound):
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusNotFound})
+		case err != nil:
+			log.Debug().Err(err).Msg("Failed to delete keystore")
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusError, Message: err.Error()})
+		default:
+			res.Data = append(res.Data, &keystoreStatusJSON{Status: statusDeleted})
+		}
+	}
+
+	// Vouch does not hold slashing protection data for locally-managed keys,
+	// so the interchange contains metadata only.
+	interchange, err := json.Marshal(&interchangeJSON{
+		Metadata: &interchangeMetadataJSON{
+			InterchangeFormatVersion: interchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", genesisResponse.Data.GenesisValidatorsRoot),
+		},
+		Data: make([]any, 0),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal slashing protection")
+		s.sendError(w, http.StatusInternalServerError, "failed to generate slashing protection")
+		return
+	}
+	res.SlashingProtection = string(interchange)
+	s.sendResponse(w, http.StatusOK, res)
+}
+
+// parsePubkey parses a hex-encoded public key.
+func parsePubkey(input string) (phase0.BLSPubKey, error) {
+	pubkey := phase0.BLSPubKey{}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(data) != len(pubkey) {
+		return pubkey, fmt.Errorf("invalid public key %q", input)
+	}
+	copy(pubkey[:], data)
+
+	return pubkey, nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                zerolog.Level
+	listenAddress           string
+	bearerToken             string
+	genesisProvider         eth2client.GenesisProvider
+	accountsProvider        accountmanager.AccountsProvider
+	keystoreManager         accountmanager.KeystoreManager
+	executionConfigProvider blockrelay.ExecutionConfigProvider
+	proposerConfigOverrider blockrelay.ProposerConfigOverrider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithListenAddress sets the listen address for the service.
+func WithListenAddress(listenAddress string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenAddress = listenAddress
+	})
+}
+
+// WithBearerToken sets the bearer token required to access the API.
+func WithBearerToken(bearerToken string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.bearerToken = bearerToken
+	})
+}
+
+// WithGenesisProvider sets the genesis provider.
+func WithGenesisProvider(provider eth2client.GenesisProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.genesisProvider = provider
+	})
+}
+
+// WithAccountsProvider sets the accounts provider.
+func WithAccountsProvider(provider accountmanager.AccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.accountsProvider = provider
+	})
+}
+
+// WithKeystoreManager sets the keystore manager.
+func WithKeystoreManager(manager accountmanager.KeystoreManager) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.keystoreManager = manager
+	})
+}
+
+// WithExecutionConfigProvider sets the execution configuration provider.
+func WithExecutionConfigProvider(provider blockrelay.ExecutionConfigProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionConfigProvider = provider
+	})
+}
+
+// WithProposerConfigOverrider sets the proposer configuration overrider.
+func WithProposerConfigOverrider(overrider blockrelay.ProposerConfigOverrider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposerConfigOverrider = overrider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.listenAddress == "" {
+		return nil, errors.New("no listen address specified")
+	}
+	if parameters.bearerToken == "" {
+		return nil, errors.New("no bearer token specified")
+	}
+	if parameters.genesisProvider == nil {
+		return nil, errors.New("no genesis provider specified")
+	}
+	if parameters.accountsProvider == nil {
+		return nil, errors.New("no accounts provider specified")
+	}
+	if parameters.keystoreManager == nil {
+		return nil, errors.New("no keystore manager specified")
+	}
+	if parameters.executionConfigProvider == nil {
+		return nil, errors.New("no execution config provider specified")
+	}
+	if parameters.proposerConfigOverrider == nil {
+		return nil, errors.New("no proposer config overrider specified")
+	}
+
+	return &parameters, nil
+}
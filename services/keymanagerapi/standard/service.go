@@ -0,0 +1,139 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a keymanager API server.
+type Service struct {
+	bearerToken             []byte
+	genesisProvider         eth2client.GenesisProvider
+	accountsProvider        accountmanager.AccountsProvider
+	keystoreManager         accountmanager.KeystoreManager
+	executionConfigProvider blockrelay.ExecutionConfigProvider
+	proposerConfigOverrider blockrelay.ProposerConfigOverrider
+	server                  *http.Server
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new keymanager API server.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "keymanagerapi").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		bearerToken:             []byte(parameters.bearerToken),
+		genesisProvider:         parameters.genesisProvider,
+		accountsProvider:        parameters.accountsProvider,
+		keystoreManager:         parameters.keystoreManager,
+		executionConfigProvider: parameters.executionConfigProvider,
+		proposerConfigOverrider: parameters.proposerConfigOverrider,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/keystores", s.authenticated(s.handleKeystores))
+	mux.HandleFunc("/eth/v1/validator/", s.authenticated(s.handleValidator))
+	s.server = &http.Server{
+		Addr:              parameters.listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Info().Str("listen_address", parameters.listenAddress).Msg("Starting keymanager API server")
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Str("listen_address", parameters.listenAddress).Err(err).Msg("Failed to run keymanager API server")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("Failed to shut down keymanager API server")
+		}
+	}()
+
+	return s, nil
+}
+
+// authenticated wraps a handler to ensure that the request carries the bearer token.
+func (s *Service) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Bearer ") {
+			s.sendError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), s.bearerToken) != 1 {
+			s.sendError(w, http.StatusForbidden, "invalid bearer token")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// errorResponse is the body of an error response.
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// sendError sends an error response.
+func (s *Service) sendError(w http.ResponseWriter, code int, message string) {
+	s.sendResponse(w, code, &errorResponse{
+		Code:    code,
+		Message: message,
+	})
+}
+
+// sendResponse sends a JSON response.
+func (*Service) sendResponse(w http.ResponseWriter, code int, data any) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to marshal response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(body); err != nil {
+		log.Debug().Err(err).Msg("Failed to write response")
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/attestantio/vouch/services/keymanagerapi/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genesisProvider := mock.NewGenesisProvider(time.Now())
+	accountsProvider := mockaccountmanager.NewAccountsProvider()
+	keystoreManager := newKeystoreManager()
+	overrider := newProposerConfigOverrider()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "ListenAddressMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithBearerToken("secret"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithExecutionConfigProvider(overrider),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+			err: "problem with parameters: no listen address specified",
+		},
+		{
+			name: "BearerTokenMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithExecutionConfigProvider(overrider),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+			err: "problem with parameters: no bearer token specified",
+		},
+		{
+			name: "GenesisProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithExecutionConfigProvider(overrider),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+			err: "problem with parameters: no genesis provider specified",
+		},
+		{
+			name: "AccountsProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithExecutionConfigProvider(overrider),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+			err: "problem with parameters: no accounts provider specified",
+		},
+		{
+			name: "KeystoreManagerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithExecutionConfigProvider(overrider),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+			err: "problem with parameters: no keystore manager specified",
+		},
+		{
+			name: "ExecutionConfigProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+			err: "problem with parameters: no execution config provider specified",
+		},
+		{
+			name: "ProposerConfigOverriderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithExecutionConfigProvider(overrider),
+			},
+			err: "problem with parameters: no proposer config overrider specified",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithListenAddress("localhost:0"),
+				standard.WithBearerToken("secret"),
+				standard.WithGenesisProvider(genesisProvider),
+				standard.WithAccountsProvider(accountsProvider),
+				standard.WithKeystoreManager(keystoreManager),
+				standard.WithExecutionConfigProvider(overrider),
+				standard.WithProposerConfigOverrider(overrider),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+var _ blockrelay.ProposerConfigOverrider = newProposerConfigOverrider()
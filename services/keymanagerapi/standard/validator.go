@@ -0,0 +1,155 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type feeRecipientJSON struct {
+	Pubkey     string `json:"pubkey,omitempty"`
+	Ethaddress string `json:"ethaddress"`
+}
+
+type feeRecipientResponse struct {
+	Data *feeRecipientJSON `json:"data"`
+}
+
+type gasLimitJSON struct {
+	Pubkey   string `json:"pubkey,omitempty"`
+	GasLimit string `json:"gas_limit"`
+}
+
+type gasLimitResponse struct {
+	Data *gasLimitJSON `json:"data"`
+}
+
+// handleValidator handles requests to the per-validator endpoints.
+func (s *Service) handleValidator(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/"), "/")
+	if len(parts) != 2 {
+		s.sendError(w, http.StatusNotFound, "not found")
+		return
+	}
+	pubkey, err := parsePubkey(parts[0])
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	account, err := s.accountsProvider.AccountByPublicKey(r.Context(), pubkey)
+	if err != nil {
+		s.sendError(w, http.StatusNotFound, "validator not found")
+		return
+	}
+
+	switch parts[1] {
+	case "feerecipient":
+		s.handleFeeRecipient(w, r, account, pubkey)
+	case "gaslimit":
+		s.handleGasLimit(w, r, account, pubkey)
+	default:
+		s.sendError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Service) handleFeeRecipient(w http.ResponseWriter, r *http.Request, account e2wtypes.Account, pubkey phase0.BLSPubKey) {
+	switch r.Method {
+	case http.MethodGet:
+		proposerConfig, err := s.executionConfigProvider.ProposerConfig(r.Context(), account, pubkey)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to obtain proposer configuration")
+			s.sendError(w, http.StatusInternalServerError, "failed to obtain proposer configuration")
+			return
+		}
+		s.sendResponse(w, http.StatusOK, &feeRecipientResponse{
+			Data: &feeRecipientJSON{
+				Pubkey:     fmt.Sprintf("%#x", pubkey),
+				Ethaddress: proposerConfig.FeeRecipient.String(),
+			},
+		})
+	case http.MethodPost:
+		req := &feeRecipientJSON{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			s.sendError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		feeRecipient := bellatrix.ExecutionAddress{}
+		data, err := hex.DecodeString(strings.TrimPrefix(req.Ethaddress, "0x"))
+		if err != nil || len(data) != len(feeRecipient) {
+			s.sendError(w, http.StatusBadRequest, "invalid ethaddress")
+			return
+		}
+		copy(feeRecipient[:], data)
+		if feeRecipient.IsZero() {
+			s.sendError(w, http.StatusBadRequest, "ethaddress cannot be zero")
+			return
+		}
+		s.proposerConfigOverrider.SetFeeRecipientOverride(r.Context(), pubkey, feeRecipient)
+		log.Info().Stringer("pubkey", pubkey).Stringer("fee_recipient", feeRecipient).Msg("Fee recipient overridden")
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodDelete:
+		s.proposerConfigOverrider.ClearFeeRecipientOverride(r.Context(), pubkey)
+		log.Info().Stringer("pubkey", pubkey).Msg("Fee recipient override removed")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Service) handleGasLimit(w http.ResponseWriter, r *http.Request, account e2wtypes.Account, pubkey phase0.BLSPubKey) {
+	switch r.Method {
+	case http.MethodGet:
+		gasLimit, err := s.proposerConfigOverrider.GasLimit(r.Context(), account, pubkey)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to obtain gas limit")
+			s.sendError(w, http.StatusInternalServerError, "failed to obtain gas limit")
+			return
+		}
+		s.sendResponse(w, http.StatusOK, &gasLimitResponse{
+			Data: &gasLimitJSON{
+				Pubkey:   fmt.Sprintf("%#x", pubkey),
+				GasLimit: strconv.FormatUint(gasLimit, 10),
+			},
+		})
+	case http.MethodPost:
+		req := &gasLimitJSON{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			s.sendError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		gasLimit, err := strconv.ParseUint(req.GasLimit, 10, 64)
+		if err != nil || gasLimit == 0 {
+			s.sendError(w, http.StatusBadRequest, "invalid gas_limit")
+			return
+		}
+		s.proposerConfigOverrider.SetGasLimitOverride(r.Context(), pubkey, gasLimit)
+		log.Info().Stringer("pubkey", pubkey).Uint64("gas_limit", gasLimit).Msg("Gas limit overridden")
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodDelete:
+		s.proposerConfigOverrider.ClearGasLimitOverride(r.Context(), pubkey)
+		log.Info().Stringer("pubkey", pubkey).Msg("Gas limit override removed")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
@@ -52,6 +52,22 @@ func (*Service) NewEpoch() {}
 // BlockDelay provides the delay between the start of a slot and vouch receiving its block.
 func (*Service) BlockDelay(_ uint, _ time.Duration) {}
 
+// UpcomingProposals is called when proposer duties for an epoch have been obtained, with the number of proposals to be made.
+func (*Service) UpcomingProposals(_ int) {}
+
+// NextDuty is called with the time of the next duty of the given type, or the zero time if no duty is scheduled.
+func (*Service) NextDuty(_ string, _ time.Time) {}
+
+// CanaryDuty is called when a duty for a canary validator completes, with the result.
+func (*Service) CanaryDuty(_ string, _ string) {}
+
+// LateBlock is called when a block arrives after attestations for its slot have voted for a different head.
+func (*Service) LateBlock() {}
+
+// SyncCommitteeMessagesTiming is called when sync committee messages for a slot complete, with what triggered
+// them, the delay between the start of the slot and their completion, and the result.
+func (*Service) SyncCommitteeMessagesTiming(_ string, _ time.Duration, _ string) {}
+
 // BeaconBlockProposalCompleted is called when a block proposal process has completed.
 func (*Service) BeaconBlockProposalCompleted(_ time.Time, _ phase0.Slot, _ string) {}
 
@@ -81,6 +97,15 @@ func (*Service) BeaconCommitteeAggregators(_ int) {}
 // Accounts sets the number of accounts in a given state.
 func (*Service) Accounts(_ string, _ uint64) {}
 
+// AccountsRefreshed is called when a refresh of accounts has completed successfully.
+func (*Service) AccountsRefreshed(_ time.Time, _ int, _ int) {}
+
+// AccountsRefreshFailed is called when a refresh of accounts encounters an error with an endpoint.
+func (*Service) AccountsRefreshFailed(_ string) {}
+
+// WalletAccounts sets the number of accounts in a given availability state for a wallet.
+func (*Service) WalletAccounts(_ string, _ string, _ int) {}
+
 // ClientOperation provides a generic monitor for client operations.
 func (*Service) ClientOperation(_ string, _ string, _ bool, _ time.Duration) {
 }
@@ -108,3 +133,6 @@ func (*Service) SyncCommitteeSubscriptionCompleted(_ time.Time, _ string) {
 // SyncCommitteeSubscribers sets the number of sync committees to which our validators are subscribed.
 func (*Service) SyncCommitteeSubscribers(_ int) {
 }
+
+// ServiceError is called when a service fails, with the category of the failure.
+func (*Service) ServiceError(_ string, _ string) {}
@@ -15,6 +15,7 @@ package prometheus
 
 import (
 	"errors"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -35,6 +36,87 @@ func (s *Service) setupAccountManagerMetrics() error {
 		}
 	}
 
+	s.accountManagerRefreshTimer = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_refresh",
+		Name:      "duration_seconds",
+		Help:      "The time vouch spends refreshing accounts.",
+		Buckets: []float64{
+			0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0,
+			1.1, 1.2, 1.3, 1.4, 1.5, 1.6, 1.7, 1.8, 1.9, 2.0,
+			2.1, 2.2, 2.3, 2.4, 2.5, 2.6, 2.7, 2.8, 2.9, 3.0,
+			3.1, 3.2, 3.3, 3.4, 3.5, 3.6, 3.7, 3.8, 3.9, 4.0,
+		},
+	})
+	if err := prometheus.Register(s.accountManagerRefreshTimer); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.accountManagerRefreshTimer = alreadyRegisteredError.ExistingCollector.(prometheus.Histogram)
+		} else {
+			return err
+		}
+	}
+
+	s.accountManagerRefreshChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_refresh",
+		Name:      "accounts_total",
+		Help:      "The number of accounts added or removed by account refreshes.",
+	}, []string{"change"})
+	if err := prometheus.Register(s.accountManagerRefreshChanges); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.accountManagerRefreshChanges = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	s.accountManagerRefreshErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_refresh",
+		Name:      "errors_total",
+		Help:      "The number of errors encountered refreshing accounts.",
+	}, []string{"endpoint"})
+	if err := prometheus.Register(s.accountManagerRefreshErrors); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.accountManagerRefreshErrors = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	s.accountManagerRefreshLatestTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_refresh",
+		Name:      "latest_timestamp_seconds",
+		Help:      "The time of the latest successful refresh of accounts.",
+	})
+	if err := prometheus.Register(s.accountManagerRefreshLatestTimestamp); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.accountManagerRefreshLatestTimestamp = alreadyRegisteredError.ExistingCollector.(prometheus.Gauge)
+		} else {
+			return err
+		}
+	}
+
+	s.accountManagerWalletAccounts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "accountmanager_wallet",
+		Name:      "accounts",
+		Help:      "The number of accounts in each availability state for each wallet.",
+	}, []string{"wallet", "state"})
+	if err := prometheus.Register(s.accountManagerWalletAccounts); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.accountManagerWalletAccounts = alreadyRegisteredError.ExistingCollector.(*prometheus.GaugeVec)
+		} else {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -42,3 +124,21 @@ func (s *Service) setupAccountManagerMetrics() error {
 func (s *Service) Accounts(state string, count uint64) {
 	s.accountManagerAccounts.WithLabelValues(state).Set(float64(count))
 }
+
+// AccountsRefreshed is called when a refresh of accounts has completed successfully.
+func (s *Service) AccountsRefreshed(started time.Time, added int, removed int) {
+	s.accountManagerRefreshTimer.Observe(time.Since(started).Seconds())
+	s.accountManagerRefreshChanges.WithLabelValues("added").Add(float64(added))
+	s.accountManagerRefreshChanges.WithLabelValues("removed").Add(float64(removed))
+	s.accountManagerRefreshLatestTimestamp.SetToCurrentTime()
+}
+
+// AccountsRefreshFailed is called when a refresh of accounts encounters an error with an endpoint.
+func (s *Service) AccountsRefreshFailed(endpoint string) {
+	s.accountManagerRefreshErrors.WithLabelValues(endpoint).Inc()
+}
+
+// WalletAccounts sets the number of accounts in a given availability state for a wallet.
+func (s *Service) WalletAccounts(wallet string, state string, count int) {
+	s.accountManagerWalletAccounts.WithLabelValues(wallet, state).Set(float64(count))
+}
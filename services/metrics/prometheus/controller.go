@@ -16,6 +16,8 @@ package prometheus
 import (
 	"errors"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -64,6 +66,97 @@ func (s *Service) setupControllerMetrics() error {
 		}
 	}
 
+	s.upcomingProposals = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Name:      "upcoming_proposals",
+		Help:      "The number of proposals to be made by vouch in the epoch for which proposer duties were most recently obtained.",
+	})
+	if err := prometheus.Register(s.upcomingProposals); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.upcomingProposals = alreadyRegisteredError.ExistingCollector.(prometheus.Gauge)
+		} else {
+			return err
+		}
+	}
+
+	s.nextDuties = &nextDutiesCollector{
+		desc: prometheus.NewDesc("vouch_next_duty_seconds",
+			"The number of seconds until the next duty of each type; +Inf if no duty is scheduled.",
+			[]string{"duty"},
+			nil,
+		),
+		times: make(map[string]time.Time),
+	}
+	if err := prometheus.Register(s.nextDuties); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.nextDuties = alreadyRegisteredError.ExistingCollector.(*nextDutiesCollector)
+		} else {
+			return err
+		}
+	}
+
+	s.canaryDuties = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "canary",
+		Name:      "duties_total",
+		Help:      "The number of duties carried out for canary validators.",
+	}, []string{"duty", "result"})
+	if err := prometheus.Register(s.canaryDuties); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.canaryDuties = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	s.canaryDutyLatest = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "canary",
+		Name:      "duty_latest_success_timestamp_seconds",
+		Help:      "The timestamp of the latest successful duty for canary validators.",
+	}, []string{"duty"})
+	if err := prometheus.Register(s.canaryDutyLatest); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.canaryDutyLatest = alreadyRegisteredError.ExistingCollector.(*prometheus.GaugeVec)
+		} else {
+			return err
+		}
+	}
+
+	s.syncCommitteeMessagesTiming = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "synccommitteemessage",
+		Name:      "completion_delay_seconds",
+		Help:      "The delay between the start of a slot and the completion of its sync committee messages.",
+		Buckets:   prometheus.LinearBuckets(0.1, 0.1, 120),
+	}, []string{"trigger", "result"})
+	if err := prometheus.Register(s.syncCommitteeMessagesTiming); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.syncCommitteeMessagesTiming = alreadyRegisteredError.ExistingCollector.(*prometheus.HistogramVec)
+		} else {
+			return err
+		}
+	}
+
+	s.lateBlocks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "late_blocks_total",
+		Help:      "The number of blocks that arrived after attestations for their slot voted for a different head.",
+	})
+	if err := prometheus.Register(s.lateBlocks); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.lateBlocks = alreadyRegisteredError.ExistingCollector.(prometheus.Counter)
+		} else {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -76,3 +169,69 @@ func (s *Service) NewEpoch() {
 func (s *Service) BlockDelay(epochSlot uint, delay time.Duration) {
 	s.blockReceiptDelay.WithLabelValues(fmt.Sprintf("%d", epochSlot)).Observe(delay.Seconds())
 }
+
+// UpcomingProposals is called when proposer duties for an epoch have been obtained, with the number of proposals to be made.
+func (s *Service) UpcomingProposals(proposals int) {
+	s.upcomingProposals.Set(float64(proposals))
+}
+
+// NextDuty is called with the time of the next duty of the given type, or the zero time if no duty is scheduled.
+func (s *Service) NextDuty(duty string, at time.Time) {
+	s.nextDuties.mutex.Lock()
+	s.nextDuties.times[duty] = at
+	s.nextDuties.mutex.Unlock()
+}
+
+// CanaryDuty is called when a duty for a canary validator completes, with the result.
+func (s *Service) CanaryDuty(duty string, result string) {
+	s.canaryDuties.WithLabelValues(duty, result).Inc()
+	if result == "succeeded" {
+		s.canaryDutyLatest.WithLabelValues(duty).SetToCurrentTime()
+	}
+}
+
+// LateBlock is called when a block arrives after attestations for its slot have voted for a different head.
+func (s *Service) LateBlock() {
+	s.lateBlocks.Inc()
+}
+
+// SyncCommitteeMessagesTiming is called when sync committee messages for a slot complete, with what triggered
+// them, the delay between the start of the slot and their completion, and the result.
+func (s *Service) SyncCommitteeMessagesTiming(trigger string, delay time.Duration, result string) {
+	s.syncCommitteeMessagesTiming.WithLabelValues(trigger, result).Observe(delay.Seconds())
+}
+
+// nextDutiesCollector reports the time until the next duties, calculated when
+// metrics are gathered.
+type nextDutiesCollector struct {
+	desc  *prometheus.Desc
+	mutex sync.Mutex
+	times map[string]time.Time
+}
+
+// Describe implements prometheus.Collector.
+func (c *nextDutiesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *nextDutiesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for duty, at := range c.times {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, secondsUntil(at, time.Now()), duty)
+	}
+}
+
+// secondsUntil returns the number of seconds from now until the given time.  A
+// time that has passed returns 0, and a zero time returns +Inf.
+func secondsUntil(at time.Time, now time.Time) float64 {
+	if at.IsZero() {
+		return math.Inf(1)
+	}
+	if !at.After(now) {
+		return 0
+	}
+
+	return at.Sub(now).Seconds()
+}
@@ -0,0 +1,58 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecondsUntil(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected float64
+	}{
+		{
+			name:     "Zero",
+			expected: math.Inf(1),
+		},
+		{
+			name:     "Past",
+			at:       now.Add(-time.Second),
+			expected: 0,
+		},
+		{
+			name:     "Now",
+			at:       now,
+			expected: 0,
+		},
+		{
+			name:     "Future",
+			at:       now.Add(1500 * time.Millisecond),
+			expected: 1.5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, secondsUntil(test.at, now))
+		})
+	}
+}
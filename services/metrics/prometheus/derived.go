@@ -0,0 +1,112 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// derivedMetricsInterval is the interval between evaluations of derived metrics.
+const derivedMetricsInterval = 30 * time.Second
+
+// DerivedMetric is an operator-defined metric calculated from other metrics.
+type DerivedMetric struct {
+	// Name is the name of the metric; it is exported as vouch_derived_<name>.
+	Name string
+	// Help is the help text for the metric.
+	Help string
+	// Expression is the expression used to calculate the metric.
+	Expression string
+}
+
+// derivedMetric is a parsed derived metric.
+type derivedMetric struct {
+	name       string
+	expression derivedNode
+	gauge      prometheus.Gauge
+}
+
+func (s *Service) setupDerivedMetrics(derivedMetrics []*DerivedMetric) error {
+	s.derivedMetrics = make([]*derivedMetric, 0, len(derivedMetrics))
+	for _, metric := range derivedMetrics {
+		expression, err := parseDerivedExpression(metric.Expression)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid expression for derived metric %s", metric.Name))
+		}
+
+		help := metric.Help
+		if help == "" {
+			help = metric.Expression
+		}
+		gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vouch",
+			Subsystem: "derived",
+			Name:      metric.Name,
+			Help:      help,
+		})
+		if err := prometheus.Register(gauge); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to register derived metric %s", metric.Name))
+		}
+
+		s.derivedMetrics = append(s.derivedMetrics, &derivedMetric{
+			name:       metric.Name,
+			expression: expression,
+			gauge:      gauge,
+		})
+	}
+
+	return nil
+}
+
+// derivedMetricsUpdater periodically updates derived metrics.
+func (s *Service) derivedMetricsUpdater(ctx context.Context) {
+	ticker := time.NewTicker(derivedMetricsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.updateDerivedMetrics(time.Now()); err != nil {
+				log.Warn().Err(err).Msg("Failed to update derived metrics")
+			}
+		}
+	}
+}
+
+// updateDerivedMetrics evaluates the derived metrics and sets their values.
+func (s *Service) updateDerivedMetrics(now time.Time) error {
+	gathered, err := s.gatherer.Gather()
+	if err != nil {
+		return errors.Wrap(err, "failed to gather metrics")
+	}
+	families := make(map[string]*dto.MetricFamily, len(gathered))
+	for _, family := range gathered {
+		families[family.GetName()] = family
+	}
+
+	for _, metric := range s.derivedMetrics {
+		value := metric.expression.evaluate(families, now)
+		log.Trace().Str("metric", metric.name).Float64("value", value).Msg("Evaluated derived metric")
+		metric.gauge.Set(value)
+	}
+
+	return nil
+}
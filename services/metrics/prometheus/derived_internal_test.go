@@ -0,0 +1,222 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDerivedExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		err        string
+	}{
+		{
+			name:       "Empty",
+			expression: "",
+			err:        "position 0: unexpected end of expression",
+		},
+		{
+			name:       "Number",
+			expression: "1.5",
+		},
+		{
+			name:       "Selector",
+			expression: "vouch_relay_auction_block_used_total",
+		},
+		{
+			name:       "SelectorWithMatchers",
+			expression: `vouch_beaconblockproposal_process_blocks_total{method="auction", result!="failed"}`,
+		},
+		{
+			name:       "Increase",
+			expression: `increase(vouch_relay_auction_block_used_total[1d]) / increase(vouch_beaconblockproposal_process_blocks_total{method="direct"}[1d])`,
+		},
+		{
+			name:       "Rate",
+			expression: "rate(vouch_epochs_processed_total[5m]) * 60",
+		},
+		{
+			name:       "Parentheses",
+			expression: "-(1 + 2) * 3",
+		},
+		{
+			name:       "UnknownFunction",
+			expression: "sum(vouch_epochs_processed_total)",
+			err:        `position 0: unknown function "sum"`,
+		},
+		{
+			name:       "MissingWindow",
+			expression: "increase(vouch_epochs_processed_total)",
+			err:        `position 37: expected '['`,
+		},
+		{
+			name:       "InvalidWindow",
+			expression: "increase(vouch_epochs_processed_total[1y])",
+			err:        `position 38: invalid duration "1y"`,
+		},
+		{
+			name:       "UnterminatedLabelValue",
+			expression: `vouch_epochs_processed_total{result="succeeded}`,
+			err:        "position 37: unterminated label value",
+		},
+		{
+			name:       "UnbalancedParentheses",
+			expression: "(1 + 2",
+			err:        `position 6: expected ')'`,
+		},
+		{
+			name:       "TrailingInput",
+			expression: "1 2",
+			err:        `position 2: unexpected "2"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseDerivedExpression(test.expression)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDerivedDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Duration
+		err      string
+	}{
+		{
+			name:     "Minutes",
+			input:    "5m",
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "Compound",
+			input:    "1h30m",
+			expected: 90 * time.Minute,
+		},
+		{
+			name:     "Days",
+			input:    "1d",
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "Weeks",
+			input:    "2w",
+			expected: 14 * 24 * time.Hour,
+		},
+		{
+			name:  "Zero",
+			input: "0s",
+			err:   `duration "0s" must be positive`,
+		},
+		{
+			name:  "Invalid",
+			input: "xd",
+			err:   `invalid duration "xd"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseDerivedDuration(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestEvaluateDerivedMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	blocks := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "test_blocks_total",
+	}, []string{"method"})
+	registry.MustRegister(blocks)
+	duration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "test_duration_seconds",
+	})
+	registry.MustRegister(duration)
+
+	relayExpression, err := parseDerivedExpression(`increase(test_blocks_total{method="auction"}[1h]) / increase(test_blocks_total[1h])`)
+	require.NoError(t, err)
+	averageExpression, err := parseDerivedExpression("test_duration_seconds_sum / test_duration_seconds_count")
+	require.NoError(t, err)
+	relayGauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_relay_ratio"})
+	averageGauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_average_duration"})
+
+	s := &Service{
+		gatherer: registry,
+		derivedMetrics: []*derivedMetric{
+			{
+				name:       "relay_ratio",
+				expression: relayExpression,
+				gauge:      relayGauge,
+			},
+			{
+				name:       "average_duration",
+				expression: averageExpression,
+				gauge:      averageGauge,
+			},
+		},
+	}
+
+	gaugeValue := func(gauge prometheus.Gauge) float64 {
+		metric := &dto.Metric{}
+		require.NoError(t, gauge.Write(metric))
+		return metric.GetGauge().GetValue()
+	}
+
+	start := time.Now()
+
+	// No data.
+	require.NoError(t, s.updateDerivedMetrics(start))
+	require.True(t, math.IsNaN(gaugeValue(relayGauge)))
+	require.True(t, math.IsNaN(gaugeValue(averageGauge)))
+
+	// Initial data.
+	blocks.WithLabelValues("auction").Add(3)
+	blocks.WithLabelValues("direct").Add(1)
+	duration.Observe(1)
+	duration.Observe(2)
+	require.NoError(t, s.updateDerivedMetrics(start.Add(30*time.Minute)))
+	require.Equal(t, 0.75, gaugeValue(relayGauge))
+	require.Equal(t, 1.5, gaugeValue(averageGauge))
+
+	// Further data within the window.
+	blocks.WithLabelValues("direct").Add(4)
+	require.NoError(t, s.updateDerivedMetrics(start.Add(60*time.Minute)))
+	require.Equal(t, 0.375, gaugeValue(relayGauge))
+
+	// Initial data drops out of the window.
+	blocks.WithLabelValues("auction").Add(1)
+	require.NoError(t, s.updateDerivedMetrics(start.Add(90*time.Minute)))
+	require.Equal(t, 0.2, gaugeValue(relayGauge))
+}
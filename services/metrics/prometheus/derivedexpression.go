@@ -0,0 +1,506 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// derivedNode is a node in a parsed derived metric expression.
+type derivedNode interface {
+	// evaluate evaluates the node against the supplied metric families.
+	// It is called exactly once per evaluation cycle, allowing nodes to
+	// maintain history.
+	evaluate(families map[string]*dto.MetricFamily, now time.Time) float64
+}
+
+// derivedNumber is a constant.
+type derivedNumber struct {
+	value float64
+}
+
+func (n *derivedNumber) evaluate(_ map[string]*dto.MetricFamily, _ time.Time) float64 {
+	return n.value
+}
+
+// derivedNegation negates its operand.
+type derivedNegation struct {
+	operand derivedNode
+}
+
+func (n *derivedNegation) evaluate(families map[string]*dto.MetricFamily, now time.Time) float64 {
+	return -n.operand.evaluate(families, now)
+}
+
+// derivedBinary is a binary arithmetic operation.
+type derivedBinary struct {
+	op    byte
+	left  derivedNode
+	right derivedNode
+}
+
+func (n *derivedBinary) evaluate(families map[string]*dto.MetricFamily, now time.Time) float64 {
+	// Always evaluate both sides, as nodes may be tracking history.
+	left := n.left.evaluate(families, now)
+	right := n.right.evaluate(families, now)
+
+	switch n.op {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	default:
+		return left / right
+	}
+}
+
+// derivedMatcher matches a label value.
+type derivedMatcher struct {
+	name     string
+	value    string
+	negative bool
+}
+
+// derivedSelector is the sum of all series of a metric that match the label matchers.
+type derivedSelector struct {
+	name     string
+	matchers []*derivedMatcher
+}
+
+func (n *derivedSelector) evaluate(families map[string]*dto.MetricFamily, _ time.Time) float64 {
+	// Direct match.
+	if family, exists := families[n.name]; exists {
+		return n.sum(family, func(metric *dto.Metric) float64 {
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				return metric.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				return metric.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				return metric.GetUntyped().GetValue()
+			default:
+				return 0
+			}
+		})
+	}
+
+	// Companion metrics for histograms and summaries.
+	if strings.HasSuffix(n.name, "_count") {
+		if family, exists := families[strings.TrimSuffix(n.name, "_count")]; exists {
+			return n.sum(family, func(metric *dto.Metric) float64 {
+				switch family.GetType() {
+				case dto.MetricType_HISTOGRAM:
+					return float64(metric.GetHistogram().GetSampleCount())
+				case dto.MetricType_SUMMARY:
+					return float64(metric.GetSummary().GetSampleCount())
+				default:
+					return 0
+				}
+			})
+		}
+	}
+	if strings.HasSuffix(n.name, "_sum") {
+		if family, exists := families[strings.TrimSuffix(n.name, "_sum")]; exists {
+			return n.sum(family, func(metric *dto.Metric) float64 {
+				switch family.GetType() {
+				case dto.MetricType_HISTOGRAM:
+					return metric.GetHistogram().GetSampleSum()
+				case dto.MetricType_SUMMARY:
+					return metric.GetSummary().GetSampleSum()
+				default:
+					return 0
+				}
+			})
+		}
+	}
+
+	// Metric not (yet) present.
+	return 0
+}
+
+// sum sums the values of the series in the family that match the selector.
+func (n *derivedSelector) sum(family *dto.MetricFamily, value func(*dto.Metric) float64) float64 {
+	total := 0.0
+	for _, metric := range family.GetMetric() {
+		if n.matches(metric) {
+			total += value(metric)
+		}
+	}
+
+	return total
+}
+
+// matches returns true if the metric matches all of the selector's matchers.
+func (n *derivedSelector) matches(metric *dto.Metric) bool {
+	for _, matcher := range n.matchers {
+		labelValue := ""
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == matcher.name {
+				labelValue = label.GetValue()
+				break
+			}
+		}
+		if (labelValue == matcher.value) == matcher.negative {
+			return false
+		}
+	}
+
+	return true
+}
+
+// derivedSample is a historical value of a selector.
+type derivedSample struct {
+	timestamp time.Time
+	value     float64
+}
+
+// derivedIncrease is the increase in a selector over a window, optionally as a per-second rate.
+type derivedIncrease struct {
+	selector *derivedSelector
+	window   time.Duration
+	rate     bool
+	samples  []*derivedSample
+}
+
+func (n *derivedIncrease) evaluate(families map[string]*dto.MetricFamily, now time.Time) float64 {
+	value := n.selector.evaluate(families, now)
+
+	// Counters start at 0 when Vouch starts, and reset only when it restarts,
+	// so if there is insufficient history the baseline is 0.
+	baseline := 0.0
+	windowStart := now.Add(-n.window)
+	// Retain the latest sample at or before the start of the window, as the
+	// baseline, and all samples after it.
+	first := 0
+	for i, sample := range n.samples {
+		if sample.timestamp.After(windowStart) {
+			break
+		}
+		first = i
+		baseline = sample.value
+	}
+	n.samples = append(n.samples[first:], &derivedSample{
+		timestamp: now,
+		value:     value,
+	})
+
+	increase := value - baseline
+	if n.rate {
+		return increase / n.window.Seconds()
+	}
+
+	return increase
+}
+
+// derivedParser is a recursive descent parser for derived metric expressions.
+//
+// The grammar is:
+//
+//	expression := term (('+' | '-') term)*
+//	term       := unary (('*' | '/') unary)*
+//	unary      := '-' unary | primary
+//	primary    := number | '(' expression ')' | function '(' selector '[' duration ']' ')' | selector
+//	function   := 'increase' | 'rate'
+//	selector   := name ('{' matcher (',' matcher)* '}')?
+//	matcher    := label ('=' | '!=') '"' value '"'
+type derivedParser struct {
+	input string
+	pos   int
+}
+
+// parseDerivedExpression parses a derived metric expression.
+func parseDerivedExpression(input string) (derivedNode, error) {
+	p := &derivedParser{input: input}
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	p.skipWhitespace()
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	return node, nil
+}
+
+func (p *derivedParser) errorf(format string, args ...any) error {
+	return errors.Errorf("position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *derivedParser) skipWhitespace() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-whitespace character, or 0 if at the end of the input.
+func (p *derivedParser) peek() byte {
+	p.skipWhitespace()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+// expect consumes the given character, returning an error if it is not next.
+func (p *derivedParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+
+	return nil
+}
+
+func (p *derivedParser) parseExpression() (derivedNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &derivedBinary{op: op, left: left, right: right}
+	}
+}
+
+func (p *derivedParser) parseTerm() (derivedNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &derivedBinary{op: op, left: left, right: right}
+	}
+}
+
+func (p *derivedParser) parseUnary() (derivedNode, error) {
+	if p.peek() == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &derivedNegation{operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *derivedParser) parsePrimary() (derivedNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, p.errorf("unexpected end of expression")
+	case c == '(':
+		p.pos++
+		node, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case isDigit(c) || c == '.':
+		return p.parseNumber()
+	case isNameStart(c):
+		start := p.pos
+		name := p.parseName()
+		if p.peek() == '(' {
+			return p.parseFunction(name, start)
+		}
+
+		return p.parseSelector(name)
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *derivedParser) parseNumber() (derivedNode, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (isDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	text := p.input[start:p.pos]
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", text)
+	}
+
+	return &derivedNumber{value: value}, nil
+}
+
+func (p *derivedParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.input) && (isNameStart(p.input[p.pos]) || isDigit(p.input[p.pos])) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+func (p *derivedParser) parseFunction(name string, start int) (derivedNode, error) {
+	if name != "increase" && name != "rate" {
+		p.pos = start
+		return nil, p.errorf("unknown function %q", name)
+	}
+	// Consume the opening parenthesis.
+	p.pos++
+
+	if !isNameStart(p.peek()) {
+		return nil, p.errorf("expected metric name")
+	}
+	selector, err := p.parseSelector(p.parseName())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+	durationStart := p.pos
+	end := strings.IndexByte(p.input[p.pos:], ']')
+	if end == -1 {
+		return nil, p.errorf("expected %q", ']')
+	}
+	window, err := parseDerivedDuration(strings.TrimSpace(p.input[p.pos : p.pos+end]))
+	if err != nil {
+		p.pos = durationStart
+		return nil, p.errorf("%v", err)
+	}
+	p.pos += end + 1
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return &derivedIncrease{
+		selector: selector,
+		window:   window,
+		rate:     name == "rate",
+		samples:  make([]*derivedSample, 0),
+	}, nil
+}
+
+func (p *derivedParser) parseSelector(name string) (*derivedSelector, error) {
+	selector := &derivedSelector{
+		name:     name,
+		matchers: make([]*derivedMatcher, 0),
+	}
+	if p.peek() != '{' {
+		return selector, nil
+	}
+	p.pos++
+
+	for p.peek() != '}' {
+		if len(selector.matchers) > 0 {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+		if !isNameStart(p.peek()) {
+			return nil, p.errorf("expected label name")
+		}
+		matcher := &derivedMatcher{
+			name: p.parseName(),
+		}
+		if p.peek() == '!' {
+			p.pos++
+			matcher.negative = true
+		}
+		if err := p.expect('='); err != nil {
+			return nil, err
+		}
+		if err := p.expect('"'); err != nil {
+			return nil, err
+		}
+		end := strings.IndexByte(p.input[p.pos:], '"')
+		if end == -1 {
+			return nil, p.errorf("unterminated label value")
+		}
+		matcher.value = p.input[p.pos : p.pos+end]
+		p.pos += end + 1
+		selector.matchers = append(selector.matchers, matcher)
+	}
+	// Consume the closing brace.
+	p.pos++
+
+	return selector, nil
+}
+
+// parseDerivedDuration parses a duration, additionally supporting days ('d') and weeks ('w').
+func parseDerivedDuration(input string) (time.Duration, error) {
+	var multiplier time.Duration
+	switch {
+	case strings.HasSuffix(input, "d"):
+		multiplier = 24 * time.Hour
+	case strings.HasSuffix(input, "w"):
+		multiplier = 7 * 24 * time.Hour
+	}
+
+	var duration time.Duration
+	if multiplier != 0 {
+		count, err := strconv.ParseUint(input[:len(input)-1], 10, 32)
+		if err != nil {
+			return 0, errors.Errorf("invalid duration %q", input)
+		}
+		duration = time.Duration(count) * multiplier
+	} else {
+		var err error
+		duration, err = time.ParseDuration(input)
+		if err != nil {
+			return 0, errors.Errorf("invalid duration %q", input)
+		}
+	}
+	if duration <= 0 {
+		return 0, errors.Errorf("duration %q must be positive", input)
+	}
+
+	return duration, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == ':'
+}
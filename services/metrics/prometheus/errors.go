@@ -0,0 +1,43 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func (s *Service) setupErrorMetrics() error {
+	s.serviceErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "errors_total",
+		Help:      "The number of service failures, by category.",
+	}, []string{"service", "category"})
+	if err := prometheus.Register(s.serviceErrors); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.serviceErrors = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ServiceError is called when a service fails, with the category of the failure.
+func (s *Service) ServiceError(service string, category string) {
+	s.serviceErrors.WithLabelValues(service, category).Inc()
+}
@@ -0,0 +1,286 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// localInstance is the name of the local instance in federated metrics.
+const localInstance = "local"
+
+// federatedMetric is a metric aggregated across instances.
+type federatedMetric struct {
+	name string
+	// aggregation is either "sum" or "min".
+	aggregation string
+}
+
+// federatedMetrics are the key health metrics aggregated across instances.
+var federatedMetrics = []*federatedMetric{
+	{name: "vouch_attestation_process_requests_total", aggregation: "sum"},
+	{name: "vouch_attestationaggregation_process_requests_total", aggregation: "sum"},
+	{name: "vouch_beaconblockproposal_process_requests_total", aggregation: "sum"},
+	{name: "vouch_synccommitteemessage_process_requests_total", aggregation: "sum"},
+	{name: "vouch_synccommitteeaggregation_process_requests_total", aggregation: "sum"},
+	{name: "vouch_accountmanager_accounts_total", aggregation: "sum"},
+	{name: "vouch_accountmanager_wallet_accounts", aggregation: "sum"},
+	{name: "vouch_doppelganger_validators", aggregation: "sum"},
+	{name: "vouch_attestation_process_latest_slot", aggregation: "min"},
+	{name: "vouch_accountmanager_refresh_latest_timestamp_seconds", aggregation: "min"},
+}
+
+// federatedValue is a single aggregated value.
+type federatedValue struct {
+	labels []*dto.LabelPair
+	value  float64
+}
+
+// checkFederationPeers checks that federation peers are valid metrics URLs.
+func checkFederationPeers(peers []string) error {
+	for _, peer := range peers {
+		peerURL, err := url.Parse(peer)
+		if err != nil {
+			return fmt.Errorf("invalid federation peer %q", peer)
+		}
+		if peerURL.Scheme != "http" && peerURL.Scheme != "https" {
+			return fmt.Errorf("invalid scheme for federation peer %q", peer)
+		}
+		if peerURL.Host == "" {
+			return fmt.Errorf("no host for federation peer %q", peer)
+		}
+	}
+
+	return nil
+}
+
+// federationHandler serves the key health metrics of this instance and its peers,
+// aggregated in to a single summary.
+func (s *Service) federationHandler(w http.ResponseWriter, r *http.Request) {
+	instances := s.federatedInstances(r.Context())
+
+	var buf bytes.Buffer
+	for _, family := range federate(instances) {
+		if _, err := expfmt.MetricFamilyToText(&buf, family); err != nil {
+			log.Error().Err(err).Msg("Failed to encode federated metrics")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", string(expfmt.FmtText))
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Debug().Err(err).Msg("Failed to write federated metrics")
+	}
+}
+
+// federatedInstances obtains the metrics for this instance and its peers.
+// Instances whose metrics could not be obtained have nil metrics.
+func (s *Service) federatedInstances(ctx context.Context) map[string]map[string]*dto.MetricFamily {
+	instances := make(map[string]map[string]*dto.MetricFamily, len(s.federationPeers)+1)
+
+	gathered, err := s.gatherer.Gather()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to gather local metrics for federation")
+		instances[localInstance] = nil
+	} else {
+		families := make(map[string]*dto.MetricFamily, len(gathered))
+		for _, family := range gathered {
+			families[family.GetName()] = family
+		}
+		instances[localInstance] = families
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, peer := range s.federationPeers {
+		wg.Add(1)
+		go func(ctx context.Context, peer string) {
+			defer wg.Done()
+			families, err := s.fetchPeerMetrics(ctx, peer)
+			if err != nil {
+				log.Debug().Str("peer", peer).Err(err).Msg("Failed to obtain metrics from federation peer")
+			}
+			mu.Lock()
+			instances[peerInstance(peer)] = families
+			mu.Unlock()
+		}(ctx, peer)
+	}
+	wg.Wait()
+
+	return instances
+}
+
+// fetchPeerMetrics fetches the metrics from a peer.
+func (s *Service) fetchPeerMetrics(ctx context.Context, peer string) (map[string]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.federationTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	// Request the text format, as that is what we parse.
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	resp, err := s.federationClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call peer")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer returned status %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse metrics")
+	}
+
+	return families, nil
+}
+
+// federate aggregates the federated metrics across instances.
+func federate(instances map[string]map[string]*dto.MetricFamily) []*dto.MetricFamily {
+	names := make([]string, 0, len(instances))
+	for name := range instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	up := &dto.MetricFamily{
+		Name: proto.String("vouch_federation_up"),
+		Help: proto.String("1 if the metrics of the instance could be obtained, otherwise 0."),
+		Type: dto.MetricType_GAUGE.Enum(),
+	}
+	for _, name := range names {
+		value := 0.0
+		if instances[name] != nil {
+			value = 1
+		}
+		up.Metric = append(up.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{{Name: proto.String("instance"), Value: proto.String(name)}},
+			Gauge: &dto.Gauge{Value: proto.Float64(value)},
+		})
+	}
+	res := []*dto.MetricFamily{up}
+
+	for _, metric := range federatedMetrics {
+		values := make(map[string]*federatedValue)
+		var metricType *dto.MetricType
+		help := ""
+		for _, name := range names {
+			family, exists := instances[name][metric.name]
+			if !exists {
+				continue
+			}
+			if metricType == nil {
+				metricType = family.Type
+				help = family.GetHelp()
+			}
+			for _, m := range family.GetMetric() {
+				value, ok := metricValue(m)
+				if !ok {
+					continue
+				}
+				key := labelsKey(m.GetLabel())
+				existing, exists := values[key]
+				switch {
+				case !exists:
+					values[key] = &federatedValue{labels: m.GetLabel(), value: value}
+				case metric.aggregation == "min":
+					if value < existing.value {
+						existing.value = value
+					}
+				default:
+					existing.value += value
+				}
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		family := &dto.MetricFamily{
+			Name: proto.String(fmt.Sprintf("vouch_federation_%s", strings.TrimPrefix(metric.name, "vouch_"))),
+			Help: proto.String(fmt.Sprintf("%s (%s across instances)", strings.TrimSuffix(help, "."), metric.aggregation)),
+			Type: metricType,
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			m := &dto.Metric{Label: values[key].labels}
+			if *metricType == dto.MetricType_COUNTER {
+				m.Counter = &dto.Counter{Value: proto.Float64(values[key].value)}
+			} else {
+				family.Type = dto.MetricType_GAUGE.Enum()
+				m.Gauge = &dto.Gauge{Value: proto.Float64(values[key].value)}
+			}
+			family.Metric = append(family.Metric, m)
+		}
+		res = append(res, family)
+	}
+
+	return res
+}
+
+// peerInstance returns the instance name for a peer.
+func peerInstance(peer string) string {
+	peerURL, err := url.Parse(peer)
+	if err != nil {
+		return peer
+	}
+
+	return peerURL.Host
+}
+
+// metricValue returns the value of a counter, gauge or untyped metric.
+func metricValue(m *dto.Metric) (float64, bool) {
+	switch {
+	case m.GetCounter() != nil:
+		return m.GetCounter().GetValue(), true
+	case m.GetGauge() != nil:
+		return m.GetGauge().GetValue(), true
+	case m.GetUntyped() != nil:
+		return m.GetUntyped().GetValue(), true
+	default:
+		return 0, false
+	}
+}
+
+// labelsKey returns a key uniquely identifying a set of labels.
+func labelsKey(labels []*dto.LabelPair) string {
+	parts := make([]string, 0, len(labels))
+	for _, label := range labels {
+		parts = append(parts, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+	}
+	sort.Strings(parts)
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederation(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vouch_attestation_process_requests_total",
+		Help: "The number of attestation processes.",
+	}, []string{"result"})
+	registry.MustRegister(requests)
+	latestSlot := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "vouch_attestation_process_latest_slot",
+		Help: "The latest slot for which Vouch attempted to attest.",
+	})
+	registry.MustRegister(latestSlot)
+	requests.WithLabelValues("succeeded").Add(10)
+	requests.WithLabelValues("failed").Add(1)
+	latestSlot.Set(100)
+
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `# TYPE vouch_attestation_process_requests_total counter
+vouch_attestation_process_requests_total{result="succeeded"} 20
+# TYPE vouch_attestation_process_latest_slot gauge
+vouch_attestation_process_latest_slot 98
+# TYPE vouch_unrelated_total counter
+vouch_unrelated_total 5
+`)
+	}))
+	defer peer.Close()
+
+	failingPeer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failingPeer.Close()
+
+	s := &Service{
+		gatherer:          registry,
+		federationPeers:   []string{peer.URL, failingPeer.URL},
+		federationTimeout: time.Second,
+		federationClient:  util.NewHTTPClient(0),
+	}
+
+	recorder := httptest.NewRecorder()
+	s.federationHandler(recorder, httptest.NewRequest(http.MethodGet, "/federation", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+
+	require.Contains(t, body, `vouch_federation_attestation_process_requests_total{result="succeeded"} 30`)
+	require.Contains(t, body, `vouch_federation_attestation_process_requests_total{result="failed"} 1`)
+	require.Contains(t, body, "vouch_federation_attestation_process_latest_slot 98")
+	require.Contains(t, body, `vouch_federation_up{instance="local"} 1`)
+	require.Contains(t, body, fmt.Sprintf(`vouch_federation_up{instance="%s"} 1`, strings.TrimPrefix(peer.URL, "http://")))
+	require.Contains(t, body, fmt.Sprintf(`vouch_federation_up{instance="%s"} 0`, strings.TrimPrefix(failingPeer.URL, "http://")))
+	require.NotContains(t, body, "unrelated")
+}
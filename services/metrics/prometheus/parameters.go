@@ -15,18 +15,31 @@ package prometheus
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/rs/zerolog"
 )
 
 type parameters struct {
-	logLevel     zerolog.Level
-	address      string
-	chainTime    chaintime.Service
-	createServer bool
+	logLevel          zerolog.Level
+	address           string
+	chainTime         chaintime.Service
+	createServer      bool
+	derivedMetrics    []*DerivedMetric
+	federationPeers   []string
+	federationTimeout time.Duration
+	pushURL           string
+	pushJob           string
+	pushInstance      string
+	pushInterval      time.Duration
 }
 
+// derivedMetricNameRegexp matches valid derived metric names.
+var derivedMetricNameRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
 // Parameter is the interface for service parameters.
 type Parameter interface {
 	apply(*parameters)
@@ -59,17 +72,69 @@ func WithChainTime(chainTime chaintime.Service) Parameter {
 	})
 }
 
-// WithCreateServer creates a web server for metrics if true.
+// WithCreateServer creates a web server for metrics, and pushes metrics if configured, if true.
 func WithCreateServer(createServer bool) Parameter {
 	return parameterFunc(func(p *parameters) {
 		p.createServer = createServer
 	})
 }
 
+// WithDerivedMetrics sets the operator-defined metrics derived from other metrics.
+func WithDerivedMetrics(derivedMetrics []*DerivedMetric) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.derivedMetrics = derivedMetrics
+	})
+}
+
+// WithFederationPeers sets the metrics URLs of peer instances to aggregate in the federation endpoint.
+func WithFederationPeers(peers []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.federationPeers = peers
+	})
+}
+
+// WithFederationTimeout sets the timeout for obtaining metrics from federation peers.
+func WithFederationTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.federationTimeout = timeout
+	})
+}
+
+// WithPushURL sets the URL of a Prometheus Pushgateway to which to push metrics.
+func WithPushURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pushURL = url
+	})
+}
+
+// WithPushJob sets the job name with which metrics are pushed.
+func WithPushJob(job string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pushJob = job
+	})
+}
+
+// WithPushInstance sets the instance label with which metrics are pushed.
+func WithPushInstance(instance string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pushInstance = instance
+	})
+}
+
+// WithPushInterval sets the interval between pushes of metrics.
+func WithPushInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pushInterval = interval
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:          zerolog.GlobalLevel(),
+		federationTimeout: 2 * time.Second,
+		pushJob:           "vouch",
+		pushInterval:      15 * time.Second,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -77,9 +142,42 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 		}
 	}
 
-	if parameters.address == "" {
+	if parameters.address == "" && parameters.pushURL == "" {
 		return nil, errors.New("no address specified")
 	}
+	names := make(map[string]bool, len(parameters.derivedMetrics))
+	for _, metric := range parameters.derivedMetrics {
+		if metric == nil {
+			return nil, errors.New("nil derived metric specified")
+		}
+		if !derivedMetricNameRegexp.MatchString(metric.Name) {
+			return nil, fmt.Errorf("invalid derived metric name %q", metric.Name)
+		}
+		if names[metric.Name] {
+			return nil, fmt.Errorf("duplicate derived metric name %q", metric.Name)
+		}
+		names[metric.Name] = true
+		if metric.Expression == "" {
+			return nil, fmt.Errorf("no expression specified for derived metric %q", metric.Name)
+		}
+	}
+	if err := checkFederationPeers(parameters.federationPeers); err != nil {
+		return nil, err
+	}
+	if parameters.federationTimeout == 0 {
+		return nil, errors.New("no federation timeout specified")
+	}
+	if err := checkPushURL(parameters.pushURL); err != nil {
+		return nil, err
+	}
+	if parameters.pushURL != "" {
+		if parameters.pushJob == "" {
+			return nil, errors.New("no push job specified")
+		}
+		if parameters.pushInterval <= 0 {
+			return nil, errors.New("push interval must be positive")
+		}
+	}
 
 	return &parameters, nil
 }
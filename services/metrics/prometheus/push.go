@@ -0,0 +1,75 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// checkPushURL checks that the push URL, if present, is a valid Pushgateway URL.
+func checkPushURL(pushURL string) error {
+	if pushURL == "" {
+		return nil
+	}
+	parsedURL, err := url.Parse(pushURL)
+	if err != nil {
+		return fmt.Errorf("invalid push URL %q", pushURL)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("invalid scheme for push URL %q", pushURL)
+	}
+	if parsedURL.Host == "" {
+		return fmt.Errorf("no host for push URL %q", pushURL)
+	}
+
+	return nil
+}
+
+// newPusher creates a pusher for all metrics to the given Pushgateway.
+func newPusher(pushURL string, job string, instance string, interval time.Duration) *push.Pusher {
+	pusher := push.New(pushURL, job).
+		Gatherer(prometheus.DefaultGatherer).
+		// A push should not outlast the interval between pushes.
+		Client(util.NewHTTPClient(interval))
+	if instance != "" {
+		pusher = pusher.Grouping("instance", instance)
+	}
+
+	return pusher
+}
+
+// metricsPusher pushes metrics to the Pushgateway periodically.
+func (s *Service) metricsPusher(ctx context.Context) {
+	ticker := time.NewTicker(s.pushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Push replaces all metrics previously pushed with the same grouping, so
+			// metrics that are no longer present are removed from the Pushgateway.
+			if err := s.pusher.PushContext(ctx); err != nil {
+				log.Warn().Err(err).Msg("Failed to push metrics")
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPusher(t *testing.T) {
+	type pushed struct {
+		method string
+		path   string
+		body   []byte
+	}
+	pushes := make(chan *pushed, 1)
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		pushes <- &pushed{
+			method: r.Method,
+			path:   r.URL.Path,
+			body:   body,
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	pusher := newPusher(gateway.URL, "vouch", "vouch-1", time.Second)
+	require.NoError(t, pusher.PushContext(context.Background()))
+
+	res := <-pushes
+	require.Equal(t, http.MethodPut, res.method)
+	require.Equal(t, "/metrics/job/vouch/instance/vouch-1", res.path)
+	require.NotEmpty(t, res.body)
+}
+
+func TestCheckPushURL(t *testing.T) {
+	require.NoError(t, checkPushURL(""))
+	require.NoError(t, checkPushURL("https://pushgateway.example.com:9091/"))
+	require.EqualError(t, checkPushURL("pushgateway:9091"), `invalid scheme for push URL "pushgateway:9091"`)
+	require.EqualError(t, checkPushURL("http:///metrics"), `no host for push URL "http:///metrics"`)
+}
@@ -19,9 +19,11 @@ import (
 	"time"
 
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
 )
@@ -34,8 +36,14 @@ type Service struct {
 	schedulerJobsCancelled *prometheus.CounterVec
 	schedulerJobsStarted   *prometheus.CounterVec
 
-	epochsProcessed   prometheus.Counter
-	blockReceiptDelay *prometheus.HistogramVec
+	epochsProcessed             prometheus.Counter
+	blockReceiptDelay           *prometheus.HistogramVec
+	upcomingProposals           prometheus.Gauge
+	nextDuties                  *nextDutiesCollector
+	canaryDuties                *prometheus.CounterVec
+	syncCommitteeMessagesTiming *prometheus.HistogramVec
+	lateBlocks                  prometheus.Counter
+	canaryDutyLatest            *prometheus.GaugeVec
 
 	attestationProcessTimer      prometheus.Histogram
 	attestationProcessRequests   *prometheus.CounterVec
@@ -68,19 +76,36 @@ type Service struct {
 	syncCommitteeSubscriptionProcessRequests *prometheus.CounterVec
 	syncCommitteeSubscribers                 prometheus.Gauge
 
-	accountManagerAccounts *prometheus.GaugeVec
+	accountManagerAccounts               *prometheus.GaugeVec
+	accountManagerRefreshTimer           prometheus.Histogram
+	accountManagerRefreshChanges         *prometheus.CounterVec
+	accountManagerRefreshErrors          *prometheus.CounterVec
+	accountManagerRefreshLatestTimestamp prometheus.Gauge
+	accountManagerWalletAccounts         *prometheus.GaugeVec
 
 	clientOperationCounter   *prometheus.CounterVec
 	clientOperationTimer     *prometheus.HistogramVec
 	strategyOperationCounter *prometheus.CounterVec
 	strategyOperationTimer   *prometheus.HistogramVec
+
+	serviceErrors *prometheus.CounterVec
+
+	gatherer       prometheus.Gatherer
+	derivedMetrics []*derivedMetric
+
+	federationPeers   []string
+	federationTimeout time.Duration
+	federationClient  *http.Client
+
+	pusher       *push.Pusher
+	pushInterval time.Duration
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new prometheus metrics service.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -93,7 +118,15 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	}
 
 	s := &Service{
-		chainTime: parameters.chainTime,
+		chainTime:         parameters.chainTime,
+		gatherer:          prometheus.DefaultGatherer,
+		federationPeers:   parameters.federationPeers,
+		federationTimeout: parameters.federationTimeout,
+		federationClient:  util.NewHTTPClient(0),
+		pushInterval:      parameters.pushInterval,
+	}
+	if parameters.pushURL != "" {
+		s.pusher = newPusher(parameters.pushURL, parameters.pushJob, parameters.pushInstance, parameters.pushInterval)
 	}
 
 	if err := s.setupSchedulerMetrics(); err != nil {
@@ -126,10 +159,28 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	if err := s.setupClientMetrics(); err != nil {
 		return nil, errors.Wrap(err, "failed to set up client metrics")
 	}
+	if err := s.setupErrorMetrics(); err != nil {
+		return nil, errors.Wrap(err, "failed to set up error metrics")
+	}
+	if err := s.setupDerivedMetrics(parameters.derivedMetrics); err != nil {
+		return nil, errors.Wrap(err, "failed to set up derived metrics")
+	}
+	if len(s.derivedMetrics) > 0 {
+		go s.derivedMetricsUpdater(ctx)
+	}
 
-	if parameters.createServer {
+	// Pushing metrics is tied to the creation of the server, as both are only
+	// required by the operational instance of the service.
+	if parameters.createServer && s.pusher != nil {
+		go s.metricsPusher(ctx)
+	}
+
+	if parameters.createServer && parameters.address != "" {
 		go func() {
 			http.Handle("/metrics", promhttp.Handler())
+			if len(s.federationPeers) > 0 {
+				http.HandleFunc("/federation", s.federationHandler)
+			}
 			server := &http.Server{
 				Addr:              parameters.address,
 				ReadHeaderTimeout: 5 * time.Second,
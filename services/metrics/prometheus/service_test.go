@@ -53,6 +53,92 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no address specified",
 		},
+		{
+			name: "DerivedMetricNameInvalid",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithAddress("http://localhost:12345/"),
+				prometheus.WithDerivedMetrics([]*prometheus.DerivedMetric{
+					{
+						Name:       "relay-ratio",
+						Expression: "1",
+					},
+				}),
+			},
+			err: `problem with parameters: invalid derived metric name "relay-ratio"`,
+		},
+		{
+			name: "DerivedMetricExpressionMissing",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithAddress("http://localhost:12345/"),
+				prometheus.WithDerivedMetrics([]*prometheus.DerivedMetric{
+					{
+						Name: "relay_ratio",
+					},
+				}),
+			},
+			err: `problem with parameters: no expression specified for derived metric "relay_ratio"`,
+		},
+		{
+			name: "DerivedMetricExpressionInvalid",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithAddress("http://localhost:12345/"),
+				prometheus.WithDerivedMetrics([]*prometheus.DerivedMetric{
+					{
+						Name:       "relay_ratio",
+						Expression: "1 +",
+					},
+				}),
+			},
+			err: "failed to set up derived metrics: invalid expression for derived metric relay_ratio: position 3: unexpected end of expression",
+		},
+		{
+			name: "FederationPeerInvalid",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithAddress("http://localhost:12345/"),
+				prometheus.WithFederationPeers([]string{"localhost:8081/metrics"}),
+			},
+			err: `problem with parameters: invalid scheme for federation peer "localhost:8081/metrics"`,
+		},
+		{
+			name: "FederationTimeoutZero",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithAddress("http://localhost:12345/"),
+				prometheus.WithFederationPeers([]string{"http://localhost:8081/metrics"}),
+				prometheus.WithFederationTimeout(0),
+			},
+			err: "problem with parameters: no federation timeout specified",
+		},
+		{
+			name: "PushURLInvalid",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithPushURL("pushgateway:9091"),
+			},
+			err: `problem with parameters: invalid scheme for push URL "pushgateway:9091"`,
+		},
+		{
+			name: "PushJobMissing",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithPushURL("http://pushgateway:9091/"),
+				prometheus.WithPushJob(""),
+			},
+			err: "problem with parameters: no push job specified",
+		},
+		{
+			name: "PushIntervalZero",
+			params: []prometheus.Parameter{
+				prometheus.WithLogLevel(zerolog.Disabled),
+				prometheus.WithPushURL("http://pushgateway:9091/"),
+				prometheus.WithPushInterval(0),
+			},
+			err: "problem with parameters: push interval must be positive",
+		},
 		{
 			name: "Good",
 			params: []prometheus.Parameter{
@@ -44,6 +44,18 @@ type ControllerMonitor interface {
 	NewEpoch()
 	// BlockDelay provides the delay between the start of a slot and vouch receiving its block.
 	BlockDelay(epochSlot uint, delay time.Duration)
+	// UpcomingProposals is called when proposer duties for an epoch have been obtained, with the number of proposals to be made.
+	UpcomingProposals(proposals int)
+	// NextDuty is called with the time of the next duty of the given type, or the zero time if no duty is scheduled.
+	NextDuty(duty string, at time.Time)
+
+	// CanaryDuty is called when a duty for a canary validator completes, with the result.
+	CanaryDuty(duty string, result string)
+	// LateBlock is called when a block arrives after attestations for its slot have voted for a different head.
+	LateBlock()
+	// SyncCommitteeMessagesTiming is called when sync committee messages for a slot complete, with what triggered
+	// them, the delay between the start of the slot and their completion, and the result.
+	SyncCommitteeMessagesTiming(trigger string, delay time.Duration, result string)
 }
 
 // BeaconBlockProposalMonitor provides methods to monitor the block proposal process.
@@ -107,6 +119,12 @@ type SyncCommitteeSubscriptionMonitor interface {
 type AccountManagerMonitor interface {
 	// Accounts sets the number of accounts in a given state.
 	Accounts(state string, count uint64)
+	// AccountsRefreshed is called when a refresh of accounts has completed successfully.
+	AccountsRefreshed(started time.Time, added int, removed int)
+	// AccountsRefreshFailed is called when a refresh of accounts encounters an error with an endpoint.
+	AccountsRefreshFailed(endpoint string)
+	// WalletAccounts sets the number of accounts in a given availability state for a wallet.
+	WalletAccounts(wallet string, state string, count int)
 }
 
 // ClientMonitor provides methods to monitor client connections.
@@ -117,6 +135,12 @@ type ClientMonitor interface {
 	StrategyOperation(strategy string, provider string, operation string, duration time.Duration)
 }
 
+// ErrorMonitor provides methods to monitor errors by category.
+type ErrorMonitor interface {
+	// ServiceError is called when a service fails, with the category of the failure.
+	ServiceError(service string, category string)
+}
+
 // ValidatorsManagerMonitor provides methods to monitor the validators manager.
 type ValidatorsManagerMonitor interface{}
 
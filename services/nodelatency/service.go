@@ -0,0 +1,32 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodelatency tracks the latency of beacon nodes, allowing requests
+// to be sent to the fastest nodes first.
+package nodelatency
+
+import (
+	"context"
+	"time"
+)
+
+// Service is the node latency service.
+type Service interface {
+	// Record records the outcome of a request to the beacon node at the
+	// given address.  Failed requests are treated as slow.
+	Record(ctx context.Context, address string, latency time.Duration, succeeded bool)
+
+	// Order returns the given addresses ordered from the lowest to the
+	// highest expected latency.
+	Order(ctx context.Context, addresses []string) []string
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// smoothing is the weight given to each new latency observation.
+const smoothing = 0.2
+
+// Service tracks the latency of beacon nodes as an exponentially weighted
+// moving average of the latency of their requests.
+type Service struct {
+	failureLatency time.Duration
+
+	mu        sync.RWMutex
+	latencies map[string]time.Duration
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new node latency service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "nodelatency").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	return &Service{
+		failureLatency: parameters.failureLatency,
+		latencies:      make(map[string]time.Duration),
+	}, nil
+}
+
+// Record records the outcome of a request to the beacon node at the given address.
+func (s *Service) Record(_ context.Context, address string, latency time.Duration, succeeded bool) {
+	if !succeeded && latency < s.failureLatency {
+		latency = s.failureLatency
+	}
+
+	s.mu.Lock()
+	current, exists := s.latencies[address]
+	if exists {
+		latency = time.Duration((1-smoothing)*float64(current) + smoothing*float64(latency))
+	}
+	s.latencies[address] = latency
+	s.mu.Unlock()
+
+	log.Trace().Str("address", address).Dur("latency", latency).Bool("succeeded", succeeded).Msg("Updated latency")
+	monitorLatency(address, latency)
+}
+
+// Order returns the given addresses ordered from the lowest to the highest
+// expected latency.  Addresses without any history are placed first, so that
+// their latency is learned.
+func (s *Service) Order(_ context.Context, addresses []string) []string {
+	ordered := make([]string, len(addresses))
+	copy(ordered, addresses)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sort.SliceStable(ordered, func(i int, j int) bool {
+		iLatency := s.latencies[ordered[i]]
+		jLatency := s.latencies[ordered[j]]
+		if iLatency != jLatency {
+			return iLatency < jLatency
+		}
+
+		return ordered[i] < ordered[j]
+	})
+
+	return ordered
+}
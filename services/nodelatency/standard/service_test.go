@@ -0,0 +1,103 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodelatency/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	monitor := nullmetrics.New(ctx)
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "FailureLatencyZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithFailureLatency(0),
+			},
+			err: "problem with parameters: failure latency must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithFailureLatency(time.Second),
+	)
+	require.NoError(t, err)
+
+	addresses := []string{"a", "b", "c", "d"}
+
+	// No history; ordered by address.
+	require.Equal(t, []string{"a", "b", "c", "d"}, s.Order(ctx, addresses))
+
+	s.Record(ctx, "a", 300*time.Millisecond, true)
+	s.Record(ctx, "b", 100*time.Millisecond, true)
+	s.Record(ctx, "c", 200*time.Millisecond, true)
+	// Nodes without history go first.
+	require.Equal(t, []string{"d", "b", "c", "a"}, s.Order(ctx, addresses))
+
+	// Failures are treated as slow.
+	s.Record(ctx, "b", 50*time.Millisecond, false)
+	s.Record(ctx, "b", 50*time.Millisecond, false)
+	s.Record(ctx, "d", 150*time.Millisecond, true)
+	require.Equal(t, []string{"d", "c", "a", "b"}, s.Order(ctx, addresses))
+
+	// The supplied addresses are not altered.
+	require.Equal(t, []string{"a", "b", "c", "d"}, addresses)
+}
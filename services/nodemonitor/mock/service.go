@@ -0,0 +1,102 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"context"
+)
+
+// Service is a mock node monitor service.
+type Service struct {
+	unhealthy  map[string]struct{}
+	priorities map[string]int
+}
+
+// New creates a new mock node monitor service, with the given addresses
+// unhealthy.
+func New(unhealthy ...string) *Service {
+	s := &Service{
+		unhealthy: make(map[string]struct{}, len(unhealthy)),
+	}
+	for _, address := range unhealthy {
+		s.unhealthy[address] = struct{}{}
+	}
+
+	return s
+}
+
+// PrioritisedService is a mock node monitor service that provides priorities.
+type PrioritisedService struct {
+	*Service
+}
+
+// NewPrioritised creates a new mock node monitor service with the given
+// priorities, and the given addresses unhealthy.
+func NewPrioritised(priorities map[string]int, unhealthy ...string) *PrioritisedService {
+	s := New(unhealthy...)
+	s.priorities = priorities
+
+	return &PrioritisedService{
+		Service: s,
+	}
+}
+
+// Priority is a mock.
+func (s *PrioritisedService) Priority(_ context.Context, address string) int {
+	return s.priorities[address]
+}
+
+// ExecutionService is a mock node monitor service that provides the health
+// of execution clients.
+type ExecutionService struct {
+	*Service
+	executionUnhealthy map[string]struct{}
+}
+
+// NewExecution creates a new mock node monitor service with the given
+// addresses having unhealthy execution clients.
+func NewExecution(executionUnhealthy ...string) *ExecutionService {
+	s := &ExecutionService{
+		Service:            New(),
+		executionUnhealthy: make(map[string]struct{}, len(executionUnhealthy)),
+	}
+	for _, address := range executionUnhealthy {
+		s.executionUnhealthy[address] = struct{}{}
+	}
+
+	return s
+}
+
+// ExecutionHealthy is a mock.
+func (s *ExecutionService) ExecutionHealthy(_ context.Context, address string) bool {
+	_, exists := s.executionUnhealthy[address]
+
+	return !exists
+}
+
+// Health is a mock.
+func (s *Service) Health(ctx context.Context, address string) float64 {
+	if s.Healthy(ctx, address) {
+		return 1
+	}
+
+	return 0
+}
+
+// Healthy is a mock.
+func (s *Service) Healthy(_ context.Context, address string) bool {
+	_, exists := s.unhealthy[address]
+
+	return !exists
+}
@@ -0,0 +1,146 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodemonitor probes beacon nodes to provide a score of their health,
+// allowing unhealthy nodes to be avoided before requests to them fail.  The
+// same probes track the health of the execution clients connected to the
+// beacon nodes, and detect beacon nodes that restart.
+package nodemonitor
+
+import (
+	"context"
+)
+
+// Service is the node monitor service.
+type Service interface {
+	// Health returns the health score of the beacon node at the given
+	// address, from 0 for a node that cannot be used to 1 for a fully
+	// healthy node.  Nodes that are not monitored are considered healthy.
+	Health(ctx context.Context, address string) float64
+
+	// Healthy returns true if the beacon node at the given address is
+	// healthy enough to be used.
+	Healthy(ctx context.Context, address string) bool
+}
+
+// ExecutionHealthProvider provides the health of the execution clients
+// connected to beacon nodes.
+type ExecutionHealthProvider interface {
+	// ExecutionHealthy returns true if the beacon node at the given address
+	// is neither syncing nor optimistic, so its execution client can be relied
+	// upon to build a block.  Nodes that are not monitored are considered
+	// healthy.
+	ExecutionHealthy(ctx context.Context, address string) bool
+}
+
+// RestartHandler is called when a beacon node is detected to have restarted.
+type RestartHandler func(ctx context.Context, address string)
+
+// RestartNotifier notifies handlers when beacon nodes restart.
+type RestartNotifier interface {
+	// AddRestartHandler adds a handler to be called when a beacon node restarts.
+	AddRestartHandler(handler RestartHandler)
+}
+
+// PriorityProvider is the interface for node monitors that know the
+// priorities of beacon nodes.
+type PriorityProvider interface {
+	// Priority returns the priority of the beacon node at the given address.
+	// Lower values have higher priority; beacon nodes without a configured
+	// priority have priority 0.
+	Priority(ctx context.Context, address string) int
+}
+
+// PreferredProviders returns the providers whose beacon nodes have the
+// highest priority of those with a healthy beacon node, along with any
+// higher priority providers.  Lower priority providers are only returned if
+// all higher priority beacon nodes are unhealthy.  If the node monitor does
+// not provide priorities, or none of the beacon nodes are healthy, all of the
+// providers are returned.
+func PreferredProviders[T any](ctx context.Context, nodeMonitor Service, providers map[string]T) map[string]T {
+	priorityProvider, isProvider := nodeMonitor.(PriorityProvider)
+	if !isProvider {
+		return providers
+	}
+
+	found := false
+	highest := 0
+	for address := range providers {
+		if !nodeMonitor.Healthy(ctx, address) {
+			continue
+		}
+		priority := priorityProvider.Priority(ctx, address)
+		if !found || priority < highest {
+			highest = priority
+			found = true
+		}
+	}
+	if !found {
+		return providers
+	}
+
+	preferred := make(map[string]T, len(providers))
+	for address, provider := range providers {
+		if priorityProvider.Priority(ctx, address) <= highest {
+			preferred[address] = provider
+		}
+	}
+
+	return preferred
+}
+
+// HealthyProviders returns the healthy providers of those preferred by
+// PreferredProviders.  If there is no node monitor, or none of the beacon
+// nodes are healthy, all of the providers are returned so that requests are
+// still made.
+func HealthyProviders[T any](ctx context.Context, nodeMonitor Service, providers map[string]T) map[string]T {
+	if nodeMonitor == nil {
+		return providers
+	}
+
+	preferred := PreferredProviders(ctx, nodeMonitor, providers)
+	healthy := make(map[string]T, len(preferred))
+	for address, provider := range preferred {
+		if nodeMonitor.Healthy(ctx, address) {
+			healthy[address] = provider
+		}
+	}
+	if len(healthy) == 0 {
+		return providers
+	}
+
+	return healthy
+}
+
+// ExecutionHealthyProviders returns the providers whose beacon nodes have
+// healthy execution clients.  If none are healthy, or the node monitor does
+// not track execution health, all providers are returned so that requests are
+// still made.
+func ExecutionHealthyProviders[T any](ctx context.Context, nodeMonitor Service, providers map[string]T) map[string]T {
+	executionHealthProvider, isProvider := nodeMonitor.(ExecutionHealthProvider)
+	if !isProvider {
+		return providers
+	}
+
+	healthy := make(map[string]T, len(providers))
+	for address, provider := range providers {
+		if executionHealthProvider.ExecutionHealthy(ctx, address) {
+			healthy[address] = provider
+		}
+	}
+	if len(healthy) == 0 {
+		return providers
+	}
+
+	return healthy
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodemonitor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/services/nodemonitor/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthyProviders(t *testing.T) {
+	ctx := context.Background()
+
+	providers := map[string]int{
+		"a": 1,
+		"b": 2,
+		"c": 3,
+	}
+
+	require.Equal(t, providers, nodemonitor.HealthyProviders(ctx, nil, providers))
+	require.Equal(t, providers, nodemonitor.HealthyProviders(ctx, mock.New(), providers))
+	require.Equal(t, map[string]int{"a": 1, "c": 3}, nodemonitor.HealthyProviders(ctx, mock.New("b"), providers))
+	require.Equal(t, providers, nodemonitor.HealthyProviders(ctx, mock.New("a", "b", "c"), providers))
+}
+
+func TestPreferredProviders(t *testing.T) {
+	ctx := context.Background()
+
+	providers := map[string]int{
+		"a": 1,
+		"b": 2,
+		"c": 3,
+	}
+	priorities := map[string]int{
+		"b": 1,
+		"c": 2,
+	}
+
+	// No priorities.
+	require.Equal(t, providers, nodemonitor.PreferredProviders(ctx, nil, providers))
+	require.Equal(t, providers, nodemonitor.PreferredProviders(ctx, mock.New("a"), providers))
+
+	// Lower priorities are only used when higher priorities are unhealthy.
+	require.Equal(t, map[string]int{"a": 1}, nodemonitor.PreferredProviders(ctx, mock.NewPrioritised(priorities), providers))
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, nodemonitor.PreferredProviders(ctx, mock.NewPrioritised(priorities, "a"), providers))
+	require.Equal(t, providers, nodemonitor.PreferredProviders(ctx, mock.NewPrioritised(priorities, "a", "b"), providers))
+	require.Equal(t, providers, nodemonitor.PreferredProviders(ctx, mock.NewPrioritised(priorities, "a", "b", "c"), providers))
+
+	// Healthy providers are taken from the preferred providers.
+	require.Equal(t, map[string]int{"a": 1}, nodemonitor.HealthyProviders(ctx, mock.NewPrioritised(priorities), providers))
+	require.Equal(t, map[string]int{"b": 2}, nodemonitor.HealthyProviders(ctx, mock.NewPrioritised(priorities, "a"), providers))
+	require.Equal(t, map[string]int{"c": 3}, nodemonitor.HealthyProviders(ctx, mock.NewPrioritised(priorities, "a", "b"), providers))
+	require.Equal(t, providers, nodemonitor.HealthyProviders(ctx, mock.NewPrioritised(priorities, "a", "b", "c"), providers))
+}
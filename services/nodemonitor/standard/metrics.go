@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	healthGauge          *prometheus.GaugeVec
+	executionHealthGauge *prometheus.GaugeVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if healthGauge != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	healthGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "nodemonitor",
+		Name:      "health",
+		Help:      "The health score of each beacon node, from 0 to 1.",
+	}, []string{"address"})
+	if err := prometheus.Register(healthGauge); err != nil {
+		return err
+	}
+
+	executionHealthGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "nodemonitor",
+		Name:      "execution_healthy",
+		Help:      "1 if the beacon node is neither syncing nor optimistic, otherwise 0.",
+	}, []string{"address"})
+	return prometheus.Register(executionHealthGauge)
+}
+
+func monitorHealth(address string, score float64, executionHealthy bool) {
+	if healthGauge != nil {
+		healthGauge.WithLabelValues(address).Set(score)
+	}
+	if executionHealthGauge != nil {
+		if executionHealthy {
+			executionHealthGauge.WithLabelValues(address).Set(1)
+		} else {
+			executionHealthGauge.WithLabelValues(address).Set(0)
+		}
+	}
+}
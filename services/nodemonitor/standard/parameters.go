@@ -0,0 +1,180 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	monitor       metrics.Service
+	scheduler     scheduler.Service
+	chainTime     chaintime.Service
+	clients       map[string]eth2client.Service
+	probeInterval time.Duration
+	scoring       bool
+	threshold     float64
+	minPeers      uint64
+	maxLatency    time.Duration
+	priorities    map[string]int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithChainTime sets the chain time service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithClients sets the beacon node clients to probe, keyed by address.
+func WithClients(clients map[string]eth2client.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clients = clients
+	})
+}
+
+// WithProbeInterval sets the interval between probes of the beacon nodes.
+func WithProbeInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.probeInterval = interval
+	})
+}
+
+// WithScoring sets whether the health scores of beacon nodes are used.  If
+// not, all beacon nodes are reported as healthy, although execution health
+// and restarts continue to be tracked.
+func WithScoring(scoring bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scoring = scoring
+	})
+}
+
+// WithThreshold sets the health score below which a beacon node is considered unhealthy.
+func WithThreshold(threshold float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.threshold = threshold
+	})
+}
+
+// WithMinPeers sets the number of peers below which the health of a beacon node is reduced.
+func WithMinPeers(peers uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.minPeers = peers
+	})
+}
+
+// WithMaxLatency sets the latency above which the health of a beacon node is reduced.
+func WithMaxLatency(latency time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxLatency = latency
+	})
+}
+
+// WithPriorities sets the priorities of the beacon nodes, keyed by address.
+// Lower values have higher priority.
+func WithPriorities(priorities map[string]int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.priorities = priorities
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		probeInterval: 12 * time.Second,
+		scoring:       true,
+		threshold:     0.5,
+		minPeers:      16,
+		maxLatency:    time.Second,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time specified")
+	}
+	if len(parameters.clients) == 0 {
+		return nil, errors.New("no clients specified")
+	}
+	for address, client := range parameters.clients {
+		if _, isProvider := client.(eth2client.NodeSyncingProvider); !isProvider {
+			return nil, fmt.Errorf("client %s is not a node syncing provider", address)
+		}
+	}
+	if parameters.probeInterval <= 0 {
+		return nil, errors.New("probe interval must be positive")
+	}
+	if parameters.threshold < 0 || parameters.threshold > 1 {
+		return nil, errors.New("threshold must be between 0 and 1")
+	}
+	if parameters.maxLatency <= 0 {
+		return nil, errors.New("max latency must be positive")
+	}
+	for address, priority := range parameters.priorities {
+		if priority < 0 {
+			return nil, fmt.Errorf("priority for %s cannot be negative", address)
+		}
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,385 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// nodeHealth is the health of a beacon node as of the last probe.
+type nodeHealth struct {
+	score            float64
+	healthy          bool
+	executionHealthy bool
+}
+
+// nodeState is the state of a beacon node as of the last probe, used to
+// detect restarts.
+type nodeState struct {
+	initialised bool
+	reachable   bool
+	version     string
+	genesisTime time.Time
+	headSlot    phase0.Slot
+}
+
+// Service probes beacon nodes to score their health and detect restarts.
+type Service struct {
+	chainTime     chaintime.Service
+	clients       map[string]eth2client.Service
+	addresses     []string
+	probeInterval time.Duration
+	scoring       bool
+	threshold     float64
+	minPeers      uint64
+	maxLatency    time.Duration
+	priorities    map[string]int
+
+	healthMu sync.RWMutex
+	health   map[string]*nodeHealth
+
+	statesMu sync.Mutex
+	states   map[string]*nodeState
+
+	handlersMu sync.Mutex
+	handlers   []nodemonitor.RestartHandler
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new node monitor service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "nodemonitor").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	addresses := make([]string, 0, len(parameters.clients))
+	for address := range parameters.clients {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	s := &Service{
+		chainTime:     parameters.chainTime,
+		clients:       parameters.clients,
+		addresses:     addresses,
+		probeInterval: parameters.probeInterval,
+		scoring:       parameters.scoring,
+		threshold:     parameters.threshold,
+		minPeers:      parameters.minPeers,
+		maxLatency:    parameters.maxLatency,
+		priorities:    parameters.priorities,
+		health:        make(map[string]*nodeHealth, len(addresses)),
+		states:        make(map[string]*nodeState, len(addresses)),
+		handlers:      make([]nodemonitor.RestartHandler, 0),
+	}
+	for _, address := range addresses {
+		s.states[address] = &nodeState{}
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"nodemonitor",
+		"Probe beacon nodes",
+		s.probeRuntime,
+		nil,
+		s.probe,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to start beacon node prober")
+	}
+
+	// Probe immediately, rather than waiting for the first interval to pass.
+	go s.probe(ctx, nil)
+
+	return s, nil
+}
+
+// Health returns the health score of the beacon node at the given address.
+func (s *Service) Health(_ context.Context, address string) float64 {
+	if !s.scoring {
+		return 1
+	}
+
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+
+	health, exists := s.health[address]
+	if !exists {
+		return 1
+	}
+
+	return health.score
+}
+
+// Healthy returns true if the beacon node at the given address is healthy.
+func (s *Service) Healthy(_ context.Context, address string) bool {
+	if !s.scoring {
+		return true
+	}
+
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+
+	health, exists := s.health[address]
+	if !exists {
+		return true
+	}
+
+	return health.healthy
+}
+
+// ExecutionHealthy returns true if the beacon node at the given address is
+// neither syncing nor optimistic.
+func (s *Service) ExecutionHealthy(_ context.Context, address string) bool {
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+
+	health, exists := s.health[address]
+	if !exists {
+		return true
+	}
+
+	return health.executionHealthy
+}
+
+// Priority returns the priority of the beacon node at the given address.
+func (s *Service) Priority(_ context.Context, address string) int {
+	return s.priorities[address]
+}
+
+// AddRestartHandler adds a handler to be called when a beacon node restarts.
+func (s *Service) AddRestartHandler(handler nodemonitor.RestartHandler) {
+	s.handlersMu.Lock()
+	s.handlers = append(s.handlers, handler)
+	s.handlersMu.Unlock()
+}
+
+func (s *Service) probeRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	return time.Now().Add(s.probeInterval), nil
+}
+
+// probe probes all beacon nodes.
+func (s *Service) probe(ctx context.Context, _ interface{}) {
+	var wg sync.WaitGroup
+	for _, address := range s.addresses {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			s.probeNode(ctx, address)
+		}(address)
+	}
+	wg.Wait()
+}
+
+// probeNode probes a single beacon node, updating its health and notifying
+// handlers if it has restarted.
+func (s *Service) probeNode(ctx context.Context, address string) {
+	probeCtx, cancel := context.WithTimeout(ctx, s.probeInterval)
+	started := time.Now()
+	syncingResponse, err := s.clients[address].(eth2client.NodeSyncingProvider).NodeSyncing(probeCtx, &api.NodeSyncingOpts{})
+	latency := time.Since(started)
+	var syncState *apiv1.SyncState
+	if err != nil {
+		log.Debug().Str("address", address).Err(err).Msg("Failed to obtain syncing state")
+	} else {
+		syncState = syncingResponse.Data
+	}
+	score := s.score(probeCtx, address, syncState, latency)
+	state := s.fetchState(probeCtx, address, syncState)
+	cancel()
+
+	healthy := score >= s.threshold
+	executionHealthy := syncState != nil && !syncState.IsSyncing && !syncState.IsOptimistic
+	s.healthMu.Lock()
+	previous, exists := s.health[address]
+	s.health[address] = &nodeHealth{
+		score:            score,
+		healthy:          healthy,
+		executionHealthy: executionHealthy,
+	}
+	s.healthMu.Unlock()
+
+	if s.scoring && (!exists || previous.healthy != healthy) {
+		if healthy {
+			log.Info().Str("address", address).Float64("score", score).Msg("Beacon node healthy")
+		} else {
+			log.Warn().Str("address", address).Float64("score", score).Msg("Beacon node unhealthy; deprioritising")
+		}
+	}
+	monitorHealth(address, score, executionHealthy)
+
+	s.statesMu.Lock()
+	previousState := s.states[address]
+	s.states[address] = state
+	s.statesMu.Unlock()
+
+	if reason := restartReason(previousState, state); reason != "" {
+		log.Info().Str("address", address).Str("reason", reason).Msg("Beacon node restarted")
+		s.notifyHandlers(ctx, address)
+	}
+}
+
+// score calculates the health score of a beacon node.  The score is the
+// product of the scores for each of the node's sync state, head slot
+// recency, peer count and response latency.
+func (s *Service) score(ctx context.Context,
+	address string,
+	syncState *apiv1.SyncState,
+	latency time.Duration,
+) float64 {
+	log := log.With().Str("address", address).Logger()
+
+	if syncState == nil {
+		log.Trace().Msg("Beacon node unreachable; scoring as unhealthy")
+		return 0
+	}
+	if syncState.IsSyncing {
+		log.Trace().Msg("Beacon node syncing; scoring as unhealthy")
+		return 0
+	}
+
+	score := 1.0
+	if syncState.IsOptimistic {
+		score *= 0.5
+	}
+
+	// A head one slot behind the current slot is expected early in a slot.
+	currentSlot := s.chainTime.CurrentSlot()
+	if syncState.HeadSlot+1 < currentSlot {
+		score /= float64(currentSlot - syncState.HeadSlot)
+	}
+
+	if latency > s.maxLatency {
+		score *= float64(s.maxLatency) / float64(latency)
+	}
+
+	if peersProvider, isProvider := s.clients[address].(eth2client.NodePeersProvider); isProvider && s.minPeers > 0 {
+		peersResponse, err := peersProvider.NodePeers(ctx, &api.NodePeersOpts{
+			State: []string{"connected"},
+		})
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to obtain peers; ignoring")
+		} else if peers := uint64(len(peersResponse.Data)); peers < s.minPeers {
+			score *= float64(peers) / float64(s.minPeers)
+		}
+	}
+
+	log.Trace().
+		Uint64("head_slot", uint64(syncState.HeadSlot)).
+		Bool("optimistic", syncState.IsOptimistic).
+		Dur("latency", latency).
+		Float64("score", score).
+		Msg("Scored beacon node")
+
+	return score
+}
+
+// fetchState fetches the state of a beacon node used to detect restarts.
+func (s *Service) fetchState(ctx context.Context,
+	address string,
+	syncState *apiv1.SyncState,
+) *nodeState {
+	state := &nodeState{
+		initialised: true,
+	}
+	if syncState == nil {
+		return state
+	}
+	state.reachable = true
+	state.headSlot = syncState.HeadSlot
+
+	client := s.clients[address]
+	if versionProvider, isProvider := client.(eth2client.NodeVersionProvider); isProvider {
+		versionResponse, err := versionProvider.NodeVersion(ctx, &api.NodeVersionOpts{})
+		if err != nil {
+			log.Debug().Str("address", address).Err(err).Msg("Failed to obtain version of beacon node")
+		} else {
+			state.version = versionResponse.Data
+		}
+	}
+
+	if genesisProvider, isProvider := client.(eth2client.GenesisProvider); isProvider {
+		genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+		if err != nil {
+			log.Debug().Str("address", address).Err(err).Msg("Failed to obtain genesis of beacon node")
+		} else {
+			state.genesisTime = genesisResponse.Data.GenesisTime
+		}
+	}
+
+	return state
+}
+
+// restartReason returns the reason for considering that a beacon node has restarted
+// between two states, or an empty string if it has not.
+func restartReason(previous *nodeState, current *nodeState) string {
+	if !previous.initialised || !current.reachable {
+		return ""
+	}
+
+	switch {
+	case !previous.reachable:
+		return "reconnected"
+	case previous.version != "" && current.version != "" && previous.version != current.version:
+		return "version changed"
+	case !previous.genesisTime.IsZero() && !current.genesisTime.IsZero() && !previous.genesisTime.Equal(current.genesisTime):
+		return "genesis changed"
+	case current.headSlot < previous.headSlot:
+		return "head slot went backwards"
+	default:
+		return ""
+	}
+}
+
+// notifyHandlers notifies the handlers of a restart.
+func (s *Service) notifyHandlers(ctx context.Context, address string) {
+	s.handlersMu.Lock()
+	handlers := make([]nodemonitor.RestartHandler, len(s.handlers))
+	copy(handlers, s.handlers)
+	s.handlersMu.Unlock()
+
+	for _, handler := range handlers {
+		handler(ctx, address)
+	}
+}
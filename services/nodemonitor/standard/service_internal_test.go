@@ -0,0 +1,436 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// plainClient is a beacon node client that provides no information.
+type plainClient struct{}
+
+func (*plainClient) Name() string {
+	return "plain"
+}
+
+func (*plainClient) Address() string {
+	return "http://localhost:5052/"
+}
+
+// probedClient is a beacon node client with a fixed state.
+type probedClient struct {
+	reachable  bool
+	syncing    bool
+	optimistic bool
+	headSlot   phase0.Slot
+	peers      int
+}
+
+func (*probedClient) Name() string {
+	return "probed"
+}
+
+func (*probedClient) Address() string {
+	return "http://localhost:5052/"
+}
+
+func (c *probedClient) NodeSyncing(_ context.Context, _ *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error) {
+	if !c.reachable {
+		return nil, errors.New("connection refused")
+	}
+
+	return &api.Response[*apiv1.SyncState]{
+		Data: &apiv1.SyncState{
+			HeadSlot:     c.headSlot,
+			IsSyncing:    c.syncing,
+			IsOptimistic: c.optimistic,
+		},
+	}, nil
+}
+
+func (c *probedClient) NodePeers(_ context.Context, _ *api.NodePeersOpts) (*api.Response[[]*apiv1.Peer], error) {
+	peers := make([]*apiv1.Peer, c.peers)
+	for i := range peers {
+		peers[i] = &apiv1.Peer{State: "connected"}
+	}
+
+	return &api.Response[[]*apiv1.Peer]{Data: peers}, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	client := &probedClient{reachable: true}
+
+	tests := []struct {
+		name   string
+		params []Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithClients(map[string]eth2client.Service{"a": client}),
+			},
+			err: "problem with parameters: no chain time specified",
+		},
+		{
+			name: "ClientsMissing",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+			},
+			err: "problem with parameters: no clients specified",
+		},
+		{
+			name: "ClientNotSyncingProvider",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": &plainClient{}}),
+			},
+			err: "problem with parameters: client a is not a node syncing provider",
+		},
+		{
+			name: "ProbeIntervalZero",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+				WithProbeInterval(0),
+			},
+			err: "problem with parameters: probe interval must be positive",
+		},
+		{
+			name: "ThresholdTooHigh",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+				WithThreshold(1.5),
+			},
+			err: "problem with parameters: threshold must be between 0 and 1",
+		},
+		{
+			name: "MaxLatencyZero",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+				WithMaxLatency(0),
+			},
+			err: "problem with parameters: max latency must be positive",
+		},
+		{
+			name: "PriorityNegative",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+				WithPriorities(map[string]int{"a": -1}),
+			},
+			err: "problem with parameters: priority for a cannot be negative",
+		},
+		{
+			name: "Good",
+			params: []Parameter{
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithScheduler(mockscheduler.New()),
+				WithChainTime(chainTime),
+				WithClients(map[string]eth2client.Service{"a": client}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis was 10 slots ago.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-10*12*time.Second))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	clients := map[string]eth2client.Service{
+		"healthy":     &probedClient{reachable: true, headSlot: 10, peers: 32},
+		"behind":      &probedClient{reachable: true, headSlot: 9, peers: 32},
+		"lagging":     &probedClient{reachable: true, headSlot: 5, peers: 32},
+		"optimistic":  &probedClient{reachable: true, optimistic: true, headSlot: 10, peers: 32},
+		"few_peers":   &probedClient{reachable: true, headSlot: 10, peers: 4},
+		"syncing":     &probedClient{reachable: true, syncing: true, headSlot: 10, peers: 32},
+		"unreachable": &probedClient{},
+	}
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithScheduler(mockscheduler.New()),
+		WithChainTime(chainTime),
+		WithClients(clients),
+	)
+	require.NoError(t, err)
+
+	// Unknown nodes are healthy.
+	require.Equal(t, 1.0, s.Health(ctx, "unknown"))
+	require.True(t, s.Healthy(ctx, "unknown"))
+
+	s.probe(ctx, nil)
+
+	tests := []struct {
+		address          string
+		health           float64
+		healthy          bool
+		executionHealthy bool
+	}{
+		{address: "healthy", health: 1, healthy: true, executionHealthy: true},
+		{address: "behind", health: 1, healthy: true, executionHealthy: true},
+		{address: "lagging", health: 0.2, healthy: false, executionHealthy: true},
+		{address: "optimistic", health: 0.5, healthy: true, executionHealthy: false},
+		{address: "few_peers", health: 0.25, healthy: false, executionHealthy: true},
+		{address: "syncing", health: 0, healthy: false, executionHealthy: false},
+		{address: "unreachable", health: 0, healthy: false, executionHealthy: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			require.InDelta(t, test.health, s.Health(ctx, test.address), 0.001)
+			require.Equal(t, test.healthy, s.Healthy(ctx, test.address))
+			require.Equal(t, test.executionHealthy, s.ExecutionHealthy(ctx, test.address))
+		})
+	}
+}
+
+func TestScoringDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithScheduler(mockscheduler.New()),
+		WithChainTime(chainTime),
+		WithClients(map[string]eth2client.Service{"a": &probedClient{reachable: true, syncing: true}}),
+		WithScoring(false),
+	)
+	require.NoError(t, err)
+
+	s.probe(ctx, nil)
+	require.Equal(t, 1.0, s.Health(ctx, "a"))
+	require.True(t, s.Healthy(ctx, "a"))
+	require.False(t, s.ExecutionHealthy(ctx, "a"))
+}
+
+// restartingClient is a beacon node client whose state can be altered.
+type restartingClient struct {
+	mu          sync.Mutex
+	reachable   bool
+	version     string
+	genesisTime time.Time
+	headSlot    phase0.Slot
+}
+
+func (*restartingClient) Name() string {
+	return "restarting"
+}
+
+func (*restartingClient) Address() string {
+	return "http://localhost:5052/"
+}
+
+func (c *restartingClient) NodeSyncing(_ context.Context, _ *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.reachable {
+		return nil, errors.New("connection refused")
+	}
+
+	return &api.Response[*apiv1.SyncState]{Data: &apiv1.SyncState{HeadSlot: c.headSlot}}, nil
+}
+
+func (c *restartingClient) NodeVersion(_ context.Context, _ *api.NodeVersionOpts) (*api.Response[string], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &api.Response[string]{Data: c.version}, nil
+}
+
+func (c *restartingClient) Genesis(_ context.Context, _ *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &api.Response[*apiv1.Genesis]{Data: &apiv1.Genesis{GenesisTime: c.genesisTime}}, nil
+}
+
+func TestRestarts(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	genesisTime := time.Unix(1606824023, 0)
+	client := &restartingClient{
+		reachable:   true,
+		version:     "v1",
+		genesisTime: genesisTime,
+		headSlot:    100,
+	}
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithScheduler(mockscheduler.New()),
+		WithChainTime(chainTime),
+		WithClients(map[string]eth2client.Service{"a": client}),
+	)
+	require.NoError(t, err)
+	// Wait for the probe made on startup to complete.
+	require.Eventually(t, func() bool {
+		s.statesMu.Lock()
+		defer s.statesMu.Unlock()
+
+		return s.states["a"].initialised
+	}, time.Second, time.Millisecond)
+
+	var restartsMu sync.Mutex
+	restarts := make([]string, 0)
+	s.AddRestartHandler(func(_ context.Context, address string) {
+		restartsMu.Lock()
+		restarts = append(restarts, address)
+		restartsMu.Unlock()
+	})
+	numRestarts := func() int {
+		restartsMu.Lock()
+		defer restartsMu.Unlock()
+
+		return len(restarts)
+	}
+
+	update := func(f func()) {
+		client.mu.Lock()
+		f()
+		client.mu.Unlock()
+		s.probe(ctx, nil)
+	}
+
+	// Initial probe does not notify.
+	s.probe(ctx, nil)
+	require.Equal(t, 0, numRestarts())
+
+	// Normal progress does not notify.
+	update(func() { client.headSlot = 101 })
+	require.Equal(t, 0, numRestarts())
+
+	// Unreachable does not notify, reachable again does.
+	update(func() { client.reachable = false })
+	require.Equal(t, 0, numRestarts())
+	update(func() { client.reachable = true })
+	require.Equal(t, 1, numRestarts())
+
+	// Version change notifies.
+	update(func() { client.version = "v2" })
+	require.Equal(t, 2, numRestarts())
+
+	// Genesis change notifies.
+	update(func() { client.genesisTime = genesisTime.Add(time.Hour) })
+	require.Equal(t, 3, numRestarts())
+
+	// Head slot going backwards notifies.
+	update(func() { client.headSlot = 50 })
+	require.Equal(t, 4, numRestarts())
+
+	// No further change does not notify.
+	s.probe(ctx, nil)
+	require.Equal(t, 4, numRestarts())
+}
@@ -0,0 +1,177 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/attestantio/vouch/services/proposalaudit"
+	"github.com/pkg/errors"
+)
+
+// entryJSON is the JSON representation of an audit entry.
+type entryJSON struct {
+	Timestamp           string            `json:"timestamp"`
+	Slot                string            `json:"slot"`
+	ValidatorIndex      string            `json:"validator_index"`
+	Bids                map[string]string `json:"bids"`
+	WinningRelays       []string          `json:"winning_relays"`
+	WinningValue        string            `json:"winning_value,omitempty"`
+	LocalExecutionValue string            `json:"local_execution_value,omitempty"`
+	LocalConsensusValue string            `json:"local_consensus_value,omitempty"`
+	Source              string            `json:"source"`
+	Reason              string            `json:"reason"`
+	Submitted           bool              `json:"submitted"`
+}
+
+// AuditProposal records the audit entry for a proposal.
+func (s *Service) AuditProposal(_ context.Context, proposal *proposalaudit.Proposal) {
+	if proposal == nil {
+		return
+	}
+
+	data, err := json.Marshal(newEntryJSON(time.Now(), proposal))
+	if err != nil {
+		log.Error().Uint64("slot", uint64(proposal.Slot)).Err(err).Msg("Failed to marshal audit entry")
+		return
+	}
+	data = append(data, '\n')
+
+	if err := s.write(data); err != nil {
+		log.Error().Uint64("slot", uint64(proposal.Slot)).Err(err).Msg("Failed to write audit entry")
+		return
+	}
+	log.Trace().Uint64("slot", uint64(proposal.Slot)).Msg("Wrote audit entry")
+}
+
+func newEntryJSON(timestamp time.Time, proposal *proposalaudit.Proposal) *entryJSON {
+	bids := make(map[string]string, len(proposal.Bids))
+	for relay, value := range proposal.Bids {
+		bids[relay] = weiString(value)
+	}
+	winningRelays := proposal.WinningRelays
+	if winningRelays == nil {
+		winningRelays = make([]string, 0)
+	}
+
+	return &entryJSON{
+		Timestamp:           timestamp.UTC().Format(time.RFC3339Nano),
+		Slot:                fmt.Sprintf("%d", proposal.Slot),
+		ValidatorIndex:      fmt.Sprintf("%d", proposal.ValidatorIndex),
+		Bids:                bids,
+		WinningRelays:       winningRelays,
+		WinningValue:        weiString(proposal.WinningValue),
+		LocalExecutionValue: weiString(proposal.LocalExecutionValue),
+		LocalConsensusValue: weiString(proposal.LocalConsensusValue),
+		Source:              proposal.Source,
+		Reason:              proposal.Reason,
+		Submitted:           proposal.Submitted,
+	}
+}
+
+// weiString returns the decimal representation of a value, or an empty
+// string if the value is not present.
+func weiString(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
+
+// write writes data to the audit log, rotating the log first if required.
+func (s *Service) write(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.New("audit log is closed")
+	}
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	if err != nil {
+		return errors.Wrap(err, "failed to write to audit log")
+	}
+
+	return nil
+}
+
+// rotate moves the current audit log to the first rotated file, shifting
+// existing rotated files along and removing those beyond the retention limits.
+// It must be called with the lock held.
+func (s *Service) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close audit log")
+	}
+	s.file = nil
+
+	if s.maxFiles == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to remove audit log")
+		}
+	} else {
+		if err := os.Remove(s.rotatedPath(s.maxFiles)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to remove oldest rotated audit log")
+		}
+		for i := s.maxFiles - 1; i > 0; i-- {
+			if err := os.Rename(s.rotatedPath(i), s.rotatedPath(i+1)); err != nil && !os.IsNotExist(err) {
+				return errors.Wrap(err, "failed to rename rotated audit log")
+			}
+		}
+		if err := os.Rename(s.path, s.rotatedPath(1)); err != nil {
+			return errors.Wrap(err, "failed to rotate audit log")
+		}
+		s.pruneByAge()
+	}
+
+	return s.open()
+}
+
+// pruneByAge removes rotated audit logs that are older than the maximum age.
+func (s *Service) pruneByAge() {
+	if s.maxAge == 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-s.maxAge)
+	for i := 1; i <= s.maxFiles; i++ {
+		path := s.rotatedPath(i)
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(path); err != nil {
+				log.Warn().Str("path", path).Err(err).Msg("Failed to remove expired audit log")
+			}
+		}
+	}
+}
+
+// rotatedPath returns the path of the given rotated audit log.
+func (s *Service) rotatedPath(index int) string {
+	return fmt.Sprintf("%s.%d", s.path, index)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/proposalaudit"
+	"github.com/attestantio/vouch/services/proposalaudit/file"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditProposal(t *testing.T) {
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	s, err := file.New(ctx,
+		file.WithLogLevel(zerolog.Disabled),
+		file.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	s.AuditProposal(ctx, nil)
+	s.AuditProposal(ctx, &proposalaudit.Proposal{
+		Slot:           12345,
+		ValidatorIndex: 678,
+		Bids: map[string]*big.Int{
+			"https://relay1.example.com/": big.NewInt(2000000000000000),
+			"https://relay2.example.com/": big.NewInt(1000000000000000),
+		},
+		WinningRelays:       []string{"https://relay1.example.com/"},
+		WinningValue:        big.NewInt(2000000000000000),
+		LocalExecutionValue: big.NewInt(500000000000000),
+		Source:              "relay",
+		Reason:              "relay bid won the auction",
+		Submitted:           true,
+	})
+	s.AuditProposal(ctx, &proposalaudit.Proposal{
+		Slot:           12346,
+		ValidatorIndex: 679,
+		Source:         "local",
+		Reason:         "no relay bids received",
+		Submitted:      true,
+	})
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	entry := make(map[string]any)
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, "12345", entry["slot"])
+	require.Equal(t, "678", entry["validator_index"])
+	require.Equal(t, map[string]any{
+		"https://relay1.example.com/": "2000000000000000",
+		"https://relay2.example.com/": "1000000000000000",
+	}, entry["bids"])
+	require.Equal(t, []any{"https://relay1.example.com/"}, entry["winning_relays"])
+	require.Equal(t, "2000000000000000", entry["winning_value"])
+	require.Equal(t, "500000000000000", entry["local_execution_value"])
+	require.NotContains(t, entry, "local_consensus_value")
+	require.Equal(t, "relay", entry["source"])
+	require.Equal(t, true, entry["submitted"])
+	require.NotEmpty(t, entry["timestamp"])
+
+	entry = make(map[string]any)
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	require.Equal(t, map[string]any{}, entry["bids"])
+	require.Equal(t, []any{}, entry["winning_relays"])
+	require.NotContains(t, entry, "winning_value")
+	require.Equal(t, "local", entry["source"])
+	require.Equal(t, "no relay bids received", entry["reason"])
+}
+
+func TestAuditProposalRotation(t *testing.T) {
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	s, err := file.New(ctx,
+		file.WithLogLevel(zerolog.Disabled),
+		file.WithPath(path),
+		// Small enough that every entry causes a rotation.
+		file.WithMaxSize(10),
+		file.WithMaxFiles(2),
+	)
+	require.NoError(t, err)
+
+	for slot := 1; slot <= 4; slot++ {
+		s.AuditProposal(ctx, &proposalaudit.Proposal{
+			Slot:   phase0.Slot(slot),
+			Source: "local",
+		})
+	}
+
+	// The current file holds the latest entry, and the rotated files the two before it.
+	for suffix, slot := range map[string]string{"": "4", ".1": "3", ".2": "2"} {
+		data, err := os.ReadFile(path + suffix)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 1)
+		entry := make(map[string]any)
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		require.Equal(t, slot, entry["slot"])
+	}
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
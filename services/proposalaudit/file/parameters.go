@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel zerolog.Level
+	path     string
+	maxSize  int64
+	maxFiles int
+	maxAge   time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPath sets the path of the audit log file.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithMaxSize sets the size in bytes at which the audit log file is rotated.
+// A value of 0 disables rotation.
+func WithMaxSize(size int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxSize = size
+	})
+}
+
+// WithMaxFiles sets the maximum number of rotated audit log files to retain.
+func WithMaxFiles(files int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxFiles = files
+	})
+}
+
+// WithMaxAge sets the maximum age of rotated audit log files to retain.
+// A value of 0 retains rotated files regardless of their age.
+func WithMaxAge(age time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxAge = age
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		maxSize:  100 * 1024 * 1024,
+		maxFiles: 10,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.path == "" {
+		return nil, errors.New("no path specified")
+	}
+	if parameters.maxSize < 0 {
+		return nil, errors.New("max size cannot be negative")
+	}
+	if parameters.maxFiles < 0 {
+		return nil, errors.New("max files cannot be negative")
+	}
+	if parameters.maxAge < 0 {
+		return nil, errors.New("max age cannot be negative")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service writes proposal audit entries to a file, one JSON object per line.
+type Service struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	maxAge   time.Duration
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new file proposal audit service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "proposalaudit").Str("impl", "file").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		path:     parameters.path,
+		maxSize:  parameters.maxSize,
+		maxFiles: parameters.maxFiles,
+		maxAge:   parameters.maxAge,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	go func(ctx context.Context) {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.file.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close audit log")
+		}
+		s.file = nil
+	}(ctx)
+
+	return s, nil
+}
+
+// open opens the audit log file for appending.
+func (s *Service) open() error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "failed to obtain audit log information")
+	}
+	s.file = file
+	s.size = info.Size()
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/proposalaudit/file"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	missingPath := filepath.Join(dir, "missing", "audit.log")
+
+	tests := []struct {
+		name   string
+		params []file.Parameter
+		err    string
+	}{
+		{
+			name: "PathMissing",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "MaxSizeNegative",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+				file.WithPath(path),
+				file.WithMaxSize(-1),
+			},
+			err: "problem with parameters: max size cannot be negative",
+		},
+		{
+			name: "MaxFilesNegative",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+				file.WithPath(path),
+				file.WithMaxFiles(-1),
+			},
+			err: "problem with parameters: max files cannot be negative",
+		},
+		{
+			name: "MaxAgeNegative",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+				file.WithPath(path),
+				file.WithMaxAge(-time.Hour),
+			},
+			err: "problem with parameters: max age cannot be negative",
+		},
+		{
+			name: "PathInvalid",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+				file.WithPath(missingPath),
+			},
+			err: "failed to open audit log: open " + missingPath + ": no such file or directory",
+		},
+		{
+			name: "Good",
+			params: []file.Parameter{
+				file.WithLogLevel(zerolog.Disabled),
+				file.WithPath(path),
+				file.WithMaxSize(1024),
+				file.WithMaxFiles(2),
+				file.WithMaxAge(24 * time.Hour),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := file.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposalaudit
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Proposal contains the information about a proposal required for auditing.
+type Proposal struct {
+	Slot           phase0.Slot
+	ValidatorIndex phase0.ValidatorIndex
+	// Bids are the values of the bids received from relays, in wei, keyed by
+	// relay address.
+	Bids map[string]*big.Int
+	// WinningRelays are the addresses of the relays that supplied the
+	// winning bid.
+	WinningRelays []string
+	// WinningValue is the value of the winning bid, in wei, or nil if there
+	// was no winning bid.
+	WinningValue *big.Int
+	// LocalExecutionValue is the execution payload value of the block built
+	// by the beacon node, in wei, or nil if not known.
+	LocalExecutionValue *big.Int
+	// LocalConsensusValue is the consensus value of the block built by the
+	// beacon node, in wei, or nil if not known.
+	LocalConsensusValue *big.Int
+	// Source is the source of the proposed block, either "relay" or "local".
+	Source string
+	// Reason is the reason for the choice of the source of the block.
+	Reason string
+	// Submitted is true if the proposal was submitted successfully.
+	Submitted bool
+}
+
+// Service is the proposal audit service.
+type Service interface {
+	// AuditProposal records the audit entry for a proposal.
+	AuditProposal(ctx context.Context, proposal *Proposal)
+}
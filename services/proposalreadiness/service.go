@@ -0,0 +1,27 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposalreadiness
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+)
+
+// Service is the proposal readiness service.
+type Service interface {
+	// CheckReadiness checks that the infrastructure required for the proposal
+	// of the given duty is available, returning an error describing any failures.
+	CheckReadiness(ctx context.Context, duty *beaconblockproposer.Duty) error
+}
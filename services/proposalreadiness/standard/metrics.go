@@ -0,0 +1,85 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	readiness      prometheus.Gauge
+	readinessCheck *prometheus.GaugeVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if readiness != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	readiness = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "proposal",
+		Name:      "readiness",
+		Help:      "1 if the most recent proposal readiness check passed, otherwise 0.",
+	})
+	if err := prometheus.Register(readiness); err != nil {
+		return err
+	}
+
+	readinessCheck = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "proposal",
+		Name:      "readiness_check",
+		Help:      "1 if the individual check passed in the most recent proposal readiness check, otherwise 0.",
+	}, []string{"check"})
+	return prometheus.Register(readinessCheck)
+}
+
+// monitorReadiness is called when a proposal readiness check completes.
+func monitorReadiness(ready bool) {
+	if readiness == nil {
+		return
+	}
+	if ready {
+		readiness.Set(1)
+	} else {
+		readiness.Set(0)
+	}
+}
+
+// monitorReadinessCheck is called when an individual proposal readiness check completes.
+func monitorReadinessCheck(check string, passed bool) {
+	if readinessCheck == nil {
+		return
+	}
+	if passed {
+		readinessCheck.WithLabelValues(check).Set(1)
+	} else {
+		readinessCheck.WithLabelValues(check).Set(0)
+	}
+}
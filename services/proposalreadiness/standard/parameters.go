@@ -0,0 +1,154 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+	"time"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                   zerolog.Level
+	monitor                    metrics.Service
+	chainTime                  chaintime.Service
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	randaoRevealSigner         signer.RANDAORevealSigner
+	executionConfigProvider    blockrelay.ExecutionConfigProvider
+	nodeMonitor                nodemonitor.Service
+	beaconNodeAddresses        []string
+	timeout                    time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithValidatingAccountsProvider sets the validating accounts provider.
+func WithValidatingAccountsProvider(provider accountmanager.ValidatingAccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatingAccountsProvider = provider
+	})
+}
+
+// WithRANDAORevealSigner sets the RANDAO reveal signer, used to check the signer is reachable.
+func WithRANDAORevealSigner(signer signer.RANDAORevealSigner) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.randaoRevealSigner = signer
+	})
+}
+
+// WithExecutionConfigProvider sets the provider of fee recipients and relays.
+func WithExecutionConfigProvider(provider blockrelay.ExecutionConfigProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionConfigProvider = provider
+	})
+}
+
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
+// WithBeaconNodeAddresses sets the addresses of the beacon nodes that propose blocks.
+func WithBeaconNodeAddresses(addresses []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconNodeAddresses = addresses
+	})
+}
+
+// WithTimeout sets the timeout for each check.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		timeout:  2 * time.Second,
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chaintime specified")
+	}
+	if parameters.validatingAccountsProvider == nil {
+		return nil, errors.New("no validating accounts provider specified")
+	}
+	if parameters.randaoRevealSigner == nil {
+		return nil, errors.New("no RANDAO reveal signer specified")
+	}
+	if parameters.executionConfigProvider == nil {
+		return nil, errors.New("no execution config provider specified")
+	}
+	if parameters.nodeMonitor == nil {
+		return nil, errors.New("no node monitor specified")
+	}
+	if _, isProvider := parameters.nodeMonitor.(nodemonitor.ExecutionHealthProvider); !isProvider {
+		return nil, errors.New("node monitor does not provide execution health")
+	}
+	if len(parameters.beaconNodeAddresses) == 0 {
+		return nil, errors.New("no beacon node addresses specified")
+	}
+	if parameters.timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,209 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// CheckReadiness checks that the infrastructure required for the proposal
+// of the given duty is available, returning an error describing any failures.
+func (s *Service) CheckReadiness(ctx context.Context, duty *beaconblockproposer.Duty) error {
+	if duty == nil {
+		return errors.New("no duty supplied")
+	}
+	log := log.With().Uint64("proposing_slot", uint64(duty.Slot())).Uint64("validator_index", uint64(duty.ValidatorIndex())).Logger()
+
+	failures := make([]string, 0)
+	fail := func(check string, err error) {
+		log.Warn().Str("check", check).Err(err).Msg("Proposal readiness check failed")
+		monitorReadinessCheck(check, false)
+		failures = append(failures, fmt.Sprintf("%s: %v", check, err))
+	}
+
+	account, err := s.account(ctx, duty)
+	if err != nil {
+		// Without the account none of the other checks can be carried out.
+		fail("account", err)
+		monitorReadiness(false)
+		return errors.New(strings.Join(failures, "; "))
+	}
+	monitorReadinessCheck("account", true)
+
+	if err := s.checkSigner(ctx, duty, account); err != nil {
+		fail("signer", err)
+	} else {
+		monitorReadinessCheck("signer", true)
+	}
+
+	relays, err := s.checkFeeRecipient(ctx, account)
+	if err != nil {
+		fail("fee_recipient", err)
+	} else {
+		monitorReadinessCheck("fee_recipient", true)
+	}
+
+	if err := s.checkRelays(ctx, relays); err != nil {
+		fail("relays", err)
+	} else {
+		monitorReadinessCheck("relays", true)
+	}
+
+	if err := s.checkBeaconNodes(ctx); err != nil {
+		fail("beacon_nodes", err)
+	} else {
+		monitorReadinessCheck("beacon_nodes", true)
+	}
+
+	monitorReadiness(len(failures) == 0)
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	log.Debug().Msg("Proposal is ready")
+
+	return nil
+}
+
+// account obtains the account for the duty.
+func (s *Service) account(ctx context.Context, duty *beaconblockproposer.Duty) (e2wtypes.Account, error) {
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx,
+		s.chainTime.SlotToEpoch(duty.Slot()),
+		[]phase0.ValidatorIndex{duty.ValidatorIndex()},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validating account")
+	}
+	account, exists := accounts[duty.ValidatorIndex()]
+	if !exists {
+		return nil, fmt.Errorf("validator %d is not validating", duty.ValidatorIndex())
+	}
+
+	return account, nil
+}
+
+// checkSigner checks that the signer for the account is reachable.  It does this by
+// signing the RANDAO reveal for the proposal, which has no slashing implications.
+func (s *Service) checkSigner(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	account e2wtypes.Account,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if _, err := s.randaoRevealSigner.SignRANDAOReveal(ctx, account, duty.Slot()); err != nil {
+		return errors.Wrap(err, "failed to sign RANDAO reveal")
+	}
+
+	return nil
+}
+
+// checkFeeRecipient checks that the fee recipient for the account can be resolved,
+// returning the relays for the account.
+func (s *Service) checkFeeRecipient(ctx context.Context,
+	account e2wtypes.Account,
+) (
+	[]*beaconblockproposer.RelayConfig,
+	error,
+) {
+	var pubkey phase0.BLSPubKey
+	if distributedAccount, isDistributedAccount := account.(e2wtypes.AccountCompositePublicKeyProvider); isDistributedAccount {
+		copy(pubkey[:], distributedAccount.CompositePublicKey().Marshal())
+	} else {
+		copy(pubkey[:], account.PublicKey().Marshal())
+	}
+
+	proposerConfig, err := s.executionConfigProvider.ProposerConfig(ctx, account, pubkey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer configuration")
+	}
+	if proposerConfig == nil {
+		return nil, errors.New("no proposer configuration")
+	}
+	if proposerConfig.FeeRecipient.IsZero() {
+		return proposerConfig.Relays, errors.New("fee recipient is zero")
+	}
+
+	return proposerConfig.Relays, nil
+}
+
+// checkRelays checks that at least one of the relays is reachable.
+func (s *Service) checkRelays(ctx context.Context, relays []*beaconblockproposer.RelayConfig) error {
+	if len(relays) == 0 {
+		// Nothing to check.
+		return nil
+	}
+
+	healthy := 0
+	for _, relay := range relays {
+		if err := s.checkRelay(ctx, relay.Address); err != nil {
+			log.Debug().Str("relay", relay.Address).Err(err).Msg("Relay is not healthy")
+			continue
+		}
+		healthy++
+	}
+	if healthy == 0 {
+		return fmt.Errorf("none of %d relays are healthy", len(relays))
+	}
+	if healthy < len(relays) {
+		log.Warn().Int("healthy", healthy).Int("relays", len(relays)).Msg("Not all relays are healthy")
+	}
+
+	return nil
+}
+
+// checkRelay checks the status of a single relay.
+func (s *Service) checkRelay(ctx context.Context, address string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/eth/v1/builder/status", strings.TrimSuffix(address, "/")), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("relay returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// checkBeaconNodes checks that at least one beacon node is able to produce blocks.
+func (s *Service) checkBeaconNodes(ctx context.Context) error {
+	healthy := 0
+	for _, address := range s.beaconNodeAddresses {
+		if !s.executionHealthProvider.ExecutionHealthy(ctx, address) {
+			log.Debug().Str("beacon_node", address).Msg("Beacon node is syncing, optimistic or unreachable")
+			continue
+		}
+		healthy++
+	}
+	if healthy == 0 {
+		return fmt.Errorf("none of %d beacon nodes can produce blocks", len(s.beaconNodeAddresses))
+	}
+	if healthy < len(s.beaconNodeAddresses) {
+		log.Warn().Int("healthy", healthy).Int("beacon_nodes", len(s.beaconNodeAddresses)).Msg("Not all beacon nodes can produce blocks")
+	}
+
+	return nil
+}
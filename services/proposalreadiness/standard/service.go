@@ -0,0 +1,76 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/blockrelay"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service checks the readiness of proposals ahead of their slots.
+type Service struct {
+	chainTime                  chaintime.Service
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	randaoRevealSigner         signer.RANDAORevealSigner
+	executionConfigProvider    blockrelay.ExecutionConfigProvider
+	executionHealthProvider    nodemonitor.ExecutionHealthProvider
+	beaconNodeAddresses        []string
+	timeout                    time.Duration
+	httpClient                 *http.Client
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new proposal readiness service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "proposalreadiness").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime:                  parameters.chainTime,
+		validatingAccountsProvider: parameters.validatingAccountsProvider,
+		randaoRevealSigner:         parameters.randaoRevealSigner,
+		executionConfigProvider:    parameters.executionConfigProvider,
+		executionHealthProvider:    parameters.nodeMonitor.(nodemonitor.ExecutionHealthProvider),
+		beaconNodeAddresses:        parameters.beaconNodeAddresses,
+		timeout:                    parameters.timeout,
+		httpClient:                 util.NewHTTPClient(parameters.timeout),
+	}
+
+	return s, nil
+}
@@ -0,0 +1,341 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	mocknodemonitor "github.com/attestantio/vouch/services/nodemonitor/mock"
+	"github.com/attestantio/vouch/services/proposalreadiness/standard"
+	"github.com/attestantio/vouch/services/signer"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// executionConfigProvider provides a fixed proposer configuration.
+type executionConfigProvider struct {
+	config *beaconblockproposer.ProposerConfig
+}
+
+func (p *executionConfigProvider) ProposerConfig(_ context.Context,
+	_ e2wtypes.Account,
+	_ phase0.BLSPubKey,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	return p.config, nil
+}
+
+// erroringSigner fails to sign.
+type erroringSigner struct{}
+
+func (*erroringSigner) SignRANDAOReveal(_ context.Context, _ e2wtypes.Account, _ phase0.Slot) (phase0.BLSSignature, error) {
+	return phase0.BLSSignature{}, errors.New("signer unreachable")
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	monitor := nullmetrics.New(ctx)
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	randaoRevealSigner := mocksigner.New()
+	configProvider := &executionConfigProvider{}
+	nodeMonitor := mocknodemonitor.NewExecution()
+	beaconNodeAddresses := []string{"localhost:5052"}
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "ValidatingAccountsProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: no validating accounts provider specified",
+		},
+		{
+			name: "RANDAORevealSignerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: no RANDAO reveal signer specified",
+		},
+		{
+			name: "ExecutionConfigProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: no execution config provider specified",
+		},
+		{
+			name: "NodeMonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: no node monitor specified",
+		},
+		{
+			name: "NodeMonitorNoExecutionHealth",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(mocknodemonitor.New()),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+			err: "problem with parameters: node monitor does not provide execution health",
+		},
+		{
+			name: "BeaconNodeAddressesMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+			},
+			err: "problem with parameters: no beacon node addresses specified",
+		},
+		{
+			name: "TimeoutZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+				standard.WithTimeout(0),
+			},
+			err: "problem with parameters: timeout must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(randaoRevealSigner),
+				standard.WithExecutionConfigProvider(configProvider),
+				standard.WithNodeMonitor(nodeMonitor),
+				standard.WithBeaconNodeAddresses(beaconNodeAddresses),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckReadiness(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), scratch.New(), keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account", []byte("pass"))
+	require.NoError(t, err)
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(1, account)
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	healthyRelay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/builder/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthyRelay.Close()
+	unhealthyRelay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthyRelay.Close()
+
+	feeRecipient := bellatrix.ExecutionAddress{0x01}
+
+	tests := []struct {
+		name                string
+		duty                *beaconblockproposer.Duty
+		signer              signer.RANDAORevealSigner
+		config              *beaconblockproposer.ProposerConfig
+		beaconNodeAddresses []string
+		nodeMonitor         nodemonitor.Service
+		err                 string
+	}{
+		{
+			name:   "Ready",
+			duty:   beaconblockproposer.NewDuty(1, 1),
+			signer: mocksigner.New(),
+			config: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{Address: healthyRelay.URL},
+					{Address: unhealthyRelay.URL},
+				},
+			},
+			beaconNodeAddresses: []string{"node1", "node2"},
+			nodeMonitor:         mocknodemonitor.NewExecution("node2"),
+		},
+		{
+			name:   "UnknownValidator",
+			duty:   beaconblockproposer.NewDuty(1, 2),
+			signer: mocksigner.New(),
+			config: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient,
+			},
+			beaconNodeAddresses: []string{"node1"},
+			nodeMonitor:         mocknodemonitor.NewExecution(),
+			err:                 "account: validator 2 is not validating",
+		},
+		{
+			name:   "NotReady",
+			duty:   beaconblockproposer.NewDuty(1, 1),
+			signer: &erroringSigner{},
+			config: &beaconblockproposer.ProposerConfig{
+				Relays: []*beaconblockproposer.RelayConfig{
+					{Address: unhealthyRelay.URL},
+				},
+			},
+			beaconNodeAddresses: []string{"node1"},
+			nodeMonitor:         mocknodemonitor.NewExecution("node1"),
+			err:                 "signer: failed to sign RANDAO reveal: signer unreachable; fee_recipient: fee recipient is zero; relays: none of 1 relays are healthy; beacon_nodes: none of 1 beacon nodes can produce blocks",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := standard.New(ctx,
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithRANDAORevealSigner(test.signer),
+				standard.WithExecutionConfigProvider(&executionConfigProvider{config: test.config}),
+				standard.WithNodeMonitor(test.nodeMonitor),
+				standard.WithBeaconNodeAddresses(test.beaconNodeAddresses),
+			)
+			require.NoError(t, err)
+
+			err = s.CheckReadiness(ctx, test.duty)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposalrevenue
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Proposal contains the information required to track the revenue of a proposal.
+type Proposal struct {
+	Slot           phase0.Slot
+	ValidatorIndex phase0.ValidatorIndex
+	// BlockHash is the hash of the execution block in the proposal.
+	BlockHash    phase0.Hash32
+	FeeRecipient bellatrix.ExecutionAddress
+	// Relay is the address of the relay that supplied the block, or empty
+	// if the block was built locally.
+	Relay string
+	// Value is the value of the winning bid, in wei, or nil if the block was
+	// built locally.
+	Value *big.Int
+}
+
+// Service is the proposal revenue service.
+type Service interface {
+	// TrackProposal tracks the revenue realised by a submitted proposal.
+	TrackProposal(ctx context.Context, proposal *Proposal)
+}
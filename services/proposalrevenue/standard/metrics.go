@@ -0,0 +1,107 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// weiPerEther is used to convert values to Ether for metrics.
+var weiPerEther = new(big.Float).SetInt64(1e18)
+
+var (
+	proposalsCounter *prometheus.CounterVec
+	revenueHistogram *prometheus.HistogramVec
+	shortfallCounter *prometheus.CounterVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if proposalsCounter != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	proposalsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "proposalrevenue",
+		Name:      "proposals_total",
+		Help:      "The number of proposals for which revenue was tracked.",
+	}, []string{"relay", "result"})
+	if err := prometheus.Register(proposalsCounter); err != nil {
+		return err
+	}
+
+	revenueHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "proposalrevenue",
+		Name:      "revenue_ether",
+		Help:      "The revenue realised by proposals, in Ether.",
+		Buckets: []float64{
+			0.001, 0.002, 0.005,
+			0.01, 0.02, 0.05,
+			0.1, 0.2, 0.5,
+			1, 2, 5,
+			10,
+		},
+	}, []string{"relay"})
+	if err := prometheus.Register(revenueHistogram); err != nil {
+		return err
+	}
+
+	shortfallCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "proposalrevenue",
+		Name:      "shortfall_ether_total",
+		Help:      "The total amount by which realised revenue fell short of winning bid values, in Ether.",
+	}, []string{"relay"})
+	return prometheus.Register(shortfallCounter)
+}
+
+func monitorProposal(relay string, result string) {
+	if proposalsCounter != nil {
+		proposalsCounter.WithLabelValues(relay, result).Inc()
+	}
+}
+
+func monitorRevenue(relay string, revenue *big.Int) {
+	if revenueHistogram != nil {
+		revenueHistogram.WithLabelValues(relay).Observe(toEther(revenue))
+	}
+}
+
+func monitorShortfall(relay string, shortfall *big.Int) {
+	if shortfallCounter != nil {
+		shortfallCounter.WithLabelValues(relay).Add(toEther(shortfall))
+	}
+}
+
+// toEther converts a value in wei to Ether.
+func toEther(wei *big.Int) float64 {
+	res, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), weiPerEther).Float64()
+	return res
+}
@@ -0,0 +1,89 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/vouch/services/proposalrevenue"
+	"github.com/pkg/errors"
+)
+
+// trackProposal is the job that obtains and records the revenue of a proposal.
+func (s *Service) trackProposal(ctx context.Context, data interface{}) {
+	proposal, isProposal := data.(*proposalrevenue.Proposal)
+	if !isProposal {
+		log.Error().Msg("Passed invalid data")
+		return
+	}
+	log := log.With().Uint64("slot", uint64(proposal.Slot)).Uint64("validator_index", uint64(proposal.ValidatorIndex)).Str("relay", proposal.Relay).Logger()
+
+	source := proposal.Relay
+	if source == "" {
+		source = "local"
+	}
+
+	revenue, included, err := s.revenue(ctx, proposal)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain proposal revenue")
+		monitorProposal(source, "failed")
+		return
+	}
+	if !included {
+		log.Info().Stringer("block_hash", proposal.BlockHash).Msg("Proposal not included in the execution chain")
+		monitorProposal(source, "not_included")
+		return
+	}
+	monitorProposal(source, "included")
+	monitorRevenue(source, revenue)
+
+	e := log.Info().Stringer("fee_recipient", proposal.FeeRecipient).Stringer("revenue", revenue)
+	if proposal.Value != nil {
+		shortfall := new(big.Int).Sub(proposal.Value, revenue)
+		e = e.Stringer("bid_value", proposal.Value)
+		if shortfall.Sign() > 0 {
+			e = e.Stringer("shortfall", shortfall)
+			monitorShortfall(source, shortfall)
+		}
+	}
+	e.Msg("Proposal revenue")
+}
+
+// revenue returns the change in balance of the fee recipient over the block
+// of the proposal, in wei, and false if the block is not in the execution chain.
+func (s *Service) revenue(ctx context.Context, proposal *proposalrevenue.Proposal) (*big.Int, bool, error) {
+	number, included, err := s.canonicalBlockNumber(ctx, proposal.BlockHash)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to obtain block")
+	}
+	if !included {
+		return nil, false, nil
+	}
+	if number == 0 {
+		return nil, false, errors.New("proposal cannot be the genesis block")
+	}
+
+	after, err := s.balance(ctx, proposal.FeeRecipient, number)
+	if err != nil {
+		return nil, false, errors.Wrap(err, fmt.Sprintf("failed to obtain balance at block %d", number))
+	}
+	before, err := s.balance(ctx, proposal.FeeRecipient, number-1)
+	if err != nil {
+		return nil, false, errors.Wrap(err, fmt.Sprintf("failed to obtain balance at block %d", number-1))
+	}
+
+	return new(big.Int).Sub(after, before), true, nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/proposalrevenue"
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+// executionServer is a minimal execution client with blocks 0x63 (99) and 0x64 (100).
+func executionServer(t *testing.T, canonicalHash string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result string
+		switch req.Method {
+		case "eth_getBlockByHash":
+			if req.Params[0] == "0x0100000000000000000000000000000000000000000000000000000000000000" {
+				result = `{"number":"0x64","hash":"0x0100000000000000000000000000000000000000000000000000000000000000"}`
+			} else {
+				result = `null`
+			}
+		case "eth_getBlockByNumber":
+			result = `{"number":"0x64","hash":"` + canonicalHash + `"}`
+		case "eth_getBalance":
+			switch req.Params[1] {
+			case "0x64":
+				result = `"0xde0b6b3a7640000"`
+			case "0x63":
+				result = `"0x6f05b59d3b20000"`
+			default:
+				result = `"0x0"`
+			}
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+}
+
+func TestRevenue(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		canonicalHash string
+		blockHash     phase0.Hash32
+		revenue       *big.Int
+		included      bool
+	}{
+		{
+			name:          "Included",
+			canonicalHash: "0x0100000000000000000000000000000000000000000000000000000000000000",
+			blockHash:     phase0.Hash32{0x01},
+			// 1 Ether - 0.5 Ether.
+			revenue:  big.NewInt(500000000000000000),
+			included: true,
+		},
+		{
+			name:          "Unknown",
+			canonicalHash: "0x0100000000000000000000000000000000000000000000000000000000000000",
+			blockHash:     phase0.Hash32{0x02},
+		},
+		{
+			name:          "Orphaned",
+			canonicalHash: "0x0300000000000000000000000000000000000000000000000000000000000000",
+			blockHash:     phase0.Hash32{0x01},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := executionServer(t, test.canonicalHash)
+			defer server.Close()
+
+			s := &Service{
+				executionAddress: server.URL,
+				client:           util.NewHTTPClient(0),
+			}
+			revenue, included, err := s.revenue(ctx, &proposalrevenue.Proposal{
+				Slot:         1,
+				BlockHash:    test.blockHash,
+				FeeRecipient: bellatrix.ExecutionAddress{0x01},
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.included, included)
+			if test.revenue != nil {
+				require.Equal(t, 0, test.revenue.Cmp(revenue))
+			}
+		})
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	res, err := parseQuantity("0x64")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), res.Uint64())
+
+	_, err = parseQuantity("64")
+	require.EqualError(t, err, `quantity "64" missing 0x prefix`)
+
+	_, err = parseQuantity("0xzz")
+	require.EqualError(t, err, `invalid quantity "0xzz"`)
+}
@@ -0,0 +1,144 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcBlock struct {
+	Number string `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// call calls a JSON-RPC method on the execution client, unmarshalling the result.
+func (s *Service) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	reqBody, err := json.Marshal(&rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.executionAddress, bytes.NewReader(reqBody))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call execution client")
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("execution client returned status %d", resp.StatusCode)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response")
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s failed: %s (%d)", method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal result")
+	}
+
+	return nil
+}
+
+// canonicalBlockNumber returns the number of the block with the given hash,
+// and false if the block is not in the canonical chain of the execution client.
+func (s *Service) canonicalBlockNumber(ctx context.Context, hash phase0.Hash32) (uint64, bool, error) {
+	var block *rpcBlock
+	if err := s.call(ctx, &block, "eth_getBlockByHash", fmt.Sprintf("%#x", hash), false); err != nil {
+		return 0, false, err
+	}
+	if block == nil {
+		return 0, false, nil
+	}
+	number, err := parseQuantity(block.Number)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "invalid block number")
+	}
+
+	var canonicalBlock *rpcBlock
+	if err := s.call(ctx, &canonicalBlock, "eth_getBlockByNumber", block.Number, false); err != nil {
+		return 0, false, err
+	}
+	if canonicalBlock == nil || !strings.EqualFold(canonicalBlock.Hash, block.Hash) {
+		return 0, false, nil
+	}
+
+	return number.Uint64(), true, nil
+}
+
+// balance returns the balance of the address at the given block number, in wei.
+func (s *Service) balance(ctx context.Context, address bellatrix.ExecutionAddress, number uint64) (*big.Int, error) {
+	var balance string
+	if err := s.call(ctx, &balance, "eth_getBalance", fmt.Sprintf("%#x", address), fmt.Sprintf("%#x", number)); err != nil {
+		return nil, err
+	}
+
+	return parseQuantity(balance)
+}
+
+// parseQuantity parses a hex-encoded JSON-RPC quantity.
+func parseQuantity(input string) (*big.Int, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return nil, fmt.Errorf("quantity %q missing 0x prefix", input)
+	}
+	res, success := new(big.Int).SetString(input[2:], 16)
+	if !success {
+		return nil, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,86 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/proposalrevenue"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service tracks the revenue of proposals by the change in the balance of
+// their fee recipients, as reported by an execution client.
+type Service struct {
+	scheduler        scheduler.Service
+	chainTime        chaintime.Service
+	executionAddress string
+	client           *http.Client
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new proposal revenue service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "proposalrevenue").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		scheduler:        parameters.scheduler,
+		chainTime:        parameters.chainTime,
+		executionAddress: parameters.executionAddress,
+		client:           util.NewHTTPClient(parameters.timeout),
+	}
+
+	return s, nil
+}
+
+// TrackProposal tracks the revenue realised by a submitted proposal.
+func (s *Service) TrackProposal(ctx context.Context, proposal *proposalrevenue.Proposal) {
+	if proposal == nil {
+		return
+	}
+
+	// Allow a full slot for the block to be imported by the execution client
+	// before checking the balance of the fee recipient.
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Proposal revenue",
+		fmt.Sprintf("Proposal revenue for slot %d", proposal.Slot),
+		s.chainTime.StartOfSlot(proposal.Slot+2),
+		s.trackProposal,
+		proposal,
+	); err != nil {
+		log.Error().Uint64("slot", uint64(proposal.Slot)).Err(err).Msg("Failed to schedule proposal revenue check")
+	}
+}
@@ -45,8 +45,12 @@ type job struct {
 // the state of each job, in an attempt to ensure additional robustness in the face
 // of high concurrent load.
 type Service struct {
-	monitor   metrics.SchedulerMonitor
-	jobs      map[string]*job
+	monitor metrics.SchedulerMonitor
+	jobs    map[string]*job
+	// running contains one-off jobs that are running, keyed by name.  The name of a job
+	// acts as its idempotency key, so a job cannot be scheduled whilst another job with
+	// the same name is running.
+	running   map[string]*job
 	jobsMutex deadlock.RWMutex
 }
 
@@ -65,12 +69,14 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 
 	return &Service{
 		jobs:    make(map[string]*job),
+		running: make(map[string]*job),
 		monitor: parameters.monitor,
 	}, nil
 }
 
 // ScheduleJob schedules a one-off job for a given time.
 // Note that if the parent context is cancelled the job wil not run.
+// If a job with the same name is running this will return scheduler.ErrJobRunning.
 func (s *Service) ScheduleJob(ctx context.Context,
 	class string,
 	name string,
@@ -91,6 +97,10 @@ func (s *Service) ScheduleJob(ctx context.Context,
 		s.jobsMutex.Unlock()
 		return scheduler.ErrJobAlreadyExists
 	}
+	if _, running := s.running[name]; running {
+		s.jobsMutex.Unlock()
+		return scheduler.ErrJobRunning
+	}
 
 	job := &job{
 		cancelCh: make(chan struct{}, 1),
@@ -114,6 +124,7 @@ func (s *Service) ScheduleJob(ctx context.Context,
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Cancel triggered; job not running")
 			// If we receive this signal the job has already been deleted from the jobs list so no need to
 			// do so again here.
+			s.jobFinished(name, job)
 			finaliseJob(job)
 			s.monitor.JobCancelled(class)
 		case <-job.runCh:
@@ -123,22 +134,26 @@ func (s *Service) ScheduleJob(ctx context.Context,
 			s.monitor.JobStartedOnSignal(class)
 			jobFunc(ctx, data)
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Job complete")
+			s.jobFinished(name, job)
 			finaliseJob(job)
 			job.active.Store(false)
 		case <-time.After(time.Until(runtime)):
 			// It is possible that the job is already active, so check that first before proceeding.
 			if job.active.Load() {
 				log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Already running; job not running")
+				s.jobFinished(name, job)
 				break
 			}
 			s.jobsMutex.Lock()
 			delete(s.jobs, name)
+			s.running[name] = job
 			s.jobsMutex.Unlock()
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Timer triggered; job running")
 			job.active.Store(true)
 			s.monitor.JobStartedOnTimer(class)
 			jobFunc(ctx, data)
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Job complete")
+			s.jobFinished(name, job)
 			job.active.Store(false)
 			finaliseJob(job)
 		}
@@ -258,10 +273,18 @@ func (s *Service) RunJob(ctx context.Context, name string) error {
 	if !job.periodic {
 		// Because this job only runs once we remove it from the jobs list immediately.
 		delete(s.jobs, name)
+		s.running[name] = job
 	}
 	s.jobsMutex.Unlock()
 
-	return s.runJob(ctx, job)
+	if err := s.runJob(ctx, job); err != nil {
+		if errors.Is(err, scheduler.ErrJobFinalised) {
+			s.jobFinished(name, job)
+		}
+		return err
+	}
+
+	return nil
 }
 
 // RunJobIfExists runs a job if it exists.
@@ -277,11 +300,13 @@ func (s *Service) RunJobIfExists(ctx context.Context, name string) {
 	if !job.periodic {
 		// Because this job only runs once we remove it from the jobs list immediately.
 		delete(s.jobs, name)
+		s.running[name] = job
 	}
 	s.jobsMutex.Unlock()
 
-	//nolint
-	s.runJob(ctx, job)
+	if err := s.runJob(ctx, job); errors.Is(err, scheduler.ErrJobFinalised) {
+		s.jobFinished(name, job)
+	}
 }
 
 // JobExists returns true if a job exists.
@@ -354,6 +379,15 @@ func (s *Service) CancelJobs(ctx context.Context, prefix string) {
 	}
 }
 
+// jobFinished notes that a one-off job is no longer running.
+func (s *Service) jobFinished(name string, job *job) {
+	s.jobsMutex.Lock()
+	if s.running[name] == job {
+		delete(s.running, name)
+	}
+	s.jobsMutex.Unlock()
+}
+
 // finaliseJob tidies up a job that is no longer in use.
 func finaliseJob(job *job) {
 	job.stateLock.Lock()
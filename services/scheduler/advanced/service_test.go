@@ -407,6 +407,37 @@ func TestDuplicateJobName(t *testing.T) {
 	require.Len(t, s.ListJobs(ctx), 0)
 }
 
+func TestDuplicateRunningJobName(t *testing.T) {
+	ctx := context.Background()
+	s, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled), advanced.WithMonitor(&nullmetrics.Service{}))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run uint32
+	releaseCh := make(chan struct{})
+	runFunc := func(ctx context.Context, data interface{}) {
+		atomic.AddUint32(&run, 1)
+		<-releaseCh
+	}
+
+	// Schedule and start the job.
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test running job", time.Now().Add(time.Second), runFunc, nil))
+	require.NoError(t, s.RunJob(ctx, "Test running job"))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, uint32(1), atomic.LoadUint32(&run))
+	require.Len(t, s.ListJobs(ctx), 0)
+
+	// Cannot schedule a job with the same name whilst it is running.
+	require.EqualError(t, s.ScheduleJob(ctx, "Test", "Test running job", time.Now(), runFunc, nil), scheduler.ErrJobRunning.Error())
+
+	// Can schedule once the job has finished.
+	close(releaseCh)
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test running job", time.Now(), runFunc, nil))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, uint32(2), atomic.LoadUint32(&run))
+}
+
 func TestBadJobs(t *testing.T) {
 	ctx := context.Background()
 	s, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled), advanced.WithMonitor(&nullmetrics.Service{}))
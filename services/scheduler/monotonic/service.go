@@ -0,0 +1,488 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monotonic
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// job contains the state of a job.
+type job struct {
+	name        string
+	class       string
+	ctx         context.Context
+	periodic    bool
+	runtime     time.Time
+	runtimeFunc scheduler.RuntimeFunc
+	runtimeData interface{}
+	jobFunc     scheduler.JobFunc
+	jobData     interface{}
+	// index is the index of the job in the queue, or -1 if it is not queued.
+	index int
+	// active is true if the job is running.
+	active bool
+	// runPending is true if a periodic job has been asked to run before
+	// its next runtime has been obtained.
+	runPending bool
+	// cancelled is true if the job has been cancelled.
+	cancelled bool
+}
+
+// contextWatcher watches a parent context on behalf of the jobs that use it.
+type contextWatcher struct {
+	jobs   int
+	stopCh chan struct{}
+}
+
+// Service is a scheduler service.  Rather than running a timer for each job, it
+// holds jobs in a single queue ordered by runtime and uses a single timer to
+// run them.  Runtimes are converted to the monotonic clock relative to the start
+// of the current slot, and the conversion is refreshed at the start of each slot,
+// so the timer is unaffected by changes to the wall clock within a slot.
+type Service struct {
+	monitor      metrics.SchedulerMonitor
+	chainTime    chaintime.Service
+	slotDuration time.Duration
+
+	mutex   sync.Mutex
+	jobs    map[string]*job
+	running map[string]*job
+	queue   jobQueue
+	// watchers contains the watchers for the parent contexts of the jobs.
+	watchers map[context.Context]*contextWatcher
+	wakeCh   chan struct{}
+
+	log zerolog.Logger
+}
+
+// New creates a new scheduling service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.  The logger is held by the service rather than the package,
+	// as the dispatcher of an existing service could otherwise read it while it
+	// is being replaced.
+	log := zerologger.With().Str("service", "scheduler").Str("impl", "monotonic").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	slotDuration := parameters.chainTime.StartOfSlot(1).Sub(parameters.chainTime.StartOfSlot(0))
+	if slotDuration <= 0 {
+		return nil, errors.New("slot duration must be positive")
+	}
+
+	s := &Service{
+		monitor:      parameters.monitor,
+		chainTime:    parameters.chainTime,
+		slotDuration: slotDuration,
+		jobs:         make(map[string]*job),
+		running:      make(map[string]*job),
+		queue:        make(jobQueue, 0),
+		watchers:     make(map[context.Context]*contextWatcher),
+		wakeCh:       make(chan struct{}, 1),
+		log:          log,
+	}
+
+	go s.dispatch(ctx)
+
+	return s, nil
+}
+
+// ScheduleJob schedules a one-off job for a given time.
+// Note that if the parent context is cancelled the job wil not run.
+// If a job with the same name is running this will return scheduler.ErrJobRunning.
+func (s *Service) ScheduleJob(ctx context.Context,
+	class string,
+	name string,
+	runtime time.Time,
+	jobFunc scheduler.JobFunc,
+	data interface{},
+) error {
+	if name == "" {
+		return scheduler.ErrNoJobName
+	}
+	if jobFunc == nil {
+		return scheduler.ErrNoJobFunc
+	}
+
+	s.mutex.Lock()
+	if _, exists := s.jobs[name]; exists {
+		s.mutex.Unlock()
+		return scheduler.ErrJobAlreadyExists
+	}
+	if _, running := s.running[name]; running {
+		s.mutex.Unlock()
+		return scheduler.ErrJobRunning
+	}
+	job := &job{
+		name:    name,
+		class:   class,
+		ctx:     ctx,
+		runtime: runtime,
+		jobFunc: jobFunc,
+		jobData: data,
+		index:   -1,
+	}
+	s.addJob(job)
+	heap.Push(&s.queue, job)
+	s.mutex.Unlock()
+	s.monitor.JobScheduled(class)
+	s.wake()
+
+	s.log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Scheduled job")
+
+	return nil
+}
+
+// SchedulePeriodicJob schedules a job to run in a loop.
+// The loop starts by calling runtimeFunc, which sets the time for the first run.
+// Once the time as specified by runtimeFunc is met, jobFunc is called.
+// Once jobFunc returns, go back to the beginning of the loop.
+func (s *Service) SchedulePeriodicJob(ctx context.Context,
+	class string,
+	name string,
+	runtimeFunc scheduler.RuntimeFunc,
+	runtimeData interface{},
+	jobFunc scheduler.JobFunc,
+	jobData interface{},
+) error {
+	if name == "" {
+		return scheduler.ErrNoJobName
+	}
+	if runtimeFunc == nil {
+		return scheduler.ErrNoRuntimeFunc
+	}
+	if jobFunc == nil {
+		return scheduler.ErrNoJobFunc
+	}
+
+	s.mutex.Lock()
+	if _, exists := s.jobs[name]; exists {
+		s.mutex.Unlock()
+		return scheduler.ErrJobAlreadyExists
+	}
+	job := &job{
+		name:        name,
+		class:       class,
+		ctx:         ctx,
+		periodic:    true,
+		runtimeFunc: runtimeFunc,
+		runtimeData: runtimeData,
+		jobFunc:     jobFunc,
+		jobData:     jobData,
+		index:       -1,
+	}
+	s.addJob(job)
+	s.mutex.Unlock()
+	s.monitor.JobScheduled(class)
+
+	go s.reschedule(job)
+
+	return nil
+}
+
+// RunJob runs a named job immediately.
+// If the job does not exist it will return an appropriate error.
+func (s *Service) RunJob(_ context.Context, name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, exists := s.jobs[name]
+	if !exists {
+		return scheduler.ErrNoSuchJob
+	}
+	if job.active {
+		return scheduler.ErrJobRunning
+	}
+	if job.index == -1 {
+		// The periodic job is obtaining its next runtime; run it once that completes.
+		job.runPending = true
+		return nil
+	}
+
+	heap.Remove(&s.queue, job.index)
+	s.startJob(job, true)
+
+	return nil
+}
+
+// RunJobIfExists runs a job if it exists.
+// This does not return an error if the job does not exist or is otherwise unable to run.
+func (s *Service) RunJobIfExists(ctx context.Context, name string) {
+	//nolint
+	s.RunJob(ctx, name)
+}
+
+// JobExists returns true if a job exists.
+func (s *Service) JobExists(_ context.Context, name string) bool {
+	s.mutex.Lock()
+	_, exists := s.jobs[name]
+	s.mutex.Unlock()
+
+	return exists
+}
+
+// ListJobs returns the names of all jobs.
+func (s *Service) ListJobs(_ context.Context) []string {
+	s.mutex.Lock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	s.mutex.Unlock()
+
+	return names
+}
+
+// CancelJob removes a named job.
+// If the job does not exist it will return an appropriate error.
+func (s *Service) CancelJob(_ context.Context, name string) error {
+	s.mutex.Lock()
+	job, exists := s.jobs[name]
+	if !exists {
+		s.mutex.Unlock()
+		return scheduler.ErrNoSuchJob
+	}
+	s.cancelJob(job)
+	s.mutex.Unlock()
+
+	s.log.Trace().Str("job", name).Msg("Cancel triggered; job not running")
+
+	return nil
+}
+
+// CancelJobIfExists cancels a job that may or may not exist.
+// If this is a period job then all future instances are cancelled.
+func (s *Service) CancelJobIfExists(ctx context.Context, name string) {
+	//nolint
+	s.CancelJob(ctx, name)
+}
+
+// CancelJobs cancels all jobs with the given prefix.
+// If the prefix matches a period job then all future instances are cancelled.
+func (s *Service) CancelJobs(_ context.Context, prefix string) {
+	s.mutex.Lock()
+	for name, job := range s.jobs {
+		if strings.HasPrefix(name, prefix) {
+			s.cancelJob(job)
+		}
+	}
+	s.mutex.Unlock()
+}
+
+// dispatch runs jobs as they become due.
+func (s *Service) dispatch(ctx context.Context) {
+	var anchorWall time.Time
+	var anchorMono time.Time
+	var nextSlot time.Time
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		s.mutex.Lock()
+		now := time.Now()
+		if !now.Before(nextSlot) {
+			// Refresh the mapping from runtimes to the monotonic clock.
+			anchorWall = s.chainTime.StartOfSlot(s.chainTime.CurrentSlot())
+			anchorMono = now.Add(anchorWall.Sub(now.Round(0)))
+			nextSlot = anchorMono
+			for !nextSlot.After(now) {
+				nextSlot = nextSlot.Add(s.slotDuration)
+			}
+			s.log.Trace().Time("slot_start", anchorWall).Msg("Refreshed slot timing")
+		}
+
+		wait := nextSlot.Sub(now)
+		for len(s.queue) > 0 {
+			due := anchorMono.Add(s.queue[0].runtime.Sub(anchorWall)).Sub(now)
+			if due > 0 {
+				if due < wait {
+					wait = due
+				}
+				break
+			}
+			job := heap.Pop(&s.queue).(*job)
+			if job.ctx.Err() != nil {
+				s.cancelJob(job)
+				continue
+			}
+			s.startJob(job, false)
+		}
+		s.mutex.Unlock()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wakeCh:
+		case <-timer.C:
+		}
+	}
+}
+
+// wake wakes the dispatcher to re-evaluate the queue.
+func (s *Service) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// addJob adds a job to the set of jobs.
+// This assumes that the mutex is held.
+func (s *Service) addJob(job *job) {
+	s.jobs[job.name] = job
+
+	watcher, exists := s.watchers[job.ctx]
+	if !exists {
+		watcher = &contextWatcher{
+			stopCh: make(chan struct{}),
+		}
+		s.watchers[job.ctx] = watcher
+		go s.watch(job.ctx, watcher)
+	}
+	watcher.jobs++
+}
+
+// removeJob removes a job from the set of jobs.
+// This assumes that the mutex is held.
+func (s *Service) removeJob(job *job) {
+	if s.jobs[job.name] != job {
+		return
+	}
+	delete(s.jobs, job.name)
+
+	watcher := s.watchers[job.ctx]
+	watcher.jobs--
+	if watcher.jobs == 0 {
+		delete(s.watchers, job.ctx)
+		close(watcher.stopCh)
+	}
+}
+
+// cancelJob cancels a job.
+// This assumes that the mutex is held.
+func (s *Service) cancelJob(job *job) {
+	if job.cancelled {
+		return
+	}
+	job.cancelled = true
+	s.removeJob(job)
+	if job.index != -1 {
+		heap.Remove(&s.queue, job.index)
+	}
+	s.monitor.JobCancelled(job.class)
+}
+
+// watch cancels the jobs with the given parent context when it is done.
+func (s *Service) watch(ctx context.Context, watcher *contextWatcher) {
+	select {
+	case <-watcher.stopCh:
+	case <-ctx.Done():
+		s.mutex.Lock()
+		for _, job := range s.jobs {
+			if job.ctx == ctx {
+				s.log.Trace().Str("job", job.name).Msg("Parent context done; job not running")
+				s.cancelJob(job)
+			}
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// startJob starts a job that is not queued.
+// This assumes that the mutex is held.
+func (s *Service) startJob(job *job, onSignal bool) {
+	job.active = true
+	if !job.periodic {
+		// A one-off job moves from the jobs to the running jobs.
+		s.removeJob(job)
+		s.running[job.name] = job
+	}
+	if onSignal {
+		s.log.Trace().Str("job", job.name).Msg("Run triggered; job running")
+		s.monitor.JobStartedOnSignal(job.class)
+	} else {
+		s.log.Trace().Str("job", job.name).Time("scheduled", job.runtime).Msg("Timer triggered; job running")
+		s.monitor.JobStartedOnTimer(job.class)
+	}
+
+	go func() {
+		job.jobFunc(job.ctx, job.jobData)
+		s.log.Trace().Str("job", job.name).Msg("Job complete")
+
+		if job.periodic {
+			s.reschedule(job)
+			return
+		}
+		s.mutex.Lock()
+		job.active = false
+		if s.running[job.name] == job {
+			delete(s.running, job.name)
+		}
+		s.mutex.Unlock()
+	}()
+}
+
+// reschedule obtains the next runtime of a periodic job and queues it.
+func (s *Service) reschedule(job *job) {
+	runtime, err := job.runtimeFunc(job.ctx, job.runtimeData)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	job.active = false
+	if job.cancelled {
+		return
+	}
+	if errors.Is(err, scheduler.ErrNoMoreInstances) {
+		s.log.Trace().Str("job", job.name).Msg("No more instances; period job stopping")
+		s.cancelJob(job)
+		return
+	}
+	if err != nil {
+		s.log.Error().Str("job", job.name).Err(err).Msg("Failed to obtain runtime; periodic job stopping")
+		s.cancelJob(job)
+		return
+	}
+
+	job.runtime = runtime
+	if job.runPending {
+		job.runPending = false
+		s.startJob(job, true)
+		return
+	}
+	heap.Push(&s.queue, job)
+	s.log.Trace().Str("job", job.name).Time("scheduled", runtime).Msg("Scheduled job")
+	s.wake()
+}
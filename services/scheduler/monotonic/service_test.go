@@ -0,0 +1,797 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monotonic_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/chaintime"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/scheduler/monotonic"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChainTime(t *testing.T) chaintime.Service {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	return chainTime
+}
+
+// shortSlotChainTime is a chaintime service with short slots.
+type shortSlotChainTime struct {
+	genesis      time.Time
+	slotDuration time.Duration
+}
+
+func (c *shortSlotChainTime) GenesisTime() time.Time {
+	return c.genesis
+}
+
+func (c *shortSlotChainTime) StartOfSlot(slot phase0.Slot) time.Time {
+	return c.genesis.Add(time.Duration(slot) * c.slotDuration)
+}
+
+func (c *shortSlotChainTime) StartOfEpoch(epoch phase0.Epoch) time.Time {
+	return c.StartOfSlot(c.FirstSlotOfEpoch(epoch))
+}
+
+func (c *shortSlotChainTime) CurrentSlot() phase0.Slot {
+	if time.Now().Before(c.genesis) {
+		return 0
+	}
+	return phase0.Slot(time.Since(c.genesis) / c.slotDuration)
+}
+
+func (c *shortSlotChainTime) CurrentEpoch() phase0.Epoch {
+	return c.SlotToEpoch(c.CurrentSlot())
+}
+
+func (*shortSlotChainTime) SlotToEpoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(slot / 32)
+}
+
+func (*shortSlotChainTime) FirstSlotOfEpoch(epoch phase0.Epoch) phase0.Slot {
+	return phase0.Slot(epoch * 32)
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	chainTime := newChainTime(t)
+
+	tests := []struct {
+		name    string
+		options []monotonic.Parameter
+		err     string
+	}{
+		{
+			name: "ChainTimeMissing",
+			options: []monotonic.Parameter{
+				monotonic.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "MonitorNil",
+			options: []monotonic.Parameter{
+				monotonic.WithLogLevel(zerolog.Disabled),
+				monotonic.WithChainTime(chainTime),
+				monotonic.WithMonitor(nil),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "Good",
+			options: []monotonic.Parameter{
+				monotonic.WithLogLevel(zerolog.Disabled),
+				monotonic.WithChainTime(chainTime),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := monotonic.New(ctx, test.options...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, s)
+			}
+		})
+	}
+}
+
+func TestJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(20*time.Millisecond), runFunc, nil))
+	require.Equal(t, int32(0), run.Load())
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestJobExists(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(10*time.Second), runFunc, nil))
+
+	require.True(t, s.JobExists(ctx, "Test job"))
+	require.False(t, s.JobExists(ctx, "Unknown job"))
+	require.Len(t, s.ListJobs(ctx), 1)
+
+	require.NoError(t, s.CancelJob(ctx, "Test job"))
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestCancelJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(100*time.Millisecond), runFunc, nil))
+	require.Equal(t, int32(0), run.Load())
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.NoError(t, s.CancelJob(ctx, "Test job"))
+	require.Len(t, s.ListJobs(ctx), 0)
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(0), run.Load())
+}
+
+func TestCancelUnknownJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	assert.EqualError(t, s.CancelJob(ctx, "Unknown job"), scheduler.ErrNoSuchJob.Error())
+}
+
+func TestCancelJobs(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 1", time.Now().Add(100*time.Millisecond), runFunc, nil))
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 2", time.Now().Add(100*time.Millisecond), runFunc, nil))
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "No cancel job", time.Now().Add(100*time.Millisecond), runFunc, nil))
+	require.Equal(t, int32(0), run.Load())
+	require.Len(t, s.ListJobs(ctx), 3)
+	s.CancelJobs(ctx, "Test job")
+	require.Len(t, s.ListJobs(ctx), 1)
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestCancelJobIfExists(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(100*time.Millisecond), runFunc, nil))
+	require.Equal(t, int32(0), run.Load())
+	require.Len(t, s.ListJobs(ctx), 1)
+	s.CancelJobIfExists(ctx, "Test job")
+	require.Len(t, s.ListJobs(ctx), 0)
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(0), run.Load())
+
+	s.CancelJobIfExists(ctx, "Unknown job")
+}
+
+func TestCancelParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(100*time.Millisecond), runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	cancel()
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	require.Len(t, s.ListJobs(ctx), 0)
+	assert.Equal(t, int32(0), run.Load())
+}
+
+func TestRunJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(time.Second), runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	require.NoError(t, s.RunJob(ctx, "Test job"))
+	time.Sleep(time.Duration(100) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestRunJobIfExists(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(time.Second), runFunc, nil))
+	require.Equal(t, int32(0), run.Load())
+	s.RunJobIfExists(ctx, "Unknown job")
+	require.Equal(t, int32(0), run.Load())
+	s.RunJobIfExists(ctx, "Test job")
+	time.Sleep(time.Duration(100) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+}
+
+func TestRunUnknownJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	assert.EqualError(t, s.RunJob(ctx, "Unknown job"), scheduler.ErrNoSuchJob.Error())
+}
+
+func TestPeriodicJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(100 * time.Millisecond), nil
+	}
+
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test periodic job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(2), run.Load())
+	require.NoError(t, s.RunJob(ctx, "Test periodic job"))
+	time.Sleep(time.Duration(10) * time.Millisecond)
+	assert.Equal(t, int32(3), run.Load())
+	require.Len(t, s.ListJobs(ctx), 1)
+
+	require.NoError(t, s.CancelJob(ctx, "Test periodic job"))
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestCancelPeriodicJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(100 * time.Millisecond), nil
+	}
+
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test periodic job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	require.NoError(t, s.CancelJob(ctx, "Test periodic job"))
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(0), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestCancelPeriodicParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(100 * time.Millisecond), nil
+	}
+
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	cancel()
+	time.Sleep(time.Duration(110) * time.Millisecond)
+	assert.Equal(t, int32(0), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestLimitedPeriodicJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		if run.Load() == 3 {
+			return time.Now(), scheduler.ErrNoMoreInstances
+		}
+		return time.Now().Add(10 * time.Millisecond), nil
+	}
+
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, int32(3), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestBadPeriodicJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		if run.Load() == 3 {
+			return time.Now(), errors.New("Bad")
+		}
+		return time.Now().Add(10 * time.Millisecond), nil
+	}
+
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Equal(t, int32(0), run.Load())
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, int32(3), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestDuplicateJobName(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(100 * time.Millisecond), nil
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test duplicate job", time.Now().Add(time.Second), runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.EqualError(t, s.ScheduleJob(ctx, "Test", "Test duplicate job", time.Now().Add(time.Second), runFunc, nil), scheduler.ErrJobAlreadyExists.Error())
+	require.Len(t, s.ListJobs(ctx), 1)
+
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test duplicate periodic job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 2)
+	require.EqualError(t, s.SchedulePeriodicJob(ctx, "Test", "Test duplicate periodic job", runtimeFunc, nil, runFunc, nil), scheduler.ErrJobAlreadyExists.Error())
+	require.Len(t, s.ListJobs(ctx), 2)
+
+	require.NoError(t, s.CancelJob(ctx, "Test duplicate job"))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.NoError(t, s.CancelJob(ctx, "Test duplicate periodic job"))
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestDuplicateRunningJobName(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	releaseCh := make(chan struct{})
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+		<-releaseCh
+	}
+
+	// Schedule and start the job.
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test running job", time.Now().Add(time.Second), runFunc, nil))
+	require.NoError(t, s.RunJob(ctx, "Test running job"))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(1), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+
+	// Cannot schedule a job with the same name whilst it is running.
+	require.EqualError(t, s.ScheduleJob(ctx, "Test", "Test running job", time.Now(), runFunc, nil), scheduler.ErrJobRunning.Error())
+
+	// Can schedule once the job has finished.
+	close(releaseCh)
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test running job", time.Now(), runFunc, nil))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(2), run.Load())
+}
+
+func TestBadJobs(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(100 * time.Millisecond), nil
+	}
+
+	require.EqualError(t, s.ScheduleJob(ctx, "Test", "", time.Now(), runFunc, nil), scheduler.ErrNoJobName.Error())
+	require.Len(t, s.ListJobs(ctx), 0)
+	require.EqualError(t, s.ScheduleJob(ctx, "Test", "Test bad job", time.Now(), nil, nil), scheduler.ErrNoJobFunc.Error())
+	require.Len(t, s.ListJobs(ctx), 0)
+
+	require.EqualError(t, s.SchedulePeriodicJob(ctx, "Test", "", runtimeFunc, nil, runFunc, nil), scheduler.ErrNoJobName.Error())
+	require.Len(t, s.ListJobs(ctx), 0)
+	require.EqualError(t, s.SchedulePeriodicJob(ctx, "Test", "Test bad period job", nil, nil, runFunc, nil), scheduler.ErrNoRuntimeFunc.Error())
+	require.Len(t, s.ListJobs(ctx), 0)
+	require.EqualError(t, s.SchedulePeriodicJob(ctx, "Test", "Test bad period job", runtimeFunc, nil, nil, nil), scheduler.ErrNoJobFunc.Error())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestManyJobs(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	runTime := time.Now().Add(500 * time.Millisecond)
+
+	jobs := 2048
+	for i := 0; i < jobs; i++ {
+		require.NoError(t, s.ScheduleJob(ctx, "Test", fmt.Sprintf("Job instance %d", i), runTime, runFunc, nil))
+	}
+	require.Len(t, s.ListJobs(ctx), jobs)
+
+	// Kick off some jobs early.
+	for i := 0; i < jobs/32; i++ {
+		// #nosec G404
+		randomJob := rand.Intn(jobs)
+		// Don't check for error as we could try to kick off the same job multiple times, which would cause an error.
+		//nolint
+		s.RunJob(ctx, fmt.Sprintf("Job instance %d", randomJob))
+	}
+
+	// Sleep to let the others run normally.
+	time.Sleep(time.Second)
+
+	require.Equal(t, int32(jobs), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestListJobs(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	jobs := s.ListJobs(ctx)
+	require.Len(t, jobs, 0)
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 1", time.Now().Add(time.Second), runFunc, nil))
+
+	jobs = s.ListJobs(ctx)
+	require.Len(t, jobs, 1)
+	require.Contains(t, jobs, "Test job 1")
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 2", time.Now().Add(time.Second), runFunc, nil))
+
+	jobs = s.ListJobs(ctx)
+	require.Len(t, jobs, 2)
+	require.Contains(t, jobs, "Test job 1")
+	require.Contains(t, jobs, "Test job 2")
+
+	require.NoError(t, s.CancelJob(ctx, "Test job 1"))
+
+	jobs = s.ListJobs(ctx)
+	require.Len(t, jobs, 1)
+	require.Contains(t, jobs, "Test job 2")
+}
+
+func TestLongRunningPeriodicJob(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	// Job takes 100 ms.
+	var run atomic.Int32
+	jobFunc := func(ctx context.Context, data interface{}) {
+		time.Sleep(100 * time.Millisecond)
+		run.Add(1)
+	}
+
+	// Job runs every 50 ms.
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(50 * time.Millisecond), nil
+	}
+
+	// Schedule the job.
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test long running periodic job", runtimeFunc, nil, jobFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+
+	// Sleep for 400 ms.  Expect two runs (50+100+50+100+50).
+	time.Sleep(400 * time.Millisecond)
+	assert.Equal(t, int32(2), run.Load())
+
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.NoError(t, s.CancelJob(ctx, "Test long running periodic job"))
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestOverlappingJobs(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	// Job takes 200ms.
+	var run atomic.Int32
+	jobFunc := func(ctx context.Context, data interface{}) {
+		time.Sleep(200 * time.Millisecond)
+		run.Add(1)
+	}
+
+	now := time.Now()
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 1", now.Add(100*time.Millisecond), jobFunc, nil))
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 2", now.Add(200*time.Millisecond), jobFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 2)
+
+	// Sleep to let jobs complete.
+	time.Sleep(500 * time.Millisecond)
+
+	// Ensure both jobs have completed.
+	require.Equal(t, int32(2), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestMulti(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	// Create a job for the future.
+	var run atomic.Int32
+	jobFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(10*time.Second), jobFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+
+	// Create a number of runners that will try to start the job simultaneously.
+	var runWG sync.WaitGroup
+	var setupWG sync.WaitGroup
+	starter := make(chan interface{})
+	for i := 0; i < 32; i++ {
+		setupWG.Add(1)
+		runWG.Add(1)
+		go func() {
+			setupWG.Done()
+			<-starter
+			//nolint
+			s.RunJob(ctx, "Test job")
+			runWG.Done()
+		}()
+	}
+	// Wait for setup to complete.
+	setupWG.Wait()
+	// Start the jobs by closing the channel.
+	close(starter)
+
+	// Wait for run to complete
+	runWG.Wait()
+	// The job itself runs in the background, so give it time to finish.
+	time.Sleep(10 * time.Millisecond)
+
+	// Ensure the job has only completed once.
+	require.Equal(t, int32(1), run.Load())
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestCancelWhilstRunning(t *testing.T) {
+	ctx := context.Background()
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(newChainTime(t)))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	runFunc := func(ctx context.Context, data interface{}) {
+		time.Sleep(50 * time.Millisecond)
+		run.Add(1)
+	}
+
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(50 * time.Millisecond), nil
+	}
+
+	// Job takes 50 ms and runs every 50ms for a total of 100ms per tick.
+	require.NoError(t, s.SchedulePeriodicJob(ctx, "Test", "Test periodic job", runtimeFunc, nil, runFunc, nil))
+	require.Len(t, s.ListJobs(ctx), 1)
+	require.Contains(t, s.ListJobs(ctx), "Test periodic job")
+	require.Equal(t, int32(0), run.Load())
+	time.Sleep(time.Duration(60) * time.Millisecond)
+	require.Equal(t, int32(0), run.Load())
+	// Cancel occurs during first run.
+	require.NoError(t, s.CancelJob(ctx, "Test periodic job"))
+	require.Len(t, s.ListJobs(ctx), 0)
+	// Wait for first run to finish.
+	time.Sleep(time.Duration(60) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+	// Ensure second run never happens.
+	time.Sleep(time.Duration(120) * time.Millisecond)
+	assert.Equal(t, int32(1), run.Load())
+}
+
+func TestSlotBoundaries(t *testing.T) {
+	ctx := context.Background()
+	chainTime := &shortSlotChainTime{
+		genesis:      time.Now().Add(-time.Second),
+		slotDuration: 50 * time.Millisecond,
+	}
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(chainTime))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var early uint32
+	var run atomic.Int32
+	jobFunc := func(ctx context.Context, data interface{}) {
+		if time.Now().Before(data.(time.Time)) {
+			atomic.AddUint32(&early, 1)
+		}
+		run.Add(1)
+	}
+
+	// Schedule jobs across a number of slots, in reverse order.
+	now := time.Now()
+	jobs := 20
+	for i := jobs; i > 0; i-- {
+		runtime := now.Add(time.Duration(i) * 15 * time.Millisecond)
+		require.NoError(t, s.ScheduleJob(ctx, "Test", fmt.Sprintf("Job instance %d", i), runtime, jobFunc, runtime))
+	}
+	require.Len(t, s.ListJobs(ctx), jobs)
+
+	time.Sleep(400 * time.Millisecond)
+	require.Equal(t, int32(jobs), run.Load())
+	require.Equal(t, uint32(0), atomic.LoadUint32(&early))
+	require.Len(t, s.ListJobs(ctx), 0)
+}
+
+func TestBeforeGenesis(t *testing.T) {
+	ctx := context.Background()
+	chainTime := &shortSlotChainTime{
+		genesis:      time.Now().Add(100 * time.Millisecond),
+		slotDuration: 50 * time.Millisecond,
+	}
+	s, err := monotonic.New(ctx, monotonic.WithLogLevel(zerolog.Disabled), monotonic.WithMonitor(&nullmetrics.Service{}), monotonic.WithChainTime(chainTime))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run atomic.Int32
+	jobFunc := func(ctx context.Context, data interface{}) {
+		run.Add(1)
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 1", time.Now().Add(20*time.Millisecond), jobFunc, nil))
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job 2", time.Now().Add(200*time.Millisecond), jobFunc, nil))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(1), run.Load())
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, int32(2), run.Load())
+}
@@ -55,6 +55,8 @@ type Service interface {
 	// This function returns two cancel funcs.  If the first is triggered the job will not run.  If the second is triggered the job
 	// runs immediately.
 	// Note that if the parent context is cancelled the job wil not run.
+	// The name of the job is its idempotency key: if a job with the same name is already scheduled this returns
+	// ErrJobAlreadyExists, and if a job with the same name is running this returns ErrJobRunning.
 	ScheduleJob(ctx context.Context, class string, name string, runtime time.Time, job JobFunc, data interface{}) error
 
 	// SchedulePeriodicJob schedules a job to run in a loop.
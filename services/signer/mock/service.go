@@ -18,6 +18,7 @@ import (
 
 	"github.com/attestantio/go-builder-client/api"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -174,3 +175,25 @@ func (*Service) SignBlobSidecar(_ context.Context,
 ) {
 	return phase0.BLSSignature{}, nil
 }
+
+// SignVoluntaryExit signs a voluntary exit.
+func (*Service) SignVoluntaryExit(_ context.Context,
+	_ e2wtypes.Account,
+	_ *phase0.VoluntaryExit,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	return phase0.BLSSignature{}, nil
+}
+
+// SignBLSToExecutionChange signs a BLS to execution change.
+func (*Service) SignBLSToExecutionChange(_ context.Context,
+	_ e2wtypes.Account,
+	_ *capella.BLSToExecutionChange,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	return phase0.BLSSignature{}, nil
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/attestantio/go-builder-client/api"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -181,3 +182,28 @@ type ValidatorRegistrationSigner interface {
 		error,
 	)
 }
+
+// VoluntaryExitSigner provides methods to sign voluntary exits.
+type VoluntaryExitSigner interface {
+	// SignVoluntaryExit signs a voluntary exit.
+	SignVoluntaryExit(ctx context.Context,
+		account e2wtypes.Account,
+		exit *phase0.VoluntaryExit,
+	) (
+		phase0.BLSSignature,
+		error,
+	)
+}
+
+// BLSToExecutionChangeSigner provides methods to sign BLS to execution changes.
+type BLSToExecutionChangeSigner interface {
+	// SignBLSToExecutionChange signs a BLS to execution change with the
+	// withdrawal account of a validator.
+	SignBLSToExecutionChange(ctx context.Context,
+		account e2wtypes.Account,
+		change *capella.BLSToExecutionChange,
+	) (
+		phase0.BLSSignature,
+		error,
+	)
+}
@@ -22,6 +22,19 @@ import (
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
+// verifyLeader confirms that this instance is still the leader, if it is
+// coordinating with other instances, before creating a slashable signature.
+func (s *Service) verifyLeader(ctx context.Context) error {
+	if s.coordinator == nil {
+		return nil
+	}
+	if err := s.coordinator.VerifyLeader(ctx); err != nil {
+		return errors.Wrap(err, "failed to verify leadership")
+	}
+
+	return nil
+}
+
 // sign signs a root, using protected methods if possible.
 func (*Service) sign(ctx context.Context,
 	account e2wtypes.Account,
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/coordination"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	"github.com/pkg/errors"
@@ -29,6 +30,7 @@ type parameters struct {
 	clientMonitor  metrics.ClientMonitor
 	specProvider   eth2client.SpecProvider
 	domainProvider eth2client.DomainProvider
+	coordinator    coordination.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -77,6 +79,14 @@ func WithDomainProvider(provider eth2client.DomainProvider) Parameter {
 	})
 }
 
+// WithCoordinator sets the coordinator used to confirm leadership before
+// creating slashable signatures.  If not supplied, leadership is not checked.
+func WithCoordinator(coordinator coordination.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.coordinator = coordinator
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
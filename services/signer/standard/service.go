@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/coordination"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -41,12 +42,19 @@ type Service struct {
 	contributionAndProofDomainType        *phase0.DomainType
 	applicationBuilderDomainType          *phase0.DomainType
 	blobSidecarDomainType                 *phase0.DomainType
+	voluntaryExitDomainType               *phase0.DomainType
+	voluntaryExitDomainEpoch              *phase0.Epoch
+	blsToExecutionChangeDomainType        *phase0.DomainType
 	domainProvider                        eth2client.DomainProvider
+	coordinator                           coordination.Service
 }
 
 // module-wide log.
 var log zerolog.Logger
 
+// farFutureEpoch is the epoch of forks that are not scheduled.
+const farFutureEpoch = uint64(0xffffffffffffffff)
+
 // New creates a new dirk account manager.
 func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
@@ -126,6 +134,17 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		blobSidecarDomainType = &tmp
 	}
 
+	var voluntaryExitDomainType *phase0.DomainType
+	if tmp, err := domainType(spec, "DOMAIN_VOLUNTARY_EXIT"); err == nil {
+		voluntaryExitDomainType = &tmp
+	}
+	voluntaryExitDomainEpoch := fixedVoluntaryExitDomainEpoch(spec)
+
+	var blsToExecutionChangeDomainType *phase0.DomainType
+	if tmp, err := domainType(spec, "DOMAIN_BLS_TO_EXECUTION_CHANGE"); err == nil {
+		blsToExecutionChangeDomainType = &tmp
+	}
+
 	s := &Service{
 		monitor:                               parameters.monitor,
 		clientMonitor:                         parameters.clientMonitor,
@@ -140,7 +159,11 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		contributionAndProofDomainType:        contributionAndProofDomainType,
 		applicationBuilderDomainType:          applicationBuilderDomainType,
 		blobSidecarDomainType:                 blobSidecarDomainType,
+		voluntaryExitDomainType:               voluntaryExitDomainType,
+		voluntaryExitDomainEpoch:              voluntaryExitDomainEpoch,
+		blsToExecutionChangeDomainType:        blsToExecutionChangeDomainType,
 		domainProvider:                        parameters.domainProvider,
+		coordinator:                           parameters.coordinator,
 	}
 
 	return s, nil
@@ -157,3 +180,21 @@ func domainType(spec map[string]interface{}, input string) (phase0.DomainType, e
 	}
 	return domainType, nil
 }
+
+// fixedVoluntaryExitDomainEpoch returns the epoch of the fork whose version is
+// used for all voluntary exit signatures, or nil if the version follows the
+// epoch of the exit.  From Deneb, voluntary exits are always signed with the
+// Capella fork version (EIP-7044).
+func fixedVoluntaryExitDomainEpoch(spec map[string]interface{}) *phase0.Epoch {
+	denebForkEpoch, isEpoch := spec["DENEB_FORK_EPOCH"].(uint64)
+	if !isEpoch || denebForkEpoch == farFutureEpoch {
+		return nil
+	}
+	capellaForkEpoch, isEpoch := spec["CAPELLA_FORK_EPOCH"].(uint64)
+	if !isEpoch {
+		return nil
+	}
+	epoch := phase0.Epoch(capellaForkEpoch)
+
+	return &epoch
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedVoluntaryExitDomainEpoch(t *testing.T) {
+	capellaForkEpoch := phase0.Epoch(194048)
+
+	tests := []struct {
+		name     string
+		spec     map[string]interface{}
+		expected *phase0.Epoch
+	}{
+		{
+			name: "PreCapella",
+			spec: map[string]interface{}{},
+		},
+		{
+			name: "DenebUnscheduled",
+			spec: map[string]interface{}{
+				"CAPELLA_FORK_EPOCH": uint64(194048),
+				"DENEB_FORK_EPOCH":   farFutureEpoch,
+			},
+		},
+		{
+			name: "DenebScheduled",
+			spec: map[string]interface{}{
+				"CAPELLA_FORK_EPOCH": uint64(194048),
+				"DENEB_FORK_EPOCH":   uint64(269568),
+			},
+			expected: &capellaForkEpoch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, fixedVoluntaryExitDomainEpoch(test.spec))
+		})
+	}
+}
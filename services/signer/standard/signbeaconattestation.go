@@ -39,6 +39,27 @@ func (s *Service) SignBeaconAttestation(ctx context.Context,
 	ctx, span := otel.Tracer("attestantio.vouch.services.signer.standard").Start(ctx, "SignBeaconAttestation")
 	defer span.End()
 
+	if err := s.verifyLeader(ctx); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	return s.signBeaconAttestation(ctx, account, slot, committeeIndex, blockRoot, sourceEpoch, sourceRoot, targetEpoch, targetRoot)
+}
+
+// signBeaconAttestation carries out the internal work of signing a beacon attestation.
+func (s *Service) signBeaconAttestation(ctx context.Context,
+	account e2wtypes.Account,
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+	blockRoot phase0.Root,
+	sourceEpoch phase0.Epoch,
+	sourceRoot phase0.Root,
+	targetEpoch phase0.Epoch,
+	targetRoot phase0.Root,
+) (
+	phase0.BLSSignature,
+	error,
+) {
 	domain, err := s.domainProvider.Domain(ctx,
 		s.beaconAttesterDomainType,
 		phase0.Epoch(slot/s.slotsPerEpoch))
@@ -48,6 +48,10 @@ func (s *Service) SignBeaconAttestations(ctx context.Context,
 		return nil, errors.New("no accounts supplied")
 	}
 
+	if err := s.verifyLeader(ctx); err != nil {
+		return nil, err
+	}
+
 	signatureDomain, err := s.domainProvider.Domain(ctx,
 		s.beaconAttesterDomainType,
 		phase0.Epoch(slot/s.slotsPerEpoch))
@@ -145,7 +149,7 @@ func (s *Service) signBeaconAttestations(ctx context.Context,
 		}
 	} else {
 		for i := range accounts {
-			sigs[i], err = s.SignBeaconAttestation(ctx,
+			sigs[i], err = s.signBeaconAttestation(ctx,
 				accounts[i],
 				slot,
 				phase0.CommitteeIndex(committeeIndices[i]),
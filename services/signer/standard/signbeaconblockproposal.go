@@ -37,6 +37,10 @@ func (s *Service) SignBeaconBlockProposal(ctx context.Context,
 	ctx, span := otel.Tracer("attestantio.vouch.services.signer.standard").Start(ctx, "SignBeaconProposal")
 	defer span.End()
 
+	if err := s.verifyLeader(ctx); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	// Fetch the domain.
 	domain, err := s.domainProvider.Domain(ctx,
 		s.beaconProposerDomainType,
@@ -0,0 +1,63 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"go.opentelemetry.io/otel"
+)
+
+// SignBLSToExecutionChange signs a BLS to execution change.
+// The change is signed with the genesis fork version, so it is valid
+// regardless of the fork in which it is included.
+func (s *Service) SignBLSToExecutionChange(ctx context.Context,
+	account e2wtypes.Account,
+	change *capella.BLSToExecutionChange,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.signer.standard").Start(ctx, "SignBLSToExecutionChange")
+	defer span.End()
+
+	if change == nil {
+		return phase0.BLSSignature{}, errors.New("no BLS to execution change supplied")
+	}
+
+	if s.blsToExecutionChangeDomainType == nil {
+		return phase0.BLSSignature{}, errors.New("no BLS to execution change domain type available; cannot sign")
+	}
+
+	root, err := change.HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to calculate hash tree root")
+	}
+
+	domain, err := s.domainProvider.GenesisDomain(ctx, *s.blsToExecutionChangeDomainType)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for BLS to execution change")
+	}
+
+	sig, err := s.sign(ctx, account, root, domain)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign BLS to execution change")
+	}
+
+	return sig, nil
+}
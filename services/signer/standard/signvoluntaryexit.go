@@ -0,0 +1,64 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"go.opentelemetry.io/otel"
+)
+
+// SignVoluntaryExit signs a voluntary exit.
+func (s *Service) SignVoluntaryExit(ctx context.Context,
+	account e2wtypes.Account,
+	exit *phase0.VoluntaryExit,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.signer.standard").Start(ctx, "SignVoluntaryExit")
+	defer span.End()
+
+	if exit == nil {
+		return phase0.BLSSignature{}, errors.New("no voluntary exit supplied")
+	}
+
+	if s.voluntaryExitDomainType == nil {
+		return phase0.BLSSignature{}, errors.New("no voluntary exit domain type available; cannot sign")
+	}
+
+	root, err := exit.HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to calculate hash tree root")
+	}
+
+	domainEpoch := exit.Epoch
+	if s.voluntaryExitDomainEpoch != nil {
+		domainEpoch = *s.voluntaryExitDomainEpoch
+	}
+	domain, err := s.domainProvider.Domain(ctx, *s.voluntaryExitDomainType, domainEpoch)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for voluntary exit")
+	}
+
+	sig, err := s.sign(ctx, account, root, domain)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockcoordination "github.com/attestantio/vouch/services/coordination/mock"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/signer/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestVerifyLeader(t *testing.T) {
+	ctx := context.Background()
+
+	coordinator := mockcoordination.New(false)
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithClientMonitor(nullmetrics.New(ctx)),
+		standard.WithSpecProvider(mock.NewSpecProvider()),
+		standard.WithDomainProvider(mock.NewDomainProvider()),
+		standard.WithCoordinator(coordinator),
+	)
+	require.NoError(t, err)
+
+	// A nil account passes the leadership check but cannot sign, so the error
+	// shows whether the check passed.
+	signAttestation := func() error {
+		_, err := s.SignBeaconAttestation(ctx, nil, 1, 0, phase0.Root{}, 0, phase0.Root{}, 0, phase0.Root{})
+		return err
+	}
+	signAttestations := func() error {
+		_, err := s.SignBeaconAttestations(ctx, []e2wtypes.Account{nil}, 1, []phase0.CommitteeIndex{0}, phase0.Root{}, 0, phase0.Root{}, 0, phase0.Root{})
+		return err
+	}
+	signProposal := func() error {
+		_, err := s.SignBeaconBlockProposal(ctx, nil, 1, 0, phase0.Root{}, phase0.Root{}, phase0.Root{})
+		return err
+	}
+
+	for _, sign := range []func() error{signAttestation, signAttestations, signProposal} {
+		coordinator.SetLeader(false)
+		require.ErrorContains(t, sign(), "failed to verify leadership: not the leader")
+
+		coordinator.SetLeader(true)
+		err := sign()
+		require.Error(t, err)
+		require.NotContains(t, err.Error(), "failed to verify leadership")
+	}
+}
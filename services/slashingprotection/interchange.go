@@ -0,0 +1,233 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// InterchangeFormatVersion is the supported EIP-3076 interchange format version.
+const InterchangeFormatVersion = "5"
+
+// Interchange is slashing protection data in the EIP-3076 interchange format.
+type Interchange struct {
+	GenesisValidatorsRoot phase0.Root
+	Data                  []*InterchangeValidator
+}
+
+// InterchangeValidator is the slashing protection data for a single validator.
+type InterchangeValidator struct {
+	PubKey             phase0.BLSPubKey
+	SignedBlocks       []*InterchangeBlock
+	SignedAttestations []*InterchangeAttestation
+}
+
+// InterchangeBlock is a signed block.
+type InterchangeBlock struct {
+	Slot        phase0.Slot
+	SigningRoot *phase0.Root
+}
+
+// InterchangeAttestation is a signed attestation.
+type InterchangeAttestation struct {
+	SourceEpoch phase0.Epoch
+	TargetEpoch phase0.Epoch
+	SigningRoot *phase0.Root
+}
+
+type interchangeJSON struct {
+	Metadata *interchangeMetadataJSON    `json:"metadata"`
+	Data     []*interchangeValidatorJSON `json:"data"`
+}
+
+type interchangeMetadataJSON struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type interchangeValidatorJSON struct {
+	PubKey             string                        `json:"pubkey"`
+	SignedBlocks       []*interchangeBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*interchangeAttestationJSON `json:"signed_attestations"`
+}
+
+type interchangeBlockJSON struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type interchangeAttestationJSON struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i *Interchange) MarshalJSON() ([]byte, error) {
+	data := make([]*interchangeValidatorJSON, 0, len(i.Data))
+	for _, validator := range i.Data {
+		validatorJSON := &interchangeValidatorJSON{
+			PubKey:             fmt.Sprintf("%#x", validator.PubKey),
+			SignedBlocks:       make([]*interchangeBlockJSON, 0, len(validator.SignedBlocks)),
+			SignedAttestations: make([]*interchangeAttestationJSON, 0, len(validator.SignedAttestations)),
+		}
+		for _, block := range validator.SignedBlocks {
+			blockJSON := &interchangeBlockJSON{
+				Slot: strconv.FormatUint(uint64(block.Slot), 10),
+			}
+			if block.SigningRoot != nil {
+				blockJSON.SigningRoot = fmt.Sprintf("%#x", *block.SigningRoot)
+			}
+			validatorJSON.SignedBlocks = append(validatorJSON.SignedBlocks, blockJSON)
+		}
+		for _, attestation := range validator.SignedAttestations {
+			attestationJSON := &interchangeAttestationJSON{
+				SourceEpoch: strconv.FormatUint(uint64(attestation.SourceEpoch), 10),
+				TargetEpoch: strconv.FormatUint(uint64(attestation.TargetEpoch), 10),
+			}
+			if attestation.SigningRoot != nil {
+				attestationJSON.SigningRoot = fmt.Sprintf("%#x", *attestation.SigningRoot)
+			}
+			validatorJSON.SignedAttestations = append(validatorJSON.SignedAttestations, attestationJSON)
+		}
+		data = append(data, validatorJSON)
+	}
+
+	return json.Marshal(&interchangeJSON{
+		Metadata: &interchangeMetadataJSON{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", i.GenesisValidatorsRoot),
+		},
+		Data: data,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Interchange) UnmarshalJSON(input []byte) error {
+	var data interchangeJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Metadata == nil {
+		return errors.New("metadata missing")
+	}
+	if data.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q", data.Metadata.InterchangeFormatVersion)
+	}
+	genesisValidatorsRoot, err := parseRoot(data.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid genesis validators root")
+	}
+	i.GenesisValidatorsRoot = *genesisValidatorsRoot
+
+	i.Data = make([]*InterchangeValidator, 0, len(data.Data))
+	for _, validatorJSON := range data.Data {
+		if validatorJSON == nil {
+			return errors.New("validator missing")
+		}
+		validator, err := validatorJSON.parse()
+		if err != nil {
+			return err
+		}
+		i.Data = append(i.Data, validator)
+	}
+
+	return nil
+}
+
+// parse parses the JSON representation of a validator.
+func (v *interchangeValidatorJSON) parse() (*InterchangeValidator, error) {
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(v.PubKey, "0x"))
+	if err != nil || len(pubKeyBytes) != phase0.PublicKeyLength {
+		return nil, fmt.Errorf("invalid public key %q", v.PubKey)
+	}
+	validator := &InterchangeValidator{
+		SignedBlocks:       make([]*InterchangeBlock, 0, len(v.SignedBlocks)),
+		SignedAttestations: make([]*InterchangeAttestation, 0, len(v.SignedAttestations)),
+	}
+	copy(validator.PubKey[:], pubKeyBytes)
+
+	for _, blockJSON := range v.SignedBlocks {
+		if blockJSON == nil {
+			return nil, fmt.Errorf("signed block missing for %s", v.PubKey)
+		}
+		slot, err := strconv.ParseUint(blockJSON.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot %q for %s", blockJSON.Slot, v.PubKey)
+		}
+		block := &InterchangeBlock{
+			Slot: phase0.Slot(slot),
+		}
+		if blockJSON.SigningRoot != "" {
+			block.SigningRoot, err = parseRoot(blockJSON.SigningRoot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid signing root for %s", v.PubKey)
+			}
+		}
+		validator.SignedBlocks = append(validator.SignedBlocks, block)
+	}
+
+	for _, attestationJSON := range v.SignedAttestations {
+		if attestationJSON == nil {
+			return nil, fmt.Errorf("signed attestation missing for %s", v.PubKey)
+		}
+		sourceEpoch, err := strconv.ParseUint(attestationJSON.SourceEpoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source epoch %q for %s", attestationJSON.SourceEpoch, v.PubKey)
+		}
+		targetEpoch, err := strconv.ParseUint(attestationJSON.TargetEpoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target epoch %q for %s", attestationJSON.TargetEpoch, v.PubKey)
+		}
+		if sourceEpoch > targetEpoch {
+			return nil, fmt.Errorf("source epoch %d greater than target epoch %d for %s", sourceEpoch, targetEpoch, v.PubKey)
+		}
+		attestation := &InterchangeAttestation{
+			SourceEpoch: phase0.Epoch(sourceEpoch),
+			TargetEpoch: phase0.Epoch(targetEpoch),
+		}
+		if attestationJSON.SigningRoot != "" {
+			attestation.SigningRoot, err = parseRoot(attestationJSON.SigningRoot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid signing root for %s", v.PubKey)
+			}
+		}
+		validator.SignedAttestations = append(validator.SignedAttestations, attestation)
+	}
+
+	return validator, nil
+}
+
+// parseRoot parses a hex-encoded root.
+func parseRoot(input string) (*phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hex")
+	}
+	if len(data) != phase0.RootLength {
+		return nil, errors.New("incorrect length")
+	}
+	root := phase0.Root{}
+	copy(root[:], data)
+
+	return &root, nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterchangeJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "MetadataMissing",
+			input: `{"data":[]}`,
+			err:   "metadata missing",
+		},
+		{
+			name:  "VersionUnsupported",
+			input: `{"metadata":{"interchange_format_version":"4","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},"data":[]}`,
+			err:   `unsupported interchange format version "4"`,
+		},
+		{
+			name:  "GenesisValidatorsRootInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x01"},"data":[]}`,
+			err:   "invalid genesis validators root: incorrect length",
+		},
+		{
+			name:  "PubKeyInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},"data":[{"pubkey":"0x01","signed_blocks":[],"signed_attestations":[]}]}`,
+			err:   `invalid public key "0x01"`,
+		},
+		{
+			name:  "SlotInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},"data":[{"pubkey":"0xb3a22e4a673ac7a153ab5b3c17a4dbef55f7e47210b20c0cbb0e66df5b36bb49ef808577610b034172e955d2312a61b9","signed_blocks":[{"slot":"-1"}],"signed_attestations":[]}]}`,
+			err:   `invalid slot "-1" for 0xb3a22e4a673ac7a153ab5b3c17a4dbef55f7e47210b20c0cbb0e66df5b36bb49ef808577610b034172e955d2312a61b9`,
+		},
+		{
+			name:  "SourceAfterTarget",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},"data":[{"pubkey":"0xb3a22e4a673ac7a153ab5b3c17a4dbef55f7e47210b20c0cbb0e66df5b36bb49ef808577610b034172e955d2312a61b9","signed_blocks":[],"signed_attestations":[{"source_epoch":"3","target_epoch":"2"}]}]}`,
+			err:   "source epoch 3 greater than target epoch 2 for 0xb3a22e4a673ac7a153ab5b3c17a4dbef55f7e47210b20c0cbb0e66df5b36bb49ef808577610b034172e955d2312a61b9",
+		},
+		{
+			name:  "Good",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},"data":[{"pubkey":"0xb3a22e4a673ac7a153ab5b3c17a4dbef55f7e47210b20c0cbb0e66df5b36bb49ef808577610b034172e955d2312a61b9","signed_blocks":[{"slot":"81952","signing_root":"0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"},{"slot":"81951"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007","signing_root":"0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"},{"source_epoch":"2290","target_epoch":"3008"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res slashingprotection.Interchange
+			err := json.Unmarshal([]byte(test.input), &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				rt, err := json.Marshal(&res)
+				require.NoError(t, err)
+				require.Equal(t, test.input, string(rt))
+			}
+		})
+	}
+}
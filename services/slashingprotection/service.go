@@ -0,0 +1,53 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slashingprotection provides a local store of signed blocks and attestations,
+// refusing to sign anything that could result in the validator being slashed.
+package slashingprotection
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service is the slashing protection service.
+type Service interface {
+	// ProtectProposal checks that signing a block proposal for the given slot cannot
+	// result in the validator being slashed, recording the proposal if so.
+	// An error is returned if the proposal must not be signed.
+	ProtectProposal(ctx context.Context, pubKey phase0.BLSPubKey, slot phase0.Slot) error
+
+	// ProtectAttestations checks that signing an attestation with the given source and
+	// target epochs cannot result in each of the validators being slashed, recording
+	// the attestation for those validators for which it is safe.
+	// The returned slice states if the attestation is safe to sign for the validator
+	// with the public key at the same position.
+	ProtectAttestations(ctx context.Context,
+		pubKeys []phase0.BLSPubKey,
+		sourceEpoch phase0.Epoch,
+		targetEpoch phase0.Epoch,
+	) (
+		[]bool,
+		error,
+	)
+}
+
+// InterchangeProvider provides slashing protection data in the EIP-3076 interchange format.
+type InterchangeProvider interface {
+	// ExportInterchange exports slashing protection data.
+	ExportInterchange(ctx context.Context) (*Interchange, error)
+
+	// ImportInterchange imports slashing protection data, merging it with existing data.
+	ImportInterchange(ctx context.Context, interchange *Interchange) error
+}
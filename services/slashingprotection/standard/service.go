@@ -0,0 +1,482 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// watermark is the highest signed block and attestation for a validator.
+// Anything at or below the watermark is refused, which is the conservative
+// approach to slashing protection described by EIP-3076.
+type watermark struct {
+	signedBlock       bool
+	slot              phase0.Slot
+	signedAttestation bool
+	sourceEpoch       phase0.Epoch
+	targetEpoch       phase0.Epoch
+}
+
+// minCompactionLogSize is the size of the log below which it is not compacted.
+const minCompactionLogSize = 1024 * 1024
+
+// compactionRatio is the size of the log relative to the file at which the log
+// is compacted.
+const compactionRatio = 8
+
+// Service is a slashing protection service that holds its data in a local file
+// in the EIP-3076 interchange format.  Changes are appended to a log alongside
+// the file, and the log is compacted in to the file once it has grown, so that
+// recording a signature does not rewrite the data for every validator.
+type Service struct {
+	path                  string
+	genesisValidatorsRoot phase0.Root
+
+	// mu protects watermarks and the log, and is held while writing the log
+	// so that nothing is signed until it has been recorded.
+	mu         sync.Mutex
+	watermarks map[phase0.BLSPubKey]*watermark
+	logFile    *os.File
+	logSize    int64
+	fileSize   int64
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new slashing protection service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "slashingprotection").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		path:                  parameters.path,
+		genesisValidatorsRoot: parameters.genesisValidatorsRoot,
+		watermarks:            make(map[phase0.BLSPubKey]*watermark),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, errors.Wrap(err, "failed to load slashing protection data")
+	}
+	log.Trace().Int("validators", len(s.watermarks)).Msg("Loaded slashing protection data")
+
+	if err := s.openLog(); err != nil {
+		return nil, errors.Wrap(err, "failed to open slashing protection log")
+	}
+
+	return s, nil
+}
+
+// ProtectProposal checks that signing a block proposal for the given slot cannot
+// result in the validator being slashed, recording the proposal if so.
+// An error is returned if the proposal must not be signed.
+func (s *Service) ProtectProposal(_ context.Context, pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.watermarks[pubKey]
+	if !exists {
+		current = &watermark{}
+	}
+	if current.signedBlock && slot <= current.slot {
+		monitorRefused("proposal", 1)
+		return fmt.Errorf("proposal for slot %d at or below previously signed slot %d", slot, current.slot)
+	}
+
+	updated := *current
+	updated.signedBlock = true
+	updated.slot = slot
+	s.watermarks[pubKey] = &updated
+	if err := s.record([]phase0.BLSPubKey{pubKey}); err != nil {
+		// Retain the updated watermark, as it is safer to refuse a later
+		// proposal than to allow a slashable one.
+		return errors.Wrap(err, "failed to record proposal")
+	}
+
+	return nil
+}
+
+// ProtectAttestations checks that signing an attestation with the given source and
+// target epochs cannot result in each of the validators being slashed, recording
+// the attestation for those validators for which it is safe.
+func (s *Service) ProtectAttestations(_ context.Context,
+	pubKeys []phase0.BLSPubKey,
+	sourceEpoch phase0.Epoch,
+	targetEpoch phase0.Epoch,
+) (
+	[]bool,
+	error,
+) {
+	if sourceEpoch > targetEpoch {
+		return nil, fmt.Errorf("source epoch %d greater than target epoch %d", sourceEpoch, targetEpoch)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]bool, len(pubKeys))
+	refused := 0
+	updatedPubKeys := make([]phase0.BLSPubKey, 0, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		current, exists := s.watermarks[pubKey]
+		if !exists {
+			current = &watermark{}
+		}
+		// A source below the highest source could surround an earlier
+		// attestation, and a target at or below the highest target could be a
+		// double vote or be surrounded by an earlier attestation.
+		if current.signedAttestation && (sourceEpoch < current.sourceEpoch || targetEpoch <= current.targetEpoch) {
+			log.Warn().
+				Str("pubkey", fmt.Sprintf("%#x", pubKey)).
+				Uint64("source_epoch", uint64(sourceEpoch)).
+				Uint64("target_epoch", uint64(targetEpoch)).
+				Uint64("signed_source_epoch", uint64(current.sourceEpoch)).
+				Uint64("signed_target_epoch", uint64(current.targetEpoch)).
+				Msg("Attestation could be slashable; refusing")
+			refused++
+			continue
+		}
+
+		updated := *current
+		updated.signedAttestation = true
+		updated.sourceEpoch = sourceEpoch
+		updated.targetEpoch = targetEpoch
+		s.watermarks[pubKey] = &updated
+		updatedPubKeys = append(updatedPubKeys, pubKey)
+		res[i] = true
+	}
+	monitorRefused("attestation", refused)
+
+	if len(updatedPubKeys) == 0 {
+		// Nothing to record.
+		return res, nil
+	}
+	if err := s.record(updatedPubKeys); err != nil {
+		return nil, errors.Wrap(err, "failed to record attestations")
+	}
+
+	return res, nil
+}
+
+// ExportInterchange exports slashing protection data.
+func (s *Service) ExportInterchange(_ context.Context) (*slashingprotection.Interchange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.interchange(nil), nil
+}
+
+// ImportInterchange imports slashing protection data, merging it with existing data.
+func (s *Service) ImportInterchange(_ context.Context, interchange *slashingprotection.Interchange) error {
+	if interchange == nil {
+		return errors.New("no interchange data supplied")
+	}
+	if !bytes.Equal(interchange.GenesisValidatorsRoot[:], s.genesisValidatorsRoot[:]) {
+		return fmt.Errorf("interchange data is for genesis validators root %#x, expected %#x", interchange.GenesisValidatorsRoot, s.genesisValidatorsRoot)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.merge(interchange)
+	if err := s.compact(); err != nil {
+		return errors.Wrap(err, "failed to record imported data")
+	}
+
+	return nil
+}
+
+// merge merges interchange data with the existing watermarks, retaining the
+// highest values for each validator.
+func (s *Service) merge(interchange *slashingprotection.Interchange) {
+	for _, validator := range interchange.Data {
+		current, exists := s.watermarks[validator.PubKey]
+		if !exists {
+			current = &watermark{}
+			s.watermarks[validator.PubKey] = current
+		}
+		for _, block := range validator.SignedBlocks {
+			if !current.signedBlock || block.Slot > current.slot {
+				current.slot = block.Slot
+			}
+			current.signedBlock = true
+		}
+		for _, attestation := range validator.SignedAttestations {
+			if !current.signedAttestation || attestation.SourceEpoch > current.sourceEpoch {
+				current.sourceEpoch = attestation.SourceEpoch
+			}
+			if !current.signedAttestation || attestation.TargetEpoch > current.targetEpoch {
+				current.targetEpoch = attestation.TargetEpoch
+			}
+			current.signedAttestation = true
+		}
+	}
+}
+
+// interchange returns the watermarks for the given validators, or all validators
+// if none are given, in the minimal interchange format.
+// Must be called with the lock held.
+func (s *Service) interchange(pubKeys []phase0.BLSPubKey) *slashingprotection.Interchange {
+	if len(pubKeys) == 0 {
+		pubKeys = make([]phase0.BLSPubKey, 0, len(s.watermarks))
+		for pubKey := range s.watermarks {
+			pubKeys = append(pubKeys, pubKey)
+		}
+		sort.Slice(pubKeys, func(i, j int) bool {
+			return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+		})
+	}
+
+	res := &slashingprotection.Interchange{
+		GenesisValidatorsRoot: s.genesisValidatorsRoot,
+		Data:                  make([]*slashingprotection.InterchangeValidator, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		current := s.watermarks[pubKey]
+		validator := &slashingprotection.InterchangeValidator{
+			PubKey:             pubKey,
+			SignedBlocks:       make([]*slashingprotection.InterchangeBlock, 0, 1),
+			SignedAttestations: make([]*slashingprotection.InterchangeAttestation, 0, 1),
+		}
+		if current.signedBlock {
+			validator.SignedBlocks = append(validator.SignedBlocks, &slashingprotection.InterchangeBlock{
+				Slot: current.slot,
+			})
+		}
+		if current.signedAttestation {
+			validator.SignedAttestations = append(validator.SignedAttestations, &slashingprotection.InterchangeAttestation{
+				SourceEpoch: current.sourceEpoch,
+				TargetEpoch: current.targetEpoch,
+			})
+		}
+		res.Data = append(res.Data, validator)
+	}
+
+	return res
+}
+
+// logPath returns the path of the log.
+func (s *Service) logPath() string {
+	return fmt.Sprintf("%s.log", s.path)
+}
+
+// load loads the watermarks from the file and the log, if present.
+func (s *Service) load() error {
+	data, err := os.ReadFile(s.path)
+	switch {
+	case err == nil:
+		interchange := &slashingprotection.Interchange{}
+		if err := json.Unmarshal(data, interchange); err != nil {
+			return errors.Wrap(err, "failed to parse file")
+		}
+		if !bytes.Equal(interchange.GenesisValidatorsRoot[:], s.genesisValidatorsRoot[:]) {
+			return fmt.Errorf("file is for genesis validators root %#x, expected %#x", interchange.GenesisValidatorsRoot, s.genesisValidatorsRoot)
+		}
+		s.merge(interchange)
+		s.fileSize = int64(len(data))
+	case os.IsNotExist(err):
+		log.Info().Str("path", s.path).Msg("No slashing protection data found; starting afresh")
+	default:
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	data, err = os.ReadFile(s.logPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to read log")
+	}
+	for len(data) > 0 {
+		entry, remainder, complete := bytes.Cut(data, []byte("\n"))
+		if !complete {
+			// A partial entry is from a write that did not complete, and so
+			// was never reported as recorded.
+			log.Warn().Int("bytes", len(entry)).Msg("Discarding partial entry at end of slashing protection log")
+			break
+		}
+		interchange := &slashingprotection.Interchange{}
+		if err := json.Unmarshal(entry, interchange); err != nil {
+			return errors.Wrap(err, "failed to parse log")
+		}
+		if !bytes.Equal(interchange.GenesisValidatorsRoot[:], s.genesisValidatorsRoot[:]) {
+			return fmt.Errorf("log is for genesis validators root %#x, expected %#x", interchange.GenesisValidatorsRoot, s.genesisValidatorsRoot)
+		}
+		s.merge(interchange)
+		data = remainder
+	}
+
+	return nil
+}
+
+// openLog opens the log for appending, compacting any existing entries in to the
+// file first.
+func (s *Service) openLog() error {
+	logFile, err := os.OpenFile(s.logPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open log")
+	}
+	s.logFile = logFile
+	if err := syncDir(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+
+	info, err := logFile.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain log information")
+	}
+	if info.Size() > 0 {
+		if err := s.compact(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// record appends the watermarks for the given validators to the log, returning
+// once they are safely on disk.  The log is compacted once it has grown.
+// Must be called with the lock held.
+func (s *Service) record(pubKeys []phase0.BLSPubKey) error {
+	data, err := json.Marshal(s.interchange(pubKeys))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal data")
+	}
+	data = append(data, '\n')
+
+	if _, err := s.logFile.Write(data); err != nil {
+		s.discardPartialEntry()
+		return errors.Wrap(err, "failed to write log")
+	}
+	if err := s.logFile.Sync(); err != nil {
+		s.discardPartialEntry()
+		return errors.Wrap(err, "failed to sync log")
+	}
+	s.logSize += int64(len(data))
+
+	if s.logSize > minCompactionLogSize && s.logSize > s.fileSize*compactionRatio {
+		// The entry is already safely in the log, so a failure here does
+		// not prevent signing.
+		if err := s.compact(); err != nil {
+			log.Warn().Err(err).Msg("Failed to compact slashing protection log")
+		}
+	}
+
+	return nil
+}
+
+// discardPartialEntry truncates the log to its last complete entry following a
+// failed write, so that later entries can be read.
+// Must be called with the lock held.
+func (s *Service) discardPartialEntry() {
+	if err := s.logFile.Truncate(s.logSize); err != nil {
+		log.Error().Err(err).Msg("Failed to discard partial entry from slashing protection log")
+	}
+}
+
+// compact writes all watermarks to the file and empties the log.
+// Must be called with the lock held.
+func (s *Service) compact() error {
+	if err := s.save(); err != nil {
+		return err
+	}
+	if err := s.logFile.Truncate(0); err != nil {
+		return errors.Wrap(err, "failed to truncate log")
+	}
+	if err := s.logFile.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync log")
+	}
+	s.logSize = 0
+
+	return nil
+}
+
+// save writes the watermarks to the file.  The data is written to a temporary
+// file that replaces the existing file once it is safely on disk, so that a
+// crash cannot leave partial data.
+// Must be called with the lock held.
+func (s *Service) save() error {
+	data, err := json.Marshal(s.interchange(nil))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal data")
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), fmt.Sprintf(".%s.*", filepath.Base(s.path)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to sync temporary file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+	if err := os.Rename(tmpFile.Name(), s.path); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to rename temporary file")
+	}
+	if err := syncDir(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+	s.fileSize = int64(len(data))
+
+	return nil
+}
+
+// syncDir ensures that changes to the entries of the given directory are on disk.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open directory")
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync directory")
+	}
+
+	return nil
+}
@@ -0,0 +1,334 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/attestantio/vouch/services/slashingprotection/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+var genesisValidatorsRoot = phase0.Root{0x01}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	base := t.TempDir()
+
+	otherChain := filepath.Join(base, "other.json")
+	require.NoError(t, os.WriteFile(otherChain, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0200000000000000000000000000000000000000000000000000000000000000"},"data":[]}`), 0o600))
+	corrupt := filepath.Join(base, "corrupt.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte(`{`), 0o600))
+	corruptLog := filepath.Join(base, "corruptlog.json")
+	require.NoError(t, os.WriteFile(corruptLog+".log", []byte("{\n"), 0o600))
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithPath(filepath.Join(base, "protection.json")),
+				standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "PathMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "GenesisValidatorsRootMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithPath(filepath.Join(base, "protection.json")),
+			},
+			err: "problem with parameters: no genesis validators root specified",
+		},
+		{
+			name: "FileCorrupt",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithPath(corrupt),
+				standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+			},
+			err: "failed to load slashing protection data: failed to parse file: unexpected end of JSON input",
+		},
+		{
+			name: "FileOtherChain",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithPath(otherChain),
+				standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+			},
+			err: "failed to load slashing protection data: file is for genesis validators root 0x0200000000000000000000000000000000000000000000000000000000000000, expected 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "LogCorrupt",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithPath(corruptLog),
+				standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+			},
+			err: "failed to load slashing protection data: failed to parse log: unexpected end of JSON input",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithPath(filepath.Join(base, "protection.json")),
+				standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProtectAttestations(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "protection.json")
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithPath(path),
+		standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+	)
+	require.NoError(t, err)
+
+	pubKeys := []phase0.BLSPubKey{{0x01}, {0x02}}
+
+	safe, err := s.ProtectAttestations(ctx, pubKeys, 10, 11)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, true}, safe)
+
+	// Double vote.
+	safe, err = s.ProtectAttestations(ctx, pubKeys[:1], 10, 11)
+	require.NoError(t, err)
+	require.Equal(t, []bool{false}, safe)
+
+	// Surrounding vote.
+	safe, err = s.ProtectAttestations(ctx, pubKeys[:1], 9, 12)
+	require.NoError(t, err)
+	require.Equal(t, []bool{false}, safe)
+
+	// Next epoch, for one validator only.
+	safe, err = s.ProtectAttestations(ctx, pubKeys[:1], 11, 12)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true}, safe)
+
+	// Mixed results.
+	safe, err = s.ProtectAttestations(ctx, pubKeys, 10, 12)
+	require.NoError(t, err)
+	require.Equal(t, []bool{false, true}, safe)
+
+	_, err = s.ProtectAttestations(ctx, pubKeys, 12, 11)
+	require.EqualError(t, err, "source epoch 12 greater than target epoch 11")
+
+	// Ensure that the data survives a restart.
+	s, err = standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithPath(path),
+		standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+	)
+	require.NoError(t, err)
+	safe, err = s.ProtectAttestations(ctx, pubKeys, 11, 12)
+	require.NoError(t, err)
+	require.Equal(t, []bool{false, false}, safe)
+}
+
+func TestProtectProposal(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithPath(filepath.Join(t.TempDir(), "protection.json")),
+		standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+	)
+	require.NoError(t, err)
+
+	pubKey := phase0.BLSPubKey{0x01}
+	require.NoError(t, s.ProtectProposal(ctx, pubKey, 100))
+	require.EqualError(t, s.ProtectProposal(ctx, pubKey, 100), "proposal for slot 100 at or below previously signed slot 100")
+	require.EqualError(t, s.ProtectProposal(ctx, pubKey, 99), "proposal for slot 99 at or below previously signed slot 100")
+	require.NoError(t, s.ProtectProposal(ctx, pubKey, 101))
+	require.NoError(t, s.ProtectProposal(ctx, phase0.BLSPubKey{0x02}, 50))
+}
+
+func TestPartialLogEntry(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "protection.json")
+	params := []standard.Parameter{
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithPath(path),
+		standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+	}
+
+	s, err := standard.New(ctx, params...)
+	require.NoError(t, err)
+	pubKey := phase0.BLSPubKey{0x01}
+	require.NoError(t, s.ProtectProposal(ctx, pubKey, 100))
+
+	// Simulate a write that did not complete.
+	logFile, err := os.OpenFile(path+".log", os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = logFile.WriteString(`{"metadata":`)
+	require.NoError(t, err)
+	require.NoError(t, logFile.Close())
+
+	s, err = standard.New(ctx, params...)
+	require.NoError(t, err)
+	require.Error(t, s.ProtectProposal(ctx, pubKey, 100))
+	require.NoError(t, s.ProtectProposal(ctx, pubKey, 101))
+
+	// The log is readable following the partial entry.
+	s, err = standard.New(ctx, params...)
+	require.NoError(t, err)
+	require.Error(t, s.ProtectProposal(ctx, pubKey, 101))
+}
+
+func TestInterchange(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithPath(filepath.Join(t.TempDir(), "protection.json")),
+		standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+	)
+	require.NoError(t, err)
+
+	pubKey := phase0.BLSPubKey{0x01}
+	require.NoError(t, s.ProtectProposal(ctx, pubKey, 100))
+	_, err = s.ProtectAttestations(ctx, []phase0.BLSPubKey{pubKey}, 2, 3)
+	require.NoError(t, err)
+
+	require.EqualError(t, s.ImportInterchange(ctx, &slashingprotection.Interchange{
+		GenesisValidatorsRoot: phase0.Root{0x02},
+	}), "interchange data is for genesis validators root 0x0200000000000000000000000000000000000000000000000000000000000000, expected 0x0100000000000000000000000000000000000000000000000000000000000000")
+
+	require.NoError(t, s.ImportInterchange(ctx, &slashingprotection.Interchange{
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Data: []*slashingprotection.InterchangeValidator{
+			{
+				PubKey: pubKey,
+				SignedBlocks: []*slashingprotection.InterchangeBlock{
+					{Slot: 90},
+				},
+				SignedAttestations: []*slashingprotection.InterchangeAttestation{
+					{SourceEpoch: 1, TargetEpoch: 5},
+					{SourceEpoch: 3, TargetEpoch: 4},
+				},
+			},
+			{
+				PubKey: phase0.BLSPubKey{0x02},
+				SignedBlocks: []*slashingprotection.InterchangeBlock{
+					{Slot: 200},
+				},
+			},
+		},
+	}))
+
+	interchange, err := s.ExportInterchange(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &slashingprotection.Interchange{
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Data: []*slashingprotection.InterchangeValidator{
+			{
+				PubKey: pubKey,
+				SignedBlocks: []*slashingprotection.InterchangeBlock{
+					{Slot: 100},
+				},
+				SignedAttestations: []*slashingprotection.InterchangeAttestation{
+					{SourceEpoch: 3, TargetEpoch: 5},
+				},
+			},
+			{
+				PubKey: phase0.BLSPubKey{0x02},
+				SignedBlocks: []*slashingprotection.InterchangeBlock{
+					{Slot: 200},
+				},
+				SignedAttestations: []*slashingprotection.InterchangeAttestation{},
+			},
+		},
+	}, interchange)
+
+	// Imported data is honoured.
+	require.Error(t, s.ProtectProposal(ctx, phase0.BLSPubKey{0x02}, 200))
+	safe, err := s.ProtectAttestations(ctx, []phase0.BLSPubKey{pubKey}, 3, 5)
+	require.NoError(t, err)
+	require.Equal(t, []bool{false}, safe)
+}
+
+func BenchmarkProtectAttestations(b *testing.B) {
+	ctx := context.Background()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithPath(filepath.Join(b.TempDir(), "protection.json")),
+		standard.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+	)
+	require.NoError(b, err)
+
+	// Validators attest in one of 32 slots each epoch.
+	pubKeys := make([]phase0.BLSPubKey, 4096)
+	for i := range pubKeys {
+		pubKeys[i] = phase0.BLSPubKey{byte(i >> 8), byte(i)}
+	}
+	slotPubKeys := len(pubKeys) / 32
+	_, err = s.ProtectAttestations(ctx, pubKeys, 0, 1)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := (i % 32) * slotPubKeys
+		epoch := phase0.Epoch(i/32 + 1)
+		_, err := s.ProtectAttestations(ctx, pubKeys[start:start+slotPubKeys], epoch, epoch+1)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// fullSubmitter is a submitter for all signed items.
+type fullSubmitter interface {
+	submitter.AttestationsSubmitter
+	submitter.ProposalSubmitter
+	submitter.BeaconCommitteeSubscriptionsSubmitter
+	submitter.AggregateAttestationsSubmitter
+	submitter.ProposalPreparationsSubmitter
+	submitter.SyncCommitteeMessagesSubmitter
+	submitter.SyncCommitteeSubscriptionsSubmitter
+	submitter.SyncCommitteeContributionsSubmitter
+	submitter.BLSToExecutionChangesSubmitter
+}
+
+// Service is the submitter for signed items.
+type Service struct {
+	mu        sync.RWMutex
+	submitter fullSubmitter
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new submitter.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "submitter").Str("impl", "dynamic").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		submitter: parameters.submitter.(fullSubmitter),
+	}
+
+	return s, nil
+}
+
+// SetSubmitter replaces the submitter.  Submissions in progress complete
+// with the previous submitter.
+func (s *Service) SetSubmitter(submitter submitter.Service) error {
+	fullSubmitter, isSubmitter := submitter.(fullSubmitter)
+	if !isSubmitter {
+		return errors.New("submitter does not support all submissions")
+	}
+
+	s.mu.Lock()
+	s.submitter = fullSubmitter
+	s.mu.Unlock()
+	log.Trace().Msg("Replaced submitter")
+
+	return nil
+}
+
+// current returns the current submitter.
+func (s *Service) current() fullSubmitter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.submitter
+}
+
+// SubmitProposal submits a proposal.
+func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSignedProposal) error {
+	return s.current().SubmitProposal(ctx, proposal)
+}
+
+// SubmitAttestations submits multiple attestations.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	return s.current().SubmitAttestations(ctx, attestations)
+}
+
+// SubmitBeaconCommitteeSubscriptions submits a batch of beacon committee subscriptions.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	return s.current().SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitAggregateAttestations submits aggregate attestations.
+func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []*phase0.SignedAggregateAndProof) error {
+	return s.current().SubmitAggregateAttestations(ctx, aggregates)
+}
+
+// SubmitProposalPreparations submits proposal preparations.
+func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	return s.current().SubmitProposalPreparations(ctx, preparations)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	return s.current().SubmitSyncCommitteeMessages(ctx, messages)
+}
+
+// SubmitSyncCommitteeSubscriptions submits a batch of sync committee subscriptions.
+func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	return s.current().SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	return s.current().SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+}
+
+// SubmitBLSToExecutionChanges submits BLS to execution changes.
+func (s *Service) SubmitBLSToExecutionChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	return s.current().SubmitBLSToExecutionChanges(ctx, changes)
+}
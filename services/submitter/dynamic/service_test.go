@@ -0,0 +1,102 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/submitter/dynamic"
+	nullsubmitter "github.com/attestantio/vouch/services/submitter/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// erroringSubmitter is a submitter that fails to submit attestations.
+type erroringSubmitter struct {
+	*nullsubmitter.Service
+}
+
+func (*erroringSubmitter) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+	return errors.New("failed")
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	nullSubmitter, err := nullsubmitter.New(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []dynamic.Parameter
+		err    string
+	}{
+		{
+			name: "SubmitterMissing",
+			params: []dynamic.Parameter{
+				dynamic.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no submitter specified",
+		},
+		{
+			name: "SubmitterIncomplete",
+			params: []dynamic.Parameter{
+				dynamic.WithLogLevel(zerolog.Disabled),
+				dynamic.WithSubmitter(struct{}{}),
+			},
+			err: "problem with parameters: submitter does not support all submissions",
+		},
+		{
+			name: "Good",
+			params: []dynamic.Parameter{
+				dynamic.WithLogLevel(zerolog.Disabled),
+				dynamic.WithSubmitter(nullSubmitter),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := dynamic.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetSubmitter(t *testing.T) {
+	ctx := context.Background()
+
+	nullSubmitter, err := nullsubmitter.New(ctx)
+	require.NoError(t, err)
+
+	s, err := dynamic.New(ctx,
+		dynamic.WithLogLevel(zerolog.Disabled),
+		dynamic.WithSubmitter(&erroringSubmitter{Service: nullSubmitter}),
+	)
+	require.NoError(t, err)
+	require.EqualError(t, s.SubmitAttestations(ctx, []*phase0.Attestation{{}}), "failed")
+
+	require.EqualError(t, s.SetSubmitter(struct{}{}), "submitter does not support all submissions")
+	require.EqualError(t, s.SubmitAttestations(ctx, []*phase0.Attestation{{}}), "failed")
+
+	require.NoError(t, s.SetSubmitter(nullSubmitter))
+	require.NoError(t, s.SubmitAttestations(ctx, []*phase0.Attestation{{}}))
+}
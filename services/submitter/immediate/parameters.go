@@ -35,6 +35,7 @@ type parameters struct {
 	syncCommitteeMessagesSubmitter        eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitter   eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitter   eth2client.SyncCommitteeContributionsSubmitter
+	blsToExecutionChangesSubmitter        eth2client.BLSToExecutionChangesSubmitter
 }
 
 // Parameter is the interface for service parameters.
@@ -118,6 +119,14 @@ func WithProposalPreparationsSubmitter(submitter eth2client.ProposalPreparations
 	})
 }
 
+// WithBLSToExecutionChangesSubmitter sets the BLS to execution changes submitter.
+// This is optional; if not supplied BLS to execution changes cannot be submitted.
+func WithBLSToExecutionChangesSubmitter(submitter eth2client.BLSToExecutionChangesSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blsToExecutionChangesSubmitter = submitter
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
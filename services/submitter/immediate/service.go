@@ -22,6 +22,7 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/pkg/errors"
@@ -41,6 +42,7 @@ type Service struct {
 	syncCommitteeMessagesSubmitter        eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitter   eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitter   eth2client.SyncCommitteeContributionsSubmitter
+	blsToExecutionChangesSubmitter        eth2client.BLSToExecutionChangesSubmitter
 }
 
 // module-wide log.
@@ -69,6 +71,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeMessagesSubmitter:        parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSubscriptionsSubmitter:   parameters.syncCommitteeSubscriptionsSubmitter,
 		syncCommitteeContributionsSubmitter:   parameters.syncCommitteeContributionsSubmitter,
+		blsToExecutionChangesSubmitter:        parameters.blsToExecutionChangesSubmitter,
 	}
 
 	return s, nil
@@ -321,3 +324,36 @@ func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contribu
 
 	return nil
 }
+
+// SubmitBLSToExecutionChanges submits BLS to execution changes.
+func (s *Service) SubmitBLSToExecutionChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	ctx, span := otel.Tracer("attestantio.vouch.services.submitter.immediate").Start(ctx, "SubmitBLSToExecutionChanges")
+	defer span.End()
+
+	if len(changes) == 0 {
+		return errors.New("no BLS to execution changes supplied")
+	}
+	if s.blsToExecutionChangesSubmitter == nil {
+		return errors.New("no BLS to execution changes submitter configured")
+	}
+
+	started := time.Now()
+	err := s.blsToExecutionChangesSubmitter.SubmitBLSToExecutionChanges(ctx, changes)
+	if service, isService := s.blsToExecutionChangesSubmitter.(eth2client.Service); isService {
+		s.clientMonitor.ClientOperation(service.Address(), "submit bls to execution changes", err == nil, time.Since(started))
+	} else {
+		s.clientMonitor.ClientOperation("<unknown>", "submit bls to execution changes", err == nil, time.Since(started))
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to submit BLS to execution changes")
+	}
+
+	if e := log.Trace(); e.Enabled() {
+		data, err := json.Marshal(changes)
+		if err == nil {
+			e.Str("changes", string(data)).Msg("Submitted BLS to execution changes")
+		}
+	}
+
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	"github.com/attestantio/vouch/services/submitter"
@@ -220,6 +221,7 @@ func TestInterfaces(t *testing.T) {
 	require.Implements(t, (*submitter.SyncCommitteeMessagesSubmitter)(nil), s)
 	require.Implements(t, (*submitter.SyncCommitteeSubscriptionsSubmitter)(nil), s)
 	require.Implements(t, (*submitter.SyncCommitteeContributionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.BLSToExecutionChangesSubmitter)(nil), s)
 }
 
 func TestSubmitProposal(t *testing.T) {
@@ -932,3 +934,115 @@ func TestSubmitSyncCommitteeContributions(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmitBLSToExecutionChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []immediate.Parameter
+		changes []*capella.SignedBLSToExecutionChange
+		err     string
+	}{
+		{
+			name: "Nil",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithBLSToExecutionChangesSubmitter(mock.NewBLSToExecutionChangesSubmitter()),
+			},
+			err: "no BLS to execution changes supplied",
+		},
+		{
+			name: "Empty",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithBLSToExecutionChangesSubmitter(mock.NewBLSToExecutionChangesSubmitter()),
+			},
+			changes: []*capella.SignedBLSToExecutionChange{},
+			err:     "no BLS to execution changes supplied",
+		},
+		{
+			name: "NotConfigured",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+			},
+			changes: []*capella.SignedBLSToExecutionChange{
+				{},
+			},
+			err: "no BLS to execution changes submitter configured",
+		},
+		{
+			name: "Erroring",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithBLSToExecutionChangesSubmitter(mock.NewErroringBLSToExecutionChangesSubmitter()),
+			},
+			changes: []*capella.SignedBLSToExecutionChange{
+				{},
+			},
+			err: "failed to submit BLS to execution changes: error",
+		},
+		{
+			name: "Good",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithBLSToExecutionChangesSubmitter(mock.NewBLSToExecutionChangesSubmitter()),
+			},
+			changes: []*capella.SignedBLSToExecutionChange{
+				{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s, err := immediate.New(context.Background(), test.params...)
+		require.NoError(t, err)
+
+		t.Run(test.name, func(t *testing.T) {
+			err := s.SubmitBLSToExecutionChanges(context.Background(), test.changes)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -21,6 +21,31 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 )
 
+// deprioritisedAddresses returns the addresses of beacon nodes that should not be
+// used for submissions that are not critical to duties, as they have nearly exhausted
+// their quotas or are unhealthy, along with the reason for each.  If all beacon nodes
+// are deprioritised none are returned, so that the submission is still made.
+func (s *Service) deprioritisedAddresses(ctx context.Context, addresses []string) map[string]string {
+	deprioritised := make(map[string]string)
+	if s.beaconNodeQuotas == nil && s.nodeMonitor == nil {
+		return deprioritised
+	}
+
+	for _, address := range addresses {
+		switch {
+		case s.beaconNodeQuotas != nil && s.beaconNodeQuotas.Deprioritised(ctx, address):
+			deprioritised[address] = "quota nearly exhausted"
+		case s.nodeMonitor != nil && !s.nodeMonitor.Healthy(ctx, address):
+			deprioritised[address] = "unhealthy"
+		}
+	}
+	if len(deprioritised) == len(addresses) {
+		return make(map[string]string)
+	}
+
+	return deprioritised
+}
+
 // serviceInfo returns the service name and provider information.
 func (*Service) serviceInfo(ctx context.Context, submitter interface{}) (string, string) {
 	serviceName := "<unknown>"
@@ -17,11 +17,14 @@ package multinode
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/beaconnodequota"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -39,6 +42,12 @@ type parameters struct {
 	syncCommitteeMessagesSubmitter         map[string]eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitters   map[string]eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitters   map[string]eth2client.SyncCommitteeContributionsSubmitter
+	blsToExecutionChangesSubmitters        map[string]eth2client.BLSToExecutionChangesSubmitter
+	attestationsSplitThreshold             int
+	proposalTimeout                        time.Duration
+	proposalPublishPolicy                  string
+	beaconNodeQuotas                       beaconnodequota.Service
+	nodeMonitor                            nodemonitor.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -136,11 +145,62 @@ func WithSyncCommitteeContributionsSubmitters(submitters map[string]eth2client.S
 	})
 }
 
+// WithBLSToExecutionChangesSubmitters sets the BLS to execution changes submitters.
+// This is optional; if not supplied BLS to execution changes cannot be submitted.
+func WithBLSToExecutionChangesSubmitters(submitters map[string]eth2client.BLSToExecutionChangesSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blsToExecutionChangesSubmitters = submitters
+	})
+}
+
+// WithAttestationsSplitThreshold sets the number of attestations above which a
+// batch is split between submitters rather than sent to all of them.  0 disables
+// splitting.
+func WithAttestationsSplitThreshold(threshold int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationsSplitThreshold = threshold
+	})
+}
+
+// WithProposalTimeout sets the deadline for each beacon node to acknowledge a
+// proposal.  Defaults to the module timeout.
+func WithProposalTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalTimeout = timeout
+	})
+}
+
+// WithProposalPublishPolicy sets the policy for publishing proposals.  "first"
+// returns as soon as any beacon node acknowledges the proposal; "all" waits for
+// all beacon nodes to acknowledge the proposal or reach their deadline.  In both
+// cases publication succeeds if any beacon node acknowledges the proposal.
+func WithProposalPublishPolicy(policy string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalPublishPolicy = policy
+	})
+}
+
+// WithBeaconNodeQuotas sets the beacon node quota service.  Beacon nodes that
+// have nearly exhausted their quotas are not used for subscriptions.
+func WithBeaconNodeQuotas(quotas beaconnodequota.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconNodeQuotas = quotas
+	})
+}
+
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel:      zerolog.GlobalLevel(),
-		clientMonitor: nullmetrics.New(context.Background()),
+		logLevel:              zerolog.GlobalLevel(),
+		clientMonitor:         nullmetrics.New(context.Background()),
+		proposalPublishPolicy: "first",
 	}
 	for _, p := range params {
 		if params != nil {
@@ -181,6 +241,17 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.syncCommitteeContributionsSubmitters) == 0 {
 		return nil, errors.New("no sync committee contributions submitters specified")
 	}
+	if parameters.attestationsSplitThreshold < 0 {
+		return nil, errors.New("attestations split threshold cannot be negative")
+	}
+	if parameters.proposalTimeout == 0 {
+		parameters.proposalTimeout = parameters.timeout
+	}
+	switch parameters.proposalPublishPolicy {
+	case "first", "all":
+	default:
+		return nil, fmt.Errorf("unknown proposal publish policy %q", parameters.proposalPublishPolicy)
+	}
 
 	return &parameters, nil
 }
@@ -15,10 +15,13 @@ package multinode
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/beaconnodequota"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -37,6 +40,17 @@ type Service struct {
 	syncCommitteeMessagesSubmitter        map[string]eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionSubmitters   map[string]eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitters  map[string]eth2client.SyncCommitteeContributionsSubmitter
+	blsToExecutionChangesSubmitters       map[string]eth2client.BLSToExecutionChangesSubmitter
+	attestationsSplitThreshold            int
+	proposalTimeout                       time.Duration
+	proposalPublishPolicy                 string
+	beaconNodeQuotas                      beaconnodequota.Service
+	nodeMonitor                           nodemonitor.Service
+
+	// attestationsThroughput is the measured throughput of each attestations
+	// submitter, in attestations per second.
+	attestationsThroughput   map[string]float64
+	attestationsThroughputMu sync.RWMutex
 }
 
 // module-wide log.
@@ -67,6 +81,13 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeMessagesSubmitter:        parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSubscriptionSubmitters:   parameters.syncCommitteeSubscriptionsSubmitters,
 		syncCommitteeContributionsSubmitters:  parameters.syncCommitteeContributionsSubmitters,
+		blsToExecutionChangesSubmitters:       parameters.blsToExecutionChangesSubmitters,
+		attestationsSplitThreshold:            parameters.attestationsSplitThreshold,
+		proposalTimeout:                       parameters.proposalTimeout,
+		proposalPublishPolicy:                 parameters.proposalPublishPolicy,
+		beaconNodeQuotas:                      parameters.beaconNodeQuotas,
+		nodeMonitor:                           parameters.nodeMonitor,
+		attestationsThroughput:                make(map[string]float64),
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -373,6 +373,24 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no sync committee contributions submitters specified",
 		},
+		{
+			name: "ProposalPublishPolicyUnknown",
+			params: []multinode.Parameter{
+				multinode.WithLogLevel(zerolog.Disabled),
+				multinode.WithTimeout(2 * time.Second),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithProposalSubmitters(beaconBlockSubmitters),
+				multinode.WithAttestationsSubmitters(attestationsSubmitters),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(beaconCommitteeSubscriptionsSubmitters),
+				multinode.WithAggregateAttestationsSubmitters(aggregateAttestationsSubmitters),
+				multinode.WithProposalPreparationsSubmitters(proposalPrepartionsSubmitters),
+				multinode.WithSyncCommitteeMessagesSubmitters(syncCommitteeMessagesSubmitters),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(syncCommitteeSubscriptionsSubmitters),
+				multinode.WithSyncCommitteeContributionsSubmitters(syncCommitteeContributionsSubmitters),
+				multinode.WithProposalPublishPolicy("some"),
+			},
+			err: `problem with parameters: unknown proposal publish policy "some"`,
+		},
 		{
 			name: "Good",
 			params: []multinode.Parameter{
@@ -442,4 +460,5 @@ func TestInterfaces(t *testing.T) {
 	require.Implements(t, (*submitter.SyncCommitteeMessagesSubmitter)(nil), s)
 	require.Implements(t, (*submitter.SyncCommitteeSubscriptionsSubmitter)(nil), s)
 	require.Implements(t, (*submitter.SyncCommitteeContributionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.BLSToExecutionChangesSubmitter)(nil), s)
 }
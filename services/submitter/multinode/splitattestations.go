@@ -0,0 +1,176 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// throughputDecay is the weight given to the existing throughput measurement
+// when a new measurement is recorded.
+const throughputDecay = 0.8
+
+// submitSplitAttestations submits a batch of attestations split between the
+// submitters in proportion to their measured throughput.  If a submitter fails
+// to submit its portion of the batch the portion is passed to the remaining
+// submitters in turn.
+func (s *Service) submitSplitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	ctx, span := otel.Tracer("attestantio.vouch.service.submitter.multinode").Start(ctx, "submitSplitAttestations", trace.WithAttributes(
+		attribute.Int("attestations", len(attestations)),
+	))
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	submitters := nodemonitor.PreferredProviders(ctx, s.nodeMonitor, s.attestationsSubmitters)
+	names := make([]string, 0, len(submitters))
+	for name := range submitters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	portions := s.attestationsPortions(names, len(attestations))
+
+	var wg sync.WaitGroup
+	var failedMu sync.Mutex
+	failed := 0
+	offset := 0
+	for i := range names {
+		if portions[i] == 0 {
+			continue
+		}
+		batch := attestations[offset : offset+portions[i]]
+		offset += portions[i]
+		wg.Add(1)
+		go func(i int, batch []*phase0.Attestation) {
+			defer wg.Done()
+			for j := range names {
+				if err := s.submitAttestationsBatch(ctx, names[(i+j)%len(names)], batch); err == nil {
+					return
+				}
+			}
+			failedMu.Lock()
+			failed += len(batch)
+			failedMu.Unlock()
+		}(i, batch)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		log.Warn().Int("failed", failed).Int("attestations", len(attestations)).Msg("Failed to submit some attestations")
+		return errors.New("failed to submit all attestations")
+	}
+
+	return nil
+}
+
+// submitAttestationsBatch submits a batch of attestations to a single submitter.
+func (s *Service) submitAttestationsBatch(ctx context.Context,
+	name string,
+	attestations []*phase0.Attestation,
+) error {
+	submitter := s.attestationsSubmitters[name]
+	log := log.With().Str("beacon_node_address", name).Uint64("slot", uint64(attestations[0].Data.Slot)).Logger()
+
+	_, address := s.serviceInfo(ctx, submitter)
+	started := time.Now()
+	_, err := util.Scatter(len(attestations), int(s.processConcurrency), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		return nil, submitter.SubmitAttestations(ctx, attestations[offset:offset+entries])
+	})
+	if err != nil {
+		err = s.handleAttestationsError(ctx, submitter, err)
+	}
+
+	s.clientMonitor.ClientOperation(address, "submit attestations", err == nil, time.Since(started))
+	if err != nil {
+		log.Warn().Err(err).Int("attestations", len(attestations)).Msg("Failed to submit batch of attestations")
+		return err
+	}
+	s.recordAttestationsThroughput(name, len(attestations), time.Since(started))
+	log.Trace().Int("attestations", len(attestations)).Msg("Submitted batch of attestations")
+
+	return nil
+}
+
+// attestationsPortions returns the number of attestations that each of the named
+// submitters should submit, in proportion to their measured throughput.
+// Submitters without a measured throughput are assumed to have the average
+// throughput of those that have one.
+func (s *Service) attestationsPortions(names []string, total int) []int {
+	weights := make([]float64, len(names))
+	known := 0
+	knownWeight := float64(0)
+	s.attestationsThroughputMu.RLock()
+	for i, name := range names {
+		if throughput, exists := s.attestationsThroughput[name]; exists && throughput > 0 {
+			weights[i] = throughput
+			known++
+			knownWeight += throughput
+		}
+	}
+	s.attestationsThroughputMu.RUnlock()
+
+	defaultWeight := float64(1)
+	if known > 0 {
+		defaultWeight = knownWeight / float64(known)
+	}
+	totalWeight := float64(0)
+	for i := range weights {
+		if weights[i] == 0 {
+			weights[i] = defaultWeight
+		}
+		totalWeight += weights[i]
+	}
+
+	portions := make([]int, len(names))
+	allocated := 0
+	highest := 0
+	for i := range weights {
+		portions[i] = int(float64(total) * weights[i] / totalWeight)
+		allocated += portions[i]
+		if weights[i] > weights[highest] {
+			highest = i
+		}
+	}
+	// Any remainder from rounding goes to the submitter with the highest throughput.
+	portions[highest] += total - allocated
+
+	return portions
+}
+
+// recordAttestationsThroughput records the throughput of a submitter.
+func (s *Service) recordAttestationsThroughput(name string, attestations int, duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+	throughput := float64(attestations) / duration.Seconds()
+
+	s.attestationsThroughputMu.Lock()
+	if existing, exists := s.attestationsThroughput[name]; exists {
+		throughput = existing*throughputDecay + throughput*(1-throughputDecay)
+	}
+	s.attestationsThroughput[name] = throughput
+	s.attestationsThroughputMu.Unlock()
+}
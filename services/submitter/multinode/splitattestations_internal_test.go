@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationsPortions(t *testing.T) {
+	tests := []struct {
+		name       string
+		throughput map[string]float64
+		names      []string
+		total      int
+		expected   []int
+	}{
+		{
+			name:     "NoMeasurements",
+			names:    []string{"1", "2"},
+			total:    10,
+			expected: []int{5, 5},
+		},
+		{
+			name:     "Remainder",
+			names:    []string{"1", "2", "3"},
+			total:    10,
+			expected: []int{4, 3, 3},
+		},
+		{
+			name: "Proportional",
+			throughput: map[string]float64{
+				"1": 300,
+				"2": 100,
+			},
+			names:    []string{"1", "2"},
+			total:    100,
+			expected: []int{75, 25},
+		},
+		{
+			name: "PartialMeasurements",
+			throughput: map[string]float64{
+				"1": 200,
+			},
+			names:    []string{"1", "2"},
+			total:    100,
+			expected: []int{50, 50},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				attestationsThroughput: make(map[string]float64),
+			}
+			for name, throughput := range test.throughput {
+				s.attestationsThroughput[name] = throughput
+			}
+			require.Equal(t, test.expected, s.attestationsPortions(test.names, test.total))
+		})
+	}
+}
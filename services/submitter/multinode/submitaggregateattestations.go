@@ -20,6 +20,8 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -42,9 +44,20 @@ func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	w := sync.NewCond(&sync.Mutex{})
 	w.L.Lock()
-	for name, submitter := range s.aggregateAttestationsSubmitters {
-		go s.submitAggregateAttestations(ctx, sem, w, name, aggregates, submitter)
+	// Submissions continue after we return, so are not cancelled with the duty.
+	submitCtx, cancel := util.Detach(ctx)
+	wg := &sync.WaitGroup{}
+	for name, submitter := range nodemonitor.PreferredProviders(ctx, s.nodeMonitor, s.aggregateAttestationsSubmitters) {
+		wg.Add(1)
+		go func(name string, submitter eth2client.AggregateAttestationsSubmitter) {
+			defer wg.Done()
+			s.submitAggregateAttestations(submitCtx, sem, w, name, aggregates, submitter)
+		}(name, submitter)
 	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
 	// Also set a timeout condition, in case no submitters return.
 	go func(s *Service, w *sync.Cond) {
 		time.Sleep(s.timeout)
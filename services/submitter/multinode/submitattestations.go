@@ -21,6 +21,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -40,13 +41,30 @@ func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0
 		return errors.New("no attestations supplied")
 	}
 
+	if s.attestationsSplitThreshold > 0 &&
+		len(attestations) > s.attestationsSplitThreshold &&
+		len(s.attestationsSubmitters) > 1 {
+		return s.submitSplitAttestations(ctx, attestations)
+	}
+
 	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	w := sync.NewCond(&sync.Mutex{})
 	w.L.Lock()
-	for name, submitter := range s.attestationsSubmitters {
-		go s.submitAttestations(ctx, sem, w, name, attestations, submitter)
+	// Submissions continue after we return, so are not cancelled with the duty.
+	submitCtx, cancel := util.Detach(ctx)
+	wg := &sync.WaitGroup{}
+	for name, submitter := range nodemonitor.PreferredProviders(ctx, s.nodeMonitor, s.attestationsSubmitters) {
+		wg.Add(1)
+		go func(name string, submitter eth2client.AttestationsSubmitter) {
+			defer wg.Done()
+			s.submitAttestations(submitCtx, sem, w, name, attestations, submitter)
+		}(name, submitter)
 	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
 	// Also set a timeout condition, in case no submitters return.
 	go func(s *Service, w *sync.Cond) {
 		time.Sleep(s.timeout)
@@ -94,6 +112,7 @@ func (s *Service) submitAttestations(ctx context.Context,
 		log.Warn().Err(err).Msg("Failed to submit attestations")
 		return
 	}
+	s.recordAttestationsThroughput(name, len(attestations), time.Since(started))
 
 	w.Signal()
 	log.Trace().Msg("Submitted attestations")
@@ -21,6 +21,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	mocknodemonitor "github.com/attestantio/vouch/services/nodemonitor/mock"
 	"github.com/attestantio/vouch/services/submitter/multinode"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/attestantio/vouch/testutil"
@@ -178,6 +179,81 @@ func TestSubmitAttestationsErroring(t *testing.T) {
 	require.EqualError(t, err, "no successful submissions before timeout")
 }
 
+func TestSubmitAttestationsPriorities(t *testing.T) {
+	ctx := context.Background()
+
+	priorities := map[string]int{
+		"1": 0,
+		"2": 1,
+	}
+
+	tests := []struct {
+		name      string
+		unhealthy []string
+		err       string
+	}{
+		{
+			name: "FallbackUnused",
+			err:  "no successful submissions before timeout",
+		},
+		{
+			name:      "FallbackUsed",
+			unhealthy: []string{"1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := multinode.New(context.Background(),
+				multinode.WithLogLevel(zerolog.Disabled),
+				multinode.WithTimeout(100*time.Millisecond),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+					"1": mock.NewErroringAttestationsSubmitter(),
+					"2": mock.NewAttestationsSubmitter(),
+				}),
+				multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+					"1": mock.NewProposalSubmitter(),
+				}),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+					"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewAggregateAttestationsSubmitter(),
+				}),
+				multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+					"1": mock.NewProposalPreparationsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+					"1": mock.NewSyncCommitteeMessagesSubmitter(),
+				}),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+					"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+					"1": mock.NewSyncCommitteeContributionsSubmitter(),
+				}),
+				multinode.WithNodeMonitor(mocknodemonitor.NewPrioritised(priorities, test.unhealthy...)),
+			)
+			require.NoError(t, err)
+
+			err = s.SubmitAttestations(ctx, []*phase0.Attestation{
+				{
+					Data: &phase0.AttestationData{
+						Source: &phase0.Checkpoint{},
+						Target: &phase0.Checkpoint{},
+					},
+				},
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestSubmitAttestationsSleepy(t *testing.T) {
 	ctx := context.Background()
 
@@ -283,3 +359,96 @@ func TestSubmitAttestationsSleepySuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSubmitAttestationsSplit(t *testing.T) {
+	ctx := context.Background()
+
+	attestations := make([]*phase0.Attestation, 0, 8)
+	for i := 0; i < 8; i++ {
+		attestations = append(attestations, &phase0.Attestation{
+			Data: &phase0.AttestationData{
+				Slot:            phase0.Slot(i),
+				BeaconBlockRoot: testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101"),
+				Source: &phase0.Checkpoint{
+					Epoch: 5,
+					Root:  testutil.HexToRoot("0x0202020202020202020202020202020202020202020202020202020202020202"),
+				},
+				Target: &phase0.Checkpoint{
+					Epoch: 6,
+					Root:  testutil.HexToRoot("0x0303030303030303030303030303030303030303030303030303030303030303"),
+				},
+			},
+			Signature: testutil.HexToSignature("0x040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404"),
+		})
+	}
+
+	tests := []struct {
+		name       string
+		submitters map[string]eth2client.AttestationsSubmitter
+		err        string
+	}{
+		{
+			name: "Good",
+			submitters: map[string]eth2client.AttestationsSubmitter{
+				"1": mock.NewAttestationsSubmitter(),
+				"2": mock.NewAttestationsSubmitter(),
+			},
+		},
+		{
+			name: "Fallback",
+			submitters: map[string]eth2client.AttestationsSubmitter{
+				"1": mock.NewAttestationsSubmitter(),
+				"2": mock.NewErroringAttestationsSubmitter(),
+			},
+		},
+		{
+			name: "Erroring",
+			submitters: map[string]eth2client.AttestationsSubmitter{
+				"1": mock.NewErroringAttestationsSubmitter(),
+				"2": mock.NewErroringAttestationsSubmitter(),
+			},
+			err: "failed to submit all attestations",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := multinode.New(context.Background(),
+				multinode.WithLogLevel(zerolog.Disabled),
+				multinode.WithTimeout(100*time.Millisecond),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithAttestationsSplitThreshold(4),
+				multinode.WithAttestationsSubmitters(test.submitters),
+				multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+					"1": mock.NewProposalSubmitter(),
+				}),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+					"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewAggregateAttestationsSubmitter(),
+				}),
+				multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+					"1": mock.NewProposalPreparationsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+					"1": mock.NewSyncCommitteeMessagesSubmitter(),
+				}),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+					"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+					"1": mock.NewSyncCommitteeContributionsSubmitter(),
+				}),
+			)
+			require.NoError(t, err)
+
+			err = s.SubmitAttestations(ctx, attestations)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
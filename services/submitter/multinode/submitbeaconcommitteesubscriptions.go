@@ -42,7 +42,16 @@ func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscr
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	w := sync.NewCond(&sync.Mutex{})
 	w.L.Lock()
+	addresses := make([]string, 0, len(s.beaconCommitteeSubscriptionSubmitters))
+	for name := range s.beaconCommitteeSubscriptionSubmitters {
+		addresses = append(addresses, name)
+	}
+	deprioritised := s.deprioritisedAddresses(ctx, addresses)
 	for name, submitter := range s.beaconCommitteeSubscriptionSubmitters {
+		if reason, exists := deprioritised[name]; exists {
+			log.Trace().Str("beacon_node_address", name).Msgf("Beacon node %s; not submitting subscriptions", reason)
+			continue
+		}
 		go s.submitBeaconCommitteeSubscriptions(ctx, sem, w, name, subscriptions, submitter)
 	}
 	// Also set a timeout condition, in case no submitters return.
@@ -21,6 +21,8 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/vouch/mock"
+	mockbeaconnodequota "github.com/attestantio/vouch/services/beaconnodequota/mock"
+	mocknodemonitor "github.com/attestantio/vouch/services/nodemonitor/mock"
 	"github.com/attestantio/vouch/services/submitter/multinode"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
@@ -230,3 +232,155 @@ func TestSubmitBeaconCommitteeSubscriptionsSleepySuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSubmitBeaconCommitteeSubscriptionsDeprioritised(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		deprioritised []string
+		skipped       bool
+	}{
+		{
+			name:          "OneDeprioritised",
+			deprioritised: []string{"1"},
+			skipped:       true,
+		},
+		{
+			name:          "AllDeprioritised",
+			deprioritised: []string{"1", "2"},
+			skipped:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+
+			s, err := multinode.New(context.Background(),
+				multinode.WithLogLevel(zerolog.TraceLevel),
+				multinode.WithTimeout(100*time.Millisecond),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+					"1": mock.NewAttestationsSubmitter(),
+				}),
+				multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+					"1": mock.NewProposalSubmitter(),
+				}),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+					"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+					"2": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewAggregateAttestationsSubmitter(),
+				}),
+				multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+					"1": mock.NewProposalPreparationsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+					"1": mock.NewSyncCommitteeMessagesSubmitter(),
+				}),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+					"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+					"1": mock.NewSyncCommitteeContributionsSubmitter(),
+				}),
+				multinode.WithBeaconNodeQuotas(mockbeaconnodequota.New(test.deprioritised...)),
+			)
+			require.NoError(t, err)
+
+			err = s.SubmitBeaconCommitteeSubscriptions(ctx, []*api.BeaconCommitteeSubscription{
+				{},
+			})
+			require.NoError(t, err)
+
+			// Return happens prior to the log message, so wait before asserting.
+			time.Sleep(time.Millisecond)
+			capture.AssertHasEntry(t, "Submitted beacon committee subscriptions")
+			if test.skipped {
+				capture.AssertHasEntry(t, "Beacon node quota nearly exhausted; not submitting subscriptions")
+			} else {
+				require.False(t, capture.HasLog(map[string]interface{}{
+					"message": "Beacon node quota nearly exhausted; not submitting subscriptions",
+				}))
+			}
+		})
+	}
+}
+
+func TestSubmitBeaconCommitteeSubscriptionsUnhealthy(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		unhealthy []string
+		skipped   bool
+	}{
+		{
+			name:      "OneUnhealthy",
+			unhealthy: []string{"1"},
+			skipped:   true,
+		},
+		{
+			name:      "AllUnhealthy",
+			unhealthy: []string{"1", "2"},
+			skipped:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+
+			s, err := multinode.New(context.Background(),
+				multinode.WithLogLevel(zerolog.TraceLevel),
+				multinode.WithTimeout(100*time.Millisecond),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+					"1": mock.NewAttestationsSubmitter(),
+				}),
+				multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+					"1": mock.NewProposalSubmitter(),
+				}),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+					"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+					"2": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewAggregateAttestationsSubmitter(),
+				}),
+				multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+					"1": mock.NewProposalPreparationsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+					"1": mock.NewSyncCommitteeMessagesSubmitter(),
+				}),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+					"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+					"1": mock.NewSyncCommitteeContributionsSubmitter(),
+				}),
+				multinode.WithNodeMonitor(mocknodemonitor.New(test.unhealthy...)),
+			)
+			require.NoError(t, err)
+
+			err = s.SubmitBeaconCommitteeSubscriptions(ctx, []*api.BeaconCommitteeSubscription{
+				{},
+			})
+			require.NoError(t, err)
+
+			// Return happens prior to the log message, so wait before asserting.
+			time.Sleep(time.Millisecond)
+			capture.AssertHasEntry(t, "Submitted beacon committee subscriptions")
+			if test.skipped {
+				capture.AssertHasEntry(t, "Beacon node unhealthy; not submitting subscriptions")
+			} else {
+				require.False(t, capture.HasLog(map[string]interface{}{
+					"message": "Beacon node unhealthy; not submitting subscriptions",
+				}))
+			}
+		})
+	}
+}
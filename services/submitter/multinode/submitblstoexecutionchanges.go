@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/semaphore"
+)
+
+// SubmitBLSToExecutionChanges submits BLS to execution changes.
+// Unlike other operations, this waits for all beacon nodes to respond, as
+// changes are usually submitted by a short-lived process that would
+// otherwise exit before slower beacon nodes had received them.
+func (s *Service) SubmitBLSToExecutionChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	ctx, span := otel.Tracer("attestantio.vouch.services.submitter.multinode").Start(ctx, "SubmitBLSToExecutionChanges", trace.WithAttributes(
+		attribute.String("strategy", "multinode"),
+		attribute.Int("changes", len(changes)),
+	))
+	defer span.End()
+
+	if len(changes) == 0 {
+		return errors.New("no BLS to execution changes supplied")
+	}
+	if len(s.blsToExecutionChangesSubmitters) == 0 {
+		return errors.New("no BLS to execution changes submitters configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sem := semaphore.NewWeighted(s.processConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for name, submitter := range s.blsToExecutionChangesSubmitters {
+		wg.Add(1)
+		go func(name string, submitter eth2client.BLSToExecutionChangesSubmitter) {
+			defer wg.Done()
+			if s.submitBLSToExecutionChanges(ctx, sem, name, changes, submitter) {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}(name, submitter)
+	}
+	wg.Wait()
+
+	if succeeded == 0 {
+		return errors.New("no successful BLS to execution changes submissions")
+	}
+	log.Trace().Int("succeeded", succeeded).Int("submitters", len(s.blsToExecutionChangesSubmitters)).Msg("Submitted BLS to execution changes")
+
+	return nil
+}
+
+// submitBLSToExecutionChanges carries out the internal work of submitting BLS to execution changes.
+// Returns true if the submission succeeded.
+func (s *Service) submitBLSToExecutionChanges(ctx context.Context,
+	sem *semaphore.Weighted,
+	name string,
+	changes []*capella.SignedBLSToExecutionChange,
+	submitter eth2client.BLSToExecutionChangesSubmitter,
+) bool {
+	log := log.With().Str("beacon_node_address", name).Logger()
+	if err := sem.Acquire(ctx, 1); err != nil {
+		log.Error().Err(err).Msg("Failed to acquire semaphore")
+		return false
+	}
+	defer sem.Release(1)
+
+	_, address := s.serviceInfo(ctx, submitter)
+	started := time.Now()
+	err := submitter.SubmitBLSToExecutionChanges(ctx, changes)
+
+	s.clientMonitor.ClientOperation(address, "submit bls to execution changes", err == nil, time.Since(started))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to submit BLS to execution changes")
+		return false
+	}
+
+	log.Trace().Msg("Submitted BLS to execution changes to beacon node")
+
+	return true
+}
@@ -0,0 +1,147 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/submitter/multinode"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func blsToExecutionChangesService(t *testing.T, submitters map[string]eth2client.BLSToExecutionChangesSubmitter) *multinode.Service {
+	t.Helper()
+
+	s, err := multinode.New(context.Background(),
+		multinode.WithLogLevel(zerolog.Disabled),
+		multinode.WithTimeout(100*time.Millisecond),
+		multinode.WithProcessConcurrency(2),
+		multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"1": mock.NewAttestationsSubmitter(),
+		}),
+		multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+			"1": mock.NewProposalSubmitter(),
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewSyncCommitteeContributionsSubmitter(),
+		}),
+		multinode.WithBLSToExecutionChangesSubmitters(submitters),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestSubmitBLSToExecutionChanges(t *testing.T) {
+	ctx := context.Background()
+
+	changes := []*capella.SignedBLSToExecutionChange{
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex: 1,
+			},
+		},
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex: 2,
+			},
+		},
+	}
+
+	submitter1 := mock.NewBLSToExecutionChangesSubmitter()
+	submitter2 := mock.NewBLSToExecutionChangesSubmitter()
+
+	tests := []struct {
+		name       string
+		submitters map[string]eth2client.BLSToExecutionChangesSubmitter
+		changes    []*capella.SignedBLSToExecutionChange
+		err        string
+	}{
+		{
+			name: "Empty",
+			submitters: map[string]eth2client.BLSToExecutionChangesSubmitter{
+				"1": mock.NewBLSToExecutionChangesSubmitter(),
+			},
+			changes: []*capella.SignedBLSToExecutionChange{},
+			err:     "no BLS to execution changes supplied",
+		},
+		{
+			name:    "NotConfigured",
+			changes: changes,
+			err:     "no BLS to execution changes submitters configured",
+		},
+		{
+			name: "Erroring",
+			submitters: map[string]eth2client.BLSToExecutionChangesSubmitter{
+				"1": mock.NewErroringBLSToExecutionChangesSubmitter(),
+				"2": mock.NewErroringBLSToExecutionChangesSubmitter(),
+			},
+			changes: changes,
+			err:     "no successful BLS to execution changes submissions",
+		},
+		{
+			name: "PartiallyErroring",
+			submitters: map[string]eth2client.BLSToExecutionChangesSubmitter{
+				"1": mock.NewErroringBLSToExecutionChangesSubmitter(),
+				"2": mock.NewBLSToExecutionChangesSubmitter(),
+			},
+			changes: changes,
+		},
+		{
+			name: "Good",
+			submitters: map[string]eth2client.BLSToExecutionChangesSubmitter{
+				"1": submitter1,
+				"2": submitter2,
+			},
+			changes: changes,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := blsToExecutionChangesService(t, test.submitters)
+			err := s.SubmitBLSToExecutionChanges(ctx, test.changes)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// All beacon nodes should have received the full batch.
+	require.Equal(t, changes, submitter1.Changes())
+	require.Equal(t, changes, submitter2.Changes())
+}
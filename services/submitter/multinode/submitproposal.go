@@ -15,84 +15,120 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/sync/semaphore"
 )
 
-// SubmitProposal submits a proposal.
+// proposalResult is the result of publishing a proposal to a single beacon node.
+type proposalResult struct {
+	name string
+	err  error
+}
+
+// SubmitProposal submits a proposal to all beacon nodes concurrently.  Publication
+// succeeds if any beacon node acknowledges the proposal.
 func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSignedProposal) error {
 	ctx, span := otel.Tracer("attestantio.vouch.service.submitter.multinode").Start(ctx, "SubmitProposal", trace.WithAttributes(
 		attribute.String("strategy", "multinode"),
+		attribute.String("policy", s.proposalPublishPolicy),
 	))
 	defer span.End()
 
 	if proposal == nil {
 		return errors.New("no proposal supplied")
 	}
+	slot, err := proposal.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain slot")
+	}
 
-	var err error
-	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.proposalSubmitters {
-		go s.submitProposal(ctx, sem, w, name, proposal, submitter)
+	submitters := nodemonitor.PreferredProviders(ctx, s.nodeMonitor, s.proposalSubmitters)
+	resCh := make(chan *proposalResult, len(submitters))
+	// Submissions continue after we return, so are not cancelled with the duty.
+	submitCtx, cancel := util.Detach(ctx)
+	for name, submitter := range submitters {
+		go s.submitProposal(submitCtx, name, slot, proposal, submitter, resCh)
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	// Collect the results.  This continues after we return, so that the
+	// results from all beacon nodes are reported.
+	outcomeCh := make(chan bool, 1)
+	go func() {
+		defer cancel()
+		results := make(map[string]string, len(submitters))
+		succeeded := false
+		for i := 0; i < len(submitters); i++ {
+			res := <-resCh
+			if res.err != nil {
+				results[res.name] = res.err.Error()
+				continue
+			}
+			results[res.name] = "succeeded"
+			if !succeeded {
+				succeeded = true
+				if s.proposalPublishPolicy == "first" {
+					outcomeCh <- true
+				}
+			}
+		}
+		if s.proposalPublishPolicy == "all" || !succeeded {
+			outcomeCh <- succeeded
+		}
+		log.Debug().Uint64("slot", uint64(slot)).Interface("results", results).Msg("Proposal publication results")
+	}()
+
+	select {
+	case succeeded := <-outcomeCh:
+		if !succeeded {
+			return errors.New("no successful submissions before timeout")
+		}
+	case <-time.After(util.BudgetedTimeout(ctx, s.timeout)):
+		return errors.New("no successful submissions before timeout")
+	}
+
+	return nil
 }
 
 // submitProposal carries out the internal work of submitting beacon blocks.
 // skipcq: RVV-B0001
 func (s *Service) submitProposal(ctx context.Context,
-	sem *semaphore.Weighted,
-	w *sync.Cond,
 	name string,
+	slot phase0.Slot,
 	proposal *api.VersionedSignedProposal,
 	submitter eth2client.ProposalSubmitter,
+	resCh chan *proposalResult,
 ) {
 	ctx, span := otel.Tracer("attestantio.vouch.service.submitter.multinode").Start(ctx, "submitProposal", trace.WithAttributes(
 		attribute.String("server", name),
 	))
 	defer span.End()
 
-	slot, err := proposal.Slot()
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to obtain slot")
-		return
-	}
 	log := log.With().Str("beacon_node_address", name).Uint64("slot", uint64(slot)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
 	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
 
-	err = submitter.SubmitProposal(ctx, proposal)
+	ctx, cancel := context.WithTimeout(ctx, s.proposalTimeout)
+	defer cancel()
+	err := submitter.SubmitProposal(ctx, proposal)
 	s.clientMonitor.ClientOperation(address, "submit proposal", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit proposal")
-		return
+	} else {
+		log.Trace().Msg("Submitted proposal")
 	}
 
-	w.Signal()
-	log.Trace().Msg("Submitted proposal")
+	resCh <- &proposalResult{
+		name: name,
+		err:  err,
+	}
 }
@@ -252,3 +252,58 @@ func TestSubmitProposalSleepySuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSubmitProposalPublishPolicyAll(t *testing.T) {
+	ctx := context.Background()
+
+	capture := logger.NewLogCapture()
+
+	s, err := multinode.New(context.Background(),
+		multinode.WithLogLevel(zerolog.TraceLevel),
+		multinode.WithTimeout(time.Second),
+		multinode.WithProposalTimeout(200*time.Millisecond),
+		multinode.WithProposalPublishPolicy("all"),
+		multinode.WithProcessConcurrency(2),
+		multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"1": mock.NewAttestationsSubmitter(),
+		}),
+		multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+			"1": mock.NewErroringProposalSubmitter(),
+			"2": mock.NewSleepyProposalSubmitter(100*time.Millisecond, mock.NewProposalSubmitter()),
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewSyncCommitteeContributionsSubmitter(),
+		}),
+	)
+	require.NoError(t, err)
+
+	err = s.SubmitProposal(ctx, &api.VersionedSignedProposal{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.SignedBeaconBlock{
+			Message: &altair.BeaconBlock{
+				Slot: 1,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Results are reported after the outcome is returned, so wait before asserting.
+	time.Sleep(10 * time.Millisecond)
+	capture.AssertHasEntry(t, "Failed to submit proposal")
+	capture.AssertHasEntry(t, "Proposal publication results")
+}
@@ -22,6 +22,8 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -44,9 +46,20 @@ func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contribu
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	w := sync.NewCond(&sync.Mutex{})
 	w.L.Lock()
-	for name, submitter := range s.syncCommitteeContributionsSubmitters {
-		go s.submitSyncCommitteeContributions(ctx, sem, w, name, contributionAndProofs, submitter)
+	// Submissions continue after we return, so are not cancelled with the duty.
+	submitCtx, cancel := util.Detach(ctx)
+	wg := &sync.WaitGroup{}
+	for name, submitter := range nodemonitor.PreferredProviders(ctx, s.nodeMonitor, s.syncCommitteeContributionsSubmitters) {
+		wg.Add(1)
+		go func(name string, submitter eth2client.SyncCommitteeContributionsSubmitter) {
+			defer wg.Done()
+			s.submitSyncCommitteeContributions(submitCtx, sem, w, name, contributionAndProofs, submitter)
+		}(name, submitter)
 	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
 	// Also set a timeout condition, in case no submitters return.
 	go func(s *Service, w *sync.Cond) {
 		time.Sleep(s.timeout)
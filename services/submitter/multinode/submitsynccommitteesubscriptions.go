@@ -42,7 +42,16 @@ func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscrip
 	sem := semaphore.NewWeighted(s.processConcurrency)
 	w := sync.NewCond(&sync.Mutex{})
 	w.L.Lock()
+	addresses := make([]string, 0, len(s.syncCommitteeSubscriptionSubmitters))
+	for name := range s.syncCommitteeSubscriptionSubmitters {
+		addresses = append(addresses, name)
+	}
+	deprioritised := s.deprioritisedAddresses(ctx, addresses)
 	for name, submitter := range s.syncCommitteeSubscriptionSubmitters {
+		if reason, exists := deprioritised[name]; exists {
+			log.Trace().Str("beacon_node_address", name).Msgf("Beacon node %s; not submitting subscriptions", reason)
+			continue
+		}
 		go s.submitSyncCommitteeSubscriptions(ctx, sem, w, name, subscriptions, submitter)
 	}
 	// Also set a timeout condition, in case no submitters return.
@@ -20,6 +20,7 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -182,3 +183,19 @@ func (*Service) SubmitSyncCommitteeContributions(_ context.Context, contribution
 
 	return nil
 }
+
+// SubmitBLSToExecutionChanges submits BLS to execution changes.
+func (*Service) SubmitBLSToExecutionChanges(_ context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	if len(changes) == 0 {
+		return errors.New("no BLS to execution changes supplied")
+	}
+
+	if e := log.Trace(); e.Enabled() {
+		data, err := json.Marshal(changes)
+		if err == nil {
+			e.Str("changes", string(data)).Msg("Not submitting BLS to execution changes")
+		}
+	}
+
+	return nil
+}
@@ -54,6 +54,7 @@ func TestSubmit(t *testing.T) {
 	require.EqualError(t, s.SubmitAttestations(context.Background(), nil), "no attestations supplied")
 	require.EqualError(t, s.SubmitBeaconCommitteeSubscriptions(context.Background(), nil), "no subscriptions supplied")
 	require.EqualError(t, s.SubmitAggregateAttestations(context.Background(), nil), "no aggregate attestations supplied")
+	require.EqualError(t, s.SubmitBLSToExecutionChanges(context.Background(), nil), "no BLS to execution changes supplied")
 }
 
 func TestInterfaces(t *testing.T) {
@@ -65,4 +66,5 @@ func TestInterfaces(t *testing.T) {
 	require.Implements(t, (*submitter.AttestationsSubmitter)(nil), s)
 	require.Implements(t, (*submitter.BeaconCommitteeSubscriptionsSubmitter)(nil), s)
 	require.Implements(t, (*submitter.AggregateAttestationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.BLSToExecutionChangesSubmitter)(nil), s)
 }
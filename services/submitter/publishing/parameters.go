@@ -0,0 +1,85 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package publishing is a submitter that wraps another submitter, publishing
+// duty events for the signed items that it submits.
+package publishing
+
+import (
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel   zerolog.Level
+	submitter  submitter.Service
+	dutyEvents dutyevents.Service
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithSubmitter sets the submitter that carries out submissions.
+func WithSubmitter(submitter submitter.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.submitter = submitter
+	})
+}
+
+// WithDutyEvents sets the duty events service to which events are published.
+func WithDutyEvents(dutyEvents dutyevents.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyEvents = dutyEvents
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.submitter == nil {
+		return nil, errors.New("no submitter specified")
+	}
+	if _, isSubmitter := parameters.submitter.(fullSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not support all submissions")
+	}
+	if parameters.dutyEvents == nil {
+		return nil, errors.New("no duty events specified")
+	}
+
+	return &parameters, nil
+}
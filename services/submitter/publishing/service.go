@@ -0,0 +1,228 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publishing
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyevents"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// fullSubmitter is a submitter for all signed items.
+type fullSubmitter interface {
+	submitter.AttestationsSubmitter
+	submitter.ProposalSubmitter
+	submitter.BeaconCommitteeSubscriptionsSubmitter
+	submitter.AggregateAttestationsSubmitter
+	submitter.ProposalPreparationsSubmitter
+	submitter.SyncCommitteeMessagesSubmitter
+	submitter.SyncCommitteeSubscriptionsSubmitter
+	submitter.SyncCommitteeContributionsSubmitter
+	submitter.BLSToExecutionChangesSubmitter
+}
+
+// Service is the submitter for signed items.
+type Service struct {
+	submitter  fullSubmitter
+	dutyEvents dutyevents.Service
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new submitter.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "submitter").Str("impl", "publishing").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		submitter:  parameters.submitter.(fullSubmitter),
+		dutyEvents: parameters.dutyEvents,
+	}
+
+	return s, nil
+}
+
+// SubmitProposal submits a proposal.
+func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSignedProposal) error {
+	validatorIndices := make(map[phase0.Slot][]phase0.ValidatorIndex)
+	if proposal != nil {
+		slot, err := proposal.Slot()
+		if err == nil {
+			validatorIndices[slot] = proposerIndices(proposal)
+		}
+	}
+
+	s.publish(ctx, dutyevents.DutyProposal, dutyevents.StageSigned, validatorIndices)
+	if err := s.submitter.SubmitProposal(ctx, proposal); err != nil {
+		return err
+	}
+	s.publish(ctx, dutyevents.DutyProposal, dutyevents.StageSubmitted, validatorIndices)
+
+	return nil
+}
+
+// SubmitAttestations submits multiple attestations.
+// Attestations do not identify their validators, so events only contain the slot.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	validatorIndices := make(map[phase0.Slot][]phase0.ValidatorIndex)
+	for _, attestation := range attestations {
+		if attestation == nil || attestation.Data == nil {
+			continue
+		}
+		validatorIndices[attestation.Data.Slot] = nil
+	}
+
+	s.publish(ctx, dutyevents.DutyAttestation, dutyevents.StageSigned, validatorIndices)
+	if err := s.submitter.SubmitAttestations(ctx, attestations); err != nil {
+		return err
+	}
+	s.publish(ctx, dutyevents.DutyAttestation, dutyevents.StageSubmitted, validatorIndices)
+
+	return nil
+}
+
+// SubmitBeaconCommitteeSubscriptions submits a batch of beacon committee subscriptions.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	return s.submitter.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitAggregateAttestations submits aggregate attestations.
+func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []*phase0.SignedAggregateAndProof) error {
+	validatorIndices := make(map[phase0.Slot][]phase0.ValidatorIndex)
+	for _, aggregate := range aggregates {
+		if aggregate == nil || aggregate.Message == nil || aggregate.Message.Aggregate == nil || aggregate.Message.Aggregate.Data == nil {
+			continue
+		}
+		slot := aggregate.Message.Aggregate.Data.Slot
+		validatorIndices[slot] = append(validatorIndices[slot], aggregate.Message.AggregatorIndex)
+	}
+
+	s.publish(ctx, dutyevents.DutyAttestationAggregation, dutyevents.StageSigned, validatorIndices)
+	if err := s.submitter.SubmitAggregateAttestations(ctx, aggregates); err != nil {
+		return err
+	}
+	s.publish(ctx, dutyevents.DutyAttestationAggregation, dutyevents.StageSubmitted, validatorIndices)
+
+	return nil
+}
+
+// SubmitProposalPreparations submits proposal preparations.
+func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	return s.submitter.SubmitProposalPreparations(ctx, preparations)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	validatorIndices := make(map[phase0.Slot][]phase0.ValidatorIndex)
+	for _, message := range messages {
+		if message == nil {
+			continue
+		}
+		validatorIndices[message.Slot] = append(validatorIndices[message.Slot], message.ValidatorIndex)
+	}
+
+	s.publish(ctx, dutyevents.DutySyncCommittee, dutyevents.StageSigned, validatorIndices)
+	if err := s.submitter.SubmitSyncCommitteeMessages(ctx, messages); err != nil {
+		return err
+	}
+	s.publish(ctx, dutyevents.DutySyncCommittee, dutyevents.StageSubmitted, validatorIndices)
+
+	return nil
+}
+
+// SubmitSyncCommitteeSubscriptions submits a batch of sync committee subscriptions.
+func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	return s.submitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	validatorIndices := make(map[phase0.Slot][]phase0.ValidatorIndex)
+	for _, contributionAndProof := range contributionAndProofs {
+		if contributionAndProof == nil || contributionAndProof.Message == nil || contributionAndProof.Message.Contribution == nil {
+			continue
+		}
+		slot := contributionAndProof.Message.Contribution.Slot
+		validatorIndices[slot] = append(validatorIndices[slot], contributionAndProof.Message.AggregatorIndex)
+	}
+
+	s.publish(ctx, dutyevents.DutySyncCommitteeAggregation, dutyevents.StageSigned, validatorIndices)
+	if err := s.submitter.SubmitSyncCommitteeContributions(ctx, contributionAndProofs); err != nil {
+		return err
+	}
+	s.publish(ctx, dutyevents.DutySyncCommitteeAggregation, dutyevents.StageSubmitted, validatorIndices)
+
+	return nil
+}
+
+// SubmitBLSToExecutionChanges submits BLS to execution changes.
+func (s *Service) SubmitBLSToExecutionChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	return s.submitter.SubmitBLSToExecutionChanges(ctx, changes)
+}
+
+// proposerIndices returns the proposer of a proposal, if available.
+func proposerIndices(proposal *api.VersionedSignedProposal) []phase0.ValidatorIndex {
+	switch {
+	case proposal.Phase0 != nil && proposal.Phase0.Message != nil:
+		return []phase0.ValidatorIndex{proposal.Phase0.Message.ProposerIndex}
+	case proposal.Altair != nil && proposal.Altair.Message != nil:
+		return []phase0.ValidatorIndex{proposal.Altair.Message.ProposerIndex}
+	case proposal.Bellatrix != nil && proposal.Bellatrix.Message != nil:
+		return []phase0.ValidatorIndex{proposal.Bellatrix.Message.ProposerIndex}
+	case proposal.Capella != nil && proposal.Capella.Message != nil:
+		return []phase0.ValidatorIndex{proposal.Capella.Message.ProposerIndex}
+	case proposal.Deneb != nil && proposal.Deneb.SignedBlock != nil && proposal.Deneb.SignedBlock.Message != nil:
+		return []phase0.ValidatorIndex{proposal.Deneb.SignedBlock.Message.ProposerIndex}
+	default:
+		return nil
+	}
+}
+
+// publish publishes an event for each slot.
+func (s *Service) publish(ctx context.Context,
+	duty string,
+	stage string,
+	validatorIndices map[phase0.Slot][]phase0.ValidatorIndex,
+) {
+	now := time.Now()
+	for slot, indices := range validatorIndices {
+		s.dutyEvents.Publish(ctx, &dutyevents.Event{
+			Duty:             duty,
+			Stage:            stage,
+			Slot:             slot,
+			ValidatorIndices: indices,
+			Timestamp:        now,
+		})
+	}
+	log.Trace().Str("duty", duty).Str("stage", stage).Int("slots", len(validatorIndices)).Msg("Published duty events")
+}
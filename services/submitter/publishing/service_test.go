@@ -0,0 +1,142 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publishing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/dutyevents"
+	mockdutyevents "github.com/attestantio/vouch/services/dutyevents/mock"
+	"github.com/attestantio/vouch/services/submitter"
+	nullsubmitter "github.com/attestantio/vouch/services/submitter/null"
+	"github.com/attestantio/vouch/services/submitter/publishing"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	nullSubmitter, err := nullsubmitter.New(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []publishing.Parameter
+		err    string
+	}{
+		{
+			name: "SubmitterMissing",
+			params: []publishing.Parameter{
+				publishing.WithLogLevel(zerolog.Disabled),
+				publishing.WithDutyEvents(mockdutyevents.New()),
+			},
+			err: "problem with parameters: no submitter specified",
+		},
+		{
+			name: "SubmitterIncomplete",
+			params: []publishing.Parameter{
+				publishing.WithLogLevel(zerolog.Disabled),
+				publishing.WithSubmitter(struct{}{}),
+				publishing.WithDutyEvents(mockdutyevents.New()),
+			},
+			err: "problem with parameters: submitter does not support all submissions",
+		},
+		{
+			name: "DutyEventsMissing",
+			params: []publishing.Parameter{
+				publishing.WithLogLevel(zerolog.Disabled),
+				publishing.WithSubmitter(nullSubmitter),
+			},
+			err: "problem with parameters: no duty events specified",
+		},
+		{
+			name: "Good",
+			params: []publishing.Parameter{
+				publishing.WithLogLevel(zerolog.Disabled),
+				publishing.WithSubmitter(nullSubmitter),
+				publishing.WithDutyEvents(mockdutyevents.New()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := publishing.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	ctx := context.Background()
+
+	nullSubmitter, err := nullsubmitter.New(ctx)
+	require.NoError(t, err)
+	dutyEvents := mockdutyevents.New()
+
+	s, err := publishing.New(ctx,
+		publishing.WithLogLevel(zerolog.Disabled),
+		publishing.WithSubmitter(nullSubmitter),
+		publishing.WithDutyEvents(dutyEvents),
+	)
+	require.NoError(t, err)
+
+	// Submissions without any items should not publish events.
+	require.Error(t, s.SubmitSyncCommitteeMessages(ctx, nil))
+	require.Empty(t, dutyEvents.Events())
+
+	require.NoError(t, s.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{
+		{
+			Slot:           10,
+			ValidatorIndex: 1,
+		},
+		{
+			Slot:           10,
+			ValidatorIndex: 2,
+		},
+	}))
+	events := dutyEvents.Events()
+	require.Len(t, events, 2)
+	require.Equal(t, dutyevents.DutySyncCommittee, events[0].Duty)
+	require.Equal(t, dutyevents.StageSigned, events[0].Stage)
+	require.Equal(t, phase0.Slot(10), events[0].Slot)
+	require.Equal(t, []phase0.ValidatorIndex{1, 2}, events[0].ValidatorIndices)
+	require.Equal(t, dutyevents.StageSubmitted, events[1].Stage)
+}
+
+func TestInterfaces(t *testing.T) {
+	s, err := publishing.New(context.Background(),
+		publishing.WithLogLevel(zerolog.Disabled),
+		publishing.WithSubmitter(&nullsubmitter.Service{}),
+		publishing.WithDutyEvents(mockdutyevents.New()),
+	)
+	require.NoError(t, err)
+	require.Implements(t, (*submitter.AttestationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.ProposalSubmitter)(nil), s)
+	require.Implements(t, (*submitter.BeaconCommitteeSubscriptionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.AggregateAttestationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.ProposalPreparationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.SyncCommitteeMessagesSubmitter)(nil), s)
+	require.Implements(t, (*submitter.SyncCommitteeSubscriptionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.SyncCommitteeContributionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.BLSToExecutionChangesSubmitter)(nil), s)
+}
@@ -0,0 +1,284 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// encoding is the encoding accepted by a beacon node for an endpoint.
+type encoding int
+
+const (
+	// encodingUnknown is used when no submission has yet shown the encoding accepted by the endpoint.
+	encodingUnknown encoding = iota
+	// encodingSSZ is used when the endpoint has accepted SSZ.
+	encodingSSZ
+	// encodingJSON is used when the endpoint has refused SSZ.
+	encodingJSON
+)
+
+// jsonSubmitter is the interface of the JSON client used for fallback submissions.
+type jsonSubmitter interface {
+	eth2client.AttestationsSubmitter
+	eth2client.ProposalSubmitter
+	eth2client.SyncCommitteeMessagesSubmitter
+	eth2client.ValidatorRegistrationsSubmitter
+}
+
+// Service submits items to a beacon node in SSZ, falling back to JSON for
+// endpoints that do not accept SSZ.
+type Service struct {
+	client       eth2client.Service
+	httpClient   *http.Client
+	base         *url.URL
+	extraHeaders map[string]string
+
+	encodingsMu sync.RWMutex
+	encodings   map[string]encoding
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new SSZ submission client.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "submitter").Str("impl", "ssz").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if _, isSubmitter := parameters.client.(jsonSubmitter); !isSubmitter {
+		return nil, errors.New("client does not support required submissions")
+	}
+
+	address := parameters.client.Address()
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+	base, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid client address")
+	}
+
+	s := &Service{
+		client:       parameters.client,
+		httpClient:   util.NewHTTPClient(parameters.timeout),
+		base:         base,
+		extraHeaders: parameters.extraHeaders,
+		encodings:    make(map[string]encoding),
+	}
+
+	return s, nil
+}
+
+// Name returns the name of the underlying client.
+func (s *Service) Name() string {
+	return s.client.Name()
+}
+
+// Address returns the address of the underlying client.
+func (s *Service) Address() string {
+	return s.client.Address()
+}
+
+// NodeVersion returns the version of the underlying beacon node.
+func (s *Service) NodeVersion(ctx context.Context, opts *api.NodeVersionOpts) (*api.Response[string], error) {
+	provider, isProvider := s.client.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide node version")
+	}
+
+	return provider.NodeVersion(ctx, opts)
+}
+
+// submit submits data in SSZ to the given endpoint, calling the JSON
+// submission function if the endpoint does not accept SSZ.
+func (s *Service) submit(ctx context.Context,
+	endpoint string,
+	data []byte,
+	headers map[string]string,
+	jsonSubmit func(context.Context) error,
+) error {
+	current := s.encoding(endpoint)
+	if current == encodingJSON {
+		return jsonSubmit(ctx)
+	}
+
+	err := s.post(ctx, endpoint, data, headers)
+	if err == nil {
+		if current == encodingUnknown {
+			log.Trace().Str("endpoint", endpoint).Msg("Endpoint accepts SSZ")
+			s.setEncoding(endpoint, encodingSSZ)
+		}
+
+		return nil
+	}
+
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusUnsupportedMediaType:
+		// The node explicitly refuses SSZ.
+		log.Debug().Str("endpoint", endpoint).Msg("Endpoint does not accept SSZ; using JSON")
+		s.setEncoding(endpoint, encodingJSON)
+
+		return jsonSubmit(ctx)
+	case apiErr.StatusCode == http.StatusBadRequest && current == encodingUnknown:
+		// Some nodes reject SSZ as a bad request; if JSON succeeds then
+		// assume that SSZ is not accepted.
+		if err := jsonSubmit(ctx); err != nil {
+			return err
+		}
+		log.Debug().Str("endpoint", endpoint).Msg("Endpoint rejected SSZ but accepted JSON; using JSON")
+		s.setEncoding(endpoint, encodingJSON)
+
+		return nil
+	default:
+		return err
+	}
+}
+
+// post sends SSZ data to the endpoint.
+func (s *Service) post(ctx context.Context,
+	endpoint string,
+	data []byte,
+	headers map[string]string,
+) error {
+	reqURL := s.base.JoinPath(endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create POST request")
+	}
+	for k, v := range s.extraHeaders {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	started := time.Now()
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call POST endpoint")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read POST response")
+	}
+
+	if resp.StatusCode/100 != 2 {
+		log.Trace().Str("endpoint", endpoint).Int("status_code", resp.StatusCode).Str("data", string(body)).Msg("SSZ POST failed")
+
+		return &api.Error{
+			Method:     http.MethodPost,
+			StatusCode: resp.StatusCode,
+			Endpoint:   endpoint,
+			Data:       body,
+		}
+	}
+	log.Trace().Str("endpoint", endpoint).Dur("elapsed", time.Since(started)).Msg("SSZ POST succeeded")
+
+	return nil
+}
+
+func (s *Service) encoding(endpoint string) encoding {
+	s.encodingsMu.RLock()
+	defer s.encodingsMu.RUnlock()
+
+	return s.encodings[endpoint]
+}
+
+func (s *Service) setEncoding(endpoint string, enc encoding) {
+	s.encodingsMu.Lock()
+	s.encodings[endpoint] = enc
+	s.encodingsMu.Unlock()
+}
+
+// jsonClient returns the underlying client for JSON submissions.
+func (s *Service) jsonClient() jsonSubmitter {
+	return s.client.(jsonSubmitter)
+}
+
+// sszMarshaler is the interface for items that can be marshalled to SSZ.
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+}
+
+// marshalFixedList marshals a list of fixed-size items to SSZ.
+func marshalFixedList[T sszMarshaler](items []T) ([]byte, error) {
+	data := make([]byte, 0)
+	for i := range items {
+		item, err := items[i].MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal item %d", i)
+		}
+		data = append(data, item...)
+	}
+
+	return data, nil
+}
+
+// marshalVariableList marshals a list of variable-size items to SSZ, with
+// each item located by a 4-byte little-endian offset.
+func marshalVariableList[T sszMarshaler](items []T) ([]byte, error) {
+	encoded := make([][]byte, len(items))
+	offset := 4 * len(items)
+	offsets := make([]byte, 0, offset)
+	for i := range items {
+		item, err := items[i].MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal item %d", i)
+		}
+		encoded[i] = item
+		offsets = append(offsets, byte(offset), byte(offset>>8), byte(offset>>16), byte(offset>>24))
+		offset += len(item)
+	}
+
+	data := make([]byte, 0, offset)
+	data = append(data, offsets...)
+	for i := range encoded {
+		data = append(data, encoded[i]...)
+	}
+
+	return data, nil
+}
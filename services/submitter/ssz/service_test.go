@@ -0,0 +1,144 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/submitter/ssz"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonClient is a client that records JSON submissions.
+type jsonClient struct {
+	address     string
+	submissions atomic.Int32
+}
+
+func (c *jsonClient) Name() string {
+	return "json"
+}
+
+func (c *jsonClient) Address() string {
+	return c.address
+}
+
+func (c *jsonClient) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+	c.submissions.Add(1)
+
+	return nil
+}
+
+func (c *jsonClient) SubmitProposal(_ context.Context, _ *api.VersionedSignedProposal) error {
+	c.submissions.Add(1)
+
+	return nil
+}
+
+func (c *jsonClient) SubmitSyncCommitteeMessages(_ context.Context, _ []*altair.SyncCommitteeMessage) error {
+	c.submissions.Add(1)
+
+	return nil
+}
+
+func (c *jsonClient) SubmitValidatorRegistrations(_ context.Context, _ []*api.VersionedSignedValidatorRegistration) error {
+	c.submissions.Add(1)
+
+	return nil
+}
+
+// nameOnlyClient is a client that cannot submit.
+type nameOnlyClient struct{}
+
+func (c *nameOnlyClient) Name() string {
+	return "name only"
+}
+
+func (c *nameOnlyClient) Address() string {
+	return "localhost:5052"
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []ssz.Parameter
+		err    string
+	}{
+		{
+			name: "ClientMissing",
+			params: []ssz.Parameter{
+				ssz.WithLogLevel(zerolog.Disabled),
+				ssz.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no client specified",
+		},
+		{
+			name: "ClientAddressMissing",
+			params: []ssz.Parameter{
+				ssz.WithLogLevel(zerolog.Disabled),
+				ssz.WithClient(&jsonClient{}),
+				ssz.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: client has no address",
+		},
+		{
+			name: "TimeoutMissing",
+			params: []ssz.Parameter{
+				ssz.WithLogLevel(zerolog.Disabled),
+				ssz.WithClient(&jsonClient{address: "localhost:5052"}),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "ClientNotSubmitter",
+			params: []ssz.Parameter{
+				ssz.WithLogLevel(zerolog.Disabled),
+				ssz.WithClient(&nameOnlyClient{}),
+				ssz.WithTimeout(time.Second),
+			},
+			err: "client does not support required submissions",
+		},
+		{
+			name: "Good",
+			params: []ssz.Parameter{
+				ssz.WithLogLevel(zerolog.Disabled),
+				ssz.WithClient(&jsonClient{address: "localhost:5052"}),
+				ssz.WithTimeout(time.Second),
+				ssz.WithExtraHeaders(map[string]string{"User-Agent": "test"}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := ssz.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "json", s.Name())
+				require.Equal(t, "localhost:5052", s.Address())
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/submitter/ssz"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// node is a test beacon node that responds to SSZ submissions with a fixed status.
+type node struct {
+	status   int
+	requests atomic.Int32
+	bodies   atomic.Int64
+}
+
+func (n *node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.requests.Add(1)
+	if r.Header.Get("Content-Type") != "application/octet-stream" {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+	n.bodies.Add(int64(len(body)))
+	w.WriteHeader(n.status)
+}
+
+func attestation() *phase0.Attestation {
+	return &phase0.Attestation{
+		AggregationBits: bitfield.NewBitlist(128),
+		Data: &phase0.AttestationData{
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+	}
+}
+
+func TestSubmitAttestations(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		status          int
+		submissions     int
+		sszRequests     int32
+		jsonSubmissions int32
+		err             string
+	}{
+		{
+			name:        "SSZAccepted",
+			status:      http.StatusOK,
+			submissions: 2,
+			sszRequests: 2,
+		},
+		{
+			name:            "UnsupportedMediaType",
+			status:          http.StatusUnsupportedMediaType,
+			submissions:     2,
+			sszRequests:     1,
+			jsonSubmissions: 2,
+		},
+		{
+			name:            "BadRequest",
+			status:          http.StatusBadRequest,
+			submissions:     2,
+			sszRequests:     1,
+			jsonSubmissions: 2,
+		},
+		{
+			name:        "ServerError",
+			status:      http.StatusInternalServerError,
+			submissions: 1,
+			sszRequests: 1,
+			err:         "POST failed with status 500",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &node{status: test.status}
+			srv := httptest.NewServer(n)
+			defer srv.Close()
+
+			client := &jsonClient{address: srv.URL}
+			s, err := ssz.New(ctx,
+				ssz.WithLogLevel(zerolog.Disabled),
+				ssz.WithClient(client),
+				ssz.WithTimeout(time.Second),
+			)
+			require.NoError(t, err)
+
+			for i := 0; i < test.submissions; i++ {
+				err = s.SubmitAttestations(ctx, []*phase0.Attestation{attestation(), attestation()})
+				if test.err != "" {
+					require.ErrorContains(t, err, test.err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+			require.Equal(t, test.sszRequests, n.requests.Load())
+			require.Equal(t, test.jsonSubmissions, client.submissions.Load())
+		})
+	}
+}
+
+func TestSubmitAttestationsEncoding(t *testing.T) {
+	ctx := context.Background()
+
+	n := &node{status: http.StatusOK}
+	srv := httptest.NewServer(n)
+	defer srv.Close()
+
+	s, err := ssz.New(ctx,
+		ssz.WithLogLevel(zerolog.Disabled),
+		ssz.WithClient(&jsonClient{address: srv.URL}),
+		ssz.WithTimeout(time.Second),
+	)
+	require.NoError(t, err)
+
+	attestations := []*phase0.Attestation{attestation(), attestation()}
+	require.NoError(t, s.SubmitAttestations(ctx, attestations))
+
+	// Two offsets followed by the two attestations.
+	expected := int64(8)
+	for i := range attestations {
+		expected += int64(attestations[i].SizeSSZ())
+	}
+	require.Equal(t, expected, n.bodies.Load())
+}
+
+func TestSubmitOthers(t *testing.T) {
+	ctx := context.Background()
+
+	n := &node{status: http.StatusOK}
+	srv := httptest.NewServer(n)
+	defer srv.Close()
+
+	client := &jsonClient{address: srv.URL}
+	s, err := ssz.New(ctx,
+		ssz.WithLogLevel(zerolog.Disabled),
+		ssz.WithClient(client),
+		ssz.WithTimeout(time.Second),
+	)
+	require.NoError(t, err)
+
+	require.EqualError(t, s.SubmitAttestations(ctx, nil), "no attestations supplied")
+	require.EqualError(t, s.SubmitProposal(ctx, nil), "no proposal supplied")
+	require.EqualError(t, s.SubmitSyncCommitteeMessages(ctx, nil), "no sync committee messages supplied")
+	require.EqualError(t, s.SubmitValidatorRegistrations(ctx, nil), "no registrations supplied")
+
+	require.NoError(t, s.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{{}}))
+	require.NoError(t, s.SubmitValidatorRegistrations(ctx, []*api.VersionedSignedValidatorRegistration{
+		{
+			Version: spec.BuilderVersionV1,
+			V1: &apiv1.SignedValidatorRegistration{
+				Message: &apiv1.ValidatorRegistration{
+					Timestamp: time.Unix(1700000000, 0),
+				},
+			},
+		},
+	}))
+	require.NoError(t, s.SubmitProposal(ctx, &api.VersionedSignedProposal{
+		Version: spec.DataVersionBellatrix,
+		Bellatrix: &bellatrix.SignedBeaconBlock{
+			Message: &bellatrix.BeaconBlock{
+				Body: &bellatrix.BeaconBlockBody{
+					ETH1Data:         &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+					SyncAggregate:    &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+					ExecutionPayload: &bellatrix.ExecutionPayload{},
+				},
+			},
+		},
+	}))
+	require.Equal(t, int32(3), n.requests.Load())
+	require.Equal(t, int32(0), client.submissions.Load())
+}
@@ -0,0 +1,37 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SubmitAttestations submits attestations.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	if len(attestations) == 0 {
+		return errors.New("no attestations supplied")
+	}
+
+	data, err := marshalVariableList(attestations)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal attestations")
+	}
+
+	return s.submit(ctx, "/eth/v1/beacon/pool/attestations", data, nil, func(ctx context.Context) error {
+		return s.jsonClient().SubmitAttestations(ctx, attestations)
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// SubmitProposal submits a proposal.
+func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSignedProposal) error {
+	if proposal == nil {
+		return errors.New("no proposal supplied")
+	}
+
+	var data []byte
+	var err error
+	switch proposal.Version {
+	case spec.DataVersionPhase0:
+		if proposal.Phase0 == nil {
+			return errors.New("no phase0 proposal supplied")
+		}
+		data, err = proposal.Phase0.MarshalSSZ()
+	case spec.DataVersionAltair:
+		if proposal.Altair == nil {
+			return errors.New("no altair proposal supplied")
+		}
+		data, err = proposal.Altair.MarshalSSZ()
+	case spec.DataVersionBellatrix:
+		if proposal.Bellatrix == nil {
+			return errors.New("no bellatrix proposal supplied")
+		}
+		data, err = proposal.Bellatrix.MarshalSSZ()
+	case spec.DataVersionCapella:
+		if proposal.Capella == nil {
+			return errors.New("no capella proposal supplied")
+		}
+		data, err = proposal.Capella.MarshalSSZ()
+	case spec.DataVersionDeneb:
+		if proposal.Deneb == nil {
+			return errors.New("no deneb proposal supplied")
+		}
+		data, err = proposal.Deneb.MarshalSSZ()
+	default:
+		return errors.New("unhandled proposal version")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal proposal")
+	}
+
+	headers := map[string]string{
+		"Eth-Consensus-Version": proposal.Version.String(),
+	}
+
+	return s.submit(ctx, "/eth/v1/beacon/blocks", data, headers, func(ctx context.Context) error {
+		return s.jsonClient().SubmitProposal(ctx, proposal)
+	})
+}
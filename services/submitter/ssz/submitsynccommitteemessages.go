@@ -0,0 +1,37 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/pkg/errors"
+)
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	if len(messages) == 0 {
+		return errors.New("no sync committee messages supplied")
+	}
+
+	data, err := marshalFixedList(messages)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sync committee messages")
+	}
+
+	return s.submit(ctx, "/eth/v1/beacon/pool/sync_committees", data, nil, func(ctx context.Context) error {
+		return s.jsonClient().SubmitSyncCommitteeMessages(ctx, messages)
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssz
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// SubmitValidatorRegistrations submits validator registrations.
+func (s *Service) SubmitValidatorRegistrations(ctx context.Context, registrations []*api.VersionedSignedValidatorRegistration) error {
+	if len(registrations) == 0 {
+		return errors.New("no registrations supplied")
+	}
+
+	unversioned := make([]*apiv1.SignedValidatorRegistration, 0, len(registrations))
+	for i := range registrations {
+		if registrations[i] == nil {
+			return errors.New("nil registration supplied")
+		}
+		if registrations[i].Version != spec.BuilderVersionV1 {
+			return errors.New("unknown validator registration version")
+		}
+		unversioned = append(unversioned, registrations[i].V1)
+	}
+
+	data, err := marshalFixedList(unversioned)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal validator registrations")
+	}
+
+	return s.submit(ctx, "/eth/v1/validator/register_validator", data, nil, func(ctx context.Context) error {
+		return s.jsonClient().SubmitValidatorRegistrations(ctx, registrations)
+	})
+}
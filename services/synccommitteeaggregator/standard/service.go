@@ -27,6 +27,7 @@ import (
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/signer"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -163,6 +164,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to obtain beacon block root")
 			s.monitor.SyncCommitteeAggregationsCompleted(started, duty.Slot, len(duty.ValidatorIndices), "failed")
+			util.MonitorError(s.monitor, "synccommitteeaggregator", util.ProviderError(err))
 			return
 		}
 		beaconBlockRoot = beaconBlockRootResponse.Data
@@ -181,6 +183,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 			if err != nil {
 				log.Warn().Err(err).Msg("Failed to obtain sync committee contribution")
 				s.monitor.SyncCommitteeAggregationsCompleted(started, duty.Slot, len(duty.ValidatorIndices), "failed")
+				util.MonitorError(s.monitor, "synccommitteeaggregator", util.ProviderError(err))
 				return
 			}
 			contribution := contributionResponse.Data
@@ -199,6 +202,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 			if err != nil {
 				log.Warn().Err(err).Msg("Failed to obtain signature of contribution and proof")
 				s.monitor.SyncCommitteeAggregationsCompleted(started, duty.Slot, len(duty.ValidatorIndices), "failed")
+				util.MonitorError(s.monitor, "synccommitteeaggregator", util.CategoriseError(util.ErrorCategorySignerUnavailable, err))
 				return
 			}
 
@@ -214,6 +218,7 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	if err := s.syncCommitteeContributionsSubmitter.SubmitSyncCommitteeContributions(ctx, signedContributionAndProofs); err != nil {
 		log.Warn().Err(err).Msg("Failed to submit signed contribution and proofs")
 		s.monitor.SyncCommitteeAggregationsCompleted(started, duty.Slot, len(signedContributionAndProofs), "failed")
+		util.MonitorError(s.monitor, "synccommitteeaggregator", util.CategoriseError(util.ErrorCategorySubmissionRejected, err))
 		return
 	}
 
@@ -32,6 +32,7 @@ import (
 	"github.com/attestantio/vouch/services/submitter"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -164,18 +165,24 @@ func (s *Service) Message(ctx context.Context, data interface{}) ([]*altair.Sync
 	}
 
 	// Fetch the beacon block root.
-	beaconBlockRootResponse, err := s.beaconBlockRootProvider.BeaconBlockRoot(ctx, &api.BeaconBlockRootOpts{
+	fetchCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseFetch)
+	beaconBlockRootResponse, err := s.beaconBlockRootProvider.BeaconBlockRoot(fetchCtx, &api.BeaconBlockRootOpts{
 		Block: "head",
 	})
+	cancel()
 	if err != nil {
 		s.monitor.SyncCommitteeMessagesCompleted(started, duty.Slot(), len(duty.ValidatorIndices()), "failed")
-		return nil, errors.Wrap(err, "failed to obtain beacon block root")
+		err = util.ProviderError(errors.Wrap(err, "failed to obtain beacon block root"))
+		util.MonitorError(s.monitor, "synccommitteemessenger", err)
+		return nil, err
 	}
 	beaconBlockRoot := beaconBlockRootResponse.Data
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained beacon block root")
 	s.syncCommitteeAggregator.SetBeaconBlockRoot(duty.Slot(), *beaconBlockRoot)
 
 	// Sign in parallel.
+	signCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSign)
+	defer cancel()
 	msgs := make([]*altair.SyncCommitteeMessage, 0, len(duty.ContributionIndices()))
 	var msgsMu sync.Mutex
 	validatorIndices := make([]phase0.ValidatorIndex, 0, len(duty.ContributionIndices()))
@@ -198,6 +205,7 @@ func (s *Service) Message(ctx context.Context, data interface{}) ([]*altair.Sync
 			sig, err := s.contribute(ctx, account, s.chainTimeService.SlotToEpoch(duty.Slot()+1), *beaconBlockRoot)
 			if err != nil {
 				log.Error().Err(err).Msg("Failed to sign sync committee message")
+				util.MonitorError(s.monitor, "synccommitteemessenger", util.CategoriseError(util.ErrorCategorySignerUnavailable, err))
 				return
 			}
 			log.Trace().Uint64("slot", uint64(duty.Slot())).Uint64("validator_index", uint64(validatorIndices[i])).Str("signature", fmt.Sprintf("%#x", sig)).Msg("Signed sync committee message")
@@ -211,14 +219,18 @@ func (s *Service) Message(ctx context.Context, data interface{}) ([]*altair.Sync
 			msgsMu.Lock()
 			msgs = append(msgs, msg)
 			msgsMu.Unlock()
-		}(ctx, &wg, i)
+		}(signCtx, &wg, i)
 	}
 	wg.Wait()
 
-	if err := s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, msgs); err != nil {
+	submitCtx, cancel := util.PhaseContext(ctx, util.DutyPhaseSubmit)
+	defer cancel()
+	if err := s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(submitCtx, msgs); err != nil {
 		log.Trace().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to submit sync committee messages")
 		s.monitor.SyncCommitteeMessagesCompleted(started, duty.Slot(), len(msgs), "failed")
-		return nil, errors.Wrap(err, "failed to submit sync committee messages")
+		err = util.CategoriseError(util.ErrorCategorySubmissionRejected, errors.Wrap(err, "failed to submit sync committee messages"))
+		util.MonitorError(s.monitor, "synccommitteemessenger", err)
+		return nil, err
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted sync committee messages")
 	s.monitor.SyncCommitteeMessagesCompleted(started, duty.Slot(), len(msgs), "succeeded")
@@ -0,0 +1,36 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package voluntaryexit
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service is the voluntary exit service.
+type Service interface {
+	// SignExits generates and signs voluntary exits for the validators with the
+	// given public keys, valid from the given epoch.
+	SignExits(ctx context.Context,
+		epoch phase0.Epoch,
+		pubKeys []phase0.BLSPubKey,
+	) (
+		map[phase0.BLSPubKey]*phase0.SignedVoluntaryExit,
+		error,
+	)
+
+	// SubmitExits broadcasts signed voluntary exits.
+	SubmitExits(ctx context.Context, exits []*phase0.SignedVoluntaryExit) error
+}
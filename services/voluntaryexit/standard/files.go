@@ -0,0 +1,78 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// encryptedExit is a signed voluntary exit encrypted with a passphrase.
+type encryptedExit struct {
+	PubKey string         `json:"pubkey"`
+	Crypto map[string]any `json:"crypto"`
+}
+
+// MarshalExit marshals a signed voluntary exit for storage.  If a passphrase
+// is supplied the exit is encrypted with it, otherwise the exit is stored in
+// its standard JSON form.
+func MarshalExit(pubKey phase0.BLSPubKey, exit *phase0.SignedVoluntaryExit, passphrase string) ([]byte, error) {
+	data, err := json.Marshal(exit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal exit")
+	}
+	if passphrase == "" {
+		return data, nil
+	}
+
+	crypto, err := keystorev4.New().Encrypt(data, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt exit")
+	}
+
+	return json.Marshal(&encryptedExit{
+		PubKey: fmt.Sprintf("%#x", pubKey),
+		Crypto: crypto,
+	})
+}
+
+// UnmarshalExit unmarshals a signed voluntary exit from storage, decrypting
+// it with the passphrase if it is encrypted.
+func UnmarshalExit(data []byte, passphrase string) (*phase0.SignedVoluntaryExit, error) {
+	var encrypted encryptedExit
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, errors.Wrap(err, "invalid exit")
+	}
+	if encrypted.Crypto != nil {
+		if passphrase == "" {
+			return nil, errors.New("exit is encrypted but no passphrase supplied")
+		}
+		var err error
+		data, err = keystorev4.New().Decrypt(encrypted.Crypto, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt exit")
+		}
+	}
+
+	exit := &phase0.SignedVoluntaryExit{}
+	if err := json.Unmarshal(data, exit); err != nil {
+		return nil, errors.Wrap(err, "invalid exit")
+	}
+
+	return exit, nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/voluntaryexit/standard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitFiles(t *testing.T) {
+	exit := &phase0.SignedVoluntaryExit{
+		Message: &phase0.VoluntaryExit{
+			Epoch:          100,
+			ValidatorIndex: 12345,
+		},
+		Signature: phase0.BLSSignature{0x01, 0x02},
+	}
+	pubKey := phase0.BLSPubKey{0x03}
+
+	// Plain.
+	data, err := standard.MarshalExit(pubKey, exit, "")
+	require.NoError(t, err)
+	res, err := standard.UnmarshalExit(data, "")
+	require.NoError(t, err)
+	require.Equal(t, exit, res)
+
+	// Encrypted.
+	data, err = standard.MarshalExit(pubKey, exit, "secret")
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "12345")
+	_, err = standard.UnmarshalExit(data, "")
+	require.EqualError(t, err, "exit is encrypted but no passphrase supplied")
+	_, err = standard.UnmarshalExit(data, "wrong")
+	require.Error(t, err)
+	res, err = standard.UnmarshalExit(data, "secret")
+	require.NoError(t, err)
+	require.Equal(t, exit, res)
+}
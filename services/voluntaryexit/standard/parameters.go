@@ -0,0 +1,98 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"errors"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel               zerolog.Level
+	accountsProvider       accountmanager.AccountsProvider
+	validatorsProvider     eth2client.ValidatorsProvider
+	voluntaryExitSigner    signer.VoluntaryExitSigner
+	voluntaryExitSubmitter eth2client.VoluntaryExitSubmitter
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAccountsProvider sets the accounts provider.
+// This is only required to sign exits.
+func WithAccountsProvider(provider accountmanager.AccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.accountsProvider = provider
+	})
+}
+
+// WithValidatorsProvider sets the validators provider.
+func WithValidatorsProvider(provider eth2client.ValidatorsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorsProvider = provider
+	})
+}
+
+// WithVoluntaryExitSigner sets the voluntary exit signer.
+// This is only required to sign exits.
+func WithVoluntaryExitSigner(signer signer.VoluntaryExitSigner) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.voluntaryExitSigner = signer
+	})
+}
+
+// WithVoluntaryExitSubmitter sets the voluntary exit submitter.
+func WithVoluntaryExitSubmitter(submitter eth2client.VoluntaryExitSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.voluntaryExitSubmitter = submitter
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		p.apply(&parameters)
+	}
+
+	if parameters.validatorsProvider == nil {
+		return nil, errors.New("no validators provider specified")
+	}
+	if parameters.voluntaryExitSubmitter == nil {
+		return nil, errors.New("no voluntary exit submitter specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,151 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// farFutureEpoch is the exit epoch of validators that have not exited.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// Service generates, signs and broadcasts voluntary exits.
+type Service struct {
+	accountsProvider       accountmanager.AccountsProvider
+	validatorsProvider     eth2client.ValidatorsProvider
+	voluntaryExitSigner    signer.VoluntaryExitSigner
+	voluntaryExitSubmitter eth2client.VoluntaryExitSubmitter
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new voluntary exit service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "voluntaryexit").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		accountsProvider:       parameters.accountsProvider,
+		validatorsProvider:     parameters.validatorsProvider,
+		voluntaryExitSigner:    parameters.voluntaryExitSigner,
+		voluntaryExitSubmitter: parameters.voluntaryExitSubmitter,
+	}
+
+	return s, nil
+}
+
+// SignExits generates and signs voluntary exits for the validators with the
+// given public keys, valid from the given epoch.
+func (s *Service) SignExits(ctx context.Context,
+	epoch phase0.Epoch,
+	pubKeys []phase0.BLSPubKey,
+) (
+	map[phase0.BLSPubKey]*phase0.SignedVoluntaryExit,
+	error,
+) {
+	if s.accountsProvider == nil || s.voluntaryExitSigner == nil {
+		return nil, errors.New("signing not configured; cannot sign exits")
+	}
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no validators specified")
+	}
+
+	validatorsResponse, err := s.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		PubKeys: pubKeys,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	indices := make(map[phase0.BLSPubKey]phase0.ValidatorIndex, len(validatorsResponse.Data))
+	for index, validator := range validatorsResponse.Data {
+		if validator.Validator.ExitEpoch != farFutureEpoch {
+			return nil, fmt.Errorf("validator %#x is already exiting", validator.Validator.PublicKey)
+		}
+		indices[validator.Validator.PublicKey] = index
+	}
+	unknown := make([]string, 0)
+	for _, pubKey := range pubKeys {
+		if _, exists := indices[pubKey]; !exists {
+			unknown = append(unknown, fmt.Sprintf("%#x", pubKey))
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("validators not known to the beacon node: %s", strings.Join(unknown, ", "))
+	}
+
+	exits := make(map[phase0.BLSPubKey]*phase0.SignedVoluntaryExit, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		account, err := s.accountsProvider.AccountByPublicKey(ctx, pubKey)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain account for validator %#x", pubKey))
+		}
+		exit := &phase0.VoluntaryExit{
+			Epoch:          epoch,
+			ValidatorIndex: indices[pubKey],
+		}
+		sig, err := s.voluntaryExitSigner.SignVoluntaryExit(ctx, account, exit)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to sign exit for validator %#x", pubKey))
+		}
+		exits[pubKey] = &phase0.SignedVoluntaryExit{
+			Message:   exit,
+			Signature: sig,
+		}
+		log.Trace().Str("pubkey", fmt.Sprintf("%#x", pubKey)).Uint64("epoch", uint64(epoch)).Msg("Signed voluntary exit")
+	}
+
+	return exits, nil
+}
+
+// SubmitExits broadcasts signed voluntary exits.
+// All exits are submitted, even if earlier submissions fail.
+func (s *Service) SubmitExits(ctx context.Context, exits []*phase0.SignedVoluntaryExit) error {
+	failed := 0
+	for _, exit := range exits {
+		if err := s.voluntaryExitSubmitter.SubmitVoluntaryExit(ctx, exit); err != nil {
+			log.Warn().Uint64("validator_index", uint64(exit.Message.ValidatorIndex)).Err(err).Msg("Failed to submit voluntary exit")
+			failed++
+			continue
+		}
+		log.Info().Uint64("validator_index", uint64(exit.Message.ValidatorIndex)).Uint64("epoch", uint64(exit.Message.Epoch)).Msg("Submitted voluntary exit")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to submit %d of %d voluntary exits", failed, len(exits))
+	}
+
+	return nil
+}
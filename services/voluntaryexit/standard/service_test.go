@@ -0,0 +1,135 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+
+	mockconsensusclient "github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/attestantio/vouch/services/voluntaryexit/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "ValidatorsProviderMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithVoluntaryExitSubmitter(consensusClient),
+			},
+			err: "problem with parameters: no validators provider specified",
+		},
+		{
+			name: "VoluntaryExitSubmitterMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithValidatorsProvider(consensusClient),
+			},
+			err: "problem with parameters: no voluntary exit submitter specified",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithAccountsProvider(mockaccountmanager.NewAccountsProvider()),
+				standard.WithValidatorsProvider(consensusClient),
+				standard.WithVoluntaryExitSigner(mocksigner.New()),
+				standard.WithVoluntaryExitSubmitter(consensusClient),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignExits(t *testing.T) {
+	ctx := context.Background()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		params  []standard.Parameter
+		pubKeys []phase0.BLSPubKey
+		err     string
+	}{
+		{
+			name: "SigningNotConfigured",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithValidatorsProvider(consensusClient),
+				standard.WithVoluntaryExitSubmitter(consensusClient),
+			},
+			pubKeys: []phase0.BLSPubKey{{0x01}},
+			err:     "signing not configured; cannot sign exits",
+		},
+		{
+			name: "NoValidators",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithAccountsProvider(mockaccountmanager.NewAccountsProvider()),
+				standard.WithValidatorsProvider(consensusClient),
+				standard.WithVoluntaryExitSigner(mocksigner.New()),
+				standard.WithVoluntaryExitSubmitter(consensusClient),
+			},
+			err: "no validators specified",
+		},
+		{
+			name: "UnknownValidator",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithAccountsProvider(mockaccountmanager.NewAccountsProvider()),
+				standard.WithValidatorsProvider(consensusClient),
+				standard.WithVoluntaryExitSigner(mocksigner.New()),
+				standard.WithVoluntaryExitSubmitter(consensusClient),
+			},
+			pubKeys: []phase0.BLSPubKey{{0x01}},
+			err:     "validators not known to the beacon node: 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := standard.New(ctx, test.params...)
+			require.NoError(t, err)
+			_, err = s.SignExits(ctx, 100, test.pubKeys)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/attestantio/vouch/services/slashingprotection"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// slashingProtectionCommand imports or exports local slashing protection data
+// in the EIP-3076 interchange format.
+func slashingProtectionCommand(ctx context.Context) bool {
+	// Force disable metrics.
+	viper.Set("metrics.prometheus.listen-address", "")
+	viper.Set("metrics.prometheus.push.url", "")
+	consensusClient, _, _, monitor, err := startBasicServices(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start basic services: %v\n", err)
+		return true
+	}
+
+	slashingProtection, err := newSlashingProtection(ctx, monitor, consensusClient, viper.GetString("slashingprotection.path"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start slashing protection: %v\n", err)
+		return true
+	}
+
+	switch pflag.Arg(1) {
+	case "export":
+		err = exportSlashingProtection(ctx, slashingProtection, pflag.Arg(2))
+	case "import":
+		err = importSlashingProtection(ctx, slashingProtection, pflag.Arg(2))
+	default:
+		err = fmt.Errorf("unknown command %q; must be one of import or export", pflag.Arg(1))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Slashing protection %s failed: %v\n", pflag.Arg(1), err)
+	}
+
+	return true
+}
+
+// exportSlashingProtection writes the slashing protection data to the given
+// path, or to standard output if no path is supplied.
+func exportSlashingProtection(ctx context.Context,
+	provider slashingprotection.InterchangeProvider,
+	path string,
+) error {
+	interchange, err := provider.ExportInterchange(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain slashing protection data")
+	}
+	data, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal slashing protection data")
+	}
+	data = append(data, '\n')
+
+	if path == "" || path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported slashing protection data for %d validators\n", len(interchange.Data))
+
+	return nil
+}
+
+// importSlashingProtection reads slashing protection data from the given path,
+// or from standard input if no path is supplied.
+func importSlashingProtection(ctx context.Context,
+	provider slashingprotection.InterchangeProvider,
+	path string,
+) error {
+	var data []byte
+	var err error
+	if path == "" || path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read slashing protection data")
+	}
+
+	interchange := &slashingprotection.Interchange{}
+	if err := json.Unmarshal(data, interchange); err != nil {
+		return errors.Wrap(err, "failed to parse slashing protection data")
+	}
+	if err := provider.ImportInterchange(ctx, interchange); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported slashing protection data for %d validators\n", len(interchange.Data))
+
+	return nil
+}
@@ -1,4 +1,4 @@
-// Copyright © 2020, 2022 Attestant Limited.
+// Copyright © 2020, 2022, 2024 Attestant Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -14,12 +14,16 @@
 package best
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attestationaggregator"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -57,15 +61,20 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
 	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// Both are reduced if required to fit within the deadline of the duty.
+	timeout := util.BudgetedTimeout(ctx, s.timeout)
+	// Signature verification takes place after the requests, so uses the parent context.
+	verifyCtx := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, timeout/2)
 
-	requests := len(s.aggregateAttestationProviders)
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.aggregateAttestationProviders)
+	requests := len(providers)
 
 	respCh := make(chan *aggregateAttestationResponse, requests)
 	errCh := make(chan *aggregateAttestationError, requests)
 	// Kick off the requests.
-	for name, provider := range s.aggregateAttestationProviders {
+	for name, provider := range providers {
 		go s.aggregateAttestation(ctx, started, name, provider, respCh, errCh, opts)
 	}
 
@@ -74,9 +83,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 	errored := 0
 	timedOut := 0
 	softTimedOut := 0
-	bestScore := float64(0)
-	var bestAggregateAttestation *phase0.Attestation
-	var bestProvider string
+	responses := make([]*aggregateAttestationResponse, 0, requests)
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
@@ -90,11 +97,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
-			if bestAggregateAttestation == nil || resp.score > bestScore {
-				bestAggregateAttestation = resp.aggregate
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 		case err := <-errCh:
 			errored++
 			log.Debug().
@@ -139,11 +142,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
-			if bestAggregateAttestation == nil || resp.score > bestScore {
-				bestAggregateAttestation = resp.aggregate
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 		case err := <-errCh:
 			errored++
 			log.Debug().
@@ -173,6 +172,18 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 		Int("timed_out", timedOut).
 		Msg("Results")
 
+	// Verify the signatures of all aggregate attestations together before selecting the best.
+	bestScore := float64(0)
+	var bestAggregateAttestation *phase0.Attestation
+	var bestProvider string
+	for _, resp := range s.verifyAggregateSignatures(verifyCtx, responses) {
+		if bestAggregateAttestation == nil || resp.score > bestScore {
+			bestAggregateAttestation = resp.aggregate
+			bestScore = resp.score
+			bestProvider = resp.provider
+		}
+	}
+
 	if bestAggregateAttestation == nil {
 		return nil, errors.New("no aggregate attestations received")
 	}
@@ -222,6 +233,14 @@ func (s *Service) aggregateAttestation(ctx context.Context,
 		}
 		return
 	}
+	if err := verifyAggregateAttestation(ctx, opts, aggregateAttestation); err != nil {
+		log.Warn().Str("provider", name).Err(err).Msg("Provider returned invalid aggregate attestation")
+		errCh <- &aggregateAttestationError{
+			provider: name,
+			err:      err,
+		}
+		return
+	}
 
 	score := s.scoreAggregateAttestation(ctx, name, aggregateAttestation)
 	respCh <- &aggregateAttestationResponse{
@@ -230,3 +249,32 @@ func (s *Service) aggregateAttestation(ctx context.Context,
 		score:     score,
 	}
 }
+
+// verifyAggregateAttestation ensures that the aggregate attestation returned by a
+// provider is for the requested attestation data and, if known, contains the
+// attestation of our aggregating validator.
+func verifyAggregateAttestation(ctx context.Context,
+	opts *api.AggregateAttestationOpts,
+	aggregateAttestation *phase0.Attestation,
+) error {
+	if aggregateAttestation.Data == nil {
+		return errors.New("aggregate attestation data nil")
+	}
+	root, err := aggregateAttestation.Data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain hash tree root of aggregate attestation data")
+	}
+	if !bytes.Equal(root[:], opts.AttestationDataRoot[:]) {
+		return fmt.Errorf("aggregate attestation data root %#x does not match requested root %#x", root, opts.AttestationDataRoot)
+	}
+
+	validatorCommitteeIndex, exists := attestationaggregator.ValidatorCommitteeIndexFromContext(ctx)
+	if exists {
+		if validatorCommitteeIndex >= aggregateAttestation.AggregationBits.Len() ||
+			!aggregateAttestation.AggregationBits.BitAt(validatorCommitteeIndex) {
+			return fmt.Errorf("aggregate attestation does not contain attestation for validator committee index %d", validatorCommitteeIndex)
+		}
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/attestationaggregator"
 	"github.com/attestantio/vouch/strategies/aggregateattestation/best"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
@@ -34,6 +35,7 @@ func TestAggregateAttestation(t *testing.T) {
 		params              []best.Parameter
 		slot                phase0.Slot
 		attestationDataRoot phase0.Root
+		ctx                 context.Context
 		err                 string
 		logEntries          []string
 	}{
@@ -48,8 +50,8 @@ func TestAggregateAttestation(t *testing.T) {
 			},
 			slot: 12345,
 			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
 			},
 		},
 		{
@@ -63,8 +65,8 @@ func TestAggregateAttestation(t *testing.T) {
 			},
 			slot: 12345,
 			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
 			},
 			err: "no aggregate attestations received",
 		},
@@ -80,8 +82,8 @@ func TestAggregateAttestation(t *testing.T) {
 			},
 			slot: 12345,
 			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
 			},
 		},
 		{
@@ -96,8 +98,8 @@ func TestAggregateAttestation(t *testing.T) {
 			},
 			slot: 12345,
 			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
 			},
 			logEntries: []string{"Soft timeout reached with responses"},
 		},
@@ -112,8 +114,8 @@ func TestAggregateAttestation(t *testing.T) {
 			},
 			slot: 12345,
 			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
 			},
 			logEntries: []string{"Soft timeout reached with no responses"},
 		},
@@ -128,11 +130,62 @@ func TestAggregateAttestation(t *testing.T) {
 				}),
 			},
 			slot: 12345,
+			attestationDataRoot: phase0.Root{
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
+			},
+			logEntries: []string{"Soft timeout reached with no responses"},
+		},
+		{
+			name: "DataRootMismatch",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good": mock.NewAggregateAttestationProvider(),
+				}),
+			},
+			slot: 12345,
 			attestationDataRoot: phase0.Root{
 				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
 				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
 			},
-			logEntries: []string{"Soft timeout reached with no responses"},
+			err:        "no aggregate attestations received",
+			logEntries: []string{"Provider returned invalid aggregate attestation"},
+		},
+		{
+			name: "ValidatorPresent",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good": mock.NewAggregateAttestationProvider(),
+				}),
+			},
+			slot: 12345,
+			attestationDataRoot: phase0.Root{
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
+			},
+			ctx: attestationaggregator.ContextWithValidatorCommitteeIndex(context.Background(), 3),
+		},
+		{
+			name: "ValidatorMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good": mock.NewAggregateAttestationProvider(),
+				}),
+			},
+			slot: 12345,
+			attestationDataRoot: phase0.Root{
+				0xf0, 0xd7, 0x72, 0x73, 0x9b, 0x56, 0x3e, 0x02, 0x14, 0xcc, 0xb4, 0x2a, 0x07, 0xd8, 0xa1, 0x47,
+				0xf0, 0x00, 0xaa, 0x68, 0x6d, 0x90, 0xfd, 0x51, 0x18, 0x64, 0x3c, 0x50, 0x4c, 0xb1, 0xeb, 0x85,
+			},
+			ctx:        attestationaggregator.ContextWithValidatorCommitteeIndex(context.Background(), 2),
+			err:        "no aggregate attestations received",
+			logEntries: []string{"Provider returned invalid aggregate attestation"},
 		},
 	}
 
@@ -141,7 +194,11 @@ func TestAggregateAttestation(t *testing.T) {
 			capture := logger.NewLogCapture()
 			s, err := best.New(context.Background(), test.params...)
 			require.NoError(t, err)
-			aggregate, err := s.AggregateAttestation(context.Background(), &api.AggregateAttestationOpts{
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			aggregate, err := s.AggregateAttestation(ctx, &api.AggregateAttestationOpts{
 				Slot:                test.slot,
 				AttestationDataRoot: test.attestationDataRoot,
 			})
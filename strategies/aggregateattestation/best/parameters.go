@@ -23,6 +23,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -32,7 +33,13 @@ type parameters struct {
 	clientMonitor                 metrics.ClientMonitor
 	processConcurrency            int64
 	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
+	nodeMonitor                   nodemonitor.Service
 	timeout                       time.Duration
+	verifySignatures              bool
+	specProvider                  eth2client.SpecProvider
+	domainProvider                eth2client.DomainProvider
+	beaconCommitteesProvider      eth2client.BeaconCommitteesProvider
+	validatorsProvider            eth2client.ValidatorsProvider
 }
 
 // Parameter is the interface for service parameters.
@@ -81,6 +88,48 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithVerifySignatures sets whether to verify the signatures of aggregate attestations.
+func WithVerifySignatures(verify bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verifySignatures = verify
+	})
+}
+
+// WithSpecProvider sets the spec provider.
+func WithSpecProvider(provider eth2client.SpecProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.specProvider = provider
+	})
+}
+
+// WithDomainProvider sets the domain provider.
+func WithDomainProvider(provider eth2client.DomainProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.domainProvider = provider
+	})
+}
+
+// WithBeaconCommitteesProvider sets the beacon committees provider.
+func WithBeaconCommitteesProvider(provider eth2client.BeaconCommitteesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconCommitteesProvider = provider
+	})
+}
+
+// WithValidatorsProvider sets the validators provider.
+func WithValidatorsProvider(provider eth2client.ValidatorsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorsProvider = provider
+	})
+}
+
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -106,6 +155,20 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.aggregateAttestationProviders) == 0 {
 		return nil, errors.New("no aggregate attestation providers specified")
 	}
+	if parameters.verifySignatures {
+		if parameters.specProvider == nil {
+			return nil, errors.New("no spec provider specified")
+		}
+		if parameters.domainProvider == nil {
+			return nil, errors.New("no domain provider specified")
+		}
+		if parameters.beaconCommitteesProvider == nil {
+			return nil, errors.New("no beacon committees provider specified")
+		}
+		if parameters.validatorsProvider == nil {
+			return nil, errors.New("no validators provider specified")
+		}
+	}
 
 	return &parameters, nil
 }
@@ -15,10 +15,15 @@ package best
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -29,14 +34,28 @@ type Service struct {
 	clientMonitor                 metrics.ClientMonitor
 	processConcurrency            int64
 	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
+	nodeMonitor                   nodemonitor.Service
 	timeout                       time.Duration
+
+	// Signature verification.
+	verifySignatures         bool
+	domainProvider           eth2client.DomainProvider
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+	validatorsProvider       eth2client.ValidatorsProvider
+	slotsPerEpoch            uint64
+	beaconAttesterDomainType phase0.DomainType
+	committeesMu             sync.Mutex
+	committeesEpoch          phase0.Epoch
+	committees               map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+	pubkeysMu                sync.RWMutex
+	pubkeys                  map[phase0.ValidatorIndex]*bls.PublicKey
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -53,8 +72,42 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		clientMonitor:                 parameters.clientMonitor,
 		processConcurrency:            parameters.processConcurrency,
 		aggregateAttestationProviders: parameters.aggregateAttestationProviders,
+		nodeMonitor:                   parameters.nodeMonitor,
+		verifySignatures:              parameters.verifySignatures,
+		domainProvider:                parameters.domainProvider,
+		beaconCommitteesProvider:      parameters.beaconCommitteesProvider,
+		validatorsProvider:            parameters.validatorsProvider,
+		pubkeys:                       make(map[phase0.ValidatorIndex]*bls.PublicKey),
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
+	if s.verifySignatures {
+		specResponse, err := parameters.specProvider.Spec(ctx, &api.SpecOpts{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain spec")
+		}
+		spec := specResponse.Data
+
+		tmp, exists := spec["SLOTS_PER_EPOCH"]
+		if !exists {
+			return nil, errors.New("SLOTS_PER_EPOCH not found in spec")
+		}
+		slotsPerEpoch, isType := tmp.(uint64)
+		if !isType {
+			return nil, errors.New("SLOTS_PER_EPOCH of unexpected type")
+		}
+		s.slotsPerEpoch = slotsPerEpoch
+
+		tmp, exists = spec["DOMAIN_BEACON_ATTESTER"]
+		if !exists {
+			return nil, errors.New("DOMAIN_BEACON_ATTESTER not found in spec")
+		}
+		beaconAttesterDomainType, isType := tmp.(phase0.DomainType)
+		if !isType {
+			return nil, errors.New("DOMAIN_BEACON_ATTESTER of unexpected type")
+		}
+		s.beaconAttesterDomainType = beaconAttesterDomainType
+	}
+
 	return s, nil
 }
@@ -0,0 +1,246 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// verifyAggregateSignatures verifies the signatures of the supplied aggregate
+// attestations as a batch, returning those aggregate attestations with valid
+// signatures.
+// The aggregate attestations must all be for the same attestation data.
+func (s *Service) verifyAggregateSignatures(ctx context.Context,
+	responses []*aggregateAttestationResponse,
+) []*aggregateAttestationResponse {
+	if !s.verifySignatures || len(responses) == 0 {
+		return responses
+	}
+
+	data := responses[0].aggregate.Data
+	signingRoot, pubkeys, err := s.committeeSigningInfo(ctx, data)
+	if err != nil {
+		// Unable to obtain the information to carry out verification is a
+		// problem with our beacon node rather than the aggregate attestations,
+		// so continue without verification.
+		log.Warn().Err(err).Msg("Failed to obtain information to verify aggregate attestation signatures; continuing without verification")
+		return responses
+	}
+
+	batch := util.NewBLSBatch(len(responses))
+	candidates := make([]*aggregateAttestationResponse, 0, len(responses))
+	for _, resp := range responses {
+		pubkey, sig, err := aggregateSignature(resp.aggregate, pubkeys)
+		if err != nil {
+			log.Warn().Str("provider", resp.provider).Err(err).Msg("Provider returned invalid aggregate attestation")
+			continue
+		}
+		batch.Add(pubkey, signingRoot, sig)
+		candidates = append(candidates, resp)
+	}
+
+	verified := batch.Verify()
+	res := make([]*aggregateAttestationResponse, 0, len(candidates))
+	for i, resp := range candidates {
+		if !verified[i] {
+			log.Warn().Str("provider", resp.provider).Msg("Provider returned aggregate attestation with invalid signature")
+			continue
+		}
+		res = append(res, resp)
+	}
+
+	return res
+}
+
+// aggregateSignature returns the aggregate public key of the participants of
+// the aggregate attestation along with its signature.
+func aggregateSignature(aggregate *phase0.Attestation,
+	committeePubkeys []*bls.PublicKey,
+) (
+	*bls.PublicKey,
+	*bls.Sign,
+	error,
+) {
+	if aggregate.AggregationBits.Len() != uint64(len(committeePubkeys)) {
+		return nil, nil, fmt.Errorf("aggregation bits length %d does not match committee size %d", aggregate.AggregationBits.Len(), len(committeePubkeys))
+	}
+
+	pubkey := &bls.PublicKey{}
+	participants := 0
+	for i, committeePubkey := range committeePubkeys {
+		if aggregate.AggregationBits.BitAt(uint64(i)) {
+			pubkey.Add(committeePubkey)
+			participants++
+		}
+	}
+	if participants == 0 {
+		return nil, nil, errors.New("aggregate attestation has no participants")
+	}
+
+	// Copy the signature, as the BLS library cannot use memory within the attestation.
+	byteSig := make([]byte, len(aggregate.Signature))
+	copy(byteSig, aggregate.Signature[:])
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(byteSig); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid signature")
+	}
+
+	return pubkey, sig, nil
+}
+
+// committeeSigningInfo returns the signing root for the attestation data along
+// with the public keys of the members of its committee, in committee order.
+func (s *Service) committeeSigningInfo(ctx context.Context,
+	data *phase0.AttestationData,
+) (
+	phase0.Root,
+	[]*bls.PublicKey,
+	error,
+) {
+	domain, err := s.domainProvider.Domain(ctx, s.beaconAttesterDomainType, data.Target.Epoch)
+	if err != nil {
+		return phase0.Root{}, nil, errors.Wrap(err, "failed to obtain beacon attester domain")
+	}
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, nil, errors.Wrap(err, "failed to obtain hash tree root of attestation data")
+	}
+	signingData := &phase0.SigningData{
+		ObjectRoot: dataRoot,
+		Domain:     domain,
+	}
+	signingRoot, err := signingData.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, nil, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	committee, err := s.committee(ctx, data.Slot, data.Index)
+	if err != nil {
+		return phase0.Root{}, nil, err
+	}
+
+	pubkeys, err := s.validatorPubkeys(ctx, committee)
+	if err != nil {
+		return phase0.Root{}, nil, err
+	}
+
+	return signingRoot, pubkeys, nil
+}
+
+// committee returns the members of the given beacon committee.
+// Committees are cached for the most recently requested epoch.
+func (s *Service) committee(ctx context.Context,
+	slot phase0.Slot,
+	index phase0.CommitteeIndex,
+) (
+	[]phase0.ValidatorIndex,
+	error,
+) {
+	epoch := phase0.Epoch(uint64(slot) / s.slotsPerEpoch)
+
+	s.committeesMu.Lock()
+	defer s.committeesMu.Unlock()
+
+	if s.committees == nil || s.committeesEpoch != epoch {
+		response, err := s.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+			State: "head",
+			Epoch: &epoch,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain beacon committees")
+		}
+		committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		for _, committee := range response.Data {
+			if _, exists := committees[committee.Slot]; !exists {
+				committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			}
+			committees[committee.Slot][committee.Index] = committee.Validators
+		}
+		s.committees = committees
+		s.committeesEpoch = epoch
+	}
+
+	committee, exists := s.committees[slot][index]
+	if !exists {
+		return nil, fmt.Errorf("no committee %d at slot %d", index, slot)
+	}
+
+	return committee, nil
+}
+
+// validatorPubkeys returns the public keys for the given validators.
+// Public keys do not change, so are cached once obtained.
+func (s *Service) validatorPubkeys(ctx context.Context,
+	indices []phase0.ValidatorIndex,
+) (
+	[]*bls.PublicKey,
+	error,
+) {
+	missing := make([]phase0.ValidatorIndex, 0)
+	s.pubkeysMu.RLock()
+	for _, index := range indices {
+		if _, exists := s.pubkeys[index]; !exists {
+			missing = append(missing, index)
+		}
+	}
+	s.pubkeysMu.RUnlock()
+
+	if len(missing) > 0 {
+		response, err := s.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+			State:   "head",
+			Indices: missing,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators")
+		}
+		pubkeys := make(map[phase0.ValidatorIndex]*bls.PublicKey, len(response.Data))
+		for index, validator := range response.Data {
+			if validator.Validator == nil {
+				continue
+			}
+			bytePubkey := make([]byte, len(validator.Validator.PublicKey))
+			copy(bytePubkey, validator.Validator.PublicKey[:])
+			pubkey := &bls.PublicKey{}
+			if err := pubkey.Deserialize(bytePubkey); err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid public key for validator %d", index))
+			}
+			pubkeys[index] = pubkey
+		}
+		s.pubkeysMu.Lock()
+		for index, pubkey := range pubkeys {
+			s.pubkeys[index] = pubkey
+		}
+		s.pubkeysMu.Unlock()
+	}
+
+	res := make([]*bls.PublicKey, len(indices))
+	s.pubkeysMu.RLock()
+	defer s.pubkeysMu.RUnlock()
+	for i, index := range indices {
+		pubkey, exists := s.pubkeys[index]
+		if !exists {
+			return nil, fmt.Errorf("no public key for validator %d", index)
+		}
+		res[i] = pubkey
+	}
+
+	return res, nil
+}
@@ -0,0 +1,189 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// committeeProvider provides a single beacon committee and its validators.
+type committeeProvider struct {
+	slot            phase0.Slot
+	index           phase0.CommitteeIndex
+	secretKeys      []*bls.SecretKey
+	validatorsCalls int
+}
+
+func (p *committeeProvider) BeaconCommittees(_ context.Context,
+	_ *api.BeaconCommitteesOpts,
+) (
+	*api.Response[[]*apiv1.BeaconCommittee],
+	error,
+) {
+	validators := make([]phase0.ValidatorIndex, len(p.secretKeys))
+	for i := range p.secretKeys {
+		validators[i] = phase0.ValidatorIndex(i)
+	}
+
+	return &api.Response[[]*apiv1.BeaconCommittee]{
+		Data: []*apiv1.BeaconCommittee{
+			{
+				Slot:       p.slot,
+				Index:      p.index,
+				Validators: validators,
+			},
+		},
+	}, nil
+}
+
+func (p *committeeProvider) Validators(_ context.Context,
+	opts *api.ValidatorsOpts,
+) (
+	*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator],
+	error,
+) {
+	p.validatorsCalls++
+	validators := make(map[phase0.ValidatorIndex]*apiv1.Validator, len(opts.Indices))
+	for _, index := range opts.Indices {
+		var pubkey phase0.BLSPubKey
+		copy(pubkey[:], p.secretKeys[index].GetPublicKey().Serialize())
+		validators[index] = &apiv1.Validator{
+			Index: index,
+			Validator: &phase0.Validator{
+				PublicKey: pubkey,
+			},
+		}
+	}
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data: validators,
+	}, nil
+}
+
+func TestVerifyAggregateSignatures(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	provider := &committeeProvider{
+		slot:       12345,
+		index:      2,
+		secretKeys: make([]*bls.SecretKey, 4),
+	}
+	for i := range provider.secretKeys {
+		provider.secretKeys[i] = &bls.SecretKey{}
+		provider.secretKeys[i].SetByCSPRNG()
+	}
+
+	s := &Service{
+		verifySignatures:         true,
+		domainProvider:           mock.NewDomainProvider(),
+		beaconCommitteesProvider: provider,
+		validatorsProvider:       provider,
+		slotsPerEpoch:            32,
+		pubkeys:                  make(map[phase0.ValidatorIndex]*bls.PublicKey),
+	}
+
+	data := &phase0.AttestationData{
+		Slot:   provider.slot,
+		Index:  provider.index,
+		Source: &phase0.Checkpoint{Epoch: 384},
+		Target: &phase0.Checkpoint{Epoch: 385},
+	}
+	domain, err := s.domainProvider.Domain(ctx, s.beaconAttesterDomainType, data.Target.Epoch)
+	require.NoError(t, err)
+	dataRoot, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	signingRoot, err := (&phase0.SigningData{ObjectRoot: dataRoot, Domain: domain}).HashTreeRoot()
+	require.NoError(t, err)
+
+	// aggregate creates an aggregate attestation signed by the given committee members.
+	aggregate := func(bits bitfield.Bitlist, signers []int) *aggregateAttestationResponse {
+		sig := &bls.Sign{}
+		for _, signer := range signers {
+			sig.Add(provider.secretKeys[signer].SignByte(signingRoot[:]))
+		}
+		attestation := &phase0.Attestation{
+			AggregationBits: bits,
+			Data:            data,
+		}
+		copy(attestation.Signature[:], sig.Serialize())
+
+		return &aggregateAttestationResponse{
+			provider:  "test",
+			aggregate: attestation,
+		}
+	}
+
+	good := aggregate(bitfield.Bitlist{0x13}, []int{0, 1})
+	goodAll := aggregate(bitfield.Bitlist{0x1f}, []int{0, 1, 2, 3})
+	wrongSigners := aggregate(bitfield.Bitlist{0x13}, []int{0, 2})
+	noParticipants := aggregate(bitfield.Bitlist{0x10}, []int{})
+	wrongLength := aggregate(bitfield.Bitlist{0x23}, []int{0, 1})
+
+	tests := []struct {
+		name      string
+		responses []*aggregateAttestationResponse
+		expected  []*aggregateAttestationResponse
+	}{
+		{
+			name:      "Empty",
+			responses: []*aggregateAttestationResponse{},
+			expected:  []*aggregateAttestationResponse{},
+		},
+		{
+			name:      "Single",
+			responses: []*aggregateAttestationResponse{good},
+			expected:  []*aggregateAttestationResponse{good},
+		},
+		{
+			name:      "Multiple",
+			responses: []*aggregateAttestationResponse{good, goodAll},
+			expected:  []*aggregateAttestationResponse{good, goodAll},
+		},
+		{
+			name:      "WrongSigners",
+			responses: []*aggregateAttestationResponse{good, wrongSigners, goodAll},
+			expected:  []*aggregateAttestationResponse{good, goodAll},
+		},
+		{
+			name:      "NoParticipants",
+			responses: []*aggregateAttestationResponse{noParticipants, good},
+			expected:  []*aggregateAttestationResponse{good},
+		},
+		{
+			name:      "WrongLength",
+			responses: []*aggregateAttestationResponse{goodAll, wrongLength},
+			expected:  []*aggregateAttestationResponse{goodAll},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, s.verifyAggregateSignatures(ctx, test.responses))
+		})
+	}
+
+	// Public keys should have been fetched once only.
+	require.Equal(t, 1, provider.validatorsCalls)
+}
@@ -17,9 +17,9 @@ import (
 	"context"
 	"time"
 
-	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -46,26 +46,33 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 
 	respCh := make(chan *phase0.Attestation, 1)
-	for name, provider := range s.aggregateAttestationProviders {
-		go func(ctx context.Context,
-			name string,
-			provider eth2client.AggregateAttestationProvider,
-			ch chan *phase0.Attestation,
-		) {
-			log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Logger()
+	failedCh := make(chan struct{}, len(s.aggregateAttestationProviders))
+	request := func(name string) {
+		log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Logger()
 
-			aggregateResponse, err := provider.AggregateAttestation(ctx, opts)
-			s.clientMonitor.ClientOperation(name, "aggregate attestation", err == nil, time.Since(started))
-			if err != nil {
-				log.Warn().Err(err).Msg("Failed to obtain aggregate attestation")
-				return
-			}
-			aggregate := aggregateResponse.Data
-			log.Trace().Str("provider", name).Msg("Obtained aggregate attestation")
+		requestStarted := time.Now()
+		aggregateResponse, err := s.aggregateAttestationProviders[name].AggregateAttestation(ctx, opts)
+		s.clientMonitor.ClientOperation(name, "aggregate attestation", err == nil, time.Since(started))
+		s.recordLatency(ctx, name, time.Since(requestStarted), err)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to obtain aggregate attestation")
+			failedCh <- struct{}{}
+			return
+		}
+		aggregate := aggregateResponse.Data
+		log.Trace().Str("provider", name).Msg("Obtained aggregate attestation")
 
-			ch <- aggregate
-		}(ctx, name, provider, respCh)
+		select {
+		case respCh <- aggregate:
+		default:
+		}
 	}
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.aggregateAttestationProviders)
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	s.hedge(ctx, names, request, failedCh)
 
 	select {
 	case <-ctx.Done():
@@ -80,3 +87,48 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 		}, nil
 	}
 }
+
+// hedge issues the request to the providers.  If hedging is enabled the
+// request is sent to the fastest provider first, and to the remainder only if
+// it fails or has not responded by the hedge delay.
+func (s *Service) hedge(ctx context.Context,
+	names []string,
+	request func(name string),
+	failedCh chan struct{},
+) {
+	if s.hedgeDelay == 0 {
+		for _, name := range names {
+			go request(name)
+		}
+		return
+	}
+
+	ordered := s.nodeLatency.Order(ctx, names)
+	go request(ordered[0])
+	if len(ordered) == 1 {
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-failedCh:
+			log.Trace().Str("provider", ordered[0]).Msg("Fastest provider failed; hedging")
+		case <-time.After(s.hedgeDelay):
+			log.Trace().Str("provider", ordered[0]).Msg("Fastest provider slow; hedging")
+		}
+		for _, name := range ordered[1:] {
+			go request(name)
+		}
+	}()
+}
+
+// recordLatency records the latency of a request with the node latency
+// service.  Requests canceled due to another provider responding first are
+// not recorded, as their latency is unknown.
+func (s *Service) recordLatency(ctx context.Context, name string, latency time.Duration, err error) {
+	if s.nodeLatency == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	s.nodeLatency.Record(ctx, name, latency, err == nil)
+}
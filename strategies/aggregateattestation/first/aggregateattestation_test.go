@@ -22,12 +22,20 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	standardnodelatency "github.com/attestantio/vouch/services/nodelatency/standard"
 	"github.com/attestantio/vouch/strategies/aggregateattestation/first"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAggregateAttestation(t *testing.T) {
+	nodeLatency, err := standardnodelatency.New(context.Background(),
+		standardnodelatency.WithLogLevel(zerolog.Disabled),
+		standardnodelatency.WithMonitor(nullmetrics.New(context.Background())),
+	)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name                string
 		params              []first.Parameter
@@ -82,6 +90,42 @@ func TestAggregateAttestation(t *testing.T) {
 				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
 			},
 		},
+		{
+			name: "HedgedFastestSlow",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2 * time.Second),
+				first.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"a-sleepy": mock.NewSleepyAggregateAttestationProvider(5*time.Second, mock.NewAggregateAttestationProvider()),
+					"b-good":   mock.NewAggregateAttestationProvider(),
+				}),
+				first.WithNodeLatency(nodeLatency),
+				first.WithHedgeDelay(100 * time.Millisecond),
+			},
+			slot: 12345,
+			attestationDataRoot: phase0.Root{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+			},
+		},
+		{
+			name: "HedgedFastestErrors",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2 * time.Second),
+				first.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"a-error": mock.NewErroringAggregateAttestationProvider(),
+					"b-good":  mock.NewAggregateAttestationProvider(),
+				}),
+				first.WithNodeLatency(nodeLatency),
+				first.WithHedgeDelay(time.Second),
+			},
+			slot: 12345,
+			attestationDataRoot: phase0.Root{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+			},
+		},
 	}
 
 	for _, test := range tests {
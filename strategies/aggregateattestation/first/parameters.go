@@ -22,6 +22,8 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodelatency"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -30,7 +32,10 @@ type parameters struct {
 	logLevel                      zerolog.Level
 	clientMonitor                 metrics.ClientMonitor
 	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
+	nodeMonitor                   nodemonitor.Service
 	timeout                       time.Duration
+	nodeLatency                   nodelatency.Service
+	hedgeDelay                    time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -72,6 +77,30 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithNodeLatency sets the node latency service, used to order requests
+// when hedging.
+func WithNodeLatency(nodeLatency nodelatency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeLatency = nodeLatency
+	})
+}
+
+// WithHedgeDelay sets the delay after which requests are sent to the
+// remaining providers if the fastest provider has not responded.  0 sends
+// requests to all providers immediately.
+func WithHedgeDelay(delay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.hedgeDelay = delay
+	})
+}
+
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -93,6 +122,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.aggregateAttestationProviders) == 0 {
 		return nil, errors.New("no aggregate attestation providers specified")
 	}
+	if parameters.hedgeDelay < 0 {
+		return nil, errors.New("hedge delay cannot be negative")
+	}
+	if parameters.hedgeDelay > 0 && parameters.nodeLatency == nil {
+		return nil, errors.New("no node latency service specified")
+	}
 
 	return &parameters, nil
 }
@@ -20,12 +20,20 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/mock"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	standardnodelatency "github.com/attestantio/vouch/services/nodelatency/standard"
 	"github.com/attestantio/vouch/strategies/aggregateattestation/first"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
 
 func TestService(t *testing.T) {
+	nodeLatency, err := standardnodelatency.New(context.Background(),
+		standardnodelatency.WithLogLevel(zerolog.Disabled),
+		standardnodelatency.WithMonitor(nullmetrics.New(context.Background())),
+	)
+	require.NoError(t, err)
+
 	aggregateAttestationProviders := map[string]eth2client.AggregateAttestationProvider{
 		"localhost:1": mock.NewAggregateAttestationProvider(),
 	}
@@ -80,6 +88,27 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no aggregate attestation providers specified",
 		},
+		{
+			name: "HedgeDelayNegative",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				first.WithNodeLatency(nodeLatency),
+				first.WithHedgeDelay(-1 * time.Second),
+			},
+			err: "problem with parameters: hedge delay cannot be negative",
+		},
+		{
+			name: "NodeLatencyMissing",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				first.WithHedgeDelay(100 * time.Millisecond),
+			},
+			err: "problem with parameters: no node latency service specified",
+		},
 		{
 			name: "Good",
 			params: []first.Parameter{
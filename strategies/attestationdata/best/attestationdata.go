@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -31,6 +32,7 @@ type attestationDataResponse struct {
 	provider        string
 	attestationData *phase0.AttestationData
 	score           float64
+	elapsed         time.Duration
 }
 
 type attestationDataError struct {
@@ -57,15 +59,18 @@ func (s *Service) AttestationData(ctx context.Context,
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
 	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// Both are reduced if required to fit within the deadline of the duty.
+	timeout := util.BudgetedTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, timeout/2)
 
-	requests := len(s.attestationDataProviders)
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.attestationDataProviders)
+	requests := len(providers)
 
 	respCh := make(chan *attestationDataResponse, requests)
 	errCh := make(chan *attestationDataError, requests)
 	// Kick off the requests.
-	for name, provider := range s.attestationDataProviders {
+	for name, provider := range providers {
 		go s.attestationData(ctx, started, name, provider, respCh, errCh, opts)
 	}
 
@@ -77,6 +82,7 @@ func (s *Service) AttestationData(ctx context.Context,
 	bestScore := float64(0)
 	var bestAttestationData *phase0.AttestationData
 	var bestProvider string
+	responses := make([]*attestationDataResponse, 0, requests)
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
@@ -90,6 +96,7 @@ func (s *Service) AttestationData(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
+			responses = append(responses, resp)
 			if bestAttestationData == nil || resp.score > bestScore {
 				bestAttestationData = resp.attestationData
 				bestScore = resp.score
@@ -139,6 +146,7 @@ func (s *Service) AttestationData(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
+			responses = append(responses, resp)
 			if bestAttestationData == nil || resp.score > bestScore {
 				bestAttestationData = resp.attestationData
 				bestScore = resp.score
@@ -172,6 +180,7 @@ func (s *Service) AttestationData(ctx context.Context,
 		Int("errored", errored).
 		Int("timed_out", timedOut).
 		Msg("Results")
+	s.updateTrust(ctx, responses, timeout)
 
 	if bestAttestationData == nil {
 		return nil, errors.New("no attestations received")
@@ -239,5 +248,6 @@ func (s *Service) attestationData(ctx context.Context,
 		provider:        name,
 		attestationData: attestationData,
 		score:           score,
+		elapsed:         time.Since(started),
 	}
 }
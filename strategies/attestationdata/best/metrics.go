@@ -0,0 +1,69 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var providerTrustScore *prometheus.GaugeVec
+
+func registerMetrics(ctx context.Context, monitor metrics.ClientMonitor) error {
+	if providerTrustScore != nil {
+		// Already registered.
+		return nil
+	}
+	service, isService := monitor.(metrics.Service)
+	if !isService {
+		// No monitor.
+		return nil
+	}
+	if service.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	providerTrustScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "attestationdata",
+		Name:      "provider_trust",
+		Help:      "The trust score of the beacon node when providing attestation data, from 0 to 1.",
+	}, []string{"provider"})
+	if err := prometheus.Register(providerTrustScore); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			providerTrustScore = alreadyRegisteredError.ExistingCollector.(*prometheus.GaugeVec)
+		} else {
+			return errors.Wrap(err, "failed to register vouch_attestationdata_provider_trust")
+		}
+	}
+
+	return nil
+}
+
+// monitorProviderTrust provides metrics for the trust score of a provider.
+func monitorProviderTrust(provider string, trust float64) {
+	if providerTrustScore == nil {
+		// Not yet registered.
+		return
+	}
+
+	providerTrustScore.WithLabelValues(provider).Set(trust)
+}
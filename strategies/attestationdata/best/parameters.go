@@ -25,6 +25,7 @@ import (
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -34,9 +35,12 @@ type parameters struct {
 	clientMonitor            metrics.ClientMonitor
 	processConcurrency       int64
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 	chainTime                chaintime.Service
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
+	trustHalfLife            time.Duration
+	headLagTolerance         uint64
 }
 
 // Parameter is the interface for service parameters.
@@ -99,12 +103,36 @@ func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
 	})
 }
 
+// WithTrustHalfLife sets the half-life of the trust scores of the providers.
+// Trust that has been lost decays back towards full trust over time.
+func WithTrustHalfLife(halfLife time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.trustHalfLife = halfLife
+	})
+}
+
+// WithHeadLagTolerance sets the number of slots by which the head of the
+// attestation data can lag the attestation slot without being penalized.
+func WithHeadLagTolerance(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.headLagTolerance = slots
+	})
+}
+
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
 		logLevel:           zerolog.GlobalLevel(),
 		clientMonitor:      nullmetrics.New(context.Background()),
 		processConcurrency: int64(runtime.GOMAXPROCS(-1)),
+		trustHalfLife:      time.Hour,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -130,6 +158,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.blockRootToSlotCache == nil {
 		return nil, errors.New("no block root to slot cache specified")
 	}
+	if parameters.trustHalfLife <= 0 {
+		return nil, errors.New("trust half-life must be positive")
+	}
 
 	return &parameters, nil
 }
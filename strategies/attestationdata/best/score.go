@@ -33,13 +33,15 @@ func (s *Service) scoreAttestationData(ctx context.Context,
 	// Initial score is based on height of source and target epochs.
 	score := float64(attestationData.Source.Epoch + attestationData.Target.Epoch)
 
-	// Increase score based on the nearness of the head slot.
+	// Increase score based on the nearness of the head slot, weighted by
+	// the trust in the provider.
+	trust := s.trust(name)
 	slot, err := s.blockRootToSlotCache.BlockRootToSlot(ctx, attestationData.BeaconBlockRoot)
 	if err != nil {
 		log.Warn().Str("root", fmt.Sprintf("%#x", attestationData.BeaconBlockRoot)).Err(err).Msg("Failed to obtain slot for block root")
 		slot = 0
 	} else {
-		score += float64(1) / float64(1+attestationData.Slot-slot)
+		score += trust / float64(1+s.headLag(attestationData.Slot, slot))
 	}
 
 	log.Trace().
@@ -48,7 +50,22 @@ func (s *Service) scoreAttestationData(ctx context.Context,
 		Uint64("head_slot", uint64(slot)).
 		Uint64("source_epoch", uint64(attestationData.Source.Epoch)).
 		Uint64("target_epoch", uint64(attestationData.Target.Epoch)).
+		Float64("trust", trust).
 		Float64("score", score).
 		Msg("Scored attestation data")
 	return score
 }
+
+// headLag returns the number of slots by which the head lags the attestation
+// slot, less the configured tolerance.
+func (s *Service) headLag(attestationSlot phase0.Slot, headSlot phase0.Slot) uint64 {
+	if headSlot >= attestationSlot {
+		return 0
+	}
+	lag := uint64(attestationSlot - headSlot)
+	if lag <= s.headLagTolerance {
+		return 0
+	}
+
+	return lag - s.headLagTolerance
+}
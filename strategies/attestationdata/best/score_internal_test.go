@@ -0,0 +1,73 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadLag(t *testing.T) {
+	tests := []struct {
+		name            string
+		tolerance       uint64
+		attestationSlot phase0.Slot
+		headSlot        phase0.Slot
+		expected        uint64
+	}{
+		{
+			name:            "Current",
+			attestationSlot: 10,
+			headSlot:        10,
+			expected:        0,
+		},
+		{
+			name:            "HeadAhead",
+			attestationSlot: 10,
+			headSlot:        11,
+			expected:        0,
+		},
+		{
+			name:            "Lag",
+			attestationSlot: 10,
+			headSlot:        8,
+			expected:        2,
+		},
+		{
+			name:            "LagWithinTolerance",
+			tolerance:       1,
+			attestationSlot: 10,
+			headSlot:        9,
+			expected:        0,
+		},
+		{
+			name:            "LagBeyondTolerance",
+			tolerance:       1,
+			attestationSlot: 10,
+			headSlot:        7,
+			expected:        2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				headLagTolerance: test.tolerance,
+			}
+			require.Equal(t, test.expected, s.headLag(test.attestationSlot, test.headSlot))
+		})
+	}
+}
@@ -15,12 +15,14 @@ package best
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -31,16 +33,21 @@ type Service struct {
 	clientMonitor            metrics.ClientMonitor
 	processConcurrency       int64
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 	chainTime                chaintime.Service
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
+	trustHalfLife            time.Duration
+	headLagTolerance         uint64
+	trustScores              map[string]*providerTrust
+	trustMu                  sync.RWMutex
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -57,10 +64,18 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		clientMonitor:            parameters.clientMonitor,
 		processConcurrency:       parameters.processConcurrency,
 		attestationDataProviders: parameters.attestationDataProviders,
+		nodeMonitor:              parameters.nodeMonitor,
 		chainTime:                parameters.chainTime,
 		blockRootToSlotCache:     parameters.blockRootToSlotCache,
+		trustHalfLife:            parameters.trustHalfLife,
+		headLagTolerance:         parameters.headLagTolerance,
+		trustScores:              make(map[string]*providerTrust),
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
+	if err := registerMetrics(ctx, s.clientMonitor); err != nil {
+		return nil, errors.Wrap(err, "failed to register metrics")
+	}
+
 	return s, nil
 }
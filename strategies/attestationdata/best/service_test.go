@@ -138,6 +138,18 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no block root to slot cache specified",
 		},
+		{
+			name: "TrustHalfLifeZero",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithTrustHalfLife(0),
+			},
+			err: "problem with parameters: trust half-life must be positive",
+		},
 	}
 
 	for _, test := range tests {
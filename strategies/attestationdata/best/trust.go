@@ -0,0 +1,112 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// trustSmoothing is the weight given to each new observation of a provider
+// when updating its trust score.
+const trustSmoothing = 0.1
+
+// providerTrust is the running trust score of a provider.
+type providerTrust struct {
+	score   float64
+	updated time.Time
+}
+
+// trust returns the current trust score of a provider, between 0 and 1.
+// Trust decays back towards 1 over time, so past behaviour is gradually
+// forgotten.
+func (s *Service) trust(provider string) float64 {
+	s.trustMu.RLock()
+	defer s.trustMu.RUnlock()
+
+	return s.decayedTrust(s.trustScores[provider], time.Now())
+}
+
+// decayedTrust returns the trust score after decay to the given time.
+func (s *Service) decayedTrust(trust *providerTrust, now time.Time) float64 {
+	if trust == nil {
+		return 1
+	}
+	elapsed := now.Sub(trust.updated)
+	if elapsed <= 0 {
+		return trust.score
+	}
+
+	return 1 - (1-trust.score)*math.Pow(0.5, float64(elapsed)/float64(s.trustHalfLife))
+}
+
+// updateTrust updates the trust scores of the providers given the responses
+// to a request.  A provider is rewarded for returning the most recent head
+// seen across all responses, and for returning it quickly.  Providers that
+// errored or did not respond in time are penalised.
+func (s *Service) updateTrust(ctx context.Context,
+	responses []*attestationDataResponse,
+	timeout time.Duration,
+) {
+	headSlots := make(map[string]phase0.Slot, len(responses))
+	bestHeadSlot := phase0.Slot(0)
+	for _, response := range responses {
+		slot, err := s.blockRootToSlotCache.BlockRootToSlot(ctx, response.attestationData.BeaconBlockRoot)
+		if err != nil {
+			// Without the slot the head cannot be judged.
+			continue
+		}
+		headSlots[response.provider] = slot
+		if slot > bestHeadSlot {
+			bestHeadSlot = slot
+		}
+	}
+
+	observations := make(map[string]float64, len(s.attestationDataProviders))
+	for name := range s.attestationDataProviders {
+		// Default is that the provider did not respond.
+		observations[name] = 0
+	}
+	for _, response := range responses {
+		slot, exists := headSlots[response.provider]
+		if !exists {
+			delete(observations, response.provider)
+			continue
+		}
+		if slot != bestHeadSlot {
+			continue
+		}
+		// Correct head, scaled down by up to half for latency.
+		latency := float64(response.elapsed) / float64(timeout)
+		if latency > 1 {
+			latency = 1
+		}
+		observations[response.provider] = 1 - latency/2
+	}
+
+	now := time.Now()
+	s.trustMu.Lock()
+	for name, observation := range observations {
+		score := s.decayedTrust(s.trustScores[name], now)*(1-trustSmoothing) + observation*trustSmoothing
+		s.trustScores[name] = &providerTrust{
+			score:   score,
+			updated: now,
+		}
+		monitorProviderTrust(name, score)
+	}
+	s.trustMu.Unlock()
+}
@@ -0,0 +1,108 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecayedTrust(t *testing.T) {
+	s := &Service{
+		trustHalfLife: time.Hour,
+	}
+	now := time.Now()
+
+	require.Equal(t, 1.0, s.decayedTrust(nil, now))
+	require.Equal(t, 0.5, s.decayedTrust(&providerTrust{score: 0.5, updated: now}, now))
+	require.InDelta(t, 0.75, s.decayedTrust(&providerTrust{score: 0.5, updated: now.Add(-time.Hour)}, now), 0.0001)
+	require.InDelta(t, 0.875, s.decayedTrust(&providerTrust{score: 0.5, updated: now.Add(-2 * time.Hour)}, now), 0.0001)
+}
+
+func TestUpdateTrust(t *testing.T) {
+	ctx := context.Background()
+
+	genesisProvider := mock.NewGenesisProvider(time.Now())
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	currentRoot := testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101")
+	staleRoot := testutil.HexToRoot("0x0202020202020202020202020202020202020202020202020202020202020202")
+	unknownRoot := testutil.HexToRoot("0x0303030303030303030303030303030303030303030303030303030303030303")
+	cacheSvc := mockcache.New(map[phase0.Root]phase0.Slot{
+		currentRoot: 10,
+		staleRoot:   9,
+	})
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithTimeout(2*time.Second),
+		WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+			"fast":    mock.NewAttestationDataProvider(),
+			"slow":    mock.NewAttestationDataProvider(),
+			"stale":   mock.NewAttestationDataProvider(),
+			"unknown": mock.NewAttestationDataProvider(),
+			"missing": mock.NewAttestationDataProvider(),
+		}),
+		WithChainTime(chainTime),
+		WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	s.updateTrust(ctx, []*attestationDataResponse{
+		{
+			provider:        "fast",
+			attestationData: &phase0.AttestationData{Slot: 10, BeaconBlockRoot: currentRoot},
+			elapsed:         0,
+		},
+		{
+			provider:        "slow",
+			attestationData: &phase0.AttestationData{Slot: 10, BeaconBlockRoot: currentRoot},
+			elapsed:         2 * time.Second,
+		},
+		{
+			provider:        "stale",
+			attestationData: &phase0.AttestationData{Slot: 10, BeaconBlockRoot: staleRoot},
+			elapsed:         0,
+		},
+		{
+			provider:        "unknown",
+			attestationData: &phase0.AttestationData{Slot: 10, BeaconBlockRoot: unknownRoot},
+			elapsed:         0,
+		},
+	}, 2*time.Second)
+
+	require.InDelta(t, 1.0, s.trust("fast"), 0.0001)
+	require.InDelta(t, 0.95, s.trust("slow"), 0.0001)
+	require.InDelta(t, 0.9, s.trust("stale"), 0.0001)
+	// Heads that cannot be judged do not affect trust.
+	require.InDelta(t, 1.0, s.trust("unknown"), 0.0001)
+	require.InDelta(t, 0.9, s.trust("missing"), 0.0001)
+}
@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -46,7 +47,7 @@ func (s *Service) AttestationData(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 
 	respCh := make(chan *phase0.AttestationData, 1)
-	for name, provider := range s.attestationDataProviders {
+	for name, provider := range nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.attestationDataProviders) {
 		go func(ctx context.Context, name string, provider eth2client.AttestationDataProvider, ch chan *phase0.AttestationData) {
 			log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Logger()
 
@@ -22,6 +22,7 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	mocknodemonitor "github.com/attestantio/vouch/services/nodemonitor/mock"
 	"github.com/attestantio/vouch/strategies/attestationdata/first"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -73,6 +74,34 @@ func TestAttestationData(t *testing.T) {
 			slot:           12345,
 			committeeIndex: 3,
 		},
+		{
+			name: "UnhealthyIgnored",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(time.Second),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"error": mock.NewErroringAttestationDataProvider(),
+					"good":  mock.NewAttestationDataProvider(),
+				}),
+				first.WithNodeMonitor(mocknodemonitor.New("good")),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			err:            "failed to obtain attestation data before timeout",
+		},
+		{
+			name: "AllUnhealthy",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2 * time.Second),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				first.WithNodeMonitor(mocknodemonitor.New("good")),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+		},
 	}
 
 	for _, test := range tests {
@@ -19,6 +19,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -28,6 +29,7 @@ import (
 type Service struct {
 	clientMonitor            metrics.ClientMonitor
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 }
 
@@ -49,6 +51,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 
 	s := &Service{
 		attestationDataProviders: parameters.attestationDataProviders,
+		nodeMonitor:              parameters.nodeMonitor,
 		timeout:                  parameters.timeout,
 		clientMonitor:            parameters.clientMonitor,
 	}
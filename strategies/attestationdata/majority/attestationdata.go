@@ -22,6 +22,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -60,15 +61,18 @@ func (s *Service) AttestationData(ctx context.Context,
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
 	// The soft timeout is half the duration of the hard timeout.
+	// The fallback, if used, runs after the hard timeout so is given the original context.
+	fallbackCtx := ctx
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
 
-	requests := len(s.attestationDataProviders)
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.attestationDataProviders)
+	requests := len(providers)
 
 	respCh := make(chan *attestationDataResponse, requests)
 	errCh := make(chan *attestationDataError, requests)
 	// Kick off the requests.
-	for name, provider := range s.attestationDataProviders {
+	for name, provider := range providers {
 		go s.attestationData(ctx, started, name, provider, respCh, errCh, opts)
 	}
 
@@ -223,9 +227,17 @@ func (s *Service) AttestationData(ctx context.Context,
 	}
 
 	if bestAttestationDataCount == 0 {
+		if s.fallback != nil {
+			log.Warn().Msg("No attestation data received; using fallback")
+			return s.fallback.AttestationData(fallbackCtx, opts)
+		}
 		return nil, errors.New("no attestation data received")
 	}
 	if bestAttestationDataCount < int(s.threshold) {
+		if s.fallback != nil {
+			log.Warn().Int("count", bestAttestationDataCount).Uint64("threshold", s.threshold).Msg("Majority attestation data lower than threshold; using fallback")
+			return s.fallback.AttestationData(fallbackCtx, opts)
+		}
 		return nil, fmt.Errorf("majority attestation data count of %d lower than threshold %d", bestAttestationDataCount, s.threshold)
 	}
 	slot, err := s.blockRootToSlotCache.BlockRootToSlot(ctx, bestAttestationData.BeaconBlockRoot)
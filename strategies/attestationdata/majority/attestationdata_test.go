@@ -26,6 +26,7 @@ import (
 	mockcache "github.com/attestantio/vouch/services/cache/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
 	"github.com/attestantio/vouch/strategies/attestationdata/best"
+	"github.com/attestantio/vouch/strategies/attestationdata/majority"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -168,3 +169,108 @@ func TestAttestationData(t *testing.T) {
 		})
 	}
 }
+
+func TestAttestationDataFallback(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	cache := mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)
+
+	tests := []struct {
+		name       string
+		params     []majority.Parameter
+		err        string
+		logEntries []string
+	}{
+		{
+			name: "ThresholdReached",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good1": mock.NewAttestationDataProvider(),
+					"good2": mock.NewAttestationDataProvider(),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+				majority.WithThreshold(2),
+				majority.WithFallback(mock.NewErroringAttestationDataProvider()),
+			},
+		},
+		{
+			name: "ThresholdNotReached",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good":  mock.NewAttestationDataProvider(),
+					"error": mock.NewErroringAttestationDataProvider(),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+				majority.WithThreshold(2),
+			},
+			err: "majority attestation data count of 1 lower than threshold 2",
+		},
+		{
+			name: "ThresholdNotReachedFallback",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good":  mock.NewAttestationDataProvider(),
+					"error": mock.NewErroringAttestationDataProvider(),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+				majority.WithThreshold(2),
+				majority.WithFallback(mock.NewAttestationDataProvider()),
+			},
+			logEntries: []string{"Majority attestation data lower than threshold; using fallback"},
+		},
+		{
+			name: "TimeoutFallback",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"sleepy": mock.NewSleepyAttestationDataProvider(5*time.Second, mock.NewAttestationDataProvider()),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+				majority.WithFallback(mock.NewAttestationDataProvider()),
+			},
+			logEntries: []string{"No attestation data received; using fallback"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			s, err := majority.New(context.Background(), test.params...)
+			require.NoError(t, err)
+			attestationData, err := s.AttestationData(context.Background(), &api.AttestationDataOpts{
+				Slot:           12345,
+				CommitteeIndex: 3,
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, attestationData)
+			}
+			for _, entry := range test.logEntries {
+				capture.AssertHasEntry(t, entry)
+			}
+		})
+	}
+}
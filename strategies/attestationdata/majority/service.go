@@ -21,6 +21,7 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -31,10 +32,12 @@ type Service struct {
 	clientMonitor            metrics.ClientMonitor
 	processConcurrency       int64
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 	chainTime                chaintime.Service
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
 	threshold                uint64
+	fallback                 eth2client.AttestationDataProvider
 }
 
 // module-wide log.
@@ -58,9 +61,11 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		clientMonitor:            parameters.clientMonitor,
 		processConcurrency:       parameters.processConcurrency,
 		attestationDataProviders: parameters.attestationDataProviders,
+		nodeMonitor:              parameters.nodeMonitor,
 		chainTime:                parameters.chainTime,
 		blockRootToSlotCache:     parameters.blockRootToSlotCache,
 		threshold:                parameters.threshold,
+		fallback:                 parameters.fallback,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
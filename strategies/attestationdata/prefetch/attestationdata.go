@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AttestationData provides prefetched attestation data if it is still current,
+// otherwise requests it from the underlying provider.
+func (s *Service) AttestationData(ctx context.Context,
+	opts *api.AttestationDataOpts,
+) (
+	*api.Response[*phase0.AttestationData],
+	error,
+) {
+	ctx, span := otel.Tracer("attestantio.vouch.strategies.attestationdata.prefetch").Start(ctx, "AttestationData", trace.WithAttributes(
+		attribute.Int64("slot", int64(opts.Slot)),
+	))
+	defer span.End()
+
+	s.mu.Lock()
+	prefetched, exists := s.prefetched[opts.Slot]
+	current := exists && prefetched.heads == s.heads
+	s.mu.Unlock()
+
+	if current {
+		monitorRequest("prefetched")
+		log.Trace().Uint64("slot", uint64(opts.Slot)).Msg("Using prefetched attestation data")
+		data := prefetched.data
+		return &api.Response[*phase0.AttestationData]{
+			Data: &phase0.AttestationData{
+				Slot:            data.Slot,
+				Index:           opts.CommitteeIndex,
+				BeaconBlockRoot: data.BeaconBlockRoot,
+				Source: &phase0.Checkpoint{
+					Epoch: data.Source.Epoch,
+					Root:  data.Source.Root,
+				},
+				Target: &phase0.Checkpoint{
+					Epoch: data.Target.Epoch,
+					Root:  data.Target.Root,
+				},
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+
+	if exists {
+		monitorRequest("refreshed")
+		log.Trace().Uint64("slot", uint64(opts.Slot)).Msg("New head since prefetch; refreshing attestation data")
+	} else {
+		monitorRequest("not_prefetched")
+	}
+
+	return s.attestationDataProvider.AttestationData(ctx, opts)
+}
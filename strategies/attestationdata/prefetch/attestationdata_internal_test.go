@@ -0,0 +1,129 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// countingAttestationDataProvider counts the requests for attestation data.
+type countingAttestationDataProvider struct {
+	requests atomic.Uint64
+	next     eth2client.AttestationDataProvider
+}
+
+func (p *countingAttestationDataProvider) AttestationData(ctx context.Context,
+	opts *api.AttestationDataOpts,
+) (
+	*api.Response[*phase0.AttestationData],
+	error,
+) {
+	p.requests.Add(1)
+	return p.next.AttestationData(ctx, opts)
+}
+
+func TestAttestationData(t *testing.T) {
+	ctx := context.Background()
+
+	// Start part way through a slot so that the current slot is stable.
+	genesisTime := time.Now().Add(-30 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	provider := &countingAttestationDataProvider{next: mock.NewAttestationDataProvider()}
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithChainTime(chainTime),
+		WithScheduler(mockscheduler.New()),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithAttestationDataProvider(provider),
+		WithOffset(time.Second),
+	)
+	require.NoError(t, err)
+
+	slot := chainTime.CurrentSlot()
+
+	// No prefetch; goes to the provider.
+	res, err := s.AttestationData(ctx, &api.AttestationDataOpts{Slot: slot, CommitteeIndex: 1})
+	require.NoError(t, err)
+	require.Equal(t, phase0.CommitteeIndex(1), res.Data.Index)
+	require.Equal(t, uint64(1), provider.requests.Load())
+
+	// Prefetch; served without going to the provider.
+	s.prefetchJob(ctx, nil)
+	require.Equal(t, uint64(2), provider.requests.Load())
+	res, err = s.AttestationData(ctx, &api.AttestationDataOpts{Slot: slot, CommitteeIndex: 3})
+	require.NoError(t, err)
+	require.Equal(t, slot, res.Data.Slot)
+	require.Equal(t, phase0.CommitteeIndex(3), res.Data.Index)
+	require.Equal(t, uint64(2), provider.requests.Load())
+
+	// New head; goes to the provider.
+	s.HandleHeadEvent(&apiv1.Event{Topic: "head", Data: &apiv1.HeadEvent{Slot: slot}})
+	_, err = s.AttestationData(ctx, &api.AttestationDataOpts{Slot: slot})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), provider.requests.Load())
+
+	// Different slot; goes to the provider.
+	_, err = s.AttestationData(ctx, &api.AttestationDataOpts{Slot: slot + 1})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), provider.requests.Load())
+}
+
+func TestPrefetchRuntime(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-30*time.Second))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithChainTime(chainTime),
+		WithScheduler(mockscheduler.New()),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+		WithOffset(time.Second),
+	)
+	require.NoError(t, err)
+
+	runtime, err := s.prefetchRuntime(ctx, nil)
+	require.NoError(t, err)
+	require.True(t, runtime.After(time.Now()))
+	slot := chainTime.CurrentSlot()
+	require.Contains(t, []time.Time{
+		chainTime.StartOfSlot(slot).Add(time.Second),
+		chainTime.StartOfSlot(slot + 1).Add(time.Second),
+	}, runtime)
+}
@@ -0,0 +1,66 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var requestsCounter *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.ClientMonitor) error {
+	if requestsCounter != nil {
+		// Already registered.
+		return nil
+	}
+	service, isService := monitor.(metrics.Service)
+	if !isService {
+		// No monitor.
+		return nil
+	}
+	if service.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	requestsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "attestationdata_prefetch",
+		Name:      "requests_total",
+		Help:      "The number of requests for attestation data, by whether prefetched data was used.",
+	}, []string{"result"})
+	if err := prometheus.Register(requestsCounter); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			requestsCounter = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return errors.Wrap(err, "failed to register vouch_attestationdata_prefetch_requests_total")
+		}
+	}
+
+	return nil
+}
+
+// monitorRequest records the result of a request for attestation data.
+func monitorRequest(result string) {
+	if requestsCounter != nil {
+		requestsCounter.WithLabelValues(result).Inc()
+	}
+}
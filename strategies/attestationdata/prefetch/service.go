@@ -0,0 +1,160 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prefetch is a strategy that requests attestation data from an
+// underlying provider shortly after the start of each slot, and uses it for
+// attestations unless a new head has been seen since it was requested.
+package prefetch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// prefetchedData is attestation data obtained ahead of the attestation.
+type prefetchedData struct {
+	data *phase0.AttestationData
+	// heads is the number of head events seen when the data was requested.
+	heads uint64
+}
+
+// Service is the provider for attestation data.
+type Service struct {
+	chainTime               chaintime.Service
+	attestationDataProvider eth2client.AttestationDataProvider
+	offset                  time.Duration
+
+	mu         sync.Mutex
+	heads      uint64
+	prefetched map[phase0.Slot]*prefetchedData
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new attestation data strategy.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "attestationdata").Str("impl", "prefetch").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.clientMonitor); err != nil {
+		return nil, errors.Wrap(err, "failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime:               parameters.chainTime,
+		attestationDataProvider: parameters.attestationDataProvider,
+		offset:                  parameters.offset,
+		prefetched:              make(map[phase0.Slot]*prefetchedData),
+	}
+
+	// Subscribe to head events, as a new head invalidates prefetched data.
+	if err := parameters.eventsProvider.Events(ctx, []string{"head"}, s.HandleHeadEvent); err != nil {
+		return nil, errors.Wrap(err, "failed to add head event handler")
+	}
+
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Prefetch",
+		"Prefetch attestation data",
+		s.prefetchRuntime,
+		nil,
+		s.prefetchJob,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to schedule attestation data prefetch")
+	}
+
+	return s, nil
+}
+
+// HandleHeadEvent handles the "head" events from the beacon node.
+func (s *Service) HandleHeadEvent(event *apiv1.Event) {
+	if event.Data == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.heads++
+	s.mu.Unlock()
+}
+
+// prefetchRuntime returns the time of the next prefetch.
+func (s *Service) prefetchRuntime(_ context.Context,
+	_ interface{},
+) (
+	time.Time,
+	error,
+) {
+	slot := s.chainTime.CurrentSlot()
+	runtime := s.chainTime.StartOfSlot(slot).Add(s.offset)
+	if !runtime.After(time.Now()) {
+		runtime = s.chainTime.StartOfSlot(slot + 1).Add(s.offset)
+	}
+
+	return runtime, nil
+}
+
+// prefetchJob prefetches the attestation data for the current slot.
+func (s *Service) prefetchJob(ctx context.Context, _ interface{}) {
+	slot := s.chainTime.CurrentSlot()
+	log := log.With().Uint64("slot", uint64(slot)).Logger()
+
+	s.mu.Lock()
+	heads := s.heads
+	// Housekeep prefetched data.
+	for prefetchedSlot := range s.prefetched {
+		if prefetchedSlot < slot {
+			delete(s.prefetched, prefetchedSlot)
+		}
+	}
+	s.mu.Unlock()
+
+	started := time.Now()
+	attestationDataResponse, err := s.attestationDataProvider.AttestationData(ctx, &api.AttestationDataOpts{
+		Slot: slot,
+	})
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to prefetch attestation data")
+		return
+	}
+	if attestationDataResponse.Data.Slot != slot {
+		log.Debug().Uint64("data_slot", uint64(attestationDataResponse.Data.Slot)).Msg("Prefetched attestation data for incorrect slot")
+		return
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Msg("Prefetched attestation data")
+
+	s.mu.Lock()
+	s.prefetched[slot] = &prefetchedData{
+		data:  attestationDataResponse.Data,
+		heads: heads,
+	}
+	s.mu.Unlock()
+}
@@ -0,0 +1,147 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/attestantio/vouch/strategies/attestationdata/prefetch"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []prefetch.Parameter
+		err    string
+	}{
+		{
+			name: "ClientMonitorMissing",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithClientMonitor(nil),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithEventsProvider(mock.NewEventsProvider()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+			err: "problem with parameters: no client monitor specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithEventsProvider(mock.NewEventsProvider()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithEventsProvider(mock.NewEventsProvider()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "EventsProviderMissing",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+			err: "problem with parameters: no events provider specified",
+		},
+		{
+			name: "AttestationDataProviderMissing",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithEventsProvider(mock.NewEventsProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+			err: "problem with parameters: no attestation data provider specified",
+		},
+		{
+			name: "OffsetMissing",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithEventsProvider(mock.NewEventsProvider()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+			},
+			err: "problem with parameters: offset must be positive",
+		},
+		{
+			name: "EventsProviderErrors",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithEventsProvider(mock.NewErroringEventsProvider()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+			err: "failed to add head event handler: error",
+		},
+		{
+			name: "Good",
+			params: []prefetch.Parameter{
+				prefetch.WithLogLevel(zerolog.Disabled),
+				prefetch.WithChainTime(chainTime),
+				prefetch.WithScheduler(mockscheduler.New()),
+				prefetch.WithEventsProvider(mock.NewEventsProvider()),
+				prefetch.WithAttestationDataProvider(mock.NewAttestationDataProvider()),
+				prefetch.WithOffset(time.Second),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := prefetch.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -58,15 +59,22 @@ func (s *Service) Proposal(ctx context.Context,
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
 	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// Both are reduced if required to fit within the deadline of the duty.
+	timeout := util.BudgetedTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, timeout/2)
 
-	requests := len(s.proposalProviders)
+	proposalProviders := nodemonitor.ExecutionHealthyProviders(ctx, s.nodeMonitor, s.proposalProviders)
+	if len(proposalProviders) != len(s.proposalProviders) {
+		log.Debug().Int("providers", len(s.proposalProviders)).Int("preferred", len(proposalProviders)).Msg("Ignoring proposal providers with unhealthy execution clients")
+	}
+	proposalProviders = nodemonitor.HealthyProviders(ctx, s.nodeMonitor, proposalProviders)
+	requests := len(proposalProviders)
 
 	respCh := make(chan *beaconBlockResponse, requests)
 	errCh := make(chan *beaconBlockError, requests)
 	// Kick off the requests.
-	for name, provider := range s.proposalProviders {
+	for name, provider := range proposalProviders {
 		providerGraffiti := opts.Graffiti[:]
 		if bytes.Contains(providerGraffiti, []byte("{{CLIENT}}")) {
 			if nodeClientProvider, isProvider := provider.(eth2client.NodeClientProvider); isProvider {
@@ -99,6 +107,7 @@ func (s *Service) Proposal(ctx context.Context,
 	bestScore := float64(0)
 	var bestProposal *api.VersionedProposal
 	var bestProvider string
+	candidates := make([]*beaconBlockResponse, 0, requests)
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
@@ -112,6 +121,7 @@ func (s *Service) Proposal(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
+			candidates = append(candidates, resp)
 			if bestProposal == nil || resp.score > bestScore {
 				bestProposal = resp.proposal
 				bestScore = resp.score
@@ -161,6 +171,7 @@ func (s *Service) Proposal(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
+			candidates = append(candidates, resp)
 			if bestProposal == nil || resp.score > bestScore {
 				bestProposal = resp.proposal
 				bestScore = resp.score
@@ -203,6 +214,7 @@ func (s *Service) Proposal(ctx context.Context,
 	if bestProvider != "" {
 		s.clientMonitor.StrategyOperation("best", bestProvider, "beacon block proposal", time.Since(started))
 	}
+	s.retainCandidates(opts.Slot, candidates)
 
 	return &api.Response[*api.VersionedProposal]{
 		Data:     bestProposal,
@@ -25,6 +25,7 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	mockcache "github.com/attestantio/vouch/services/cache/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mocknodemonitor "github.com/attestantio/vouch/services/nodemonitor/mock"
 	"github.com/attestantio/vouch/strategies/beaconblockproposal/best"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
@@ -171,6 +172,46 @@ func TestProposal(t *testing.T) {
 			committeeIndex: 3,
 			logEntries:     []string{"Soft timeout reached with no responses"},
 		},
+		{
+			name: "PreferHealthyExecution",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(2),
+				best.WithSignedBeaconBlockProvider(signedBeaconBlockProvider),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"good":       mock.NewProposalProvider(),
+					"optimistic": mock.NewProposalProvider(),
+				}),
+				best.WithNodeMonitor(mocknodemonitor.NewExecution("optimistic")),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			logEntries:     []string{"Ignoring proposal providers with unhealthy execution clients"},
+		},
+		{
+			name: "AllUnhealthyExecution",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(2),
+				best.WithSignedBeaconBlockProvider(signedBeaconBlockProvider),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"optimistic": mock.NewProposalProvider(),
+				}),
+				best.WithNodeMonitor(mocknodemonitor.NewExecution("optimistic")),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+		},
 	}
 
 	for _, test := range tests {
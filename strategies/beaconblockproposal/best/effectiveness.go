@@ -0,0 +1,244 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// proposalCandidatesRetention is the number of slots for which scored
+// proposal candidates are retained, waiting for the proposed block to
+// be included on chain.
+const proposalCandidatesRetention = phase0.Slot(8)
+
+// proposalContents is a summary of the contents of a block that contribute
+// to its value.
+type proposalContents struct {
+	attestations  uint64
+	syncCommittee uint64
+	execution     uint64
+}
+
+// proposalCandidate is a proposal scored at the time of proposing.
+type proposalCandidate struct {
+	provider string
+	score    float64
+	contents *proposalContents
+}
+
+// retainCandidates retains the scored candidates for a slot, for later
+// comparison against the block included on chain.
+func (s *Service) retainCandidates(slot phase0.Slot, responses []*beaconBlockResponse) {
+	if len(responses) == 0 {
+		return
+	}
+
+	candidates := make([]*proposalCandidate, 0, len(responses))
+	for _, response := range responses {
+		contents, err := contentsOfProposal(response.proposal)
+		if err != nil {
+			log.Debug().Str("provider", response.provider).Err(err).Msg("Failed to obtain contents of proposal; not retaining")
+			continue
+		}
+		candidates = append(candidates, &proposalCandidate{
+			provider: response.provider,
+			score:    response.score,
+			contents: contents,
+		})
+	}
+
+	s.proposalCandidatesMu.Lock()
+	s.proposalCandidates[slot] = candidates
+	for candidateSlot := range s.proposalCandidates {
+		if candidateSlot+proposalCandidatesRetention < slot {
+			delete(s.proposalCandidates, candidateSlot)
+		}
+	}
+	s.proposalCandidatesMu.Unlock()
+}
+
+// checkProposalEffectiveness compares a block included on chain against
+// the candidates scored when it was proposed, if any.
+// This must be called before the votes for the block are added to the
+// prior votes, as otherwise the block's own votes are discounted.
+func (s *Service) checkProposalEffectiveness(ctx context.Context,
+	block *spec.VersionedSignedBeaconBlock,
+) {
+	if block == nil {
+		return
+	}
+	slot, err := block.Slot()
+	if err != nil {
+		return
+	}
+
+	s.proposalCandidatesMu.Lock()
+	candidates, exists := s.proposalCandidates[slot]
+	delete(s.proposalCandidates, slot)
+	s.proposalCandidatesMu.Unlock()
+	if !exists || len(candidates) == 0 {
+		// Not one of our proposals.
+		return
+	}
+	log := log.With().Uint64("slot", uint64(slot)).Logger()
+
+	proposal, err := proposalFromBlock(block)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain proposal from included block")
+		return
+	}
+	contents, err := contentsOfProposal(proposal)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain contents of included block")
+		return
+	}
+	score := s.scoreBeaconBlockProposal(ctx, "included", proposal)
+
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.score > best.score {
+			best = candidate
+		}
+	}
+
+	log.Debug().
+		Str("best_provider", best.provider).
+		Float64("best_score", best.score).
+		Float64("included_score", score).
+		Uint64("best_attestations", best.contents.attestations).
+		Uint64("included_attestations", contents.attestations).
+		Uint64("best_sync_committee", best.contents.syncCommittee).
+		Uint64("included_sync_committee", contents.syncCommittee).
+		Uint64("best_execution", best.contents.execution).
+		Uint64("included_execution", contents.execution).
+		Msg("Included block compared with proposal candidates")
+
+	monitorProposalEffectiveness(score >= best.score,
+		capturedRatio(score, best.score),
+		capturedRatio(float64(contents.attestations), float64(best.contents.attestations)),
+		capturedRatio(float64(contents.syncCommittee), float64(best.contents.syncCommittee)),
+		capturedRatio(float64(contents.execution), float64(best.contents.execution)),
+	)
+}
+
+// capturedRatio returns the ratio of the included value to the best value
+// available.  If there was no value available then the ratio is 1.
+func capturedRatio(included float64, best float64) float64 {
+	if best <= 0 {
+		return 1
+	}
+
+	return included / best
+}
+
+// proposalFromBlock creates a proposal from a signed block, allowing it to
+// be scored in the same way as candidate proposals.
+func proposalFromBlock(block *spec.VersionedSignedBeaconBlock) (*api.VersionedProposal, error) {
+	proposal := &api.VersionedProposal{
+		Version: block.Version,
+	}
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		if block.Phase0 == nil {
+			return nil, errors.New("no phase0 block")
+		}
+		proposal.Phase0 = block.Phase0.Message
+	case spec.DataVersionAltair:
+		if block.Altair == nil {
+			return nil, errors.New("no altair block")
+		}
+		proposal.Altair = block.Altair.Message
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+		proposal.Bellatrix = block.Bellatrix.Message
+	case spec.DataVersionCapella:
+		if block.Capella == nil {
+			return nil, errors.New("no capella block")
+		}
+		proposal.Capella = block.Capella.Message
+	case spec.DataVersionDeneb:
+		if block.Deneb == nil {
+			return nil, errors.New("no deneb block")
+		}
+		proposal.Deneb = &apiv1deneb.BlockContents{
+			Block: block.Deneb.Message,
+		}
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+	if proposal.IsEmpty() {
+		return nil, errors.New("no block message")
+	}
+
+	return proposal, nil
+}
+
+// contentsOfProposal summarises the contents of a proposal.
+// Execution value is based on the gas used, as transactions are opaque.
+func contentsOfProposal(proposal *api.VersionedProposal) (*proposalContents, error) {
+	if proposal == nil {
+		return nil, errors.New("no proposal")
+	}
+	attestations, err := proposal.Attestations()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attestations")
+	}
+
+	contents := &proposalContents{}
+	for _, attestation := range attestations {
+		if attestation.AggregationBits != nil {
+			contents.attestations += attestation.AggregationBits.Count()
+		}
+	}
+
+	var syncAggregate *altair.SyncAggregate
+	switch proposal.Version {
+	case spec.DataVersionPhase0:
+	case spec.DataVersionAltair:
+		syncAggregate = proposal.Altair.Body.SyncAggregate
+	case spec.DataVersionBellatrix:
+		syncAggregate = proposal.Bellatrix.Body.SyncAggregate
+		if proposal.Bellatrix.Body.ExecutionPayload != nil {
+			contents.execution = proposal.Bellatrix.Body.ExecutionPayload.GasUsed
+		}
+	case spec.DataVersionCapella:
+		syncAggregate = proposal.Capella.Body.SyncAggregate
+		if proposal.Capella.Body.ExecutionPayload != nil {
+			contents.execution = proposal.Capella.Body.ExecutionPayload.GasUsed
+		}
+	case spec.DataVersionDeneb:
+		syncAggregate = proposal.Deneb.Block.Body.SyncAggregate
+		if proposal.Deneb.Block.Body.ExecutionPayload != nil {
+			contents.execution = proposal.Deneb.Block.Body.ExecutionPayload.GasUsed +
+				proposal.Deneb.Block.Body.ExecutionPayload.BlobGasUsed
+		}
+	default:
+		return nil, fmt.Errorf("unhandled proposal version %v", proposal.Version)
+	}
+	if syncAggregate != nil {
+		contents.syncCommittee = syncAggregate.SyncCommitteeBits.Count()
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,199 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/attestantio/vouch/testutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func altairEffectivenessBlock(slot phase0.Slot, attested uint64, synced uint64) *altair.BeaconBlock {
+	syncCommitteeBits := bitfield.NewBitvector512()
+	for i := uint64(0); i < synced; i++ {
+		syncCommitteeBits.SetBitAt(i, true)
+	}
+
+	return &altair.BeaconBlock{
+		Slot:       slot,
+		ParentRoot: testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101"),
+		StateRoot:  testutil.HexToRoot("0x0202020202020202020202020202020202020202020202020202020202020202"),
+		Body: &altair.BeaconBlockBody{
+			ETH1Data: &phase0.ETH1Data{},
+			Attestations: []*phase0.Attestation{
+				{
+					AggregationBits: bitList(attested, 128),
+					Data: &phase0.AttestationData{
+						Slot:            slot - 1,
+						BeaconBlockRoot: testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101"),
+						Source:          &phase0.Checkpoint{},
+						Target:          &phase0.Checkpoint{},
+					},
+				},
+			},
+			SyncAggregate: &altair.SyncAggregate{
+				SyncCommitteeBits: syncCommitteeBits,
+			},
+		},
+	}
+}
+
+func TestContentsOfProposal(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposal *api.VersionedProposal
+		contents *proposalContents
+		err      string
+	}{
+		{
+			name: "Nil",
+			err:  "no proposal",
+		},
+		{
+			name:     "Empty",
+			proposal: &api.VersionedProposal{},
+			err:      "failed to obtain attestations: unsupported version",
+		},
+		{
+			name: "Altair",
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionAltair,
+				Altair:  altairEffectivenessBlock(100, 10, 20),
+			},
+			contents: &proposalContents{
+				attestations:  10,
+				syncCommittee: 20,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contents, err := contentsOfProposal(test.proposal)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.contents, contents)
+			}
+		})
+	}
+}
+
+func TestCheckProposalEffectiveness(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	cacheSvc := mockcache.New(map[phase0.Root]phase0.Slot{})
+	blockToSlotCache := cacheSvc.(cache.BlockRootToSlotProvider)
+
+	capture := logger.NewLogCapture()
+	s, err := New(ctx,
+		WithLogLevel(zerolog.TraceLevel),
+		WithTimeout(2*time.Second),
+		WithClientMonitor(null.New(context.Background())),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithChainTimeService(chainTime),
+		WithSpecProvider(specProvider),
+		WithProcessConcurrency(6),
+		WithProposalProviders(map[string]eth2client.ProposalProvider{
+			"one": mock.NewProposalProvider(),
+		}),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		WithBlockRootToSlotCache(blockToSlotCache),
+	)
+	require.NoError(t, err)
+
+	candidates := []*beaconBlockResponse{
+		{
+			provider: "one",
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionAltair,
+				Altair:  altairEffectivenessBlock(100, 10, 20),
+			},
+			score: 10,
+		},
+		{
+			provider: "two",
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionAltair,
+				Altair:  altairEffectivenessBlock(100, 20, 40),
+			},
+			score: 20,
+		},
+	}
+	s.retainCandidates(100, candidates)
+	require.Len(t, s.proposalCandidates[100], 2)
+
+	// Candidates for old slots should be pruned.
+	s.retainCandidates(100+proposalCandidatesRetention+1, candidates)
+	require.NotContains(t, s.proposalCandidates, phase0.Slot(100))
+	s.retainCandidates(100, candidates)
+
+	// Nil block should be ignored.
+	s.checkProposalEffectiveness(ctx, nil)
+
+	// Block for a slot without candidates should be ignored.
+	s.checkProposalEffectiveness(ctx, &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.SignedBeaconBlock{
+			Message: altairEffectivenessBlock(99, 10, 20),
+		},
+	})
+	require.False(t, capture.HasLog(map[string]interface{}{
+		"message": "Included block compared with proposal candidates",
+	}))
+
+	// Block for a slot with candidates should be compared, and the candidates released.
+	s.checkProposalEffectiveness(ctx, &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.SignedBeaconBlock{
+			Message: altairEffectivenessBlock(100, 10, 20),
+		},
+	})
+	capture.AssertHasEntry(t, "Included block compared with proposal candidates")
+	require.NotContains(t, s.proposalCandidates, phase0.Slot(100))
+}
+
+func TestCapturedRatio(t *testing.T) {
+	require.Equal(t, 1.0, capturedRatio(0, 0))
+	require.Equal(t, 1.0, capturedRatio(10, 0))
+	require.Equal(t, 0.5, capturedRatio(10, 20))
+	require.Equal(t, 2.0, capturedRatio(20, 10))
+}
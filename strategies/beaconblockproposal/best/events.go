@@ -61,6 +61,7 @@ func (s *Service) HandleHeadEvent(event *apiv1.Event) {
 	}
 	block := blockResponse.Data
 
+	s.checkProposalEffectiveness(ctx, block)
 	s.updateBlockVotes(ctx, block)
 }
 
@@ -0,0 +1,105 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	includedProposals   *prometheus.CounterVec
+	includedValueRatios *prometheus.HistogramVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.ClientMonitor) error {
+	if includedProposals != nil {
+		// Already registered.
+		return nil
+	}
+	service, isService := monitor.(metrics.Service)
+	if !isService {
+		// No monitor.
+		return nil
+	}
+	if service.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	includedProposals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal",
+		Name:      "included_total",
+		Help:      "The number of proposed blocks included on chain, and if they were at least as valuable as the best candidate.",
+	}, []string{"result"})
+	if err := prometheus.Register(includedProposals); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			includedProposals = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return errors.Wrap(err, "failed to register vouch_beaconblockproposal_included_total")
+		}
+	}
+
+	includedValueRatios = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal",
+		Name:      "included_value_ratio",
+		Help:      "The value of included blocks relative to the best candidate.",
+		Buckets: []float64{
+			0.5, 0.8, 0.9, 0.95, 0.99, 1.0,
+			1.01, 1.05, 1.1, 1.5,
+		},
+	}, []string{"component"})
+	if err := prometheus.Register(includedValueRatios); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			includedValueRatios = alreadyRegisteredError.ExistingCollector.(*prometheus.HistogramVec)
+		} else {
+			return errors.Wrap(err, "failed to register vouch_beaconblockproposal_included_value_ratio")
+		}
+	}
+
+	return nil
+}
+
+// monitorProposalEffectiveness provides metrics for the value captured by
+// an included block relative to the best candidate.
+func monitorProposalEffectiveness(best bool,
+	total float64,
+	attestations float64,
+	syncCommittee float64,
+	execution float64,
+) {
+	if includedProposals == nil {
+		// Not yet registered.
+		return
+	}
+
+	if best {
+		includedProposals.WithLabelValues("best").Inc()
+	} else {
+		includedProposals.WithLabelValues("below_best").Inc()
+	}
+	includedValueRatios.WithLabelValues("total").Observe(total)
+	includedValueRatios.WithLabelValues("attestations").Observe(attestations)
+	includedValueRatios.WithLabelValues("sync_committee").Observe(syncCommittee)
+	includedValueRatios.WithLabelValues("execution").Observe(execution)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -36,6 +37,7 @@ type parameters struct {
 	chainTime                 chaintime.Service
 	specProvider              eth2client.SpecProvider
 	proposalProviders         map[string]eth2client.ProposalProvider
+	nodeMonitor               nodemonitor.Service
 	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
 	timeout                   time.Duration
 	blockRootToSlotCache      cache.BlockRootToSlotProvider
@@ -130,6 +132,13 @@ func WithExecutionPayloadFactor(factor float64) Parameter {
 	})
 }
 
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
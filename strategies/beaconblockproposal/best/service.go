@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rs/zerolog"
@@ -36,6 +37,7 @@ type Service struct {
 	processConcurrency        int64
 	chainTime                 chaintime.Service
 	proposalProviders         map[string]eth2client.ProposalProvider
+	nodeMonitor               nodemonitor.Service
 	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
 	timeout                   time.Duration
 	blockRootToSlotCache      cache.BlockRootToSlotProvider
@@ -52,6 +54,9 @@ type Service struct {
 
 	priorBlocksVotes   map[phase0.Root]*priorBlockVotes
 	priorBlocksVotesMu sync.RWMutex
+
+	proposalCandidates   map[phase0.Slot][]*proposalCandidate
+	proposalCandidatesMu sync.Mutex
 }
 
 type priorBlockVotes struct {
@@ -157,6 +162,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		processConcurrency:        parameters.processConcurrency,
 		chainTime:                 parameters.chainTime,
 		proposalProviders:         parameters.proposalProviders,
+		nodeMonitor:               parameters.nodeMonitor,
 		signedBeaconBlockProvider: parameters.signedBeaconBlockProvider,
 		timeout:                   parameters.timeout,
 		blockRootToSlotCache:      parameters.blockRootToSlotCache,
@@ -169,10 +175,15 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		proposerWeight:            proposerWeight,
 		weightDenominator:         weightDenominator,
 		priorBlocksVotes:          make(map[phase0.Root]*priorBlockVotes),
+		proposalCandidates:        make(map[phase0.Slot][]*proposalCandidate),
 		executionPayloadFactor:    parameters.executionPayloadFactor,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
+	if err := registerMetrics(ctx, s.clientMonitor); err != nil {
+		return nil, errors.Wrap(err, "failed to register metrics")
+	}
+
 	// Subscribe to head events.  This allows us to go early for attestations if a block arrives, as well as
 	// re-request duties if there is a change in beacon block.
 	// This also allows us to re-request duties if the dependent roots change.
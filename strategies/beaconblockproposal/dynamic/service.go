@@ -0,0 +1,91 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a proposal provider that passes requests to a provider that
+// can be replaced at runtime, allowing the underlying strategy to be rebuilt
+// when the set of beacon nodes changes.
+type Service struct {
+	mu               sync.RWMutex
+	proposalProvider eth2client.ProposalProvider
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new dynamic proposal strategy.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "beaconblockproposal").Str("impl", "dynamic").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		proposalProvider: parameters.proposalProvider,
+	}, nil
+}
+
+// SetProposalProvider replaces the proposal provider.  Requests in progress
+// complete with the previous provider.
+func (s *Service) SetProposalProvider(provider eth2client.ProposalProvider) {
+	s.mu.Lock()
+	s.proposalProvider = provider
+	s.mu.Unlock()
+	log.Trace().Msg("Replaced proposal provider")
+}
+
+// provider returns the current proposal provider.
+func (s *Service) provider() eth2client.ProposalProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.proposalProvider
+}
+
+// Proposal provides the proposal from the current provider.
+func (s *Service) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	return s.provider().Proposal(ctx, opts)
+}
+
+// NodeClient provides the client for the node, if the current provider supplies it.
+func (s *Service) NodeClient(ctx context.Context) (*api.Response[string], error) {
+	nodeClientProvider, isProvider := s.provider().(eth2client.NodeClientProvider)
+	if !isProvider {
+		return nil, errors.New("proposal provider does not provide node client")
+	}
+
+	return nodeClientProvider.NodeClient(ctx)
+}
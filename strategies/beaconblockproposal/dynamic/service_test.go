@@ -0,0 +1,77 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic_test
+
+import (
+	"context"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/beaconblockproposal/dynamic"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []dynamic.Parameter
+		err    string
+	}{
+		{
+			name: "ProviderMissing",
+			params: []dynamic.Parameter{
+				dynamic.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no proposal provider specified",
+		},
+		{
+			name: "Good",
+			params: []dynamic.Parameter{
+				dynamic.WithLogLevel(zerolog.Disabled),
+				dynamic.WithProposalProvider(mock.NewErroringProposalProvider()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := dynamic.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetProposalProvider(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := dynamic.New(ctx,
+		dynamic.WithLogLevel(zerolog.Disabled),
+		dynamic.WithProposalProvider(mock.NewErroringProposalProvider()),
+	)
+	require.NoError(t, err)
+
+	_, err = s.Proposal(ctx, &api.ProposalOpts{Slot: 1})
+	require.Error(t, err)
+
+	s.SetProposalProvider(mock.NewProposalProvider())
+	_, err = s.Proposal(ctx, &api.ProposalOpts{Slot: 1})
+	require.NoError(t, err)
+}
@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -32,6 +33,7 @@ import (
 type Service struct {
 	clientMonitor     metrics.ClientMonitor
 	proposalProviders map[string]eth2client.ProposalProvider
+	nodeMonitor       nodemonitor.Service
 	timeout           time.Duration
 }
 
@@ -53,6 +55,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 
 	s := &Service{
 		proposalProviders: parameters.proposalProviders,
+		nodeMonitor:       parameters.nodeMonitor,
 		timeout:           parameters.timeout,
 		clientMonitor:     parameters.clientMonitor,
 	}
@@ -76,8 +79,14 @@ func (s *Service) Proposal(ctx context.Context,
 	// cancel the context to cancel the other requests.
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 
+	proposalProviders := nodemonitor.ExecutionHealthyProviders(ctx, s.nodeMonitor, s.proposalProviders)
+	if len(proposalProviders) != len(s.proposalProviders) {
+		log.Debug().Int("providers", len(s.proposalProviders)).Int("preferred", len(proposalProviders)).Msg("Ignoring proposal providers with unhealthy execution clients")
+	}
+	proposalProviders = nodemonitor.HealthyProviders(ctx, s.nodeMonitor, proposalProviders)
+
 	proposalCh := make(chan *api.VersionedProposal, 1)
-	for name, provider := range s.proposalProviders {
+	for name, provider := range proposalProviders {
 		go func(ctx context.Context, name string, provider eth2client.ProposalProvider, ch chan *api.VersionedProposal) {
 			log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Logger()
 
@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -46,7 +47,7 @@ func (s *Service) BeaconBlockRoot(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 
 	respCh := make(chan *api.Response[*phase0.Root], 1)
-	for name, provider := range s.beaconBlockRootProviders {
+	for name, provider := range nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.beaconBlockRootProviders) {
 		go func(ctx context.Context,
 			name string,
 			provider eth2client.BeaconBlockRootProvider,
@@ -19,6 +19,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -29,6 +30,7 @@ type Service struct {
 	log                      zerolog.Logger
 	clientMonitor            metrics.ClientMonitor
 	beaconBlockRootProviders map[string]eth2client.BeaconBlockRootProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 }
 
@@ -48,6 +50,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	s := &Service{
 		log:                      log,
 		beaconBlockRootProviders: parameters.beaconBlockRootProviders,
+		nodeMonitor:              parameters.nodeMonitor,
 		timeout:                  parameters.timeout,
 		clientMonitor:            parameters.clientMonitor,
 	}
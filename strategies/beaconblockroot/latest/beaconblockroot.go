@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -60,12 +61,13 @@ func (s *Service) BeaconBlockRoot(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
 
-	requests := len(s.beaconBlockRootProviders)
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.beaconBlockRootProviders)
+	requests := len(providers)
 
 	respCh := make(chan *beaconBlockRootResponse, requests)
 	errCh := make(chan *beaconBlockRootError, requests)
 	// Kick off the requests.
-	for name, provider := range s.beaconBlockRootProviders {
+	for name, provider := range providers {
 		go s.beaconBlockRoot(ctx, started, name, provider, respCh, errCh, opts)
 	}
 
@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -33,6 +34,7 @@ type parameters struct {
 	clientMonitor            metrics.ClientMonitor
 	processConcurrency       int64
 	beaconBlockRootProviders map[string]eth2client.BeaconBlockRootProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
 }
@@ -90,6 +92,13 @@ func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
 	})
 }
 
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -31,6 +32,7 @@ type Service struct {
 	clientMonitor            metrics.ClientMonitor
 	processConcurrency       int64
 	beaconBlockRootProviders map[string]eth2client.BeaconBlockRootProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
 }
@@ -54,6 +56,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		clientMonitor:            parameters.clientMonitor,
 		processConcurrency:       parameters.processConcurrency,
 		beaconBlockRootProviders: parameters.beaconBlockRootProviders,
+		nodeMonitor:              parameters.nodeMonitor,
 		blockRootToSlotCache:     parameters.blockRootToSlotCache,
 	}
 	s.log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
@@ -23,6 +23,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -77,12 +78,13 @@ func (s *Service) BlindedProposalWithExpectedPayload(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
 
-	requests := len(s.blindedProposalProviders)
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.blindedProposalProviders)
+	requests := len(providers)
 
 	respCh := make(chan *beaconBlockResponse, requests)
 	errCh := make(chan *beaconBlockError, requests)
 	// Kick off the requests.
-	for name, provider := range s.blindedProposalProviders {
+	for name, provider := range providers {
 		providerGraffiti := opts.Graffiti[:]
 		if bytes.Contains(providerGraffiti, []byte("{{CLIENT}}")) {
 			if nodeClientProvider, isProvider := provider.(eth2client.NodeClientProvider); isProvider {
@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -36,6 +37,7 @@ type parameters struct {
 	chainTime                 chaintime.Service
 	specProvider              eth2client.SpecProvider
 	blindedProposalProviders  map[string]eth2client.BlindedProposalProvider
+	nodeMonitor               nodemonitor.Service
 	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
 	timeout                   time.Duration
 	blockRootToSlotCache      cache.BlockRootToSlotProvider
@@ -122,6 +124,13 @@ func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
 	})
 }
 
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rs/zerolog"
@@ -36,6 +37,7 @@ type Service struct {
 	processConcurrency        int64
 	chainTime                 chaintime.Service
 	blindedProposalProviders  map[string]eth2client.BlindedProposalProvider
+	nodeMonitor               nodemonitor.Service
 	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
 	timeout                   time.Duration
 	blockRootToSlotCache      cache.BlockRootToSlotProvider
@@ -156,6 +158,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		processConcurrency:        parameters.processConcurrency,
 		chainTime:                 parameters.chainTime,
 		blindedProposalProviders:  parameters.blindedProposalProviders,
+		nodeMonitor:               parameters.nodeMonitor,
 		signedBeaconBlockProvider: parameters.signedBeaconBlockProvider,
 		timeout:                   parameters.timeout,
 		blockRootToSlotCache:      parameters.blockRootToSlotCache,
@@ -0,0 +1,81 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a blinded proposal provider that passes requests to a provider that
+// can be replaced at runtime, allowing the underlying strategy to be rebuilt
+// when the set of beacon nodes changes.
+type Service struct {
+	mu                      sync.RWMutex
+	blindedProposalProvider eth2client.BlindedProposalProvider
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new dynamic blinded proposal strategy.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "blindedbeaconblockproposal").Str("impl", "dynamic").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		blindedProposalProvider: parameters.blindedProposalProvider,
+	}, nil
+}
+
+// SetBlindedProposalProvider replaces the blinded proposal provider.  Requests in progress
+// complete with the previous provider.
+func (s *Service) SetBlindedProposalProvider(provider eth2client.BlindedProposalProvider) {
+	s.mu.Lock()
+	s.blindedProposalProvider = provider
+	s.mu.Unlock()
+	log.Trace().Msg("Replaced blinded proposal provider")
+}
+
+// provider returns the current blinded proposal provider.
+func (s *Service) provider() eth2client.BlindedProposalProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.blindedProposalProvider
+}
+
+// BlindedProposal provides the blinded proposal from the current provider.
+func (s *Service) BlindedProposal(ctx context.Context,
+	opts *api.BlindedProposalOpts,
+) (
+	*api.Response[*api.VersionedBlindedProposal],
+	error,
+) {
+	return s.provider().BlindedProposal(ctx, opts)
+}
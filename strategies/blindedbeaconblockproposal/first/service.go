@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -37,6 +38,7 @@ type Service struct {
 	clientMonitor            metrics.ClientMonitor
 	chainTime                chaintime.Service
 	blindedProposalProviders map[string]eth2client.BlindedProposalProvider
+	nodeMonitor              nodemonitor.Service
 	timeout                  time.Duration
 }
 
@@ -61,6 +63,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	s := &Service{
 		chainTime:                parameters.chainTime,
 		blindedProposalProviders: parameters.blindedProposalProviders,
+		nodeMonitor:              parameters.nodeMonitor,
 		timeout:                  parameters.timeout,
 		clientMonitor:            parameters.clientMonitor,
 	}
@@ -96,7 +99,7 @@ func (s *Service) BlindedProposalWithExpectedPayload(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 
 	proposalCh := make(chan *api.Response[*api.VersionedBlindedProposal], 1)
-	for name, provider := range s.blindedProposalProviders {
+	for name, provider := range nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.blindedProposalProviders) {
 		go func(ctx context.Context, name string, provider eth2client.BlindedProposalProvider, ch chan *api.Response[*api.VersionedBlindedProposal]) {
 			log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Logger()
 
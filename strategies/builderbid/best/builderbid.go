@@ -28,10 +28,11 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	"github.com/attestantio/vouch/util"
+	"github.com/attestantio/vouch/util/builders"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
-	e2types "github.com/wealdtech/go-eth2-types/v2"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -44,9 +45,18 @@ var zeroExecutionAddress bellatrix.ExecutionAddress
 var zeroValue uint256.Int
 
 type builderBidResponse struct {
-	provider builderclient.BuilderBidProvider
-	bid      *builderspec.VersionedSignedBuilderBid
-	score    *big.Int
+	provider  builderclient.BuilderBidProvider
+	bid       *builderspec.VersionedSignedBuilderBid
+	score     *big.Int
+	signature *bidSignature
+	censoring bool
+}
+
+// bidSignature contains the information required to verify the signature of a bid.
+type bidSignature struct {
+	pubkey      *bls.PublicKey
+	signingRoot phase0.Root
+	sig         *bls.Sign
 }
 
 type builderBidError struct {
@@ -75,33 +85,62 @@ func (s *Service) BuilderBid(ctx context.Context,
 		Values:       make(map[string]*big.Int),
 		Providers:    make([]builderclient.BuilderBidProvider, 0),
 	}
-	requests := len(proposerConfig.Relays)
-
-	// We have two timeouts: a soft timeout and a hard timeout.
-	// At the soft timeout, we return if we have any responses so far.
-	// At the hard timeout, we return unconditionally.
-	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
-
-	respCh := make(chan *builderBidResponse, requests)
-	errCh := make(chan *builderBidError, requests)
-	// Kick off the requests.
-	for _, relay := range proposerConfig.Relays {
-		builderClient, err := util.FetchBuilderClient(ctx, relay.Address, s.monitor, s.releaseVersion)
-		if err != nil {
-			// Error but continue.
-			log.Error().Err(err).Msg("Failed to obtain builder client for block auction")
-			continue
+	relayGroups := s.relayGroups(proposerConfig.Relays)
+	requests := len(relayGroups)
+
+	// The auction has two deadlines: a soft timeout and a hard timeout.
+	// The auction closes early if all relays have responded.
+	// At the soft timeout, the auction closes if we have any verified bids so far.
+	// After the soft timeout, the auction closes as soon as a verified bid is received.
+	// At the hard timeout, the auction closes unconditionally.
+	// Both are reduced if required to fit within the deadline of the duty.
+	timeout := util.BudgetedTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, s.budgetedSoftTimeout(timeout))
+
+	// Channels are sized for every relay, as fallbacks mean that there can be
+	// more than one message for each request.
+	respCh := make(chan *builderBidResponse, len(proposerConfig.Relays))
+	errCh := make(chan *builderBidError, len(proposerConfig.Relays))
+
+	// fallbacks are the remaining relays in a group, keyed by the address of the
+	// relay in the group that is currently being queried.
+	fallbacks := make(map[string][]*beaconblockproposer.RelayConfig)
+	startBuilderBid := func(relays []*beaconblockproposer.RelayConfig) bool {
+		for i, relay := range relays {
+			builderClient, err := builders.FetchClient(ctx, relay.Address, s.monitor, s.releaseVersion)
+			if err != nil {
+				// Error but continue.
+				log.Error().Err(err).Msg("Failed to obtain builder client for block auction")
+				continue
+			}
+			provider, isProvider := builderClient.(builderclient.BuilderBidProvider)
+			if !isProvider {
+				// Error but continue.
+				log.Error().Err(err).Msg("Builder client does not supply builder bids")
+				continue
+			}
+			res.AllProviders = append(res.AllProviders, provider)
+			if i < len(relays)-1 {
+				fallbacks[provider.Address()] = relays[i+1:]
+			}
+			go s.builderBid(ctx, provider, respCh, errCh, slot, parentHash, pubkey, relay, excludedBuilders)
+			return true
 		}
-		provider, isProvider := builderClient.(builderclient.BuilderBidProvider)
-		if !isProvider {
-			// Error but continue.
-			log.Error().Err(err).Msg("Builder client does not supply builder bids")
-			continue
+		return false
+	}
+	fallBack := func(provider builderclient.BuilderBidProvider) bool {
+		relays, exists := fallbacks[provider.Address()]
+		if !exists {
+			return false
 		}
-		res.AllProviders = append(res.AllProviders, provider)
-		go s.builderBid(ctx, provider, respCh, errCh, slot, parentHash, pubkey, relay, excludedBuilders)
+		delete(fallbacks, provider.Address())
+		return startBuilderBid(relays)
+	}
+
+	// Kick off the requests.
+	for _, relays := range relayGroups {
+		startBuilderBid(relays)
 	}
 
 	// Wait for all responses (or context done).
@@ -109,7 +148,14 @@ func (s *Service) BuilderBid(ctx context.Context,
 	errored := 0
 	timedOut := 0
 	softTimedOut := 0
-	bestScore := big.NewInt(0)
+	// Signatures of bids are verified together where possible, but a bid
+	// only counts towards closing the auction early once it is verified.
+	pending := make([]*builderBidResponse, 0, len(proposerConfig.Relays))
+	bids := make([]*builderBidResponse, 0, len(proposerConfig.Relays))
+	verifyPending := func() {
+		bids = append(bids, s.verifyBidSignatures(ctx, pending)...)
+		pending = pending[:0]
+	}
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
@@ -121,29 +167,22 @@ func (s *Service) BuilderBid(ctx context.Context,
 				// This means that the bid was ineligible, for example the bid value was too small.
 				continue
 			}
-			switch {
-			case resp.score.Cmp(bestScore) > 0:
-				log.Trace().Str("provider", resp.provider.Address()).Stringer("score", resp.score).Msg("New winning bid")
-				res.Bid = resp.bid
-				bestScore = resp.score
-				res.Providers = []builderclient.BuilderBidProvider{resp.provider}
-			case res.Bid != nil && resp.score.Cmp(bestScore) == 0 && bidsEqual(res.Bid, resp.bid):
-				log.Trace().Str("provider", resp.provider.Address()).Msg("Matching bid from different relay")
-				res.Providers = append(res.Providers, resp.provider)
-			default:
-				log.Trace().Str("provider", resp.provider.Address()).Stringer("score", resp.score).Msg("Low or slow bid")
-			}
-			res.Values[resp.provider.Address()] = resp.score
+			pending = append(pending, resp)
 		case err := <-errCh:
+			if fallBack(err.provider) {
+				log.Debug().Dur("elapsed", time.Since(started)).Str("provider", err.provider.Address()).Err(err.err).Msg("Error received; falling back to alternative relay")
+				continue
+			}
 			errored++
 			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Str("provider", err.provider.Address()).Err(err.err).Msg("Error received")
 		case <-softCtx.Done():
-			// If we have any responses at this point we consider the non-responders timed out.
-			if responded > 0 {
+			// If we have any verified bids at this point we consider the non-responders timed out.
+			verifyPending()
+			if len(bids) > 0 {
 				timedOut = requests - responded - errored
-				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Soft timeout reached with responses")
+				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Soft timeout reached with bids")
 			} else {
-				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
+				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with no bids")
 			}
 			// Set the number of requests that have soft timed out.
 			softTimedOut = requests - responded - errored - timedOut
@@ -151,7 +190,7 @@ func (s *Service) BuilderBid(ctx context.Context,
 	}
 	softCancel()
 
-	// Loop 2: after soft timeout.
+	// Loop 2: after soft timeout, only entered if no verified bids have been received.
 	for responded+errored+timedOut != requests {
 		select {
 		case resp := <-respCh:
@@ -161,20 +200,19 @@ func (s *Service) BuilderBid(ctx context.Context,
 				// This means that the bid was ineligible, for example the bid value was too small.
 				continue
 			}
-			switch {
-			case resp.score.Cmp(bestScore) > 0:
-				log.Trace().Str("provider", resp.provider.Address()).Stringer("score", resp.score).Msg("New winning bid")
-				res.Bid = resp.bid
-				bestScore = resp.score
-				res.Providers = []builderclient.BuilderBidProvider{resp.provider}
-			case res.Bid != nil && resp.score.Cmp(bestScore) == 0 && bidsEqual(res.Bid, resp.bid):
-				log.Trace().Str("provider", resp.provider.Address()).Msg("Matching bid from different relay")
-				res.Providers = append(res.Providers, resp.provider)
-			default:
-				log.Trace().Str("provider", resp.provider.Address()).Stringer("score", resp.score).Msg("Low or slow bid")
+			pending = append(pending, resp)
+			verifyPending()
+			if len(bids) == 0 {
+				continue
 			}
-			res.Values[resp.provider.Address()] = resp.score
+			// We have a verified bid, so consider the non-responders timed out.
+			timedOut = requests - responded - errored
+			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Bid received after soft timeout")
 		case err := <-errCh:
+			if fallBack(err.provider) {
+				log.Debug().Dur("elapsed", time.Since(started)).Str("provider", err.provider.Address()).Err(err.err).Msg("Error received; falling back to alternative relay")
+				continue
+			}
 			errored++
 			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Str("provider", err.provider.Address()).Err(err.err).Msg("Error received")
 		case <-ctx.Done():
@@ -186,6 +224,29 @@ func (s *Service) BuilderBid(ctx context.Context,
 	cancel()
 	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Results")
 
+	// Verify the signatures of any remaining bids together before selecting the best.
+	verifyPending()
+	bestScore := big.NewInt(0)
+	for _, resp := range bids {
+		switch {
+		case resp.score.Cmp(bestScore) > 0:
+			log.Trace().Str("provider", resp.provider.Address()).Stringer("score", resp.score).Msg("New winning bid")
+			res.Bid = resp.bid
+			bestScore = resp.score
+			res.Providers = []builderclient.BuilderBidProvider{resp.provider}
+		case res.Bid != nil && resp.score.Cmp(bestScore) == 0 && bidsEqual(res.Bid, resp.bid):
+			log.Trace().Str("provider", resp.provider.Address()).Msg("Matching bid from different relay")
+			res.Providers = append(res.Providers, resp.provider)
+		default:
+			log.Trace().Str("provider", resp.provider.Address()).Stringer("score", resp.score).Msg("Low or slow bid")
+		}
+		res.Values[resp.provider.Address()] = resp.score
+	}
+
+	if s.censorshipResistance && res.Bid != nil {
+		s.preferNonCensoringBid(res, bestScore, bids)
+	}
+
 	if res.Bid == nil {
 		log.Debug().Msg("No useful bids received")
 		monitorAuctionBlock("", false, time.Since(started))
@@ -201,6 +262,16 @@ func (s *Service) BuilderBid(ctx context.Context,
 	return res, nil
 }
 
+// budgetedSoftTimeout returns the soft timeout for an auction with the given
+// hard timeout, scaled down in proportion if the hard timeout has been reduced.
+func (s *Service) budgetedSoftTimeout(timeout time.Duration) time.Duration {
+	if timeout >= s.timeout {
+		return s.softTimeout
+	}
+
+	return time.Duration(float64(s.softTimeout) * float64(timeout) / float64(s.timeout))
+}
+
 func (s *Service) builderBid(ctx context.Context,
 	provider builderclient.BuilderBidProvider,
 	respCh chan *builderBidResponse,
@@ -221,8 +292,10 @@ func (s *Service) builderBid(ctx context.Context,
 		span.AddEvent("grace period over")
 	}
 
+	started := time.Now()
 	builderBid, err := s.obtainBid(ctx, provider, slot, parentHash, pubkey)
 	if err != nil {
+		monitorBidLatency(provider.Address(), "failed", time.Since(started))
 		errCh <- &builderBidError{
 			provider: provider,
 			err:      err,
@@ -230,12 +303,14 @@ func (s *Service) builderBid(ctx context.Context,
 		return
 	}
 	if builderBid == nil {
+		monitorBidLatency(provider.Address(), "empty", time.Since(started))
 		respCh <- &builderBidResponse{
 			provider: provider,
 			score:    big.NewInt(0),
 		}
 		return
 	}
+	monitorBidLatency(provider.Address(), "succeeded", time.Since(started))
 
 	if len(excludedBuilders) > 0 {
 		builder, err := builderBid.Builder()
@@ -266,6 +341,7 @@ func (s *Service) builderBid(ctx context.Context,
 		}
 		return
 	}
+	monitorBidValue(provider.Address(), value.ToBig())
 
 	if value.ToBig().Cmp(relayConfig.MinValue.BigInt()) < 0 {
 		log.Debug().Stringer("value", value.ToBig()).Stringer("min_value", relayConfig.MinValue.BigInt()).Msg("Value below minimum; ignoring")
@@ -276,7 +352,20 @@ func (s *Service) builderBid(ctx context.Context,
 		return
 	}
 
-	if err := s.verifyBidDetails(ctx, builderBid, slot, relayConfig, provider); err != nil {
+	if reason, err := s.verifyBidDetails(ctx, builderBid, slot, parentHash); err != nil {
+		monitorInvalidBid(provider.Address(), reason)
+		errCh <- &builderBidError{
+			provider: provider,
+			err:      err,
+		}
+		return
+	}
+
+	// The signature is verified along with those of other bids before the bid
+	// counts towards closing the auction.
+	signature, err := s.bidSignature(ctx, relayConfig, builderBid, provider)
+	if err != nil {
+		monitorInvalidBid(provider.Address(), "signature")
 		errCh <- &builderBidError{
 			provider: provider,
 			err:      err,
@@ -285,9 +374,11 @@ func (s *Service) builderBid(ctx context.Context,
 	}
 
 	respCh <- &builderBidResponse{
-		bid:      builderBid,
-		provider: provider,
-		score:    value.ToBig(),
+		bid:       builderBid,
+		provider:  provider,
+		score:     value.ToBig(),
+		signature: signature,
+		censoring: relayConfig.Censoring,
 	}
 }
 
@@ -341,50 +432,54 @@ func (*Service) getBidValue(_ context.Context,
 	return value, nil
 }
 
-func (s *Service) verifyBidDetails(ctx context.Context,
+// verifyBidDetails verifies that the details of the bid match those expected.
+// If the bid is invalid the reason is returned along with the error.
+func (s *Service) verifyBidDetails(_ context.Context,
 	bid *builderspec.VersionedSignedBuilderBid,
 	slot phase0.Slot,
-	relayConfig *beaconblockproposer.RelayConfig,
-	provider builderclient.BuilderBidProvider,
-) error {
-	feeRecipient, err := bid.FeeRecipient()
+	parentHash phase0.Hash32,
+) (
+	string,
+	error,
+) {
+	bidParentHash, err := bid.ParentHash()
 	if err != nil {
-		return errors.Wrap(err, "failed to obtain builder bid fee recipient")
+		return "malformed", errors.Wrap(err, "failed to obtain builder bid parent hash")
 	}
-	if bytes.Equal(feeRecipient[:], zeroExecutionAddress[:]) {
-		return errors.New("zero fee recipient")
+	if !bytes.Equal(bidParentHash[:], parentHash[:]) {
+		return "parent_hash", fmt.Errorf("provided parent hash %#x not expected value of %#x", bidParentHash, parentHash)
 	}
 
-	timestamp, err := bid.Timestamp()
+	feeRecipient, err := bid.FeeRecipient()
 	if err != nil {
-		return errors.Wrap(err, "failed to obtain builder bid timestamp")
+		return "malformed", errors.Wrap(err, "failed to obtain builder bid fee recipient")
 	}
-	if uint64(s.chainTime.StartOfSlot(slot).Unix()) != timestamp {
-		return fmt.Errorf("provided timestamp %d for slot %d not expected value of %d", timestamp, slot, s.chainTime.StartOfSlot(slot).Unix())
+	if bytes.Equal(feeRecipient[:], zeroExecutionAddress[:]) {
+		return "fee_recipient", errors.New("zero fee recipient")
 	}
 
-	verified, err := s.verifyBidSignature(ctx, relayConfig, bid, provider)
+	timestamp, err := bid.Timestamp()
 	if err != nil {
-		return err
+		return "malformed", errors.Wrap(err, "failed to obtain builder bid timestamp")
 	}
-	if !verified {
-		s.log.Warn().Msg("Failed to verify bid signature")
-		return errors.New("invalid signature")
+	if uint64(s.chainTime.StartOfSlot(slot).Unix()) != timestamp {
+		return "timestamp", fmt.Errorf("provided timestamp %d for slot %d not expected value of %d", timestamp, slot, s.chainTime.StartOfSlot(slot).Unix())
 	}
 
-	return nil
+	return "", nil
 }
 
-// verifyBidSignature verifies the signature of a bid to ensure it comes from the expected source.
-func (s *Service) verifyBidSignature(_ context.Context,
+// bidSignature obtains the information required to verify the signature of a bid
+// to ensure it comes from the expected source.
+// It returns nil if the public key of the relay is not known.
+func (s *Service) bidSignature(_ context.Context,
 	relayConfig *beaconblockproposer.RelayConfig,
 	bid *builderspec.VersionedSignedBuilderBid,
 	provider builderclient.BuilderBidProvider,
 ) (
-	bool,
+	*bidSignature,
 	error,
 ) {
-	var err error
 	log := s.log.With().Str("provider", provider.Address()).Logger()
 
 	relayPubkey := relayConfig.PublicKey
@@ -393,7 +488,7 @@ func (s *Service) verifyBidSignature(_ context.Context,
 		relayPubkey = provider.Pubkey()
 		if relayPubkey == nil {
 			log.Trace().Msg("Relay configuration does not contain public key; skipping validation")
-			return true, nil
+			return nil, nil
 		}
 	}
 
@@ -401,9 +496,11 @@ func (s *Service) verifyBidSignature(_ context.Context,
 	pubkey, exists := s.relayPubkeys[*relayPubkey]
 	s.relayPubkeysMu.RUnlock()
 	if !exists {
-		pubkey, err = e2types.BLSPublicKeyFromBytes(relayPubkey[:])
-		if err != nil {
-			return false, errors.Wrap(err, "invalid public key supplied with bid")
+		bytePubkey := make([]byte, len(relayPubkey))
+		copy(bytePubkey, relayPubkey[:])
+		pubkey = &bls.PublicKey{}
+		if err := pubkey.Deserialize(bytePubkey); err != nil {
+			return nil, errors.Wrap(errors.Wrap(err, "failed to deserialize public key"), "invalid public key supplied with bid")
 		}
 		s.relayPubkeysMu.Lock()
 		s.relayPubkeys[*relayPubkey] = pubkey
@@ -412,7 +509,7 @@ func (s *Service) verifyBidSignature(_ context.Context,
 
 	dataRoot, err := bid.MessageHashTreeRoot()
 	if err != nil {
-		return false, errors.Wrap(err, "failed to hash bid message")
+		return nil, errors.Wrap(err, "failed to hash bid message")
 	}
 
 	signingData := &phase0.SigningData{
@@ -421,30 +518,63 @@ func (s *Service) verifyBidSignature(_ context.Context,
 	}
 	signingRoot, err := signingData.HashTreeRoot()
 	if err != nil {
-		return false, errors.Wrap(err, "failed to hash signing data")
+		return nil, errors.Wrap(err, "failed to hash signing data")
 	}
 
 	bidSig, err := bid.Signature()
 	if err != nil {
-		return false, errors.Wrap(err, "failed to obtain bid signature")
+		return nil, errors.Wrap(err, "failed to obtain bid signature")
 	}
 
 	byteSig := make([]byte, len(bidSig))
 	copy(byteSig, bidSig[:])
-	sig, err := e2types.BLSSignatureFromBytes(byteSig)
-	if err != nil {
-		return false, errors.Wrap(err, "invalid signature")
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(byteSig); err != nil {
+		return nil, errors.Wrap(errors.Wrap(err, "failed to deserialize signature"), "invalid signature")
 	}
 
-	verified := sig.Verify(signingRoot[:], pubkey)
-	if !verified {
-		data, err := json.Marshal(bid)
-		if err == nil {
-			log.Debug().RawJSON("bid", data).Msg("Verification failure")
+	return &bidSignature{
+		pubkey:      pubkey,
+		signingRoot: signingRoot,
+		sig:         sig,
+	}, nil
+}
+
+// verifyBidSignatures verifies the signatures of the supplied bids as a batch,
+// returning those bids with valid signatures.
+func (s *Service) verifyBidSignatures(_ context.Context,
+	bids []*builderBidResponse,
+) []*builderBidResponse {
+	batch := util.NewBLSBatch(len(bids))
+	for _, bid := range bids {
+		if bid.signature != nil {
+			batch.Add(bid.signature.pubkey, bid.signature.signingRoot, bid.signature.sig)
+		}
+	}
+	verified := batch.Verify()
+
+	res := make([]*builderBidResponse, 0, len(bids))
+	i := 0
+	for _, bid := range bids {
+		if bid.signature != nil {
+			valid := verified[i]
+			i++
+			if !valid {
+				monitorInvalidBid(bid.provider.Address(), "signature")
+				s.log.Warn().Str("provider", bid.provider.Address()).Msg("Failed to verify bid signature")
+				if e := s.log.Debug(); e.Enabled() {
+					data, err := json.Marshal(bid.bid)
+					if err == nil {
+						e.Str("provider", bid.provider.Address()).RawJSON("bid", data).Msg("Verification failure")
+					}
+				}
+				continue
+			}
 		}
+		res = append(res, bid)
 	}
 
-	return verified, nil
+	return res
 }
 
 // bidsEqual returns true if the two bids are equal.
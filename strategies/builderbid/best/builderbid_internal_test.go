@@ -17,14 +17,22 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	builderclient "github.com/attestantio/go-builder-client"
 	builderspec "github.com/attestantio/go-builder-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
@@ -54,7 +62,7 @@ func TestVerifyBidSignature(t *testing.T) {
 	require.NoError(t, e2types.InitBLS())
 
 	s := &Service{
-		relayPubkeys:             make(map[phase0.BLSPubKey]*e2types.BLSPublicKey),
+		relayPubkeys:             make(map[phase0.BLSPubKey]*bls.PublicKey),
 		applicationBuilderDomain: domain("0x00000001d3010778cd08ee514b08fe67b6c503b510987a4ce43f42306d97c67c"),
 	}
 
@@ -120,17 +128,205 @@ func TestVerifyBidSignature(t *testing.T) {
 		},
 	}
 
+	// Bids from all tests, to verify as a single batch.
+	batch := make([]*builderBidResponse, 0)
+	expected := make([]*builderBidResponse, 0)
+
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			bid := &builderspec.VersionedSignedBuilderBid{}
 			require.NoError(t, json.Unmarshal(test.bid, bid))
-			verified, err := s.verifyBidSignature(ctx, test.relayConfig, bid, test.provider)
+			signature, err := s.bidSignature(ctx, test.relayConfig, bid, test.provider)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				resp := &builderBidResponse{
+					provider:  test.provider,
+					bid:       bid,
+					signature: signature,
+				}
+				verified := s.verifyBidSignatures(ctx, []*builderBidResponse{resp})
+				require.Equal(t, test.expected, len(verified) == 1)
+				batch = append(batch, resp)
+				if test.expected {
+					expected = append(expected, resp)
+				}
+			}
+		})
+	}
+
+	require.Equal(t, expected, s.verifyBidSignatures(ctx, batch))
+}
+
+func TestVerifyBidDetails(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Unix(1667652084, 0)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	s := &Service{
+		chainTime: chainTime,
+	}
+
+	goodBid := `{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0xf843fff3b010a668e97a7958a1fab678ce34b06dc394452df17dad43a0f8a9ad","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853000","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191"}}`
+	parentHash := phase0.Hash32{0x15, 0xb3, 0x8d, 0x69, 0xd5, 0x47, 0x89, 0x35, 0x97, 0x84, 0xbd, 0x28, 0x26, 0xd2, 0x81, 0x1e, 0x93, 0x8e, 0x6a, 0xbf, 0x87, 0x58, 0x8a, 0xb7, 0x5d, 0x0e, 0x62, 0x85, 0x74, 0x94, 0x77, 0x1a}
+
+	tests := []struct {
+		name       string
+		bid        string
+		slot       phase0.Slot
+		parentHash phase0.Hash32
+		reason     string
+		err        string
+	}{
+		{
+			name:       "ParentHashMismatch",
+			bid:        goodBid,
+			parentHash: phase0.Hash32{0x01},
+			reason:     "parent_hash",
+			err:        "provided parent hash 0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a not expected value of 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:       "ZeroFeeRecipient",
+			bid:        strings.Replace(goodBid, "0x320715b08bcf4cac1df2c55288a6bad79da1566b", "0x0000000000000000000000000000000000000000", 1),
+			parentHash: parentHash,
+			reason:     "fee_recipient",
+			err:        "zero fee recipient",
+		},
+		{
+			name:       "TimestampMismatch",
+			bid:        goodBid,
+			slot:       1,
+			parentHash: parentHash,
+			reason:     "timestamp",
+			err:        "provided timestamp 1667652084 for slot 1 not expected value of 1667652096",
+		},
+		{
+			name:       "Good",
+			bid:        goodBid,
+			parentHash: parentHash,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bid := &builderspec.VersionedSignedBuilderBid{}
+			require.NoError(t, json.Unmarshal([]byte(test.bid), bid))
+			reason, err := s.verifyBidDetails(ctx, bid, test.slot, test.parentHash)
+			require.Equal(t, test.reason, reason)
 			if test.err != "" {
 				require.EqualError(t, err, test.err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, test.expected, verified)
 			}
 		})
 	}
 }
+
+// bidHandler returns a handler that serves the given bid after the given delay.
+func bidHandler(bid string, delay time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(bid))
+	})
+}
+
+func TestBuilderBidInvalidSignatureFirst(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+	viper.Set("timeout", 5*time.Second)
+
+	genesisTime := time.Unix(1667652084, 0)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	goodBid := `{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0xf843fff3b010a668e97a7958a1fab678ce34b06dc394452df17dad43a0f8a9ad","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853000","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191"}}`
+	parentHash := phase0.Hash32{0x15, 0xb3, 0x8d, 0x69, 0xd5, 0x47, 0x89, 0x35, 0x97, 0x84, 0xbd, 0x28, 0x26, 0xd2, 0x81, 0x1e, 0x93, 0x8e, 0x6a, 0xbf, 0x87, 0x58, 0x8a, 0xb7, 0x5d, 0x0e, 0x62, 0x85, 0x74, 0x94, 0x77, 0x1a}
+	badBid := strings.Replace(goodBid,
+		"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191",
+		"0xa73233d802d26e59489bc8d67b56465b0807e90a3a2e457c500a3f612e46d302d5b0a1c02dc2f51a11747ab0bb129ef416bee4e74b3710052f366ce2200cdc56fcf69ca68476874d4a2c8dc7afde78ff93e3e7f145e742d1abb800c3b11327b5",
+		1)
+	relayPubkey := pubkey("0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a")
+
+	// The relay with the invalid signature responds before the soft timeout,
+	// the relay with the valid signature after it.
+	badRelay := httptest.NewServer(bidHandler(badBid, 0))
+	defer badRelay.Close()
+	goodRelay := httptest.NewServer(bidHandler(goodBid, 200*time.Millisecond))
+	defer goodRelay.Close()
+
+	s := &Service{
+		log:                      zerolog.Nop(),
+		chainTime:                chainTime,
+		timeout:                  2 * time.Second,
+		softTimeout:              50 * time.Millisecond,
+		relayPubkeys:             make(map[phase0.BLSPubKey]*bls.PublicKey),
+		applicationBuilderDomain: domain("0x00000001d3010778cd08ee514b08fe67b6c503b510987a4ce43f42306d97c67c"),
+	}
+
+	res, err := s.BuilderBid(ctx, 0, parentHash, phase0.BLSPubKey{}, &beaconblockproposer.ProposerConfig{
+		Relays: []*beaconblockproposer.RelayConfig{
+			{Address: badRelay.URL, PublicKey: relayPubkey},
+			{Address: goodRelay.URL, PublicKey: relayPubkey},
+		},
+	}, nil)
+	require.NoError(t, err)
+	// The invalid bid must not close the auction at the soft timeout.
+	require.NotNil(t, res.Bid)
+	require.Len(t, res.Providers, 1)
+	require.Equal(t, fmt.Sprintf("%s/", goodRelay.URL), res.Providers[0].Address())
+	require.Len(t, res.Values, 1)
+}
+
+func TestBudgetedSoftTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		softTimeout time.Duration
+		budgeted    time.Duration
+		expected    time.Duration
+	}{
+		{
+			name:        "Full",
+			timeout:     2 * time.Second,
+			softTimeout: 500 * time.Millisecond,
+			budgeted:    2 * time.Second,
+			expected:    500 * time.Millisecond,
+		},
+		{
+			name:        "Reduced",
+			timeout:     2 * time.Second,
+			softTimeout: 500 * time.Millisecond,
+			budgeted:    time.Second,
+			expected:    250 * time.Millisecond,
+		},
+		{
+			name:        "Large",
+			timeout:     10 * time.Second,
+			softTimeout: 8 * time.Second,
+			budgeted:    5 * time.Second,
+			expected:    4 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				timeout:     test.timeout,
+				softTimeout: test.softTimeout,
+			}
+			require.Equal(t, test.expected, s.budgetedSoftTimeout(test.budgeted))
+		})
+	}
+}
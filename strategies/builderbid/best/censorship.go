@@ -0,0 +1,71 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"math/big"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	builderclient "github.com/attestantio/go-builder-client"
+)
+
+// preferNonCensoringBid replaces a winning bid that is only available from
+// relays labelled as censoring with the best bid from the other relays, unless
+// the winning bid exceeds it by more than the censoring margin.
+func (s *Service) preferNonCensoringBid(res *blockauctioneer.Results,
+	bestScore *big.Int,
+	bids []*builderBidResponse,
+) {
+	censoring := make(map[string]bool, len(bids))
+	for _, resp := range bids {
+		censoring[resp.provider.Address()] = resp.censoring
+	}
+	for _, provider := range res.Providers {
+		if !censoring[provider.Address()] {
+			// The winning bid is available from a non-censoring relay.
+			return
+		}
+	}
+
+	var alternative *builderBidResponse
+	var alternativeProviders []builderclient.BuilderBidProvider
+	for _, resp := range bids {
+		if resp.censoring {
+			continue
+		}
+		switch {
+		case alternative == nil || resp.score.Cmp(alternative.score) > 0:
+			alternative = resp
+			alternativeProviders = []builderclient.BuilderBidProvider{resp.provider}
+		case resp.score.Cmp(alternative.score) == 0 && bidsEqual(alternative.bid, resp.bid):
+			alternativeProviders = append(alternativeProviders, resp.provider)
+		}
+	}
+	if alternative == nil || alternative.score.Sign() == 0 {
+		s.log.Trace().Msg("No bids from non-censoring relays")
+		return
+	}
+
+	// The censoring bid is selected only if it exceeds the alternative by more than the margin.
+	threshold := new(big.Int).Mul(alternative.score, new(big.Int).SetUint64(100+s.censoringMargin))
+	threshold = threshold.Div(threshold, big.NewInt(100))
+	if bestScore.Cmp(threshold) > 0 {
+		s.log.Trace().Stringer("censoring_score", bestScore).Stringer("threshold", threshold).Msg("Bid from censoring relay exceeds margin; selecting")
+		return
+	}
+
+	s.log.Debug().Stringer("censoring_score", bestScore).Stringer("score", alternative.score).Msg("Selecting bid from non-censoring relay")
+	res.Bid = alternative.bid
+	res.Providers = alternativeProviders
+}
@@ -0,0 +1,127 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	builderclient "github.com/attestantio/go-builder-client"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/vouch/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// addressedBuilderClient is a mock builder client with a configurable address.
+type addressedBuilderClient struct {
+	*mock.BuilderClient
+	address string
+}
+
+func (c *addressedBuilderClient) Address() string {
+	return c.address
+}
+
+func TestPreferNonCensoringBid(t *testing.T) {
+	censoringProvider := &addressedBuilderClient{BuilderClient: &mock.BuilderClient{}, address: "https://censoring.example.com/"}
+	otherCensoringProvider := &addressedBuilderClient{BuilderClient: &mock.BuilderClient{}, address: "https://censoring2.example.com/"}
+	nonCensoringProvider := &addressedBuilderClient{BuilderClient: &mock.BuilderClient{}, address: "https://noncensoring.example.com/"}
+
+	censoringBid := &builderspec.VersionedSignedBuilderBid{}
+	nonCensoringBid := &builderspec.VersionedSignedBuilderBid{}
+
+	tests := []struct {
+		name              string
+		margin            uint64
+		winner            *builderBidResponse
+		bids              []*builderBidResponse
+		expectedBid       *builderspec.VersionedSignedBuilderBid
+		expectedProviders []builderclient.BuilderBidProvider
+	}{
+		{
+			name:   "WinnerNonCensoring",
+			margin: 10,
+			winner: &builderBidResponse{provider: nonCensoringProvider, bid: nonCensoringBid, score: big.NewInt(1000)},
+			bids: []*builderBidResponse{
+				{provider: nonCensoringProvider, bid: nonCensoringBid, score: big.NewInt(1000)},
+				{provider: censoringProvider, bid: censoringBid, score: big.NewInt(900), censoring: true},
+			},
+			expectedBid:       nonCensoringBid,
+			expectedProviders: []builderclient.BuilderBidProvider{nonCensoringProvider},
+		},
+		{
+			name:   "NoNonCensoringBids",
+			margin: 10,
+			winner: &builderBidResponse{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1000)},
+			bids: []*builderBidResponse{
+				{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1000), censoring: true},
+				{provider: otherCensoringProvider, bid: nonCensoringBid, score: big.NewInt(900), censoring: true},
+			},
+			expectedBid:       censoringBid,
+			expectedProviders: []builderclient.BuilderBidProvider{censoringProvider},
+		},
+		{
+			name:   "WithinMargin",
+			margin: 10,
+			winner: &builderBidResponse{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1100)},
+			bids: []*builderBidResponse{
+				{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1100), censoring: true},
+				{provider: nonCensoringProvider, bid: nonCensoringBid, score: big.NewInt(1000)},
+			},
+			expectedBid:       nonCensoringBid,
+			expectedProviders: []builderclient.BuilderBidProvider{nonCensoringProvider},
+		},
+		{
+			name:   "ExceedsMargin",
+			margin: 10,
+			winner: &builderBidResponse{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1101)},
+			bids: []*builderBidResponse{
+				{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1101), censoring: true},
+				{provider: nonCensoringProvider, bid: nonCensoringBid, score: big.NewInt(1000)},
+			},
+			expectedBid:       censoringBid,
+			expectedProviders: []builderclient.BuilderBidProvider{censoringProvider},
+		},
+		{
+			name:   "ZeroMargin",
+			margin: 0,
+			winner: &builderBidResponse{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1001)},
+			bids: []*builderBidResponse{
+				{provider: censoringProvider, bid: censoringBid, score: big.NewInt(1001), censoring: true},
+				{provider: nonCensoringProvider, bid: nonCensoringBid, score: big.NewInt(1000)},
+			},
+			expectedBid:       censoringBid,
+			expectedProviders: []builderclient.BuilderBidProvider{censoringProvider},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				log:                  zerolog.Nop(),
+				censorshipResistance: true,
+				censoringMargin:      test.margin,
+			}
+			res := &blockauctioneer.Results{
+				Bid:       test.winner.bid,
+				Providers: []builderclient.BuilderBidProvider{test.winner.provider},
+			}
+			s.preferNonCensoringBid(res, test.winner.score, test.bids)
+			require.Same(t, test.expectedBid, res.Bid)
+			require.Equal(t, test.expectedProviders, res.Providers)
+		})
+	}
+}
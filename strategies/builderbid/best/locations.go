@@ -0,0 +1,80 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+)
+
+// relayGroups groups relays that are endpoints of the same relay, as identified
+// by their public key, with the endpoints in each group ordered by location
+// preference.  Only the first endpoint in each group is queried for a bid, with
+// the others used in turn if it fails.
+//
+// If no location preferences are configured each relay is in its own group.
+func (s *Service) relayGroups(relays []*beaconblockproposer.RelayConfig) [][]*beaconblockproposer.RelayConfig {
+	groups := make([][]*beaconblockproposer.RelayConfig, 0, len(relays))
+	if len(s.locationPreferences) == 0 {
+		for _, relay := range relays {
+			groups = append(groups, []*beaconblockproposer.RelayConfig{relay})
+		}
+		return groups
+	}
+
+	groupIndices := make(map[string]int)
+	for _, relay := range relays {
+		key := relay.Address
+		if relay.PublicKey != nil {
+			key = fmt.Sprintf("%#x", *relay.PublicKey)
+		}
+		index, exists := groupIndices[key]
+		if !exists {
+			index = len(groups)
+			groupIndices[key] = index
+			groups = append(groups, make([]*beaconblockproposer.RelayConfig, 0, 1))
+		}
+		groups[index] = append(groups[index], relay)
+	}
+
+	for _, group := range groups {
+		group := group
+		sort.SliceStable(group, func(i int, j int) bool {
+			iRank := s.locationRank(group[i].Location)
+			jRank := s.locationRank(group[j].Location)
+			if iRank == jRank {
+				return group[i].Address < group[j].Address
+			}
+			return iRank < jRank
+		})
+	}
+
+	return groups
+}
+
+// locationRank returns the rank of a location in the location preferences, with
+// lower being more preferred.  Locations that are not in the preferences rank
+// below all those that are.
+func (s *Service) locationRank(location string) int {
+	for i, preference := range s.locationPreferences {
+		if strings.EqualFold(location, preference) {
+			return i
+		}
+	}
+
+	return len(s.locationPreferences)
+}
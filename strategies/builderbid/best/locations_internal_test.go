@@ -0,0 +1,82 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayGroups(t *testing.T) {
+	pubKey1 := phase0.BLSPubKey{0x01}
+	pubKey2 := phase0.BLSPubKey{0x02}
+
+	relay1US := &beaconblockproposer.RelayConfig{Address: "https://relay1-us.example.com/", PublicKey: &pubKey1, Location: "us"}
+	relay1EU := &beaconblockproposer.RelayConfig{Address: "https://relay1-eu.example.com/", PublicKey: &pubKey1, Location: "eu"}
+	relay1 := &beaconblockproposer.RelayConfig{Address: "https://relay1.example.com/", PublicKey: &pubKey1}
+	relay2 := &beaconblockproposer.RelayConfig{Address: "https://relay2.example.com/", PublicKey: &pubKey2, Location: "us"}
+	relay3 := &beaconblockproposer.RelayConfig{Address: "https://relay3.example.com/"}
+
+	tests := []struct {
+		name                string
+		locationPreferences []string
+		relays              []*beaconblockproposer.RelayConfig
+		expected            [][]*beaconblockproposer.RelayConfig
+	}{
+		{
+			name:     "Empty",
+			relays:   []*beaconblockproposer.RelayConfig{},
+			expected: [][]*beaconblockproposer.RelayConfig{},
+		},
+		{
+			name:   "NoPreferences",
+			relays: []*beaconblockproposer.RelayConfig{relay1US, relay1EU, relay2},
+			expected: [][]*beaconblockproposer.RelayConfig{
+				{relay1US},
+				{relay1EU},
+				{relay2},
+			},
+		},
+		{
+			name:                "Preferences",
+			locationPreferences: []string{"eu", "us"},
+			relays:              []*beaconblockproposer.RelayConfig{relay1US, relay2, relay1, relay1EU, relay3},
+			expected: [][]*beaconblockproposer.RelayConfig{
+				{relay1EU, relay1US, relay1},
+				{relay2},
+				{relay3},
+			},
+		},
+		{
+			name:                "PreferencesCase",
+			locationPreferences: []string{"US"},
+			relays:              []*beaconblockproposer.RelayConfig{relay1EU, relay1US},
+			expected: [][]*beaconblockproposer.RelayConfig{
+				{relay1US, relay1EU},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				locationPreferences: test.locationPreferences,
+			}
+			require.Equal(t, test.expected, s.relayGroups(test.relays))
+		})
+	}
+}
@@ -15,6 +15,7 @@ package best
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/attestantio/vouch/services/metrics"
@@ -25,8 +26,14 @@ import (
 var (
 	auctionBlockUsed  *prometheus.CounterVec
 	auctionBlockTimer prometheus.Histogram
+	invalidBids       *prometheus.CounterVec
+	bidLatency        *prometheus.HistogramVec
+	bidValue          *prometheus.HistogramVec
 )
 
+// weiPerETH is used to convert bid values to ETH for metrics.
+var weiPerETH = big.NewFloat(1e18)
+
 func registerMetrics(ctx context.Context, monitor metrics.Service) error {
 	if auctionBlockUsed != nil {
 		// Already registered.
@@ -69,6 +76,44 @@ func registerPrometheusMetrics(_ context.Context) error {
 		return errors.Wrap(err, "failed to register vouch_relay_auction_block_duration_seconds")
 	}
 
+	invalidBids = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_builder_bid",
+		Name:      "invalid_total",
+		Help:      "The number of invalid builder bids received from a relay.",
+	}, []string{"provider", "reason"})
+	if err := prometheus.Register(invalidBids); err != nil {
+		return errors.Wrap(err, "failed to register vouch_relay_builder_bid_invalid_total")
+	}
+
+	bidLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_builder_bid",
+		Name:      "latency_seconds",
+		Help:      "The time taken for a relay to respond to a builder bid request.",
+		Buckets: []float64{
+			0.05, 0.1, 0.15, 0.2, 0.25, 0.3, 0.4, 0.5, 0.6, 0.7,
+			0.8, 0.9, 1.0, 1.25, 1.5, 1.75, 2.0, 2.5, 3.0, 4.0,
+		},
+	}, []string{"provider", "result"})
+	if err := prometheus.Register(bidLatency); err != nil {
+		return errors.Wrap(err, "failed to register vouch_relay_builder_bid_latency_seconds")
+	}
+
+	bidValue = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_builder_bid",
+		Name:      "value_eth",
+		Help:      "The value of builder bids received from a relay, in ETH.",
+		Buckets: []float64{
+			0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0,
+			2.5, 5.0, 10.0,
+		},
+	}, []string{"provider"})
+	if err := prometheus.Register(bidValue); err != nil {
+		return errors.Wrap(err, "failed to register vouch_relay_builder_bid_value_eth")
+	}
+
 	return nil
 }
 
@@ -84,3 +129,34 @@ func monitorAuctionBlock(provider string, succeeded bool, duration time.Duration
 		auctionBlockUsed.WithLabelValues(provider).Add(1)
 	}
 }
+
+// monitorInvalidBid provides metrics for an invalid bid.
+func monitorInvalidBid(provider string, reason string) {
+	if invalidBids == nil {
+		// Not yet registered.
+		return
+	}
+
+	invalidBids.WithLabelValues(provider, reason).Inc()
+}
+
+// monitorBidLatency provides metrics for the time taken to obtain a bid from a relay.
+func monitorBidLatency(provider string, result string, duration time.Duration) {
+	if bidLatency == nil {
+		// Not yet registered.
+		return
+	}
+
+	bidLatency.WithLabelValues(provider, result).Observe(duration.Seconds())
+}
+
+// monitorBidValue provides metrics for the value of a bid from a relay.
+func monitorBidValue(provider string, value *big.Int) {
+	if bidValue == nil {
+		// Not yet registered.
+		return
+	}
+
+	eth, _ := new(big.Float).Quo(new(big.Float).SetInt(value), weiPerETH).Float64()
+	bidValue.WithLabelValues(provider).Observe(eth)
+}
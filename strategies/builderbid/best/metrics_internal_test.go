@@ -0,0 +1,90 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func histogram(t *testing.T, vec *prometheus.HistogramVec, labels ...string) *dto.Histogram {
+	t.Helper()
+
+	observer, err := vec.GetMetricWithLabelValues(labels...)
+	require.NoError(t, err)
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+
+	return metric.GetHistogram()
+}
+
+func TestMonitorBidLatency(t *testing.T) {
+	// Not registered; should not panic.
+	defer func(latency *prometheus.HistogramVec) { bidLatency = latency }(bidLatency)
+	bidLatency = nil
+	monitorBidLatency("relay1", "succeeded", time.Second)
+
+	bidLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "latency_seconds",
+	}, []string{"provider", "result"})
+
+	monitorBidLatency("relay1", "succeeded", 200*time.Millisecond)
+	monitorBidLatency("relay1", "succeeded", 300*time.Millisecond)
+	monitorBidLatency("relay1", "failed", time.Second)
+	monitorBidLatency("relay2", "empty", 100*time.Millisecond)
+
+	succeeded := histogram(t, bidLatency, "relay1", "succeeded")
+	require.Equal(t, uint64(2), succeeded.GetSampleCount())
+	require.InDelta(t, 0.5, succeeded.GetSampleSum(), 1e-9)
+
+	failed := histogram(t, bidLatency, "relay1", "failed")
+	require.Equal(t, uint64(1), failed.GetSampleCount())
+	require.InDelta(t, 1.0, failed.GetSampleSum(), 1e-9)
+
+	empty := histogram(t, bidLatency, "relay2", "empty")
+	require.Equal(t, uint64(1), empty.GetSampleCount())
+
+	require.Equal(t, uint64(0), histogram(t, bidLatency, "relay2", "succeeded").GetSampleCount())
+}
+
+func TestMonitorBidValue(t *testing.T) {
+	// Not registered; should not panic.
+	defer func(value *prometheus.HistogramVec) { bidValue = value }(bidValue)
+	bidValue = nil
+	monitorBidValue("relay1", big.NewInt(1))
+
+	bidValue = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "value_eth",
+	}, []string{"provider"})
+
+	// 0.05 ETH.
+	monitorBidValue("relay1", big.NewInt(50000000000000000))
+	// 1.5 ETH.
+	monitorBidValue("relay1", new(big.Int).Mul(big.NewInt(15), big.NewInt(100000000000000000)))
+	// 0 ETH.
+	monitorBidValue("relay2", big.NewInt(0))
+
+	relay1 := histogram(t, bidValue, "relay1")
+	require.Equal(t, uint64(2), relay1.GetSampleCount())
+	require.InDelta(t, 1.55, relay1.GetSampleSum(), 1e-9)
+
+	relay2 := histogram(t, bidValue, "relay2")
+	require.Equal(t, uint64(1), relay2.GetSampleCount())
+	require.Zero(t, relay2.GetSampleSum())
+}
@@ -27,13 +27,17 @@ import (
 )
 
 type parameters struct {
-	logLevel       zerolog.Level
-	monitor        metrics.Service
-	specProvider   consensusclient.SpecProvider
-	domainProvider consensusclient.DomainProvider
-	chainTime      chaintime.Service
-	timeout        time.Duration
-	releaseVersion string
+	logLevel             zerolog.Level
+	monitor              metrics.Service
+	specProvider         consensusclient.SpecProvider
+	domainProvider       consensusclient.DomainProvider
+	chainTime            chaintime.Service
+	timeout              time.Duration
+	softTimeout          time.Duration
+	releaseVersion       string
+	locationPreferences  []string
+	censorshipResistance bool
+	censoringMargin      uint64
 }
 
 // Parameter is the interface for service parameters.
@@ -89,6 +93,14 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithSoftTimeout sets the soft timeout for requests, after which the auction
+// closes if any bids have been received.
+func WithSoftTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.softTimeout = timeout
+	})
+}
+
 // WithReleaseVersion sets the release version for Vouch.
 func WithReleaseVersion(version string) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -96,6 +108,29 @@ func WithReleaseVersion(version string) Parameter {
 	})
 }
 
+// WithLocationPreferences sets the preferred relay locations, in order of preference.
+func WithLocationPreferences(locations []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.locationPreferences = locations
+	})
+}
+
+// WithCensorshipResistance sets the strategy to prefer bids from relays that
+// are not labelled as censoring.
+func WithCensorshipResistance(enabled bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.censorshipResistance = enabled
+	})
+}
+
+// WithCensoringMargin sets the percentage by which a bid from a censoring relay
+// must exceed the best bid from a non-censoring relay to be selected.
+func WithCensoringMargin(margin uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.censoringMargin = margin
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -123,6 +158,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
+	if parameters.softTimeout == 0 {
+		parameters.softTimeout = parameters.timeout / 2
+	}
+	if parameters.softTimeout > parameters.timeout {
+		return nil, errors.New("soft timeout cannot be greater than timeout")
+	}
 
 	return &parameters, nil
 }
@@ -22,10 +22,10 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
-	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // Service is the provider for builder bids.
@@ -34,10 +34,14 @@ type Service struct {
 	monitor                  metrics.Service
 	chainTime                chaintime.Service
 	timeout                  time.Duration
+	softTimeout              time.Duration
 	releaseVersion           string
-	relayPubkeys             map[phase0.BLSPubKey]*e2types.BLSPublicKey
+	relayPubkeys             map[phase0.BLSPubKey]*bls.PublicKey
 	relayPubkeysMu           sync.RWMutex
 	applicationBuilderDomain phase0.Domain
+	locationPreferences      []string
+	censorshipResistance     bool
+	censoringMargin          uint64
 }
 
 // New creates a new builder bid strategy.
@@ -81,9 +85,13 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		monitor:                  parameters.monitor,
 		chainTime:                parameters.chainTime,
 		timeout:                  parameters.timeout,
+		softTimeout:              parameters.softTimeout,
 		releaseVersion:           parameters.releaseVersion,
-		relayPubkeys:             make(map[phase0.BLSPubKey]*e2types.BLSPublicKey),
+		relayPubkeys:             make(map[phase0.BLSPubKey]*bls.PublicKey),
 		applicationBuilderDomain: domain,
+		locationPreferences:      parameters.locationPreferences,
+		censorshipResistance:     parameters.censorshipResistance,
+		censoringMargin:          parameters.censoringMargin,
 	}
 
 	return s, nil
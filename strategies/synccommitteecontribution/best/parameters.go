@@ -23,6 +23,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -32,6 +33,7 @@ type parameters struct {
 	clientMonitor                      metrics.ClientMonitor
 	processConcurrency                 int64
 	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
+	nodeMonitor                        nodemonitor.Service
 	timeout                            time.Duration
 }
 
@@ -81,6 +83,13 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithNodeMonitor sets the node monitor service.
+func WithNodeMonitor(monitor nodemonitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeMonitor = monitor
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
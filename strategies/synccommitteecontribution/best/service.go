@@ -19,6 +19,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -29,6 +30,7 @@ type Service struct {
 	clientMonitor                      metrics.ClientMonitor
 	processConcurrency                 int64
 	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
+	nodeMonitor                        nodemonitor.Service
 	timeout                            time.Duration
 }
 
@@ -53,6 +55,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		clientMonitor:                      parameters.clientMonitor,
 		processConcurrency:                 parameters.processConcurrency,
 		syncCommitteeContributionProviders: parameters.syncCommitteeContributionProviders,
+		nodeMonitor:                        parameters.nodeMonitor,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -20,6 +20,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -64,12 +65,13 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
 
-	requests := len(s.syncCommitteeContributionProviders)
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.syncCommitteeContributionProviders)
+	requests := len(providers)
 
 	respCh := make(chan *syncCommitteeContributionResponse, requests)
 	errCh := make(chan *syncCommitteeContributionError, requests)
 	// Kick off the requests.
-	for name, provider := range s.syncCommitteeContributionProviders {
+	for name, provider := range providers {
 		go s.syncCommitteeContribution(ctx, started, name, provider, respCh, errCh, opts)
 	}
 
@@ -19,6 +19,8 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodelatency"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -28,7 +30,10 @@ import (
 type Service struct {
 	clientMonitor                      metrics.ClientMonitor
 	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
+	nodeMonitor                        nodemonitor.Service
 	timeout                            time.Duration
+	nodeLatency                        nodelatency.Service
+	hedgeDelay                         time.Duration
 }
 
 // module-wide log.
@@ -49,6 +54,9 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 
 	s := &Service{
 		syncCommitteeContributionProviders: parameters.syncCommitteeContributionProviders,
+		nodeMonitor:                        parameters.nodeMonitor,
+		nodeLatency:                        parameters.nodeLatency,
+		hedgeDelay:                         parameters.hedgeDelay,
 		timeout:                            parameters.timeout,
 		clientMonitor:                      parameters.clientMonitor,
 	}
@@ -17,9 +17,9 @@ import (
 	"context"
 	"time"
 
-	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/vouch/services/nodemonitor"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -50,26 +50,33 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 
 	respCh := make(chan *altair.SyncCommitteeContribution, 1)
-	for name, provider := range s.syncCommitteeContributionProviders {
-		go func(ctx context.Context,
-			name string,
-			provider eth2client.SyncCommitteeContributionProvider,
-			ch chan *altair.SyncCommitteeContribution,
-		) {
-			log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Uint64("subcommittee_index", opts.SubcommitteeIndex).Stringer("beacon_block_root", opts.BeaconBlockRoot).Logger()
+	failedCh := make(chan struct{}, len(s.syncCommitteeContributionProviders))
+	request := func(name string) {
+		log := log.With().Str("provider", name).Uint64("slot", uint64(opts.Slot)).Uint64("subcommittee_index", opts.SubcommitteeIndex).Stringer("beacon_block_root", opts.BeaconBlockRoot).Logger()
 
-			contributionResponse, err := provider.SyncCommitteeContribution(ctx, opts)
-			s.clientMonitor.ClientOperation(name, "sync committee contribution", err == nil, time.Since(started))
-			if err != nil {
-				log.Warn().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain sync committee contribution")
-				return
-			}
-			contribution := contributionResponse.Data
-			log.Trace().Str("provider", name).Dur("elapsed", time.Since(started)).Msg("Obtained sync committee contribution")
+		requestStarted := time.Now()
+		contributionResponse, err := s.syncCommitteeContributionProviders[name].SyncCommitteeContribution(ctx, opts)
+		s.clientMonitor.ClientOperation(name, "sync committee contribution", err == nil, time.Since(started))
+		s.recordLatency(ctx, name, time.Since(requestStarted), err)
+		if err != nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain sync committee contribution")
+			failedCh <- struct{}{}
+			return
+		}
+		contribution := contributionResponse.Data
+		log.Trace().Str("provider", name).Dur("elapsed", time.Since(started)).Msg("Obtained sync committee contribution")
 
-			ch <- contribution
-		}(ctx, name, provider, respCh)
+		select {
+		case respCh <- contribution:
+		default:
+		}
 	}
+	providers := nodemonitor.HealthyProviders(ctx, s.nodeMonitor, s.syncCommitteeContributionProviders)
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	s.hedge(ctx, names, request, failedCh)
 
 	select {
 	case <-ctx.Done():
@@ -84,3 +91,48 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 		}, nil
 	}
 }
+
+// hedge issues the request to the providers.  If hedging is enabled the
+// request is sent to the fastest provider first, and to the remainder only if
+// it fails or has not responded by the hedge delay.
+func (s *Service) hedge(ctx context.Context,
+	names []string,
+	request func(name string),
+	failedCh chan struct{},
+) {
+	if s.hedgeDelay == 0 {
+		for _, name := range names {
+			go request(name)
+		}
+		return
+	}
+
+	ordered := s.nodeLatency.Order(ctx, names)
+	go request(ordered[0])
+	if len(ordered) == 1 {
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-failedCh:
+			log.Trace().Str("provider", ordered[0]).Msg("Fastest provider failed; hedging")
+		case <-time.After(s.hedgeDelay):
+			log.Trace().Str("provider", ordered[0]).Msg("Fastest provider slow; hedging")
+		}
+		for _, name := range ordered[1:] {
+			go request(name)
+		}
+	}()
+}
+
+// recordLatency records the latency of a request with the node latency
+// service.  Requests canceled due to another provider responding first are
+// not recorded, as their latency is unknown.
+func (s *Service) recordLatency(ctx context.Context, name string, latency time.Duration, err error) {
+	if s.nodeLatency == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	s.nodeLatency.Record(ctx, name, latency, err == nil)
+}
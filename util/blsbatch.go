@@ -0,0 +1,77 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// signingRootLength is the length of a signing root, in bytes.
+const signingRootLength = 32
+
+// BLSBatch is a set of BLS signatures to be verified together.
+// Verifying signatures as a batch is significantly cheaper than verifying
+// them individually, at the cost of an additional individual verification
+// of each signature should the batch as a whole fail.
+type BLSBatch struct {
+	sigs  []bls.Sign
+	pubs  []bls.PublicKey
+	roots []byte
+}
+
+// NewBLSBatch creates a new batch with capacity for the given number of signatures.
+func NewBLSBatch(size int) *BLSBatch {
+	return &BLSBatch{
+		sigs:  make([]bls.Sign, 0, size),
+		pubs:  make([]bls.PublicKey, 0, size),
+		roots: make([]byte, 0, size*signingRootLength),
+	}
+}
+
+// Add adds a signature over the given signing root to the batch.
+func (b *BLSBatch) Add(pubkey *bls.PublicKey, signingRoot phase0.Root, sig *bls.Sign) {
+	b.sigs = append(b.sigs, *sig)
+	b.pubs = append(b.pubs, *pubkey)
+	b.roots = append(b.roots, signingRoot[:]...)
+}
+
+// Len returns the number of signatures in the batch.
+func (b *BLSBatch) Len() int {
+	return len(b.sigs)
+}
+
+// Verify verifies the signatures in the batch, returning the validity of each
+// in the order in which they were added.
+func (b *BLSBatch) Verify() []bool {
+	res := make([]bool, len(b.sigs))
+	if len(b.sigs) == 0 {
+		return res
+	}
+
+	if len(b.sigs) > 1 && bls.MultiVerify(b.sigs, b.pubs, b.roots) {
+		for i := range res {
+			res[i] = true
+		}
+		return res
+	}
+
+	// Either a single signature or the batch failed; verify individually to
+	// find the bad signature(s).
+	for i := range b.sigs {
+		res[i] = b.sigs[i].VerifyByte(&b.pubs[i], b.roots[i*signingRootLength:(i+1)*signingRootLength])
+	}
+
+	return res
+}
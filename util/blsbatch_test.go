@@ -0,0 +1,100 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestBLSBatch(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	type signature struct {
+		pubkey *bls.PublicKey
+		root   phase0.Root
+		sig    *bls.Sign
+	}
+	sign := func(root phase0.Root, valid bool) *signature {
+		var secretKey bls.SecretKey
+		secretKey.SetByCSPRNG()
+		sig := secretKey.SignByte(root[:])
+		if !valid {
+			// Sign a different root.
+			otherRoot := root
+			otherRoot[0]++
+			sig = secretKey.SignByte(otherRoot[:])
+		}
+		return &signature{
+			pubkey: secretKey.GetPublicKey(),
+			root:   root,
+			sig:    sig,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		signatures []*signature
+		expected   []bool
+	}{
+		{
+			name:     "Empty",
+			expected: []bool{},
+		},
+		{
+			name:       "Single",
+			signatures: []*signature{sign(phase0.Root{0x01}, true)},
+			expected:   []bool{true},
+		},
+		{
+			name:       "SingleInvalid",
+			signatures: []*signature{sign(phase0.Root{0x01}, false)},
+			expected:   []bool{false},
+		},
+		{
+			name: "Multiple",
+			signatures: []*signature{
+				sign(phase0.Root{0x01}, true),
+				sign(phase0.Root{0x02}, true),
+				sign(phase0.Root{0x02}, true),
+			},
+			expected: []bool{true, true, true},
+		},
+		{
+			name: "MultipleInvalid",
+			signatures: []*signature{
+				sign(phase0.Root{0x01}, true),
+				sign(phase0.Root{0x02}, false),
+				sign(phase0.Root{0x03}, true),
+			},
+			expected: []bool{true, false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batch := util.NewBLSBatch(len(test.signatures))
+			for _, signature := range test.signatures {
+				batch.Add(signature.pubkey, signature.root, signature.sig)
+			}
+			require.Equal(t, len(test.signatures), batch.Len())
+			require.Equal(t, test.expected, batch.Verify())
+		})
+	}
+}
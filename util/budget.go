@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// DutyPhase is a phase of a duty.
+type DutyPhase int
+
+const (
+	// DutyPhaseFetch is the phase in which the data for a duty is obtained.
+	DutyPhaseFetch DutyPhase = iota
+	// DutyPhaseSign is the phase in which the data for a duty is signed.
+	DutyPhaseSign
+	// DutyPhaseSubmit is the phase in which the signed data for a duty is submitted.
+	DutyPhaseSubmit
+)
+
+// DutyBudget is the relative share of the time before a duty's deadline
+// given to each phase of the duty.  Wait is the share spent waiting for the
+// duty to start, for example for a block to arrive before attesting.
+type DutyBudget struct {
+	Wait   float64
+	Fetch  float64
+	Sign   float64
+	Submit float64
+}
+
+// WaitDuration returns the time that a duty with the given deadline should
+// wait before starting, or 0 if the budget does not include a wait share.
+func (b *DutyBudget) WaitDuration(deadline time.Duration) time.Duration {
+	if b == nil || b.Wait <= 0 {
+		return 0
+	}
+	total := 0.0
+	for _, share := range []float64{b.Wait, b.Fetch, b.Sign, b.Submit} {
+		if share > 0 {
+			total += share
+		}
+	}
+
+	return time.Duration(float64(deadline) * b.Wait / total)
+}
+
+type dutyBudgetKey struct{}
+
+// WithDutyBudget returns a context that carries the budget for the phases of
+// a duty.  The budget only applies if the context also has a deadline.
+func WithDutyBudget(ctx context.Context, budget *DutyBudget) context.Context {
+	if budget == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, dutyBudgetKey{}, budget)
+}
+
+// PhaseContext returns a context for the given phase of a duty.  The phase is
+// given its share of the time remaining before the deadline of the duty, with
+// the shares of the later phases held back for them, so a phase that finishes
+// early leaves more time for those that follow.  If the context does not carry
+// a budget and a deadline the context is returned unaltered.
+func PhaseContext(ctx context.Context, phase DutyPhase) (context.Context, context.CancelFunc) {
+	budget, exists := ctx.Value(dutyBudgetKey{}).(*DutyBudget)
+	if !exists {
+		return ctx, func() {}
+	}
+	deadline, exists := ctx.Deadline()
+	if !exists {
+		return ctx, func() {}
+	}
+
+	shares := []float64{budget.Fetch, budget.Sign, budget.Submit}
+	if int(phase) < 0 || int(phase) >= len(shares) || shares[phase] <= 0 {
+		return ctx, func() {}
+	}
+	total := 0.0
+	for _, share := range shares[phase:] {
+		if share > 0 {
+			total += share
+		}
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return ctx, func() {}
+	}
+	allowed := time.Duration(float64(remaining) * shares[phase] / total)
+
+	return context.WithDeadline(ctx, time.Now().Add(allowed))
+}
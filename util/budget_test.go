@@ -0,0 +1,159 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseContext(t *testing.T) {
+	budget := &util.DutyBudget{
+		Fetch:  2,
+		Sign:   1,
+		Submit: 1,
+	}
+
+	tests := []struct {
+		name     string
+		budget   *util.DutyBudget
+		deadline time.Duration
+		phase    util.DutyPhase
+		expected time.Duration
+		unbound  bool
+	}{
+		{
+			name:    "NoBudget",
+			phase:   util.DutyPhaseFetch,
+			unbound: true,
+		},
+		{
+			name:    "NoDeadline",
+			budget:  budget,
+			phase:   util.DutyPhaseFetch,
+			unbound: true,
+		},
+		{
+			name:     "Fetch",
+			budget:   budget,
+			deadline: 4 * time.Second,
+			phase:    util.DutyPhaseFetch,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "Sign",
+			budget:   budget,
+			deadline: 4 * time.Second,
+			phase:    util.DutyPhaseSign,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "Submit",
+			budget:   budget,
+			deadline: 4 * time.Second,
+			phase:    util.DutyPhaseSubmit,
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "ZeroShare",
+			budget:   &util.DutyBudget{Fetch: 1, Submit: 1},
+			deadline: 4 * time.Second,
+			phase:    util.DutyPhaseSign,
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "LaterZeroShare",
+			budget:   &util.DutyBudget{Fetch: 1, Submit: 1},
+			deadline: 4 * time.Second,
+			phase:    util.DutyPhaseFetch,
+			expected: 2 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := util.WithDutyBudget(context.Background(), test.budget)
+			if test.deadline != 0 {
+				var deadlineCancel context.CancelFunc
+				ctx, deadlineCancel = util.WithDutyDeadline(ctx, time.Now().Add(test.deadline))
+				defer deadlineCancel()
+			}
+
+			phaseCtx, cancel := util.PhaseContext(ctx, test.phase)
+			defer cancel()
+			deadline, exists := phaseCtx.Deadline()
+			if test.unbound {
+				require.False(t, exists)
+				return
+			}
+			require.True(t, exists)
+			require.WithinDuration(t, time.Now().Add(test.expected), deadline, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestWaitDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   *util.DutyBudget
+		deadline time.Duration
+		expected time.Duration
+	}{
+		{
+			name:     "Nil",
+			deadline: 12 * time.Second,
+		},
+		{
+			name: "NoWait",
+			budget: &util.DutyBudget{
+				Fetch:  1,
+				Sign:   1,
+				Submit: 1,
+			},
+			deadline: 12 * time.Second,
+		},
+		{
+			name: "Default",
+			budget: &util.DutyBudget{
+				Wait:   1.5,
+				Fetch:  1,
+				Sign:   1,
+				Submit: 1,
+			},
+			deadline: 12 * time.Second,
+			expected: 4 * time.Second,
+		},
+		{
+			name: "NegativeShareIgnored",
+			budget: &util.DutyBudget{
+				Wait:   1,
+				Fetch:  -1,
+				Sign:   1,
+				Submit: 1,
+			},
+			deadline: 12 * time.Second,
+			expected: 4 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.budget.WaitDuration(test.deadline))
+		})
+	}
+}
@@ -11,7 +11,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package util
+// Package builders provides clients for builders and relays.
+package builders
 
 import (
 	"context"
@@ -22,6 +23,7 @@ import (
 	builder "github.com/attestantio/go-builder-client"
 	httpclient "github.com/attestantio/go-builder-client/http"
 	"github.com/attestantio/go-eth2-client/metrics"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -31,8 +33,8 @@ var (
 	buildersMu sync.Mutex
 )
 
-// FetchBuilderClient fetches a builder client, instantiating it if required.
-func FetchBuilderClient(ctx context.Context, address string, monitor metrics.Service, releaseVersion string) (builder.Service, error) {
+// FetchClient fetches a builder client, instantiating it if required.
+func FetchClient(ctx context.Context, address string, monitor metrics.Service, releaseVersion string) (builder.Service, error) {
 	if address == "" {
 		return nil, errors.New("no address supplied")
 	}
@@ -52,8 +54,8 @@ func FetchBuilderClient(ctx context.Context, address string, monitor metrics.Ser
 	if !exists {
 		client, err = httpclient.New(ctx,
 			httpclient.WithMonitor(monitor),
-			httpclient.WithLogLevel(LogLevel("builderclient")),
-			httpclient.WithTimeout(Timeout("builderclient")),
+			httpclient.WithLogLevel(util.LogLevel("builderclient")),
+			httpclient.WithTimeout(util.Timeout("builderclient")),
 			httpclient.WithAddress(address),
 			httpclient.WithExtraHeaders(extraHeaders),
 		)
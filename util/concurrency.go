@@ -37,3 +37,31 @@ func ProcessConcurrency(path string) int64 {
 	}
 	return ProcessConcurrency(path[0:lastPeriod])
 }
+
+// validatorsPerProcess is the number of validators for which a single process is
+// expected to be sufficient.
+const validatorsPerProcess = 250
+
+// maxProcessConcurrencyMultiplier is the maximum multiple of available processors
+// that adaptive process concurrency will return.
+const maxProcessConcurrencyMultiplier = 4
+
+// AdaptiveProcessConcurrency returns a process concurrency suitable for the given
+// number of validators and processors.  It is never lower than the number of
+// processors, and increases with the number of validators up to a multiple of
+// the number of processors.
+func AdaptiveProcessConcurrency(validators int, procs int) int64 {
+	if procs < 1 {
+		procs = 1
+	}
+
+	concurrency := validators / validatorsPerProcess
+	if concurrency < procs {
+		concurrency = procs
+	}
+	if concurrency > procs*maxProcessConcurrencyMultiplier {
+		concurrency = procs * maxProcessConcurrencyMultiplier
+	}
+
+	return int64(concurrency)
+}
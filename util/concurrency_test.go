@@ -88,3 +88,49 @@ func TestProcessConcurrency(t *testing.T) {
 		})
 	}
 }
+
+func TestAdaptiveProcessConcurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators int
+		procs      int
+		expected   int64
+	}{
+		{
+			name:       "NoProcs",
+			validators: 100,
+			procs:      0,
+			expected:   1,
+		},
+		{
+			name:       "NoValidators",
+			validators: 0,
+			procs:      8,
+			expected:   8,
+		},
+		{
+			name:       "FewValidators",
+			validators: 100,
+			procs:      8,
+			expected:   8,
+		},
+		{
+			name:       "ManyValidators",
+			validators: 3000,
+			procs:      8,
+			expected:   12,
+		},
+		{
+			name:       "Capped",
+			validators: 100000,
+			procs:      8,
+			expected:   32,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, util.AdaptiveProcessConcurrency(test.validators, test.procs))
+		})
+	}
+}
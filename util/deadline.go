@@ -0,0 +1,79 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// WithDutyDeadline returns a context that expires at the given deadline, and
+// a function that releases its resources.  The function should be called as
+// soon as the duty completes.
+func WithDutyDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, deadline)
+}
+
+// detachedContext is a context that carries the values, but not the
+// cancellation, of its parent.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+// Detach returns a context that carries the values and deadline of the given
+// context but is not cancelled with it, for work that continues after the
+// caller returns, such as submissions to additional beacon nodes.  The
+// returned function should be called once that work completes.
+func Detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	if deadline, exists := ctx.Deadline(); exists {
+		return context.WithDeadline(detachedContext{parent: ctx}, deadline)
+	}
+
+	return context.WithCancel(detachedContext{parent: ctx})
+}
+
+// BudgetedTimeout returns the given timeout, reduced if required to fit within
+// the deadline of the context.
+func BudgetedTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	deadline, exists := ctx.Deadline()
+	if !exists {
+		return timeout
+	}
+
+	remaining := time.Until(deadline)
+	if remaining < 0 {
+		return 0
+	}
+	if remaining < timeout {
+		return remaining
+	}
+
+	return timeout
+}
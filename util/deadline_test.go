@@ -0,0 +1,124 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDutyDeadline(t *testing.T) {
+	ctx, cancel := util.WithDutyDeadline(context.Background(), time.Now().Add(50*time.Millisecond))
+	defer cancel()
+	deadline, exists := ctx.Deadline()
+	require.True(t, exists)
+	require.WithinDuration(t, time.Now().Add(50*time.Millisecond), deadline, 20*time.Millisecond)
+	require.NoError(t, ctx.Err())
+
+	select {
+	case <-ctx.Done():
+		require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		require.Fail(t, "context did not expire")
+	}
+}
+
+func TestWithDutyDeadlineCancel(t *testing.T) {
+	ctx, cancel := util.WithDutyDeadline(context.Background(), time.Now().Add(time.Minute))
+	cancel()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+type testKey struct{}
+
+func TestDetach(t *testing.T) {
+	parent, parentCancel := util.WithDutyDeadline(context.WithValue(context.Background(), testKey{}, "value"), time.Now().Add(time.Minute))
+	ctx, cancel := util.Detach(parent)
+	defer cancel()
+
+	parentCancel()
+	require.ErrorIs(t, parent.Err(), context.Canceled)
+	require.NoError(t, ctx.Err())
+	require.Equal(t, "value", ctx.Value(testKey{}))
+	parentDeadline, _ := parent.Deadline()
+	deadline, exists := ctx.Deadline()
+	require.True(t, exists)
+	require.Equal(t, parentDeadline, deadline)
+
+	cancel()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestDetachNoDeadline(t *testing.T) {
+	ctx, cancel := util.Detach(context.Background())
+	defer cancel()
+	_, exists := ctx.Deadline()
+	require.False(t, exists)
+	require.NoError(t, ctx.Err())
+}
+
+func TestBudgetedTimeout(t *testing.T) {
+	expiredCtx, expiredCancel := util.WithDutyDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer expiredCancel()
+	laterCtx, laterCancel := util.WithDutyDeadline(context.Background(), time.Now().Add(time.Minute))
+	defer laterCancel()
+	soonerCtx, soonerCancel := util.WithDutyDeadline(context.Background(), time.Now().Add(time.Second))
+	defer soonerCancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		timeout  time.Duration
+		expected time.Duration
+		leeway   time.Duration
+	}{
+		{
+			name:     "NoDeadline",
+			ctx:      context.Background(),
+			timeout:  2 * time.Second,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "DeadlineLater",
+			ctx:      laterCtx,
+			timeout:  2 * time.Second,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "DeadlineSooner",
+			ctx:      soonerCtx,
+			timeout:  2 * time.Second,
+			expected: time.Second,
+			leeway:   100 * time.Millisecond,
+		},
+		{
+			name:     "DeadlinePassed",
+			ctx:      expiredCtx,
+			timeout:  2 * time.Second,
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := util.BudgetedTimeout(test.ctx, test.timeout)
+			require.LessOrEqual(t, res, test.expected)
+			require.GreaterOrEqual(t, res, test.expected-test.leeway)
+		})
+	}
+}
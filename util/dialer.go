@@ -0,0 +1,218 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AddressFamily is an IP address family preferred when connecting to a host.
+type AddressFamily int
+
+const (
+	// AddressFamilyAny has no preference, using the order returned by the resolver.
+	AddressFamilyAny AddressFamily = iota
+	// AddressFamilyIPv4 prefers IPv4 addresses.
+	AddressFamilyIPv4
+	// AddressFamilyIPv6 prefers IPv6 addresses.
+	AddressFamilyIPv6
+)
+
+// defaultFallbackDelay is the default time to wait for a connection with the
+// preferred address family before also trying the other, as per RFC 6555.
+const defaultFallbackDelay = 300 * time.Millisecond
+
+// ParseAddressFamily parses an address family.
+func ParseAddressFamily(input string) (AddressFamily, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "any":
+		return AddressFamilyAny, nil
+	case "ipv4":
+		return AddressFamilyIPv4, nil
+	case "ipv6":
+		return AddressFamilyIPv6, nil
+	default:
+		return AddressFamilyAny, fmt.Errorf("unrecognised address family %q", input)
+	}
+}
+
+var (
+	fallbackDelay = defaultFallbackDelay
+	preferences   = make(map[string]AddressFamily)
+	dialerMu      sync.RWMutex
+)
+
+// ConfigureDialer configures the dialer used by DialContext.  fallbackDelay is the
+// time to wait for a connection with the preferred address family before also
+// trying the other; hostPreferences are the address families preferred for
+// individual hosts.
+func ConfigureDialer(delay time.Duration, hostPreferences map[string]AddressFamily) error {
+	if delay <= 0 {
+		return errors.New("fallback delay must be positive")
+	}
+
+	prefs := make(map[string]AddressFamily, len(hostPreferences))
+	for host, family := range hostPreferences {
+		prefs[normaliseHost(host)] = family
+	}
+
+	dialerMu.Lock()
+	fallbackDelay = delay
+	preferences = prefs
+	dialerMu.Unlock()
+
+	return nil
+}
+
+// DialContext connects to the address on the named network.  Connections to
+// hosts with both IPv4 and IPv6 addresses race the two address families
+// ("happy eyeballs"), starting with the family preferred for the host.
+func DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	dialerMu.RLock()
+	delay := fallbackDelay
+	dialerMu.RUnlock()
+	dialer := &net.Dialer{
+		KeepAlive:     30 * time.Second,
+		FallbackDelay: delay,
+	}
+
+	if network != "tcp" {
+		return dialer.DialContext(ctx, network, address)
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	var primary, fallback string
+	switch HostAddressFamily(host) {
+	case AddressFamilyIPv4:
+		primary, fallback = "tcp4", "tcp6"
+	case AddressFamilyIPv6:
+		primary, fallback = "tcp6", "tcp4"
+	default:
+		// The standard dialer races address families in the order returned by the resolver.
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	return dialPreferred(ctx, dialer, primary, fallback, address, delay)
+}
+
+// HostAddressFamily returns the address family preferred for the given host.
+func HostAddressFamily(host string) AddressFamily {
+	if net.ParseIP(strings.Trim(host, "[]")) != nil {
+		// Literal addresses have a single address family.
+		return AddressFamilyAny
+	}
+
+	dialerMu.RLock()
+	family := preferences[normaliseHost(host)]
+	dialerMu.RUnlock()
+
+	return family
+}
+
+// NewHTTPClient returns an HTTP client with the given timeout that connects using DialContext.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// dialPreferred connects to the address with the primary network, also trying
+// the fallback network if the primary has not connected after the fallback delay
+// or fails.  The first connection to succeed is returned.
+func dialPreferred(ctx context.Context,
+	dialer *net.Dialer,
+	primary string,
+	fallback string,
+	address string,
+	delay time.Duration,
+) (
+	net.Conn,
+	error,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		conn    net.Conn
+		err     error
+		primary bool
+	}
+	results := make(chan result, 2)
+	dial := func(network string, isPrimary bool) {
+		conn, err := dialer.DialContext(ctx, network, address)
+		results <- result{conn: conn, err: err, primary: isPrimary}
+	}
+
+	go dial(primary, true)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var primaryErr error
+	fallbackStarted := false
+	outstanding := 1
+	for outstanding > 0 {
+		select {
+		case <-timer.C:
+			if !fallbackStarted {
+				fallbackStarted = true
+				outstanding++
+				go dial(fallback, false)
+			}
+		case res := <-results:
+			outstanding--
+			if res.err == nil {
+				// Close any connection that completes after this one.
+				go func(remaining int) {
+					for i := 0; i < remaining; i++ {
+						if late := <-results; late.conn != nil {
+							_ = late.conn.Close()
+						}
+					}
+				}(outstanding)
+				return res.conn, nil
+			}
+			if res.primary {
+				primaryErr = res.err
+				if !fallbackStarted {
+					fallbackStarted = true
+					outstanding++
+					go dial(fallback, false)
+				}
+			}
+		}
+	}
+
+	// Both failed; the primary error is the more relevant.
+	return nil, primaryErr
+}
+
+// normaliseHost normalises a host for comparison purposes.
+func normaliseHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
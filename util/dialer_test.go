@@ -0,0 +1,131 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddressFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected util.AddressFamily
+		err      string
+	}{
+		{
+			name:     "Empty",
+			input:    "",
+			expected: util.AddressFamilyAny,
+		},
+		{
+			name:     "Any",
+			input:    "any",
+			expected: util.AddressFamilyAny,
+		},
+		{
+			name:     "IPv4",
+			input:    "IPv4",
+			expected: util.AddressFamilyIPv4,
+		},
+		{
+			name:     "IPv6",
+			input:    " ipv6 ",
+			expected: util.AddressFamilyIPv6,
+		},
+		{
+			name:  "Invalid",
+			input: "ipv5",
+			err:   `unrecognised address family "ipv5"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseAddressFamily(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestHostAddressFamily(t *testing.T) {
+	require.EqualError(t, util.ConfigureDialer(0, nil), "fallback delay must be positive")
+
+	require.NoError(t, util.ConfigureDialer(300*time.Millisecond, map[string]util.AddressFamily{
+		"Relay.Example.com": util.AddressFamilyIPv4,
+		"beacon.local":      util.AddressFamilyIPv6,
+	}))
+	defer func() {
+		require.NoError(t, util.ConfigureDialer(300*time.Millisecond, nil))
+	}()
+
+	require.Equal(t, util.AddressFamilyIPv4, util.HostAddressFamily("relay.example.com"))
+	require.Equal(t, util.AddressFamilyIPv4, util.HostAddressFamily("relay.example.com."))
+	require.Equal(t, util.AddressFamilyIPv6, util.HostAddressFamily("beacon.local"))
+	require.Equal(t, util.AddressFamilyAny, util.HostAddressFamily("other.example.com"))
+	require.Equal(t, util.AddressFamilyAny, util.HostAddressFamily("127.0.0.1"))
+	require.Equal(t, util.AddressFamilyAny, util.HostAddressFamily("[::1]"))
+}
+
+func TestDialContext(t *testing.T) {
+	ctx := context.Background()
+
+	// Listen on IPv4 only.
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	for _, family := range []util.AddressFamily{util.AddressFamilyAny, util.AddressFamilyIPv4, util.AddressFamilyIPv6} {
+		require.NoError(t, util.ConfigureDialer(50*time.Millisecond, map[string]util.AddressFamily{
+			"localhost": family,
+		}))
+		// Preferring IPv6 falls back to IPv4.
+		conn, err := util.DialContext(ctx, "tcp", net.JoinHostPort("localhost", port))
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}
+
+	// Nothing listening.
+	require.NoError(t, listener.Close())
+	require.NoError(t, util.ConfigureDialer(50*time.Millisecond, map[string]util.AddressFamily{
+		"localhost": util.AddressFamilyIPv4,
+	}))
+	defer func() {
+		require.NoError(t, util.ConfigureDialer(300*time.Millisecond, nil))
+	}()
+	_, err = util.DialContext(ctx, "tcp", net.JoinHostPort("localhost", port))
+	require.Error(t, err)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/attestantio/vouch/services/metrics"
+)
+
+// ErrorCategory is the broad category of an error, allowing failures
+// to be attributed to the component that caused them.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryProviderTimeout is a provider that failed to respond in time.
+	ErrorCategoryProviderTimeout ErrorCategory = "provider_timeout"
+	// ErrorCategoryProviderUnavailable is a provider that returned an error.
+	ErrorCategoryProviderUnavailable ErrorCategory = "provider_unavailable"
+	// ErrorCategoryProviderInvalidData is a provider that returned data that failed validation.
+	ErrorCategoryProviderInvalidData ErrorCategory = "provider_invalid_data"
+	// ErrorCategorySignerUnavailable is a signer that failed to provide signatures.
+	ErrorCategorySignerUnavailable ErrorCategory = "signer_unavailable"
+	// ErrorCategorySubmissionRejected is a submission that was not accepted.
+	ErrorCategorySubmissionRejected ErrorCategory = "submission_rejected"
+	// ErrorCategoryInternal is an error within Vouch itself.
+	ErrorCategoryInternal ErrorCategory = "internal"
+)
+
+// categorisedError is an error with a category.
+type categorisedError struct {
+	category ErrorCategory
+	err      error
+}
+
+// Error returns the error message.
+func (e *categorisedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *categorisedError) Unwrap() error {
+	return e.err
+}
+
+// CategoriseError attaches a category to an error.
+// The error message is unchanged.
+func CategoriseError(category ErrorCategory, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &categorisedError{
+		category: category,
+		err:      err,
+	}
+}
+
+// ProviderError categorises an error returned by a provider, separating
+// timeouts from other failures.
+func ProviderError(err error) error {
+	if isTimeout(err) {
+		return CategoriseError(ErrorCategoryProviderTimeout, err)
+	}
+
+	return CategoriseError(ErrorCategoryProviderUnavailable, err)
+}
+
+// ErrorCategoryOf returns the category of an error.  The outermost category
+// is used if more than one is present.  Uncategorised timeouts are treated as
+// provider timeouts, and all other uncategorised errors as internal.
+func ErrorCategoryOf(err error) ErrorCategory {
+	var categorised *categorisedError
+	if errors.As(err, &categorised) {
+		return categorised.category
+	}
+	if isTimeout(err) {
+		return ErrorCategoryProviderTimeout
+	}
+
+	return ErrorCategoryInternal
+}
+
+// MonitorError reports the category of an error to the monitor, if the
+// monitor supports it.
+func MonitorError(monitor interface{}, service string, err error) {
+	if err == nil {
+		return
+	}
+	errorMonitor, isErrorMonitor := monitor.(metrics.ErrorMonitor)
+	if !isErrorMonitor {
+		return
+	}
+	errorMonitor.ServiceError(service, string(ErrorCategoryOf(err)))
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/vouch/util"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type errorMonitor struct {
+	service  string
+	category string
+}
+
+func (*errorMonitor) Presenter() string {
+	return "test"
+}
+
+func (m *errorMonitor) ServiceError(service string, category string) {
+	m.service = service
+	m.category = category
+}
+
+func TestCategoriseError(t *testing.T) {
+	require.NoError(t, util.CategoriseError(util.ErrorCategoryInternal, nil))
+
+	err := util.CategoriseError(util.ErrorCategorySignerUnavailable, errors.New("signer down"))
+	require.EqualError(t, err, "signer down")
+	require.Equal(t, util.ErrorCategorySignerUnavailable, util.ErrorCategoryOf(err))
+}
+
+func TestErrorCategoryOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		category util.ErrorCategory
+	}{
+		{
+			name:     "Uncategorised",
+			err:      errors.New("bad"),
+			category: util.ErrorCategoryInternal,
+		},
+		{
+			name:     "UncategorisedTimeout",
+			err:      pkgerrors.Wrap(context.DeadlineExceeded, "failed"),
+			category: util.ErrorCategoryProviderTimeout,
+		},
+		{
+			name:     "Wrapped",
+			err:      pkgerrors.Wrap(util.CategoriseError(util.ErrorCategorySubmissionRejected, errors.New("rejected")), "failed"),
+			category: util.ErrorCategorySubmissionRejected,
+		},
+		{
+			name:     "Outermost",
+			err:      util.CategoriseError(util.ErrorCategoryProviderInvalidData, util.CategoriseError(util.ErrorCategoryInternal, errors.New("bad"))),
+			category: util.ErrorCategoryProviderInvalidData,
+		},
+		{
+			name:     "ProviderTimeout",
+			err:      util.ProviderError(pkgerrors.Wrap(context.DeadlineExceeded, "failed")),
+			category: util.ErrorCategoryProviderTimeout,
+		},
+		{
+			name:     "ProviderUnavailable",
+			err:      util.ProviderError(errors.New("connection refused")),
+			category: util.ErrorCategoryProviderUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.category, util.ErrorCategoryOf(test.err))
+		})
+	}
+}
+
+func TestMonitorError(t *testing.T) {
+	monitor := &errorMonitor{}
+
+	util.MonitorError(monitor, "attester", nil)
+	require.Empty(t, monitor.service)
+
+	util.MonitorError(monitor, "attester", util.CategoriseError(util.ErrorCategorySignerUnavailable, errors.New("signer down")))
+	require.Equal(t, "attester", monitor.service)
+	require.Equal(t, "signer_unavailable", monitor.category)
+
+	// Monitors that do not support errors are ignored.
+	util.MonitorError(struct{}{}, "attester", errors.New("bad"))
+}